@@ -5,6 +5,9 @@ import (
 	"crypto/rand"
 	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,15 +17,25 @@ import (
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/garethgeorge/backrest/gen/go/v1/v1connect"
 	"github.com/garethgeorge/backrest/internal/api"
 	"github.com/garethgeorge/backrest/internal/auth"
+	"github.com/garethgeorge/backrest/internal/buildinfo"
 	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/metrics"
 	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplogexport"
 	"github.com/garethgeorge/backrest/internal/orchestrator"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
+	"github.com/garethgeorge/backrest/internal/redact"
+	"github.com/garethgeorge/backrest/internal/replica"
+	"github.com/garethgeorge/backrest/internal/resthost"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
 	"github.com/garethgeorge/backrest/internal/rotatinglog"
+	"github.com/garethgeorge/backrest/pkg/restic"
 	"github.com/garethgeorge/backrest/webui"
 	"github.com/mattn/go-colorable"
 	"go.etcd.io/bbolt"
@@ -33,10 +46,46 @@ import (
 )
 
 var InstallDepsOnly = flag.Bool("install-deps-only", false, "install dependencies and exit")
+var MigrateOplogFrom = flag.String("migrate-oplog-from", "", "copy the operation history stored by the given oplog backend, bbolt or sqlite, into the configured backend and exit. The configured backend's database must not exist yet.")
+var ExportOplog = flag.String("export-oplog", "", "write the operation history to the given file, or stdout if it's \"-\", as newline delimited protojson and exit.")
+var ExportOplogSanitize = flag.Bool("export-oplog-sanitize", false, "redact the config's secrets and drop the output of commands from the operations written by -export-oplog e.g. to share them for debugging.")
+var ImportOplog = flag.String("import-oplog", "", "add the operations in a file written by -export-oplog, or stdin if it's \"-\", to the operation history and exit. Operations already in the history are skipped.")
+var RepairOplog = flag.Bool("repair-oplog", false, "check the integrity of the operation history and, if it's damaged, rebuild it from the records that can be read and exit. Torn records are discarded and the indexes are rebuilt, the damaged database is kept next to the rebuilt one. Backrest must not be running.")
+var BootstrapFromReplica = flag.String("bootstrap-from-replica", "", "path to a config replica of a lost instance, its config and operation history are restored before starting. The passphrase is read from the BACKREST_REPLICA_PASSPHRASE environment variable.")
 
 func main() {
 	flag.Parse()
 
+	if *MigrateOplogFrom != "" {
+		if err := migrateOplog(*MigrateOplogFrom, config.OplogBackend()); err != nil {
+			zap.S().Fatalf("error migrating oplog: %v", err)
+		}
+		zap.S().Infof("migrated the oplog from %v to %v, exiting", *MigrateOplogFrom, config.OplogBackend())
+		return
+	}
+	if *ExportOplog != "" {
+		count, err := exportOplog(*ExportOplog, *ExportOplogSanitize)
+		if err != nil {
+			zap.S().Fatalf("error exporting oplog: %v", err)
+		}
+		zap.S().Infof("exported %d operations to %q, exiting", count, *ExportOplog)
+		return
+	}
+	if *RepairOplog {
+		if err := repairOplog(); err != nil {
+			zap.S().Fatalf("error repairing oplog: %v", err)
+		}
+		return
+	}
+	if *ImportOplog != "" {
+		stats, err := importOplog(*ImportOplog)
+		if err != nil {
+			zap.S().Fatalf("error importing oplog: %v", err)
+		}
+		zap.S().Infof("imported %d operations from %q, skipped %d already in the oplog and %d unfinished, exiting", stats.Imported, *ImportOplog, stats.Duplicates, stats.Unfinished)
+		return
+	}
+
 	resticPath, err := resticinstaller.FindOrInstallResticBinary()
 	if err != nil {
 		zap.S().Fatalf("error finding or installing restic: %v", err)
@@ -64,16 +113,30 @@ func main() {
 	var wg sync.WaitGroup
 
 	// Create / load the operation log
-	oplogFile := path.Join(config.DataDir(), "oplog.boltdb")
-	oplog, err := oplog.NewOpLog(oplogFile)
+	oplogFile, err := oplog.StorePath(config.OplogBackend(), config.DataDir())
 	if err != nil {
-		if !errors.Is(err, bbolt.ErrTimeout) {
+		zap.S().Fatalf("error creating oplog: %v", err)
+	}
+	oplogStore, err := oplog.OpenStore(config.OplogBackend(), config.DataDir())
+	if err != nil {
+		if errors.Is(err, bbolt.ErrTimeout) {
 			zap.S().Fatalf("timeout while waiting to open database, is the database open elsewhere?")
 		}
 		zap.S().Warnf("operation log may be corrupted, if errors recur delete the file %q and restart. Your backups stored in your repos are safe.", oplogFile)
 		zap.S().Fatalf("error creating oplog : %v", err)
 	}
+	oplog := oplog.NewOpLogWithStore(oplogStore)
 	defer oplog.Close()
+	oplog.SetOrigin(operationOrigin(ctx, resticPath))
+
+	if *BootstrapFromReplica != "" {
+		if err := bootstrapFromReplica(*BootstrapFromReplica, configStore, oplog); err != nil {
+			zap.S().Fatalf("error bootstrapping from replica: %v", err)
+		}
+		if cfg, err = configStore.Get(); err != nil {
+			zap.S().Fatalf("error loading config: %v", err)
+		}
+	}
 
 	// Create rotating log storage
 	logStore := rotatinglog.NewRotatingLog(path.Join(config.DataDir(), "rotatinglogs"), 14) // 14 days of logs
@@ -82,18 +145,40 @@ func main() {
 	}
 
 	// Create orchestrator and start task loop.
-	orchestrator, err := orchestrator.NewOrchestrator(resticPath, cfg, oplog, logStore)
+	// a read replica mirrors the oplog of its primary and never runs operations, its orchestrator has no repos or plans
+	// to schedule and no oplog to write to.
+	replicaOf := config.ReplicaOf()
+	orchestratorConfig, orchestratorLog := cfg, oplog
+	if replicaOf != "" {
+		orchestratorConfig, orchestratorLog = config.NewDefaultConfig(), nil
+	}
+	orchestrator, err := orchestrator.NewOrchestrator(resticPath, orchestratorConfig, orchestratorLog, logStore)
 	if err != nil {
 		zap.S().Fatalf("error creating orchestrator: %v", err)
 	}
 
-	wg.Add(1)
-	go func() {
-		orchestrator.Run(ctx)
-		wg.Done()
-	}()
+	var handlerOpts []connect.HandlerOption
+	if replicaOf != "" {
+		follower, err := replica.NewFollower(replicaOf, configStore, oplog)
+		if err != nil {
+			zap.S().Fatalf("error creating read replica: %v", err)
+		}
+		handlerOpts = append(handlerOpts, connect.WithInterceptors(api.NewReadReplicaInterceptor()))
+
+		zap.S().Info("running as a read replica, operations are mirrored from the primary")
+		wg.Add(1)
+		go func() {
+			follower.Run(ctx)
+			wg.Done()
+		}()
+	} else {
+		wg.Add(1)
+		go func() {
+			orchestrator.Run(ctx)
+			wg.Done()
+		}()
+	}
 
-	// Create and serve the HTTP gateway
 	apiBackrestHandler := api.NewBackrestHandler(
 		configStore,
 		orchestrator,
@@ -105,23 +190,44 @@ func main() {
 
 	mux := http.NewServeMux()
 	mux.Handle(v1connect.NewAuthenticationHandler(apiAuthenticationHandler))
-	backrestHandlerPath, backrestHandler := v1connect.NewBackrestHandler(apiBackrestHandler)
+	backrestHandlerPath, backrestHandler := v1connect.NewBackrestHandler(apiBackrestHandler, handlerOpts...)
 	mux.Handle(backrestHandlerPath, auth.RequireAuthentication(backrestHandler, authenticator))
-	mux.Handle("/", webui.Handler())
-	mux.Handle("/download/", http.StripPrefix("/download", api.NewDownloadHandler(oplog)))
+	if replicaOf == "" {
+		mux.Handle("/webhook/snapshots/", http.StripPrefix("/webhook/snapshots", auth.RequireAuthentication(api.NewSnapshotWebhookHandler(apiBackrestHandler), authenticator)))
+	}
+	// hosted repos authenticate their clients with the credentials in the repo hosting settings.
+	mux.Handle("/restic/", http.StripPrefix("/restic", resthost.NewServer(configStore)))
+	mux.Handle("/metrics", auth.RequireAuthentication(metrics.Handler(), authenticator))
+	downloadHandler := http.StripPrefix("/download", api.NewDownloadHandler(oplog, orchestrator))
+	// snapshot archives are also fetched by scripts restoring onto new machines, they are served by headless instances too.
+	// Their URLs expire like the other download URLs.
+	mux.Handle("/download/archive/", downloadHandler)
+	if config.Headless() || !webui.Embedded {
+		// other download links are only handed out to be opened by the web UI's users.
+		zap.S().Info("running headless, only the API is served")
+	} else {
+		mux.Handle("/", webui.Handler())
+		mux.Handle("/download/", downloadHandler)
+		// share links are opened by guests without access to the UI, they're protected by their passphrase.
+		mux.Handle("/share/", http.StripPrefix("/share", api.NewShareHandler(oplog, orchestrator)))
+	}
 
 	// Serve the HTTP gateway
 	server := &http.Server{
 		Addr:    config.BindAddress(),
 		Handler: h2c.NewHandler(mux, &http2.Server{}), // h2c is HTTP/2 without TLS for grpc-connect support.
 	}
+	listener, err := listen(server.Addr)
+	if err != nil {
+		zap.S().Fatalf("error listening on %v: %v", server.Addr, err)
+	}
 
 	zap.S().Infof("starting web server %v", server.Addr)
 	go func() {
 		<-ctx.Done()
 		server.Shutdown(context.Background())
 	}()
-	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
 		zap.L().Error("error starting server", zap.Error(err))
 	}
 	zap.L().Info("HTTP gateway shutdown")
@@ -130,26 +236,60 @@ func main() {
 }
 
 func init() {
-	zap.ReplaceGlobals(zap.Must(zap.NewProduction()))
+	zap.ReplaceGlobals(zap.Must(zap.NewProduction(zap.WrapCore(withDaemonLog))))
 	if !strings.HasPrefix(os.Getenv("ENV"), "prod") {
 		c := zap.NewDevelopmentEncoderConfig()
 		c.EncodeLevel = zapcore.CapitalColorLevelEncoder
 		c.EncodeTime = zapcore.ISO8601TimeEncoder
-		l := zap.New(zapcore.NewCore(
+		l := zap.New(withDaemonLog(zapcore.NewCore(
 			zapcore.NewConsoleEncoder(c),
 			zapcore.AddSync(colorable.NewColorableStdout()),
 			zapcore.DebugLevel,
-		))
+		)))
 		zap.ReplaceGlobals(l)
 	}
 }
 
+// withDaemonLog tees info and higher level logs of core into logging.DaemonLog and redacts both.
+func withDaemonLog(core zapcore.Core) zapcore.Core {
+	c := zap.NewProductionEncoderConfig()
+	c.EncodeTime = zapcore.ISO8601TimeEncoder
+	return redact.WrapCore(zapcore.NewTee(
+		core,
+		zapcore.NewCore(zapcore.NewConsoleEncoder(c), zapcore.AddSync(logging.DaemonLog), zapcore.InfoLevel),
+	))
+}
+
 func createConfigProvider() config.ConfigStore {
 	return &config.CachingValidatingStore{
 		ConfigStore: &config.JsonFileStore{Path: config.ConfigFilePath()},
 	}
 }
 
+// listen listens on the TCP address, or on the unix socket at the path of a "unix:" address. A socket left behind by a
+// previous run is replaced.
+func listen(addr string) (net.Listener, error) {
+	socketPath, ok := strings.CutPrefix(addr, "unix:")
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+	if fi, err := os.Lstat(socketPath); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(socketPath); err != nil {
+			return nil, fmt.Errorf("remove existing socket: %w", err)
+		}
+	}
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	// only the user backrest runs as and its group may connect.
+	if err := os.Chmod(socketPath, 0660); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("set socket permissions: %w", err)
+	}
+	return l, nil
+}
+
 func onterm(s os.Signal, callback func()) {
 	sigchan := make(chan os.Signal, 1)
 	signal.Notify(sigchan, s, syscall.SIGTERM)
@@ -159,6 +299,158 @@ func onterm(s os.Signal, callback func()) {
 	}
 }
 
+// operationOrigin describes this machine and the software versions in use, it is recorded on every operation.
+func operationOrigin(ctx context.Context, resticPath string) oplog.Origin {
+	hostname, err := os.Hostname()
+	if err != nil {
+		zap.S().Warnf("error getting hostname: %v", err)
+	}
+	resticVersion, err := restic.Version(ctx, resticPath)
+	if err != nil {
+		zap.S().Warnf("error getting restic version: %v", err)
+	}
+	return oplog.Origin{
+		Hostname:        hostname,
+		BackrestVersion: buildinfo.Version,
+		ResticVersion:   resticVersion,
+	}
+}
+
+// migrateOplog copies the oplog stored by the from backend into a new database of the to backend.
+func migrateOplog(from, to string) error {
+	if from == to {
+		return fmt.Errorf("the oplog is already stored by %v", to)
+	}
+	dstPath, err := oplog.StorePath(to, config.DataDir())
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dstPath); err == nil {
+		return fmt.Errorf("%q already exists, move it away to migrate the oplog again", dstPath)
+	}
+	srcPath, err := oplog.StorePath(from, config.DataDir())
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(srcPath); err != nil {
+		return fmt.Errorf("no oplog to migrate: %w", err)
+	}
+
+	src, err := oplog.OpenStore(from, config.DataDir())
+	if err != nil {
+		return fmt.Errorf("open %v oplog: %w", from, err)
+	}
+	defer src.Close()
+	dst, err := oplog.OpenStore(to, config.DataDir())
+	if err != nil {
+		return fmt.Errorf("open %v oplog: %w", to, err)
+	}
+	if err := oplog.CopyStore(dst, src); err != nil {
+		dst.Close()
+		os.Remove(dstPath)
+		return err
+	}
+	return dst.Close()
+}
+
+// exportOplog writes the operations in the configured oplog to the file at path, "-" for stdout.
+func repairOplog() error {
+	if config.OplogBackend() != oplog.BackendBolt {
+		return fmt.Errorf("only the %v oplog can be repaired, SQLite recovers from interrupted writes itself", oplog.BackendBolt)
+	}
+	dbPath, err := oplog.StorePath(config.OplogBackend(), config.DataDir())
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf("no oplog to repair: %w", err)
+	}
+	report, err := oplog.CheckBoltStore(dbPath)
+	if err != nil {
+		return err
+	}
+	if report.OK() {
+		zap.S().Infof("the oplog is intact, %v, exiting", report)
+		return nil
+	}
+	zap.S().Warnf("the oplog is damaged, %v, repairing it", report)
+	if report, err = oplog.RepairBoltStore(dbPath); err != nil {
+		return err
+	}
+	zap.S().Infof("repaired the oplog: %v, exiting", report)
+	return nil
+}
+
+func exportOplog(path string, sanitize bool) (int, error) {
+	store, err := oplog.OpenStore(config.OplogBackend(), config.DataDir())
+	if err != nil {
+		return 0, fmt.Errorf("open oplog: %w", err)
+	}
+	log := oplog.NewOpLogWithStore(store)
+	defer log.Close()
+
+	var redactor *redact.Redactor
+	if sanitize {
+		cfg, err := createConfigProvider().Get()
+		if err != nil {
+			return 0, fmt.Errorf("load config: %w", err)
+		}
+		redactor = redact.New(redact.SecretsFromConfig(cfg))
+	}
+
+	if path == "-" {
+		return oplogexport.WriteOperations(os.Stdout, log, nil, redactor)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return 0, err
+	}
+	count, err := oplogexport.WriteOperations(f, log, nil, redactor)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return count, err
+}
+
+// importOplog adds the operations in the file at path, "-" for stdin, to the configured oplog.
+func importOplog(path string) (oplogexport.ImportStats, error) {
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return oplogexport.ImportStats{}, err
+		}
+		defer f.Close()
+		r = f
+	}
+	store, err := oplog.OpenStore(config.OplogBackend(), config.DataDir())
+	if err != nil {
+		return oplogexport.ImportStats{}, fmt.Errorf("open oplog: %w", err)
+	}
+	log := oplog.NewOpLogWithStore(store)
+	defer log.Close()
+	return oplogexport.Import(log, r)
+}
+
+func bootstrapFromReplica(path string, configStore config.ConfigStore, log *oplog.OpLog) error {
+	sealed, err := replica.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	r, err := replica.Open(sealed, os.Getenv("BACKREST_REPLICA_PASSPHRASE"))
+	if err != nil {
+		return err
+	}
+	if err := replica.Bootstrap(r, configStore, log); err != nil {
+		return err
+	}
+	zap.S().Infof("bootstrapped from replica of instance %q created at %v", sealed.Instance, time.UnixMilli(sealed.UnixTimeMs))
+	return nil
+}
+
 func getSecret() []byte {
 	secretFile := path.Join(config.DataDir(), "jwt-secret")
 	data, err := os.ReadFile(secretFile)
@@ -0,0 +1,524 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: v1/alerts.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Alert_Kind int32
+
+const (
+	Alert_KIND_UNKNOWN            Alert_Kind = 0
+	Alert_KIND_BACKUP_FAILED      Alert_Kind = 1 // the plan's most recent backup failed.
+	Alert_KIND_MISSED_SCHEDULE    Alert_Kind = 2 // the plan has not run a backup since it was last expected to.
+	Alert_KIND_STAGING_QUOTA      Alert_Kind = 3 // the restores staged for the namespace are at or above its staging quota.
+	Alert_KIND_OPLOG_INCONSISTENT Alert_Kind = 4 // the most recent oplog verification of the repo found inconsistencies.
+)
+
+// Enum value maps for Alert_Kind.
+var (
+	Alert_Kind_name = map[int32]string{
+		0: "KIND_UNKNOWN",
+		1: "KIND_BACKUP_FAILED",
+		2: "KIND_MISSED_SCHEDULE",
+		3: "KIND_STAGING_QUOTA",
+		4: "KIND_OPLOG_INCONSISTENT",
+	}
+	Alert_Kind_value = map[string]int32{
+		"KIND_UNKNOWN":            0,
+		"KIND_BACKUP_FAILED":      1,
+		"KIND_MISSED_SCHEDULE":    2,
+		"KIND_STAGING_QUOTA":      3,
+		"KIND_OPLOG_INCONSISTENT": 4,
+	}
+)
+
+func (x Alert_Kind) Enum() *Alert_Kind {
+	p := new(Alert_Kind)
+	*p = x
+	return p
+}
+
+func (x Alert_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Alert_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_alerts_proto_enumTypes[0].Descriptor()
+}
+
+func (Alert_Kind) Type() protoreflect.EnumType {
+	return &file_v1_alerts_proto_enumTypes[0]
+}
+
+func (x Alert_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Alert_Kind.Descriptor instead.
+func (Alert_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_v1_alerts_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// Alert is a problem found by one of backrest's health rules. An alert is listed until the problem is resolved,
+// acknowledging or snoozing it only suppresses its repeat notifications.
+type Alert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // identifies the alert e.g. "backup_failed/<plan>", stable for as long as the problem lasts.
+	Kind        Alert_Kind  `protobuf:"varint,2,opt,name=kind,proto3,enum=v1.Alert_Kind" json:"kind,omitempty"`
+	PlanId      string      `protobuf:"bytes,3,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`                 // optional, the plan the alert is about.
+	RepoId      string      `protobuf:"bytes,4,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`                 // optional, the repo the alert is about.
+	Namespace   string      `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`                         // optional, the namespace the alert is about.
+	Message     string      `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`                             // human readable description of the problem.
+	SinceMs     int64       `protobuf:"varint,7,opt,name=since_ms,json=sinceMs,proto3" json:"since_ms,omitempty"`             // optional, unix time in milliseconds the problem started at.
+	OperationId int64       `protobuf:"varint,8,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"` // optional, the operation that most recently showed the problem.
+	State       *AlertState `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`                                 // set if the alert was acknowledged or snoozed.
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_alerts_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_alerts_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_v1_alerts_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Alert) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Alert) GetKind() Alert_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Alert_KIND_UNKNOWN
+}
+
+func (x *Alert) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *Alert) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *Alert) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Alert) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Alert) GetSinceMs() int64 {
+	if x != nil {
+		return x.SinceMs
+	}
+	return 0
+}
+
+func (x *Alert) GetOperationId() int64 {
+	if x != nil {
+		return x.OperationId
+	}
+	return 0
+}
+
+func (x *Alert) GetState() *AlertState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type AlertList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Alerts []*Alert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+}
+
+func (x *AlertList) Reset() {
+	*x = AlertList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_alerts_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlertList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertList) ProtoMessage() {}
+
+func (x *AlertList) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_alerts_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertList.ProtoReflect.Descriptor instead.
+func (*AlertList) Descriptor() ([]byte, []int) {
+	return file_v1_alerts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AlertList) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+// AlertState is the user's response to an alert, it's discarded once the alert is resolved.
+type AlertState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SinceMs        int64  `protobuf:"varint,1,opt,name=since_ms,json=sinceMs,proto3" json:"since_ms,omitempty"`                        // since_ms of the alert the state applies to, a recurrence of the problem is a new alert.
+	AcknowledgedMs int64  `protobuf:"varint,2,opt,name=acknowledged_ms,json=acknowledgedMs,proto3" json:"acknowledged_ms,omitempty"`   // unix time in milliseconds the alert was acknowledged, notifications are suppressed until it's resolved.
+	AcknowledgedBy string `protobuf:"bytes,3,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`    // name of the user that acknowledged the alert, empty if authentication is disabled.
+	SnoozedUntilMs int64  `protobuf:"varint,4,opt,name=snoozed_until_ms,json=snoozedUntilMs,proto3" json:"snoozed_until_ms,omitempty"` // notifications are suppressed until this unix time in milliseconds.
+}
+
+func (x *AlertState) Reset() {
+	*x = AlertState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_alerts_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlertState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertState) ProtoMessage() {}
+
+func (x *AlertState) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_alerts_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertState.ProtoReflect.Descriptor instead.
+func (*AlertState) Descriptor() ([]byte, []int) {
+	return file_v1_alerts_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AlertState) GetSinceMs() int64 {
+	if x != nil {
+		return x.SinceMs
+	}
+	return 0
+}
+
+func (x *AlertState) GetAcknowledgedMs() int64 {
+	if x != nil {
+		return x.AcknowledgedMs
+	}
+	return 0
+}
+
+func (x *AlertState) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *AlertState) GetSnoozedUntilMs() int64 {
+	if x != nil {
+		return x.SnoozedUntilMs
+	}
+	return 0
+}
+
+type SnoozeAlertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DurationMinutes int64  `protobuf:"varint,2,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"` // required, how long to suppress the alert's notifications for.
+}
+
+func (x *SnoozeAlertRequest) Reset() {
+	*x = SnoozeAlertRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_alerts_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnoozeAlertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeAlertRequest) ProtoMessage() {}
+
+func (x *SnoozeAlertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_alerts_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeAlertRequest.ProtoReflect.Descriptor instead.
+func (*SnoozeAlertRequest) Descriptor() ([]byte, []int) {
+	return file_v1_alerts_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SnoozeAlertRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SnoozeAlertRequest) GetDurationMinutes() int64 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+var File_v1_alerts_proto protoreflect.FileDescriptor
+
+var file_v1_alerts_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x76, 0x31, 0x2f, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0x8a, 0x03, 0x0a, 0x05, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x22, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x72, 0x65, 0x70, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x70, 0x6f, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6c, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x22, 0x7f, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4b,
+	0x49, 0x4e, 0x44, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x55, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x4d, 0x49, 0x53, 0x53,
+	0x45, 0x44, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x16, 0x0a,
+	0x12, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x49, 0x4e, 0x47, 0x5f, 0x51, 0x55,
+	0x4f, 0x54, 0x41, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x4f, 0x50,
+	0x4c, 0x4f, 0x47, 0x5f, 0x49, 0x4e, 0x43, 0x4f, 0x4e, 0x53, 0x49, 0x53, 0x54, 0x45, 0x4e, 0x54,
+	0x10, 0x04, 0x22, 0x2e, 0x0a, 0x09, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x06, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x09, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x52, 0x06, 0x61, 0x6c, 0x65, 0x72,
+	0x74, 0x73, 0x22, 0xa3, 0x01, 0x0a, 0x0a, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x64, 0x4d, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x42, 0x79, 0x12, 0x28,
+	0x0a, 0x10, 0x73, 0x6e, 0x6f, 0x6f, 0x7a, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x5f,
+	0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x73, 0x6e, 0x6f, 0x6f, 0x7a, 0x65,
+	0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x4d, 0x73, 0x22, 0x4f, 0x0a, 0x12, 0x53, 0x6e, 0x6f, 0x6f,
+	0x7a, 0x65, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x29,
+	0x0a, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x72, 0x65, 0x74, 0x68, 0x67, 0x65,
+	0x6f, 0x72, 0x67, 0x65, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x72, 0x65, 0x73, 0x74, 0x2f, 0x67, 0x65,
+	0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v1_alerts_proto_rawDescOnce sync.Once
+	file_v1_alerts_proto_rawDescData = file_v1_alerts_proto_rawDesc
+)
+
+func file_v1_alerts_proto_rawDescGZIP() []byte {
+	file_v1_alerts_proto_rawDescOnce.Do(func() {
+		file_v1_alerts_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_alerts_proto_rawDescData)
+	})
+	return file_v1_alerts_proto_rawDescData
+}
+
+var file_v1_alerts_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v1_alerts_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_v1_alerts_proto_goTypes = []interface{}{
+	(Alert_Kind)(0),            // 0: v1.Alert.Kind
+	(*Alert)(nil),              // 1: v1.Alert
+	(*AlertList)(nil),          // 2: v1.AlertList
+	(*AlertState)(nil),         // 3: v1.AlertState
+	(*SnoozeAlertRequest)(nil), // 4: v1.SnoozeAlertRequest
+}
+var file_v1_alerts_proto_depIdxs = []int32{
+	0, // 0: v1.Alert.kind:type_name -> v1.Alert.Kind
+	3, // 1: v1.Alert.state:type_name -> v1.AlertState
+	1, // 2: v1.AlertList.alerts:type_name -> v1.Alert
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_v1_alerts_proto_init() }
+func file_v1_alerts_proto_init() {
+	if File_v1_alerts_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v1_alerts_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_alerts_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlertList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_alerts_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlertState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_alerts_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnoozeAlertRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v1_alerts_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_v1_alerts_proto_goTypes,
+		DependencyIndexes: file_v1_alerts_proto_depIdxs,
+		EnumInfos:         file_v1_alerts_proto_enumTypes,
+		MessageInfos:      file_v1_alerts_proto_msgTypes,
+	}.Build()
+	File_v1_alerts_proto = out.File
+	file_v1_alerts_proto_rawDesc = nil
+	file_v1_alerts_proto_goTypes = nil
+	file_v1_alerts_proto_depIdxs = nil
+}
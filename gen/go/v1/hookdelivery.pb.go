@@ -0,0 +1,543 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: v1/hookdelivery.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HookDelivery is a notification sent by a hook that couldn't be delivered e.g. because the receiver was down. Deliveries
+// are persisted and retried with backoff until they succeed or run out of attempts, after which they are kept as dead letters.
+type HookDelivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hook          string `protobuf:"bytes,2,opt,name=hook,proto3" json:"hook,omitempty"` // the name of the hook that sent the notification e.g. "plan/<plan id>/hook/0".
+	RepoId        string `protobuf:"bytes,3,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`
+	PlanId        string `protobuf:"bytes,4,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	OperationId   int64  `protobuf:"varint,5,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"` // the hook's operation, updated when the delivery succeeds or is given up on.
+	Destination   string `protobuf:"bytes,6,opt,name=destination,proto3" json:"destination,omitempty"`                     // the scheme and host the notification is sent to, safe to display.
+	CreatedMs     int64  `protobuf:"varint,7,opt,name=created_ms,json=createdMs,proto3" json:"created_ms,omitempty"`
+	Attempts      int32  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttemptMs int64  `protobuf:"varint,9,opt,name=next_attempt_ms,json=nextAttemptMs,proto3" json:"next_attempt_ms,omitempty"` // unset for dead letters.
+	LastError     string `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	DeadLetter    bool   `protobuf:"varint,11,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"` // true once the delivery ran out of attempts.
+	// the request to retry, omitted by the API as it may hold secrets e.g. tokens.
+	//
+	// Types that are assignable to Request:
+	//
+	//	*HookDelivery_Http
+	//	*HookDelivery_Shoutrrr
+	Request isHookDelivery_Request `protobuf_oneof:"request"`
+}
+
+func (x *HookDelivery) Reset() {
+	*x = HookDelivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_hookdelivery_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HookDelivery) ProtoMessage() {}
+
+func (x *HookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_hookdelivery_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HookDelivery.ProtoReflect.Descriptor instead.
+func (*HookDelivery) Descriptor() ([]byte, []int) {
+	return file_v1_hookdelivery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HookDelivery) GetHook() string {
+	if x != nil {
+		return x.Hook
+	}
+	return ""
+}
+
+func (x *HookDelivery) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *HookDelivery) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *HookDelivery) GetOperationId() int64 {
+	if x != nil {
+		return x.OperationId
+	}
+	return 0
+}
+
+func (x *HookDelivery) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *HookDelivery) GetCreatedMs() int64 {
+	if x != nil {
+		return x.CreatedMs
+	}
+	return 0
+}
+
+func (x *HookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *HookDelivery) GetNextAttemptMs() int64 {
+	if x != nil {
+		return x.NextAttemptMs
+	}
+	return 0
+}
+
+func (x *HookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *HookDelivery) GetDeadLetter() bool {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return false
+}
+
+func (m *HookDelivery) GetRequest() isHookDelivery_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *HookDelivery) GetHttp() *HookDelivery_HttpRequest {
+	if x, ok := x.GetRequest().(*HookDelivery_Http); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *HookDelivery) GetShoutrrr() *HookDelivery_ShoutrrrMessage {
+	if x, ok := x.GetRequest().(*HookDelivery_Shoutrrr); ok {
+		return x.Shoutrrr
+	}
+	return nil
+}
+
+type isHookDelivery_Request interface {
+	isHookDelivery_Request()
+}
+
+type HookDelivery_Http struct {
+	Http *HookDelivery_HttpRequest `protobuf:"bytes,100,opt,name=http,proto3,oneof"`
+}
+
+type HookDelivery_Shoutrrr struct {
+	Shoutrrr *HookDelivery_ShoutrrrMessage `protobuf:"bytes,101,opt,name=shoutrrr,proto3,oneof"`
+}
+
+func (*HookDelivery_Http) isHookDelivery_Request() {}
+
+func (*HookDelivery_Shoutrrr) isHookDelivery_Request() {}
+
+type HookDeliveryList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pending     []*HookDelivery `protobuf:"bytes,1,rep,name=pending,proto3" json:"pending,omitempty"`                            // deliveries waiting for their next attempt.
+	DeadLetters []*HookDelivery `protobuf:"bytes,2,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"` // deliveries that ran out of attempts, most recent first.
+}
+
+func (x *HookDeliveryList) Reset() {
+	*x = HookDeliveryList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_hookdelivery_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HookDeliveryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HookDeliveryList) ProtoMessage() {}
+
+func (x *HookDeliveryList) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_hookdelivery_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HookDeliveryList.ProtoReflect.Descriptor instead.
+func (*HookDeliveryList) Descriptor() ([]byte, []int) {
+	return file_v1_hookdelivery_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HookDeliveryList) GetPending() []*HookDelivery {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *HookDeliveryList) GetDeadLetters() []*HookDelivery {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+type HookDelivery_HttpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method      string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Url         string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Body        []byte `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *HookDelivery_HttpRequest) Reset() {
+	*x = HookDelivery_HttpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_hookdelivery_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HookDelivery_HttpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HookDelivery_HttpRequest) ProtoMessage() {}
+
+func (x *HookDelivery_HttpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_hookdelivery_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HookDelivery_HttpRequest.ProtoReflect.Descriptor instead.
+func (*HookDelivery_HttpRequest) Descriptor() ([]byte, []int) {
+	return file_v1_hookdelivery_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *HookDelivery_HttpRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HookDelivery_HttpRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *HookDelivery_HttpRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *HookDelivery_HttpRequest) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type HookDelivery_ShoutrrrMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url     string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *HookDelivery_ShoutrrrMessage) Reset() {
+	*x = HookDelivery_ShoutrrrMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_hookdelivery_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HookDelivery_ShoutrrrMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HookDelivery_ShoutrrrMessage) ProtoMessage() {}
+
+func (x *HookDelivery_ShoutrrrMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_hookdelivery_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HookDelivery_ShoutrrrMessage.ProtoReflect.Descriptor instead.
+func (*HookDelivery_ShoutrrrMessage) Descriptor() ([]byte, []int) {
+	return file_v1_hookdelivery_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *HookDelivery_ShoutrrrMessage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *HookDelivery_ShoutrrrMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_v1_hookdelivery_proto protoreflect.FileDescriptor
+
+var file_v1_hookdelivery_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x76, 0x31, 0x2f, 0x68, 0x6f, 0x6f, 0x6b, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0xfa, 0x04, 0x0a, 0x0c,
+	0x48, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x6f, 0x6b,
+	0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6c, 0x61,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e,
+	0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x4d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x4d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x61,
+	0x64, 0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x04, 0x68, 0x74,
+	0x74, 0x70, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f,
+	0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x3e,
+	0x0a, 0x08, 0x73, 0x68, 0x6f, 0x75, 0x74, 0x72, 0x72, 0x72, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x2e, 0x53, 0x68, 0x6f, 0x75, 0x74, 0x72, 0x72, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x08, 0x73, 0x68, 0x6f, 0x75, 0x74, 0x72, 0x72, 0x72, 0x1a, 0x6e,
+	0x0a, 0x0b, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f,
+	0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x53, 0x68, 0x6f, 0x75, 0x74, 0x72, 0x72, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x73, 0x0a, 0x10, 0x48, 0x6f, 0x6f, 0x6b,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52,
+	0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x33, 0x0a, 0x0c, 0x64, 0x65, 0x61, 0x64,
+	0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x52, 0x0b, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x42, 0x2c, 0x5a,
+	0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x72, 0x65,
+	0x74, 0x68, 0x67, 0x65, 0x6f, 0x72, 0x67, 0x65, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x72, 0x65, 0x73,
+	0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v1_hookdelivery_proto_rawDescOnce sync.Once
+	file_v1_hookdelivery_proto_rawDescData = file_v1_hookdelivery_proto_rawDesc
+)
+
+func file_v1_hookdelivery_proto_rawDescGZIP() []byte {
+	file_v1_hookdelivery_proto_rawDescOnce.Do(func() {
+		file_v1_hookdelivery_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_hookdelivery_proto_rawDescData)
+	})
+	return file_v1_hookdelivery_proto_rawDescData
+}
+
+var file_v1_hookdelivery_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_v1_hookdelivery_proto_goTypes = []interface{}{
+	(*HookDelivery)(nil),                 // 0: v1.HookDelivery
+	(*HookDeliveryList)(nil),             // 1: v1.HookDeliveryList
+	(*HookDelivery_HttpRequest)(nil),     // 2: v1.HookDelivery.HttpRequest
+	(*HookDelivery_ShoutrrrMessage)(nil), // 3: v1.HookDelivery.ShoutrrrMessage
+}
+var file_v1_hookdelivery_proto_depIdxs = []int32{
+	2, // 0: v1.HookDelivery.http:type_name -> v1.HookDelivery.HttpRequest
+	3, // 1: v1.HookDelivery.shoutrrr:type_name -> v1.HookDelivery.ShoutrrrMessage
+	0, // 2: v1.HookDeliveryList.pending:type_name -> v1.HookDelivery
+	0, // 3: v1.HookDeliveryList.dead_letters:type_name -> v1.HookDelivery
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_v1_hookdelivery_proto_init() }
+func file_v1_hookdelivery_proto_init() {
+	if File_v1_hookdelivery_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v1_hookdelivery_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HookDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_hookdelivery_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HookDeliveryList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_hookdelivery_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HookDelivery_HttpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_hookdelivery_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HookDelivery_ShoutrrrMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_v1_hookdelivery_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*HookDelivery_Http)(nil),
+		(*HookDelivery_Shoutrrr)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v1_hookdelivery_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_v1_hookdelivery_proto_goTypes,
+		DependencyIndexes: file_v1_hookdelivery_proto_depIdxs,
+		MessageInfos:      file_v1_hookdelivery_proto_msgTypes,
+	}.Build()
+	File_v1_hookdelivery_proto = out.File
+	file_v1_hookdelivery_proto_rawDesc = nil
+	file_v1_hookdelivery_proto_goTypes = nil
+	file_v1_hookdelivery_proto_depIdxs = nil
+}
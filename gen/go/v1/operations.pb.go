@@ -84,6 +84,7 @@ const (
 	OperationStatus_STATUS_ERROR            OperationStatus = 4 // used to indicate that the operation failed.
 	OperationStatus_STATUS_SYSTEM_CANCELLED OperationStatus = 5 // indicates operation cancelled by the system.
 	OperationStatus_STATUS_USER_CANCELLED   OperationStatus = 6 // indicates operation cancelled by the user.
+	OperationStatus_STATUS_SKIPPED          OperationStatus = 8 // indicates the operation did not run because a run condition of its plan was not met.
 )
 
 // Enum value maps for OperationStatus.
@@ -97,6 +98,7 @@ var (
 		4: "STATUS_ERROR",
 		5: "STATUS_SYSTEM_CANCELLED",
 		6: "STATUS_USER_CANCELLED",
+		8: "STATUS_SKIPPED",
 	}
 	OperationStatus_value = map[string]int32{
 		"STATUS_UNKNOWN":          0,
@@ -107,6 +109,7 @@ var (
 		"STATUS_ERROR":            4,
 		"STATUS_SYSTEM_CANCELLED": 5,
 		"STATUS_USER_CANCELLED":   6,
+		"STATUS_SKIPPED":          8,
 	}
 )
 
@@ -137,12 +140,117 @@ func (OperationStatus) EnumDescriptor() ([]byte, []int) {
 	return file_v1_operations_proto_rawDescGZIP(), []int{1}
 }
 
+type OperationMigrate_Phase int32
+
+const (
+	OperationMigrate_PHASE_UNKNOWN    OperationMigrate_Phase = 0
+	OperationMigrate_PHASE_MIGRATE    OperationMigrate_Phase = 1 // the migration is being applied.
+	OperationMigrate_PHASE_RECOMPRESS OperationMigrate_Phase = 2 // the data stored uncompressed is being repacked.
+	OperationMigrate_PHASE_DONE       OperationMigrate_Phase = 3
+)
+
+// Enum value maps for OperationMigrate_Phase.
+var (
+	OperationMigrate_Phase_name = map[int32]string{
+		0: "PHASE_UNKNOWN",
+		1: "PHASE_MIGRATE",
+		2: "PHASE_RECOMPRESS",
+		3: "PHASE_DONE",
+	}
+	OperationMigrate_Phase_value = map[string]int32{
+		"PHASE_UNKNOWN":    0,
+		"PHASE_MIGRATE":    1,
+		"PHASE_RECOMPRESS": 2,
+		"PHASE_DONE":       3,
+	}
+)
+
+func (x OperationMigrate_Phase) Enum() *OperationMigrate_Phase {
+	p := new(OperationMigrate_Phase)
+	*p = x
+	return p
+}
+
+func (x OperationMigrate_Phase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationMigrate_Phase) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_operations_proto_enumTypes[2].Descriptor()
+}
+
+func (OperationMigrate_Phase) Type() protoreflect.EnumType {
+	return &file_v1_operations_proto_enumTypes[2]
+}
+
+func (x OperationMigrate_Phase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationMigrate_Phase.Descriptor instead.
+func (OperationMigrate_Phase) EnumDescriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{14, 0}
+}
+
+type OplogInconsistency_Kind int32
+
+const (
+	OplogInconsistency_KIND_UNKNOWN                 OplogInconsistency_Kind = 0
+	OplogInconsistency_KIND_BACKUP_WITHOUT_SNAPSHOT OplogInconsistency_Kind = 1 // a successful backup that was not skipped as unchanged has no snapshot ID.
+	OplogInconsistency_KIND_SNAPSHOT_NOT_IN_REPO    OplogInconsistency_Kind = 2 // an indexed snapshot not marked forgotten is missing from the repo.
+	OplogInconsistency_KIND_STUCK_PENDING           OplogInconsistency_Kind = 3 // an operation is still pending long after it was scheduled to run.
+)
+
+// Enum value maps for OplogInconsistency_Kind.
+var (
+	OplogInconsistency_Kind_name = map[int32]string{
+		0: "KIND_UNKNOWN",
+		1: "KIND_BACKUP_WITHOUT_SNAPSHOT",
+		2: "KIND_SNAPSHOT_NOT_IN_REPO",
+		3: "KIND_STUCK_PENDING",
+	}
+	OplogInconsistency_Kind_value = map[string]int32{
+		"KIND_UNKNOWN":                 0,
+		"KIND_BACKUP_WITHOUT_SNAPSHOT": 1,
+		"KIND_SNAPSHOT_NOT_IN_REPO":    2,
+		"KIND_STUCK_PENDING":           3,
+	}
+)
+
+func (x OplogInconsistency_Kind) Enum() *OplogInconsistency_Kind {
+	p := new(OplogInconsistency_Kind)
+	*p = x
+	return p
+}
+
+func (x OplogInconsistency_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OplogInconsistency_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_operations_proto_enumTypes[3].Descriptor()
+}
+
+func (OplogInconsistency_Kind) Type() protoreflect.EnumType {
+	return &file_v1_operations_proto_enumTypes[3]
+}
+
+func (x OplogInconsistency_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OplogInconsistency_Kind.Descriptor instead.
+func (OplogInconsistency_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{19, 0}
+}
+
 type OperationList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Operations []*Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	Operations    []*Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	NextPageToken string       `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // set if GetOperations has more operations, pass it as the page_token of the next request.
 }
 
 func (x *OperationList) Reset() {
@@ -184,6 +292,13 @@ func (x *OperationList) GetOperations() []*Operation {
 	return nil
 }
 
+func (x *OperationList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type Operation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -207,6 +322,25 @@ type Operation struct {
 	DisplayMessage string `protobuf:"bytes,7,opt,name=display_message,json=displayMessage,proto3" json:"display_message,omitempty"`
 	// logref can point to arbitrary logs associated with the operation.
 	Logref string `protobuf:"bytes,9,opt,name=logref,proto3" json:"logref,omitempty"`
+	// optional, the attempt number if the operation is a retry of a failed operation. The first retry is attempt 2.
+	RetryAttempt int32 `protobuf:"varint,12,opt,name=retry_attempt,json=retryAttempt,proto3" json:"retry_attempt,omitempty"`
+	// optional, the hostname of the machine and the backrest and restic versions that created the operation.
+	Hostname        string `protobuf:"bytes,13,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	BackrestVersion string `protobuf:"bytes,14,opt,name=backrest_version,json=backrestVersion,proto3" json:"backrest_version,omitempty"`
+	ResticVersion   string `protobuf:"bytes,15,opt,name=restic_version,json=resticVersion,proto3" json:"restic_version,omitempty"`
+	// optional, set on operations interrupted by a shutdown. The operation's task is queued again the next time backrest starts.
+	ResumeOnStart bool `protobuf:"varint,16,opt,name=resume_on_start,json=resumeOnStart,proto3" json:"resume_on_start,omitempty"`
+	// optional, captured when a backup fails to help find the cause of intermittent failures.
+	FailureDiagnostics *FailureDiagnostics `protobuf:"bytes,17,opt,name=failure_diagnostics,json=failureDiagnostics,proto3" json:"failure_diagnostics,omitempty"`
+	// optional, the end of the log referenced by logref if it's a command log, the full log is fetched with GetLogs.
+	LogTail string `protobuf:"bytes,18,opt,name=log_tail,json=logTail,proto3" json:"log_tail,omitempty"`
+	// optional, a note left on the operation by a user e.g. explaining a failure, see AnnotateOperation.
+	Note *OperationNote `protobuf:"bytes,19,opt,name=note,proto3" json:"note,omitempty"`
+	// optional, CPU and memory used by the restic commands the operation ran.
+	ResourceUsage *ResourceUsage `protobuf:"bytes,20,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`
+	// optional, unix time in milliseconds the operation's snapshot was found to be no longer in the repo e.g. because it
+	// was forgotten. Orphaned operations are removed per OplogRetention.orphan_grace_days.
+	OrphanedMs int64 `protobuf:"varint,21,opt,name=orphaned_ms,json=orphanedMs,proto3" json:"orphaned_ms,omitempty"`
 	// Types that are assignable to Op:
 	//
 	//	*Operation_OperationBackup
@@ -216,6 +350,14 @@ type Operation struct {
 	//	*Operation_OperationRestore
 	//	*Operation_OperationStats
 	//	*Operation_OperationRunHook
+	//	*Operation_OperationCheck
+	//	*Operation_OperationCopy
+	//	*Operation_OperationInit
+	//	*Operation_OperationMigrate
+	//	*Operation_OperationRecover
+	//	*Operation_OperationTag
+	//	*Operation_OperationVerifyOplog
+	//	*Operation_OperationRewrite
 	Op isOperation_Op `protobuf_oneof:"op"`
 }
 
@@ -328,6 +470,76 @@ func (x *Operation) GetLogref() string {
 	return ""
 }
 
+func (x *Operation) GetRetryAttempt() int32 {
+	if x != nil {
+		return x.RetryAttempt
+	}
+	return 0
+}
+
+func (x *Operation) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Operation) GetBackrestVersion() string {
+	if x != nil {
+		return x.BackrestVersion
+	}
+	return ""
+}
+
+func (x *Operation) GetResticVersion() string {
+	if x != nil {
+		return x.ResticVersion
+	}
+	return ""
+}
+
+func (x *Operation) GetResumeOnStart() bool {
+	if x != nil {
+		return x.ResumeOnStart
+	}
+	return false
+}
+
+func (x *Operation) GetFailureDiagnostics() *FailureDiagnostics {
+	if x != nil {
+		return x.FailureDiagnostics
+	}
+	return nil
+}
+
+func (x *Operation) GetLogTail() string {
+	if x != nil {
+		return x.LogTail
+	}
+	return ""
+}
+
+func (x *Operation) GetNote() *OperationNote {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *Operation) GetResourceUsage() *ResourceUsage {
+	if x != nil {
+		return x.ResourceUsage
+	}
+	return nil
+}
+
+func (x *Operation) GetOrphanedMs() int64 {
+	if x != nil {
+		return x.OrphanedMs
+	}
+	return 0
+}
+
 func (m *Operation) GetOp() isOperation_Op {
 	if m != nil {
 		return m.Op
@@ -384,6 +596,62 @@ func (x *Operation) GetOperationRunHook() *OperationRunHook {
 	return nil
 }
 
+func (x *Operation) GetOperationCheck() *OperationCheck {
+	if x, ok := x.GetOp().(*Operation_OperationCheck); ok {
+		return x.OperationCheck
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationCopy() *OperationCopy {
+	if x, ok := x.GetOp().(*Operation_OperationCopy); ok {
+		return x.OperationCopy
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationInit() *OperationInit {
+	if x, ok := x.GetOp().(*Operation_OperationInit); ok {
+		return x.OperationInit
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationMigrate() *OperationMigrate {
+	if x, ok := x.GetOp().(*Operation_OperationMigrate); ok {
+		return x.OperationMigrate
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationRecover() *OperationRecover {
+	if x, ok := x.GetOp().(*Operation_OperationRecover); ok {
+		return x.OperationRecover
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationTag() *OperationTag {
+	if x, ok := x.GetOp().(*Operation_OperationTag); ok {
+		return x.OperationTag
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationVerifyOplog() *OperationVerifyOplog {
+	if x, ok := x.GetOp().(*Operation_OperationVerifyOplog); ok {
+		return x.OperationVerifyOplog
+	}
+	return nil
+}
+
+func (x *Operation) GetOperationRewrite() *OperationRewrite {
+	if x, ok := x.GetOp().(*Operation_OperationRewrite); ok {
+		return x.OperationRewrite
+	}
+	return nil
+}
+
 type isOperation_Op interface {
 	isOperation_Op()
 }
@@ -416,6 +684,38 @@ type Operation_OperationRunHook struct {
 	OperationRunHook *OperationRunHook `protobuf:"bytes,106,opt,name=operation_run_hook,json=operationRunHook,proto3,oneof"`
 }
 
+type Operation_OperationCheck struct {
+	OperationCheck *OperationCheck `protobuf:"bytes,107,opt,name=operation_check,json=operationCheck,proto3,oneof"`
+}
+
+type Operation_OperationCopy struct {
+	OperationCopy *OperationCopy `protobuf:"bytes,108,opt,name=operation_copy,json=operationCopy,proto3,oneof"`
+}
+
+type Operation_OperationInit struct {
+	OperationInit *OperationInit `protobuf:"bytes,109,opt,name=operation_init,json=operationInit,proto3,oneof"`
+}
+
+type Operation_OperationMigrate struct {
+	OperationMigrate *OperationMigrate `protobuf:"bytes,110,opt,name=operation_migrate,json=operationMigrate,proto3,oneof"`
+}
+
+type Operation_OperationRecover struct {
+	OperationRecover *OperationRecover `protobuf:"bytes,111,opt,name=operation_recover,json=operationRecover,proto3,oneof"`
+}
+
+type Operation_OperationTag struct {
+	OperationTag *OperationTag `protobuf:"bytes,112,opt,name=operation_tag,json=operationTag,proto3,oneof"`
+}
+
+type Operation_OperationVerifyOplog struct {
+	OperationVerifyOplog *OperationVerifyOplog `protobuf:"bytes,113,opt,name=operation_verify_oplog,json=operationVerifyOplog,proto3,oneof"`
+}
+
+type Operation_OperationRewrite struct {
+	OperationRewrite *OperationRewrite `protobuf:"bytes,114,opt,name=operation_rewrite,json=operationRewrite,proto3,oneof"`
+}
+
 func (*Operation_OperationBackup) isOperation_Op() {}
 
 func (*Operation_OperationIndexSnapshot) isOperation_Op() {}
@@ -430,6 +730,22 @@ func (*Operation_OperationStats) isOperation_Op() {}
 
 func (*Operation_OperationRunHook) isOperation_Op() {}
 
+func (*Operation_OperationCheck) isOperation_Op() {}
+
+func (*Operation_OperationCopy) isOperation_Op() {}
+
+func (*Operation_OperationInit) isOperation_Op() {}
+
+func (*Operation_OperationMigrate) isOperation_Op() {}
+
+func (*Operation_OperationRecover) isOperation_Op() {}
+
+func (*Operation_OperationTag) isOperation_Op() {}
+
+func (*Operation_OperationVerifyOplog) isOperation_Op() {}
+
+func (*Operation_OperationRewrite) isOperation_Op() {}
+
 // OperationEvent is used in the wireformat to stream operation changes to clients
 type OperationEvent struct {
 	state         protoimpl.MessageState
@@ -491,8 +807,15 @@ type OperationBackup struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LastStatus *BackupProgressEntry   `protobuf:"bytes,3,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`
-	Errors     []*BackupProgressError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	LastStatus      *BackupProgressEntry   `protobuf:"bytes,3,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`
+	Errors          []*BackupProgressError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	Unchanged       bool                   `protobuf:"varint,5,opt,name=unchanged,proto3" json:"unchanged,omitempty"`                                      // no files changed since the plan's last snapshot and the plan skips unchanged backups, no snapshot was kept.
+	ScheduledTimeMs int64                  `protobuf:"varint,6,opt,name=scheduled_time_ms,json=scheduledTimeMs,proto3" json:"scheduled_time_ms,omitempty"` // unix time in milliseconds the scheduled backup was due at including jitter_ms, set if the plan has jitter.
+	JitterMs        int64                  `protobuf:"varint,7,opt,name=jitter_ms,json=jitterMs,proto3" json:"jitter_ms,omitempty"`                        // random delay added to the plan's schedule, see Plan.jitter_minutes.
+	ErrorCount      int64                  `protobuf:"varint,8,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`                  // number of items restic failed to read, errors only holds the first of them.
+	Warnings        []string               `protobuf:"bytes,9,rep,name=warnings,proto3" json:"warnings,omitempty"`                                         // warnings restic printed outside of its JSON output e.g. about its cache, the first are kept.
+	WarningCount    int64                  `protobuf:"varint,10,opt,name=warning_count,json=warningCount,proto3" json:"warning_count,omitempty"`           // number of warnings restic printed.
+	Annotations     []*SnapshotAnnotation  `protobuf:"bytes,11,rep,name=annotations,proto3" json:"annotations,omitempty"`                                  // metadata attached to the backup's snapshot as "annotation:<key>=<value>" tags.
 }
 
 func (x *OperationBackup) Reset() {
@@ -541,21 +864,128 @@ func (x *OperationBackup) GetErrors() []*BackupProgressError {
 	return nil
 }
 
+func (x *OperationBackup) GetUnchanged() bool {
+	if x != nil {
+		return x.Unchanged
+	}
+	return false
+}
+
+func (x *OperationBackup) GetScheduledTimeMs() int64 {
+	if x != nil {
+		return x.ScheduledTimeMs
+	}
+	return 0
+}
+
+func (x *OperationBackup) GetJitterMs() int64 {
+	if x != nil {
+		return x.JitterMs
+	}
+	return 0
+}
+
+func (x *OperationBackup) GetErrorCount() int64 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *OperationBackup) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *OperationBackup) GetWarningCount() int64 {
+	if x != nil {
+		return x.WarningCount
+	}
+	return 0
+}
+
+func (x *OperationBackup) GetAnnotations() []*SnapshotAnnotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+// SnapshotAnnotation is a piece of metadata describing what a snapshot captured e.g. the version of the application
+// whose data was backed up, it's given by the backup's trigger or written by the plan's pre backup command.
+type SnapshotAnnotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`     // letters, digits, '_', '.' and '-', at most 64 characters.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // at most 256 characters, no commas or control characters.
+}
+
+func (x *SnapshotAnnotation) Reset() {
+	*x = SnapshotAnnotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotAnnotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotAnnotation) ProtoMessage() {}
+
+func (x *SnapshotAnnotation) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotAnnotation.ProtoReflect.Descriptor instead.
+func (*SnapshotAnnotation) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SnapshotAnnotation) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SnapshotAnnotation) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 // OperationIndexSnapshot tracks that a snapshot was detected by backrest.
 type OperationIndexSnapshot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Snapshot   *ResticSnapshot `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`                          // the snapshot that was indexed.
-	Forgot     bool            `protobuf:"varint,3,opt,name=forgot,proto3" json:"forgot,omitempty"`                             // tracks whether this snapshot is forgotten yet.
-	ForgotByOp int64           `protobuf:"varint,4,opt,name=forgot_by_op,json=forgotByOp,proto3" json:"forgot_by_op,omitempty"` // ID of a forget operation that removed this snapshot.
+	Snapshot    *ResticSnapshot `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`                             // the snapshot that was indexed.
+	Forgot      bool            `protobuf:"varint,3,opt,name=forgot,proto3" json:"forgot,omitempty"`                                // tracks whether this snapshot is forgotten yet.
+	ForgotByOp  int64           `protobuf:"varint,4,opt,name=forgot_by_op,json=forgotByOp,proto3" json:"forgot_by_op,omitempty"`    // ID of a forget operation that removed this snapshot.
+	ForgetDueMs int64           `protobuf:"varint,5,opt,name=forget_due_ms,json=forgetDueMs,proto3" json:"forget_due_ms,omitempty"` // unix time in milliseconds the plan's retention policy first selected the snapshot for removal, set while the repo's prune cool-down keeps it.
 }
 
 func (x *OperationIndexSnapshot) Reset() {
 	*x = OperationIndexSnapshot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_operations_proto_msgTypes[4]
+		mi := &file_v1_operations_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -568,7 +998,7 @@ func (x *OperationIndexSnapshot) String() string {
 func (*OperationIndexSnapshot) ProtoMessage() {}
 
 func (x *OperationIndexSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[4]
+	mi := &file_v1_operations_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +1011,7 @@ func (x *OperationIndexSnapshot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationIndexSnapshot.ProtoReflect.Descriptor instead.
 func (*OperationIndexSnapshot) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{4}
+	return file_v1_operations_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OperationIndexSnapshot) GetSnapshot() *ResticSnapshot {
@@ -605,20 +1035,28 @@ func (x *OperationIndexSnapshot) GetForgotByOp() int64 {
 	return 0
 }
 
+func (x *OperationIndexSnapshot) GetForgetDueMs() int64 {
+	if x != nil {
+		return x.ForgetDueMs
+	}
+	return 0
+}
+
 // OperationForget tracks a forget operation.
 type OperationForget struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Forget []*ResticSnapshot `protobuf:"bytes,1,rep,name=forget,proto3" json:"forget,omitempty"`
-	Policy *RetentionPolicy  `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Forget   []*ResticSnapshot `protobuf:"bytes,1,rep,name=forget,proto3" json:"forget,omitempty"`
+	Policy   *RetentionPolicy  `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Retained []*ResticSnapshot `protobuf:"bytes,3,rep,name=retained,proto3" json:"retained,omitempty"` // snapshots selected for removal that are kept until the repo's prune cool-down passed.
 }
 
 func (x *OperationForget) Reset() {
 	*x = OperationForget{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_operations_proto_msgTypes[5]
+		mi := &file_v1_operations_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -631,7 +1069,7 @@ func (x *OperationForget) String() string {
 func (*OperationForget) ProtoMessage() {}
 
 func (x *OperationForget) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[5]
+	mi := &file_v1_operations_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +1082,7 @@ func (x *OperationForget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationForget.ProtoReflect.Descriptor instead.
 func (*OperationForget) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{5}
+	return file_v1_operations_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OperationForget) GetForget() []*ResticSnapshot {
@@ -661,32 +1099,937 @@ func (x *OperationForget) GetPolicy() *RetentionPolicy {
 	return nil
 }
 
-// OperationPrune tracks a prune operation.
-type OperationPrune struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *OperationForget) GetRetained() []*ResticSnapshot {
+	if x != nil {
+		return x.Retained
+	}
+	return nil
+}
+
+// OperationPrune tracks a prune operation.
+type OperationPrune struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Output string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"` // output of the prune.
+}
+
+func (x *OperationPrune) Reset() {
+	*x = OperationPrune{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationPrune) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationPrune) ProtoMessage() {}
+
+func (x *OperationPrune) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationPrune.ProtoReflect.Descriptor instead.
+func (*OperationPrune) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OperationPrune) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+type OperationCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Output string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"` // output of the check.
+}
+
+func (x *OperationCheck) Reset() {
+	*x = OperationCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationCheck) ProtoMessage() {}
+
+func (x *OperationCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationCheck.ProtoReflect.Descriptor instead.
+func (*OperationCheck) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OperationCheck) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+// OperationNote is a free text note attached to an operation by a user.
+type OperationNote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text       string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Author     string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`                              // name of the user who wrote the note, empty if authentication is disabled.
+	UnixTimeMs int64  `protobuf:"varint,3,opt,name=unix_time_ms,json=unixTimeMs,proto3" json:"unix_time_ms,omitempty"` // time the note was last edited.
+}
+
+func (x *OperationNote) Reset() {
+	*x = OperationNote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationNote) ProtoMessage() {}
+
+func (x *OperationNote) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationNote.ProtoReflect.Descriptor instead.
+func (*OperationNote) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OperationNote) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *OperationNote) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *OperationNote) GetUnixTimeMs() int64 {
+	if x != nil {
+		return x.UnixTimeMs
+	}
+	return 0
+}
+
+// FailureDiagnostics is a snapshot of the state of the system and the repo's backend taken when an operation failed.
+type FailureDiagnostics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnixTimeMs           int64        `protobuf:"varint,1,opt,name=unix_time_ms,json=unixTimeMs,proto3" json:"unix_time_ms,omitempty"`                               // time the diagnostics were captured.
+	ResticOutput         string       `protobuf:"bytes,2,opt,name=restic_output,json=resticOutput,proto3" json:"restic_output,omitempty"`                            // tail of the failed restic command's output, progress messages are omitted.
+	DaemonLog            string       `protobuf:"bytes,3,opt,name=daemon_log,json=daemonLog,proto3" json:"daemon_log,omitempty"`                                     // the most recent lines of backrest's log.
+	Disks                []*DiskUsage `protobuf:"bytes,4,rep,name=disks,proto3" json:"disks,omitempty"`                                                              // free space of the plan's paths, the repo if it's local and backrest's data dir.
+	MemoryTotalBytes     int64        `protobuf:"varint,5,opt,name=memory_total_bytes,json=memoryTotalBytes,proto3" json:"memory_total_bytes,omitempty"`             // optional, total memory of the system. Only reported on Linux.
+	MemoryAvailableBytes int64        `protobuf:"varint,6,opt,name=memory_available_bytes,json=memoryAvailableBytes,proto3" json:"memory_available_bytes,omitempty"` // optional, memory available to new processes. Only reported on Linux.
+	DaemonMemoryBytes    int64        `protobuf:"varint,7,opt,name=daemon_memory_bytes,json=daemonMemoryBytes,proto3" json:"daemon_memory_bytes,omitempty"`          // memory obtained from the OS by backrest.
+	BackendLatencyMs     int64        `protobuf:"varint,8,opt,name=backend_latency_ms,json=backendLatencyMs,proto3" json:"backend_latency_ms,omitempty"`             // time taken to read the repo's config from its backend.
+	BackendError         string       `protobuf:"bytes,9,opt,name=backend_error,json=backendError,proto3" json:"backend_error,omitempty"`                            // set if the repo's config could not be read, backend_latency_ms is then the time until the failure.
+}
+
+func (x *FailureDiagnostics) Reset() {
+	*x = FailureDiagnostics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailureDiagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailureDiagnostics) ProtoMessage() {}
+
+func (x *FailureDiagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailureDiagnostics.ProtoReflect.Descriptor instead.
+func (*FailureDiagnostics) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FailureDiagnostics) GetUnixTimeMs() int64 {
+	if x != nil {
+		return x.UnixTimeMs
+	}
+	return 0
+}
+
+func (x *FailureDiagnostics) GetResticOutput() string {
+	if x != nil {
+		return x.ResticOutput
+	}
+	return ""
+}
+
+func (x *FailureDiagnostics) GetDaemonLog() string {
+	if x != nil {
+		return x.DaemonLog
+	}
+	return ""
+}
+
+func (x *FailureDiagnostics) GetDisks() []*DiskUsage {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+func (x *FailureDiagnostics) GetMemoryTotalBytes() int64 {
+	if x != nil {
+		return x.MemoryTotalBytes
+	}
+	return 0
+}
+
+func (x *FailureDiagnostics) GetMemoryAvailableBytes() int64 {
+	if x != nil {
+		return x.MemoryAvailableBytes
+	}
+	return 0
+}
+
+func (x *FailureDiagnostics) GetDaemonMemoryBytes() int64 {
+	if x != nil {
+		return x.DaemonMemoryBytes
+	}
+	return 0
+}
+
+func (x *FailureDiagnostics) GetBackendLatencyMs() int64 {
+	if x != nil {
+		return x.BackendLatencyMs
+	}
+	return 0
+}
+
+func (x *FailureDiagnostics) GetBackendError() string {
+	if x != nil {
+		return x.BackendError
+	}
+	return ""
+}
+
+// ResourceUsage is the CPU and memory used by the restic commands of an operation. Averages are over the time the
+// commands ran, usage is sampled while they run on Linux and only the totals restic reports on exit are kept elsewhere.
+type ResourceUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commands     int32   `protobuf:"varint,1,opt,name=commands,proto3" json:"commands,omitempty"`                                // number of restic commands run.
+	RunTimeMs    int64   `protobuf:"varint,2,opt,name=run_time_ms,json=runTimeMs,proto3" json:"run_time_ms,omitempty"`           // total time the commands ran.
+	CpuTimeMs    int64   `protobuf:"varint,3,opt,name=cpu_time_ms,json=cpuTimeMs,proto3" json:"cpu_time_ms,omitempty"`           // user and system CPU time used by the commands.
+	AvgCpuCores  float64 `protobuf:"fixed64,4,opt,name=avg_cpu_cores,json=avgCpuCores,proto3" json:"avg_cpu_cores,omitempty"`    // cpu_time_ms over run_time_ms, 1.0 is one core fully used.
+	PeakCpuCores float64 `protobuf:"fixed64,5,opt,name=peak_cpu_cores,json=peakCpuCores,proto3" json:"peak_cpu_cores,omitempty"` // highest CPU usage between two samples.
+	AvgRssBytes  int64   `protobuf:"varint,6,opt,name=avg_rss_bytes,json=avgRssBytes,proto3" json:"avg_rss_bytes,omitempty"`     // average resident memory of the samples.
+	PeakRssBytes int64   `protobuf:"varint,7,opt,name=peak_rss_bytes,json=peakRssBytes,proto3" json:"peak_rss_bytes,omitempty"`  // highest resident memory of any command.
+}
+
+func (x *ResourceUsage) Reset() {
+	*x = ResourceUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceUsage) ProtoMessage() {}
+
+func (x *ResourceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceUsage.ProtoReflect.Descriptor instead.
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResourceUsage) GetCommands() int32 {
+	if x != nil {
+		return x.Commands
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetRunTimeMs() int64 {
+	if x != nil {
+		return x.RunTimeMs
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetCpuTimeMs() int64 {
+	if x != nil {
+		return x.CpuTimeMs
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetAvgCpuCores() float64 {
+	if x != nil {
+		return x.AvgCpuCores
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetPeakCpuCores() float64 {
+	if x != nil {
+		return x.PeakCpuCores
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetAvgRssBytes() int64 {
+	if x != nil {
+		return x.AvgRssBytes
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetPeakRssBytes() int64 {
+	if x != nil {
+		return x.PeakRssBytes
+	}
+	return 0
+}
+
+type DiskUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path       string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	FreeBytes  int64  `protobuf:"varint,2,opt,name=free_bytes,json=freeBytes,proto3" json:"free_bytes,omitempty"` // bytes available to unprivileged users.
+	TotalBytes int64  `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	Error      string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // set if the usage of the path's filesystem could not be read.
+}
+
+func (x *DiskUsage) Reset() {
+	*x = DiskUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiskUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskUsage) ProtoMessage() {}
+
+func (x *DiskUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskUsage.ProtoReflect.Descriptor instead.
+func (*DiskUsage) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DiskUsage) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DiskUsage) GetFreeBytes() int64 {
+	if x != nil {
+		return x.FreeBytes
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// OperationInit tracks a restic init of the operation's repo.
+type OperationInit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Output string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"` // output of the init.
+}
+
+func (x *OperationInit) Reset() {
+	*x = OperationInit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationInit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationInit) ProtoMessage() {}
+
+func (x *OperationInit) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationInit.ProtoReflect.Descriptor instead.
+func (*OperationInit) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OperationInit) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+// OperationMigrate tracks a restic migrate of the operation's repo.
+type OperationMigrate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Migration  string                 `protobuf:"bytes,1,opt,name=migration,proto3" json:"migration,omitempty"`                         // name of the migration applied e.g. "upgrade_repo_v2", empty if the repo only needed recompressing.
+	Output     string                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`                               // output of the migration and the recompression.
+	Recompress bool                   `protobuf:"varint,3,opt,name=recompress,proto3" json:"recompress,omitempty"`                      // the data stored uncompressed is repacked compressed after the migration.
+	Phase      OperationMigrate_Phase `protobuf:"varint,4,opt,name=phase,proto3,enum=v1.OperationMigrate_Phase" json:"phase,omitempty"` // the step of the upgrade the operation is at.
+}
+
+func (x *OperationMigrate) Reset() {
+	*x = OperationMigrate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationMigrate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationMigrate) ProtoMessage() {}
+
+func (x *OperationMigrate) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationMigrate.ProtoReflect.Descriptor instead.
+func (*OperationMigrate) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OperationMigrate) GetMigration() string {
+	if x != nil {
+		return x.Migration
+	}
+	return ""
+}
+
+func (x *OperationMigrate) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *OperationMigrate) GetRecompress() bool {
+	if x != nil {
+		return x.Recompress
+	}
+	return false
+}
+
+func (x *OperationMigrate) GetPhase() OperationMigrate_Phase {
+	if x != nil {
+		return x.Phase
+	}
+	return OperationMigrate_PHASE_UNKNOWN
+}
+
+// OperationRecover tracks a restic recover of the operation's repo.
+type OperationRecover struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Output     string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`                           // output of the recover.
+	Roots      int32  `protobuf:"varint,2,opt,name=roots,proto3" json:"roots,omitempty"`                            // number of directories found that no snapshot referenced.
+	SnapshotId string `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"` // ID of the snapshot holding the recovered directories, empty if nothing was recovered.
+}
+
+func (x *OperationRecover) Reset() {
+	*x = OperationRecover{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationRecover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationRecover) ProtoMessage() {}
+
+func (x *OperationRecover) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationRecover.ProtoReflect.Descriptor instead.
+func (*OperationRecover) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OperationRecover) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *OperationRecover) GetRoots() int32 {
+	if x != nil {
+		return x.Roots
+	}
+	return 0
+}
+
+func (x *OperationRecover) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+// OperationTag tracks a restic tag of snapshots in the operation's repo. restic saves each snapshot it changes under a
+// new ID, the snapshots are indexed again once they are tagged.
+type OperationTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SnapshotIds []string `protobuf:"bytes,1,rep,name=snapshot_ids,json=snapshotIds,proto3" json:"snapshot_ids,omitempty"` // IDs of the snapshots whose tags were changed.
+	Add         []string `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`                                    // tags added to the snapshots.
+	Remove      []string `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`                              // tags removed from the snapshots.
+}
+
+func (x *OperationTag) Reset() {
+	*x = OperationTag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationTag) ProtoMessage() {}
+
+func (x *OperationTag) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationTag.ProtoReflect.Descriptor instead.
+func (*OperationTag) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OperationTag) GetSnapshotIds() []string {
+	if x != nil {
+		return x.SnapshotIds
+	}
+	return nil
+}
+
+func (x *OperationTag) GetAdd() []string {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *OperationTag) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+// OperationRewrite tracks a restic rewrite removing files from snapshots of the operation's repo.
+type OperationRewrite struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SnapshotIds        []string `protobuf:"bytes,1,rep,name=snapshot_ids,json=snapshotIds,proto3" json:"snapshot_ids,omitempty"`                       // IDs of the snapshots rewritten.
+	Excludes           []string `protobuf:"bytes,2,rep,name=excludes,proto3" json:"excludes,omitempty"`                                                // patterns of the files removed from the snapshots.
+	Forget             bool     `protobuf:"varint,3,opt,name=forget,proto3" json:"forget,omitempty"`                                                   // whether the original snapshots are forgotten once rewritten.
+	SnapshotsRewritten int32    `protobuf:"varint,4,opt,name=snapshots_rewritten,json=snapshotsRewritten,proto3" json:"snapshots_rewritten,omitempty"` // number of snapshots that held excluded files and were saved under new IDs.
+	Output             string   `protobuf:"bytes,5,opt,name=output,proto3" json:"output,omitempty"`                                                    // output of the rewrite.
+}
+
+func (x *OperationRewrite) Reset() {
+	*x = OperationRewrite{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationRewrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationRewrite) ProtoMessage() {}
+
+func (x *OperationRewrite) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationRewrite.ProtoReflect.Descriptor instead.
+func (*OperationRewrite) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OperationRewrite) GetSnapshotIds() []string {
+	if x != nil {
+		return x.SnapshotIds
+	}
+	return nil
+}
+
+func (x *OperationRewrite) GetExcludes() []string {
+	if x != nil {
+		return x.Excludes
+	}
+	return nil
+}
+
+func (x *OperationRewrite) GetForget() bool {
+	if x != nil {
+		return x.Forget
+	}
+	return false
+}
+
+func (x *OperationRewrite) GetSnapshotsRewritten() int32 {
+	if x != nil {
+		return x.SnapshotsRewritten
+	}
+	return 0
+}
+
+func (x *OperationRewrite) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+// OperationVerifyOplog tracks a check of the oplog's invariants for the operation's repo, the operation fails if any
+// inconsistency is found.
+type OperationVerifyOplog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OperationsChecked  int32                 `protobuf:"varint,1,opt,name=operations_checked,json=operationsChecked,proto3" json:"operations_checked,omitempty"`
+	InconsistencyCount int32                 `protobuf:"varint,2,opt,name=inconsistency_count,json=inconsistencyCount,proto3" json:"inconsistency_count,omitempty"` // total number of inconsistencies found, only the first are listed.
+	Inconsistencies    []*OplogInconsistency `protobuf:"bytes,3,rep,name=inconsistencies,proto3" json:"inconsistencies,omitempty"`
+}
+
+func (x *OperationVerifyOplog) Reset() {
+	*x = OperationVerifyOplog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationVerifyOplog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationVerifyOplog) ProtoMessage() {}
+
+func (x *OperationVerifyOplog) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationVerifyOplog.ProtoReflect.Descriptor instead.
+func (*OperationVerifyOplog) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OperationVerifyOplog) GetOperationsChecked() int32 {
+	if x != nil {
+		return x.OperationsChecked
+	}
+	return 0
+}
+
+func (x *OperationVerifyOplog) GetInconsistencyCount() int32 {
+	if x != nil {
+		return x.InconsistencyCount
+	}
+	return 0
+}
+
+func (x *OperationVerifyOplog) GetInconsistencies() []*OplogInconsistency {
+	if x != nil {
+		return x.Inconsistencies
+	}
+	return nil
+}
+
+// OplogInconsistency is an operation that breaks one of the oplog's invariants.
+type OplogInconsistency struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind        OplogInconsistency_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=v1.OplogInconsistency_Kind" json:"kind,omitempty"`
+	OperationId int64                   `protobuf:"varint,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Message     string                  `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *OplogInconsistency) Reset() {
+	*x = OplogInconsistency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OplogInconsistency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OplogInconsistency) ProtoMessage() {}
+
+func (x *OplogInconsistency) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OplogInconsistency.ProtoReflect.Descriptor instead.
+func (*OplogInconsistency) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OplogInconsistency) GetKind() OplogInconsistency_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return OplogInconsistency_KIND_UNKNOWN
+}
+
+func (x *OplogInconsistency) GetOperationId() int64 {
+	if x != nil {
+		return x.OperationId
+	}
+	return 0
+}
+
+func (x *OplogInconsistency) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// OperationCopy tracks a restic copy of a plan's snapshots into the operation's repo.
+type OperationCopy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Output string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"` // output of the prune.
+	SourceRepo      string            `protobuf:"bytes,1,opt,name=source_repo,json=sourceRepo,proto3" json:"source_repo,omitempty"`                // ID of the repo the snapshots were copied from.
+	CopiedSnapshots []*CopiedSnapshot `protobuf:"bytes,2,rep,name=copied_snapshots,json=copiedSnapshots,proto3" json:"copied_snapshots,omitempty"` // snapshots newly copied, snapshots copied by earlier runs are not listed.
 }
 
-func (x *OperationPrune) Reset() {
-	*x = OperationPrune{}
+func (x *OperationCopy) Reset() {
+	*x = OperationCopy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_operations_proto_msgTypes[6]
+		mi := &file_v1_operations_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OperationPrune) String() string {
+func (x *OperationCopy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OperationPrune) ProtoMessage() {}
+func (*OperationCopy) ProtoMessage() {}
 
-func (x *OperationPrune) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[6]
+func (x *OperationCopy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -697,14 +2040,76 @@ func (x *OperationPrune) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OperationPrune.ProtoReflect.Descriptor instead.
-func (*OperationPrune) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use OperationCopy.ProtoReflect.Descriptor instead.
+func (*OperationCopy) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *OperationPrune) GetOutput() string {
+func (x *OperationCopy) GetSourceRepo() string {
 	if x != nil {
-		return x.Output
+		return x.SourceRepo
+	}
+	return ""
+}
+
+func (x *OperationCopy) GetCopiedSnapshots() []*CopiedSnapshot {
+	if x != nil {
+		return x.CopiedSnapshots
+	}
+	return nil
+}
+
+type CopiedSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceId      string `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`                // short ID of the snapshot in the source repo.
+	DestinationId string `protobuf:"bytes,2,opt,name=destination_id,json=destinationId,proto3" json:"destination_id,omitempty"` // short ID of the copy in the destination repo.
+}
+
+func (x *CopiedSnapshot) Reset() {
+	*x = CopiedSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_operations_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopiedSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopiedSnapshot) ProtoMessage() {}
+
+func (x *CopiedSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_operations_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopiedSnapshot.ProtoReflect.Descriptor instead.
+func (*CopiedSnapshot) Descriptor() ([]byte, []int) {
+	return file_v1_operations_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CopiedSnapshot) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *CopiedSnapshot) GetDestinationId() string {
+	if x != nil {
+		return x.DestinationId
 	}
 	return ""
 }
@@ -714,15 +2119,17 @@ type OperationRestore struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Path   string                `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`     // path in the snapshot to restore.
-	Target string                `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // location to restore it to.
-	Status *RestoreProgressEntry `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // status of the restore.
+	Path    string                `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                       // path in the snapshot to restore.
+	Target  string                `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`                   // location to restore it to.
+	Status  *RestoreProgressEntry `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                   // status of the restore.
+	InPlace bool                  `protobuf:"varint,4,opt,name=in_place,json=inPlace,proto3" json:"in_place,omitempty"` // files were restored to their original location, target is the filesystem root.
+	Swap    bool                  `protobuf:"varint,5,opt,name=swap,proto3" json:"swap,omitempty"`                      // the in place restore was staged next to path and swapped in once complete.
 }
 
 func (x *OperationRestore) Reset() {
 	*x = OperationRestore{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_operations_proto_msgTypes[7]
+		mi := &file_v1_operations_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -735,7 +2142,7 @@ func (x *OperationRestore) String() string {
 func (*OperationRestore) ProtoMessage() {}
 
 func (x *OperationRestore) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[7]
+	mi := &file_v1_operations_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +2155,7 @@ func (x *OperationRestore) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRestore.ProtoReflect.Descriptor instead.
 func (*OperationRestore) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{7}
+	return file_v1_operations_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *OperationRestore) GetPath() string {
@@ -772,6 +2179,20 @@ func (x *OperationRestore) GetStatus() *RestoreProgressEntry {
 	return nil
 }
 
+func (x *OperationRestore) GetInPlace() bool {
+	if x != nil {
+		return x.InPlace
+	}
+	return false
+}
+
+func (x *OperationRestore) GetSwap() bool {
+	if x != nil {
+		return x.Swap
+	}
+	return false
+}
+
 type OperationStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -783,7 +2204,7 @@ type OperationStats struct {
 func (x *OperationStats) Reset() {
 	*x = OperationStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_operations_proto_msgTypes[8]
+		mi := &file_v1_operations_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -796,7 +2217,7 @@ func (x *OperationStats) String() string {
 func (*OperationStats) ProtoMessage() {}
 
 func (x *OperationStats) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[8]
+	mi := &file_v1_operations_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,7 +2230,7 @@ func (x *OperationStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationStats.ProtoReflect.Descriptor instead.
 func (*OperationStats) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{8}
+	return file_v1_operations_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *OperationStats) GetStats() *RepoStats {
@@ -832,7 +2253,7 @@ type OperationRunHook struct {
 func (x *OperationRunHook) Reset() {
 	*x = OperationRunHook{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_operations_proto_msgTypes[9]
+		mi := &file_v1_operations_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -845,7 +2266,7 @@ func (x *OperationRunHook) String() string {
 func (*OperationRunHook) ProtoMessage() {}
 
 func (x *OperationRunHook) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_operations_proto_msgTypes[9]
+	mi := &file_v1_operations_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,7 +2279,7 @@ func (x *OperationRunHook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRunHook.ProtoReflect.Descriptor instead.
 func (*OperationRunHook) Descriptor() ([]byte, []int) {
-	return file_v1_operations_proto_rawDescGZIP(), []int{9}
+	return file_v1_operations_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *OperationRunHook) GetName() string {
@@ -888,137 +2309,366 @@ var file_v1_operations_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x0f, 0x76, 0x31, 0x2f, 0x72, 0x65,
 	0x73, 0x74, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x76, 0x31, 0x2f, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3e, 0x0a, 0x0d, 0x4f,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x66, 0x0a, 0x0d, 0x4f,
 	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x0a,
 	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd7, 0x06, 0x0a, 0x09,
-	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x6c, 0x6f,
-	0x77, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x6c, 0x6f, 0x77,
-	0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70,
-	0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c,
-	0x61, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65,
-	0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0f, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x73,
-	0x12, 0x27, 0x0a, 0x10, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e,
-	0x64, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78,
-	0x54, 0x69, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x4d, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x72, 0x65, 0x66, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x72, 0x65, 0x66, 0x12, 0x40, 0x0a, 0x10, 0x6f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x18, 0x64,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x48, 0x00, 0x52, 0x0f, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x56, 0x0a, 0x18,
-	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f,
-	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x16, 0x6f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x12, 0x40, 0x0a, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x18, 0x66, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72,
-	0x67, 0x65, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x46, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x12, 0x3d, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x18, 0x67, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
-	0x75, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x50, 0x72, 0x75, 0x6e, 0x65, 0x12, 0x43, 0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x68, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x3d, 0x0a, 0x0f, 0x6f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x69, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x44, 0x0a, 0x12, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x18,
-	0x6a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6e, 0x48, 0x6f, 0x6f, 0x6b, 0x48, 0x00, 0x52, 0x10, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6e, 0x48, 0x6f, 0x6f, 0x6b, 0x42,
-	0x04, 0x0a, 0x02, 0x6f, 0x70, 0x22, 0x69, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x12, 0x2b, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x7c, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63,
-	0x6b, 0x75, 0x70, 0x12, 0x38, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
-	0x63, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f, 0x0a,
-	0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
-	0x73, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x82,
-	0x01, 0x0a, 0x16, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x6e, 0x61,
-	0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x73, 0x74, 0x69, 0x63, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
-	0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72,
-	0x67, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x67, 0x6f,
-	0x74, 0x12, 0x20, 0x0a, 0x0c, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x5f, 0x62, 0x79, 0x5f, 0x6f,
-	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x42,
-	0x79, 0x4f, 0x70, 0x22, 0x6a, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x46, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x74,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74,
-	0x69, 0x63, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x67,
-	0x65, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
-	0x28, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x75, 0x6e,
-	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x70, 0x0a, 0x10, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
-	0x68, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x35, 0x0a, 0x0e, 0x4f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x22, 0x7d, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x75, 0x6e, 0x48, 0x6f, 0x6f, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x72, 0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x67, 0x72, 0x65, 0x66, 0x12,
-	0x30, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6f, 0x6b, 0x2e, 0x43, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x2a, 0x60, 0x0a, 0x12, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54,
-	0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56,
-	0x45, 0x4e, 0x54, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a,
-	0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02,
-	0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45,
-	0x44, 0x10, 0x03, 0x2a, 0xc2, 0x01, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x55,
-	0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53,
-	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
-	0x15, 0x0a, 0x11, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x49, 0x4e, 0x50, 0x52, 0x4f, 0x47,
-	0x52, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
-	0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54,
-	0x41, 0x54, 0x55, 0x53, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x07, 0x12, 0x10,
-	0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04,
-	0x12, 0x1b, 0x0a, 0x17, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45,
-	0x4d, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x19, 0x0a,
-	0x15, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x43, 0x41, 0x4e,
-	0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x06, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x72, 0x65, 0x74, 0x68, 0x67, 0x65, 0x6f,
-	0x72, 0x67, 0x65, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x72, 0x65, 0x73, 0x74, 0x2f, 0x67, 0x65, 0x6e,
-	0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x89, 0x0e, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x66, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65,
+	0x70, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x70,
+	0x6f, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x2b,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x75,
+	0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6d,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d,
+	0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x73, 0x12, 0x27, 0x0a, 0x10, 0x75, 0x6e, 0x69, 0x78,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x4d,
+	0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f,
+	0x67, 0x72, 0x65, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x72,
+	0x65, 0x66, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x72, 0x65, 0x73, 0x74, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x62,
+	0x61, 0x63, 0x6b, 0x72, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x65, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x69, 0x63, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f,
+	0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4f, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x47, 0x0a,
+	0x13, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x52, 0x12, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x44, 0x69, 0x61, 0x67, 0x6e,
+	0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x5f, 0x74, 0x61,
+	0x69, 0x6c, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x54, 0x61, 0x69,
+	0x6c, 0x12, 0x25, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f,
+	0x74, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x5f, 0x6d,
+	0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65,
+	0x64, 0x4d, 0x73, 0x12, 0x40, 0x0a, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x48, 0x00, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x56, 0x0a, 0x18, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x16, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x40, 0x0a,
+	0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x6f, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x66, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x48, 0x00, 0x52, 0x0f,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x3d, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x75,
+	0x6e, 0x65, 0x18, 0x67, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x0e,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x12, 0x43,
+	0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x18, 0x68, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x48,
+	0x00, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x3d, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x69, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x48, 0x00, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x44, 0x0a, 0x12, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x72, 0x75, 0x6e, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x6a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6e,
+	0x48, 0x6f, 0x6f, 0x6b, 0x48, 0x00, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x75, 0x6e, 0x48, 0x6f, 0x6f, 0x6b, 0x12, 0x3d, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x6b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x3a, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x70, 0x79, 0x18, 0x6c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x70, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x70, 0x79, 0x12, 0x3a, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x6d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x69, 0x74, 0x48, 0x00,
+	0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x69, 0x74, 0x12,
+	0x43, 0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x67,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x6e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x67,
+	0x72, 0x61, 0x74, 0x65, 0x12, 0x43, 0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x6f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x48, 0x00, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x0d, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x70, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x61, 0x67, 0x48, 0x00, 0x52, 0x0c, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x61, 0x67, 0x12, 0x50, 0x0a, 0x16, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x18, 0x71, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4f, 0x70, 0x6c, 0x6f, 0x67, 0x48, 0x00, 0x52, 0x14,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4f,
+	0x70, 0x6c, 0x6f, 0x67, 0x12, 0x43, 0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x72, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x48, 0x00, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x6f, 0x70, 0x22,
+	0x69, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x16, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2b, 0x0a,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xff, 0x02, 0x0a, 0x0f, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x38,
+	0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x63, 0x6b, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x75, 0x6e,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x4d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x38, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3c, 0x0a, 0x12,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x16, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x69, 0x63, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x12, 0x20, 0x0a,
+	0x0c, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x5f, 0x62, 0x79, 0x5f, 0x6f, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x42, 0x79, 0x4f, 0x70, 0x12,
+	0x22, 0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x75, 0x65, 0x5f, 0x6d, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x44, 0x75,
+	0x65, 0x4d, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x69, 0x63, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x2e, 0x0a, 0x08, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x69, 0x63, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x08, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64,
+	0x22, 0x28, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x75,
+	0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x28, 0x0a, 0x0e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x22, 0x5d, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4e, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d,
+	0x65, 0x4d, 0x73, 0x22, 0x86, 0x03, 0x0a, 0x12, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x44,
+	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x6e,
+	0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x65, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x69, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x4c, 0x6f, 0x67,
+	0x12, 0x23, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x64, 0x69, 0x73, 0x6b, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x14, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x41, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x64, 0x61, 0x65,
+	0x6d, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xff, 0x01, 0x0a,
+	0x0d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x72, 0x75,
+	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x63, 0x70,
+	0x75, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x76,
+	0x67, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x61, 0x76, 0x67, 0x43, 0x70, 0x75, 0x43, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x24,
+	0x0a, 0x0e, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x70, 0x65, 0x61, 0x6b, 0x43, 0x70, 0x75, 0x43,
+	0x6f, 0x72, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x76, 0x67, 0x5f, 0x72, 0x73, 0x73, 0x5f,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x61, 0x76, 0x67,
+	0x52, 0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x65, 0x61, 0x6b,
+	0x5f, 0x72, 0x73, 0x73, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x70, 0x65, 0x61, 0x6b, 0x52, 0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x75,
+	0x0a, 0x09, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x27, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xef,
+	0x01, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x67, 0x72,
+	0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72,
+	0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x2e, 0x50,
+	0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x22, 0x53, 0x0a, 0x05, 0x50,
+	0x68, 0x61, 0x73, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x48, 0x41, 0x53, 0x45,
+	0x5f, 0x4d, 0x49, 0x47, 0x52, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x48,
+	0x41, 0x53, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x10, 0x02,
+	0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x03,
+	0x22, 0x61, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x6f, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x6f,
+	0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x49, 0x64, 0x22, 0x5b, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x61, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x49, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x61, 0x64, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x22, 0xb2, 0x01, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x13,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74,
+	0x74, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xb8, 0x01, 0x0a, 0x14, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4f, 0x70, 0x6c, 0x6f, 0x67, 0x12, 0x2d,
+	0x0a, 0x12, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x2f, 0x0a,
+	0x13, 0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x69, 0x6e, 0x63, 0x6f,
+	0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x40,
+	0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x6c,
+	0x6f, 0x67, 0x49, 0x6e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52,
+	0x0f, 0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x22, 0xf5, 0x01, 0x0a, 0x12, 0x4f, 0x70, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x63, 0x6f, 0x6e, 0x73,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2f, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x6c, 0x6f, 0x67,
+	0x49, 0x6e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x4b, 0x69,
+	0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x71, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a,
+	0x0c, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
+	0x20, 0x0a, 0x1c, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x55, 0x50, 0x5f, 0x57,
+	0x49, 0x54, 0x48, 0x4f, 0x55, 0x54, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10,
+	0x01, 0x12, 0x1d, 0x0a, 0x19, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48,
+	0x4f, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x10, 0x02,
+	0x12, 0x16, 0x0a, 0x12, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x55, 0x43, 0x4b, 0x5f, 0x50,
+	0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x22, 0x6f, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x3d, 0x0a, 0x10, 0x63, 0x6f,
+	0x70, 0x69, 0x65, 0x64, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x70, 0x69, 0x65, 0x64,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x0f, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x54, 0x0a, 0x0e, 0x43, 0x6f, 0x70,
+	0x69, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22,
+	0x9f, 0x01, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6e, 0x5f, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x77, 0x61,
+	0x70, 0x22, 0x35, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x7d, 0x0a, 0x10, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6e, 0x48, 0x6f, 0x6f, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x72, 0x65,
+	0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c,
+	0x6f, 0x67, 0x72, 0x65, 0x66, 0x12, 0x30, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f,
+	0x6f, 0x6b, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x60, 0x0a, 0x12, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x11, 0x0a,
+	0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
+	0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x50, 0x44,
+	0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xd6, 0x01, 0x0a, 0x0f, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a,
+	0x0e, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44,
+	0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x49, 0x4e, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03,
+	0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49,
+	0x4e, 0x47, 0x10, 0x07, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45,
+	0x44, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x53,
+	0x45, 0x52, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x06, 0x12, 0x12,
+	0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44,
+	0x10, 0x08, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x61, 0x72, 0x65, 0x74, 0x68, 0x67, 0x65, 0x6f, 0x72, 0x67, 0x65, 0x2f, 0x62, 0x61,
+	0x63, 0x6b, 0x72, 0x65, 0x73, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1033,54 +2683,89 @@ func file_v1_operations_proto_rawDescGZIP() []byte {
 	return file_v1_operations_proto_rawDescData
 }
 
-var file_v1_operations_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_v1_operations_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_v1_operations_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_v1_operations_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_v1_operations_proto_goTypes = []interface{}{
 	(OperationEventType)(0),        // 0: v1.OperationEventType
 	(OperationStatus)(0),           // 1: v1.OperationStatus
-	(*OperationList)(nil),          // 2: v1.OperationList
-	(*Operation)(nil),              // 3: v1.Operation
-	(*OperationEvent)(nil),         // 4: v1.OperationEvent
-	(*OperationBackup)(nil),        // 5: v1.OperationBackup
-	(*OperationIndexSnapshot)(nil), // 6: v1.OperationIndexSnapshot
-	(*OperationForget)(nil),        // 7: v1.OperationForget
-	(*OperationPrune)(nil),         // 8: v1.OperationPrune
-	(*OperationRestore)(nil),       // 9: v1.OperationRestore
-	(*OperationStats)(nil),         // 10: v1.OperationStats
-	(*OperationRunHook)(nil),       // 11: v1.OperationRunHook
-	(*BackupProgressEntry)(nil),    // 12: v1.BackupProgressEntry
-	(*BackupProgressError)(nil),    // 13: v1.BackupProgressError
-	(*ResticSnapshot)(nil),         // 14: v1.ResticSnapshot
-	(*RetentionPolicy)(nil),        // 15: v1.RetentionPolicy
-	(*RestoreProgressEntry)(nil),   // 16: v1.RestoreProgressEntry
-	(*RepoStats)(nil),              // 17: v1.RepoStats
-	(Hook_Condition)(0),            // 18: v1.Hook.Condition
+	(OperationMigrate_Phase)(0),    // 2: v1.OperationMigrate.Phase
+	(OplogInconsistency_Kind)(0),   // 3: v1.OplogInconsistency.Kind
+	(*OperationList)(nil),          // 4: v1.OperationList
+	(*Operation)(nil),              // 5: v1.Operation
+	(*OperationEvent)(nil),         // 6: v1.OperationEvent
+	(*OperationBackup)(nil),        // 7: v1.OperationBackup
+	(*SnapshotAnnotation)(nil),     // 8: v1.SnapshotAnnotation
+	(*OperationIndexSnapshot)(nil), // 9: v1.OperationIndexSnapshot
+	(*OperationForget)(nil),        // 10: v1.OperationForget
+	(*OperationPrune)(nil),         // 11: v1.OperationPrune
+	(*OperationCheck)(nil),         // 12: v1.OperationCheck
+	(*OperationNote)(nil),          // 13: v1.OperationNote
+	(*FailureDiagnostics)(nil),     // 14: v1.FailureDiagnostics
+	(*ResourceUsage)(nil),          // 15: v1.ResourceUsage
+	(*DiskUsage)(nil),              // 16: v1.DiskUsage
+	(*OperationInit)(nil),          // 17: v1.OperationInit
+	(*OperationMigrate)(nil),       // 18: v1.OperationMigrate
+	(*OperationRecover)(nil),       // 19: v1.OperationRecover
+	(*OperationTag)(nil),           // 20: v1.OperationTag
+	(*OperationRewrite)(nil),       // 21: v1.OperationRewrite
+	(*OperationVerifyOplog)(nil),   // 22: v1.OperationVerifyOplog
+	(*OplogInconsistency)(nil),     // 23: v1.OplogInconsistency
+	(*OperationCopy)(nil),          // 24: v1.OperationCopy
+	(*CopiedSnapshot)(nil),         // 25: v1.CopiedSnapshot
+	(*OperationRestore)(nil),       // 26: v1.OperationRestore
+	(*OperationStats)(nil),         // 27: v1.OperationStats
+	(*OperationRunHook)(nil),       // 28: v1.OperationRunHook
+	(*BackupProgressEntry)(nil),    // 29: v1.BackupProgressEntry
+	(*BackupProgressError)(nil),    // 30: v1.BackupProgressError
+	(*ResticSnapshot)(nil),         // 31: v1.ResticSnapshot
+	(*RetentionPolicy)(nil),        // 32: v1.RetentionPolicy
+	(*RestoreProgressEntry)(nil),   // 33: v1.RestoreProgressEntry
+	(*RepoStats)(nil),              // 34: v1.RepoStats
+	(Hook_Condition)(0),            // 35: v1.Hook.Condition
 }
 var file_v1_operations_proto_depIdxs = []int32{
-	3,  // 0: v1.OperationList.operations:type_name -> v1.Operation
+	5,  // 0: v1.OperationList.operations:type_name -> v1.Operation
 	1,  // 1: v1.Operation.status:type_name -> v1.OperationStatus
-	5,  // 2: v1.Operation.operation_backup:type_name -> v1.OperationBackup
-	6,  // 3: v1.Operation.operation_index_snapshot:type_name -> v1.OperationIndexSnapshot
-	7,  // 4: v1.Operation.operation_forget:type_name -> v1.OperationForget
-	8,  // 5: v1.Operation.operation_prune:type_name -> v1.OperationPrune
-	9,  // 6: v1.Operation.operation_restore:type_name -> v1.OperationRestore
-	10, // 7: v1.Operation.operation_stats:type_name -> v1.OperationStats
-	11, // 8: v1.Operation.operation_run_hook:type_name -> v1.OperationRunHook
-	0,  // 9: v1.OperationEvent.type:type_name -> v1.OperationEventType
-	3,  // 10: v1.OperationEvent.operation:type_name -> v1.Operation
-	12, // 11: v1.OperationBackup.last_status:type_name -> v1.BackupProgressEntry
-	13, // 12: v1.OperationBackup.errors:type_name -> v1.BackupProgressError
-	14, // 13: v1.OperationIndexSnapshot.snapshot:type_name -> v1.ResticSnapshot
-	14, // 14: v1.OperationForget.forget:type_name -> v1.ResticSnapshot
-	15, // 15: v1.OperationForget.policy:type_name -> v1.RetentionPolicy
-	16, // 16: v1.OperationRestore.status:type_name -> v1.RestoreProgressEntry
-	17, // 17: v1.OperationStats.stats:type_name -> v1.RepoStats
-	18, // 18: v1.OperationRunHook.condition:type_name -> v1.Hook.Condition
-	19, // [19:19] is the sub-list for method output_type
-	19, // [19:19] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	14, // 2: v1.Operation.failure_diagnostics:type_name -> v1.FailureDiagnostics
+	13, // 3: v1.Operation.note:type_name -> v1.OperationNote
+	15, // 4: v1.Operation.resource_usage:type_name -> v1.ResourceUsage
+	7,  // 5: v1.Operation.operation_backup:type_name -> v1.OperationBackup
+	9,  // 6: v1.Operation.operation_index_snapshot:type_name -> v1.OperationIndexSnapshot
+	10, // 7: v1.Operation.operation_forget:type_name -> v1.OperationForget
+	11, // 8: v1.Operation.operation_prune:type_name -> v1.OperationPrune
+	26, // 9: v1.Operation.operation_restore:type_name -> v1.OperationRestore
+	27, // 10: v1.Operation.operation_stats:type_name -> v1.OperationStats
+	28, // 11: v1.Operation.operation_run_hook:type_name -> v1.OperationRunHook
+	12, // 12: v1.Operation.operation_check:type_name -> v1.OperationCheck
+	24, // 13: v1.Operation.operation_copy:type_name -> v1.OperationCopy
+	17, // 14: v1.Operation.operation_init:type_name -> v1.OperationInit
+	18, // 15: v1.Operation.operation_migrate:type_name -> v1.OperationMigrate
+	19, // 16: v1.Operation.operation_recover:type_name -> v1.OperationRecover
+	20, // 17: v1.Operation.operation_tag:type_name -> v1.OperationTag
+	22, // 18: v1.Operation.operation_verify_oplog:type_name -> v1.OperationVerifyOplog
+	21, // 19: v1.Operation.operation_rewrite:type_name -> v1.OperationRewrite
+	0,  // 20: v1.OperationEvent.type:type_name -> v1.OperationEventType
+	5,  // 21: v1.OperationEvent.operation:type_name -> v1.Operation
+	29, // 22: v1.OperationBackup.last_status:type_name -> v1.BackupProgressEntry
+	30, // 23: v1.OperationBackup.errors:type_name -> v1.BackupProgressError
+	8,  // 24: v1.OperationBackup.annotations:type_name -> v1.SnapshotAnnotation
+	31, // 25: v1.OperationIndexSnapshot.snapshot:type_name -> v1.ResticSnapshot
+	31, // 26: v1.OperationForget.forget:type_name -> v1.ResticSnapshot
+	32, // 27: v1.OperationForget.policy:type_name -> v1.RetentionPolicy
+	31, // 28: v1.OperationForget.retained:type_name -> v1.ResticSnapshot
+	16, // 29: v1.FailureDiagnostics.disks:type_name -> v1.DiskUsage
+	2,  // 30: v1.OperationMigrate.phase:type_name -> v1.OperationMigrate.Phase
+	23, // 31: v1.OperationVerifyOplog.inconsistencies:type_name -> v1.OplogInconsistency
+	3,  // 32: v1.OplogInconsistency.kind:type_name -> v1.OplogInconsistency.Kind
+	25, // 33: v1.OperationCopy.copied_snapshots:type_name -> v1.CopiedSnapshot
+	33, // 34: v1.OperationRestore.status:type_name -> v1.RestoreProgressEntry
+	34, // 35: v1.OperationStats.stats:type_name -> v1.RepoStats
+	35, // 36: v1.OperationRunHook.condition:type_name -> v1.Hook.Condition
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_v1_operations_proto_init() }
@@ -1140,7 +2825,7 @@ func file_v1_operations_proto_init() {
 			}
 		}
 		file_v1_operations_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationIndexSnapshot); i {
+			switch v := v.(*SnapshotAnnotation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1152,7 +2837,7 @@ func file_v1_operations_proto_init() {
 			}
 		}
 		file_v1_operations_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationForget); i {
+			switch v := v.(*OperationIndexSnapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1164,7 +2849,7 @@ func file_v1_operations_proto_init() {
 			}
 		}
 		file_v1_operations_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationPrune); i {
+			switch v := v.(*OperationForget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1176,7 +2861,7 @@ func file_v1_operations_proto_init() {
 			}
 		}
 		file_v1_operations_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationRestore); i {
+			switch v := v.(*OperationPrune); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1188,7 +2873,7 @@ func file_v1_operations_proto_init() {
 			}
 		}
 		file_v1_operations_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationStats); i {
+			switch v := v.(*OperationCheck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1200,6 +2885,186 @@ func file_v1_operations_proto_init() {
 			}
 		}
 		file_v1_operations_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationNote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailureDiagnostics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiskUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationInit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationMigrate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationRecover); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationTag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationRewrite); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationVerifyOplog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OplogInconsistency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationCopy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopiedSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationRestore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_operations_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OperationRunHook); i {
 			case 0:
 				return &v.state
@@ -1220,14 +3085,22 @@ func file_v1_operations_proto_init() {
 		(*Operation_OperationRestore)(nil),
 		(*Operation_OperationStats)(nil),
 		(*Operation_OperationRunHook)(nil),
+		(*Operation_OperationCheck)(nil),
+		(*Operation_OperationCopy)(nil),
+		(*Operation_OperationInit)(nil),
+		(*Operation_OperationMigrate)(nil),
+		(*Operation_OperationRecover)(nil),
+		(*Operation_OperationTag)(nil),
+		(*Operation_OperationVerifyOplog)(nil),
+		(*Operation_OperationRewrite)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_operations_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   10,
+			NumEnums:      4,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
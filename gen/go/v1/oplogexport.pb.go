@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: v1/oplogexport.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OplogArchive is the content of an operation history export, it is written as gzip compressed JSON.
+type OplogArchive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version      int32        `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                                 // version of the archive format, incremented on incompatible changes.
+	ExportedAtMs int64        `protobuf:"varint,2,opt,name=exported_at_ms,json=exportedAtMs,proto3" json:"exported_at_ms,omitempty"` // unix time in milliseconds the archive was exported at.
+	Instance     string       `protobuf:"bytes,3,opt,name=instance,proto3" json:"instance,omitempty"`                                // instance the operations were run by.
+	Config       *Config      `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                                    // the config at the time of the export, secrets are redacted.
+	Operations   []*Operation `protobuf:"bytes,5,rep,name=operations,proto3" json:"operations,omitempty"`                            // every operation in the log, in the order they were added.
+}
+
+func (x *OplogArchive) Reset() {
+	*x = OplogArchive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_oplogexport_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OplogArchive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OplogArchive) ProtoMessage() {}
+
+func (x *OplogArchive) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_oplogexport_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OplogArchive.ProtoReflect.Descriptor instead.
+func (*OplogArchive) Descriptor() ([]byte, []int) {
+	return file_v1_oplogexport_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OplogArchive) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OplogArchive) GetExportedAtMs() int64 {
+	if x != nil {
+		return x.ExportedAtMs
+	}
+	return 0
+}
+
+func (x *OplogArchive) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *OplogArchive) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *OplogArchive) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+var File_v1_oplogexport_proto protoreflect.FileDescriptor
+
+var file_v1_oplogexport_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x0f, 0x76, 0x31, 0x2f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x76, 0x31, 0x2f,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xbd, 0x01, 0x0a, 0x0c, 0x4f, 0x70, 0x6c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0e, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x4d,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x0a,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x2d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67,
+	0x61, 0x72, 0x65, 0x74, 0x68, 0x67, 0x65, 0x6f, 0x72, 0x67, 0x65, 0x2f, 0x62, 0x61, 0x63, 0x6b,
+	0x72, 0x65, 0x73, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v1_oplogexport_proto_rawDescOnce sync.Once
+	file_v1_oplogexport_proto_rawDescData = file_v1_oplogexport_proto_rawDesc
+)
+
+func file_v1_oplogexport_proto_rawDescGZIP() []byte {
+	file_v1_oplogexport_proto_rawDescOnce.Do(func() {
+		file_v1_oplogexport_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_oplogexport_proto_rawDescData)
+	})
+	return file_v1_oplogexport_proto_rawDescData
+}
+
+var file_v1_oplogexport_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_v1_oplogexport_proto_goTypes = []interface{}{
+	(*OplogArchive)(nil), // 0: v1.OplogArchive
+	(*Config)(nil),       // 1: v1.Config
+	(*Operation)(nil),    // 2: v1.Operation
+}
+var file_v1_oplogexport_proto_depIdxs = []int32{
+	1, // 0: v1.OplogArchive.config:type_name -> v1.Config
+	2, // 1: v1.OplogArchive.operations:type_name -> v1.Operation
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_v1_oplogexport_proto_init() }
+func file_v1_oplogexport_proto_init() {
+	if File_v1_oplogexport_proto != nil {
+		return
+	}
+	file_v1_config_proto_init()
+	file_v1_operations_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_v1_oplogexport_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OplogArchive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v1_oplogexport_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_v1_oplogexport_proto_goTypes,
+		DependencyIndexes: file_v1_oplogexport_proto_depIdxs,
+		MessageInfos:      file_v1_oplogexport_proto_msgTypes,
+	}.Build()
+	File_v1_oplogexport_proto = out.File
+	file_v1_oplogexport_proto_rawDesc = nil
+	file_v1_oplogexport_proto_goTypes = nil
+	file_v1_oplogexport_proto_depIdxs = nil
+}
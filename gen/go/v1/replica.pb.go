@@ -0,0 +1,278 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: v1/replica.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConfigReplica is what an instance knows about its repos and plans, pushed off-site so that a replacement instance can
+// be bootstrapped from it.
+type ConfigReplica struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Config     *Config      `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Operations []*Operation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"` // the most recent operations of each plan, log refs are cleared as logs are not replicated.
+}
+
+func (x *ConfigReplica) Reset() {
+	*x = ConfigReplica{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_replica_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigReplica) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigReplica) ProtoMessage() {}
+
+func (x *ConfigReplica) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_replica_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigReplica.ProtoReflect.Descriptor instead.
+func (*ConfigReplica) Descriptor() ([]byte, []int) {
+	return file_v1_replica_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigReplica) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ConfigReplica) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+// SealedReplica is a ConfigReplica encrypted with the passphrase from the config_replication settings of its instance.
+type SealedReplica struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version    int32  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                           // version of the encryption scheme.
+	Instance   string `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`                          // ID of the instance the replica was created by.
+	UnixTimeMs int64  `protobuf:"varint,3,opt,name=unix_time_ms,json=unixTimeMs,proto3" json:"unix_time_ms,omitempty"` // time the replica was created at.
+	Salt       []byte `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`                                  // salt of the key derived from the passphrase.
+	Nonce      []byte `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Ciphertext []byte `protobuf:"bytes,6,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"` // AES-256-GCM encrypted ConfigReplica, the instance ID is authenticated as additional data.
+}
+
+func (x *SealedReplica) Reset() {
+	*x = SealedReplica{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_replica_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SealedReplica) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealedReplica) ProtoMessage() {}
+
+func (x *SealedReplica) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_replica_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealedReplica.ProtoReflect.Descriptor instead.
+func (*SealedReplica) Descriptor() ([]byte, []int) {
+	return file_v1_replica_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SealedReplica) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SealedReplica) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *SealedReplica) GetUnixTimeMs() int64 {
+	if x != nil {
+		return x.UnixTimeMs
+	}
+	return 0
+}
+
+func (x *SealedReplica) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *SealedReplica) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *SealedReplica) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+var File_v1_replica_proto protoreflect.FileDescriptor
+
+var file_v1_replica_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x0f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x62, 0x0a, 0x0d,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x22, 0x0a,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x2d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0xb1, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x6e, 0x69, 0x78,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61,
+	0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e,
+	0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72,
+	0x74, 0x65, 0x78, 0x74, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x72, 0x65, 0x74, 0x68, 0x67, 0x65, 0x6f, 0x72, 0x67, 0x65, 0x2f,
+	0x62, 0x61, 0x63, 0x6b, 0x72, 0x65, 0x73, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v1_replica_proto_rawDescOnce sync.Once
+	file_v1_replica_proto_rawDescData = file_v1_replica_proto_rawDesc
+)
+
+func file_v1_replica_proto_rawDescGZIP() []byte {
+	file_v1_replica_proto_rawDescOnce.Do(func() {
+		file_v1_replica_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_replica_proto_rawDescData)
+	})
+	return file_v1_replica_proto_rawDescData
+}
+
+var file_v1_replica_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_v1_replica_proto_goTypes = []interface{}{
+	(*ConfigReplica)(nil), // 0: v1.ConfigReplica
+	(*SealedReplica)(nil), // 1: v1.SealedReplica
+	(*Config)(nil),        // 2: v1.Config
+	(*Operation)(nil),     // 3: v1.Operation
+}
+var file_v1_replica_proto_depIdxs = []int32{
+	2, // 0: v1.ConfigReplica.config:type_name -> v1.Config
+	3, // 1: v1.ConfigReplica.operations:type_name -> v1.Operation
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_v1_replica_proto_init() }
+func file_v1_replica_proto_init() {
+	if File_v1_replica_proto != nil {
+		return
+	}
+	file_v1_config_proto_init()
+	file_v1_operations_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_v1_replica_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigReplica); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_replica_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SealedReplica); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v1_replica_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_v1_replica_proto_goTypes,
+		DependencyIndexes: file_v1_replica_proto_depIdxs,
+		MessageInfos:      file_v1_replica_proto_msgTypes,
+	}.Build()
+	File_v1_replica_proto = out.File
+	file_v1_replica_proto_rawDesc = nil
+	file_v1_replica_proto_goTypes = nil
+	file_v1_replica_proto_depIdxs = nil
+}
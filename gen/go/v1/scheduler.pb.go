@@ -0,0 +1,441 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: v1/scheduler.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SchedulerDecision_Kind int32
+
+const (
+	SchedulerDecision_KIND_UNKNOWN       SchedulerDecision_Kind = 0
+	SchedulerDecision_KIND_SCHEDULED     SchedulerDecision_Kind = 1 // the task was queued to run at run_at_ms.
+	SchedulerDecision_KIND_STARTED       SchedulerDecision_Kind = 2 // the task started running, the reason explains a late start e.g. while the repo was busy.
+	SchedulerDecision_KIND_DEFERRED      SchedulerDecision_Kind = 3 // the task was due outside of its plan's backup window, it was queued again at run_at_ms.
+	SchedulerDecision_KIND_SKIPPED       SchedulerDecision_Kind = 4 // the task was due but skipped e.g. because its plan's run conditions were not met.
+	SchedulerDecision_KIND_NOT_SCHEDULED SchedulerDecision_Kind = 5 // the plan or repo is disabled or paused, its tasks are not queued.
+	SchedulerDecision_KIND_CANCELLED     SchedulerDecision_Kind = 6 // the queued task was cancelled by a config change, the tasks of the new config are queued instead.
+	SchedulerDecision_KIND_STOPPED       SchedulerDecision_Kind = 7 // the running task was stopped because its plan's backup window closed.
+)
+
+// Enum value maps for SchedulerDecision_Kind.
+var (
+	SchedulerDecision_Kind_name = map[int32]string{
+		0: "KIND_UNKNOWN",
+		1: "KIND_SCHEDULED",
+		2: "KIND_STARTED",
+		3: "KIND_DEFERRED",
+		4: "KIND_SKIPPED",
+		5: "KIND_NOT_SCHEDULED",
+		6: "KIND_CANCELLED",
+		7: "KIND_STOPPED",
+	}
+	SchedulerDecision_Kind_value = map[string]int32{
+		"KIND_UNKNOWN":       0,
+		"KIND_SCHEDULED":     1,
+		"KIND_STARTED":       2,
+		"KIND_DEFERRED":      3,
+		"KIND_SKIPPED":       4,
+		"KIND_NOT_SCHEDULED": 5,
+		"KIND_CANCELLED":     6,
+		"KIND_STOPPED":       7,
+	}
+)
+
+func (x SchedulerDecision_Kind) Enum() *SchedulerDecision_Kind {
+	p := new(SchedulerDecision_Kind)
+	*p = x
+	return p
+}
+
+func (x SchedulerDecision_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SchedulerDecision_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_scheduler_proto_enumTypes[0].Descriptor()
+}
+
+func (SchedulerDecision_Kind) Type() protoreflect.EnumType {
+	return &file_v1_scheduler_proto_enumTypes[0]
+}
+
+func (x SchedulerDecision_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SchedulerDecision_Kind.Descriptor instead.
+func (SchedulerDecision_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_v1_scheduler_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// SchedulerDecision records why the scheduler did or didn't run a task at a given time.
+type SchedulerDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnixTimeMs int64                  `protobuf:"varint,1,opt,name=unix_time_ms,json=unixTimeMs,proto3" json:"unix_time_ms,omitempty"` // unix time in milliseconds the decision was made at.
+	Kind       SchedulerDecision_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=v1.SchedulerDecision_Kind" json:"kind,omitempty"`
+	Task       string                 `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`                         // optional, the name of the task the decision is about.
+	PlanId     string                 `protobuf:"bytes,4,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`       // optional, the plan the decision is about.
+	RepoId     string                 `protobuf:"bytes,5,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`       // optional, the repo the decision is about.
+	RunAtMs    int64                  `protobuf:"varint,6,opt,name=run_at_ms,json=runAtMs,proto3" json:"run_at_ms,omitempty"` // unix time in milliseconds the task is queued to run at, set for scheduled and deferred tasks.
+	Reason     string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`                     // human readable explanation of the decision e.g. "jitter of 4m12s applied".
+}
+
+func (x *SchedulerDecision) Reset() {
+	*x = SchedulerDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_scheduler_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchedulerDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulerDecision) ProtoMessage() {}
+
+func (x *SchedulerDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_scheduler_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulerDecision.ProtoReflect.Descriptor instead.
+func (*SchedulerDecision) Descriptor() ([]byte, []int) {
+	return file_v1_scheduler_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SchedulerDecision) GetUnixTimeMs() int64 {
+	if x != nil {
+		return x.UnixTimeMs
+	}
+	return 0
+}
+
+func (x *SchedulerDecision) GetKind() SchedulerDecision_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return SchedulerDecision_KIND_UNKNOWN
+}
+
+func (x *SchedulerDecision) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *SchedulerDecision) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *SchedulerDecision) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *SchedulerDecision) GetRunAtMs() int64 {
+	if x != nil {
+		return x.RunAtMs
+	}
+	return 0
+}
+
+func (x *SchedulerDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SchedulerDecisionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlanId  string `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`     // optional, only decisions about the plan are returned.
+	RepoId  string `protobuf:"bytes,2,opt,name=repo_id,json=repoId,proto3" json:"repo_id,omitempty"`     // optional, only decisions about the repo are returned.
+	SinceMs int64  `protobuf:"varint,3,opt,name=since_ms,json=sinceMs,proto3" json:"since_ms,omitempty"` // optional, only decisions made at or after this unix time in milliseconds are returned.
+	Limit   int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                    // optional, the maximum number of decisions to return.
+}
+
+func (x *SchedulerDecisionsRequest) Reset() {
+	*x = SchedulerDecisionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_scheduler_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchedulerDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulerDecisionsRequest) ProtoMessage() {}
+
+func (x *SchedulerDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_scheduler_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulerDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*SchedulerDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_scheduler_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SchedulerDecisionsRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *SchedulerDecisionsRequest) GetRepoId() string {
+	if x != nil {
+		return x.RepoId
+	}
+	return ""
+}
+
+func (x *SchedulerDecisionsRequest) GetSinceMs() int64 {
+	if x != nil {
+		return x.SinceMs
+	}
+	return 0
+}
+
+func (x *SchedulerDecisionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SchedulerDecisionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Decisions []*SchedulerDecision `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"` // newest first.
+}
+
+func (x *SchedulerDecisionList) Reset() {
+	*x = SchedulerDecisionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_scheduler_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchedulerDecisionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulerDecisionList) ProtoMessage() {}
+
+func (x *SchedulerDecisionList) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_scheduler_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulerDecisionList.ProtoReflect.Descriptor instead.
+func (*SchedulerDecisionList) Descriptor() ([]byte, []int) {
+	return file_v1_scheduler_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SchedulerDecisionList) GetDecisions() []*SchedulerDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+var File_v1_scheduler_proto protoreflect.FileDescriptor
+
+var file_v1_scheduler_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0x83, 0x03, 0x0a, 0x11, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20,
+	0x0a, 0x0c, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73,
+	0x12, 0x2e, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x44, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x73, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x09, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x74,
+	0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x41, 0x74,
+	0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xa1, 0x01, 0x0a, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x43,
+	0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x4b,
+	0x49, 0x4e, 0x44, 0x5f, 0x44, 0x45, 0x46, 0x45, 0x52, 0x52, 0x45, 0x44, 0x10, 0x03, 0x12, 0x10,
+	0x0a, 0x0c, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x04,
+	0x12, 0x16, 0x0a, 0x12, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x43, 0x48,
+	0x45, 0x44, 0x55, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c,
+	0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x07, 0x22, 0x7e,
+	0x0a, 0x19, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x44, 0x65, 0x63, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c,
+	0x61, 0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4c,
+	0x0a, 0x15, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x44, 0x65, 0x63, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x2c, 0x5a, 0x2a,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x72, 0x65, 0x74,
+	0x68, 0x67, 0x65, 0x6f, 0x72, 0x67, 0x65, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x72, 0x65, 0x73, 0x74,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_v1_scheduler_proto_rawDescOnce sync.Once
+	file_v1_scheduler_proto_rawDescData = file_v1_scheduler_proto_rawDesc
+)
+
+func file_v1_scheduler_proto_rawDescGZIP() []byte {
+	file_v1_scheduler_proto_rawDescOnce.Do(func() {
+		file_v1_scheduler_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_scheduler_proto_rawDescData)
+	})
+	return file_v1_scheduler_proto_rawDescData
+}
+
+var file_v1_scheduler_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v1_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_v1_scheduler_proto_goTypes = []interface{}{
+	(SchedulerDecision_Kind)(0),       // 0: v1.SchedulerDecision.Kind
+	(*SchedulerDecision)(nil),         // 1: v1.SchedulerDecision
+	(*SchedulerDecisionsRequest)(nil), // 2: v1.SchedulerDecisionsRequest
+	(*SchedulerDecisionList)(nil),     // 3: v1.SchedulerDecisionList
+}
+var file_v1_scheduler_proto_depIdxs = []int32{
+	0, // 0: v1.SchedulerDecision.kind:type_name -> v1.SchedulerDecision.Kind
+	1, // 1: v1.SchedulerDecisionList.decisions:type_name -> v1.SchedulerDecision
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_v1_scheduler_proto_init() }
+func file_v1_scheduler_proto_init() {
+	if File_v1_scheduler_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v1_scheduler_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchedulerDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_scheduler_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchedulerDecisionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_scheduler_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchedulerDecisionList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v1_scheduler_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_v1_scheduler_proto_goTypes,
+		DependencyIndexes: file_v1_scheduler_proto_depIdxs,
+		EnumInfos:         file_v1_scheduler_proto_enumTypes,
+		MessageInfos:      file_v1_scheduler_proto_msgTypes,
+	}.Build()
+	File_v1_scheduler_proto = out.File
+	file_v1_scheduler_proto_rawDesc = nil
+	file_v1_scheduler_proto_goTypes = nil
+	file_v1_scheduler_proto_depIdxs = nil
+}
@@ -21,25 +21,76 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Backrest_GetConfig_FullMethodName          = "/v1.Backrest/GetConfig"
-	Backrest_SetConfig_FullMethodName          = "/v1.Backrest/SetConfig"
-	Backrest_AddRepo_FullMethodName            = "/v1.Backrest/AddRepo"
-	Backrest_GetOperationEvents_FullMethodName = "/v1.Backrest/GetOperationEvents"
-	Backrest_GetOperations_FullMethodName      = "/v1.Backrest/GetOperations"
-	Backrest_ListSnapshots_FullMethodName      = "/v1.Backrest/ListSnapshots"
-	Backrest_ListSnapshotFiles_FullMethodName  = "/v1.Backrest/ListSnapshotFiles"
-	Backrest_IndexSnapshots_FullMethodName     = "/v1.Backrest/IndexSnapshots"
-	Backrest_Backup_FullMethodName             = "/v1.Backrest/Backup"
-	Backrest_Prune_FullMethodName              = "/v1.Backrest/Prune"
-	Backrest_Forget_FullMethodName             = "/v1.Backrest/Forget"
-	Backrest_Restore_FullMethodName            = "/v1.Backrest/Restore"
-	Backrest_Unlock_FullMethodName             = "/v1.Backrest/Unlock"
-	Backrest_Stats_FullMethodName              = "/v1.Backrest/Stats"
-	Backrest_Cancel_FullMethodName             = "/v1.Backrest/Cancel"
-	Backrest_GetLogs_FullMethodName            = "/v1.Backrest/GetLogs"
-	Backrest_GetDownloadURL_FullMethodName     = "/v1.Backrest/GetDownloadURL"
-	Backrest_ClearHistory_FullMethodName       = "/v1.Backrest/ClearHistory"
-	Backrest_PathAutocomplete_FullMethodName   = "/v1.Backrest/PathAutocomplete"
+	Backrest_GetConfig_FullMethodName                  = "/v1.Backrest/GetConfig"
+	Backrest_SetConfig_FullMethodName                  = "/v1.Backrest/SetConfig"
+	Backrest_AddRepo_FullMethodName                    = "/v1.Backrest/AddRepo"
+	Backrest_GetOperationEvents_FullMethodName         = "/v1.Backrest/GetOperationEvents"
+	Backrest_SubscribeOperations_FullMethodName        = "/v1.Backrest/SubscribeOperations"
+	Backrest_GetOperations_FullMethodName              = "/v1.Backrest/GetOperations"
+	Backrest_ListSnapshots_FullMethodName              = "/v1.Backrest/ListSnapshots"
+	Backrest_ListSnapshotFiles_FullMethodName          = "/v1.Backrest/ListSnapshotFiles"
+	Backrest_ListRestorePoints_FullMethodName          = "/v1.Backrest/ListRestorePoints"
+	Backrest_DiffSnapshots_FullMethodName              = "/v1.Backrest/DiffSnapshots"
+	Backrest_SearchSnapshots_FullMethodName            = "/v1.Backrest/SearchSnapshots"
+	Backrest_ListFileVersions_FullMethodName           = "/v1.Backrest/ListFileVersions"
+	Backrest_TagSnapshots_FullMethodName               = "/v1.Backrest/TagSnapshots"
+	Backrest_PreviewRewriteSnapshots_FullMethodName    = "/v1.Backrest/PreviewRewriteSnapshots"
+	Backrest_RewriteSnapshots_FullMethodName           = "/v1.Backrest/RewriteSnapshots"
+	Backrest_GetSnapshotStats_FullMethodName           = "/v1.Backrest/GetSnapshotStats"
+	Backrest_IndexSnapshots_FullMethodName             = "/v1.Backrest/IndexSnapshots"
+	Backrest_Backup_FullMethodName                     = "/v1.Backrest/Backup"
+	Backrest_BackupWithAnnotations_FullMethodName      = "/v1.Backrest/BackupWithAnnotations"
+	Backrest_PreviewBackup_FullMethodName              = "/v1.Backrest/PreviewBackup"
+	Backrest_Prune_FullMethodName                      = "/v1.Backrest/Prune"
+	Backrest_Forget_FullMethodName                     = "/v1.Backrest/Forget"
+	Backrest_Check_FullMethodName                      = "/v1.Backrest/Check"
+	Backrest_InitRepo_FullMethodName                   = "/v1.Backrest/InitRepo"
+	Backrest_MigrateRepo_FullMethodName                = "/v1.Backrest/MigrateRepo"
+	Backrest_GetRepoUpgrade_FullMethodName             = "/v1.Backrest/GetRepoUpgrade"
+	Backrest_UpgradeRepo_FullMethodName                = "/v1.Backrest/UpgradeRepo"
+	Backrest_RecoverRepo_FullMethodName                = "/v1.Backrest/RecoverRepo"
+	Backrest_Restore_FullMethodName                    = "/v1.Backrest/Restore"
+	Backrest_GetRestoreConflicts_FullMethodName        = "/v1.Backrest/GetRestoreConflicts"
+	Backrest_PreviewRestore_FullMethodName             = "/v1.Backrest/PreviewRestore"
+	Backrest_Unlock_FullMethodName                     = "/v1.Backrest/Unlock"
+	Backrest_MountRepo_FullMethodName                  = "/v1.Backrest/MountRepo"
+	Backrest_UnmountRepo_FullMethodName                = "/v1.Backrest/UnmountRepo"
+	Backrest_GetMounts_FullMethodName                  = "/v1.Backrest/GetMounts"
+	Backrest_ListRepoKeys_FullMethodName               = "/v1.Backrest/ListRepoKeys"
+	Backrest_AddRepoKey_FullMethodName                 = "/v1.Backrest/AddRepoKey"
+	Backrest_RemoveRepoKey_FullMethodName              = "/v1.Backrest/RemoveRepoKey"
+	Backrest_ChangeRepoPassword_FullMethodName         = "/v1.Backrest/ChangeRepoPassword"
+	Backrest_GetRepoCacheUsage_FullMethodName          = "/v1.Backrest/GetRepoCacheUsage"
+	Backrest_Stats_FullMethodName                      = "/v1.Backrest/Stats"
+	Backrest_GetRepoStatsHistory_FullMethodName        = "/v1.Backrest/GetRepoStatsHistory"
+	Backrest_GetStats_FullMethodName                   = "/v1.Backrest/GetStats"
+	Backrest_Cancel_FullMethodName                     = "/v1.Backrest/Cancel"
+	Backrest_GetLogs_FullMethodName                    = "/v1.Backrest/GetLogs"
+	Backrest_GetResticInfo_FullMethodName              = "/v1.Backrest/GetResticInfo"
+	Backrest_GetDownloadURL_FullMethodName             = "/v1.Backrest/GetDownloadURL"
+	Backrest_GetRestoreDownloadURL_FullMethodName      = "/v1.Backrest/GetRestoreDownloadURL"
+	Backrest_CreateShareLink_FullMethodName            = "/v1.Backrest/CreateShareLink"
+	Backrest_GetShareLinks_FullMethodName              = "/v1.Backrest/GetShareLinks"
+	Backrest_RevokeShareLink_FullMethodName            = "/v1.Backrest/RevokeShareLink"
+	Backrest_GetSnapshotFileDownloadURL_FullMethodName = "/v1.Backrest/GetSnapshotFileDownloadURL"
+	Backrest_GetSnapshotArchiveURL_FullMethodName      = "/v1.Backrest/GetSnapshotArchiveURL"
+	Backrest_ExportOperations_FullMethodName           = "/v1.Backrest/ExportOperations"
+	Backrest_ImportOperations_FullMethodName           = "/v1.Backrest/ImportOperations"
+	Backrest_AnnotateOperation_FullMethodName          = "/v1.Backrest/AnnotateOperation"
+	Backrest_ClearHistory_FullMethodName               = "/v1.Backrest/ClearHistory"
+	Backrest_PathAutocomplete_FullMethodName           = "/v1.Backrest/PathAutocomplete"
+	Backrest_DescribeCron_FullMethodName               = "/v1.Backrest/DescribeCron"
+	Backrest_ValidateCron_FullMethodName               = "/v1.Backrest/ValidateCron"
+	Backrest_SetPaused_FullMethodName                  = "/v1.Backrest/SetPaused"
+	Backrest_SetBandwidthLimit_FullMethodName          = "/v1.Backrest/SetBandwidthLimit"
+	Backrest_PutReplica_FullMethodName                 = "/v1.Backrest/PutReplica"
+	Backrest_Search_FullMethodName                     = "/v1.Backrest/Search"
+	Backrest_GetAlerts_FullMethodName                  = "/v1.Backrest/GetAlerts"
+	Backrest_AcknowledgeAlert_FullMethodName           = "/v1.Backrest/AcknowledgeAlert"
+	Backrest_SnoozeAlert_FullMethodName                = "/v1.Backrest/SnoozeAlert"
+	Backrest_GetHookDeliveries_FullMethodName          = "/v1.Backrest/GetHookDeliveries"
+	Backrest_RetryHookDelivery_FullMethodName          = "/v1.Backrest/RetryHookDelivery"
+	Backrest_GetSchedulerDecisions_FullMethodName      = "/v1.Backrest/GetSchedulerDecisions"
 )
 
 // BackrestClient is the client API for Backrest service.
@@ -49,34 +100,166 @@ type BackrestClient interface {
 	GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config, error)
 	SetConfig(ctx context.Context, in *Config, opts ...grpc.CallOption) (*Config, error)
 	AddRepo(ctx context.Context, in *Repo, opts ...grpc.CallOption) (*Config, error)
+	// GetOperationEvents streams every operation event the caller may see, see SubscribeOperations.
 	GetOperationEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Backrest_GetOperationEventsClient, error)
+	// SubscribeOperations streams the events of operations as they are created, updated and deleted, optionally only those
+	// of a plan or repo. The stream is closed if the client falls behind, clients reconnect and fetch the operations they
+	// may have missed with GetOperations.
+	SubscribeOperations(ctx context.Context, in *SubscribeOperationsRequest, opts ...grpc.CallOption) (Backrest_SubscribeOperationsClient, error)
 	GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*OperationList, error)
 	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ResticSnapshotList, error)
 	ListSnapshotFiles(ctx context.Context, in *ListSnapshotFilesRequest, opts ...grpc.CallOption) (*ListSnapshotFilesResponse, error)
+	// ListRestorePoints collapses a repo's snapshots into one restore point per plan and calendar day or week, newest first.
+	ListRestorePoints(ctx context.Context, in *ListRestorePointsRequest, opts ...grpc.CallOption) (*RestorePointList, error)
+	// DiffSnapshots lists the files added, removed and modified between two snapshots, by default between a snapshot and
+	// its parent i.e. what changed in the backup that created it.
+	DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...grpc.CallOption) (*SnapshotDiff, error)
+	// SearchSnapshots finds files whose name matches a glob pattern in a repo's snapshots, matches are streamed a
+	// snapshot at a time as they are found.
+	SearchSnapshots(ctx context.Context, in *SearchSnapshotsRequest, opts ...grpc.CallOption) (Backrest_SearchSnapshotsClient, error)
+	// ListFileVersions lists the versions of a file in a plan's snapshots newest first, so that the version to restore can
+	// be picked from the file's history.
+	ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*FileVersionList, error)
+	// TagSnapshots schedules a restic tag adding and removing tags of snapshots, it returns the ID of the scheduled operation.
+	// restic saves each changed snapshot under a new ID. The plan and instance tags backrest selects snapshots by can't be
+	// changed.
+	TagSnapshots(ctx context.Context, in *TagSnapshotsRequest, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// PreviewRewriteSnapshots reports the snapshots a rewrite would change by running restic rewrite --dry-run, the preview's
+	// digest must be passed back to RewriteSnapshots to confirm the rewrite.
+	PreviewRewriteSnapshots(ctx context.Context, in *RewriteSnapshotsRequest, opts ...grpc.CallOption) (*RewritePreview, error)
+	// RewriteSnapshots schedules a restic rewrite removing the excluded files from snapshots, it returns the ID of the
+	// scheduled operation. restic saves each changed snapshot under a new ID, the originals are kept unless forget is set.
+	RewriteSnapshots(ctx context.Context, in *RewriteSnapshotsRequest, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// GetSnapshotStats returns statistics for a snapshot, computed on the first request and cached afterwards.
+	GetSnapshotStats(ctx context.Context, in *GetSnapshotStatsRequest, opts ...grpc.CallOption) (*SnapshotStats, error)
 	// IndexSnapshots triggers indexin. It accepts a repo id and returns empty if the task is enqueued.
 	IndexSnapshots(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
 	Backup(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	// Prune schedules a prune operation. It accepts a plan id and returns empty if the task is enqueued.
-	Prune(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
-	Forget(ctx context.Context, in *ForgetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// BackupWithAnnotations runs a plan's backup like Backup and attaches the annotations to its snapshot as tags, e.g. the
+	// version of the application being released, so that the snapshot can be found by them later.
+	BackupWithAnnotations(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PreviewBackup runs a plan's backup with restic backup --dry-run and reports what it would add to the repo, nothing
+	// is written to the repo. It accepts a plan id.
+	PreviewBackup(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*BackupPreview, error)
+	// Prune schedules a prune operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Prune(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// Forget schedules a forget operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Forget(ctx context.Context, in *ForgetRequest, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// Check schedules a check operation. It accepts a repo id and returns the ID of the scheduled operation.
+	Check(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// InitRepo schedules a restic init of a repo already in the config, e.g. one whose storage was recreated. It accepts a
+	// repo id and returns the ID of the scheduled operation. Repos added with AddRepo are initialized when they are added.
+	InitRepo(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// MigrateRepo schedules a restic migrate of the repo, by default upgrading it to the v2 repository format. It returns
+	// the ID of the scheduled operation.
+	MigrateRepo(ctx context.Context, in *MigrateRepoRequest, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// GetRepoUpgrade checks whether the repo uses an old format version or holds data stored uncompressed and describes
+	// the benefits and risks of upgrading it.
+	GetRepoUpgrade(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*RepoUpgradeAssessment, error)
+	// UpgradeRepo schedules an upgrade of the repo to the v2 format, optionally followed by repacking the data stored
+	// uncompressed. It returns the ID of the scheduled operation, an interrupted upgrade is resumed when backrest next starts.
+	UpgradeRepo(ctx context.Context, in *UpgradeRepoRequest, opts ...grpc.CallOption) (*types.Int64Value, error)
+	// RecoverRepo schedules a restic recover of the repo, which saves the data of forgotten snapshots that was not pruned
+	// yet into a new snapshot. It returns the ID of the recover operation.
+	RecoverRepo(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error)
 	// Restore schedules a restore operation.
 	Restore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetRestoreConflicts reports the local files an in place restore would overwrite, the report's digest must be passed
+	// back to Restore to confirm the in place restore.
+	GetRestoreConflicts(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestoreConflictReport, error)
+	// PreviewRestore lists the files a restore would write and checks that the target can be written before the restore
+	// is scheduled, nothing is restored.
+	PreviewRestore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestorePreview, error)
 	// Unlock synchronously attempts to unlock the repo. Will block if other operations are in progress.
 	Unlock(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// MountRepo mounts the repo's snapshots as a read only filesystem with restic mount, under backrest's data directory.
+	// The repo is unmounted once it wasn't requested for its idle timeout, mounting a mounted repo restarts the timeout.
+	MountRepo(ctx context.Context, in *MountRepoRequest, opts ...grpc.CallOption) (*RepoMount, error)
+	// UnmountRepo unmounts a repo mounted with MountRepo, it accepts a repo id.
+	UnmountRepo(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetMounts returns the mounted repos the caller may access.
+	GetMounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RepoMountList, error)
+	// ListRepoKeys lists the keys of a repo, it accepts a repo id.
+	ListRepoKeys(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*RepoKeyList, error)
+	// AddRepoKey adds a key with another password to a repo e.g. for another machine, the repo's password in the config
+	// is unchanged.
+	AddRepoKey(ctx context.Context, in *AddRepoKeyRequest, opts ...grpc.CallOption) (*RepoKey, error)
+	// RemoveRepoKey removes a key from a repo, the key holding the repo's password in the config can't be removed.
+	RemoveRepoKey(ctx context.Context, in *RemoveRepoKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ChangeRepoPassword replaces the key holding the repo's password in the config with a key holding the new password
+	// and stores the new password in the config. The old key is only removed once the config is updated.
+	ChangeRepoPassword(ctx context.Context, in *ChangeRepoPasswordRequest, opts ...grpc.CallOption) (*Config, error)
+	// GetRepoCacheUsage returns the disk usage of the repo's local restic cache, it accepts a repo id.
+	GetRepoCacheUsage(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*RepoCacheUsage, error)
 	// Stats runs 'restic stats` on the repository and appends the results to the operations log.
 	Stats(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetRepoStatsHistory returns the stats collected for a repo over time, oldest first, e.g. to graph the repo's growth.
+	GetRepoStatsHistory(ctx context.Context, in *RepoStatsHistoryRequest, opts ...grpc.CallOption) (*RepoStatsHistory, error)
+	// GetStats returns statistics of each plan's backups aggregated from the operation log, e.g. success rates and durations.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*PlanStatsList, error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
 	Cancel(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// GetLogs returns the keyed large data for the given operation.
 	GetLogs(ctx context.Context, in *LogDataRequest, opts ...grpc.CallOption) (*types.BytesValue, error)
-	// GetDownloadURL returns a signed download URL given a forget operation ID.
+	// GetResticInfo returns the restic binary backrest uses by default and the binary used by each repo the caller may access.
+	GetResticInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResticInfo, error)
+	// GetDownloadURL returns a signed URL that downloads the files of a restore operation given its ID.
 	GetDownloadURL(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*types.StringValue, error)
+	// GetRestoreDownloadURL returns a signed URL that downloads a file or directory of a restore operation's target. The
+	// path is part of the signature, the URL can't be changed to download other files of the restore.
+	GetRestoreDownloadURL(ctx context.Context, in *RestoreDownloadURLRequest, opts ...grpc.CallOption) (*types.StringValue, error)
+	// CreateShareLink creates a passphrase protected link to download a directory or file of a restore without access to
+	// the UI, the link's URL is only returned here.
+	CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error)
+	// GetShareLinks lists the share links of the repos the caller may access.
+	GetShareLinks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ShareLinkList, error)
+	// RevokeShareLink revokes the share link with the given ID, downloads in progress are not interrupted.
+	RevokeShareLink(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*ShareLink, error)
+	// GetSnapshotFileDownloadURL returns a signed URL that streams a single file from a snapshot without restoring it first.
+	GetSnapshotFileDownloadURL(ctx context.Context, in *ListSnapshotFilesRequest, opts ...grpc.CallOption) (*types.StringValue, error)
+	// GetSnapshotArchiveURL returns a signed URL that streams a directory of a snapshot, the whole snapshot if no path is
+	// given, as an uncompressed tar archive. Appending "?offset=<bytes>" to the URL resumes an interrupted download.
+	GetSnapshotArchiveURL(ctx context.Context, in *ListSnapshotFilesRequest, opts ...grpc.CallOption) (*types.StringValue, error)
+	// ExportOperations streams the operations of the plans and repos the caller may access as newline delimited protojson,
+	// messages are chunks of the output and lines may span messages.
+	ExportOperations(ctx context.Context, in *ExportOperationsRequest, opts ...grpc.CallOption) (Backrest_ExportOperationsClient, error)
+	// ImportOperations adds the operations in a stream of newline delimited protojson, as written by ExportOperations, to
+	// the oplog. Operations are given new IDs, those already in the oplog or unfinished when they were exported are skipped.
+	ImportOperations(ctx context.Context, opts ...grpc.CallOption) (Backrest_ImportOperationsClient, error)
+	// AnnotateOperation attaches a note to a finished operation replacing any it has, an empty note removes it. Returns the
+	// updated operation.
+	AnnotateOperation(ctx context.Context, in *AnnotateOperationRequest, opts ...grpc.CallOption) (*Operation, error)
 	// Clears the history of operations
 	ClearHistory(ctx context.Context, in *ClearHistoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// PathAutocomplete provides path autocompletion options for a given filesystem path.
 	PathAutocomplete(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.StringList, error)
+	// DescribeCron returns a human readable description of a cron expression. Returns an error if the expression is invalid.
+	DescribeCron(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.StringValue, error)
+	// ValidateCron returns a description of a cron expression and the next times it fires. Returns an error if the expression is invalid.
+	ValidateCron(ctx context.Context, in *ValidateCronRequest, opts ...grpc.CallOption) (*ValidateCronResponse, error)
+	// SetPaused pauses or resumes scheduled tasks for a plan or a repo, returns the updated config.
+	SetPaused(ctx context.Context, in *SetPausedRequest, opts ...grpc.CallOption) (*Config, error)
+	// SetBandwidthLimit changes a repo's bandwidth limits, returns the updated config. Backups in progress for the repo are
+	// restarted with the new limits.
+	SetBandwidthLimit(ctx context.Context, in *SetBandwidthLimitRequest, opts ...grpc.CallOption) (*Config, error)
+	// PutReplica stores a config replica pushed by another instance, replicas are kept per instance and replace older ones.
+	PutReplica(ctx context.Context, in *SealedReplica, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Search finds operations and snapshots matching a query across every plan and repo the caller may access, newest first.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// GetAlerts evaluates the health rules and returns the current alerts of the plans and repos the caller may access.
+	GetAlerts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AlertList, error)
+	// AcknowledgeAlert suppresses notifications for the alert with the given ID until it's resolved, returns the updated alert.
+	AcknowledgeAlert(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*Alert, error)
+	// SnoozeAlert suppresses notifications for an alert for a duration, returns the updated alert.
+	SnoozeAlert(ctx context.Context, in *SnoozeAlertRequest, opts ...grpc.CallOption) (*Alert, error)
+	// GetHookDeliveries lists the hook notifications waiting to be retried and those that were given up on.
+	GetHookDeliveries(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HookDeliveryList, error)
+	// RetryHookDelivery moves the dead letter with the given ID back to the retry queue, it is attempted immediately.
+	RetryHookDelivery(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetSchedulerDecisions lists the scheduler's recent decisions about the tasks of the plans and repos the caller may
+	// access, newest first, e.g. to find out why a backup didn't run. Decisions are kept in memory and lost on restart.
+	GetSchedulerDecisions(ctx context.Context, in *SchedulerDecisionsRequest, opts ...grpc.CallOption) (*SchedulerDecisionList, error)
 }
 
 type backrestClient struct {
@@ -146,6 +329,38 @@ func (x *backrestGetOperationEventsClient) Recv() (*OperationEvent, error) {
 	return m, nil
 }
 
+func (c *backrestClient) SubscribeOperations(ctx context.Context, in *SubscribeOperationsRequest, opts ...grpc.CallOption) (Backrest_SubscribeOperationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Backrest_ServiceDesc.Streams[1], Backrest_SubscribeOperations_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backrestSubscribeOperationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Backrest_SubscribeOperationsClient interface {
+	Recv() (*OperationEvent, error)
+	grpc.ClientStream
+}
+
+type backrestSubscribeOperationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backrestSubscribeOperationsClient) Recv() (*OperationEvent, error) {
+	m := new(OperationEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *backrestClient) GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*OperationList, error) {
 	out := new(OperationList)
 	err := c.cc.Invoke(ctx, Backrest_GetOperations_FullMethodName, in, out, opts...)
@@ -173,6 +388,101 @@ func (c *backrestClient) ListSnapshotFiles(ctx context.Context, in *ListSnapshot
 	return out, nil
 }
 
+func (c *backrestClient) ListRestorePoints(ctx context.Context, in *ListRestorePointsRequest, opts ...grpc.CallOption) (*RestorePointList, error) {
+	out := new(RestorePointList)
+	err := c.cc.Invoke(ctx, Backrest_ListRestorePoints_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...grpc.CallOption) (*SnapshotDiff, error) {
+	out := new(SnapshotDiff)
+	err := c.cc.Invoke(ctx, Backrest_DiffSnapshots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) SearchSnapshots(ctx context.Context, in *SearchSnapshotsRequest, opts ...grpc.CallOption) (Backrest_SearchSnapshotsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Backrest_ServiceDesc.Streams[2], Backrest_SearchSnapshots_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backrestSearchSnapshotsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Backrest_SearchSnapshotsClient interface {
+	Recv() (*SnapshotFileMatch, error)
+	grpc.ClientStream
+}
+
+type backrestSearchSnapshotsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backrestSearchSnapshotsClient) Recv() (*SnapshotFileMatch, error) {
+	m := new(SnapshotFileMatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backrestClient) ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*FileVersionList, error) {
+	out := new(FileVersionList)
+	err := c.cc.Invoke(ctx, Backrest_ListFileVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) TagSnapshots(ctx context.Context, in *TagSnapshotsRequest, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
+	err := c.cc.Invoke(ctx, Backrest_TagSnapshots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) PreviewRewriteSnapshots(ctx context.Context, in *RewriteSnapshotsRequest, opts ...grpc.CallOption) (*RewritePreview, error) {
+	out := new(RewritePreview)
+	err := c.cc.Invoke(ctx, Backrest_PreviewRewriteSnapshots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) RewriteSnapshots(ctx context.Context, in *RewriteSnapshotsRequest, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
+	err := c.cc.Invoke(ctx, Backrest_RewriteSnapshots_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetSnapshotStats(ctx context.Context, in *GetSnapshotStatsRequest, opts ...grpc.CallOption) (*SnapshotStats, error) {
+	out := new(SnapshotStats)
+	err := c.cc.Invoke(ctx, Backrest_GetSnapshotStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) IndexSnapshots(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Backrest_IndexSnapshots_FullMethodName, in, out, opts...)
@@ -191,8 +501,26 @@ func (c *backrestClient) Backup(ctx context.Context, in *types.StringValue, opts
 	return out, nil
 }
 
-func (c *backrestClient) Prune(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *backrestClient) BackupWithAnnotations(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_BackupWithAnnotations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) PreviewBackup(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*BackupPreview, error) {
+	out := new(BackupPreview)
+	err := c.cc.Invoke(ctx, Backrest_PreviewBackup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) Prune(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
 	err := c.cc.Invoke(ctx, Backrest_Prune_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -200,8 +528,8 @@ func (c *backrestClient) Prune(ctx context.Context, in *types.StringValue, opts
 	return out, nil
 }
 
-func (c *backrestClient) Forget(ctx context.Context, in *ForgetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *backrestClient) Forget(ctx context.Context, in *ForgetRequest, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
 	err := c.cc.Invoke(ctx, Backrest_Forget_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -209,6 +537,60 @@ func (c *backrestClient) Forget(ctx context.Context, in *ForgetRequest, opts ...
 	return out, nil
 }
 
+func (c *backrestClient) Check(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
+	err := c.cc.Invoke(ctx, Backrest_Check_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) InitRepo(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
+	err := c.cc.Invoke(ctx, Backrest_InitRepo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) MigrateRepo(ctx context.Context, in *MigrateRepoRequest, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
+	err := c.cc.Invoke(ctx, Backrest_MigrateRepo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetRepoUpgrade(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*RepoUpgradeAssessment, error) {
+	out := new(RepoUpgradeAssessment)
+	err := c.cc.Invoke(ctx, Backrest_GetRepoUpgrade_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) UpgradeRepo(ctx context.Context, in *UpgradeRepoRequest, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
+	err := c.cc.Invoke(ctx, Backrest_UpgradeRepo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) RecoverRepo(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.Int64Value, error) {
+	out := new(types.Int64Value)
+	err := c.cc.Invoke(ctx, Backrest_RecoverRepo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) Restore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Backrest_Restore_FullMethodName, in, out, opts...)
@@ -218,6 +600,24 @@ func (c *backrestClient) Restore(ctx context.Context, in *RestoreSnapshotRequest
 	return out, nil
 }
 
+func (c *backrestClient) GetRestoreConflicts(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestoreConflictReport, error) {
+	out := new(RestoreConflictReport)
+	err := c.cc.Invoke(ctx, Backrest_GetRestoreConflicts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) PreviewRestore(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestorePreview, error) {
+	out := new(RestorePreview)
+	err := c.cc.Invoke(ctx, Backrest_PreviewRestore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) Unlock(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Backrest_Unlock_FullMethodName, in, out, opts...)
@@ -227,6 +627,78 @@ func (c *backrestClient) Unlock(ctx context.Context, in *types.StringValue, opts
 	return out, nil
 }
 
+func (c *backrestClient) MountRepo(ctx context.Context, in *MountRepoRequest, opts ...grpc.CallOption) (*RepoMount, error) {
+	out := new(RepoMount)
+	err := c.cc.Invoke(ctx, Backrest_MountRepo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) UnmountRepo(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_UnmountRepo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetMounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RepoMountList, error) {
+	out := new(RepoMountList)
+	err := c.cc.Invoke(ctx, Backrest_GetMounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) ListRepoKeys(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*RepoKeyList, error) {
+	out := new(RepoKeyList)
+	err := c.cc.Invoke(ctx, Backrest_ListRepoKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) AddRepoKey(ctx context.Context, in *AddRepoKeyRequest, opts ...grpc.CallOption) (*RepoKey, error) {
+	out := new(RepoKey)
+	err := c.cc.Invoke(ctx, Backrest_AddRepoKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) RemoveRepoKey(ctx context.Context, in *RemoveRepoKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_RemoveRepoKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) ChangeRepoPassword(ctx context.Context, in *ChangeRepoPasswordRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Backrest_ChangeRepoPassword_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetRepoCacheUsage(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*RepoCacheUsage, error) {
+	out := new(RepoCacheUsage)
+	err := c.cc.Invoke(ctx, Backrest_GetRepoCacheUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) Stats(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Backrest_Stats_FullMethodName, in, out, opts...)
@@ -236,6 +708,24 @@ func (c *backrestClient) Stats(ctx context.Context, in *types.StringValue, opts
 	return out, nil
 }
 
+func (c *backrestClient) GetRepoStatsHistory(ctx context.Context, in *RepoStatsHistoryRequest, opts ...grpc.CallOption) (*RepoStatsHistory, error) {
+	out := new(RepoStatsHistory)
+	err := c.cc.Invoke(ctx, Backrest_GetRepoStatsHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*PlanStatsList, error) {
+	out := new(PlanStatsList)
+	err := c.cc.Invoke(ctx, Backrest_GetStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) Cancel(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Backrest_Cancel_FullMethodName, in, out, opts...)
@@ -254,6 +744,15 @@ func (c *backrestClient) GetLogs(ctx context.Context, in *LogDataRequest, opts .
 	return out, nil
 }
 
+func (c *backrestClient) GetResticInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ResticInfo, error) {
+	out := new(ResticInfo)
+	err := c.cc.Invoke(ctx, Backrest_GetResticInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backrestClient) GetDownloadURL(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*types.StringValue, error) {
 	out := new(types.StringValue)
 	err := c.cc.Invoke(ctx, Backrest_GetDownloadURL_FullMethodName, in, out, opts...)
@@ -263,59 +762,428 @@ func (c *backrestClient) GetDownloadURL(ctx context.Context, in *types.Int64Valu
 	return out, nil
 }
 
-func (c *backrestClient) ClearHistory(ctx context.Context, in *ClearHistoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, Backrest_ClearHistory_FullMethodName, in, out, opts...)
+func (c *backrestClient) GetRestoreDownloadURL(ctx context.Context, in *RestoreDownloadURLRequest, opts ...grpc.CallOption) (*types.StringValue, error) {
+	out := new(types.StringValue)
+	err := c.cc.Invoke(ctx, Backrest_GetRestoreDownloadURL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *backrestClient) PathAutocomplete(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.StringList, error) {
-	out := new(types.StringList)
-	err := c.cc.Invoke(ctx, Backrest_PathAutocomplete_FullMethodName, in, out, opts...)
+func (c *backrestClient) CreateShareLink(ctx context.Context, in *CreateShareLinkRequest, opts ...grpc.CallOption) (*CreateShareLinkResponse, error) {
+	out := new(CreateShareLinkResponse)
+	err := c.cc.Invoke(ctx, Backrest_CreateShareLink_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// BackrestServer is the server API for Backrest service.
-// All implementations must embed UnimplementedBackrestServer
-// for forward compatibility
-type BackrestServer interface {
-	GetConfig(context.Context, *emptypb.Empty) (*Config, error)
-	SetConfig(context.Context, *Config) (*Config, error)
-	AddRepo(context.Context, *Repo) (*Config, error)
-	GetOperationEvents(*emptypb.Empty, Backrest_GetOperationEventsServer) error
-	GetOperations(context.Context, *GetOperationsRequest) (*OperationList, error)
-	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ResticSnapshotList, error)
-	ListSnapshotFiles(context.Context, *ListSnapshotFilesRequest) (*ListSnapshotFilesResponse, error)
-	// IndexSnapshots triggers indexin. It accepts a repo id and returns empty if the task is enqueued.
-	IndexSnapshots(context.Context, *types.StringValue) (*emptypb.Empty, error)
-	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
-	Backup(context.Context, *types.StringValue) (*emptypb.Empty, error)
-	// Prune schedules a prune operation. It accepts a plan id and returns empty if the task is enqueued.
-	Prune(context.Context, *types.StringValue) (*emptypb.Empty, error)
-	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
-	Forget(context.Context, *ForgetRequest) (*emptypb.Empty, error)
-	// Restore schedules a restore operation.
-	Restore(context.Context, *RestoreSnapshotRequest) (*emptypb.Empty, error)
-	// Unlock synchronously attempts to unlock the repo. Will block if other operations are in progress.
-	Unlock(context.Context, *types.StringValue) (*emptypb.Empty, error)
-	// Stats runs 'restic stats` on the repository and appends the results to the operations log.
-	Stats(context.Context, *types.StringValue) (*emptypb.Empty, error)
-	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
-	Cancel(context.Context, *types.Int64Value) (*emptypb.Empty, error)
-	// GetLogs returns the keyed large data for the given operation.
-	GetLogs(context.Context, *LogDataRequest) (*types.BytesValue, error)
-	// GetDownloadURL returns a signed download URL given a forget operation ID.
-	GetDownloadURL(context.Context, *types.Int64Value) (*types.StringValue, error)
-	// Clears the history of operations
+func (c *backrestClient) GetShareLinks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ShareLinkList, error) {
+	out := new(ShareLinkList)
+	err := c.cc.Invoke(ctx, Backrest_GetShareLinks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) RevokeShareLink(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*ShareLink, error) {
+	out := new(ShareLink)
+	err := c.cc.Invoke(ctx, Backrest_RevokeShareLink_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetSnapshotFileDownloadURL(ctx context.Context, in *ListSnapshotFilesRequest, opts ...grpc.CallOption) (*types.StringValue, error) {
+	out := new(types.StringValue)
+	err := c.cc.Invoke(ctx, Backrest_GetSnapshotFileDownloadURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetSnapshotArchiveURL(ctx context.Context, in *ListSnapshotFilesRequest, opts ...grpc.CallOption) (*types.StringValue, error) {
+	out := new(types.StringValue)
+	err := c.cc.Invoke(ctx, Backrest_GetSnapshotArchiveURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) ExportOperations(ctx context.Context, in *ExportOperationsRequest, opts ...grpc.CallOption) (Backrest_ExportOperationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Backrest_ServiceDesc.Streams[3], Backrest_ExportOperations_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backrestExportOperationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Backrest_ExportOperationsClient interface {
+	Recv() (*types.BytesValue, error)
+	grpc.ClientStream
+}
+
+type backrestExportOperationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backrestExportOperationsClient) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backrestClient) ImportOperations(ctx context.Context, opts ...grpc.CallOption) (Backrest_ImportOperationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Backrest_ServiceDesc.Streams[4], Backrest_ImportOperations_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backrestImportOperationsClient{stream}
+	return x, nil
+}
+
+type Backrest_ImportOperationsClient interface {
+	Send(*types.BytesValue) error
+	CloseAndRecv() (*ImportOperationsResponse, error)
+	grpc.ClientStream
+}
+
+type backrestImportOperationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backrestImportOperationsClient) Send(m *types.BytesValue) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *backrestImportOperationsClient) CloseAndRecv() (*ImportOperationsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportOperationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backrestClient) AnnotateOperation(ctx context.Context, in *AnnotateOperationRequest, opts ...grpc.CallOption) (*Operation, error) {
+	out := new(Operation)
+	err := c.cc.Invoke(ctx, Backrest_AnnotateOperation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) ClearHistory(ctx context.Context, in *ClearHistoryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_ClearHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) PathAutocomplete(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.StringList, error) {
+	out := new(types.StringList)
+	err := c.cc.Invoke(ctx, Backrest_PathAutocomplete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) DescribeCron(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*types.StringValue, error) {
+	out := new(types.StringValue)
+	err := c.cc.Invoke(ctx, Backrest_DescribeCron_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) ValidateCron(ctx context.Context, in *ValidateCronRequest, opts ...grpc.CallOption) (*ValidateCronResponse, error) {
+	out := new(ValidateCronResponse)
+	err := c.cc.Invoke(ctx, Backrest_ValidateCron_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) SetPaused(ctx context.Context, in *SetPausedRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Backrest_SetPaused_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) SetBandwidthLimit(ctx context.Context, in *SetBandwidthLimitRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, Backrest_SetBandwidthLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) PutReplica(ctx context.Context, in *SealedReplica, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_PutReplica_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, Backrest_Search_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetAlerts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AlertList, error) {
+	out := new(AlertList)
+	err := c.cc.Invoke(ctx, Backrest_GetAlerts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) AcknowledgeAlert(ctx context.Context, in *types.StringValue, opts ...grpc.CallOption) (*Alert, error) {
+	out := new(Alert)
+	err := c.cc.Invoke(ctx, Backrest_AcknowledgeAlert_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) SnoozeAlert(ctx context.Context, in *SnoozeAlertRequest, opts ...grpc.CallOption) (*Alert, error) {
+	out := new(Alert)
+	err := c.cc.Invoke(ctx, Backrest_SnoozeAlert_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetHookDeliveries(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HookDeliveryList, error) {
+	out := new(HookDeliveryList)
+	err := c.cc.Invoke(ctx, Backrest_GetHookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) RetryHookDelivery(ctx context.Context, in *types.Int64Value, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Backrest_RetryHookDelivery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrestClient) GetSchedulerDecisions(ctx context.Context, in *SchedulerDecisionsRequest, opts ...grpc.CallOption) (*SchedulerDecisionList, error) {
+	out := new(SchedulerDecisionList)
+	err := c.cc.Invoke(ctx, Backrest_GetSchedulerDecisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BackrestServer is the server API for Backrest service.
+// All implementations must embed UnimplementedBackrestServer
+// for forward compatibility
+type BackrestServer interface {
+	GetConfig(context.Context, *emptypb.Empty) (*Config, error)
+	SetConfig(context.Context, *Config) (*Config, error)
+	AddRepo(context.Context, *Repo) (*Config, error)
+	// GetOperationEvents streams every operation event the caller may see, see SubscribeOperations.
+	GetOperationEvents(*emptypb.Empty, Backrest_GetOperationEventsServer) error
+	// SubscribeOperations streams the events of operations as they are created, updated and deleted, optionally only those
+	// of a plan or repo. The stream is closed if the client falls behind, clients reconnect and fetch the operations they
+	// may have missed with GetOperations.
+	SubscribeOperations(*SubscribeOperationsRequest, Backrest_SubscribeOperationsServer) error
+	GetOperations(context.Context, *GetOperationsRequest) (*OperationList, error)
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ResticSnapshotList, error)
+	ListSnapshotFiles(context.Context, *ListSnapshotFilesRequest) (*ListSnapshotFilesResponse, error)
+	// ListRestorePoints collapses a repo's snapshots into one restore point per plan and calendar day or week, newest first.
+	ListRestorePoints(context.Context, *ListRestorePointsRequest) (*RestorePointList, error)
+	// DiffSnapshots lists the files added, removed and modified between two snapshots, by default between a snapshot and
+	// its parent i.e. what changed in the backup that created it.
+	DiffSnapshots(context.Context, *DiffSnapshotsRequest) (*SnapshotDiff, error)
+	// SearchSnapshots finds files whose name matches a glob pattern in a repo's snapshots, matches are streamed a
+	// snapshot at a time as they are found.
+	SearchSnapshots(*SearchSnapshotsRequest, Backrest_SearchSnapshotsServer) error
+	// ListFileVersions lists the versions of a file in a plan's snapshots newest first, so that the version to restore can
+	// be picked from the file's history.
+	ListFileVersions(context.Context, *ListFileVersionsRequest) (*FileVersionList, error)
+	// TagSnapshots schedules a restic tag adding and removing tags of snapshots, it returns the ID of the scheduled operation.
+	// restic saves each changed snapshot under a new ID. The plan and instance tags backrest selects snapshots by can't be
+	// changed.
+	TagSnapshots(context.Context, *TagSnapshotsRequest) (*types.Int64Value, error)
+	// PreviewRewriteSnapshots reports the snapshots a rewrite would change by running restic rewrite --dry-run, the preview's
+	// digest must be passed back to RewriteSnapshots to confirm the rewrite.
+	PreviewRewriteSnapshots(context.Context, *RewriteSnapshotsRequest) (*RewritePreview, error)
+	// RewriteSnapshots schedules a restic rewrite removing the excluded files from snapshots, it returns the ID of the
+	// scheduled operation. restic saves each changed snapshot under a new ID, the originals are kept unless forget is set.
+	RewriteSnapshots(context.Context, *RewriteSnapshotsRequest) (*types.Int64Value, error)
+	// GetSnapshotStats returns statistics for a snapshot, computed on the first request and cached afterwards.
+	GetSnapshotStats(context.Context, *GetSnapshotStatsRequest) (*SnapshotStats, error)
+	// IndexSnapshots triggers indexin. It accepts a repo id and returns empty if the task is enqueued.
+	IndexSnapshots(context.Context, *types.StringValue) (*emptypb.Empty, error)
+	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
+	Backup(context.Context, *types.StringValue) (*emptypb.Empty, error)
+	// BackupWithAnnotations runs a plan's backup like Backup and attaches the annotations to its snapshot as tags, e.g. the
+	// version of the application being released, so that the snapshot can be found by them later.
+	BackupWithAnnotations(context.Context, *BackupRequest) (*emptypb.Empty, error)
+	// PreviewBackup runs a plan's backup with restic backup --dry-run and reports what it would add to the repo, nothing
+	// is written to the repo. It accepts a plan id.
+	PreviewBackup(context.Context, *types.StringValue) (*BackupPreview, error)
+	// Prune schedules a prune operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Prune(context.Context, *types.StringValue) (*types.Int64Value, error)
+	// Forget schedules a forget operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Forget(context.Context, *ForgetRequest) (*types.Int64Value, error)
+	// Check schedules a check operation. It accepts a repo id and returns the ID of the scheduled operation.
+	Check(context.Context, *types.StringValue) (*types.Int64Value, error)
+	// InitRepo schedules a restic init of a repo already in the config, e.g. one whose storage was recreated. It accepts a
+	// repo id and returns the ID of the scheduled operation. Repos added with AddRepo are initialized when they are added.
+	InitRepo(context.Context, *types.StringValue) (*types.Int64Value, error)
+	// MigrateRepo schedules a restic migrate of the repo, by default upgrading it to the v2 repository format. It returns
+	// the ID of the scheduled operation.
+	MigrateRepo(context.Context, *MigrateRepoRequest) (*types.Int64Value, error)
+	// GetRepoUpgrade checks whether the repo uses an old format version or holds data stored uncompressed and describes
+	// the benefits and risks of upgrading it.
+	GetRepoUpgrade(context.Context, *types.StringValue) (*RepoUpgradeAssessment, error)
+	// UpgradeRepo schedules an upgrade of the repo to the v2 format, optionally followed by repacking the data stored
+	// uncompressed. It returns the ID of the scheduled operation, an interrupted upgrade is resumed when backrest next starts.
+	UpgradeRepo(context.Context, *UpgradeRepoRequest) (*types.Int64Value, error)
+	// RecoverRepo schedules a restic recover of the repo, which saves the data of forgotten snapshots that was not pruned
+	// yet into a new snapshot. It returns the ID of the recover operation.
+	RecoverRepo(context.Context, *types.StringValue) (*types.Int64Value, error)
+	// Restore schedules a restore operation.
+	Restore(context.Context, *RestoreSnapshotRequest) (*emptypb.Empty, error)
+	// GetRestoreConflicts reports the local files an in place restore would overwrite, the report's digest must be passed
+	// back to Restore to confirm the in place restore.
+	GetRestoreConflicts(context.Context, *RestoreSnapshotRequest) (*RestoreConflictReport, error)
+	// PreviewRestore lists the files a restore would write and checks that the target can be written before the restore
+	// is scheduled, nothing is restored.
+	PreviewRestore(context.Context, *RestoreSnapshotRequest) (*RestorePreview, error)
+	// Unlock synchronously attempts to unlock the repo. Will block if other operations are in progress.
+	Unlock(context.Context, *types.StringValue) (*emptypb.Empty, error)
+	// MountRepo mounts the repo's snapshots as a read only filesystem with restic mount, under backrest's data directory.
+	// The repo is unmounted once it wasn't requested for its idle timeout, mounting a mounted repo restarts the timeout.
+	MountRepo(context.Context, *MountRepoRequest) (*RepoMount, error)
+	// UnmountRepo unmounts a repo mounted with MountRepo, it accepts a repo id.
+	UnmountRepo(context.Context, *types.StringValue) (*emptypb.Empty, error)
+	// GetMounts returns the mounted repos the caller may access.
+	GetMounts(context.Context, *emptypb.Empty) (*RepoMountList, error)
+	// ListRepoKeys lists the keys of a repo, it accepts a repo id.
+	ListRepoKeys(context.Context, *types.StringValue) (*RepoKeyList, error)
+	// AddRepoKey adds a key with another password to a repo e.g. for another machine, the repo's password in the config
+	// is unchanged.
+	AddRepoKey(context.Context, *AddRepoKeyRequest) (*RepoKey, error)
+	// RemoveRepoKey removes a key from a repo, the key holding the repo's password in the config can't be removed.
+	RemoveRepoKey(context.Context, *RemoveRepoKeyRequest) (*emptypb.Empty, error)
+	// ChangeRepoPassword replaces the key holding the repo's password in the config with a key holding the new password
+	// and stores the new password in the config. The old key is only removed once the config is updated.
+	ChangeRepoPassword(context.Context, *ChangeRepoPasswordRequest) (*Config, error)
+	// GetRepoCacheUsage returns the disk usage of the repo's local restic cache, it accepts a repo id.
+	GetRepoCacheUsage(context.Context, *types.StringValue) (*RepoCacheUsage, error)
+	// Stats runs 'restic stats` on the repository and appends the results to the operations log.
+	Stats(context.Context, *types.StringValue) (*emptypb.Empty, error)
+	// GetRepoStatsHistory returns the stats collected for a repo over time, oldest first, e.g. to graph the repo's growth.
+	GetRepoStatsHistory(context.Context, *RepoStatsHistoryRequest) (*RepoStatsHistory, error)
+	// GetStats returns statistics of each plan's backups aggregated from the operation log, e.g. success rates and durations.
+	GetStats(context.Context, *GetStatsRequest) (*PlanStatsList, error)
+	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
+	Cancel(context.Context, *types.Int64Value) (*emptypb.Empty, error)
+	// GetLogs returns the keyed large data for the given operation.
+	GetLogs(context.Context, *LogDataRequest) (*types.BytesValue, error)
+	// GetResticInfo returns the restic binary backrest uses by default and the binary used by each repo the caller may access.
+	GetResticInfo(context.Context, *emptypb.Empty) (*ResticInfo, error)
+	// GetDownloadURL returns a signed URL that downloads the files of a restore operation given its ID.
+	GetDownloadURL(context.Context, *types.Int64Value) (*types.StringValue, error)
+	// GetRestoreDownloadURL returns a signed URL that downloads a file or directory of a restore operation's target. The
+	// path is part of the signature, the URL can't be changed to download other files of the restore.
+	GetRestoreDownloadURL(context.Context, *RestoreDownloadURLRequest) (*types.StringValue, error)
+	// CreateShareLink creates a passphrase protected link to download a directory or file of a restore without access to
+	// the UI, the link's URL is only returned here.
+	CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error)
+	// GetShareLinks lists the share links of the repos the caller may access.
+	GetShareLinks(context.Context, *emptypb.Empty) (*ShareLinkList, error)
+	// RevokeShareLink revokes the share link with the given ID, downloads in progress are not interrupted.
+	RevokeShareLink(context.Context, *types.Int64Value) (*ShareLink, error)
+	// GetSnapshotFileDownloadURL returns a signed URL that streams a single file from a snapshot without restoring it first.
+	GetSnapshotFileDownloadURL(context.Context, *ListSnapshotFilesRequest) (*types.StringValue, error)
+	// GetSnapshotArchiveURL returns a signed URL that streams a directory of a snapshot, the whole snapshot if no path is
+	// given, as an uncompressed tar archive. Appending "?offset=<bytes>" to the URL resumes an interrupted download.
+	GetSnapshotArchiveURL(context.Context, *ListSnapshotFilesRequest) (*types.StringValue, error)
+	// ExportOperations streams the operations of the plans and repos the caller may access as newline delimited protojson,
+	// messages are chunks of the output and lines may span messages.
+	ExportOperations(*ExportOperationsRequest, Backrest_ExportOperationsServer) error
+	// ImportOperations adds the operations in a stream of newline delimited protojson, as written by ExportOperations, to
+	// the oplog. Operations are given new IDs, those already in the oplog or unfinished when they were exported are skipped.
+	ImportOperations(Backrest_ImportOperationsServer) error
+	// AnnotateOperation attaches a note to a finished operation replacing any it has, an empty note removes it. Returns the
+	// updated operation.
+	AnnotateOperation(context.Context, *AnnotateOperationRequest) (*Operation, error)
+	// Clears the history of operations
 	ClearHistory(context.Context, *ClearHistoryRequest) (*emptypb.Empty, error)
 	// PathAutocomplete provides path autocompletion options for a given filesystem path.
 	PathAutocomplete(context.Context, *types.StringValue) (*types.StringList, error)
+	// DescribeCron returns a human readable description of a cron expression. Returns an error if the expression is invalid.
+	DescribeCron(context.Context, *types.StringValue) (*types.StringValue, error)
+	// ValidateCron returns a description of a cron expression and the next times it fires. Returns an error if the expression is invalid.
+	ValidateCron(context.Context, *ValidateCronRequest) (*ValidateCronResponse, error)
+	// SetPaused pauses or resumes scheduled tasks for a plan or a repo, returns the updated config.
+	SetPaused(context.Context, *SetPausedRequest) (*Config, error)
+	// SetBandwidthLimit changes a repo's bandwidth limits, returns the updated config. Backups in progress for the repo are
+	// restarted with the new limits.
+	SetBandwidthLimit(context.Context, *SetBandwidthLimitRequest) (*Config, error)
+	// PutReplica stores a config replica pushed by another instance, replicas are kept per instance and replace older ones.
+	PutReplica(context.Context, *SealedReplica) (*emptypb.Empty, error)
+	// Search finds operations and snapshots matching a query across every plan and repo the caller may access, newest first.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// GetAlerts evaluates the health rules and returns the current alerts of the plans and repos the caller may access.
+	GetAlerts(context.Context, *emptypb.Empty) (*AlertList, error)
+	// AcknowledgeAlert suppresses notifications for the alert with the given ID until it's resolved, returns the updated alert.
+	AcknowledgeAlert(context.Context, *types.StringValue) (*Alert, error)
+	// SnoozeAlert suppresses notifications for an alert for a duration, returns the updated alert.
+	SnoozeAlert(context.Context, *SnoozeAlertRequest) (*Alert, error)
+	// GetHookDeliveries lists the hook notifications waiting to be retried and those that were given up on.
+	GetHookDeliveries(context.Context, *emptypb.Empty) (*HookDeliveryList, error)
+	// RetryHookDelivery moves the dead letter with the given ID back to the retry queue, it is attempted immediately.
+	RetryHookDelivery(context.Context, *types.Int64Value) (*emptypb.Empty, error)
+	// GetSchedulerDecisions lists the scheduler's recent decisions about the tasks of the plans and repos the caller may
+	// access, newest first, e.g. to find out why a backup didn't run. Decisions are kept in memory and lost on restart.
+	GetSchedulerDecisions(context.Context, *SchedulerDecisionsRequest) (*SchedulerDecisionList, error)
 	mustEmbedUnimplementedBackrestServer()
 }
 
@@ -335,6 +1203,9 @@ func (UnimplementedBackrestServer) AddRepo(context.Context, *Repo) (*Config, err
 func (UnimplementedBackrestServer) GetOperationEvents(*emptypb.Empty, Backrest_GetOperationEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetOperationEvents not implemented")
 }
+func (UnimplementedBackrestServer) SubscribeOperations(*SubscribeOperationsRequest, Backrest_SubscribeOperationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOperations not implemented")
+}
 func (UnimplementedBackrestServer) GetOperations(context.Context, *GetOperationsRequest) (*OperationList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOperations not implemented")
 }
@@ -344,42 +1215,192 @@ func (UnimplementedBackrestServer) ListSnapshots(context.Context, *ListSnapshots
 func (UnimplementedBackrestServer) ListSnapshotFiles(context.Context, *ListSnapshotFilesRequest) (*ListSnapshotFilesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshotFiles not implemented")
 }
+func (UnimplementedBackrestServer) ListRestorePoints(context.Context, *ListRestorePointsRequest) (*RestorePointList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRestorePoints not implemented")
+}
+func (UnimplementedBackrestServer) DiffSnapshots(context.Context, *DiffSnapshotsRequest) (*SnapshotDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffSnapshots not implemented")
+}
+func (UnimplementedBackrestServer) SearchSnapshots(*SearchSnapshotsRequest, Backrest_SearchSnapshotsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchSnapshots not implemented")
+}
+func (UnimplementedBackrestServer) ListFileVersions(context.Context, *ListFileVersionsRequest) (*FileVersionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFileVersions not implemented")
+}
+func (UnimplementedBackrestServer) TagSnapshots(context.Context, *TagSnapshotsRequest) (*types.Int64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TagSnapshots not implemented")
+}
+func (UnimplementedBackrestServer) PreviewRewriteSnapshots(context.Context, *RewriteSnapshotsRequest) (*RewritePreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewRewriteSnapshots not implemented")
+}
+func (UnimplementedBackrestServer) RewriteSnapshots(context.Context, *RewriteSnapshotsRequest) (*types.Int64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RewriteSnapshots not implemented")
+}
+func (UnimplementedBackrestServer) GetSnapshotStats(context.Context, *GetSnapshotStatsRequest) (*SnapshotStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshotStats not implemented")
+}
 func (UnimplementedBackrestServer) IndexSnapshots(context.Context, *types.StringValue) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IndexSnapshots not implemented")
 }
 func (UnimplementedBackrestServer) Backup(context.Context, *types.StringValue) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
 }
-func (UnimplementedBackrestServer) Prune(context.Context, *types.StringValue) (*emptypb.Empty, error) {
+func (UnimplementedBackrestServer) BackupWithAnnotations(context.Context, *BackupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupWithAnnotations not implemented")
+}
+func (UnimplementedBackrestServer) PreviewBackup(context.Context, *types.StringValue) (*BackupPreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewBackup not implemented")
+}
+func (UnimplementedBackrestServer) Prune(context.Context, *types.StringValue) (*types.Int64Value, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Prune not implemented")
 }
-func (UnimplementedBackrestServer) Forget(context.Context, *ForgetRequest) (*emptypb.Empty, error) {
+func (UnimplementedBackrestServer) Forget(context.Context, *ForgetRequest) (*types.Int64Value, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Forget not implemented")
 }
+func (UnimplementedBackrestServer) Check(context.Context, *types.StringValue) (*types.Int64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedBackrestServer) InitRepo(context.Context, *types.StringValue) (*types.Int64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitRepo not implemented")
+}
+func (UnimplementedBackrestServer) MigrateRepo(context.Context, *MigrateRepoRequest) (*types.Int64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateRepo not implemented")
+}
+func (UnimplementedBackrestServer) GetRepoUpgrade(context.Context, *types.StringValue) (*RepoUpgradeAssessment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRepoUpgrade not implemented")
+}
+func (UnimplementedBackrestServer) UpgradeRepo(context.Context, *UpgradeRepoRequest) (*types.Int64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeRepo not implemented")
+}
+func (UnimplementedBackrestServer) RecoverRepo(context.Context, *types.StringValue) (*types.Int64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverRepo not implemented")
+}
 func (UnimplementedBackrestServer) Restore(context.Context, *RestoreSnapshotRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
 }
+func (UnimplementedBackrestServer) GetRestoreConflicts(context.Context, *RestoreSnapshotRequest) (*RestoreConflictReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRestoreConflicts not implemented")
+}
+func (UnimplementedBackrestServer) PreviewRestore(context.Context, *RestoreSnapshotRequest) (*RestorePreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewRestore not implemented")
+}
 func (UnimplementedBackrestServer) Unlock(context.Context, *types.StringValue) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
 }
+func (UnimplementedBackrestServer) MountRepo(context.Context, *MountRepoRequest) (*RepoMount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MountRepo not implemented")
+}
+func (UnimplementedBackrestServer) UnmountRepo(context.Context, *types.StringValue) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmountRepo not implemented")
+}
+func (UnimplementedBackrestServer) GetMounts(context.Context, *emptypb.Empty) (*RepoMountList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMounts not implemented")
+}
+func (UnimplementedBackrestServer) ListRepoKeys(context.Context, *types.StringValue) (*RepoKeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRepoKeys not implemented")
+}
+func (UnimplementedBackrestServer) AddRepoKey(context.Context, *AddRepoKeyRequest) (*RepoKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRepoKey not implemented")
+}
+func (UnimplementedBackrestServer) RemoveRepoKey(context.Context, *RemoveRepoKeyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRepoKey not implemented")
+}
+func (UnimplementedBackrestServer) ChangeRepoPassword(context.Context, *ChangeRepoPasswordRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeRepoPassword not implemented")
+}
+func (UnimplementedBackrestServer) GetRepoCacheUsage(context.Context, *types.StringValue) (*RepoCacheUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRepoCacheUsage not implemented")
+}
 func (UnimplementedBackrestServer) Stats(context.Context, *types.StringValue) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
 }
+func (UnimplementedBackrestServer) GetRepoStatsHistory(context.Context, *RepoStatsHistoryRequest) (*RepoStatsHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRepoStatsHistory not implemented")
+}
+func (UnimplementedBackrestServer) GetStats(context.Context, *GetStatsRequest) (*PlanStatsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
 func (UnimplementedBackrestServer) Cancel(context.Context, *types.Int64Value) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
 func (UnimplementedBackrestServer) GetLogs(context.Context, *LogDataRequest) (*types.BytesValue, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
 }
+func (UnimplementedBackrestServer) GetResticInfo(context.Context, *emptypb.Empty) (*ResticInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResticInfo not implemented")
+}
 func (UnimplementedBackrestServer) GetDownloadURL(context.Context, *types.Int64Value) (*types.StringValue, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDownloadURL not implemented")
 }
+func (UnimplementedBackrestServer) GetRestoreDownloadURL(context.Context, *RestoreDownloadURLRequest) (*types.StringValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRestoreDownloadURL not implemented")
+}
+func (UnimplementedBackrestServer) CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedBackrestServer) GetShareLinks(context.Context, *emptypb.Empty) (*ShareLinkList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShareLinks not implemented")
+}
+func (UnimplementedBackrestServer) RevokeShareLink(context.Context, *types.Int64Value) (*ShareLink, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShareLink not implemented")
+}
+func (UnimplementedBackrestServer) GetSnapshotFileDownloadURL(context.Context, *ListSnapshotFilesRequest) (*types.StringValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshotFileDownloadURL not implemented")
+}
+func (UnimplementedBackrestServer) GetSnapshotArchiveURL(context.Context, *ListSnapshotFilesRequest) (*types.StringValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshotArchiveURL not implemented")
+}
+func (UnimplementedBackrestServer) ExportOperations(*ExportOperationsRequest, Backrest_ExportOperationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportOperations not implemented")
+}
+func (UnimplementedBackrestServer) ImportOperations(Backrest_ImportOperationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportOperations not implemented")
+}
+func (UnimplementedBackrestServer) AnnotateOperation(context.Context, *AnnotateOperationRequest) (*Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnotateOperation not implemented")
+}
 func (UnimplementedBackrestServer) ClearHistory(context.Context, *ClearHistoryRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearHistory not implemented")
 }
 func (UnimplementedBackrestServer) PathAutocomplete(context.Context, *types.StringValue) (*types.StringList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PathAutocomplete not implemented")
 }
+func (UnimplementedBackrestServer) DescribeCron(context.Context, *types.StringValue) (*types.StringValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeCron not implemented")
+}
+func (UnimplementedBackrestServer) ValidateCron(context.Context, *ValidateCronRequest) (*ValidateCronResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCron not implemented")
+}
+func (UnimplementedBackrestServer) SetPaused(context.Context, *SetPausedRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPaused not implemented")
+}
+func (UnimplementedBackrestServer) SetBandwidthLimit(context.Context, *SetBandwidthLimitRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBandwidthLimit not implemented")
+}
+func (UnimplementedBackrestServer) PutReplica(context.Context, *SealedReplica) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutReplica not implemented")
+}
+func (UnimplementedBackrestServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedBackrestServer) GetAlerts(context.Context, *emptypb.Empty) (*AlertList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlerts not implemented")
+}
+func (UnimplementedBackrestServer) AcknowledgeAlert(context.Context, *types.StringValue) (*Alert, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeAlert not implemented")
+}
+func (UnimplementedBackrestServer) SnoozeAlert(context.Context, *SnoozeAlertRequest) (*Alert, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeAlert not implemented")
+}
+func (UnimplementedBackrestServer) GetHookDeliveries(context.Context, *emptypb.Empty) (*HookDeliveryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHookDeliveries not implemented")
+}
+func (UnimplementedBackrestServer) RetryHookDelivery(context.Context, *types.Int64Value) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryHookDelivery not implemented")
+}
+func (UnimplementedBackrestServer) GetSchedulerDecisions(context.Context, *SchedulerDecisionsRequest) (*SchedulerDecisionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedulerDecisions not implemented")
+}
 func (UnimplementedBackrestServer) mustEmbedUnimplementedBackrestServer() {}
 
 // UnsafeBackrestServer may be embedded to opt out of forward compatibility for this service.
@@ -389,351 +1410,1286 @@ type UnsafeBackrestServer interface {
 	mustEmbedUnimplementedBackrestServer()
 }
 
-func RegisterBackrestServer(s grpc.ServiceRegistrar, srv BackrestServer) {
-	s.RegisterService(&Backrest_ServiceDesc, srv)
+func RegisterBackrestServer(s grpc.ServiceRegistrar, srv BackrestServer) {
+	s.RegisterService(&Backrest_ServiceDesc, srv)
+}
+
+func _Backrest_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetConfig(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).SetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_SetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).SetConfig(ctx, req.(*Config))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_AddRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Repo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).AddRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_AddRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).AddRepo(ctx, req.(*Repo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetOperationEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackrestServer).GetOperationEvents(m, &backrestGetOperationEventsServer{stream})
+}
+
+type Backrest_GetOperationEventsServer interface {
+	Send(*OperationEvent) error
+	grpc.ServerStream
+}
+
+type backrestGetOperationEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backrestGetOperationEventsServer) Send(m *OperationEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Backrest_SubscribeOperations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOperationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackrestServer).SubscribeOperations(m, &backrestSubscribeOperationsServer{stream})
+}
+
+type Backrest_SubscribeOperationsServer interface {
+	Send(*OperationEvent) error
+	grpc.ServerStream
+}
+
+type backrestSubscribeOperationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backrestSubscribeOperationsServer) Send(m *OperationEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Backrest_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetOperations(ctx, req.(*GetOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_ListSnapshotFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ListSnapshotFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ListSnapshotFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ListSnapshotFiles(ctx, req.(*ListSnapshotFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_ListRestorePoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRestorePointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ListRestorePoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ListRestorePoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ListRestorePoints(ctx, req.(*ListRestorePointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_DiffSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).DiffSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_DiffSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).DiffSnapshots(ctx, req.(*DiffSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_SearchSnapshots_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchSnapshotsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackrestServer).SearchSnapshots(m, &backrestSearchSnapshotsServer{stream})
+}
+
+type Backrest_SearchSnapshotsServer interface {
+	Send(*SnapshotFileMatch) error
+	grpc.ServerStream
+}
+
+type backrestSearchSnapshotsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backrestSearchSnapshotsServer) Send(m *SnapshotFileMatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Backrest_ListFileVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ListFileVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ListFileVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ListFileVersions(ctx, req.(*ListFileVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_TagSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).TagSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_TagSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).TagSnapshots(ctx, req.(*TagSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_PreviewRewriteSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewriteSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).PreviewRewriteSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_PreviewRewriteSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).PreviewRewriteSnapshots(ctx, req.(*RewriteSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_RewriteSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewriteSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).RewriteSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_RewriteSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).RewriteSnapshots(ctx, req.(*RewriteSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetSnapshotStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetSnapshotStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetSnapshotStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetSnapshotStats(ctx, req.(*GetSnapshotStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_IndexSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).IndexSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_IndexSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).IndexSnapshots(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Backup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Backup(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_BackupWithAnnotations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).BackupWithAnnotations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_BackupWithAnnotations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).BackupWithAnnotations(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_PreviewBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).PreviewBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_PreviewBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).PreviewBackup(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Prune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Prune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Prune_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Prune(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Forget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Forget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Forget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Forget(ctx, req.(*ForgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Check(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_InitRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).InitRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_InitRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).InitRepo(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_MigrateRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).MigrateRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_MigrateRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).MigrateRepo(ctx, req.(*MigrateRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetRepoUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetRepoUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetRepoUpgrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetRepoUpgrade(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_UpgradeRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).UpgradeRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_UpgradeRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).UpgradeRepo(ctx, req.(*UpgradeRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_RecoverRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).RecoverRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_RecoverRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).RecoverRepo(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Restore(ctx, req.(*RestoreSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetRestoreConflicts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetRestoreConflicts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetRestoreConflicts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetRestoreConflicts(ctx, req.(*RestoreSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_PreviewRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).PreviewRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_PreviewRestore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).PreviewRestore(ctx, req.(*RestoreSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Unlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Unlock(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_MountRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MountRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).MountRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_MountRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).MountRepo(ctx, req.(*MountRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_UnmountRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).UnmountRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_UnmountRepo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).UnmountRepo(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetMounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetMounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetMounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetMounts(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_ListRepoKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ListRepoKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ListRepoKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ListRepoKeys(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_AddRepoKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRepoKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).AddRepoKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_AddRepoKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).AddRepoKey(ctx, req.(*AddRepoKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_RemoveRepoKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRepoKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).RemoveRepoKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_RemoveRepoKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).RemoveRepoKey(ctx, req.(*RemoveRepoKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_ChangeRepoPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeRepoPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).ChangeRepoPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_ChangeRepoPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).ChangeRepoPassword(ctx, req.(*ChangeRepoPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetRepoCacheUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetRepoCacheUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetRepoCacheUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetRepoCacheUsage(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Stats(ctx, req.(*types.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetRepoStatsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepoStatsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetRepoStatsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetRepoStatsHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetRepoStatsHistory(ctx, req.(*RepoStatsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Int64Value)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).Cancel(ctx, req.(*types.Int64Value))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetLogs(ctx, req.(*LogDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetResticInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetResticInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetResticInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetResticInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Int64Value)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetDownloadURL(ctx, req.(*types.Int64Value))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetRestoreDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetRestoreDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetRestoreDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetRestoreDownloadURL(ctx, req.(*RestoreDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).CreateShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_CreateShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).CreateShareLink(ctx, req.(*CreateShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Backrest_GetShareLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrestServer).GetShareLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Backrest_GetShareLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrestServer).GetShareLinks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Backrest_RevokeShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Int64Value)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).GetConfig(ctx, in)
+		return srv.(BackrestServer).RevokeShareLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_GetConfig_FullMethodName,
+		FullMethod: Backrest_RevokeShareLink_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).GetConfig(ctx, req.(*emptypb.Empty))
+		return srv.(BackrestServer).RevokeShareLink(ctx, req.(*types.Int64Value))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Config)
+func _Backrest_GetSnapshotFileDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotFilesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).SetConfig(ctx, in)
+		return srv.(BackrestServer).GetSnapshotFileDownloadURL(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_SetConfig_FullMethodName,
+		FullMethod: Backrest_GetSnapshotFileDownloadURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).SetConfig(ctx, req.(*Config))
+		return srv.(BackrestServer).GetSnapshotFileDownloadURL(ctx, req.(*ListSnapshotFilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_AddRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Repo)
+func _Backrest_GetSnapshotArchiveURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotFilesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).AddRepo(ctx, in)
+		return srv.(BackrestServer).GetSnapshotArchiveURL(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_AddRepo_FullMethodName,
+		FullMethod: Backrest_GetSnapshotArchiveURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).AddRepo(ctx, req.(*Repo))
+		return srv.(BackrestServer).GetSnapshotArchiveURL(ctx, req.(*ListSnapshotFilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_GetOperationEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
+func _Backrest_ExportOperations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportOperationsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(BackrestServer).GetOperationEvents(m, &backrestGetOperationEventsServer{stream})
+	return srv.(BackrestServer).ExportOperations(m, &backrestExportOperationsServer{stream})
 }
 
-type Backrest_GetOperationEventsServer interface {
-	Send(*OperationEvent) error
+type Backrest_ExportOperationsServer interface {
+	Send(*types.BytesValue) error
 	grpc.ServerStream
 }
 
-type backrestGetOperationEventsServer struct {
+type backrestExportOperationsServer struct {
 	grpc.ServerStream
 }
 
-func (x *backrestGetOperationEventsServer) Send(m *OperationEvent) error {
+func (x *backrestExportOperationsServer) Send(m *types.BytesValue) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Backrest_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetOperationsRequest)
+func _Backrest_ImportOperations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackrestServer).ImportOperations(&backrestImportOperationsServer{stream})
+}
+
+type Backrest_ImportOperationsServer interface {
+	SendAndClose(*ImportOperationsResponse) error
+	Recv() (*types.BytesValue, error)
+	grpc.ServerStream
+}
+
+type backrestImportOperationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backrestImportOperationsServer) SendAndClose(m *ImportOperationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *backrestImportOperationsServer) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Backrest_AnnotateOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnotateOperationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).GetOperations(ctx, in)
+		return srv.(BackrestServer).AnnotateOperation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_GetOperations_FullMethodName,
+		FullMethod: Backrest_AnnotateOperation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).GetOperations(ctx, req.(*GetOperationsRequest))
+		return srv.(BackrestServer).AnnotateOperation(ctx, req.(*AnnotateOperationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSnapshotsRequest)
+func _Backrest_ClearHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).ListSnapshots(ctx, in)
+		return srv.(BackrestServer).ClearHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_ListSnapshots_FullMethodName,
+		FullMethod: Backrest_ClearHistory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+		return srv.(BackrestServer).ClearHistory(ctx, req.(*ClearHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_ListSnapshotFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSnapshotFilesRequest)
+func _Backrest_PathAutocomplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).ListSnapshotFiles(ctx, in)
+		return srv.(BackrestServer).PathAutocomplete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_ListSnapshotFiles_FullMethodName,
+		FullMethod: Backrest_PathAutocomplete_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).ListSnapshotFiles(ctx, req.(*ListSnapshotFilesRequest))
+		return srv.(BackrestServer).PathAutocomplete(ctx, req.(*types.StringValue))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_IndexSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Backrest_DescribeCron_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(types.StringValue)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).IndexSnapshots(ctx, in)
+		return srv.(BackrestServer).DescribeCron(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_IndexSnapshots_FullMethodName,
+		FullMethod: Backrest_DescribeCron_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).IndexSnapshots(ctx, req.(*types.StringValue))
+		return srv.(BackrestServer).DescribeCron(ctx, req.(*types.StringValue))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.StringValue)
+func _Backrest_ValidateCron_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCronRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).Backup(ctx, in)
+		return srv.(BackrestServer).ValidateCron(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_Backup_FullMethodName,
+		FullMethod: Backrest_ValidateCron_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).Backup(ctx, req.(*types.StringValue))
+		return srv.(BackrestServer).ValidateCron(ctx, req.(*ValidateCronRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_Prune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.StringValue)
+func _Backrest_SetPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPausedRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).Prune(ctx, in)
+		return srv.(BackrestServer).SetPaused(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_Prune_FullMethodName,
+		FullMethod: Backrest_SetPaused_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).Prune(ctx, req.(*types.StringValue))
+		return srv.(BackrestServer).SetPaused(ctx, req.(*SetPausedRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_Forget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForgetRequest)
+func _Backrest_SetBandwidthLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBandwidthLimitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).Forget(ctx, in)
+		return srv.(BackrestServer).SetBandwidthLimit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_Forget_FullMethodName,
+		FullMethod: Backrest_SetBandwidthLimit_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).Forget(ctx, req.(*ForgetRequest))
+		return srv.(BackrestServer).SetBandwidthLimit(ctx, req.(*SetBandwidthLimitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RestoreSnapshotRequest)
+func _Backrest_PutReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SealedReplica)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).Restore(ctx, in)
+		return srv.(BackrestServer).PutReplica(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_Restore_FullMethodName,
+		FullMethod: Backrest_PutReplica_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).Restore(ctx, req.(*RestoreSnapshotRequest))
+		return srv.(BackrestServer).PutReplica(ctx, req.(*SealedReplica))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.StringValue)
+func _Backrest_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).Unlock(ctx, in)
+		return srv.(BackrestServer).Search(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_Unlock_FullMethodName,
+		FullMethod: Backrest_Search_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).Unlock(ctx, req.(*types.StringValue))
+		return srv.(BackrestServer).Search(ctx, req.(*SearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.StringValue)
+func _Backrest_GetAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).Stats(ctx, in)
+		return srv.(BackrestServer).GetAlerts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_Stats_FullMethodName,
+		FullMethod: Backrest_GetAlerts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).Stats(ctx, req.(*types.StringValue))
+		return srv.(BackrestServer).GetAlerts(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.Int64Value)
+func _Backrest_AcknowledgeAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.StringValue)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).Cancel(ctx, in)
+		return srv.(BackrestServer).AcknowledgeAlert(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_Cancel_FullMethodName,
+		FullMethod: Backrest_AcknowledgeAlert_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).Cancel(ctx, req.(*types.Int64Value))
+		return srv.(BackrestServer).AcknowledgeAlert(ctx, req.(*types.StringValue))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LogDataRequest)
+func _Backrest_SnoozeAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeAlertRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).GetLogs(ctx, in)
+		return srv.(BackrestServer).SnoozeAlert(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_GetLogs_FullMethodName,
+		FullMethod: Backrest_SnoozeAlert_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).GetLogs(ctx, req.(*LogDataRequest))
+		return srv.(BackrestServer).SnoozeAlert(ctx, req.(*SnoozeAlertRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_GetDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.Int64Value)
+func _Backrest_GetHookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).GetDownloadURL(ctx, in)
+		return srv.(BackrestServer).GetHookDeliveries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_GetDownloadURL_FullMethodName,
+		FullMethod: Backrest_GetHookDeliveries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).GetDownloadURL(ctx, req.(*types.Int64Value))
+		return srv.(BackrestServer).GetHookDeliveries(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_ClearHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClearHistoryRequest)
+func _Backrest_RetryHookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Int64Value)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).ClearHistory(ctx, in)
+		return srv.(BackrestServer).RetryHookDelivery(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_ClearHistory_FullMethodName,
+		FullMethod: Backrest_RetryHookDelivery_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).ClearHistory(ctx, req.(*ClearHistoryRequest))
+		return srv.(BackrestServer).RetryHookDelivery(ctx, req.(*types.Int64Value))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Backrest_PathAutocomplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.StringValue)
+func _Backrest_GetSchedulerDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulerDecisionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrestServer).PathAutocomplete(ctx, in)
+		return srv.(BackrestServer).GetSchedulerDecisions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Backrest_PathAutocomplete_FullMethodName,
+		FullMethod: Backrest_GetSchedulerDecisions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrestServer).PathAutocomplete(ctx, req.(*types.StringValue))
+		return srv.(BackrestServer).GetSchedulerDecisions(ctx, req.(*SchedulerDecisionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -769,6 +2725,34 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSnapshotFiles",
 			Handler:    _Backrest_ListSnapshotFiles_Handler,
 		},
+		{
+			MethodName: "ListRestorePoints",
+			Handler:    _Backrest_ListRestorePoints_Handler,
+		},
+		{
+			MethodName: "DiffSnapshots",
+			Handler:    _Backrest_DiffSnapshots_Handler,
+		},
+		{
+			MethodName: "ListFileVersions",
+			Handler:    _Backrest_ListFileVersions_Handler,
+		},
+		{
+			MethodName: "TagSnapshots",
+			Handler:    _Backrest_TagSnapshots_Handler,
+		},
+		{
+			MethodName: "PreviewRewriteSnapshots",
+			Handler:    _Backrest_PreviewRewriteSnapshots_Handler,
+		},
+		{
+			MethodName: "RewriteSnapshots",
+			Handler:    _Backrest_RewriteSnapshots_Handler,
+		},
+		{
+			MethodName: "GetSnapshotStats",
+			Handler:    _Backrest_GetSnapshotStats_Handler,
+		},
 		{
 			MethodName: "IndexSnapshots",
 			Handler:    _Backrest_IndexSnapshots_Handler,
@@ -777,6 +2761,14 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Backup",
 			Handler:    _Backrest_Backup_Handler,
 		},
+		{
+			MethodName: "BackupWithAnnotations",
+			Handler:    _Backrest_BackupWithAnnotations_Handler,
+		},
+		{
+			MethodName: "PreviewBackup",
+			Handler:    _Backrest_PreviewBackup_Handler,
+		},
 		{
 			MethodName: "Prune",
 			Handler:    _Backrest_Prune_Handler,
@@ -785,18 +2777,90 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Forget",
 			Handler:    _Backrest_Forget_Handler,
 		},
+		{
+			MethodName: "Check",
+			Handler:    _Backrest_Check_Handler,
+		},
+		{
+			MethodName: "InitRepo",
+			Handler:    _Backrest_InitRepo_Handler,
+		},
+		{
+			MethodName: "MigrateRepo",
+			Handler:    _Backrest_MigrateRepo_Handler,
+		},
+		{
+			MethodName: "GetRepoUpgrade",
+			Handler:    _Backrest_GetRepoUpgrade_Handler,
+		},
+		{
+			MethodName: "UpgradeRepo",
+			Handler:    _Backrest_UpgradeRepo_Handler,
+		},
+		{
+			MethodName: "RecoverRepo",
+			Handler:    _Backrest_RecoverRepo_Handler,
+		},
 		{
 			MethodName: "Restore",
 			Handler:    _Backrest_Restore_Handler,
 		},
+		{
+			MethodName: "GetRestoreConflicts",
+			Handler:    _Backrest_GetRestoreConflicts_Handler,
+		},
+		{
+			MethodName: "PreviewRestore",
+			Handler:    _Backrest_PreviewRestore_Handler,
+		},
 		{
 			MethodName: "Unlock",
 			Handler:    _Backrest_Unlock_Handler,
 		},
+		{
+			MethodName: "MountRepo",
+			Handler:    _Backrest_MountRepo_Handler,
+		},
+		{
+			MethodName: "UnmountRepo",
+			Handler:    _Backrest_UnmountRepo_Handler,
+		},
+		{
+			MethodName: "GetMounts",
+			Handler:    _Backrest_GetMounts_Handler,
+		},
+		{
+			MethodName: "ListRepoKeys",
+			Handler:    _Backrest_ListRepoKeys_Handler,
+		},
+		{
+			MethodName: "AddRepoKey",
+			Handler:    _Backrest_AddRepoKey_Handler,
+		},
+		{
+			MethodName: "RemoveRepoKey",
+			Handler:    _Backrest_RemoveRepoKey_Handler,
+		},
+		{
+			MethodName: "ChangeRepoPassword",
+			Handler:    _Backrest_ChangeRepoPassword_Handler,
+		},
+		{
+			MethodName: "GetRepoCacheUsage",
+			Handler:    _Backrest_GetRepoCacheUsage_Handler,
+		},
 		{
 			MethodName: "Stats",
 			Handler:    _Backrest_Stats_Handler,
 		},
+		{
+			MethodName: "GetRepoStatsHistory",
+			Handler:    _Backrest_GetRepoStatsHistory_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Backrest_GetStats_Handler,
+		},
 		{
 			MethodName: "Cancel",
 			Handler:    _Backrest_Cancel_Handler,
@@ -805,10 +2869,42 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLogs",
 			Handler:    _Backrest_GetLogs_Handler,
 		},
+		{
+			MethodName: "GetResticInfo",
+			Handler:    _Backrest_GetResticInfo_Handler,
+		},
 		{
 			MethodName: "GetDownloadURL",
 			Handler:    _Backrest_GetDownloadURL_Handler,
 		},
+		{
+			MethodName: "GetRestoreDownloadURL",
+			Handler:    _Backrest_GetRestoreDownloadURL_Handler,
+		},
+		{
+			MethodName: "CreateShareLink",
+			Handler:    _Backrest_CreateShareLink_Handler,
+		},
+		{
+			MethodName: "GetShareLinks",
+			Handler:    _Backrest_GetShareLinks_Handler,
+		},
+		{
+			MethodName: "RevokeShareLink",
+			Handler:    _Backrest_RevokeShareLink_Handler,
+		},
+		{
+			MethodName: "GetSnapshotFileDownloadURL",
+			Handler:    _Backrest_GetSnapshotFileDownloadURL_Handler,
+		},
+		{
+			MethodName: "GetSnapshotArchiveURL",
+			Handler:    _Backrest_GetSnapshotArchiveURL_Handler,
+		},
+		{
+			MethodName: "AnnotateOperation",
+			Handler:    _Backrest_AnnotateOperation_Handler,
+		},
 		{
 			MethodName: "ClearHistory",
 			Handler:    _Backrest_ClearHistory_Handler,
@@ -817,6 +2913,54 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PathAutocomplete",
 			Handler:    _Backrest_PathAutocomplete_Handler,
 		},
+		{
+			MethodName: "DescribeCron",
+			Handler:    _Backrest_DescribeCron_Handler,
+		},
+		{
+			MethodName: "ValidateCron",
+			Handler:    _Backrest_ValidateCron_Handler,
+		},
+		{
+			MethodName: "SetPaused",
+			Handler:    _Backrest_SetPaused_Handler,
+		},
+		{
+			MethodName: "SetBandwidthLimit",
+			Handler:    _Backrest_SetBandwidthLimit_Handler,
+		},
+		{
+			MethodName: "PutReplica",
+			Handler:    _Backrest_PutReplica_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Backrest_Search_Handler,
+		},
+		{
+			MethodName: "GetAlerts",
+			Handler:    _Backrest_GetAlerts_Handler,
+		},
+		{
+			MethodName: "AcknowledgeAlert",
+			Handler:    _Backrest_AcknowledgeAlert_Handler,
+		},
+		{
+			MethodName: "SnoozeAlert",
+			Handler:    _Backrest_SnoozeAlert_Handler,
+		},
+		{
+			MethodName: "GetHookDeliveries",
+			Handler:    _Backrest_GetHookDeliveries_Handler,
+		},
+		{
+			MethodName: "RetryHookDelivery",
+			Handler:    _Backrest_RetryHookDelivery_Handler,
+		},
+		{
+			MethodName: "GetSchedulerDecisions",
+			Handler:    _Backrest_GetSchedulerDecisions_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -824,6 +2968,26 @@ var Backrest_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Backrest_GetOperationEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeOperations",
+			Handler:       _Backrest_SubscribeOperations_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SearchSnapshots",
+			Handler:       _Backrest_SearchSnapshots_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportOperations",
+			Handler:       _Backrest_ExportOperations_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportOperations",
+			Handler:       _Backrest_ImportOperations_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "v1/service.proto",
 }
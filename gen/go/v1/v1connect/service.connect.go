@@ -44,6 +44,9 @@ const (
 	// BackrestGetOperationEventsProcedure is the fully-qualified name of the Backrest's
 	// GetOperationEvents RPC.
 	BackrestGetOperationEventsProcedure = "/v1.Backrest/GetOperationEvents"
+	// BackrestSubscribeOperationsProcedure is the fully-qualified name of the Backrest's
+	// SubscribeOperations RPC.
+	BackrestSubscribeOperationsProcedure = "/v1.Backrest/SubscribeOperations"
 	// BackrestGetOperationsProcedure is the fully-qualified name of the Backrest's GetOperations RPC.
 	BackrestGetOperationsProcedure = "/v1.Backrest/GetOperations"
 	// BackrestListSnapshotsProcedure is the fully-qualified name of the Backrest's ListSnapshots RPC.
@@ -51,55 +54,230 @@ const (
 	// BackrestListSnapshotFilesProcedure is the fully-qualified name of the Backrest's
 	// ListSnapshotFiles RPC.
 	BackrestListSnapshotFilesProcedure = "/v1.Backrest/ListSnapshotFiles"
+	// BackrestListRestorePointsProcedure is the fully-qualified name of the Backrest's
+	// ListRestorePoints RPC.
+	BackrestListRestorePointsProcedure = "/v1.Backrest/ListRestorePoints"
+	// BackrestDiffSnapshotsProcedure is the fully-qualified name of the Backrest's DiffSnapshots RPC.
+	BackrestDiffSnapshotsProcedure = "/v1.Backrest/DiffSnapshots"
+	// BackrestSearchSnapshotsProcedure is the fully-qualified name of the Backrest's SearchSnapshots
+	// RPC.
+	BackrestSearchSnapshotsProcedure = "/v1.Backrest/SearchSnapshots"
+	// BackrestListFileVersionsProcedure is the fully-qualified name of the Backrest's ListFileVersions
+	// RPC.
+	BackrestListFileVersionsProcedure = "/v1.Backrest/ListFileVersions"
+	// BackrestTagSnapshotsProcedure is the fully-qualified name of the Backrest's TagSnapshots RPC.
+	BackrestTagSnapshotsProcedure = "/v1.Backrest/TagSnapshots"
+	// BackrestPreviewRewriteSnapshotsProcedure is the fully-qualified name of the Backrest's
+	// PreviewRewriteSnapshots RPC.
+	BackrestPreviewRewriteSnapshotsProcedure = "/v1.Backrest/PreviewRewriteSnapshots"
+	// BackrestRewriteSnapshotsProcedure is the fully-qualified name of the Backrest's RewriteSnapshots
+	// RPC.
+	BackrestRewriteSnapshotsProcedure = "/v1.Backrest/RewriteSnapshots"
+	// BackrestGetSnapshotStatsProcedure is the fully-qualified name of the Backrest's GetSnapshotStats
+	// RPC.
+	BackrestGetSnapshotStatsProcedure = "/v1.Backrest/GetSnapshotStats"
 	// BackrestIndexSnapshotsProcedure is the fully-qualified name of the Backrest's IndexSnapshots RPC.
 	BackrestIndexSnapshotsProcedure = "/v1.Backrest/IndexSnapshots"
 	// BackrestBackupProcedure is the fully-qualified name of the Backrest's Backup RPC.
 	BackrestBackupProcedure = "/v1.Backrest/Backup"
+	// BackrestBackupWithAnnotationsProcedure is the fully-qualified name of the Backrest's
+	// BackupWithAnnotations RPC.
+	BackrestBackupWithAnnotationsProcedure = "/v1.Backrest/BackupWithAnnotations"
+	// BackrestPreviewBackupProcedure is the fully-qualified name of the Backrest's PreviewBackup RPC.
+	BackrestPreviewBackupProcedure = "/v1.Backrest/PreviewBackup"
 	// BackrestPruneProcedure is the fully-qualified name of the Backrest's Prune RPC.
 	BackrestPruneProcedure = "/v1.Backrest/Prune"
 	// BackrestForgetProcedure is the fully-qualified name of the Backrest's Forget RPC.
 	BackrestForgetProcedure = "/v1.Backrest/Forget"
+	// BackrestCheckProcedure is the fully-qualified name of the Backrest's Check RPC.
+	BackrestCheckProcedure = "/v1.Backrest/Check"
+	// BackrestInitRepoProcedure is the fully-qualified name of the Backrest's InitRepo RPC.
+	BackrestInitRepoProcedure = "/v1.Backrest/InitRepo"
+	// BackrestMigrateRepoProcedure is the fully-qualified name of the Backrest's MigrateRepo RPC.
+	BackrestMigrateRepoProcedure = "/v1.Backrest/MigrateRepo"
+	// BackrestGetRepoUpgradeProcedure is the fully-qualified name of the Backrest's GetRepoUpgrade RPC.
+	BackrestGetRepoUpgradeProcedure = "/v1.Backrest/GetRepoUpgrade"
+	// BackrestUpgradeRepoProcedure is the fully-qualified name of the Backrest's UpgradeRepo RPC.
+	BackrestUpgradeRepoProcedure = "/v1.Backrest/UpgradeRepo"
+	// BackrestRecoverRepoProcedure is the fully-qualified name of the Backrest's RecoverRepo RPC.
+	BackrestRecoverRepoProcedure = "/v1.Backrest/RecoverRepo"
 	// BackrestRestoreProcedure is the fully-qualified name of the Backrest's Restore RPC.
 	BackrestRestoreProcedure = "/v1.Backrest/Restore"
+	// BackrestGetRestoreConflictsProcedure is the fully-qualified name of the Backrest's
+	// GetRestoreConflicts RPC.
+	BackrestGetRestoreConflictsProcedure = "/v1.Backrest/GetRestoreConflicts"
+	// BackrestPreviewRestoreProcedure is the fully-qualified name of the Backrest's PreviewRestore RPC.
+	BackrestPreviewRestoreProcedure = "/v1.Backrest/PreviewRestore"
 	// BackrestUnlockProcedure is the fully-qualified name of the Backrest's Unlock RPC.
 	BackrestUnlockProcedure = "/v1.Backrest/Unlock"
+	// BackrestMountRepoProcedure is the fully-qualified name of the Backrest's MountRepo RPC.
+	BackrestMountRepoProcedure = "/v1.Backrest/MountRepo"
+	// BackrestUnmountRepoProcedure is the fully-qualified name of the Backrest's UnmountRepo RPC.
+	BackrestUnmountRepoProcedure = "/v1.Backrest/UnmountRepo"
+	// BackrestGetMountsProcedure is the fully-qualified name of the Backrest's GetMounts RPC.
+	BackrestGetMountsProcedure = "/v1.Backrest/GetMounts"
+	// BackrestListRepoKeysProcedure is the fully-qualified name of the Backrest's ListRepoKeys RPC.
+	BackrestListRepoKeysProcedure = "/v1.Backrest/ListRepoKeys"
+	// BackrestAddRepoKeyProcedure is the fully-qualified name of the Backrest's AddRepoKey RPC.
+	BackrestAddRepoKeyProcedure = "/v1.Backrest/AddRepoKey"
+	// BackrestRemoveRepoKeyProcedure is the fully-qualified name of the Backrest's RemoveRepoKey RPC.
+	BackrestRemoveRepoKeyProcedure = "/v1.Backrest/RemoveRepoKey"
+	// BackrestChangeRepoPasswordProcedure is the fully-qualified name of the Backrest's
+	// ChangeRepoPassword RPC.
+	BackrestChangeRepoPasswordProcedure = "/v1.Backrest/ChangeRepoPassword"
+	// BackrestGetRepoCacheUsageProcedure is the fully-qualified name of the Backrest's
+	// GetRepoCacheUsage RPC.
+	BackrestGetRepoCacheUsageProcedure = "/v1.Backrest/GetRepoCacheUsage"
 	// BackrestStatsProcedure is the fully-qualified name of the Backrest's Stats RPC.
 	BackrestStatsProcedure = "/v1.Backrest/Stats"
+	// BackrestGetRepoStatsHistoryProcedure is the fully-qualified name of the Backrest's
+	// GetRepoStatsHistory RPC.
+	BackrestGetRepoStatsHistoryProcedure = "/v1.Backrest/GetRepoStatsHistory"
+	// BackrestGetStatsProcedure is the fully-qualified name of the Backrest's GetStats RPC.
+	BackrestGetStatsProcedure = "/v1.Backrest/GetStats"
 	// BackrestCancelProcedure is the fully-qualified name of the Backrest's Cancel RPC.
 	BackrestCancelProcedure = "/v1.Backrest/Cancel"
 	// BackrestGetLogsProcedure is the fully-qualified name of the Backrest's GetLogs RPC.
 	BackrestGetLogsProcedure = "/v1.Backrest/GetLogs"
+	// BackrestGetResticInfoProcedure is the fully-qualified name of the Backrest's GetResticInfo RPC.
+	BackrestGetResticInfoProcedure = "/v1.Backrest/GetResticInfo"
 	// BackrestGetDownloadURLProcedure is the fully-qualified name of the Backrest's GetDownloadURL RPC.
 	BackrestGetDownloadURLProcedure = "/v1.Backrest/GetDownloadURL"
+	// BackrestGetRestoreDownloadURLProcedure is the fully-qualified name of the Backrest's
+	// GetRestoreDownloadURL RPC.
+	BackrestGetRestoreDownloadURLProcedure = "/v1.Backrest/GetRestoreDownloadURL"
+	// BackrestCreateShareLinkProcedure is the fully-qualified name of the Backrest's CreateShareLink
+	// RPC.
+	BackrestCreateShareLinkProcedure = "/v1.Backrest/CreateShareLink"
+	// BackrestGetShareLinksProcedure is the fully-qualified name of the Backrest's GetShareLinks RPC.
+	BackrestGetShareLinksProcedure = "/v1.Backrest/GetShareLinks"
+	// BackrestRevokeShareLinkProcedure is the fully-qualified name of the Backrest's RevokeShareLink
+	// RPC.
+	BackrestRevokeShareLinkProcedure = "/v1.Backrest/RevokeShareLink"
+	// BackrestGetSnapshotFileDownloadURLProcedure is the fully-qualified name of the Backrest's
+	// GetSnapshotFileDownloadURL RPC.
+	BackrestGetSnapshotFileDownloadURLProcedure = "/v1.Backrest/GetSnapshotFileDownloadURL"
+	// BackrestGetSnapshotArchiveURLProcedure is the fully-qualified name of the Backrest's
+	// GetSnapshotArchiveURL RPC.
+	BackrestGetSnapshotArchiveURLProcedure = "/v1.Backrest/GetSnapshotArchiveURL"
+	// BackrestExportOperationsProcedure is the fully-qualified name of the Backrest's ExportOperations
+	// RPC.
+	BackrestExportOperationsProcedure = "/v1.Backrest/ExportOperations"
+	// BackrestImportOperationsProcedure is the fully-qualified name of the Backrest's ImportOperations
+	// RPC.
+	BackrestImportOperationsProcedure = "/v1.Backrest/ImportOperations"
+	// BackrestAnnotateOperationProcedure is the fully-qualified name of the Backrest's
+	// AnnotateOperation RPC.
+	BackrestAnnotateOperationProcedure = "/v1.Backrest/AnnotateOperation"
 	// BackrestClearHistoryProcedure is the fully-qualified name of the Backrest's ClearHistory RPC.
 	BackrestClearHistoryProcedure = "/v1.Backrest/ClearHistory"
 	// BackrestPathAutocompleteProcedure is the fully-qualified name of the Backrest's PathAutocomplete
 	// RPC.
 	BackrestPathAutocompleteProcedure = "/v1.Backrest/PathAutocomplete"
+	// BackrestDescribeCronProcedure is the fully-qualified name of the Backrest's DescribeCron RPC.
+	BackrestDescribeCronProcedure = "/v1.Backrest/DescribeCron"
+	// BackrestValidateCronProcedure is the fully-qualified name of the Backrest's ValidateCron RPC.
+	BackrestValidateCronProcedure = "/v1.Backrest/ValidateCron"
+	// BackrestSetPausedProcedure is the fully-qualified name of the Backrest's SetPaused RPC.
+	BackrestSetPausedProcedure = "/v1.Backrest/SetPaused"
+	// BackrestSetBandwidthLimitProcedure is the fully-qualified name of the Backrest's
+	// SetBandwidthLimit RPC.
+	BackrestSetBandwidthLimitProcedure = "/v1.Backrest/SetBandwidthLimit"
+	// BackrestPutReplicaProcedure is the fully-qualified name of the Backrest's PutReplica RPC.
+	BackrestPutReplicaProcedure = "/v1.Backrest/PutReplica"
+	// BackrestSearchProcedure is the fully-qualified name of the Backrest's Search RPC.
+	BackrestSearchProcedure = "/v1.Backrest/Search"
+	// BackrestGetAlertsProcedure is the fully-qualified name of the Backrest's GetAlerts RPC.
+	BackrestGetAlertsProcedure = "/v1.Backrest/GetAlerts"
+	// BackrestAcknowledgeAlertProcedure is the fully-qualified name of the Backrest's AcknowledgeAlert
+	// RPC.
+	BackrestAcknowledgeAlertProcedure = "/v1.Backrest/AcknowledgeAlert"
+	// BackrestSnoozeAlertProcedure is the fully-qualified name of the Backrest's SnoozeAlert RPC.
+	BackrestSnoozeAlertProcedure = "/v1.Backrest/SnoozeAlert"
+	// BackrestGetHookDeliveriesProcedure is the fully-qualified name of the Backrest's
+	// GetHookDeliveries RPC.
+	BackrestGetHookDeliveriesProcedure = "/v1.Backrest/GetHookDeliveries"
+	// BackrestRetryHookDeliveryProcedure is the fully-qualified name of the Backrest's
+	// RetryHookDelivery RPC.
+	BackrestRetryHookDeliveryProcedure = "/v1.Backrest/RetryHookDelivery"
+	// BackrestGetSchedulerDecisionsProcedure is the fully-qualified name of the Backrest's
+	// GetSchedulerDecisions RPC.
+	BackrestGetSchedulerDecisionsProcedure = "/v1.Backrest/GetSchedulerDecisions"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	backrestServiceDescriptor                  = v1.File_v1_service_proto.Services().ByName("Backrest")
-	backrestGetConfigMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("GetConfig")
-	backrestSetConfigMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("SetConfig")
-	backrestAddRepoMethodDescriptor            = backrestServiceDescriptor.Methods().ByName("AddRepo")
-	backrestGetOperationEventsMethodDescriptor = backrestServiceDescriptor.Methods().ByName("GetOperationEvents")
-	backrestGetOperationsMethodDescriptor      = backrestServiceDescriptor.Methods().ByName("GetOperations")
-	backrestListSnapshotsMethodDescriptor      = backrestServiceDescriptor.Methods().ByName("ListSnapshots")
-	backrestListSnapshotFilesMethodDescriptor  = backrestServiceDescriptor.Methods().ByName("ListSnapshotFiles")
-	backrestIndexSnapshotsMethodDescriptor     = backrestServiceDescriptor.Methods().ByName("IndexSnapshots")
-	backrestBackupMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("Backup")
-	backrestPruneMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("Prune")
-	backrestForgetMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("Forget")
-	backrestRestoreMethodDescriptor            = backrestServiceDescriptor.Methods().ByName("Restore")
-	backrestUnlockMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("Unlock")
-	backrestStatsMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("Stats")
-	backrestCancelMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("Cancel")
-	backrestGetLogsMethodDescriptor            = backrestServiceDescriptor.Methods().ByName("GetLogs")
-	backrestGetDownloadURLMethodDescriptor     = backrestServiceDescriptor.Methods().ByName("GetDownloadURL")
-	backrestClearHistoryMethodDescriptor       = backrestServiceDescriptor.Methods().ByName("ClearHistory")
-	backrestPathAutocompleteMethodDescriptor   = backrestServiceDescriptor.Methods().ByName("PathAutocomplete")
+	backrestServiceDescriptor                          = v1.File_v1_service_proto.Services().ByName("Backrest")
+	backrestGetConfigMethodDescriptor                  = backrestServiceDescriptor.Methods().ByName("GetConfig")
+	backrestSetConfigMethodDescriptor                  = backrestServiceDescriptor.Methods().ByName("SetConfig")
+	backrestAddRepoMethodDescriptor                    = backrestServiceDescriptor.Methods().ByName("AddRepo")
+	backrestGetOperationEventsMethodDescriptor         = backrestServiceDescriptor.Methods().ByName("GetOperationEvents")
+	backrestSubscribeOperationsMethodDescriptor        = backrestServiceDescriptor.Methods().ByName("SubscribeOperations")
+	backrestGetOperationsMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("GetOperations")
+	backrestListSnapshotsMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("ListSnapshots")
+	backrestListSnapshotFilesMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("ListSnapshotFiles")
+	backrestListRestorePointsMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("ListRestorePoints")
+	backrestDiffSnapshotsMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("DiffSnapshots")
+	backrestSearchSnapshotsMethodDescriptor            = backrestServiceDescriptor.Methods().ByName("SearchSnapshots")
+	backrestListFileVersionsMethodDescriptor           = backrestServiceDescriptor.Methods().ByName("ListFileVersions")
+	backrestTagSnapshotsMethodDescriptor               = backrestServiceDescriptor.Methods().ByName("TagSnapshots")
+	backrestPreviewRewriteSnapshotsMethodDescriptor    = backrestServiceDescriptor.Methods().ByName("PreviewRewriteSnapshots")
+	backrestRewriteSnapshotsMethodDescriptor           = backrestServiceDescriptor.Methods().ByName("RewriteSnapshots")
+	backrestGetSnapshotStatsMethodDescriptor           = backrestServiceDescriptor.Methods().ByName("GetSnapshotStats")
+	backrestIndexSnapshotsMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("IndexSnapshots")
+	backrestBackupMethodDescriptor                     = backrestServiceDescriptor.Methods().ByName("Backup")
+	backrestBackupWithAnnotationsMethodDescriptor      = backrestServiceDescriptor.Methods().ByName("BackupWithAnnotations")
+	backrestPreviewBackupMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("PreviewBackup")
+	backrestPruneMethodDescriptor                      = backrestServiceDescriptor.Methods().ByName("Prune")
+	backrestForgetMethodDescriptor                     = backrestServiceDescriptor.Methods().ByName("Forget")
+	backrestCheckMethodDescriptor                      = backrestServiceDescriptor.Methods().ByName("Check")
+	backrestInitRepoMethodDescriptor                   = backrestServiceDescriptor.Methods().ByName("InitRepo")
+	backrestMigrateRepoMethodDescriptor                = backrestServiceDescriptor.Methods().ByName("MigrateRepo")
+	backrestGetRepoUpgradeMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("GetRepoUpgrade")
+	backrestUpgradeRepoMethodDescriptor                = backrestServiceDescriptor.Methods().ByName("UpgradeRepo")
+	backrestRecoverRepoMethodDescriptor                = backrestServiceDescriptor.Methods().ByName("RecoverRepo")
+	backrestRestoreMethodDescriptor                    = backrestServiceDescriptor.Methods().ByName("Restore")
+	backrestGetRestoreConflictsMethodDescriptor        = backrestServiceDescriptor.Methods().ByName("GetRestoreConflicts")
+	backrestPreviewRestoreMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("PreviewRestore")
+	backrestUnlockMethodDescriptor                     = backrestServiceDescriptor.Methods().ByName("Unlock")
+	backrestMountRepoMethodDescriptor                  = backrestServiceDescriptor.Methods().ByName("MountRepo")
+	backrestUnmountRepoMethodDescriptor                = backrestServiceDescriptor.Methods().ByName("UnmountRepo")
+	backrestGetMountsMethodDescriptor                  = backrestServiceDescriptor.Methods().ByName("GetMounts")
+	backrestListRepoKeysMethodDescriptor               = backrestServiceDescriptor.Methods().ByName("ListRepoKeys")
+	backrestAddRepoKeyMethodDescriptor                 = backrestServiceDescriptor.Methods().ByName("AddRepoKey")
+	backrestRemoveRepoKeyMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("RemoveRepoKey")
+	backrestChangeRepoPasswordMethodDescriptor         = backrestServiceDescriptor.Methods().ByName("ChangeRepoPassword")
+	backrestGetRepoCacheUsageMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("GetRepoCacheUsage")
+	backrestStatsMethodDescriptor                      = backrestServiceDescriptor.Methods().ByName("Stats")
+	backrestGetRepoStatsHistoryMethodDescriptor        = backrestServiceDescriptor.Methods().ByName("GetRepoStatsHistory")
+	backrestGetStatsMethodDescriptor                   = backrestServiceDescriptor.Methods().ByName("GetStats")
+	backrestCancelMethodDescriptor                     = backrestServiceDescriptor.Methods().ByName("Cancel")
+	backrestGetLogsMethodDescriptor                    = backrestServiceDescriptor.Methods().ByName("GetLogs")
+	backrestGetResticInfoMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("GetResticInfo")
+	backrestGetDownloadURLMethodDescriptor             = backrestServiceDescriptor.Methods().ByName("GetDownloadURL")
+	backrestGetRestoreDownloadURLMethodDescriptor      = backrestServiceDescriptor.Methods().ByName("GetRestoreDownloadURL")
+	backrestCreateShareLinkMethodDescriptor            = backrestServiceDescriptor.Methods().ByName("CreateShareLink")
+	backrestGetShareLinksMethodDescriptor              = backrestServiceDescriptor.Methods().ByName("GetShareLinks")
+	backrestRevokeShareLinkMethodDescriptor            = backrestServiceDescriptor.Methods().ByName("RevokeShareLink")
+	backrestGetSnapshotFileDownloadURLMethodDescriptor = backrestServiceDescriptor.Methods().ByName("GetSnapshotFileDownloadURL")
+	backrestGetSnapshotArchiveURLMethodDescriptor      = backrestServiceDescriptor.Methods().ByName("GetSnapshotArchiveURL")
+	backrestExportOperationsMethodDescriptor           = backrestServiceDescriptor.Methods().ByName("ExportOperations")
+	backrestImportOperationsMethodDescriptor           = backrestServiceDescriptor.Methods().ByName("ImportOperations")
+	backrestAnnotateOperationMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("AnnotateOperation")
+	backrestClearHistoryMethodDescriptor               = backrestServiceDescriptor.Methods().ByName("ClearHistory")
+	backrestPathAutocompleteMethodDescriptor           = backrestServiceDescriptor.Methods().ByName("PathAutocomplete")
+	backrestDescribeCronMethodDescriptor               = backrestServiceDescriptor.Methods().ByName("DescribeCron")
+	backrestValidateCronMethodDescriptor               = backrestServiceDescriptor.Methods().ByName("ValidateCron")
+	backrestSetPausedMethodDescriptor                  = backrestServiceDescriptor.Methods().ByName("SetPaused")
+	backrestSetBandwidthLimitMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("SetBandwidthLimit")
+	backrestPutReplicaMethodDescriptor                 = backrestServiceDescriptor.Methods().ByName("PutReplica")
+	backrestSearchMethodDescriptor                     = backrestServiceDescriptor.Methods().ByName("Search")
+	backrestGetAlertsMethodDescriptor                  = backrestServiceDescriptor.Methods().ByName("GetAlerts")
+	backrestAcknowledgeAlertMethodDescriptor           = backrestServiceDescriptor.Methods().ByName("AcknowledgeAlert")
+	backrestSnoozeAlertMethodDescriptor                = backrestServiceDescriptor.Methods().ByName("SnoozeAlert")
+	backrestGetHookDeliveriesMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("GetHookDeliveries")
+	backrestRetryHookDeliveryMethodDescriptor          = backrestServiceDescriptor.Methods().ByName("RetryHookDelivery")
+	backrestGetSchedulerDecisionsMethodDescriptor      = backrestServiceDescriptor.Methods().ByName("GetSchedulerDecisions")
 )
 
 // BackrestClient is a client for the v1.Backrest service.
@@ -107,34 +285,166 @@ type BackrestClient interface {
 	GetConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.Config], error)
 	SetConfig(context.Context, *connect.Request[v1.Config]) (*connect.Response[v1.Config], error)
 	AddRepo(context.Context, *connect.Request[v1.Repo]) (*connect.Response[v1.Config], error)
+	// GetOperationEvents streams every operation event the caller may see, see SubscribeOperations.
 	GetOperationEvents(context.Context, *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[v1.OperationEvent], error)
+	// SubscribeOperations streams the events of operations as they are created, updated and deleted, optionally only those
+	// of a plan or repo. The stream is closed if the client falls behind, clients reconnect and fetch the operations they
+	// may have missed with GetOperations.
+	SubscribeOperations(context.Context, *connect.Request[v1.SubscribeOperationsRequest]) (*connect.ServerStreamForClient[v1.OperationEvent], error)
 	GetOperations(context.Context, *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error)
 	ListSnapshots(context.Context, *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ResticSnapshotList], error)
 	ListSnapshotFiles(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[v1.ListSnapshotFilesResponse], error)
+	// ListRestorePoints collapses a repo's snapshots into one restore point per plan and calendar day or week, newest first.
+	ListRestorePoints(context.Context, *connect.Request[v1.ListRestorePointsRequest]) (*connect.Response[v1.RestorePointList], error)
+	// DiffSnapshots lists the files added, removed and modified between two snapshots, by default between a snapshot and
+	// its parent i.e. what changed in the backup that created it.
+	DiffSnapshots(context.Context, *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.SnapshotDiff], error)
+	// SearchSnapshots finds files whose name matches a glob pattern in a repo's snapshots, matches are streamed a
+	// snapshot at a time as they are found.
+	SearchSnapshots(context.Context, *connect.Request[v1.SearchSnapshotsRequest]) (*connect.ServerStreamForClient[v1.SnapshotFileMatch], error)
+	// ListFileVersions lists the versions of a file in a plan's snapshots newest first, so that the version to restore can
+	// be picked from the file's history.
+	ListFileVersions(context.Context, *connect.Request[v1.ListFileVersionsRequest]) (*connect.Response[v1.FileVersionList], error)
+	// TagSnapshots schedules a restic tag adding and removing tags of snapshots, it returns the ID of the scheduled operation.
+	// restic saves each changed snapshot under a new ID. The plan and instance tags backrest selects snapshots by can't be
+	// changed.
+	TagSnapshots(context.Context, *connect.Request[v1.TagSnapshotsRequest]) (*connect.Response[types.Int64Value], error)
+	// PreviewRewriteSnapshots reports the snapshots a rewrite would change by running restic rewrite --dry-run, the preview's
+	// digest must be passed back to RewriteSnapshots to confirm the rewrite.
+	PreviewRewriteSnapshots(context.Context, *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[v1.RewritePreview], error)
+	// RewriteSnapshots schedules a restic rewrite removing the excluded files from snapshots, it returns the ID of the
+	// scheduled operation. restic saves each changed snapshot under a new ID, the originals are kept unless forget is set.
+	RewriteSnapshots(context.Context, *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[types.Int64Value], error)
+	// GetSnapshotStats returns statistics for a snapshot, computed on the first request and cached afterwards.
+	GetSnapshotStats(context.Context, *connect.Request[v1.GetSnapshotStatsRequest]) (*connect.Response[v1.SnapshotStats], error)
 	// IndexSnapshots triggers indexin. It accepts a repo id and returns empty if the task is enqueued.
 	IndexSnapshots(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
 	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
 	Backup(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
-	// Prune schedules a prune operation. It accepts a plan id and returns empty if the task is enqueued.
-	Prune(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
-	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
-	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[emptypb.Empty], error)
+	// BackupWithAnnotations runs a plan's backup like Backup and attaches the annotations to its snapshot as tags, e.g. the
+	// version of the application being released, so that the snapshot can be found by them later.
+	BackupWithAnnotations(context.Context, *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error)
+	// PreviewBackup runs a plan's backup with restic backup --dry-run and reports what it would add to the repo, nothing
+	// is written to the repo. It accepts a plan id.
+	PreviewBackup(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.BackupPreview], error)
+	// Prune schedules a prune operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Prune(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
+	// Forget schedules a forget operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[types.Int64Value], error)
+	// Check schedules a check operation. It accepts a repo id and returns the ID of the scheduled operation.
+	Check(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
+	// InitRepo schedules a restic init of a repo already in the config, e.g. one whose storage was recreated. It accepts a
+	// repo id and returns the ID of the scheduled operation. Repos added with AddRepo are initialized when they are added.
+	InitRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
+	// MigrateRepo schedules a restic migrate of the repo, by default upgrading it to the v2 repository format. It returns
+	// the ID of the scheduled operation.
+	MigrateRepo(context.Context, *connect.Request[v1.MigrateRepoRequest]) (*connect.Response[types.Int64Value], error)
+	// GetRepoUpgrade checks whether the repo uses an old format version or holds data stored uncompressed and describes
+	// the benefits and risks of upgrading it.
+	GetRepoUpgrade(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoUpgradeAssessment], error)
+	// UpgradeRepo schedules an upgrade of the repo to the v2 format, optionally followed by repacking the data stored
+	// uncompressed. It returns the ID of the scheduled operation, an interrupted upgrade is resumed when backrest next starts.
+	UpgradeRepo(context.Context, *connect.Request[v1.UpgradeRepoRequest]) (*connect.Response[types.Int64Value], error)
+	// RecoverRepo schedules a restic recover of the repo, which saves the data of forgotten snapshots that was not pruned
+	// yet into a new snapshot. It returns the ID of the recover operation.
+	RecoverRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
+	// GetRestoreConflicts reports the local files an in place restore would overwrite, the report's digest must be passed
+	// back to Restore to confirm the in place restore.
+	GetRestoreConflicts(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreConflictReport], error)
+	// PreviewRestore lists the files a restore would write and checks that the target can be written before the restore
+	// is scheduled, nothing is restored.
+	PreviewRestore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestorePreview], error)
 	// Unlock synchronously attempts to unlock the repo. Will block if other operations are in progress.
 	Unlock(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
+	// MountRepo mounts the repo's snapshots as a read only filesystem with restic mount, under backrest's data directory.
+	// The repo is unmounted once it wasn't requested for its idle timeout, mounting a mounted repo restarts the timeout.
+	MountRepo(context.Context, *connect.Request[v1.MountRepoRequest]) (*connect.Response[v1.RepoMount], error)
+	// UnmountRepo unmounts a repo mounted with MountRepo, it accepts a repo id.
+	UnmountRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
+	// GetMounts returns the mounted repos the caller may access.
+	GetMounts(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RepoMountList], error)
+	// ListRepoKeys lists the keys of a repo, it accepts a repo id.
+	ListRepoKeys(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoKeyList], error)
+	// AddRepoKey adds a key with another password to a repo e.g. for another machine, the repo's password in the config
+	// is unchanged.
+	AddRepoKey(context.Context, *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error)
+	// RemoveRepoKey removes a key from a repo, the key holding the repo's password in the config can't be removed.
+	RemoveRepoKey(context.Context, *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error)
+	// ChangeRepoPassword replaces the key holding the repo's password in the config with a key holding the new password
+	// and stores the new password in the config. The old key is only removed once the config is updated.
+	ChangeRepoPassword(context.Context, *connect.Request[v1.ChangeRepoPasswordRequest]) (*connect.Response[v1.Config], error)
+	// GetRepoCacheUsage returns the disk usage of the repo's local restic cache, it accepts a repo id.
+	GetRepoCacheUsage(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoCacheUsage], error)
 	// Stats runs 'restic stats` on the repository and appends the results to the operations log.
 	Stats(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
+	// GetRepoStatsHistory returns the stats collected for a repo over time, oldest first, e.g. to graph the repo's growth.
+	GetRepoStatsHistory(context.Context, *connect.Request[v1.RepoStatsHistoryRequest]) (*connect.Response[v1.RepoStatsHistory], error)
+	// GetStats returns statistics of each plan's backups aggregated from the operation log, e.g. success rates and durations.
+	GetStats(context.Context, *connect.Request[v1.GetStatsRequest]) (*connect.Response[v1.PlanStatsList], error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
 	Cancel(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error)
 	// GetLogs returns the keyed large data for the given operation.
 	GetLogs(context.Context, *connect.Request[v1.LogDataRequest]) (*connect.Response[types.BytesValue], error)
-	// GetDownloadURL returns a signed download URL given a forget operation ID.
+	// GetResticInfo returns the restic binary backrest uses by default and the binary used by each repo the caller may access.
+	GetResticInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ResticInfo], error)
+	// GetDownloadURL returns a signed URL that downloads the files of a restore operation given its ID.
 	GetDownloadURL(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[types.StringValue], error)
+	// GetRestoreDownloadURL returns a signed URL that downloads a file or directory of a restore operation's target. The
+	// path is part of the signature, the URL can't be changed to download other files of the restore.
+	GetRestoreDownloadURL(context.Context, *connect.Request[v1.RestoreDownloadURLRequest]) (*connect.Response[types.StringValue], error)
+	// CreateShareLink creates a passphrase protected link to download a directory or file of a restore without access to
+	// the UI, the link's URL is only returned here.
+	CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error)
+	// GetShareLinks lists the share links of the repos the caller may access.
+	GetShareLinks(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ShareLinkList], error)
+	// RevokeShareLink revokes the share link with the given ID, downloads in progress are not interrupted.
+	RevokeShareLink(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[v1.ShareLink], error)
+	// GetSnapshotFileDownloadURL returns a signed URL that streams a single file from a snapshot without restoring it first.
+	GetSnapshotFileDownloadURL(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error)
+	// GetSnapshotArchiveURL returns a signed URL that streams a directory of a snapshot, the whole snapshot if no path is
+	// given, as an uncompressed tar archive. Appending "?offset=<bytes>" to the URL resumes an interrupted download.
+	GetSnapshotArchiveURL(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error)
+	// ExportOperations streams the operations of the plans and repos the caller may access as newline delimited protojson,
+	// messages are chunks of the output and lines may span messages.
+	ExportOperations(context.Context, *connect.Request[v1.ExportOperationsRequest]) (*connect.ServerStreamForClient[types.BytesValue], error)
+	// ImportOperations adds the operations in a stream of newline delimited protojson, as written by ExportOperations, to
+	// the oplog. Operations are given new IDs, those already in the oplog or unfinished when they were exported are skipped.
+	ImportOperations(context.Context) *connect.ClientStreamForClient[types.BytesValue, v1.ImportOperationsResponse]
+	// AnnotateOperation attaches a note to a finished operation replacing any it has, an empty note removes it. Returns the
+	// updated operation.
+	AnnotateOperation(context.Context, *connect.Request[v1.AnnotateOperationRequest]) (*connect.Response[v1.Operation], error)
 	// Clears the history of operations
 	ClearHistory(context.Context, *connect.Request[v1.ClearHistoryRequest]) (*connect.Response[emptypb.Empty], error)
 	// PathAutocomplete provides path autocompletion options for a given filesystem path.
 	PathAutocomplete(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringList], error)
+	// DescribeCron returns a human readable description of a cron expression. Returns an error if the expression is invalid.
+	DescribeCron(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringValue], error)
+	// ValidateCron returns a description of a cron expression and the next times it fires. Returns an error if the expression is invalid.
+	ValidateCron(context.Context, *connect.Request[v1.ValidateCronRequest]) (*connect.Response[v1.ValidateCronResponse], error)
+	// SetPaused pauses or resumes scheduled tasks for a plan or a repo, returns the updated config.
+	SetPaused(context.Context, *connect.Request[v1.SetPausedRequest]) (*connect.Response[v1.Config], error)
+	// SetBandwidthLimit changes a repo's bandwidth limits, returns the updated config. Backups in progress for the repo are
+	// restarted with the new limits.
+	SetBandwidthLimit(context.Context, *connect.Request[v1.SetBandwidthLimitRequest]) (*connect.Response[v1.Config], error)
+	// PutReplica stores a config replica pushed by another instance, replicas are kept per instance and replace older ones.
+	PutReplica(context.Context, *connect.Request[v1.SealedReplica]) (*connect.Response[emptypb.Empty], error)
+	// Search finds operations and snapshots matching a query across every plan and repo the caller may access, newest first.
+	Search(context.Context, *connect.Request[v1.SearchRequest]) (*connect.Response[v1.SearchResponse], error)
+	// GetAlerts evaluates the health rules and returns the current alerts of the plans and repos the caller may access.
+	GetAlerts(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.AlertList], error)
+	// AcknowledgeAlert suppresses notifications for the alert with the given ID until it's resolved, returns the updated alert.
+	AcknowledgeAlert(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.Alert], error)
+	// SnoozeAlert suppresses notifications for an alert for a duration, returns the updated alert.
+	SnoozeAlert(context.Context, *connect.Request[v1.SnoozeAlertRequest]) (*connect.Response[v1.Alert], error)
+	// GetHookDeliveries lists the hook notifications waiting to be retried and those that were given up on.
+	GetHookDeliveries(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.HookDeliveryList], error)
+	// RetryHookDelivery moves the dead letter with the given ID back to the retry queue, it is attempted immediately.
+	RetryHookDelivery(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error)
+	// GetSchedulerDecisions lists the scheduler's recent decisions about the tasks of the plans and repos the caller may
+	// access, newest first, e.g. to find out why a backup didn't run. Decisions are kept in memory and lost on restart.
+	GetSchedulerDecisions(context.Context, *connect.Request[v1.SchedulerDecisionsRequest]) (*connect.Response[v1.SchedulerDecisionList], error)
 }
 
 // NewBackrestClient constructs a client for the v1.Backrest service. By default, it uses the
@@ -171,6 +481,12 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestGetOperationEventsMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		subscribeOperations: connect.NewClient[v1.SubscribeOperationsRequest, v1.OperationEvent](
+			httpClient,
+			baseURL+BackrestSubscribeOperationsProcedure,
+			connect.WithSchema(backrestSubscribeOperationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		getOperations: connect.NewClient[v1.GetOperationsRequest, v1.OperationList](
 			httpClient,
 			baseURL+BackrestGetOperationsProcedure,
@@ -189,6 +505,54 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestListSnapshotFilesMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		listRestorePoints: connect.NewClient[v1.ListRestorePointsRequest, v1.RestorePointList](
+			httpClient,
+			baseURL+BackrestListRestorePointsProcedure,
+			connect.WithSchema(backrestListRestorePointsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		diffSnapshots: connect.NewClient[v1.DiffSnapshotsRequest, v1.SnapshotDiff](
+			httpClient,
+			baseURL+BackrestDiffSnapshotsProcedure,
+			connect.WithSchema(backrestDiffSnapshotsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		searchSnapshots: connect.NewClient[v1.SearchSnapshotsRequest, v1.SnapshotFileMatch](
+			httpClient,
+			baseURL+BackrestSearchSnapshotsProcedure,
+			connect.WithSchema(backrestSearchSnapshotsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listFileVersions: connect.NewClient[v1.ListFileVersionsRequest, v1.FileVersionList](
+			httpClient,
+			baseURL+BackrestListFileVersionsProcedure,
+			connect.WithSchema(backrestListFileVersionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		tagSnapshots: connect.NewClient[v1.TagSnapshotsRequest, types.Int64Value](
+			httpClient,
+			baseURL+BackrestTagSnapshotsProcedure,
+			connect.WithSchema(backrestTagSnapshotsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		previewRewriteSnapshots: connect.NewClient[v1.RewriteSnapshotsRequest, v1.RewritePreview](
+			httpClient,
+			baseURL+BackrestPreviewRewriteSnapshotsProcedure,
+			connect.WithSchema(backrestPreviewRewriteSnapshotsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		rewriteSnapshots: connect.NewClient[v1.RewriteSnapshotsRequest, types.Int64Value](
+			httpClient,
+			baseURL+BackrestRewriteSnapshotsProcedure,
+			connect.WithSchema(backrestRewriteSnapshotsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getSnapshotStats: connect.NewClient[v1.GetSnapshotStatsRequest, v1.SnapshotStats](
+			httpClient,
+			baseURL+BackrestGetSnapshotStatsProcedure,
+			connect.WithSchema(backrestGetSnapshotStatsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		indexSnapshots: connect.NewClient[types.StringValue, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestIndexSnapshotsProcedure,
@@ -201,36 +565,156 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestBackupMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
-		prune: connect.NewClient[types.StringValue, emptypb.Empty](
+		backupWithAnnotations: connect.NewClient[v1.BackupRequest, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestBackupWithAnnotationsProcedure,
+			connect.WithSchema(backrestBackupWithAnnotationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		previewBackup: connect.NewClient[types.StringValue, v1.BackupPreview](
+			httpClient,
+			baseURL+BackrestPreviewBackupProcedure,
+			connect.WithSchema(backrestPreviewBackupMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		prune: connect.NewClient[types.StringValue, types.Int64Value](
 			httpClient,
 			baseURL+BackrestPruneProcedure,
 			connect.WithSchema(backrestPruneMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
-		forget: connect.NewClient[v1.ForgetRequest, emptypb.Empty](
+		forget: connect.NewClient[v1.ForgetRequest, types.Int64Value](
 			httpClient,
 			baseURL+BackrestForgetProcedure,
 			connect.WithSchema(backrestForgetMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		check: connect.NewClient[types.StringValue, types.Int64Value](
+			httpClient,
+			baseURL+BackrestCheckProcedure,
+			connect.WithSchema(backrestCheckMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		initRepo: connect.NewClient[types.StringValue, types.Int64Value](
+			httpClient,
+			baseURL+BackrestInitRepoProcedure,
+			connect.WithSchema(backrestInitRepoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		migrateRepo: connect.NewClient[v1.MigrateRepoRequest, types.Int64Value](
+			httpClient,
+			baseURL+BackrestMigrateRepoProcedure,
+			connect.WithSchema(backrestMigrateRepoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getRepoUpgrade: connect.NewClient[types.StringValue, v1.RepoUpgradeAssessment](
+			httpClient,
+			baseURL+BackrestGetRepoUpgradeProcedure,
+			connect.WithSchema(backrestGetRepoUpgradeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		upgradeRepo: connect.NewClient[v1.UpgradeRepoRequest, types.Int64Value](
+			httpClient,
+			baseURL+BackrestUpgradeRepoProcedure,
+			connect.WithSchema(backrestUpgradeRepoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		recoverRepo: connect.NewClient[types.StringValue, types.Int64Value](
+			httpClient,
+			baseURL+BackrestRecoverRepoProcedure,
+			connect.WithSchema(backrestRecoverRepoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		restore: connect.NewClient[v1.RestoreSnapshotRequest, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestRestoreProcedure,
 			connect.WithSchema(backrestRestoreMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getRestoreConflicts: connect.NewClient[v1.RestoreSnapshotRequest, v1.RestoreConflictReport](
+			httpClient,
+			baseURL+BackrestGetRestoreConflictsProcedure,
+			connect.WithSchema(backrestGetRestoreConflictsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		previewRestore: connect.NewClient[v1.RestoreSnapshotRequest, v1.RestorePreview](
+			httpClient,
+			baseURL+BackrestPreviewRestoreProcedure,
+			connect.WithSchema(backrestPreviewRestoreMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		unlock: connect.NewClient[types.StringValue, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestUnlockProcedure,
 			connect.WithSchema(backrestUnlockMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		mountRepo: connect.NewClient[v1.MountRepoRequest, v1.RepoMount](
+			httpClient,
+			baseURL+BackrestMountRepoProcedure,
+			connect.WithSchema(backrestMountRepoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		unmountRepo: connect.NewClient[types.StringValue, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestUnmountRepoProcedure,
+			connect.WithSchema(backrestUnmountRepoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getMounts: connect.NewClient[emptypb.Empty, v1.RepoMountList](
+			httpClient,
+			baseURL+BackrestGetMountsProcedure,
+			connect.WithSchema(backrestGetMountsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listRepoKeys: connect.NewClient[types.StringValue, v1.RepoKeyList](
+			httpClient,
+			baseURL+BackrestListRepoKeysProcedure,
+			connect.WithSchema(backrestListRepoKeysMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		addRepoKey: connect.NewClient[v1.AddRepoKeyRequest, v1.RepoKey](
+			httpClient,
+			baseURL+BackrestAddRepoKeyProcedure,
+			connect.WithSchema(backrestAddRepoKeyMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		removeRepoKey: connect.NewClient[v1.RemoveRepoKeyRequest, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestRemoveRepoKeyProcedure,
+			connect.WithSchema(backrestRemoveRepoKeyMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		changeRepoPassword: connect.NewClient[v1.ChangeRepoPasswordRequest, v1.Config](
+			httpClient,
+			baseURL+BackrestChangeRepoPasswordProcedure,
+			connect.WithSchema(backrestChangeRepoPasswordMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getRepoCacheUsage: connect.NewClient[types.StringValue, v1.RepoCacheUsage](
+			httpClient,
+			baseURL+BackrestGetRepoCacheUsageProcedure,
+			connect.WithSchema(backrestGetRepoCacheUsageMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		stats: connect.NewClient[types.StringValue, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestStatsProcedure,
 			connect.WithSchema(backrestStatsMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getRepoStatsHistory: connect.NewClient[v1.RepoStatsHistoryRequest, v1.RepoStatsHistory](
+			httpClient,
+			baseURL+BackrestGetRepoStatsHistoryProcedure,
+			connect.WithSchema(backrestGetRepoStatsHistoryMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getStats: connect.NewClient[v1.GetStatsRequest, v1.PlanStatsList](
+			httpClient,
+			baseURL+BackrestGetStatsProcedure,
+			connect.WithSchema(backrestGetStatsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		cancel: connect.NewClient[types.Int64Value, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestCancelProcedure,
@@ -243,12 +727,72 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestGetLogsMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getResticInfo: connect.NewClient[emptypb.Empty, v1.ResticInfo](
+			httpClient,
+			baseURL+BackrestGetResticInfoProcedure,
+			connect.WithSchema(backrestGetResticInfoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		getDownloadURL: connect.NewClient[types.Int64Value, types.StringValue](
 			httpClient,
 			baseURL+BackrestGetDownloadURLProcedure,
 			connect.WithSchema(backrestGetDownloadURLMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getRestoreDownloadURL: connect.NewClient[v1.RestoreDownloadURLRequest, types.StringValue](
+			httpClient,
+			baseURL+BackrestGetRestoreDownloadURLProcedure,
+			connect.WithSchema(backrestGetRestoreDownloadURLMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createShareLink: connect.NewClient[v1.CreateShareLinkRequest, v1.CreateShareLinkResponse](
+			httpClient,
+			baseURL+BackrestCreateShareLinkProcedure,
+			connect.WithSchema(backrestCreateShareLinkMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getShareLinks: connect.NewClient[emptypb.Empty, v1.ShareLinkList](
+			httpClient,
+			baseURL+BackrestGetShareLinksProcedure,
+			connect.WithSchema(backrestGetShareLinksMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		revokeShareLink: connect.NewClient[types.Int64Value, v1.ShareLink](
+			httpClient,
+			baseURL+BackrestRevokeShareLinkProcedure,
+			connect.WithSchema(backrestRevokeShareLinkMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getSnapshotFileDownloadURL: connect.NewClient[v1.ListSnapshotFilesRequest, types.StringValue](
+			httpClient,
+			baseURL+BackrestGetSnapshotFileDownloadURLProcedure,
+			connect.WithSchema(backrestGetSnapshotFileDownloadURLMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getSnapshotArchiveURL: connect.NewClient[v1.ListSnapshotFilesRequest, types.StringValue](
+			httpClient,
+			baseURL+BackrestGetSnapshotArchiveURLProcedure,
+			connect.WithSchema(backrestGetSnapshotArchiveURLMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exportOperations: connect.NewClient[v1.ExportOperationsRequest, types.BytesValue](
+			httpClient,
+			baseURL+BackrestExportOperationsProcedure,
+			connect.WithSchema(backrestExportOperationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		importOperations: connect.NewClient[types.BytesValue, v1.ImportOperationsResponse](
+			httpClient,
+			baseURL+BackrestImportOperationsProcedure,
+			connect.WithSchema(backrestImportOperationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		annotateOperation: connect.NewClient[v1.AnnotateOperationRequest, v1.Operation](
+			httpClient,
+			baseURL+BackrestAnnotateOperationProcedure,
+			connect.WithSchema(backrestAnnotateOperationMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		clearHistory: connect.NewClient[v1.ClearHistoryRequest, emptypb.Empty](
 			httpClient,
 			baseURL+BackrestClearHistoryProcedure,
@@ -261,30 +805,153 @@ func NewBackrestClient(httpClient connect.HTTPClient, baseURL string, opts ...co
 			connect.WithSchema(backrestPathAutocompleteMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		describeCron: connect.NewClient[types.StringValue, types.StringValue](
+			httpClient,
+			baseURL+BackrestDescribeCronProcedure,
+			connect.WithSchema(backrestDescribeCronMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		validateCron: connect.NewClient[v1.ValidateCronRequest, v1.ValidateCronResponse](
+			httpClient,
+			baseURL+BackrestValidateCronProcedure,
+			connect.WithSchema(backrestValidateCronMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setPaused: connect.NewClient[v1.SetPausedRequest, v1.Config](
+			httpClient,
+			baseURL+BackrestSetPausedProcedure,
+			connect.WithSchema(backrestSetPausedMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setBandwidthLimit: connect.NewClient[v1.SetBandwidthLimitRequest, v1.Config](
+			httpClient,
+			baseURL+BackrestSetBandwidthLimitProcedure,
+			connect.WithSchema(backrestSetBandwidthLimitMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		putReplica: connect.NewClient[v1.SealedReplica, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestPutReplicaProcedure,
+			connect.WithSchema(backrestPutReplicaMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		search: connect.NewClient[v1.SearchRequest, v1.SearchResponse](
+			httpClient,
+			baseURL+BackrestSearchProcedure,
+			connect.WithSchema(backrestSearchMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getAlerts: connect.NewClient[emptypb.Empty, v1.AlertList](
+			httpClient,
+			baseURL+BackrestGetAlertsProcedure,
+			connect.WithSchema(backrestGetAlertsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		acknowledgeAlert: connect.NewClient[types.StringValue, v1.Alert](
+			httpClient,
+			baseURL+BackrestAcknowledgeAlertProcedure,
+			connect.WithSchema(backrestAcknowledgeAlertMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		snoozeAlert: connect.NewClient[v1.SnoozeAlertRequest, v1.Alert](
+			httpClient,
+			baseURL+BackrestSnoozeAlertProcedure,
+			connect.WithSchema(backrestSnoozeAlertMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getHookDeliveries: connect.NewClient[emptypb.Empty, v1.HookDeliveryList](
+			httpClient,
+			baseURL+BackrestGetHookDeliveriesProcedure,
+			connect.WithSchema(backrestGetHookDeliveriesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		retryHookDelivery: connect.NewClient[types.Int64Value, emptypb.Empty](
+			httpClient,
+			baseURL+BackrestRetryHookDeliveryProcedure,
+			connect.WithSchema(backrestRetryHookDeliveryMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getSchedulerDecisions: connect.NewClient[v1.SchedulerDecisionsRequest, v1.SchedulerDecisionList](
+			httpClient,
+			baseURL+BackrestGetSchedulerDecisionsProcedure,
+			connect.WithSchema(backrestGetSchedulerDecisionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // backrestClient implements BackrestClient.
 type backrestClient struct {
-	getConfig          *connect.Client[emptypb.Empty, v1.Config]
-	setConfig          *connect.Client[v1.Config, v1.Config]
-	addRepo            *connect.Client[v1.Repo, v1.Config]
-	getOperationEvents *connect.Client[emptypb.Empty, v1.OperationEvent]
-	getOperations      *connect.Client[v1.GetOperationsRequest, v1.OperationList]
-	listSnapshots      *connect.Client[v1.ListSnapshotsRequest, v1.ResticSnapshotList]
-	listSnapshotFiles  *connect.Client[v1.ListSnapshotFilesRequest, v1.ListSnapshotFilesResponse]
-	indexSnapshots     *connect.Client[types.StringValue, emptypb.Empty]
-	backup             *connect.Client[types.StringValue, emptypb.Empty]
-	prune              *connect.Client[types.StringValue, emptypb.Empty]
-	forget             *connect.Client[v1.ForgetRequest, emptypb.Empty]
-	restore            *connect.Client[v1.RestoreSnapshotRequest, emptypb.Empty]
-	unlock             *connect.Client[types.StringValue, emptypb.Empty]
-	stats              *connect.Client[types.StringValue, emptypb.Empty]
-	cancel             *connect.Client[types.Int64Value, emptypb.Empty]
-	getLogs            *connect.Client[v1.LogDataRequest, types.BytesValue]
-	getDownloadURL     *connect.Client[types.Int64Value, types.StringValue]
-	clearHistory       *connect.Client[v1.ClearHistoryRequest, emptypb.Empty]
-	pathAutocomplete   *connect.Client[types.StringValue, types.StringList]
+	getConfig                  *connect.Client[emptypb.Empty, v1.Config]
+	setConfig                  *connect.Client[v1.Config, v1.Config]
+	addRepo                    *connect.Client[v1.Repo, v1.Config]
+	getOperationEvents         *connect.Client[emptypb.Empty, v1.OperationEvent]
+	subscribeOperations        *connect.Client[v1.SubscribeOperationsRequest, v1.OperationEvent]
+	getOperations              *connect.Client[v1.GetOperationsRequest, v1.OperationList]
+	listSnapshots              *connect.Client[v1.ListSnapshotsRequest, v1.ResticSnapshotList]
+	listSnapshotFiles          *connect.Client[v1.ListSnapshotFilesRequest, v1.ListSnapshotFilesResponse]
+	listRestorePoints          *connect.Client[v1.ListRestorePointsRequest, v1.RestorePointList]
+	diffSnapshots              *connect.Client[v1.DiffSnapshotsRequest, v1.SnapshotDiff]
+	searchSnapshots            *connect.Client[v1.SearchSnapshotsRequest, v1.SnapshotFileMatch]
+	listFileVersions           *connect.Client[v1.ListFileVersionsRequest, v1.FileVersionList]
+	tagSnapshots               *connect.Client[v1.TagSnapshotsRequest, types.Int64Value]
+	previewRewriteSnapshots    *connect.Client[v1.RewriteSnapshotsRequest, v1.RewritePreview]
+	rewriteSnapshots           *connect.Client[v1.RewriteSnapshotsRequest, types.Int64Value]
+	getSnapshotStats           *connect.Client[v1.GetSnapshotStatsRequest, v1.SnapshotStats]
+	indexSnapshots             *connect.Client[types.StringValue, emptypb.Empty]
+	backup                     *connect.Client[types.StringValue, emptypb.Empty]
+	backupWithAnnotations      *connect.Client[v1.BackupRequest, emptypb.Empty]
+	previewBackup              *connect.Client[types.StringValue, v1.BackupPreview]
+	prune                      *connect.Client[types.StringValue, types.Int64Value]
+	forget                     *connect.Client[v1.ForgetRequest, types.Int64Value]
+	check                      *connect.Client[types.StringValue, types.Int64Value]
+	initRepo                   *connect.Client[types.StringValue, types.Int64Value]
+	migrateRepo                *connect.Client[v1.MigrateRepoRequest, types.Int64Value]
+	getRepoUpgrade             *connect.Client[types.StringValue, v1.RepoUpgradeAssessment]
+	upgradeRepo                *connect.Client[v1.UpgradeRepoRequest, types.Int64Value]
+	recoverRepo                *connect.Client[types.StringValue, types.Int64Value]
+	restore                    *connect.Client[v1.RestoreSnapshotRequest, emptypb.Empty]
+	getRestoreConflicts        *connect.Client[v1.RestoreSnapshotRequest, v1.RestoreConflictReport]
+	previewRestore             *connect.Client[v1.RestoreSnapshotRequest, v1.RestorePreview]
+	unlock                     *connect.Client[types.StringValue, emptypb.Empty]
+	mountRepo                  *connect.Client[v1.MountRepoRequest, v1.RepoMount]
+	unmountRepo                *connect.Client[types.StringValue, emptypb.Empty]
+	getMounts                  *connect.Client[emptypb.Empty, v1.RepoMountList]
+	listRepoKeys               *connect.Client[types.StringValue, v1.RepoKeyList]
+	addRepoKey                 *connect.Client[v1.AddRepoKeyRequest, v1.RepoKey]
+	removeRepoKey              *connect.Client[v1.RemoveRepoKeyRequest, emptypb.Empty]
+	changeRepoPassword         *connect.Client[v1.ChangeRepoPasswordRequest, v1.Config]
+	getRepoCacheUsage          *connect.Client[types.StringValue, v1.RepoCacheUsage]
+	stats                      *connect.Client[types.StringValue, emptypb.Empty]
+	getRepoStatsHistory        *connect.Client[v1.RepoStatsHistoryRequest, v1.RepoStatsHistory]
+	getStats                   *connect.Client[v1.GetStatsRequest, v1.PlanStatsList]
+	cancel                     *connect.Client[types.Int64Value, emptypb.Empty]
+	getLogs                    *connect.Client[v1.LogDataRequest, types.BytesValue]
+	getResticInfo              *connect.Client[emptypb.Empty, v1.ResticInfo]
+	getDownloadURL             *connect.Client[types.Int64Value, types.StringValue]
+	getRestoreDownloadURL      *connect.Client[v1.RestoreDownloadURLRequest, types.StringValue]
+	createShareLink            *connect.Client[v1.CreateShareLinkRequest, v1.CreateShareLinkResponse]
+	getShareLinks              *connect.Client[emptypb.Empty, v1.ShareLinkList]
+	revokeShareLink            *connect.Client[types.Int64Value, v1.ShareLink]
+	getSnapshotFileDownloadURL *connect.Client[v1.ListSnapshotFilesRequest, types.StringValue]
+	getSnapshotArchiveURL      *connect.Client[v1.ListSnapshotFilesRequest, types.StringValue]
+	exportOperations           *connect.Client[v1.ExportOperationsRequest, types.BytesValue]
+	importOperations           *connect.Client[types.BytesValue, v1.ImportOperationsResponse]
+	annotateOperation          *connect.Client[v1.AnnotateOperationRequest, v1.Operation]
+	clearHistory               *connect.Client[v1.ClearHistoryRequest, emptypb.Empty]
+	pathAutocomplete           *connect.Client[types.StringValue, types.StringList]
+	describeCron               *connect.Client[types.StringValue, types.StringValue]
+	validateCron               *connect.Client[v1.ValidateCronRequest, v1.ValidateCronResponse]
+	setPaused                  *connect.Client[v1.SetPausedRequest, v1.Config]
+	setBandwidthLimit          *connect.Client[v1.SetBandwidthLimitRequest, v1.Config]
+	putReplica                 *connect.Client[v1.SealedReplica, emptypb.Empty]
+	search                     *connect.Client[v1.SearchRequest, v1.SearchResponse]
+	getAlerts                  *connect.Client[emptypb.Empty, v1.AlertList]
+	acknowledgeAlert           *connect.Client[types.StringValue, v1.Alert]
+	snoozeAlert                *connect.Client[v1.SnoozeAlertRequest, v1.Alert]
+	getHookDeliveries          *connect.Client[emptypb.Empty, v1.HookDeliveryList]
+	retryHookDelivery          *connect.Client[types.Int64Value, emptypb.Empty]
+	getSchedulerDecisions      *connect.Client[v1.SchedulerDecisionsRequest, v1.SchedulerDecisionList]
 }
 
 // GetConfig calls v1.Backrest.GetConfig.
@@ -307,6 +974,11 @@ func (c *backrestClient) GetOperationEvents(ctx context.Context, req *connect.Re
 	return c.getOperationEvents.CallServerStream(ctx, req)
 }
 
+// SubscribeOperations calls v1.Backrest.SubscribeOperations.
+func (c *backrestClient) SubscribeOperations(ctx context.Context, req *connect.Request[v1.SubscribeOperationsRequest]) (*connect.ServerStreamForClient[v1.OperationEvent], error) {
+	return c.subscribeOperations.CallServerStream(ctx, req)
+}
+
 // GetOperations calls v1.Backrest.GetOperations.
 func (c *backrestClient) GetOperations(ctx context.Context, req *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error) {
 	return c.getOperations.CallUnary(ctx, req)
@@ -322,6 +994,46 @@ func (c *backrestClient) ListSnapshotFiles(ctx context.Context, req *connect.Req
 	return c.listSnapshotFiles.CallUnary(ctx, req)
 }
 
+// ListRestorePoints calls v1.Backrest.ListRestorePoints.
+func (c *backrestClient) ListRestorePoints(ctx context.Context, req *connect.Request[v1.ListRestorePointsRequest]) (*connect.Response[v1.RestorePointList], error) {
+	return c.listRestorePoints.CallUnary(ctx, req)
+}
+
+// DiffSnapshots calls v1.Backrest.DiffSnapshots.
+func (c *backrestClient) DiffSnapshots(ctx context.Context, req *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.SnapshotDiff], error) {
+	return c.diffSnapshots.CallUnary(ctx, req)
+}
+
+// SearchSnapshots calls v1.Backrest.SearchSnapshots.
+func (c *backrestClient) SearchSnapshots(ctx context.Context, req *connect.Request[v1.SearchSnapshotsRequest]) (*connect.ServerStreamForClient[v1.SnapshotFileMatch], error) {
+	return c.searchSnapshots.CallServerStream(ctx, req)
+}
+
+// ListFileVersions calls v1.Backrest.ListFileVersions.
+func (c *backrestClient) ListFileVersions(ctx context.Context, req *connect.Request[v1.ListFileVersionsRequest]) (*connect.Response[v1.FileVersionList], error) {
+	return c.listFileVersions.CallUnary(ctx, req)
+}
+
+// TagSnapshots calls v1.Backrest.TagSnapshots.
+func (c *backrestClient) TagSnapshots(ctx context.Context, req *connect.Request[v1.TagSnapshotsRequest]) (*connect.Response[types.Int64Value], error) {
+	return c.tagSnapshots.CallUnary(ctx, req)
+}
+
+// PreviewRewriteSnapshots calls v1.Backrest.PreviewRewriteSnapshots.
+func (c *backrestClient) PreviewRewriteSnapshots(ctx context.Context, req *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[v1.RewritePreview], error) {
+	return c.previewRewriteSnapshots.CallUnary(ctx, req)
+}
+
+// RewriteSnapshots calls v1.Backrest.RewriteSnapshots.
+func (c *backrestClient) RewriteSnapshots(ctx context.Context, req *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[types.Int64Value], error) {
+	return c.rewriteSnapshots.CallUnary(ctx, req)
+}
+
+// GetSnapshotStats calls v1.Backrest.GetSnapshotStats.
+func (c *backrestClient) GetSnapshotStats(ctx context.Context, req *connect.Request[v1.GetSnapshotStatsRequest]) (*connect.Response[v1.SnapshotStats], error) {
+	return c.getSnapshotStats.CallUnary(ctx, req)
+}
+
 // IndexSnapshots calls v1.Backrest.IndexSnapshots.
 func (c *backrestClient) IndexSnapshots(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
 	return c.indexSnapshots.CallUnary(ctx, req)
@@ -332,31 +1044,131 @@ func (c *backrestClient) Backup(ctx context.Context, req *connect.Request[types.
 	return c.backup.CallUnary(ctx, req)
 }
 
+// BackupWithAnnotations calls v1.Backrest.BackupWithAnnotations.
+func (c *backrestClient) BackupWithAnnotations(ctx context.Context, req *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.backupWithAnnotations.CallUnary(ctx, req)
+}
+
+// PreviewBackup calls v1.Backrest.PreviewBackup.
+func (c *backrestClient) PreviewBackup(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.BackupPreview], error) {
+	return c.previewBackup.CallUnary(ctx, req)
+}
+
 // Prune calls v1.Backrest.Prune.
-func (c *backrestClient) Prune(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+func (c *backrestClient) Prune(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
 	return c.prune.CallUnary(ctx, req)
 }
 
 // Forget calls v1.Backrest.Forget.
-func (c *backrestClient) Forget(ctx context.Context, req *connect.Request[v1.ForgetRequest]) (*connect.Response[emptypb.Empty], error) {
+func (c *backrestClient) Forget(ctx context.Context, req *connect.Request[v1.ForgetRequest]) (*connect.Response[types.Int64Value], error) {
 	return c.forget.CallUnary(ctx, req)
 }
 
+// Check calls v1.Backrest.Check.
+func (c *backrestClient) Check(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	return c.check.CallUnary(ctx, req)
+}
+
+// InitRepo calls v1.Backrest.InitRepo.
+func (c *backrestClient) InitRepo(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	return c.initRepo.CallUnary(ctx, req)
+}
+
+// MigrateRepo calls v1.Backrest.MigrateRepo.
+func (c *backrestClient) MigrateRepo(ctx context.Context, req *connect.Request[v1.MigrateRepoRequest]) (*connect.Response[types.Int64Value], error) {
+	return c.migrateRepo.CallUnary(ctx, req)
+}
+
+// GetRepoUpgrade calls v1.Backrest.GetRepoUpgrade.
+func (c *backrestClient) GetRepoUpgrade(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.RepoUpgradeAssessment], error) {
+	return c.getRepoUpgrade.CallUnary(ctx, req)
+}
+
+// UpgradeRepo calls v1.Backrest.UpgradeRepo.
+func (c *backrestClient) UpgradeRepo(ctx context.Context, req *connect.Request[v1.UpgradeRepoRequest]) (*connect.Response[types.Int64Value], error) {
+	return c.upgradeRepo.CallUnary(ctx, req)
+}
+
+// RecoverRepo calls v1.Backrest.RecoverRepo.
+func (c *backrestClient) RecoverRepo(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	return c.recoverRepo.CallUnary(ctx, req)
+}
+
 // Restore calls v1.Backrest.Restore.
 func (c *backrestClient) Restore(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
 	return c.restore.CallUnary(ctx, req)
 }
 
+// GetRestoreConflicts calls v1.Backrest.GetRestoreConflicts.
+func (c *backrestClient) GetRestoreConflicts(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreConflictReport], error) {
+	return c.getRestoreConflicts.CallUnary(ctx, req)
+}
+
+// PreviewRestore calls v1.Backrest.PreviewRestore.
+func (c *backrestClient) PreviewRestore(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestorePreview], error) {
+	return c.previewRestore.CallUnary(ctx, req)
+}
+
 // Unlock calls v1.Backrest.Unlock.
 func (c *backrestClient) Unlock(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
 	return c.unlock.CallUnary(ctx, req)
 }
 
+// MountRepo calls v1.Backrest.MountRepo.
+func (c *backrestClient) MountRepo(ctx context.Context, req *connect.Request[v1.MountRepoRequest]) (*connect.Response[v1.RepoMount], error) {
+	return c.mountRepo.CallUnary(ctx, req)
+}
+
+// UnmountRepo calls v1.Backrest.UnmountRepo.
+func (c *backrestClient) UnmountRepo(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+	return c.unmountRepo.CallUnary(ctx, req)
+}
+
+// GetMounts calls v1.Backrest.GetMounts.
+func (c *backrestClient) GetMounts(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.RepoMountList], error) {
+	return c.getMounts.CallUnary(ctx, req)
+}
+
+// ListRepoKeys calls v1.Backrest.ListRepoKeys.
+func (c *backrestClient) ListRepoKeys(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.RepoKeyList], error) {
+	return c.listRepoKeys.CallUnary(ctx, req)
+}
+
+// AddRepoKey calls v1.Backrest.AddRepoKey.
+func (c *backrestClient) AddRepoKey(ctx context.Context, req *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error) {
+	return c.addRepoKey.CallUnary(ctx, req)
+}
+
+// RemoveRepoKey calls v1.Backrest.RemoveRepoKey.
+func (c *backrestClient) RemoveRepoKey(ctx context.Context, req *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.removeRepoKey.CallUnary(ctx, req)
+}
+
+// ChangeRepoPassword calls v1.Backrest.ChangeRepoPassword.
+func (c *backrestClient) ChangeRepoPassword(ctx context.Context, req *connect.Request[v1.ChangeRepoPasswordRequest]) (*connect.Response[v1.Config], error) {
+	return c.changeRepoPassword.CallUnary(ctx, req)
+}
+
+// GetRepoCacheUsage calls v1.Backrest.GetRepoCacheUsage.
+func (c *backrestClient) GetRepoCacheUsage(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.RepoCacheUsage], error) {
+	return c.getRepoCacheUsage.CallUnary(ctx, req)
+}
+
 // Stats calls v1.Backrest.Stats.
 func (c *backrestClient) Stats(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
 	return c.stats.CallUnary(ctx, req)
 }
 
+// GetRepoStatsHistory calls v1.Backrest.GetRepoStatsHistory.
+func (c *backrestClient) GetRepoStatsHistory(ctx context.Context, req *connect.Request[v1.RepoStatsHistoryRequest]) (*connect.Response[v1.RepoStatsHistory], error) {
+	return c.getRepoStatsHistory.CallUnary(ctx, req)
+}
+
+// GetStats calls v1.Backrest.GetStats.
+func (c *backrestClient) GetStats(ctx context.Context, req *connect.Request[v1.GetStatsRequest]) (*connect.Response[v1.PlanStatsList], error) {
+	return c.getStats.CallUnary(ctx, req)
+}
+
 // Cancel calls v1.Backrest.Cancel.
 func (c *backrestClient) Cancel(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error) {
 	return c.cancel.CallUnary(ctx, req)
@@ -367,11 +1179,61 @@ func (c *backrestClient) GetLogs(ctx context.Context, req *connect.Request[v1.Lo
 	return c.getLogs.CallUnary(ctx, req)
 }
 
+// GetResticInfo calls v1.Backrest.GetResticInfo.
+func (c *backrestClient) GetResticInfo(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ResticInfo], error) {
+	return c.getResticInfo.CallUnary(ctx, req)
+}
+
 // GetDownloadURL calls v1.Backrest.GetDownloadURL.
 func (c *backrestClient) GetDownloadURL(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[types.StringValue], error) {
 	return c.getDownloadURL.CallUnary(ctx, req)
 }
 
+// GetRestoreDownloadURL calls v1.Backrest.GetRestoreDownloadURL.
+func (c *backrestClient) GetRestoreDownloadURL(ctx context.Context, req *connect.Request[v1.RestoreDownloadURLRequest]) (*connect.Response[types.StringValue], error) {
+	return c.getRestoreDownloadURL.CallUnary(ctx, req)
+}
+
+// CreateShareLink calls v1.Backrest.CreateShareLink.
+func (c *backrestClient) CreateShareLink(ctx context.Context, req *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error) {
+	return c.createShareLink.CallUnary(ctx, req)
+}
+
+// GetShareLinks calls v1.Backrest.GetShareLinks.
+func (c *backrestClient) GetShareLinks(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ShareLinkList], error) {
+	return c.getShareLinks.CallUnary(ctx, req)
+}
+
+// RevokeShareLink calls v1.Backrest.RevokeShareLink.
+func (c *backrestClient) RevokeShareLink(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[v1.ShareLink], error) {
+	return c.revokeShareLink.CallUnary(ctx, req)
+}
+
+// GetSnapshotFileDownloadURL calls v1.Backrest.GetSnapshotFileDownloadURL.
+func (c *backrestClient) GetSnapshotFileDownloadURL(ctx context.Context, req *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error) {
+	return c.getSnapshotFileDownloadURL.CallUnary(ctx, req)
+}
+
+// GetSnapshotArchiveURL calls v1.Backrest.GetSnapshotArchiveURL.
+func (c *backrestClient) GetSnapshotArchiveURL(ctx context.Context, req *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error) {
+	return c.getSnapshotArchiveURL.CallUnary(ctx, req)
+}
+
+// ExportOperations calls v1.Backrest.ExportOperations.
+func (c *backrestClient) ExportOperations(ctx context.Context, req *connect.Request[v1.ExportOperationsRequest]) (*connect.ServerStreamForClient[types.BytesValue], error) {
+	return c.exportOperations.CallServerStream(ctx, req)
+}
+
+// ImportOperations calls v1.Backrest.ImportOperations.
+func (c *backrestClient) ImportOperations(ctx context.Context) *connect.ClientStreamForClient[types.BytesValue, v1.ImportOperationsResponse] {
+	return c.importOperations.CallClientStream(ctx)
+}
+
+// AnnotateOperation calls v1.Backrest.AnnotateOperation.
+func (c *backrestClient) AnnotateOperation(ctx context.Context, req *connect.Request[v1.AnnotateOperationRequest]) (*connect.Response[v1.Operation], error) {
+	return c.annotateOperation.CallUnary(ctx, req)
+}
+
 // ClearHistory calls v1.Backrest.ClearHistory.
 func (c *backrestClient) ClearHistory(ctx context.Context, req *connect.Request[v1.ClearHistoryRequest]) (*connect.Response[emptypb.Empty], error) {
 	return c.clearHistory.CallUnary(ctx, req)
@@ -382,39 +1244,231 @@ func (c *backrestClient) PathAutocomplete(ctx context.Context, req *connect.Requ
 	return c.pathAutocomplete.CallUnary(ctx, req)
 }
 
+// DescribeCron calls v1.Backrest.DescribeCron.
+func (c *backrestClient) DescribeCron(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.StringValue], error) {
+	return c.describeCron.CallUnary(ctx, req)
+}
+
+// ValidateCron calls v1.Backrest.ValidateCron.
+func (c *backrestClient) ValidateCron(ctx context.Context, req *connect.Request[v1.ValidateCronRequest]) (*connect.Response[v1.ValidateCronResponse], error) {
+	return c.validateCron.CallUnary(ctx, req)
+}
+
+// SetPaused calls v1.Backrest.SetPaused.
+func (c *backrestClient) SetPaused(ctx context.Context, req *connect.Request[v1.SetPausedRequest]) (*connect.Response[v1.Config], error) {
+	return c.setPaused.CallUnary(ctx, req)
+}
+
+// SetBandwidthLimit calls v1.Backrest.SetBandwidthLimit.
+func (c *backrestClient) SetBandwidthLimit(ctx context.Context, req *connect.Request[v1.SetBandwidthLimitRequest]) (*connect.Response[v1.Config], error) {
+	return c.setBandwidthLimit.CallUnary(ctx, req)
+}
+
+// PutReplica calls v1.Backrest.PutReplica.
+func (c *backrestClient) PutReplica(ctx context.Context, req *connect.Request[v1.SealedReplica]) (*connect.Response[emptypb.Empty], error) {
+	return c.putReplica.CallUnary(ctx, req)
+}
+
+// Search calls v1.Backrest.Search.
+func (c *backrestClient) Search(ctx context.Context, req *connect.Request[v1.SearchRequest]) (*connect.Response[v1.SearchResponse], error) {
+	return c.search.CallUnary(ctx, req)
+}
+
+// GetAlerts calls v1.Backrest.GetAlerts.
+func (c *backrestClient) GetAlerts(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.AlertList], error) {
+	return c.getAlerts.CallUnary(ctx, req)
+}
+
+// AcknowledgeAlert calls v1.Backrest.AcknowledgeAlert.
+func (c *backrestClient) AcknowledgeAlert(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.Alert], error) {
+	return c.acknowledgeAlert.CallUnary(ctx, req)
+}
+
+// SnoozeAlert calls v1.Backrest.SnoozeAlert.
+func (c *backrestClient) SnoozeAlert(ctx context.Context, req *connect.Request[v1.SnoozeAlertRequest]) (*connect.Response[v1.Alert], error) {
+	return c.snoozeAlert.CallUnary(ctx, req)
+}
+
+// GetHookDeliveries calls v1.Backrest.GetHookDeliveries.
+func (c *backrestClient) GetHookDeliveries(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.HookDeliveryList], error) {
+	return c.getHookDeliveries.CallUnary(ctx, req)
+}
+
+// RetryHookDelivery calls v1.Backrest.RetryHookDelivery.
+func (c *backrestClient) RetryHookDelivery(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error) {
+	return c.retryHookDelivery.CallUnary(ctx, req)
+}
+
+// GetSchedulerDecisions calls v1.Backrest.GetSchedulerDecisions.
+func (c *backrestClient) GetSchedulerDecisions(ctx context.Context, req *connect.Request[v1.SchedulerDecisionsRequest]) (*connect.Response[v1.SchedulerDecisionList], error) {
+	return c.getSchedulerDecisions.CallUnary(ctx, req)
+}
+
 // BackrestHandler is an implementation of the v1.Backrest service.
 type BackrestHandler interface {
 	GetConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.Config], error)
 	SetConfig(context.Context, *connect.Request[v1.Config]) (*connect.Response[v1.Config], error)
 	AddRepo(context.Context, *connect.Request[v1.Repo]) (*connect.Response[v1.Config], error)
+	// GetOperationEvents streams every operation event the caller may see, see SubscribeOperations.
 	GetOperationEvents(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[v1.OperationEvent]) error
+	// SubscribeOperations streams the events of operations as they are created, updated and deleted, optionally only those
+	// of a plan or repo. The stream is closed if the client falls behind, clients reconnect and fetch the operations they
+	// may have missed with GetOperations.
+	SubscribeOperations(context.Context, *connect.Request[v1.SubscribeOperationsRequest], *connect.ServerStream[v1.OperationEvent]) error
 	GetOperations(context.Context, *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error)
 	ListSnapshots(context.Context, *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ResticSnapshotList], error)
 	ListSnapshotFiles(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[v1.ListSnapshotFilesResponse], error)
+	// ListRestorePoints collapses a repo's snapshots into one restore point per plan and calendar day or week, newest first.
+	ListRestorePoints(context.Context, *connect.Request[v1.ListRestorePointsRequest]) (*connect.Response[v1.RestorePointList], error)
+	// DiffSnapshots lists the files added, removed and modified between two snapshots, by default between a snapshot and
+	// its parent i.e. what changed in the backup that created it.
+	DiffSnapshots(context.Context, *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.SnapshotDiff], error)
+	// SearchSnapshots finds files whose name matches a glob pattern in a repo's snapshots, matches are streamed a
+	// snapshot at a time as they are found.
+	SearchSnapshots(context.Context, *connect.Request[v1.SearchSnapshotsRequest], *connect.ServerStream[v1.SnapshotFileMatch]) error
+	// ListFileVersions lists the versions of a file in a plan's snapshots newest first, so that the version to restore can
+	// be picked from the file's history.
+	ListFileVersions(context.Context, *connect.Request[v1.ListFileVersionsRequest]) (*connect.Response[v1.FileVersionList], error)
+	// TagSnapshots schedules a restic tag adding and removing tags of snapshots, it returns the ID of the scheduled operation.
+	// restic saves each changed snapshot under a new ID. The plan and instance tags backrest selects snapshots by can't be
+	// changed.
+	TagSnapshots(context.Context, *connect.Request[v1.TagSnapshotsRequest]) (*connect.Response[types.Int64Value], error)
+	// PreviewRewriteSnapshots reports the snapshots a rewrite would change by running restic rewrite --dry-run, the preview's
+	// digest must be passed back to RewriteSnapshots to confirm the rewrite.
+	PreviewRewriteSnapshots(context.Context, *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[v1.RewritePreview], error)
+	// RewriteSnapshots schedules a restic rewrite removing the excluded files from snapshots, it returns the ID of the
+	// scheduled operation. restic saves each changed snapshot under a new ID, the originals are kept unless forget is set.
+	RewriteSnapshots(context.Context, *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[types.Int64Value], error)
+	// GetSnapshotStats returns statistics for a snapshot, computed on the first request and cached afterwards.
+	GetSnapshotStats(context.Context, *connect.Request[v1.GetSnapshotStatsRequest]) (*connect.Response[v1.SnapshotStats], error)
 	// IndexSnapshots triggers indexin. It accepts a repo id and returns empty if the task is enqueued.
 	IndexSnapshots(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
 	// Backup schedules a backup operation. It accepts a plan id and returns empty if the task is enqueued.
 	Backup(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
-	// Prune schedules a prune operation. It accepts a plan id and returns empty if the task is enqueued.
-	Prune(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
-	// Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
-	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[emptypb.Empty], error)
+	// BackupWithAnnotations runs a plan's backup like Backup and attaches the annotations to its snapshot as tags, e.g. the
+	// version of the application being released, so that the snapshot can be found by them later.
+	BackupWithAnnotations(context.Context, *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error)
+	// PreviewBackup runs a plan's backup with restic backup --dry-run and reports what it would add to the repo, nothing
+	// is written to the repo. It accepts a plan id.
+	PreviewBackup(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.BackupPreview], error)
+	// Prune schedules a prune operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Prune(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
+	// Forget schedules a forget operation. It accepts a plan id and returns the ID of the scheduled operation.
+	Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[types.Int64Value], error)
+	// Check schedules a check operation. It accepts a repo id and returns the ID of the scheduled operation.
+	Check(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
+	// InitRepo schedules a restic init of a repo already in the config, e.g. one whose storage was recreated. It accepts a
+	// repo id and returns the ID of the scheduled operation. Repos added with AddRepo are initialized when they are added.
+	InitRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
+	// MigrateRepo schedules a restic migrate of the repo, by default upgrading it to the v2 repository format. It returns
+	// the ID of the scheduled operation.
+	MigrateRepo(context.Context, *connect.Request[v1.MigrateRepoRequest]) (*connect.Response[types.Int64Value], error)
+	// GetRepoUpgrade checks whether the repo uses an old format version or holds data stored uncompressed and describes
+	// the benefits and risks of upgrading it.
+	GetRepoUpgrade(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoUpgradeAssessment], error)
+	// UpgradeRepo schedules an upgrade of the repo to the v2 format, optionally followed by repacking the data stored
+	// uncompressed. It returns the ID of the scheduled operation, an interrupted upgrade is resumed when backrest next starts.
+	UpgradeRepo(context.Context, *connect.Request[v1.UpgradeRepoRequest]) (*connect.Response[types.Int64Value], error)
+	// RecoverRepo schedules a restic recover of the repo, which saves the data of forgotten snapshots that was not pruned
+	// yet into a new snapshot. It returns the ID of the recover operation.
+	RecoverRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error)
 	// Restore schedules a restore operation.
 	Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error)
+	// GetRestoreConflicts reports the local files an in place restore would overwrite, the report's digest must be passed
+	// back to Restore to confirm the in place restore.
+	GetRestoreConflicts(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreConflictReport], error)
+	// PreviewRestore lists the files a restore would write and checks that the target can be written before the restore
+	// is scheduled, nothing is restored.
+	PreviewRestore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestorePreview], error)
 	// Unlock synchronously attempts to unlock the repo. Will block if other operations are in progress.
 	Unlock(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
+	// MountRepo mounts the repo's snapshots as a read only filesystem with restic mount, under backrest's data directory.
+	// The repo is unmounted once it wasn't requested for its idle timeout, mounting a mounted repo restarts the timeout.
+	MountRepo(context.Context, *connect.Request[v1.MountRepoRequest]) (*connect.Response[v1.RepoMount], error)
+	// UnmountRepo unmounts a repo mounted with MountRepo, it accepts a repo id.
+	UnmountRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
+	// GetMounts returns the mounted repos the caller may access.
+	GetMounts(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RepoMountList], error)
+	// ListRepoKeys lists the keys of a repo, it accepts a repo id.
+	ListRepoKeys(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoKeyList], error)
+	// AddRepoKey adds a key with another password to a repo e.g. for another machine, the repo's password in the config
+	// is unchanged.
+	AddRepoKey(context.Context, *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error)
+	// RemoveRepoKey removes a key from a repo, the key holding the repo's password in the config can't be removed.
+	RemoveRepoKey(context.Context, *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error)
+	// ChangeRepoPassword replaces the key holding the repo's password in the config with a key holding the new password
+	// and stores the new password in the config. The old key is only removed once the config is updated.
+	ChangeRepoPassword(context.Context, *connect.Request[v1.ChangeRepoPasswordRequest]) (*connect.Response[v1.Config], error)
+	// GetRepoCacheUsage returns the disk usage of the repo's local restic cache, it accepts a repo id.
+	GetRepoCacheUsage(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoCacheUsage], error)
 	// Stats runs 'restic stats` on the repository and appends the results to the operations log.
 	Stats(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error)
+	// GetRepoStatsHistory returns the stats collected for a repo over time, oldest first, e.g. to graph the repo's growth.
+	GetRepoStatsHistory(context.Context, *connect.Request[v1.RepoStatsHistoryRequest]) (*connect.Response[v1.RepoStatsHistory], error)
+	// GetStats returns statistics of each plan's backups aggregated from the operation log, e.g. success rates and durations.
+	GetStats(context.Context, *connect.Request[v1.GetStatsRequest]) (*connect.Response[v1.PlanStatsList], error)
 	// Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
 	Cancel(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error)
 	// GetLogs returns the keyed large data for the given operation.
 	GetLogs(context.Context, *connect.Request[v1.LogDataRequest]) (*connect.Response[types.BytesValue], error)
-	// GetDownloadURL returns a signed download URL given a forget operation ID.
+	// GetResticInfo returns the restic binary backrest uses by default and the binary used by each repo the caller may access.
+	GetResticInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ResticInfo], error)
+	// GetDownloadURL returns a signed URL that downloads the files of a restore operation given its ID.
 	GetDownloadURL(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[types.StringValue], error)
+	// GetRestoreDownloadURL returns a signed URL that downloads a file or directory of a restore operation's target. The
+	// path is part of the signature, the URL can't be changed to download other files of the restore.
+	GetRestoreDownloadURL(context.Context, *connect.Request[v1.RestoreDownloadURLRequest]) (*connect.Response[types.StringValue], error)
+	// CreateShareLink creates a passphrase protected link to download a directory or file of a restore without access to
+	// the UI, the link's URL is only returned here.
+	CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error)
+	// GetShareLinks lists the share links of the repos the caller may access.
+	GetShareLinks(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ShareLinkList], error)
+	// RevokeShareLink revokes the share link with the given ID, downloads in progress are not interrupted.
+	RevokeShareLink(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[v1.ShareLink], error)
+	// GetSnapshotFileDownloadURL returns a signed URL that streams a single file from a snapshot without restoring it first.
+	GetSnapshotFileDownloadURL(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error)
+	// GetSnapshotArchiveURL returns a signed URL that streams a directory of a snapshot, the whole snapshot if no path is
+	// given, as an uncompressed tar archive. Appending "?offset=<bytes>" to the URL resumes an interrupted download.
+	GetSnapshotArchiveURL(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error)
+	// ExportOperations streams the operations of the plans and repos the caller may access as newline delimited protojson,
+	// messages are chunks of the output and lines may span messages.
+	ExportOperations(context.Context, *connect.Request[v1.ExportOperationsRequest], *connect.ServerStream[types.BytesValue]) error
+	// ImportOperations adds the operations in a stream of newline delimited protojson, as written by ExportOperations, to
+	// the oplog. Operations are given new IDs, those already in the oplog or unfinished when they were exported are skipped.
+	ImportOperations(context.Context, *connect.ClientStream[types.BytesValue]) (*connect.Response[v1.ImportOperationsResponse], error)
+	// AnnotateOperation attaches a note to a finished operation replacing any it has, an empty note removes it. Returns the
+	// updated operation.
+	AnnotateOperation(context.Context, *connect.Request[v1.AnnotateOperationRequest]) (*connect.Response[v1.Operation], error)
 	// Clears the history of operations
 	ClearHistory(context.Context, *connect.Request[v1.ClearHistoryRequest]) (*connect.Response[emptypb.Empty], error)
 	// PathAutocomplete provides path autocompletion options for a given filesystem path.
 	PathAutocomplete(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringList], error)
+	// DescribeCron returns a human readable description of a cron expression. Returns an error if the expression is invalid.
+	DescribeCron(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringValue], error)
+	// ValidateCron returns a description of a cron expression and the next times it fires. Returns an error if the expression is invalid.
+	ValidateCron(context.Context, *connect.Request[v1.ValidateCronRequest]) (*connect.Response[v1.ValidateCronResponse], error)
+	// SetPaused pauses or resumes scheduled tasks for a plan or a repo, returns the updated config.
+	SetPaused(context.Context, *connect.Request[v1.SetPausedRequest]) (*connect.Response[v1.Config], error)
+	// SetBandwidthLimit changes a repo's bandwidth limits, returns the updated config. Backups in progress for the repo are
+	// restarted with the new limits.
+	SetBandwidthLimit(context.Context, *connect.Request[v1.SetBandwidthLimitRequest]) (*connect.Response[v1.Config], error)
+	// PutReplica stores a config replica pushed by another instance, replicas are kept per instance and replace older ones.
+	PutReplica(context.Context, *connect.Request[v1.SealedReplica]) (*connect.Response[emptypb.Empty], error)
+	// Search finds operations and snapshots matching a query across every plan and repo the caller may access, newest first.
+	Search(context.Context, *connect.Request[v1.SearchRequest]) (*connect.Response[v1.SearchResponse], error)
+	// GetAlerts evaluates the health rules and returns the current alerts of the plans and repos the caller may access.
+	GetAlerts(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.AlertList], error)
+	// AcknowledgeAlert suppresses notifications for the alert with the given ID until it's resolved, returns the updated alert.
+	AcknowledgeAlert(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.Alert], error)
+	// SnoozeAlert suppresses notifications for an alert for a duration, returns the updated alert.
+	SnoozeAlert(context.Context, *connect.Request[v1.SnoozeAlertRequest]) (*connect.Response[v1.Alert], error)
+	// GetHookDeliveries lists the hook notifications waiting to be retried and those that were given up on.
+	GetHookDeliveries(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.HookDeliveryList], error)
+	// RetryHookDelivery moves the dead letter with the given ID back to the retry queue, it is attempted immediately.
+	RetryHookDelivery(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error)
+	// GetSchedulerDecisions lists the scheduler's recent decisions about the tasks of the plans and repos the caller may
+	// access, newest first, e.g. to find out why a backup didn't run. Decisions are kept in memory and lost on restart.
+	GetSchedulerDecisions(context.Context, *connect.Request[v1.SchedulerDecisionsRequest]) (*connect.Response[v1.SchedulerDecisionList], error)
 }
 
 // NewBackrestHandler builds an HTTP handler from the service implementation. It returns the path on
@@ -447,6 +1501,12 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestGetOperationEventsMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestSubscribeOperationsHandler := connect.NewServerStreamHandler(
+		BackrestSubscribeOperationsProcedure,
+		svc.SubscribeOperations,
+		connect.WithSchema(backrestSubscribeOperationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestGetOperationsHandler := connect.NewUnaryHandler(
 		BackrestGetOperationsProcedure,
 		svc.GetOperations,
@@ -465,6 +1525,54 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestListSnapshotFilesMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestListRestorePointsHandler := connect.NewUnaryHandler(
+		BackrestListRestorePointsProcedure,
+		svc.ListRestorePoints,
+		connect.WithSchema(backrestListRestorePointsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestDiffSnapshotsHandler := connect.NewUnaryHandler(
+		BackrestDiffSnapshotsProcedure,
+		svc.DiffSnapshots,
+		connect.WithSchema(backrestDiffSnapshotsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestSearchSnapshotsHandler := connect.NewServerStreamHandler(
+		BackrestSearchSnapshotsProcedure,
+		svc.SearchSnapshots,
+		connect.WithSchema(backrestSearchSnapshotsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestListFileVersionsHandler := connect.NewUnaryHandler(
+		BackrestListFileVersionsProcedure,
+		svc.ListFileVersions,
+		connect.WithSchema(backrestListFileVersionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestTagSnapshotsHandler := connect.NewUnaryHandler(
+		BackrestTagSnapshotsProcedure,
+		svc.TagSnapshots,
+		connect.WithSchema(backrestTagSnapshotsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestPreviewRewriteSnapshotsHandler := connect.NewUnaryHandler(
+		BackrestPreviewRewriteSnapshotsProcedure,
+		svc.PreviewRewriteSnapshots,
+		connect.WithSchema(backrestPreviewRewriteSnapshotsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestRewriteSnapshotsHandler := connect.NewUnaryHandler(
+		BackrestRewriteSnapshotsProcedure,
+		svc.RewriteSnapshots,
+		connect.WithSchema(backrestRewriteSnapshotsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetSnapshotStatsHandler := connect.NewUnaryHandler(
+		BackrestGetSnapshotStatsProcedure,
+		svc.GetSnapshotStats,
+		connect.WithSchema(backrestGetSnapshotStatsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestIndexSnapshotsHandler := connect.NewUnaryHandler(
 		BackrestIndexSnapshotsProcedure,
 		svc.IndexSnapshots,
@@ -477,6 +1585,18 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestBackupMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestBackupWithAnnotationsHandler := connect.NewUnaryHandler(
+		BackrestBackupWithAnnotationsProcedure,
+		svc.BackupWithAnnotations,
+		connect.WithSchema(backrestBackupWithAnnotationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestPreviewBackupHandler := connect.NewUnaryHandler(
+		BackrestPreviewBackupProcedure,
+		svc.PreviewBackup,
+		connect.WithSchema(backrestPreviewBackupMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestPruneHandler := connect.NewUnaryHandler(
 		BackrestPruneProcedure,
 		svc.Prune,
@@ -489,24 +1609,132 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestForgetMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestCheckHandler := connect.NewUnaryHandler(
+		BackrestCheckProcedure,
+		svc.Check,
+		connect.WithSchema(backrestCheckMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestInitRepoHandler := connect.NewUnaryHandler(
+		BackrestInitRepoProcedure,
+		svc.InitRepo,
+		connect.WithSchema(backrestInitRepoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestMigrateRepoHandler := connect.NewUnaryHandler(
+		BackrestMigrateRepoProcedure,
+		svc.MigrateRepo,
+		connect.WithSchema(backrestMigrateRepoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetRepoUpgradeHandler := connect.NewUnaryHandler(
+		BackrestGetRepoUpgradeProcedure,
+		svc.GetRepoUpgrade,
+		connect.WithSchema(backrestGetRepoUpgradeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestUpgradeRepoHandler := connect.NewUnaryHandler(
+		BackrestUpgradeRepoProcedure,
+		svc.UpgradeRepo,
+		connect.WithSchema(backrestUpgradeRepoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestRecoverRepoHandler := connect.NewUnaryHandler(
+		BackrestRecoverRepoProcedure,
+		svc.RecoverRepo,
+		connect.WithSchema(backrestRecoverRepoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestRestoreHandler := connect.NewUnaryHandler(
 		BackrestRestoreProcedure,
 		svc.Restore,
 		connect.WithSchema(backrestRestoreMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestGetRestoreConflictsHandler := connect.NewUnaryHandler(
+		BackrestGetRestoreConflictsProcedure,
+		svc.GetRestoreConflicts,
+		connect.WithSchema(backrestGetRestoreConflictsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestPreviewRestoreHandler := connect.NewUnaryHandler(
+		BackrestPreviewRestoreProcedure,
+		svc.PreviewRestore,
+		connect.WithSchema(backrestPreviewRestoreMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestUnlockHandler := connect.NewUnaryHandler(
 		BackrestUnlockProcedure,
 		svc.Unlock,
 		connect.WithSchema(backrestUnlockMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestMountRepoHandler := connect.NewUnaryHandler(
+		BackrestMountRepoProcedure,
+		svc.MountRepo,
+		connect.WithSchema(backrestMountRepoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestUnmountRepoHandler := connect.NewUnaryHandler(
+		BackrestUnmountRepoProcedure,
+		svc.UnmountRepo,
+		connect.WithSchema(backrestUnmountRepoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetMountsHandler := connect.NewUnaryHandler(
+		BackrestGetMountsProcedure,
+		svc.GetMounts,
+		connect.WithSchema(backrestGetMountsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestListRepoKeysHandler := connect.NewUnaryHandler(
+		BackrestListRepoKeysProcedure,
+		svc.ListRepoKeys,
+		connect.WithSchema(backrestListRepoKeysMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestAddRepoKeyHandler := connect.NewUnaryHandler(
+		BackrestAddRepoKeyProcedure,
+		svc.AddRepoKey,
+		connect.WithSchema(backrestAddRepoKeyMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestRemoveRepoKeyHandler := connect.NewUnaryHandler(
+		BackrestRemoveRepoKeyProcedure,
+		svc.RemoveRepoKey,
+		connect.WithSchema(backrestRemoveRepoKeyMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestChangeRepoPasswordHandler := connect.NewUnaryHandler(
+		BackrestChangeRepoPasswordProcedure,
+		svc.ChangeRepoPassword,
+		connect.WithSchema(backrestChangeRepoPasswordMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetRepoCacheUsageHandler := connect.NewUnaryHandler(
+		BackrestGetRepoCacheUsageProcedure,
+		svc.GetRepoCacheUsage,
+		connect.WithSchema(backrestGetRepoCacheUsageMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestStatsHandler := connect.NewUnaryHandler(
 		BackrestStatsProcedure,
 		svc.Stats,
 		connect.WithSchema(backrestStatsMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestGetRepoStatsHistoryHandler := connect.NewUnaryHandler(
+		BackrestGetRepoStatsHistoryProcedure,
+		svc.GetRepoStatsHistory,
+		connect.WithSchema(backrestGetRepoStatsHistoryMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetStatsHandler := connect.NewUnaryHandler(
+		BackrestGetStatsProcedure,
+		svc.GetStats,
+		connect.WithSchema(backrestGetStatsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestCancelHandler := connect.NewUnaryHandler(
 		BackrestCancelProcedure,
 		svc.Cancel,
@@ -519,12 +1747,72 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestGetLogsMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestGetResticInfoHandler := connect.NewUnaryHandler(
+		BackrestGetResticInfoProcedure,
+		svc.GetResticInfo,
+		connect.WithSchema(backrestGetResticInfoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestGetDownloadURLHandler := connect.NewUnaryHandler(
 		BackrestGetDownloadURLProcedure,
 		svc.GetDownloadURL,
 		connect.WithSchema(backrestGetDownloadURLMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestGetRestoreDownloadURLHandler := connect.NewUnaryHandler(
+		BackrestGetRestoreDownloadURLProcedure,
+		svc.GetRestoreDownloadURL,
+		connect.WithSchema(backrestGetRestoreDownloadURLMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestCreateShareLinkHandler := connect.NewUnaryHandler(
+		BackrestCreateShareLinkProcedure,
+		svc.CreateShareLink,
+		connect.WithSchema(backrestCreateShareLinkMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetShareLinksHandler := connect.NewUnaryHandler(
+		BackrestGetShareLinksProcedure,
+		svc.GetShareLinks,
+		connect.WithSchema(backrestGetShareLinksMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestRevokeShareLinkHandler := connect.NewUnaryHandler(
+		BackrestRevokeShareLinkProcedure,
+		svc.RevokeShareLink,
+		connect.WithSchema(backrestRevokeShareLinkMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetSnapshotFileDownloadURLHandler := connect.NewUnaryHandler(
+		BackrestGetSnapshotFileDownloadURLProcedure,
+		svc.GetSnapshotFileDownloadURL,
+		connect.WithSchema(backrestGetSnapshotFileDownloadURLMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetSnapshotArchiveURLHandler := connect.NewUnaryHandler(
+		BackrestGetSnapshotArchiveURLProcedure,
+		svc.GetSnapshotArchiveURL,
+		connect.WithSchema(backrestGetSnapshotArchiveURLMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestExportOperationsHandler := connect.NewServerStreamHandler(
+		BackrestExportOperationsProcedure,
+		svc.ExportOperations,
+		connect.WithSchema(backrestExportOperationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestImportOperationsHandler := connect.NewClientStreamHandler(
+		BackrestImportOperationsProcedure,
+		svc.ImportOperations,
+		connect.WithSchema(backrestImportOperationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestAnnotateOperationHandler := connect.NewUnaryHandler(
+		BackrestAnnotateOperationProcedure,
+		svc.AnnotateOperation,
+		connect.WithSchema(backrestAnnotateOperationMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	backrestClearHistoryHandler := connect.NewUnaryHandler(
 		BackrestClearHistoryProcedure,
 		svc.ClearHistory,
@@ -537,6 +1825,78 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 		connect.WithSchema(backrestPathAutocompleteMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	backrestDescribeCronHandler := connect.NewUnaryHandler(
+		BackrestDescribeCronProcedure,
+		svc.DescribeCron,
+		connect.WithSchema(backrestDescribeCronMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestValidateCronHandler := connect.NewUnaryHandler(
+		BackrestValidateCronProcedure,
+		svc.ValidateCron,
+		connect.WithSchema(backrestValidateCronMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestSetPausedHandler := connect.NewUnaryHandler(
+		BackrestSetPausedProcedure,
+		svc.SetPaused,
+		connect.WithSchema(backrestSetPausedMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestSetBandwidthLimitHandler := connect.NewUnaryHandler(
+		BackrestSetBandwidthLimitProcedure,
+		svc.SetBandwidthLimit,
+		connect.WithSchema(backrestSetBandwidthLimitMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestPutReplicaHandler := connect.NewUnaryHandler(
+		BackrestPutReplicaProcedure,
+		svc.PutReplica,
+		connect.WithSchema(backrestPutReplicaMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestSearchHandler := connect.NewUnaryHandler(
+		BackrestSearchProcedure,
+		svc.Search,
+		connect.WithSchema(backrestSearchMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetAlertsHandler := connect.NewUnaryHandler(
+		BackrestGetAlertsProcedure,
+		svc.GetAlerts,
+		connect.WithSchema(backrestGetAlertsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestAcknowledgeAlertHandler := connect.NewUnaryHandler(
+		BackrestAcknowledgeAlertProcedure,
+		svc.AcknowledgeAlert,
+		connect.WithSchema(backrestAcknowledgeAlertMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestSnoozeAlertHandler := connect.NewUnaryHandler(
+		BackrestSnoozeAlertProcedure,
+		svc.SnoozeAlert,
+		connect.WithSchema(backrestSnoozeAlertMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetHookDeliveriesHandler := connect.NewUnaryHandler(
+		BackrestGetHookDeliveriesProcedure,
+		svc.GetHookDeliveries,
+		connect.WithSchema(backrestGetHookDeliveriesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestRetryHookDeliveryHandler := connect.NewUnaryHandler(
+		BackrestRetryHookDeliveryProcedure,
+		svc.RetryHookDelivery,
+		connect.WithSchema(backrestRetryHookDeliveryMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	backrestGetSchedulerDecisionsHandler := connect.NewUnaryHandler(
+		BackrestGetSchedulerDecisionsProcedure,
+		svc.GetSchedulerDecisions,
+		connect.WithSchema(backrestGetSchedulerDecisionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/v1.Backrest/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BackrestGetConfigProcedure:
@@ -547,36 +1907,138 @@ func NewBackrestHandler(svc BackrestHandler, opts ...connect.HandlerOption) (str
 			backrestAddRepoHandler.ServeHTTP(w, r)
 		case BackrestGetOperationEventsProcedure:
 			backrestGetOperationEventsHandler.ServeHTTP(w, r)
+		case BackrestSubscribeOperationsProcedure:
+			backrestSubscribeOperationsHandler.ServeHTTP(w, r)
 		case BackrestGetOperationsProcedure:
 			backrestGetOperationsHandler.ServeHTTP(w, r)
 		case BackrestListSnapshotsProcedure:
 			backrestListSnapshotsHandler.ServeHTTP(w, r)
 		case BackrestListSnapshotFilesProcedure:
 			backrestListSnapshotFilesHandler.ServeHTTP(w, r)
+		case BackrestListRestorePointsProcedure:
+			backrestListRestorePointsHandler.ServeHTTP(w, r)
+		case BackrestDiffSnapshotsProcedure:
+			backrestDiffSnapshotsHandler.ServeHTTP(w, r)
+		case BackrestSearchSnapshotsProcedure:
+			backrestSearchSnapshotsHandler.ServeHTTP(w, r)
+		case BackrestListFileVersionsProcedure:
+			backrestListFileVersionsHandler.ServeHTTP(w, r)
+		case BackrestTagSnapshotsProcedure:
+			backrestTagSnapshotsHandler.ServeHTTP(w, r)
+		case BackrestPreviewRewriteSnapshotsProcedure:
+			backrestPreviewRewriteSnapshotsHandler.ServeHTTP(w, r)
+		case BackrestRewriteSnapshotsProcedure:
+			backrestRewriteSnapshotsHandler.ServeHTTP(w, r)
+		case BackrestGetSnapshotStatsProcedure:
+			backrestGetSnapshotStatsHandler.ServeHTTP(w, r)
 		case BackrestIndexSnapshotsProcedure:
 			backrestIndexSnapshotsHandler.ServeHTTP(w, r)
 		case BackrestBackupProcedure:
 			backrestBackupHandler.ServeHTTP(w, r)
+		case BackrestBackupWithAnnotationsProcedure:
+			backrestBackupWithAnnotationsHandler.ServeHTTP(w, r)
+		case BackrestPreviewBackupProcedure:
+			backrestPreviewBackupHandler.ServeHTTP(w, r)
 		case BackrestPruneProcedure:
 			backrestPruneHandler.ServeHTTP(w, r)
 		case BackrestForgetProcedure:
 			backrestForgetHandler.ServeHTTP(w, r)
+		case BackrestCheckProcedure:
+			backrestCheckHandler.ServeHTTP(w, r)
+		case BackrestInitRepoProcedure:
+			backrestInitRepoHandler.ServeHTTP(w, r)
+		case BackrestMigrateRepoProcedure:
+			backrestMigrateRepoHandler.ServeHTTP(w, r)
+		case BackrestGetRepoUpgradeProcedure:
+			backrestGetRepoUpgradeHandler.ServeHTTP(w, r)
+		case BackrestUpgradeRepoProcedure:
+			backrestUpgradeRepoHandler.ServeHTTP(w, r)
+		case BackrestRecoverRepoProcedure:
+			backrestRecoverRepoHandler.ServeHTTP(w, r)
 		case BackrestRestoreProcedure:
 			backrestRestoreHandler.ServeHTTP(w, r)
+		case BackrestGetRestoreConflictsProcedure:
+			backrestGetRestoreConflictsHandler.ServeHTTP(w, r)
+		case BackrestPreviewRestoreProcedure:
+			backrestPreviewRestoreHandler.ServeHTTP(w, r)
 		case BackrestUnlockProcedure:
 			backrestUnlockHandler.ServeHTTP(w, r)
+		case BackrestMountRepoProcedure:
+			backrestMountRepoHandler.ServeHTTP(w, r)
+		case BackrestUnmountRepoProcedure:
+			backrestUnmountRepoHandler.ServeHTTP(w, r)
+		case BackrestGetMountsProcedure:
+			backrestGetMountsHandler.ServeHTTP(w, r)
+		case BackrestListRepoKeysProcedure:
+			backrestListRepoKeysHandler.ServeHTTP(w, r)
+		case BackrestAddRepoKeyProcedure:
+			backrestAddRepoKeyHandler.ServeHTTP(w, r)
+		case BackrestRemoveRepoKeyProcedure:
+			backrestRemoveRepoKeyHandler.ServeHTTP(w, r)
+		case BackrestChangeRepoPasswordProcedure:
+			backrestChangeRepoPasswordHandler.ServeHTTP(w, r)
+		case BackrestGetRepoCacheUsageProcedure:
+			backrestGetRepoCacheUsageHandler.ServeHTTP(w, r)
 		case BackrestStatsProcedure:
 			backrestStatsHandler.ServeHTTP(w, r)
+		case BackrestGetRepoStatsHistoryProcedure:
+			backrestGetRepoStatsHistoryHandler.ServeHTTP(w, r)
+		case BackrestGetStatsProcedure:
+			backrestGetStatsHandler.ServeHTTP(w, r)
 		case BackrestCancelProcedure:
 			backrestCancelHandler.ServeHTTP(w, r)
 		case BackrestGetLogsProcedure:
 			backrestGetLogsHandler.ServeHTTP(w, r)
+		case BackrestGetResticInfoProcedure:
+			backrestGetResticInfoHandler.ServeHTTP(w, r)
 		case BackrestGetDownloadURLProcedure:
 			backrestGetDownloadURLHandler.ServeHTTP(w, r)
+		case BackrestGetRestoreDownloadURLProcedure:
+			backrestGetRestoreDownloadURLHandler.ServeHTTP(w, r)
+		case BackrestCreateShareLinkProcedure:
+			backrestCreateShareLinkHandler.ServeHTTP(w, r)
+		case BackrestGetShareLinksProcedure:
+			backrestGetShareLinksHandler.ServeHTTP(w, r)
+		case BackrestRevokeShareLinkProcedure:
+			backrestRevokeShareLinkHandler.ServeHTTP(w, r)
+		case BackrestGetSnapshotFileDownloadURLProcedure:
+			backrestGetSnapshotFileDownloadURLHandler.ServeHTTP(w, r)
+		case BackrestGetSnapshotArchiveURLProcedure:
+			backrestGetSnapshotArchiveURLHandler.ServeHTTP(w, r)
+		case BackrestExportOperationsProcedure:
+			backrestExportOperationsHandler.ServeHTTP(w, r)
+		case BackrestImportOperationsProcedure:
+			backrestImportOperationsHandler.ServeHTTP(w, r)
+		case BackrestAnnotateOperationProcedure:
+			backrestAnnotateOperationHandler.ServeHTTP(w, r)
 		case BackrestClearHistoryProcedure:
 			backrestClearHistoryHandler.ServeHTTP(w, r)
 		case BackrestPathAutocompleteProcedure:
 			backrestPathAutocompleteHandler.ServeHTTP(w, r)
+		case BackrestDescribeCronProcedure:
+			backrestDescribeCronHandler.ServeHTTP(w, r)
+		case BackrestValidateCronProcedure:
+			backrestValidateCronHandler.ServeHTTP(w, r)
+		case BackrestSetPausedProcedure:
+			backrestSetPausedHandler.ServeHTTP(w, r)
+		case BackrestSetBandwidthLimitProcedure:
+			backrestSetBandwidthLimitHandler.ServeHTTP(w, r)
+		case BackrestPutReplicaProcedure:
+			backrestPutReplicaHandler.ServeHTTP(w, r)
+		case BackrestSearchProcedure:
+			backrestSearchHandler.ServeHTTP(w, r)
+		case BackrestGetAlertsProcedure:
+			backrestGetAlertsHandler.ServeHTTP(w, r)
+		case BackrestAcknowledgeAlertProcedure:
+			backrestAcknowledgeAlertHandler.ServeHTTP(w, r)
+		case BackrestSnoozeAlertProcedure:
+			backrestSnoozeAlertHandler.ServeHTTP(w, r)
+		case BackrestGetHookDeliveriesProcedure:
+			backrestGetHookDeliveriesHandler.ServeHTTP(w, r)
+		case BackrestRetryHookDeliveryProcedure:
+			backrestRetryHookDeliveryHandler.ServeHTTP(w, r)
+		case BackrestGetSchedulerDecisionsProcedure:
+			backrestGetSchedulerDecisionsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -602,6 +2064,10 @@ func (UnimplementedBackrestHandler) GetOperationEvents(context.Context, *connect
 	return connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetOperationEvents is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) SubscribeOperations(context.Context, *connect.Request[v1.SubscribeOperationsRequest], *connect.ServerStream[v1.OperationEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SubscribeOperations is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) GetOperations(context.Context, *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetOperations is not implemented"))
 }
@@ -614,6 +2080,38 @@ func (UnimplementedBackrestHandler) ListSnapshotFiles(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ListSnapshotFiles is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) ListRestorePoints(context.Context, *connect.Request[v1.ListRestorePointsRequest]) (*connect.Response[v1.RestorePointList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ListRestorePoints is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) DiffSnapshots(context.Context, *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.SnapshotDiff], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.DiffSnapshots is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) SearchSnapshots(context.Context, *connect.Request[v1.SearchSnapshotsRequest], *connect.ServerStream[v1.SnapshotFileMatch]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SearchSnapshots is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ListFileVersions(context.Context, *connect.Request[v1.ListFileVersionsRequest]) (*connect.Response[v1.FileVersionList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ListFileVersions is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) TagSnapshots(context.Context, *connect.Request[v1.TagSnapshotsRequest]) (*connect.Response[types.Int64Value], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.TagSnapshots is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) PreviewRewriteSnapshots(context.Context, *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[v1.RewritePreview], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PreviewRewriteSnapshots is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) RewriteSnapshots(context.Context, *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[types.Int64Value], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RewriteSnapshots is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetSnapshotStats(context.Context, *connect.Request[v1.GetSnapshotStatsRequest]) (*connect.Response[v1.SnapshotStats], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetSnapshotStats is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) IndexSnapshots(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.IndexSnapshots is not implemented"))
 }
@@ -622,26 +2120,106 @@ func (UnimplementedBackrestHandler) Backup(context.Context, *connect.Request[typ
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Backup is not implemented"))
 }
 
-func (UnimplementedBackrestHandler) Prune(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+func (UnimplementedBackrestHandler) BackupWithAnnotations(context.Context, *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.BackupWithAnnotations is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) PreviewBackup(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.BackupPreview], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PreviewBackup is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) Prune(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Prune is not implemented"))
 }
 
-func (UnimplementedBackrestHandler) Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[emptypb.Empty], error) {
+func (UnimplementedBackrestHandler) Forget(context.Context, *connect.Request[v1.ForgetRequest]) (*connect.Response[types.Int64Value], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Forget is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) Check(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Check is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) InitRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.InitRepo is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) MigrateRepo(context.Context, *connect.Request[v1.MigrateRepoRequest]) (*connect.Response[types.Int64Value], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.MigrateRepo is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetRepoUpgrade(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoUpgradeAssessment], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetRepoUpgrade is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) UpgradeRepo(context.Context, *connect.Request[v1.UpgradeRepoRequest]) (*connect.Response[types.Int64Value], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.UpgradeRepo is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) RecoverRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RecoverRepo is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) Restore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Restore is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) GetRestoreConflicts(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreConflictReport], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetRestoreConflicts is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) PreviewRestore(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestorePreview], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PreviewRestore is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) Unlock(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Unlock is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) MountRepo(context.Context, *connect.Request[v1.MountRepoRequest]) (*connect.Response[v1.RepoMount], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.MountRepo is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) UnmountRepo(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.UnmountRepo is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetMounts(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RepoMountList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetMounts is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ListRepoKeys(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoKeyList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ListRepoKeys is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) AddRepoKey(context.Context, *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.AddRepoKey is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) RemoveRepoKey(context.Context, *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RemoveRepoKey is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ChangeRepoPassword(context.Context, *connect.Request[v1.ChangeRepoPasswordRequest]) (*connect.Response[v1.Config], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ChangeRepoPassword is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetRepoCacheUsage(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.RepoCacheUsage], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetRepoCacheUsage is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) Stats(context.Context, *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Stats is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) GetRepoStatsHistory(context.Context, *connect.Request[v1.RepoStatsHistoryRequest]) (*connect.Response[v1.RepoStatsHistory], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetRepoStatsHistory is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetStats(context.Context, *connect.Request[v1.GetStatsRequest]) (*connect.Response[v1.PlanStatsList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetStats is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) Cancel(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Cancel is not implemented"))
 }
@@ -650,10 +2228,50 @@ func (UnimplementedBackrestHandler) GetLogs(context.Context, *connect.Request[v1
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetLogs is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) GetResticInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ResticInfo], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetResticInfo is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) GetDownloadURL(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[types.StringValue], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetDownloadURL is not implemented"))
 }
 
+func (UnimplementedBackrestHandler) GetRestoreDownloadURL(context.Context, *connect.Request[v1.RestoreDownloadURLRequest]) (*connect.Response[types.StringValue], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetRestoreDownloadURL is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.CreateShareLink is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetShareLinks(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ShareLinkList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetShareLinks is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) RevokeShareLink(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[v1.ShareLink], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RevokeShareLink is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetSnapshotFileDownloadURL(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetSnapshotFileDownloadURL is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetSnapshotArchiveURL(context.Context, *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetSnapshotArchiveURL is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ExportOperations(context.Context, *connect.Request[v1.ExportOperationsRequest], *connect.ServerStream[types.BytesValue]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ExportOperations is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ImportOperations(context.Context, *connect.ClientStream[types.BytesValue]) (*connect.Response[v1.ImportOperationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ImportOperations is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) AnnotateOperation(context.Context, *connect.Request[v1.AnnotateOperationRequest]) (*connect.Response[v1.Operation], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.AnnotateOperation is not implemented"))
+}
+
 func (UnimplementedBackrestHandler) ClearHistory(context.Context, *connect.Request[v1.ClearHistoryRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ClearHistory is not implemented"))
 }
@@ -661,3 +2279,51 @@ func (UnimplementedBackrestHandler) ClearHistory(context.Context, *connect.Reque
 func (UnimplementedBackrestHandler) PathAutocomplete(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringList], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PathAutocomplete is not implemented"))
 }
+
+func (UnimplementedBackrestHandler) DescribeCron(context.Context, *connect.Request[types.StringValue]) (*connect.Response[types.StringValue], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.DescribeCron is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) ValidateCron(context.Context, *connect.Request[v1.ValidateCronRequest]) (*connect.Response[v1.ValidateCronResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.ValidateCron is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) SetPaused(context.Context, *connect.Request[v1.SetPausedRequest]) (*connect.Response[v1.Config], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SetPaused is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) SetBandwidthLimit(context.Context, *connect.Request[v1.SetBandwidthLimitRequest]) (*connect.Response[v1.Config], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SetBandwidthLimit is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) PutReplica(context.Context, *connect.Request[v1.SealedReplica]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.PutReplica is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) Search(context.Context, *connect.Request[v1.SearchRequest]) (*connect.Response[v1.SearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.Search is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetAlerts(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.AlertList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetAlerts is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) AcknowledgeAlert(context.Context, *connect.Request[types.StringValue]) (*connect.Response[v1.Alert], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.AcknowledgeAlert is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) SnoozeAlert(context.Context, *connect.Request[v1.SnoozeAlertRequest]) (*connect.Response[v1.Alert], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.SnoozeAlert is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetHookDeliveries(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.HookDeliveryList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetHookDeliveries is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) RetryHookDelivery(context.Context, *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.RetryHookDelivery is not implemented"))
+}
+
+func (UnimplementedBackrestHandler) GetSchedulerDecisions(context.Context, *connect.Request[v1.SchedulerDecisionsRequest]) (*connect.Response[v1.SchedulerDecisionList], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("v1.Backrest.GetSchedulerDecisions is not implemented"))
+}
@@ -0,0 +1,214 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/backupwindow"
+	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+)
+
+// missedScheduleGrace is how long a plan's backup may run late before the plan is alerted as having missed its schedule.
+const missedScheduleGrace = time.Hour
+
+var ErrNotFound = errors.New("alert not found")
+
+// Evaluate applies the health rules to the config and the operation log, returning the current alerts without their
+// states.
+func Evaluate(cfg *v1.Config, log *oplog.OpLog, now time.Time) ([]*v1.Alert, error) {
+	var alerts []*v1.Alert
+	for _, plan := range cfg.Plans {
+		planAlerts, err := evaluatePlan(cfg, plan, log, now)
+		if err != nil {
+			return nil, fmt.Errorf("plan %q: %w", plan.Id, err)
+		}
+		alerts = append(alerts, planAlerts...)
+	}
+
+	for _, repo := range cfg.Repos {
+		alert, err := evaluateOplogVerification(repo, log)
+		if err != nil {
+			return nil, fmt.Errorf("repo %q: %w", repo.Id, err)
+		}
+		if alert != nil {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	for _, ns := range cfg.Namespaces {
+		if ns.StagingQuotaBytes <= 0 {
+			continue
+		}
+		staged, err := StagedRestoreBytes(log, config.ReposInNamespace(cfg, ns.Id))
+		if err != nil {
+			return nil, fmt.Errorf("namespace %q: %w", ns.Id, err)
+		}
+		if staged >= ns.StagingQuotaBytes {
+			alerts = append(alerts, &v1.Alert{
+				Id:        "staging_quota/" + ns.Id,
+				Kind:      v1.Alert_KIND_STAGING_QUOTA,
+				Namespace: ns.Id,
+				Message:   fmt.Sprintf("%d bytes of restores are staged, at or above the namespace's quota of %d bytes. New restores are refused until old restores are removed.", staged, ns.StagingQuotaBytes),
+			})
+		}
+	}
+	return alerts, nil
+}
+
+// evaluatePlan returns the alerts for a plan's failed backups and missed schedule. Disabled and paused plans only
+// alert failures.
+func evaluatePlan(cfg *v1.Config, plan *v1.Plan, log *oplog.OpLog, now time.Time) ([]*v1.Alert, error) {
+	var lastRun time.Time
+	var running bool
+	var failedSince int64
+	var lastFailure *v1.Operation
+	if err := log.ForEachByPlan(plan.Id, indexutil.CollectAll(), func(op *v1.Operation) error {
+		if op.GetOperationBackup() == nil {
+			return nil
+		}
+		switch op.Status {
+		case v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_WARNING:
+			failedSince, lastFailure = 0, nil
+		case v1.OperationStatus_STATUS_ERROR:
+			if lastFailure == nil {
+				failedSince = op.UnixTimeStartMs
+			}
+			lastFailure = op
+		case v1.OperationStatus_STATUS_INPROGRESS:
+		default:
+			return nil // the backup did not run.
+		}
+		lastRun = time.UnixMilli(op.UnixTimeStartMs)
+		running = op.Status == v1.OperationStatus_STATUS_INPROGRESS
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list backups: %w", err)
+	}
+
+	var alerts []*v1.Alert
+	if lastFailure != nil {
+		alerts = append(alerts, &v1.Alert{
+			Id:          "backup_failed/" + plan.Id,
+			Kind:        v1.Alert_KIND_BACKUP_FAILED,
+			PlanId:      plan.Id,
+			RepoId:      plan.Repo,
+			Message:     "Backup failed: " + lastFailure.DisplayMessage,
+			SinceMs:     failedSince,
+			OperationId: lastFailure.Id,
+		})
+	}
+
+	if lastRun.IsZero() || running || !scheduled(cfg, plan) {
+		return alerts, nil
+	}
+	expected, err := nextExpectedBackup(plan, lastRun)
+	if err != nil {
+		return nil, err
+	}
+	if !expected.IsZero() && now.After(expected.Add(missedScheduleGrace)) {
+		alerts = append(alerts, &v1.Alert{
+			Id:      "missed_schedule/" + plan.Id,
+			Kind:    v1.Alert_KIND_MISSED_SCHEDULE,
+			PlanId:  plan.Id,
+			RepoId:  plan.Repo,
+			Message: fmt.Sprintf("No backup has run since %v, a backup was expected at %v.", lastRun.Format(time.RFC3339), expected.Format(time.RFC3339)),
+			SinceMs: expected.UnixMilli(),
+		})
+	}
+	return alerts, nil
+}
+
+// evaluateOplogVerification returns an alert if the repo's most recent oplog verification found inconsistencies, nil
+// otherwise.
+func evaluateOplogVerification(repo *v1.Repo, log *oplog.OpLog) (*v1.Alert, error) {
+	var latest *v1.Operation
+	var since int64
+	if err := log.ForEachByRepo(repo.Id, indexutil.Reversed(indexutil.CollectAll()), func(op *v1.Operation) error {
+		verify := op.GetOperationVerifyOplog()
+		if verify == nil || op.UnixTimeEndMs == 0 {
+			return nil
+		}
+		if verify.InconsistencyCount == 0 {
+			return oplog.ErrStopIteration
+		}
+		if latest == nil {
+			latest = op
+		}
+		since = op.UnixTimeStartMs // the start of the oldest verification in the run of failed verifications.
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list oplog verifications: %w", err)
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return &v1.Alert{
+		Id:          "oplog_inconsistent/" + repo.Id,
+		Kind:        v1.Alert_KIND_OPLOG_INCONSISTENT,
+		RepoId:      repo.Id,
+		Message:     "Oplog verification " + latest.DisplayMessage,
+		SinceMs:     since,
+		OperationId: latest.Id,
+	}, nil
+}
+
+// scheduled returns true if the orchestrator schedules backups of the plan on a schedule of its own.
+func scheduled(cfg *v1.Config, plan *v1.Plan) bool {
+	if plan.Disabled || plan.Paused != nil {
+		return false
+	}
+	if idx := slices.IndexFunc(cfg.Repos, func(r *v1.Repo) bool { return r.Id == plan.Repo }); idx != -1 && cfg.Repos[idx].Paused != nil {
+		return false
+	}
+	return plan.Cron != "" || plan.GetSchedule().GetInterval() != ""
+}
+
+// nextExpectedBackup returns the time the plan's first backup after lastRun is expected to start.
+func nextExpectedBackup(plan *v1.Plan, lastRun time.Time) (time.Time, error) {
+	var expected time.Time
+	if interval := plan.GetSchedule().GetInterval(); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse interval %q: %w", interval, err)
+		}
+		expected = lastRun.Add(d)
+	} else {
+		sched, err := cronutil.ParseInLocation(plan.Cron, time.Now().Location().String())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse schedule %q: %w", plan.Cron, err)
+		}
+		expected = sched.Next(lastRun)
+	}
+	if w := plan.BackupWindow; w != nil && !expected.IsZero() && !backupwindow.Allows(w, expected) {
+		expected = backupwindow.NextStart(w, expected)
+	}
+	return expected, nil
+}
+
+// StagedRestoreBytes returns the size of the restores of the repos that are still on disk.
+func StagedRestoreBytes(log *oplog.OpLog, repoIDs []string) (int64, error) {
+	var staged int64
+	for _, repoID := range repoIDs {
+		if err := log.ForEachByRepo(repoID, indexutil.CollectAll(), func(op *v1.Operation) error {
+			restore := op.GetOperationRestore()
+			if restore == nil || restore.Target == "" || restore.InPlace {
+				return nil
+			}
+			if _, err := os.Stat(restore.Target); err != nil {
+				return nil // the restore was removed or never written, it no longer counts against the quota.
+			}
+			staged += max(restore.GetStatus().GetTotalBytes(), restore.GetStatus().GetBytesRestored())
+			return nil
+		}); err != nil {
+			return 0, fmt.Errorf("sum staged restores: %w", err)
+		}
+	}
+	return staged, nil
+}
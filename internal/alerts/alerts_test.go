@@ -0,0 +1,169 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+func newTestOpLog(t *testing.T) *oplog.OpLog {
+	t.Helper()
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+	return log
+}
+
+func addBackup(t *testing.T, log *oplog.OpLog, planID string, start time.Time, status v1.OperationStatus) *v1.Operation {
+	t.Helper()
+	op := &v1.Operation{
+		RepoId:          "repo",
+		PlanId:          planID,
+		UnixTimeStartMs: start.UnixMilli(),
+		Status:          status,
+		DisplayMessage:  "boom",
+		Op:              &v1.Operation_OperationBackup{},
+	}
+	if err := log.Add(op); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	return op
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	cfg := &v1.Config{
+		Repos: []*v1.Repo{{Id: "repo"}},
+		Plans: []*v1.Plan{
+			{Id: "failing", Repo: "repo", Cron: "0 * * * *"},
+			{Id: "late", Repo: "repo", Cron: "0 0 * * *"},
+			{Id: "late-but-paused", Repo: "repo", Cron: "0 0 * * *", Paused: &v1.PauseState{}},
+			{Id: "healthy", Repo: "repo", Cron: "0 0 * * *"},
+		},
+	}
+	log := newTestOpLog(t)
+	addBackup(t, log, "failing", now.Add(-3*time.Hour), v1.OperationStatus_STATUS_SUCCESS)
+	firstFailure := addBackup(t, log, "failing", now.Add(-2*time.Hour), v1.OperationStatus_STATUS_ERROR)
+	lastFailure := addBackup(t, log, "failing", now.Add(-time.Hour), v1.OperationStatus_STATUS_ERROR)
+	addBackup(t, log, "failing", now.Add(-time.Minute), v1.OperationStatus_STATUS_PENDING)
+	addBackup(t, log, "late", now.Add(-50*time.Hour), v1.OperationStatus_STATUS_SUCCESS)
+	addBackup(t, log, "late-but-paused", now.Add(-50*time.Hour), v1.OperationStatus_STATUS_SUCCESS)
+	addBackup(t, log, "healthy", now.Add(-12*time.Hour), v1.OperationStatus_STATUS_ERROR)
+	addBackup(t, log, "healthy", now.Add(-11*time.Hour), v1.OperationStatus_STATUS_SUCCESS)
+
+	alerts, err := Evaluate(cfg, log, now)
+	if err != nil {
+		t.Fatalf("Evaluate() error: %v", err)
+	}
+	got := make(map[string]*v1.Alert)
+	for _, alert := range alerts {
+		got[alert.Id] = alert
+	}
+	if len(got) != 2 {
+		t.Errorf("expected 2 alerts, got %v", alerts)
+	}
+	if alert := got["backup_failed/failing"]; alert == nil || alert.SinceMs != firstFailure.UnixTimeStartMs || alert.OperationId != lastFailure.Id {
+		t.Errorf("expected a failed backup alert since the first failure for the last failure, got %v", alert)
+	}
+	if alert := got["missed_schedule/late"]; alert == nil || alert.Kind != v1.Alert_KIND_MISSED_SCHEDULE {
+		t.Errorf("expected a missed schedule alert, got %v", alert)
+	}
+}
+
+func TestEvaluateOplogVerification(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	cfg := &v1.Config{Repos: []*v1.Repo{{Id: "repo"}}}
+	log := newTestOpLog(t)
+	addVerify := func(start time.Time, inconsistencies int32) *v1.Operation {
+		op := &v1.Operation{
+			RepoId:          "repo",
+			PlanId:          "_unassociated_",
+			UnixTimeStartMs: start.UnixMilli(),
+			UnixTimeEndMs:   start.Add(time.Second).UnixMilli(),
+			Status:          v1.OperationStatus_STATUS_SUCCESS,
+			DisplayMessage:  "found inconsistencies",
+			Op:              &v1.Operation_OperationVerifyOplog{OperationVerifyOplog: &v1.OperationVerifyOplog{InconsistencyCount: inconsistencies}},
+		}
+		if err := log.Add(op); err != nil {
+			t.Fatalf("error adding operation: %v", err)
+		}
+		return op
+	}
+
+	addVerify(now.Add(-72*time.Hour), 1)
+	addVerify(now.Add(-48*time.Hour), 0)
+	first := addVerify(now.Add(-24*time.Hour), 2)
+	last := addVerify(now.Add(-time.Hour), 1)
+
+	alerts, err := Evaluate(cfg, log, now)
+	if err != nil {
+		t.Fatalf("Evaluate() error: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].Kind != v1.Alert_KIND_OPLOG_INCONSISTENT || alerts[0].SinceMs != first.UnixTimeStartMs || alerts[0].OperationId != last.Id {
+		t.Errorf("expected an oplog inconsistency alert since the first failed verification in a row, got %v", alerts)
+	}
+
+	addVerify(now, 0)
+	if alerts, err := Evaluate(cfg, log, now); err != nil || len(alerts) != 0 {
+		t.Errorf("expected no alerts once a verification passes, got %v, err: %v", alerts, err)
+	}
+}
+
+func TestAcknowledgeAndSnooze(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cfg := &v1.Config{
+		Repos: []*v1.Repo{{Id: "repo"}},
+		Plans: []*v1.Plan{{Id: "plan", Repo: "repo", Cron: "0 0 1 1 *"}},
+	}
+	log := newTestOpLog(t)
+	addBackup(t, log, "plan", now.Add(-time.Hour), v1.OperationStatus_STATUS_ERROR)
+
+	if muted, err := BackupFailuresMuted(cfg, log, "plan", now); err != nil || muted {
+		t.Fatalf("expected failures not to be muted before the alert is snoozed, got %v, %v", muted, err)
+	}
+	if _, err := Snooze(cfg, log, "backup_failed/plan", time.Hour, now); err != nil {
+		t.Fatalf("Snooze() error: %v", err)
+	}
+	if muted, _ := BackupFailuresMuted(cfg, log, "plan", now.Add(30*time.Minute)); !muted {
+		t.Errorf("expected failures to be muted while the alert is snoozed")
+	}
+	if muted, _ := BackupFailuresMuted(cfg, log, "plan", now.Add(2*time.Hour)); muted {
+		t.Errorf("expected failures not to be muted once the snooze ended")
+	}
+
+	alert, err := Acknowledge(cfg, log, "backup_failed/plan", "alice", now)
+	if err != nil {
+		t.Fatalf("Acknowledge() error: %v", err)
+	}
+	if alert.State.AcknowledgedBy != "alice" || alert.State.SnoozedUntilMs == 0 {
+		t.Errorf("expected the acknowledgement to be added to the snoozed state, got %v", alert.State)
+	}
+	if muted, _ := BackupFailuresMuted(cfg, log, "plan", now.Add(48*time.Hour)); !muted {
+		t.Errorf("expected failures to be muted while the alert is acknowledged")
+	}
+
+	// the alert is resolved by a successful backup, the next failure is a new alert.
+	addBackup(t, log, "plan", now.Add(time.Minute), v1.OperationStatus_STATUS_SUCCESS)
+	if alerts, err := Current(cfg, log, now); err != nil || len(alerts) != 0 {
+		t.Fatalf("expected no alerts after a successful backup, got %v, %v", alerts, err)
+	}
+	addBackup(t, log, "plan", now.Add(2*time.Minute), v1.OperationStatus_STATUS_ERROR)
+	alerts, err := Current(cfg, log, now)
+	if err != nil || len(alerts) != 1 || alerts[0].State != nil {
+		t.Errorf("expected a new alert without a state, got %v, %v", alerts, err)
+	}
+
+	if _, err := Acknowledge(cfg, log, "backup_failed/other", "alice", now); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound acknowledging an alert that does not exist, got %v", err)
+	}
+}
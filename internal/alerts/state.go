@@ -0,0 +1,104 @@
+package alerts
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+// Current returns the current alerts with their states. States of alerts that were resolved are discarded so that a
+// recurrence of the problem notifies again.
+func Current(cfg *v1.Config, log *oplog.OpLog, now time.Time) ([]*v1.Alert, error) {
+	alerts, err := Evaluate(cfg, log, now)
+	if err != nil {
+		return nil, err
+	}
+	states, err := log.GetAlertStates()
+	if err != nil {
+		return nil, fmt.Errorf("get alert states: %w", err)
+	}
+
+	for _, alert := range alerts {
+		if state, ok := states[alert.Id]; ok && state.SinceMs == alert.SinceMs {
+			alert.State = state
+			delete(states, alert.Id)
+		}
+	}
+	if len(states) > 0 {
+		resolved := make([]string, 0, len(states))
+		for id := range states {
+			resolved = append(resolved, id)
+		}
+		if err := log.DeleteAlertStates(resolved...); err != nil {
+			return nil, fmt.Errorf("delete states of resolved alerts: %w", err)
+		}
+	}
+	return alerts, nil
+}
+
+// Acknowledge suppresses notifications for the alert until it's resolved.
+func Acknowledge(cfg *v1.Config, log *oplog.OpLog, id string, user string, now time.Time) (*v1.Alert, error) {
+	return updateState(cfg, log, id, now, func(state *v1.AlertState) {
+		state.AcknowledgedMs = now.UnixMilli()
+		state.AcknowledgedBy = user
+	})
+}
+
+// Snooze suppresses notifications for the alert until now + d, or until it's resolved if that's sooner.
+func Snooze(cfg *v1.Config, log *oplog.OpLog, id string, d time.Duration, now time.Time) (*v1.Alert, error) {
+	return updateState(cfg, log, id, now, func(state *v1.AlertState) {
+		state.SnoozedUntilMs = now.Add(d).UnixMilli()
+	})
+}
+
+func updateState(cfg *v1.Config, log *oplog.OpLog, id string, now time.Time, update func(state *v1.AlertState)) (*v1.Alert, error) {
+	alerts, err := Current(cfg, log, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range alerts {
+		if alert.Id != id {
+			continue
+		}
+		if alert.State == nil {
+			alert.State = &v1.AlertState{SinceMs: alert.SinceMs}
+		}
+		update(alert.State)
+		if err := log.PutAlertState(alert.Id, alert.State); err != nil {
+			return nil, fmt.Errorf("put alert state: %w", err)
+		}
+		return alert, nil
+	}
+	return nil, ErrNotFound
+}
+
+// muted returns true if the state suppresses notifications at now.
+func muted(state *v1.AlertState, now time.Time) bool {
+	return state != nil && (state.AcknowledgedMs != 0 || state.SnoozedUntilMs > now.UnixMilli())
+}
+
+// BackupFailuresMuted returns true if the plan's failed backup alert is acknowledged or snoozed, notifications of the
+// plan's further failed backups are suppressed until a backup succeeds.
+func BackupFailuresMuted(cfg *v1.Config, log *oplog.OpLog, planID string, now time.Time) (bool, error) {
+	idx := slices.IndexFunc(cfg.Plans, func(p *v1.Plan) bool { return p.Id == planID })
+	if idx == -1 {
+		return false, nil
+	}
+	alerts, err := evaluatePlan(cfg, cfg.Plans[idx], log, now)
+	if err != nil {
+		return false, err
+	}
+	states, err := log.GetAlertStates()
+	if err != nil {
+		return false, fmt.Errorf("get alert states: %w", err)
+	}
+	for _, alert := range alerts {
+		if state := states[alert.Id]; alert.Kind == v1.Alert_KIND_BACKUP_FAILED && state.GetSinceMs() == alert.SinceMs {
+			return muted(state, now), nil
+		}
+	}
+	return false, nil
+}
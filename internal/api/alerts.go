@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/alerts"
+	"github.com/garethgeorge/backrest/internal/auth"
+	"github.com/garethgeorge/backrest/internal/config"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetAlerts implements POST /v1.Backrest/GetAlerts
+func (s *BackrestHandler) GetAlerts(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.AlertList], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	current, err := alerts.Current(config.ResolveRepoGroups(cfg), s.oplog, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate alerts: %w", err)
+	}
+
+	list := &v1.AlertList{}
+	for _, alert := range current {
+		if access.canAccessAlert(alert) {
+			list.Alerts = append(list.Alerts, alert)
+		}
+	}
+	return connect.NewResponse(list), nil
+}
+
+// AcknowledgeAlert implements POST /v1.Backrest/AcknowledgeAlert
+func (s *BackrestHandler) AcknowledgeAlert(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.Alert], error) {
+	var user string
+	if u, ok := ctx.Value(auth.UserContextKey).(*v1.User); ok {
+		user = u.Name
+	}
+	return s.updateAlert(ctx, req.Msg.Value, func(cfg *v1.Config, now time.Time) (*v1.Alert, error) {
+		return alerts.Acknowledge(cfg, s.oplog, req.Msg.Value, user, now)
+	})
+}
+
+// SnoozeAlert implements POST /v1.Backrest/SnoozeAlert
+func (s *BackrestHandler) SnoozeAlert(ctx context.Context, req *connect.Request[v1.SnoozeAlertRequest]) (*connect.Response[v1.Alert], error) {
+	if req.Msg.DurationMinutes <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("duration must be positive"))
+	}
+	return s.updateAlert(ctx, req.Msg.Id, func(cfg *v1.Config, now time.Time) (*v1.Alert, error) {
+		return alerts.Snooze(cfg, s.oplog, req.Msg.Id, time.Duration(req.Msg.DurationMinutes)*time.Minute, now)
+	})
+}
+
+// updateAlert applies update to the alert if the caller may access it, alerts of other namespaces do not exist for the caller.
+func (s *BackrestHandler) updateAlert(ctx context.Context, id string, update func(cfg *v1.Config, now time.Time) (*v1.Alert, error)) (*connect.Response[v1.Alert], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	cfg = config.ResolveRepoGroups(cfg)
+	now := time.Now()
+
+	current, err := alerts.Evaluate(cfg, s.oplog, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate alerts: %w", err)
+	}
+	found := false
+	for _, alert := range current {
+		if alert.Id == id && access.canAccessAlert(alert) {
+			found = true
+		}
+	}
+	if !found {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("alert %q not found", id))
+	}
+
+	alert, err := update(cfg, now)
+	if errors.Is(err, alerts.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("alert %q not found", id))
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update alert: %w", err)
+	}
+	zap.L().Info("updated alert state", zap.String("alert", id), zap.Any("state", alert.State))
+	return connect.NewResponse(alert), nil
+}
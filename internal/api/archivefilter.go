@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// archiveFilter selects the files of a restore archive download by the include and exclude query parameters, each may
+// be repeated e.g. "?include=*.docx&exclude=drafts". Patterns are globs as understood by path.Match. A pattern without
+// a slash matches a file or directory name at any depth, a pattern with a slash matches the path relative to the
+// downloaded directory from its start. A file matches if it or one of its parent directories does.
+type archiveFilter struct {
+	include []archiveGlob
+	exclude []archiveGlob
+}
+
+type archiveGlob struct {
+	raw      string // the pattern as requested.
+	pattern  string
+	anchored bool // the pattern is matched against the path from the downloaded directory rather than each name.
+}
+
+// archiveFilterFromRequest parses the include and exclude patterns of an archive download, no patterns keep every file.
+func archiveFilterFromRequest(r *http.Request) (archiveFilter, error) {
+	query := r.URL.Query()
+	var f archiveFilter
+	var err error
+	if f.include, err = parseArchiveGlobs(query["include"]); err != nil {
+		return archiveFilter{}, err
+	}
+	if f.exclude, err = parseArchiveGlobs(query["exclude"]); err != nil {
+		return archiveFilter{}, err
+	}
+	return f, nil
+}
+
+func parseArchiveGlobs(patterns []string) ([]archiveGlob, error) {
+	var globs []archiveGlob
+	for _, p := range patterns {
+		g := archiveGlob{
+			raw:      p,
+			pattern:  strings.Trim(p, "/"),
+			anchored: strings.Contains(strings.TrimSuffix(p, "/"), "/"),
+		}
+		if g.pattern == "" {
+			return nil, fmt.Errorf("empty pattern %q", p)
+		}
+		if _, err := path.Match(g.pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// patterns returns the include and exclude patterns as they were requested, for the archive's manifest.
+func (f archiveFilter) patterns() (include, exclude []string) {
+	for _, g := range f.include {
+		include = append(include, g.raw)
+	}
+	for _, g := range f.exclude {
+		exclude = append(exclude, g.raw)
+	}
+	return include, exclude
+}
+
+// skipDir returns true if the directory at rel, a slash separated path relative to the downloaded directory, is
+// excluded along with everything under it.
+func (f archiveFilter) skipDir(rel string) bool {
+	return matchArchiveGlobs(f.exclude, rel)
+}
+
+// keepFile returns true if the file at rel, a slash separated path relative to the downloaded directory, is archived.
+func (f archiveFilter) keepFile(rel string) bool {
+	if matchArchiveGlobs(f.exclude, rel) {
+		return false
+	}
+	return len(f.include) == 0 || matchArchiveGlobs(f.include, rel)
+}
+
+func matchArchiveGlobs(globs []archiveGlob, rel string) bool {
+	for _, g := range globs {
+		if g.match(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns true if the glob matches the path or one of its parent directories.
+func (g archiveGlob) match(rel string) bool {
+	names := strings.Split(rel, "/")
+	for i := range names {
+		candidate := names[i]
+		if g.anchored {
+			candidate = strings.Join(names[:i+1], "/")
+		}
+		if ok, _ := path.Match(g.pattern, candidate); ok {
+			return true
+		}
+	}
+	return false
+}
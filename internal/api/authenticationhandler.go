@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 
 	"connectrpc.com/connect"
 	"github.com/garethgeorge/backrest/gen/go/types"
@@ -29,6 +30,9 @@ func (s *AuthenticationHandler) Login(ctx context.Context, req *connect.Request[
 	user, err := s.authenticator.Login(req.Msg.Username, req.Msg.Password)
 	if err != nil {
 		zap.L().Warn("failed login attempt", zap.Error(err))
+		if errors.Is(err, auth.ErrTooManyAttempts) {
+			return nil, connect.NewError(connect.CodeResourceExhausted, err)
+		}
 		return nil, connect.NewError(connect.CodeUnauthenticated, auth.ErrInvalidPassword)
 	}
 
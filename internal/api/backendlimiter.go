@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	backendMaxConcurrent = 2                // backend reads run concurrently per repo.
+	backendMaxQueued     = 8                // backend reads wait for a slot per repo, more are rejected.
+	backendQueueTimeout  = 30 * time.Second // time a backend read waits for a slot before it's rejected.
+	backendRetryAfter    = 5 * time.Second  // hint returned to rejected clients for when to retry.
+)
+
+// backendLimiter limits the RPCs that read from a repo's backend directly e.g. listing snapshots or files. Identical
+// requests in flight are coalesced into a single read and at most backendMaxConcurrent reads run per repo, so that
+// several open UI tabs or a misbehaving client can't overload the backend. Requests beyond the queue are rejected
+// with CodeResourceExhausted and a Retry-After header.
+type backendLimiter struct {
+	calls singleflight.Group
+
+	mu    sync.Mutex
+	repos map[string]*repoSlots
+}
+
+type repoSlots struct {
+	slots  chan struct{}
+	queued int
+}
+
+func newBackendLimiter() *backendLimiter {
+	return &backendLimiter{
+		repos: make(map[string]*repoSlots),
+	}
+}
+
+// limitBackend runs fn for the repo once a slot is free, concurrent calls with the same key share a single run of fn
+// and its result. The result is shared between callers and must not be modified. fn's context is not cancelled when
+// one caller goes away since other callers may be waiting on it, each caller stops waiting when its own ctx is done.
+func limitBackend[T any](ctx context.Context, l *backendLimiter, repoID, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	ch := l.calls.DoChan(repoID+"\x00"+key, func() (any, error) {
+		release, err := l.acquire(repoID)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	var zero T
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// acquire waits for a slot of the repo for up to backendQueueTimeout, the returned func releases the slot.
+func (l *backendLimiter) acquire(repoID string) (func(), error) {
+	l.mu.Lock()
+	repo, ok := l.repos[repoID]
+	if !ok {
+		repo = &repoSlots{slots: make(chan struct{}, backendMaxConcurrent)}
+		l.repos[repoID] = repo
+	}
+	if repo.queued >= backendMaxQueued {
+		l.mu.Unlock()
+		return nil, tooManyBackendRequests(repoID)
+	}
+	repo.queued++
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		repo.queued--
+		l.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(backendQueueTimeout)
+	defer timer.Stop()
+	select {
+	case repo.slots <- struct{}{}:
+		return func() { <-repo.slots }, nil
+	case <-timer.C:
+		return nil, tooManyBackendRequests(repoID)
+	}
+}
+
+func tooManyBackendRequests(repoID string) error {
+	err := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("too many concurrent requests to the backend of repo %q, retry later", repoID))
+	err.Meta().Set("Retry-After", strconv.Itoa(int(backendRetryAfter.Seconds())))
+	return err
+}
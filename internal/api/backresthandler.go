@@ -1,26 +1,36 @@
 package api
 
 import (
+	"bytes"
+	"cmp"
 	"context"
-	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/garethgeorge/backrest/gen/go/types"
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/gen/go/v1/v1connect"
+	"github.com/garethgeorge/backrest/internal/auth"
 	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/config/validationutil"
+	"github.com/garethgeorge/backrest/internal/cronutil"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
 	"github.com/garethgeorge/backrest/internal/orchestrator"
 	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
 	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/internal/replica"
 	"github.com/garethgeorge/backrest/internal/resticinstaller"
 	"github.com/garethgeorge/backrest/internal/rotatinglog"
 	"github.com/garethgeorge/backrest/pkg/restic"
@@ -35,16 +45,20 @@ type BackrestHandler struct {
 	orchestrator *orchestrator.Orchestrator
 	oplog        *oplog.OpLog
 	logStore     *rotatinglog.RotatingLog
+	replicas     *replica.Store // replicas pushed to this instance by other instances.
+	backend      *backendLimiter
 }
 
 var _ v1connect.BackrestHandler = &BackrestHandler{}
 
-func NewBackrestHandler(config config.ConfigStore, orchestrator *orchestrator.Orchestrator, oplog *oplog.OpLog, logStore *rotatinglog.RotatingLog) *BackrestHandler {
+func NewBackrestHandler(configStore config.ConfigStore, orchestrator *orchestrator.Orchestrator, oplog *oplog.OpLog, logStore *rotatinglog.RotatingLog) *BackrestHandler {
 	s := &BackrestHandler{
-		config:       config,
+		config:       configStore,
 		orchestrator: orchestrator,
 		oplog:        oplog,
 		logStore:     logStore,
+		replicas:     replica.NewStore(path.Join(config.DataDir(), "replicas")),
+		backend:      newBackendLimiter(),
 	}
 
 	return s
@@ -56,7 +70,15 @@ func (s *BackrestHandler) GetConfig(ctx context.Context, req *connect.Request[em
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config: %w", err)
 	}
-	return connect.NewResponse(config), nil
+	return connect.NewResponse(configForCaller(ctx, config)), nil
+}
+
+// configForCaller returns the part of the config visible to the caller, see config.ForNamespace.
+func configForCaller(ctx context.Context, cfg *v1.Config) *v1.Config {
+	if ns := namespaceFromContext(ctx); ns != "" {
+		return config.ForNamespace(cfg, ns)
+	}
+	return cfg
 }
 
 // SetConfig implements POST /v1/config
@@ -71,13 +93,22 @@ func (s *BackrestHandler) SetConfig(ctx context.Context, req *connect.Request[v1
 		return nil, errors.New("config modno mismatch, reload and try again")
 	}
 
-	if err := config.ValidateConfig(req.Msg); err != nil {
+	// users in a namespace edit only their namespace's part of the config.
+	updated := req.Msg
+	if ns := namespaceFromContext(ctx); ns != "" {
+		updated, err = config.MergeNamespace(existing, req.Msg, ns)
+		if err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+	}
+
+	if err := config.ValidateConfig(updated); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
-	req.Msg.Modno += 1
+	updated.Modno += 1
 
-	if err := s.config.Update(req.Msg); err != nil {
+	if err := s.config.Update(updated); err != nil {
 		return nil, fmt.Errorf("failed to update config: %w", err)
 	}
 
@@ -88,7 +119,7 @@ func (s *BackrestHandler) SetConfig(ctx context.Context, req *connect.Request[v1
 	if err := s.orchestrator.ApplyConfig(newConfig); err != nil {
 		return nil, fmt.Errorf("failed to apply config: %w", err)
 	}
-	return connect.NewResponse(newConfig), nil
+	return connect.NewResponse(configForCaller(ctx, newConfig)), nil
 }
 
 // AddRepo implements POST /v1/config/repo, it includes validation that the repo can be initialized.
@@ -99,6 +130,15 @@ func (s *BackrestHandler) AddRepo(ctx context.Context, req *connect.Request[v1.R
 	}
 
 	c = proto.Clone(c).(*v1.Config)
+	if ns := namespaceFromContext(ctx); ns != "" {
+		if req.Msg.Namespace != "" && req.Msg.Namespace != ns {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("cannot add repo to namespace %q", req.Msg.Namespace))
+		}
+		req.Msg.Namespace = ns
+		if err := config.CheckNamespaceRepo(config.FindNamespace(c, ns), req.Msg, nil); err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+	}
 	c.Repos = append(c.Repos, req.Msg)
 
 	if err := config.ValidateConfig(c); err != nil {
@@ -116,7 +156,9 @@ func (s *BackrestHandler) AddRepo(ctx context.Context, req *connect.Request[v1.R
 	}
 
 	// use background context such that the init op can try to complete even if the connection is closed.
-	if err := r.Init(context.Background()); err != nil {
+	initStart := time.Now()
+	initOutput := bytes.NewBuffer(nil)
+	if err := r.Init(context.Background(), initOutput); err != nil {
 		return nil, fmt.Errorf("failed to init repo: %w", err)
 	}
 
@@ -128,17 +170,144 @@ func (s *BackrestHandler) AddRepo(ctx context.Context, req *connect.Request[v1.R
 	zap.L().Debug("applying config", zap.Int32("version", c.Version))
 	s.orchestrator.ApplyConfig(c)
 
+	// the init ran before the repo was in the config, it is recorded once the repo's operations can be shown.
+	if err := s.oplog.Add(&v1.Operation{
+		InstanceId:      c.Instance,
+		RepoId:          req.Msg.Id,
+		PlanId:          tasks.PlanForUnassociatedOperations,
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		UnixTimeStartMs: initStart.UnixMilli(),
+		UnixTimeEndMs:   time.Now().UnixMilli(),
+		Op: &v1.Operation_OperationInit{
+			OperationInit: &v1.OperationInit{Output: initOutput.String()},
+		},
+	}); err != nil {
+		zap.S().Errorf("add init operation for repo %q: %v", req.Msg.Id, err)
+	}
+
 	// index snapshots for the newly added repository.
 	zap.L().Debug("scheduling index snapshots task")
 	s.orchestrator.ScheduleTask(tasks.NewOneoffIndexSnapshotsTask(req.Msg.Id, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityIndexSnapshots)
 
 	zap.L().Debug("done add repo")
-	return connect.NewResponse(c), nil
+	return connect.NewResponse(configForCaller(ctx, c)), nil
+}
+
+// SetPaused implements POST /v1.Backrest/SetPaused, the pause state is stored in the config so that it survives restarts.
+func (s *BackrestHandler) SetPaused(ctx context.Context, req *connect.Request[v1.SetPausedRequest]) (*connect.Response[v1.Config], error) {
+	if (req.Msg.PlanId == "") == (req.Msg.RepoId == "") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("exactly one of planId and repoId must be set"))
+	}
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
+	} else if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+
+	c, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	c = proto.Clone(c).(*v1.Config)
+
+	var state *v1.PauseState
+	if req.Msg.Paused {
+		state = &v1.PauseState{
+			Reason:     req.Msg.Reason,
+			UnixTimeMs: time.Now().UnixMilli(),
+		}
+		if user, ok := ctx.Value(auth.UserContextKey).(*v1.User); ok {
+			state.PausedBy = user.Name
+		}
+	}
+
+	if req.Msg.PlanId != "" {
+		idx := slices.IndexFunc(c.Plans, func(p *v1.Plan) bool { return p.Id == req.Msg.PlanId })
+		if idx == -1 {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plan %q not found", req.Msg.PlanId))
+		}
+		c.Plans[idx].Paused = state
+	} else {
+		idx := slices.IndexFunc(c.Repos, func(r *v1.Repo) bool { return r.Id == req.Msg.RepoId })
+		if idx == -1 {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q not found", req.Msg.RepoId))
+		}
+		c.Repos[idx].Paused = state
+	}
+	c.Modno += 1
+
+	if err := s.config.Update(c); err != nil {
+		return nil, fmt.Errorf("failed to update config: %w", err)
+	}
+	if err := s.orchestrator.ApplyConfig(c); err != nil {
+		return nil, fmt.Errorf("failed to apply config: %w", err)
+	}
+
+	zap.L().Info("updated pause state", zap.String("plan", req.Msg.PlanId), zap.String("repo", req.Msg.RepoId), zap.Bool("paused", req.Msg.Paused), zap.String("reason", req.Msg.Reason))
+	return connect.NewResponse(configForCaller(ctx, c)), nil
+}
+
+// SetBandwidthLimit implements POST /v1.Backrest/SetBandwidthLimit
+func (s *BackrestHandler) SetBandwidthLimit(ctx context.Context, req *connect.Request[v1.SetBandwidthLimitRequest]) (*connect.Response[v1.Config], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+
+	c, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	c = proto.Clone(c).(*v1.Config)
+
+	idx := slices.IndexFunc(c.Repos, func(r *v1.Repo) bool { return r.Id == req.Msg.RepoId })
+	if idx == -1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q not found", req.Msg.RepoId))
+	}
+	c.Repos[idx].LimitUploadKbps = req.Msg.LimitUploadKbps
+	c.Repos[idx].LimitDownloadKbps = req.Msg.LimitDownloadKbps
+	c.Modno += 1
+
+	if err := config.ValidateConfig(c); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := s.config.Update(c); err != nil {
+		return nil, fmt.Errorf("failed to update config: %w", err)
+	}
+	if err := s.orchestrator.ApplyConfig(c); err != nil {
+		return nil, fmt.Errorf("failed to apply config: %w", err)
+	}
+	// restic reads the limits when a backup starts, running backups are restarted to pick them up.
+	if err := s.orchestrator.RestartBackups(req.Msg.RepoId); err != nil {
+		return nil, fmt.Errorf("failed to restart backups: %w", err)
+	}
+
+	zap.L().Info("updated bandwidth limits", zap.String("repo", req.Msg.RepoId), zap.Int32("upload_kbps", req.Msg.LimitUploadKbps), zap.Int32("download_kbps", req.Msg.LimitDownloadKbps))
+	return connect.NewResponse(configForCaller(ctx, c)), nil
+}
+
+// PutReplica implements POST /v1.Backrest/PutReplica, replicas are stored as pushed, this instance cannot decrypt them.
+func (s *BackrestHandler) PutReplica(ctx context.Context, req *connect.Request[v1.SealedReplica]) (*connect.Response[emptypb.Empty], error) {
+	if namespaceFromContext(ctx) != "" {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("replicas can only be stored by users outside of any namespace"))
+	}
+	if len(req.Msg.Ciphertext) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("replica is empty"))
+	}
+	if err := s.replicas.Put(req.Msg); err != nil {
+		return nil, fmt.Errorf("failed to store replica: %w", err)
+	}
+	zap.L().Info("stored config replica", zap.String("instance", req.Msg.Instance), zap.Time("created", time.UnixMilli(req.Msg.UnixTimeMs)))
+	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
 // ListSnapshots implements POST /v1/snapshots
 func (s *BackrestHandler) ListSnapshots(ctx context.Context, req *connect.Request[v1.ListSnapshotsRequest]) (*connect.Response[v1.ResticSnapshotList], error) {
 	query := req.Msg
+	if err := s.checkRepoAccess(ctx, query.RepoId); err != nil {
+		return nil, err
+	}
 	repo, err := s.orchestrator.GetRepoOrchestrator(query.RepoId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repo: %w", err)
@@ -151,9 +320,14 @@ func (s *BackrestHandler) ListSnapshots(ctx context.Context, req *connect.Reques
 		if err != nil {
 			return nil, fmt.Errorf("failed to get plan %q: %w", query.PlanId, err)
 		}
-		snapshots, err = repo.SnapshotsForPlan(ctx, plan)
+		if err := s.checkPlanAccess(ctx, query.PlanId); err != nil {
+			return nil, err
+		}
+		snapshots, err = limitBackend(ctx, s.backend, query.RepoId, "snapshots\x00"+query.PlanId, func(ctx context.Context) ([]*restic.Snapshot, error) {
+			return repo.SnapshotsForPlan(ctx, plan)
+		})
 	} else {
-		snapshots, err = repo.Snapshots(ctx)
+		snapshots, err = limitBackend(ctx, s.backend, query.RepoId, "snapshots", repo.Snapshots)
 	}
 
 	if err != nil {
@@ -163,6 +337,9 @@ func (s *BackrestHandler) ListSnapshots(ctx context.Context, req *connect.Reques
 	// Transform the snapshots and return them.
 	var rs []*v1.ResticSnapshot
 	for _, snapshot := range snapshots {
+		if !hasAllTags(snapshot.Tags, query.Tags) {
+			continue
+		}
 		rs = append(rs, protoutil.SnapshotToProto(snapshot))
 	}
 
@@ -173,12 +350,17 @@ func (s *BackrestHandler) ListSnapshots(ctx context.Context, req *connect.Reques
 
 func (s *BackrestHandler) ListSnapshotFiles(ctx context.Context, req *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[v1.ListSnapshotFilesResponse], error) {
 	query := req.Msg
+	if err := s.checkRepoAccess(ctx, query.RepoId); err != nil {
+		return nil, err
+	}
 	repo, err := s.orchestrator.GetRepoOrchestrator(query.RepoId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repo: %w", err)
 	}
 
-	entries, err := repo.ListSnapshotFiles(ctx, query.SnapshotId, query.Path)
+	entries, err := limitBackend(ctx, s.backend, query.RepoId, "ls\x00"+query.SnapshotId+"\x00"+query.Path, func(ctx context.Context) ([]*v1.LsEntry, error) {
+		return repo.ListSnapshotFiles(ctx, query.SnapshotId, query.Path)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list snapshot files: %w", err)
 	}
@@ -189,8 +371,107 @@ func (s *BackrestHandler) ListSnapshotFiles(ctx context.Context, req *connect.Re
 	}), nil
 }
 
+func (s *BackrestHandler) DiffSnapshots(ctx context.Context, req *connect.Request[v1.DiffSnapshotsRequest]) (*connect.Response[v1.SnapshotDiff], error) {
+	query := req.Msg
+	if query.SnapshotId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("snapshot ID is required"))
+	}
+	if err := s.checkRepoAccess(ctx, query.RepoId); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(query.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	base := query.BaseSnapshotId
+	if base == "" {
+		snapshots, err := limitBackend(ctx, s.backend, query.RepoId, "snapshots", repo.Snapshots)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		idx := slices.IndexFunc(snapshots, func(s *restic.Snapshot) bool {
+			return strings.HasPrefix(s.Id, query.SnapshotId)
+		})
+		if idx == -1 {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("snapshot %q not found", query.SnapshotId))
+		}
+		if base = snapshots[idx].Parent; base == "" {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("snapshot %q has no parent to diff against, a base snapshot is required", query.SnapshotId))
+		}
+	}
+
+	diff, err := limitBackend(ctx, s.backend, query.RepoId, "diff\x00"+base+"\x00"+query.SnapshotId, func(ctx context.Context) (*v1.SnapshotDiff, error) {
+		return repo.Diff(ctx, base, query.SnapshotId)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff snapshots: %w", err)
+	}
+	return connect.NewResponse(diff), nil
+}
+
+func (s *BackrestHandler) GetSnapshotStats(ctx context.Context, req *connect.Request[v1.GetSnapshotStatsRequest]) (*connect.Response[v1.SnapshotStats], error) {
+	query := req.Msg
+	if query.SnapshotId == "" {
+		return nil, errors.New("snapshot ID is required")
+	}
+	if err := s.checkRepoAccess(ctx, query.RepoId); err != nil {
+		return nil, err
+	}
+
+	// snapshots are immutable so stats computed once are valid forever.
+	if stats, err := s.oplog.GetSnapshotStats(query.SnapshotId); err == nil {
+		return connect.NewResponse(stats), nil
+	} else if !errors.Is(err, oplog.ErrNotExist) {
+		return nil, fmt.Errorf("failed to get cached snapshot stats: %w", err)
+	}
+
+	repo, err := s.orchestrator.GetRepoOrchestrator(query.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	stats, err := limitBackend(ctx, s.backend, query.RepoId, "stats\x00"+query.SnapshotId, func(ctx context.Context) (*v1.SnapshotStats, error) {
+		stats, err := repo.SnapshotStats(ctx, query.SnapshotId)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.oplog.PutSnapshotStats(query.SnapshotId, stats); err != nil {
+			zap.S().Warnf("failed to cache stats for snapshot %v: %v", query.SnapshotId, err)
+		}
+		return stats, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot stats: %w", err)
+	}
+
+	return connect.NewResponse(stats), nil
+}
+
 // GetOperationEvents implements GET /v1/events/operations
 func (s *BackrestHandler) GetOperationEvents(ctx context.Context, req *connect.Request[emptypb.Empty], resp *connect.ServerStream[v1.OperationEvent]) error {
+	return s.streamOperationEvents(ctx, &v1.SubscribeOperationsRequest{}, resp)
+}
+
+// SubscribeOperations implements POST /v1.Backrest/SubscribeOperations
+func (s *BackrestHandler) SubscribeOperations(ctx context.Context, req *connect.Request[v1.SubscribeOperationsRequest], resp *connect.ServerStream[v1.OperationEvent]) error {
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return err
+		}
+	}
+	if req.Msg.RepoId != "" {
+		if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+			return err
+		}
+	}
+	return s.streamOperationEvents(ctx, req.Msg, resp)
+}
+
+// streamOperationEvents sends the events of the operations matching the request to the stream until the client
+// disconnects or falls behind.
+func (s *BackrestHandler) streamOperationEvents(ctx context.Context, req *v1.SubscribeOperationsRequest, resp *connect.ServerStream[v1.OperationEvent]) error {
+	ns := namespaceFromContext(ctx)
 
 	errChan := make(chan error, 1)
 	events := make(chan *v1.OperationEvent, 100)
@@ -217,15 +498,35 @@ func (s *BackrestHandler) GetOperationEvents(ctx context.Context, req *connect.R
 			return
 		}
 
+		if (req.PlanId != "" && event.Operation.PlanId != req.PlanId) || (req.RepoId != "" && event.Operation.RepoId != req.RepoId) {
+			return
+		}
+		if ns != "" {
+			// resolved per event as repos may be added to the namespace while the stream is open.
+			access, err := s.namespaceAccess(ctx)
+			if err != nil || !access.canAccessOperation(event.Operation) {
+				return
+			}
+		}
+
 		select {
 		case events <- event:
 		default:
-			errChan <- errors.New("event buffer overflow, closing stream for client retry and catchup")
+			select {
+			case errChan <- errors.New("event buffer overflow, closing stream for client retry and catchup"):
+			default: // the stream is already closing.
+			}
 		}
 	}
 	s.oplog.Subscribe(&callback)
 	defer s.oplog.Unsubscribe(&callback)
 
+	// the headers are sent right away, clients opening the stream wait for them before fetching the operations they
+	// may have missed.
+	if err := resp.Send(nil); err != nil {
+		return fmt.Errorf("failed to send headers: %w", err)
+	}
+
 	for {
 		select {
 		case err := <-errChan:
@@ -241,13 +542,20 @@ func (s *BackrestHandler) GetOperationEvents(ctx context.Context, req *connect.R
 }
 
 func (s *BackrestHandler) GetOperations(ctx context.Context, req *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newOperationFilter(req.Msg)
+	if err != nil {
+		return nil, err
+	}
 	idCollector := indexutil.CollectAll()
 
 	if req.Msg.LastN != 0 {
 		idCollector = indexutil.CollectLastN(int(req.Msg.LastN))
 	}
 
-	var err error
 	var ops []*v1.Operation
 	opCollector := func(op *v1.Operation) error {
 		ops = append(ops, op)
@@ -256,13 +564,21 @@ func (s *BackrestHandler) GetOperations(ctx context.Context, req *connect.Reques
 	if req.Msg.RepoId != "" && req.Msg.PlanId != "" {
 		return nil, errors.New("cannot specify both repoId and planId")
 	} else if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
 		err = s.oplog.ForEachByPlan(req.Msg.PlanId, idCollector, opCollector)
 	} else if req.Msg.RepoId != "" {
+		if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+			return nil, err
+		}
 		err = s.oplog.ForEachByRepo(req.Msg.RepoId, idCollector, opCollector)
 	} else if req.Msg.SnapshotId != "" {
 		err = s.oplog.ForEachBySnapshotId(req.Msg.SnapshotId, idCollector, opCollector)
 	} else if req.Msg.FlowId != 0 {
 		err = s.oplog.ForEachByFlowId(req.Msg.FlowId, idCollector, opCollector)
+	} else if req.Msg.RepoGroupId != "" {
+		ops, err = s.getOperationsForRepoGroup(access, req.Msg.RepoGroupId, idCollector, int(req.Msg.LastN))
 	} else if len(req.Msg.Ids) > 0 {
 		ops = make([]*v1.Operation, 0, len(req.Msg.Ids))
 		for i, id := range req.Msg.Ids {
@@ -270,21 +586,72 @@ func (s *BackrestHandler) GetOperations(ctx context.Context, req *connect.Reques
 			if err != nil {
 				return nil, fmt.Errorf("failed to get operation %d: %w", i, err)
 			}
+			if err := s.checkOperationAccess(ctx, op); err != nil {
+				return nil, err
+			}
 			ops = append(ops, op)
 		}
+	} else if filter.startAfterMs != 0 || filter.startBeforeMs != 0 {
+		err = s.oplog.ForEachByStartTime(filter.startAfterMs, filter.startBeforeMs, idCollector, opCollector)
+	} else if len(filter.statuses) == 1 {
+		err = s.oplog.ForEachByStatus(filter.statuses[0], idCollector, opCollector)
 	} else {
 		err = s.oplog.ForAll(opCollector)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get operations: %w", err)
 	}
+	ops = slices.DeleteFunc(ops, func(op *v1.Operation) bool {
+		return !access.canAccessOperation(op) || !filter.matches(op)
+	})
+	ops, nextPageToken, err := pageOperations(req.Msg, ops)
+	if err != nil {
+		return nil, err
+	}
 
 	return connect.NewResponse(&v1.OperationList{
-		Operations: ops,
+		Operations:    ops,
+		NextPageToken: nextPageToken,
 	}), nil
 }
 
+// getOperationsForRepoGroup returns the operations for every repo in the group that the caller may access ordered by
+// operation ID.
+func (s *BackrestHandler) getOperationsForRepoGroup(access *namespaceAccess, groupID string, idCollector indexutil.Collector, lastN int) ([]*v1.Operation, error) {
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	if config.FindRepoGroup(cfg, groupID) == nil {
+		return nil, fmt.Errorf("repo group %q not found", groupID)
+	}
+
+	var ops []*v1.Operation
+	for _, repoID := range config.ReposInGroup(cfg, groupID) {
+		if !access.canAccessRepo(repoID) {
+			continue
+		}
+		if err := s.oplog.ForEachByRepo(repoID, idCollector, func(op *v1.Operation) error {
+			ops = append(ops, op)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(ops, func(a, b *v1.Operation) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	if lastN > 0 && len(ops) > lastN {
+		ops = ops[len(ops)-lastN:]
+	}
+	return ops, nil
+}
+
 func (s *BackrestHandler) IndexSnapshots(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
 	_, err := s.orchestrator.GetRepo(req.Msg.Value)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
@@ -296,12 +663,32 @@ func (s *BackrestHandler) IndexSnapshots(ctx context.Context, req *connect.Reque
 }
 
 func (s *BackrestHandler) Backup(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
-	plan, err := s.orchestrator.GetPlan(req.Msg.Value)
+	return s.BackupWithAnnotations(ctx, connect.NewRequest(&v1.BackupRequest{PlanId: req.Msg.Value}))
+}
+
+// BackupWithAnnotations implements POST /v1.Backrest/BackupWithAnnotations
+func (s *BackrestHandler) BackupWithAnnotations(ctx context.Context, req *connect.Request[v1.BackupRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+		return nil, err
+	}
+	plan, err := s.orchestrator.GetPlan(req.Msg.PlanId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get plan %q: %w", req.Msg.Value, err)
+		return nil, fmt.Errorf("failed to get plan %q: %w", req.Msg.PlanId, err)
+	}
+	for _, a := range req.Msg.Annotations {
+		if err := repo.ValidateAnnotation(a); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 	}
+	annotations, err := repo.MergeAnnotations(nil, req.Msg.Annotations)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	task := tasks.NewOneoffBackupTask(plan, time.Now())
+	task.Annotations = annotations
 	wait := make(chan struct{})
-	s.orchestrator.ScheduleTask(tasks.NewOneoffBackupTask(plan, time.Now()), tasks.TaskPriorityInteractive, func(e error) {
+	s.orchestrator.ScheduleTask(task, tasks.TaskPriorityInteractive, func(e error) {
 		err = e
 		close(wait)
 	})
@@ -309,97 +696,420 @@ func (s *BackrestHandler) Backup(ctx context.Context, req *connect.Request[types
 	return connect.NewResponse(&emptypb.Empty{}), err
 }
 
-func (s *BackrestHandler) Forget(ctx context.Context, req *connect.Request[v1.ForgetRequest]) (*connect.Response[emptypb.Empty], error) {
+// PreviewBackup implements POST /v1.Backrest/PreviewBackup
+func (s *BackrestHandler) PreviewBackup(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.BackupPreview], error) {
+	if err := s.checkPlanAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	plan, err := s.orchestrator.GetPlan(req.Msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan %q: %w", req.Msg.Value, err)
+	}
+	if plan.CommandSource != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("backups of a command's output can't be previewed without running the command"))
+	}
+	if err := s.checkRepoAccess(ctx, plan.Repo); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(plan.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	release, err := s.backend.acquire(plan.Repo)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// the plan's pre backup command isn't run, a preview of a plan whose paths it prepares may fail or be inaccurate.
+	opts := []restic.GenericOption{restic.WithFlags("--dry-run")}
+	if plan.FilesFrom != nil {
+		filesFrom, err := tasks.WriteFilesFrom(ctx, plan, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write files from list: %w", err)
+		}
+		defer os.Remove(filesFrom)
+		opts = append(opts, restic.WithFlags("--files-from-verbatim", filesFrom))
+	}
+
+	summary, err := repo.Backup(ctx, plan, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview backup: %w", err)
+	}
+	return connect.NewResponse(&v1.BackupPreview{
+		PlanId:          plan.Id,
+		RepoId:          plan.Repo,
+		FilesNew:        summary.FilesNew,
+		FilesChanged:    summary.FilesChanged,
+		FilesUnmodified: summary.FilesUnmodified,
+		BytesAdded:      summary.DataAdded,
+		TotalFiles:      summary.TotalFilesProcessed,
+		TotalBytes:      summary.TotalBytesProcessed,
+		DurationMs:      int64(summary.TotalDuration * 1000),
+	}), nil
+}
+
+func (s *BackrestHandler) Forget(ctx context.Context, req *connect.Request[v1.ForgetRequest]) (*connect.Response[types.Int64Value], error) {
 	at := time.Now()
 	_, err := s.orchestrator.GetPlan(req.Msg.PlanId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get plan %q: %w", req.Msg.PlanId, err)
 	}
+	if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+		return nil, err
+	}
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
 
+	var task tasks.Task
 	if req.Msg.SnapshotId != "" && req.Msg.PlanId != "" && req.Msg.RepoId != "" {
-		wait := make(chan struct{})
-		s.orchestrator.ScheduleTask(
-			tasks.NewOneoffForgetSnapshotTask(req.Msg.RepoId, req.Msg.PlanId, 0, at, req.Msg.SnapshotId),
-			tasks.TaskPriorityInteractive+tasks.TaskPriorityForget, func(e error) {
-				err = e
-				close(wait)
-			})
-		<-wait
+		task = tasks.NewOneoffForgetSnapshotTask(req.Msg.RepoId, req.Msg.PlanId, 0, at, req.Msg.SnapshotId)
 	} else if req.Msg.RepoId != "" && req.Msg.PlanId != "" {
-		wait := make(chan struct{})
-		s.orchestrator.ScheduleTask(
-			tasks.NewOneoffForgetTask(req.Msg.RepoId, req.Msg.PlanId, 0, at),
-			tasks.TaskPriorityInteractive+tasks.TaskPriorityForget, func(e error) {
-				err = e
-				close(wait)
-			})
-		<-wait
+		task = tasks.NewOneoffForgetTask(req.Msg.RepoId, req.Msg.PlanId, 0, at)
 	} else {
 		return nil, errors.New("must specify repoId and planId and (optionally) snapshotId")
 	}
 
+	opID, err := s.orchestrator.ScheduleOneoffTask(task, tasks.TaskPriorityInteractive+tasks.TaskPriorityForget)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to schedule forget: %w", err)
 	}
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
 }
 
-func (s *BackrestHandler) Prune(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+func (s *BackrestHandler) Prune(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	if err := s.checkPlanAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
 	plan, err := s.orchestrator.GetPlan(req.Msg.Value)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get plan %q: %w", req.Msg.Value, err)
 	}
 
-	at := time.Now()
-	wait := make(chan struct{})
-	s.orchestrator.ScheduleTask(tasks.NewOneoffPruneTask(plan.Repo, plan.Id, 0, at, true), tasks.TaskPriorityInteractive+tasks.TaskPriorityPrune, func(e error) {
-		err = e
-		close(wait)
-	})
-	<-wait
-
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffPruneTask(plan.Repo, plan.Id, 0, time.Now(), true), tasks.TaskPriorityInteractive+tasks.TaskPriorityPrune)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule prune: %w", err)
+	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
 }
 
-func (s *BackrestHandler) Restore(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
-	if req.Msg.Target == "" {
-		req.Msg.Target = path.Join(os.Getenv("HOME"), "Downloads")
+func (s *BackrestHandler) Check(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
 	}
-	if req.Msg.Path == "" {
-		req.Msg.Path = "/"
+	_, err := s.orchestrator.GetRepo(req.Msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
 	}
 
-	target := path.Join(req.Msg.Target, fmt.Sprintf("restic-restore-%v", time.Now().Format("2006-01-02T15-04-05")))
-	_, err := os.Stat(target)
-	if !errors.Is(err, os.ErrNotExist) {
-		return nil, fmt.Errorf("restore target dir %q already exists", req.Msg.Target)
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffCheckTask(req.Msg.Value, tasks.PlanForUnassociatedOperations, 0, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule check: %w", err)
 	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
+}
 
-	at := time.Now()
-
-	flowID, err := tasks.FlowIDForSnapshotID(s.oplog, req.Msg.SnapshotId)
+func (s *BackrestHandler) InitRepo(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	_, err := s.orchestrator.GetRepo(req.Msg.Value)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get flow ID for snapshot %q: %w", req.Msg.SnapshotId, err)
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
 	}
-	s.orchestrator.ScheduleTask(tasks.NewOneoffRestoreTask(req.Msg.RepoId, req.Msg.PlanId, flowID, at, req.Msg.SnapshotId, req.Msg.Path, target), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
 
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffInitTask(req.Msg.Value, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule init: %w", err)
+	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
 }
 
-func (s *BackrestHandler) Unlock(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
-	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.Value)
+func (s *BackrestHandler) MigrateRepo(ctx context.Context, req *connect.Request[v1.MigrateRepoRequest]) (*connect.Response[types.Int64Value], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	_, err := s.orchestrator.GetRepo(req.Msg.RepoId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+	migration := req.Msg.Migration
+	if migration == "" {
+		migration = tasks.DefaultMigration
 	}
 
-	if err := repo.Unlock(context.Background()); err != nil {
-		return nil, fmt.Errorf("failed to unlock repo %q: %w", req.Msg.Value, err)
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffMigrateTask(req.Msg.RepoId, migration, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule migrate: %w", err)
+	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
+}
+
+// RecoverRepo schedules a restic recover of the repo, the data of forgotten snapshots that wasn't pruned yet is saved
+// into a new snapshot.
+func (s *BackrestHandler) RecoverRepo(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.Int64Value], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	_, err := s.orchestrator.GetRepo(req.Msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
+	}
+
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffRecoverTask(req.Msg.Value, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule recover: %w", err)
+	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
+}
+
+// TagSnapshots implements POST /v1.Backrest/TagSnapshots
+func (s *BackrestHandler) TagSnapshots(ctx context.Context, req *connect.Request[v1.TagSnapshotsRequest]) (*connect.Response[types.Int64Value], error) {
+	if len(req.Msg.SnapshotIds) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one snapshot ID is required"))
+	}
+	for _, id := range req.Msg.SnapshotIds {
+		if !snapshotIDRegex.MatchString(id) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid snapshot ID %q", id))
+		}
+	}
+	if len(req.Msg.Add) == 0 && len(req.Msg.Remove) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("no tags to add or remove"))
+	}
+	for _, tag := range append(slices.Clone(req.Msg.Add), req.Msg.Remove...) {
+		if err := validationutil.ValidateTag(tag); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("tag %q invalid: %w", tag, err))
+		}
+	}
+
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	planID := tasks.PlanForUnassociatedOperations
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
+		planID = req.Msg.PlanId
+	}
+	if _, err := s.orchestrator.GetRepo(req.Msg.RepoId); err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffTagTask(req.Msg.RepoId, planID, req.Msg.SnapshotIds, req.Msg.Add, req.Msg.Remove, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule tag: %w", err)
+	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
+}
+
+// PreviewRewriteSnapshots implements POST /v1.Backrest/PreviewRewriteSnapshots
+func (s *BackrestHandler) PreviewRewriteSnapshots(ctx context.Context, req *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[v1.RewritePreview], error) {
+	preview, err := s.rewritePreview(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(preview), nil
+}
+
+// RewriteSnapshots implements POST /v1.Backrest/RewriteSnapshots
+func (s *BackrestHandler) RewriteSnapshots(ctx context.Context, req *connect.Request[v1.RewriteSnapshotsRequest]) (*connect.Response[types.Int64Value], error) {
+	preview, err := s.rewritePreview(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.ConfirmDigest != preview.Digest {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("the snapshots the rewrite would change differ from the confirmed preview, %d snapshots would be rewritten", preview.SnapshotsRewritten))
+	}
+
+	planID := tasks.PlanForUnassociatedOperations
+	if req.Msg.PlanId != "" {
+		planID = req.Msg.PlanId
+	}
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffRewriteTask(req.Msg.RepoId, planID, req.Msg.SnapshotIds, req.Msg.Excludes, req.Msg.Forget, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule rewrite: %w", err)
+	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
+}
+
+// rewritePreview validates the rewrite request and runs it as a dry run.
+func (s *BackrestHandler) rewritePreview(ctx context.Context, req *v1.RewriteSnapshotsRequest) (*v1.RewritePreview, error) {
+	if len(req.SnapshotIds) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one snapshot ID is required"))
+	}
+	for _, id := range req.SnapshotIds {
+		if !snapshotIDRegex.MatchString(id) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid snapshot ID %q", id))
+		}
+	}
+	if len(req.Excludes) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one exclude pattern is required"))
+	}
+	for _, exclude := range req.Excludes {
+		if strings.TrimSpace(exclude) == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("exclude patterns must not be empty"))
+		}
+	}
+
+	if err := s.checkRepoAccess(ctx, req.RepoId); err != nil {
+		return nil, err
+	}
+	if req.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.PlanId); err != nil {
+			return nil, err
+		}
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.RepoId, err)
+	}
+	release, err := s.backend.acquire(req.RepoId)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	preview, err := repo.PreviewRewrite(ctx, req.SnapshotIds, req.Excludes, req.Forget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview rewrite: %w", err)
+	}
+	return preview, nil
+}
+
+func (s *BackrestHandler) Restore(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkStagingQuota(ctx); err != nil {
+		return nil, err
+	}
+	if req.Msg.Path == "" {
+		req.Msg.Path = "/"
+	}
+
+	if req.Msg.InPlace {
+		return s.restoreInPlace(ctx, req.Msg)
+	} else if req.Msg.Swap {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("only in place restores can be swapped in"))
+	}
+
+	target, err := restoreTarget(ctx, req.Msg, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat(target)
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("restore target dir %q already exists", req.Msg.Target)
+	}
+
+	at := time.Now()
+
+	flowID, err := tasks.FlowIDForSnapshotID(s.oplog, req.Msg.SnapshotId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow ID for snapshot %q: %w", req.Msg.SnapshotId, err)
+	}
+	s.orchestrator.ScheduleTask(tasks.NewOneoffRestoreTask(req.Msg.RepoId, req.Msg.PlanId, flowID, at, req.Msg.SnapshotId, req.Msg.Path, target), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// restoreTarget returns the new directory a restore that isn't in place started at now writes to. Users in a namespace
+// always restore to its staging dir.
+func restoreTarget(ctx context.Context, req *v1.RestoreSnapshotRequest, now time.Time) (string, error) {
+	if ns := namespaceFromContext(ctx); ns != "" {
+		// a subdirectory could be a symlink restored by an earlier restore, so only the staging dir itself is accepted.
+		if req.Target != "" && path.Clean(req.Target) != stagingDir(ns) {
+			return "", connect.NewError(connect.CodePermissionDenied, fmt.Errorf("restores of namespaced users are written to %q", stagingDir(ns)))
+		}
+		req.Target = stagingDir(ns)
+	} else if req.Target == "" {
+		req.Target = path.Join(os.Getenv("HOME"), "Downloads")
+	}
+	return path.Join(req.Target, fmt.Sprintf("restic-restore-%v", now.Format("2006-01-02T15-04-05"))), nil
+}
+
+// restoreInPlace schedules a restore to the original location once the caller has confirmed the current conflicts.
+func (s *BackrestHandler) restoreInPlace(ctx context.Context, req *v1.RestoreSnapshotRequest) (*connect.Response[emptypb.Empty], error) {
+	if req.Swap && req.Path == "/" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("the filesystem root can't be swapped in, restore a directory under it"))
+	}
+	report, err := s.restoreConflicts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.ConfirmConflictsDigest != report.Digest {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("the files the restore would overwrite or remove changed since they were confirmed, %d files would be overwritten of which %d are newer locally and %d removed", report.OverwrittenFiles, report.NewerLocalFiles, report.RemovedFiles))
+	}
+
+	flowID, err := tasks.FlowIDForSnapshotID(s.oplog, req.SnapshotId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow ID for snapshot %q: %w", req.SnapshotId, err)
+	}
+	s.orchestrator.ScheduleTask(tasks.NewOneoffInPlaceRestoreTask(req.RepoId, req.PlanId, flowID, time.Now(), req.SnapshotId, req.Path, req.Swap), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *BackrestHandler) GetRestoreConflicts(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreConflictReport], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if !req.Msg.InPlace {
+		// other restores are written to a new directory, there is nothing to overwrite.
+		return connect.NewResponse(&v1.RestoreConflictReport{}), nil
+	}
+	if req.Msg.Path == "" {
+		req.Msg.Path = "/"
+	}
+	report, err := s.restoreConflicts(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(report), nil
+}
+
+func (s *BackrestHandler) restoreConflicts(ctx context.Context, req *v1.RestoreSnapshotRequest) (*v1.RestoreConflictReport, error) {
+	if namespaceFromContext(ctx) != "" {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("in place restores are not available to namespaced users"))
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.RepoId, err)
+	}
+	report, err := repo.RestoreConflicts(ctx, req.SnapshotId, req.Path, "/", req.Swap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check restore conflicts: %w", err)
+	}
+	return report, nil
+}
+
+func (s *BackrestHandler) Unlock(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
+	}
+
+	if err := repo.Unlock(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to unlock repo %q: %w", req.Msg.Value, err)
 	}
 
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
 func (s *BackrestHandler) Stats(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
 	at := time.Now()
 	var err error
 	wait := make(chan struct{})
@@ -412,7 +1122,65 @@ func (s *BackrestHandler) Stats(ctx context.Context, req *connect.Request[types.
 
 }
 
+// GetRepoCacheUsage implements POST /v1.Backrest/GetRepoCacheUsage
+func (s *BackrestHandler) GetRepoCacheUsage(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.RepoCacheUsage], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
+	}
+	usage, err := repo.CacheUsage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache usage of repo %q: %w", req.Msg.Value, err)
+	}
+	return connect.NewResponse(usage), nil
+}
+
+func (s *BackrestHandler) GetRepoStatsHistory(ctx context.Context, req *connect.Request[v1.RepoStatsHistoryRequest]) (*connect.Response[v1.RepoStatsHistory], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = 365
+	}
+
+	var entries []*v1.RepoStatsHistory_Entry
+	if err := s.oplog.ForEachByRepo(req.Msg.RepoId, indexutil.Reversed(indexutil.CollectAll()), func(op *v1.Operation) error {
+		stats := op.GetOperationStats().GetStats()
+		if stats == nil || op.Status != v1.OperationStatus_STATUS_SUCCESS || op.UnixTimeStartMs < req.Msg.AfterMs {
+			return nil
+		}
+		entries = append(entries, &v1.RepoStatsHistory_Entry{
+			UnixTimeMs:  op.UnixTimeStartMs,
+			OperationId: op.Id,
+			Stats:       stats,
+		})
+		if len(entries) >= limit {
+			return oplog.ErrStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list stats of repo %q: %w", req.Msg.RepoId, err)
+	}
+	slices.SortFunc(entries, func(a, b *v1.RepoStatsHistory_Entry) int {
+		return cmp.Compare(a.UnixTimeMs, b.UnixTimeMs)
+	})
+	return connect.NewResponse(&v1.RepoStatsHistory{Entries: entries}), nil
+}
+
 func (s *BackrestHandler) Cancel(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error) {
+	if namespaceFromContext(ctx) != "" {
+		op, err := s.oplog.Get(req.Msg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operation %v: %w", req.Msg.Value, err)
+		}
+		if err := s.checkOperationAccess(ctx, op); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.orchestrator.CancelOperation(req.Msg.Value, v1.OperationStatus_STATUS_USER_CANCELLED); err != nil {
 		return nil, err
 	}
@@ -427,6 +1195,17 @@ func (s *BackrestHandler) ClearHistory(ctx context.Context, req *connect.Request
 	if len(req.Msg.Ops) != 0 {
 		ids = append(ids, req.Msg.Ops...)
 	}
+	if namespaceFromContext(ctx) != "" {
+		for _, id := range req.Msg.Ops {
+			op, err := s.oplog.Get(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get operation %v: %w", id, err)
+			}
+			if err := s.checkOperationAccess(ctx, op); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	opCollector := func(op *v1.Operation) error {
 		if !req.Msg.OnlyFailed || op.Status == v1.OperationStatus_STATUS_ERROR {
@@ -438,8 +1217,14 @@ func (s *BackrestHandler) ClearHistory(ctx context.Context, req *connect.Request
 	if req.Msg.RepoId != "" && req.Msg.PlanId != "" {
 		return nil, errors.New("cannot specify both repoId and planId")
 	} else if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
 		err = s.oplog.ForEachByPlan(req.Msg.PlanId, indexutil.CollectAll(), opCollector)
 	} else if req.Msg.RepoId != "" {
+		if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+			return nil, err
+		}
 		err = s.oplog.ForEachByRepo(req.Msg.RepoId, indexutil.CollectAll(), opCollector)
 	}
 
@@ -455,6 +1240,12 @@ func (s *BackrestHandler) ClearHistory(ctx context.Context, req *connect.Request
 }
 
 func (s *BackrestHandler) GetLogs(ctx context.Context, req *connect.Request[v1.LogDataRequest]) (*connect.Response[types.BytesValue], error) {
+	if err := s.checkLogAccess(ctx, req.Msg.GetRef()); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(req.Msg.GetRef(), orchestrator.CommandLogRefPrefix) {
+		return s.getCommandLog(req.Msg.GetRef())
+	}
 	data, err := s.logStore.Read(req.Msg.GetRef())
 	if err != nil {
 		if errors.Is(err, rotatinglog.ErrFileNotFound) {
@@ -468,27 +1259,209 @@ func (s *BackrestHandler) GetLogs(ctx context.Context, req *connect.Request[v1.L
 	return connect.NewResponse(&types.BytesValue{Value: data}), nil
 }
 
+// getCommandLog reads the full command log of an operation.
+func (s *BackrestHandler) getCommandLog(ref string) (*connect.Response[types.BytesValue], error) {
+	f, err := s.orchestrator.OpenCommandLog(ref)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return connect.NewResponse(&types.BytesValue{
+				Value: []byte(fmt.Sprintf("log %v not found, it may have been removed from the log history", ref)),
+			}), nil
+		}
+		return nil, fmt.Errorf("open command log %v: %w", ref, err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read command log %v: %w", ref, err)
+	}
+	return connect.NewResponse(&types.BytesValue{Value: data}), nil
+}
+
+// GetResticInfo implements GET /v1.Backrest/GetResticInfo
+func (s *BackrestHandler) GetResticInfo(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ResticInfo], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// versions are looked up once per binary, repos usually share the default binary.
+	binaries := make(map[string]*v1.ResticInfo_Binary)
+	describe := func(path string) *v1.ResticInfo_Binary {
+		if b, ok := binaries[path]; ok {
+			return b
+		}
+		b := &v1.ResticInfo_Binary{Path: path}
+		if version, err := restic.Version(ctx, path); err != nil {
+			b.Error = err.Error()
+		} else {
+			b.Version = version
+		}
+		binaries[path] = b
+		return b
+	}
+
+	info := &v1.ResticInfo{RequiredVersion: resticinstaller.RequiredResticVersion}
+	if bin, err := resticinstaller.FindOrInstallResticBinary(); err != nil {
+		info.DefaultBinary = &v1.ResticInfo_Binary{Error: err.Error()}
+	} else {
+		info.DefaultBinary = describe(bin)
+	}
+	for _, r := range s.orchestrator.Config().Repos {
+		if !access.canAccessRepo(r.Id) {
+			continue
+		}
+		repo, err := s.orchestrator.GetRepoOrchestrator(r.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repo %q: %w", r.Id, err)
+		}
+		info.Repos = append(info.Repos, &v1.ResticInfo_RepoBinary{
+			RepoId: r.Id,
+			Binary: describe(repo.ResticPath()),
+		})
+	}
+	return connect.NewResponse(info), nil
+}
+
 func (s *BackrestHandler) GetDownloadURL(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[types.StringValue], error) {
-	op, err := s.oplog.Get(req.Msg.Value)
+	return s.GetRestoreDownloadURL(ctx, connect.NewRequest(&v1.RestoreDownloadURLRequest{RestoreOpId: req.Msg.Value}))
+}
+
+// GetRestoreDownloadURL implements POST /v1.Backrest/GetRestoreDownloadURL
+func (s *BackrestHandler) GetRestoreDownloadURL(ctx context.Context, req *connect.Request[v1.RestoreDownloadURLRequest]) (*connect.Response[types.StringValue], error) {
+	op, err := s.oplog.Get(req.Msg.RestoreOpId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get operation %v: %w", req.Msg.Value, err)
+		return nil, fmt.Errorf("failed to get operation %v: %w", req.Msg.RestoreOpId, err)
+	}
+	if err := s.checkOperationAccess(ctx, op); err != nil {
+		return nil, err
 	}
-	_, ok := op.Op.(*v1.Operation_OperationRestore)
+	restoreOp, ok := op.Op.(*v1.Operation_OperationRestore)
 	if !ok {
-		return nil, fmt.Errorf("operation %v is not a restore operation", req.Msg.Value)
+		return nil, fmt.Errorf("operation %v is not a restore operation", req.Msg.RestoreOpId)
+	}
+	if restoreOp.OperationRestore.InPlace {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("operation %v restored files to their original location, there is nothing to download", req.Msg.RestoreOpId))
+	}
+	if p := strings.Trim(req.Msg.Path, "/"); p != "" && !filepath.IsLocal(filepath.FromSlash(p)) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path %q must be relative to the restore's target", req.Msg.Path))
+	}
+	dirPath := cleanRestoreDownloadPath(req.Msg.Path)
+	token, err := newDownloadToken(op.Id, time.Now(), s.downloadURLTTL(), req.Msg.SingleUse)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signRestoreForDownload(token, dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signature: %w", err)
+	}
+	return connect.NewResponse(&types.StringValue{
+		Value: fmt.Sprintf("./download/%s-%s/%s", token, hex.EncodeToString(signature), (&url.URL{Path: dirPath}).EscapedPath()),
+	}), nil
+}
+
+// downloadURLTTL returns how long the download URLs handed out stay valid for.
+func (s *BackrestHandler) downloadURLTTL() time.Duration {
+	if ttl := time.Duration(s.orchestrator.Config().GetDownloads().GetUrlTtlSeconds()) * time.Second; ttl > 0 {
+		return ttl
+	}
+	return defaultDownloadURLTTL
+}
+
+// GetSnapshotFileDownloadURL implements POST /v1.Backrest/GetSnapshotFileDownloadURL
+func (s *BackrestHandler) GetSnapshotFileDownloadURL(ctx context.Context, req *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error) {
+	query := req.Msg
+	if !snapshotIDRegex.MatchString(query.SnapshotId) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid snapshot ID %q", query.SnapshotId))
+	}
+	if !strings.HasPrefix(query.Path, "/") || path.Clean(query.Path) != query.Path {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path %q must be absolute and clean", query.Path))
+	}
+	if err := s.checkRepoAccess(ctx, query.RepoId); err != nil {
+		return nil, err
 	}
-	b := make([]byte, 8)
-	binary.BigEndian.PutUint64(b, uint64(op.Id))
-	signature, err := generateSignature(b)
+	repo, err := s.orchestrator.GetRepoOrchestrator(query.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	// restic dump writes directories as tar archives, only files are streamed as they are.
+	entries, err := repo.ListSnapshotFiles(ctx, query.SnapshotId, query.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshot files: %w", err)
+	}
+	idx := slices.IndexFunc(entries, func(e *v1.LsEntry) bool { return e.Path == query.Path })
+	if idx == -1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("path %q not found in snapshot %v", query.Path, query.SnapshotId))
+	}
+	if entries[idx].Type != "file" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path %q is a %v, only files can be downloaded directly", query.Path, entries[idx].Type))
+	}
+
+	token, err := newDownloadToken(0, time.Now(), s.downloadURLTTL(), false)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signSnapshotFileForDownload(token, query.RepoId, query.SnapshotId, query.Path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate signature: %w", err)
 	}
 	return connect.NewResponse(&types.StringValue{
-		Value: fmt.Sprintf("./download/%x-%s/", op.Id, hex.EncodeToString(signature)),
+		Value: fmt.Sprintf("./download/dump/%s-%s/%s/%s%s", token, hex.EncodeToString(signature), query.RepoId, query.SnapshotId, (&url.URL{Path: query.Path}).EscapedPath()),
+	}), nil
+}
+
+func (s *BackrestHandler) GetSnapshotArchiveURL(ctx context.Context, req *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error) {
+	query := req.Msg
+	if query.Path == "" {
+		query.Path = "/"
+	}
+	if !snapshotIDRegex.MatchString(query.SnapshotId) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid snapshot ID %q", query.SnapshotId))
+	}
+	if !strings.HasPrefix(query.Path, "/") || path.Clean(query.Path) != query.Path {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path %q must be absolute and clean", query.Path))
+	}
+	if err := s.checkRepoAccess(ctx, query.RepoId); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(query.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	// restic dump writes files as they are, only directories are streamed as archives.
+	if query.Path != "/" {
+		entries, err := repo.ListSnapshotFiles(ctx, query.SnapshotId, query.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshot files: %w", err)
+		}
+		idx := slices.IndexFunc(entries, func(e *v1.LsEntry) bool { return e.Path == query.Path })
+		if idx == -1 {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("path %q not found in snapshot %v", query.Path, query.SnapshotId))
+		}
+		if entries[idx].Type != "dir" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path %q is a %v, only directories can be downloaded as archives", query.Path, entries[idx].Type))
+		}
+	}
+
+	token, err := newDownloadToken(0, time.Now(), s.downloadURLTTL(), false)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signSnapshotArchiveForDownload(token, query.RepoId, query.SnapshotId, query.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signature: %w", err)
+	}
+	return connect.NewResponse(&types.StringValue{
+		Value: fmt.Sprintf("./download/archive/%s-%s/%s/%s%s", token, hex.EncodeToString(signature), query.RepoId, query.SnapshotId, (&url.URL{Path: query.Path}).EscapedPath()),
 	}), nil
 }
 
 func (s *BackrestHandler) PathAutocomplete(ctx context.Context, path *connect.Request[types.StringValue]) (*connect.Response[types.StringList], error) {
+	if err := s.checkHostPathAccess(ctx, path.Msg.Value); err != nil {
+		return nil, err
+	}
 	ents, err := os.ReadDir(path.Msg.Value)
 	if errors.Is(err, os.ErrNotExist) {
 		return connect.NewResponse(&types.StringList{}), nil
@@ -503,3 +1476,48 @@ func (s *BackrestHandler) PathAutocomplete(ctx context.Context, path *connect.Re
 
 	return connect.NewResponse(&types.StringList{Values: paths}), nil
 }
+
+func (s *BackrestHandler) DescribeCron(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[types.StringValue], error) {
+	desc, err := cronutil.Describe(req.Msg.Value)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&types.StringValue{Value: desc}), nil
+}
+
+// maxCronPreviewCount bounds the number of fire times returned by ValidateCron.
+const maxCronPreviewCount = 100
+
+func (s *BackrestHandler) ValidateCron(ctx context.Context, req *connect.Request[v1.ValidateCronRequest]) (*connect.Response[v1.ValidateCronResponse], error) {
+	count := int(req.Msg.Count)
+	if count <= 0 {
+		count = 5
+	} else if count > maxCronPreviewCount {
+		count = maxCronPreviewCount
+	}
+
+	desc, err := cronutil.Describe(req.Msg.Expr)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	sched, err := cronutil.ParseInLocation(req.Msg.Expr, time.Now().Location().String())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	res := &v1.ValidateCronResponse{Description: desc}
+	for _, t := range sched.NextN(time.Now(), count) {
+		res.NextUnixTimeMs = append(res.NextUnixTimeMs, t.UnixMilli())
+	}
+	return connect.NewResponse(res), nil
+}
+
+// hasAllTags returns true if tags holds every tag of want.
+func hasAllTags(tags []string, want []string) bool {
+	for _, tag := range want {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// archiveCompression is a codec that tar archives are compressed with for download.
+type archiveCompression struct {
+	name        string
+	contentType string
+	extension   string
+	minLevel    int
+	maxLevel    int
+}
+
+var (
+	compressionNone = archiveCompression{name: "none", contentType: "application/x-tar", extension: ".tar"}
+	compressionGzip = archiveCompression{name: "gzip", contentType: "application/gzip", extension: ".tar.gz", minLevel: gzip.BestSpeed, maxLevel: gzip.BestCompression}
+	compressionZstd = archiveCompression{name: "zstd", contentType: "application/zstd", extension: ".tar.zst", minLevel: 1, maxLevel: 22}
+
+	archiveCompressions = []archiveCompression{compressionNone, compressionGzip, compressionZstd}
+)
+
+// archiveCompressionFromRequest picks the codec for an archive download. It is chosen by the compression query parameter
+// e.g. "?compression=gzip&level=9", or else by the first of the request's Accept types that names a codec, or else the
+// default is used. A level of 0 uses the codec's default level.
+func archiveCompressionFromRequest(r *http.Request, def archiveCompression) (archiveCompression, int, error) {
+	query := r.URL.Query()
+	c := def
+	if name := query.Get("compression"); name != "" {
+		found := false
+		for _, candidate := range archiveCompressions {
+			if candidate.name == name {
+				c, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			return archiveCompression{}, 0, fmt.Errorf("unknown compression %q", name)
+		}
+	} else if accepted, ok := acceptedArchiveCompression(r.Header.Get("Accept")); ok {
+		c = accepted
+	}
+
+	var level int
+	if l := query.Get("level"); l != "" {
+		var err error
+		if level, err = strconv.Atoi(l); err != nil {
+			return archiveCompression{}, 0, fmt.Errorf("invalid level %q", l)
+		}
+		if level < c.minLevel || level > c.maxLevel {
+			return archiveCompression{}, 0, fmt.Errorf("level %d is out of range for compression %v, expected %d to %d", level, c.name, c.minLevel, c.maxLevel)
+		}
+	}
+	return c, level, nil
+}
+
+// zipCompressionFromRequest picks how the files of a zip archive download are compressed by the compression query
+// parameter, "deflate" which is the default or "none" to store them e.g. "?format=zip&compression=none". A level of 0
+// uses deflate's default level.
+func zipCompressionFromRequest(r *http.Request) (deflate bool, level int, err error) {
+	query := r.URL.Query()
+	switch name := query.Get("compression"); name {
+	case "", "deflate":
+		deflate = true
+	case compressionNone.name:
+	default:
+		return false, 0, fmt.Errorf("unknown compression %q for a zip archive, expected deflate or none", name)
+	}
+
+	if l := query.Get("level"); l != "" {
+		if level, err = strconv.Atoi(l); err != nil {
+			return false, 0, fmt.Errorf("invalid level %q", l)
+		}
+		if !deflate || level < flate.BestSpeed || level > flate.BestCompression {
+			return false, 0, fmt.Errorf("level %d is out of range for a zip archive, expected %d to %d with deflate", level, flate.BestSpeed, flate.BestCompression)
+		}
+	}
+	return deflate, level, nil
+}
+
+// acceptedArchiveCompression returns the codec of the first media type in an Accept header that names one, wildcards
+// e.g. "*/*" as sent by browsers are ignored.
+func acceptedArchiveCompression(accept string) (archiveCompression, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		for _, c := range archiveCompressions {
+			if c.contentType == mediaType {
+				return c, true
+			}
+		}
+	}
+	return archiveCompression{}, false
+}
+
+// newWriter returns a writer compressing to w, it must be closed to flush the compressed stream.
+func (c archiveCompression) newWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	switch c.name {
+	case compressionGzip.name:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(w, level)
+	case compressionZstd.name:
+		encoderLevel := zstd.SpeedDefault
+		if level != 0 {
+			encoderLevel = zstd.EncoderLevelFromZstd(level)
+		}
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(encoderLevel))
+	default:
+		return nopWriteCloser{w}, nil
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+// GetSchedulerDecisions implements POST /v1.Backrest/GetSchedulerDecisions
+func (s *BackrestHandler) GetSchedulerDecisions(ctx context.Context, req *connect.Request[v1.SchedulerDecisionsRequest]) (*connect.Response[v1.SchedulerDecisionList], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.SchedulerDecisionList{}
+	for _, d := range s.orchestrator.SchedulerDecisions() {
+		if req.Msg.Limit > 0 && len(list.Decisions) >= int(req.Msg.Limit) {
+			break
+		}
+		if d.UnixTimeMs < req.Msg.SinceMs {
+			break // decisions are newest first.
+		}
+		if (req.Msg.PlanId != "" && d.PlanId != req.Msg.PlanId) || (req.Msg.RepoId != "" && d.RepoId != req.Msg.RepoId) {
+			continue
+		}
+		// decisions about tasks of no repo e.g. garbage collection are only shown to unrestricted callers.
+		if !access.canAccessRepo(d.RepoId) {
+			continue
+		}
+		list.Decisions = append(list.Decisions, d)
+	}
+	return connect.NewResponse(list), nil
+}
@@ -1,41 +1,65 @@
 package api
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"crypto/hmac"
+	"crypto/rand"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator"
+	"github.com/garethgeorge/backrest/internal/restorearchive"
 	"go.uber.org/zap"
 )
 
-func NewDownloadHandler(oplog *oplog.OpLog) http.Handler {
+// NewDownloadHandler serves the signed URLs returned by GetDownloadURL and GetRestoreDownloadURL, which download a restore
+// or a part of it as a tar or zip archive or a single restored file as is, by
+// GetSnapshotFileDownloadURL, which stream a single file from a snapshot, and by GetSnapshotArchiveURL, which stream a
+// snapshot's directory as a tar archive.
+func NewDownloadHandler(oplog *oplog.OpLog, orchestrator *orchestrator.Orchestrator) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		p := r.URL.Path[1:]
+		if rest, ok := strings.CutPrefix(p, "dump/"); ok {
+			serveSnapshotFile(w, r, orchestrator, rest)
+			return
+		}
+		if rest, ok := strings.CutPrefix(p, "archive/"); ok {
+			serveSnapshotArchive(w, r, orchestrator, rest)
+			return
+		}
 
-		opID, signature, filePath, err := parseDownloadPath(p)
+		token, signature, filePath, err := parseDownloadPath(p)
 		if err != nil {
 			http.Error(w, "invalid path", http.StatusBadRequest)
 			return
 		}
 
-		if ok, err := checkDownloadURLSignature(opID, signature); err != nil || !ok {
+		filePath = cleanRestoreDownloadPath(filePath)
+		if ok, err := checkDownloadURLSignature(token, filePath, signature); err != nil || !ok {
 			http.Error(w, fmt.Sprintf("invalid signature: %v", err), http.StatusForbidden)
 			return
 		}
+		if time.Now().Unix() >= token.expires {
+			http.Error(w, "download URL expired", http.StatusGone)
+			return
+		}
 
-		op, err := oplog.Get(int64(opID))
+		op, err := oplog.Get(token.opID)
 		if err != nil {
 			http.Error(w, "restore not found", http.StatusNotFound)
 			return
@@ -46,89 +70,420 @@ func NewDownloadHandler(oplog *oplog.OpLog) http.Handler {
 			return
 		}
 		targetPath := restoreOp.OperationRestore.GetTarget()
-		if targetPath == "" {
+		if targetPath == "" || restoreOp.OperationRestore.InPlace {
 			http.Error(w, "restore target not found", http.StatusNotFound)
 			return
 		}
-		fullPath := filepath.Join(targetPath, filePath)
+		// a single use URL is consumed by its first request, a range request resuming its download is rejected.
+		if !usedDownloadNonces.consume(token, time.Now()) {
+			http.Error(w, "download URL was already used", http.StatusGone)
+			return
+		}
+		// zstd is the default as compressing is often the bottleneck of downloads over a LAN.
+		serveRestoreDownload(w, r, oplog, orchestrator, op, filePath, compressionZstd, true)
+	})
+}
 
-		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=archive-%v.tar.gz", time.Now().Format("2006-01-02-15-04-05")))
-		w.Header().Set("Content-Type", "application/gzip")
-		w.Header().Set("Content-Transfer-Encoding", "binary")
+// archiveQueryParams are the query parameters of a restore download that ask for an archive.
+var archiveQueryParams = []string{"format", "compression", "level", "include", "exclude"}
 
-		gzw := gzip.NewWriter(w)
-		defer gzw.Close()
-		t := tar.NewWriter(gzw)
-		zap.L().Info("creating tar archive", zap.String("path", fullPath))
-		if err := filepath.Walk(fullPath, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-			if info.IsDir() {
-				return nil
-			}
-
-			stat, err := os.Stat(path)
-			if err != nil {
-				zap.L().Warn("error stating file", zap.String("path", path), zap.Error(err))
-				return nil
-			}
-			file, err := os.OpenFile(path, os.O_RDONLY, 0)
-			if err != nil {
-				zap.L().Warn("error opening file", zap.String("path", path), zap.Error(err))
-				return nil
-			}
+// serveRestoreDownload streams the file at dirPath, relative to the target of the restore operation, as is if it's a
+// regular file and the request doesn't ask for an archive with one of archiveQueryParams. Anything else is streamed as
+// an archive, see serveRestoreArchive.
+func serveRestoreDownload(w http.ResponseWriter, r *http.Request, oplog *oplog.OpLog, orchestrator *orchestrator.Orchestrator, op *v1.Operation, dirPath string, def archiveCompression, followSymlinks bool) {
+	query := r.URL.Query()
+	if !slices.ContainsFunc(archiveQueryParams, query.Has) {
+		fullPath := filepath.Join(op.GetOperationRestore().GetTarget(), dirPath)
+		if file, info, ok := openRegularFile(fullPath, followSymlinks); ok {
 			defer file.Close()
+			serveRestoreFile(w, r, file, info)
+			return
+		}
+	}
+	serveRestoreArchive(w, r, oplog, orchestrator, op, dirPath, def, followSymlinks)
+}
 
-			if err := t.WriteHeader(&tar.Header{
-				Name:    path[len(fullPath)+1:],
-				Size:    stat.Size(),
-				Mode:    int64(stat.Mode()),
-				ModTime: stat.ModTime(),
-			}); err != nil {
-				zap.L().Warn("error writing tar header", zap.String("path", path), zap.Error(err))
-				return nil
-			}
-			if n, err := io.CopyN(t, file, stat.Size()); err != nil {
-				zap.L().Warn("error copying file to tar archive", zap.String("path", path), zap.Error(err))
-			} else if n != stat.Size() {
-				zap.L().Warn("error copying file to tar archive: short write", zap.String("path", path))
+// openRegularFile opens the file at path if it's a regular file, or a symlink to one if followSymlinks is set.
+func openRegularFile(path string, followSymlinks bool) (*os.File, os.FileInfo, bool) {
+	stat := os.Lstat
+	if followSymlinks {
+		stat = os.Stat
+	}
+	if info, err := stat(path); err != nil || !info.Mode().IsRegular() {
+		return nil, nil, false
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, false
+	}
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		file.Close()
+		return nil, nil, false
+	}
+	return file, info, true
+}
+
+// serveRestoreFile streams a restored file as is. The content type is picked by the file's extension, or sniffed from
+// its contents, and range requests are supported so that downloads of large files can be resumed.
+func serveRestoreFile(w http.ResponseWriter, r *http.Request, file *os.File, info os.FileInfo) {
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name()}))
+	zap.L().Info("streaming restored file", zap.String("path", file.Name()), zap.Int64("size", info.Size()))
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// serveRestoreArchive streams the file or directory at dirPath, relative to the target of the restore operation, as a tar
+// archive with a manifest. The archive is compressed with def unless the request asks for another codec. "?format=zip"
+// streams a zip archive instead, which Windows opens natively. The files archived can be selected with include and
+// exclude patterns, see archiveFilter. Symlinks are skipped unless followSymlinks is set, in which case the files they
+// point to are archived.
+func serveRestoreArchive(w http.ResponseWriter, r *http.Request, oplog *oplog.OpLog, orchestrator *orchestrator.Orchestrator, op *v1.Operation, dirPath string, def archiveCompression, followSymlinks bool) {
+	restoreOp := op.GetOperationRestore()
+	fullPath := filepath.Join(restoreOp.GetTarget(), dirPath)
+	filter, err := archiveFilterFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var (
+		contentType, extension string
+		newWriter              func(w io.Writer, manifest *restorearchive.Manifest) *restorearchive.Writer
+		cw                     io.WriteCloser
+	)
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "tar":
+		compression, level, err := archiveCompressionFromRequest(r, def)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if cw, err = compression.newWriter(w, level); err != nil {
+			http.Error(w, fmt.Sprintf("create %v writer: %v", compression.name, err), http.StatusInternalServerError)
+			return
+		}
+		contentType, extension = compression.contentType, compression.extension
+		newWriter = restorearchive.NewWriter
+	case "zip":
+		// the files of a zip archive are compressed individually.
+		deflate, level, err := zipCompressionFromRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cw = nopWriteCloser{w}
+		contentType, extension = "application/zip", ".zip"
+		newWriter = func(w io.Writer, manifest *restorearchive.Manifest) *restorearchive.Writer {
+			return restorearchive.NewZipWriter(w, manifest, deflate, level)
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unknown format %q, expected tar or zip", format), http.StatusBadRequest)
+		return
+	}
+	defer cw.Close()
+
+	now := time.Now()
+	manifest := &restorearchive.Manifest{
+		SnapshotId: op.SnapshotId,
+		Repo:       op.RepoId,
+		Plan:       op.PlanId,
+		Path:       path.Join(restoreOp.GetPath(), filepath.ToSlash(dirPath)),
+		CreatedAt:  now,
+	}
+	manifest.Include, manifest.Exclude = filter.patterns()
+	if snapshotTime, ok := snapshotTimeFromOplog(oplog, op.SnapshotId); ok {
+		manifest.SnapshotTime = &snapshotTime
+	}
+	name, err := restoreArchiveName(orchestrator.Config().GetDownloads().GetArchiveName(), manifest, now)
+	if err != nil {
+		zap.L().Warn("error naming restore archive, using the default name", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + extension}))
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+	w.Header().Set("Vary", "Accept")
+
+	t := newWriter(cw, manifest)
+	zap.L().Info("creating archive", zap.String("path", fullPath), zap.String("type", contentType))
+	if err := filepath.Walk(fullPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := filepath.Base(path) // a single file is archived by its name.
+		if path != fullPath {
+			name = path[len(fullPath)+1:]
+		}
+		if info.IsDir() {
+			if path != fullPath && filter.skipDir(filepath.ToSlash(name)) {
+				return filepath.SkipDir
 			}
 			return nil
-		}); err != nil {
-			zap.S().Errorf("error creating tar archive: %v", err)
-			http.Error(w, "error creating tar archive", http.StatusInternalServerError)
 		}
-		t.Flush()
-		if err := t.Close(); err != nil {
-			zap.S().Errorf("error closing tar archive: %v", err)
-			http.Error(w, "error closing tar archive", http.StatusInternalServerError)
+		if !followSymlinks && !info.Mode().IsRegular() {
+			return nil
 		}
-	})
+		if !filter.keepFile(filepath.ToSlash(name)) {
+			return nil
+		}
+
+		stat, err := os.Stat(path)
+		if err != nil {
+			zap.L().Warn("error stating file", zap.String("path", path), zap.Error(err))
+			return nil
+		}
+		file, err := os.OpenFile(path, os.O_RDONLY, 0)
+		if err != nil {
+			zap.L().Warn("error opening file", zap.String("path", path), zap.Error(err))
+			return nil
+		}
+		defer file.Close()
+
+		if err := t.AddFile(name, stat, file); err != nil {
+			zap.L().Warn("error adding file to archive", zap.String("path", path), zap.Error(err))
+		}
+		return nil
+	}); err != nil {
+		zap.S().Errorf("error creating archive: %v", err)
+		http.Error(w, "error creating archive", http.StatusInternalServerError)
+	}
+	if err := t.Close(); err != nil {
+		zap.S().Errorf("error closing archive: %v", err)
+		http.Error(w, "error closing archive", http.StatusInternalServerError)
+	}
+}
+
+// restoreArchiveName names a restore archive by the configured template, the default name is returned if there is no
+// template or it fails to render.
+func restoreArchiveName(tmpl string, manifest *restorearchive.Manifest, now time.Time) (string, error) {
+	defaultName := "archive-" + now.Format("2006-01-02-15-04-05")
+	if tmpl == "" {
+		return defaultName, nil
+	}
+	vars := restorearchive.NameVars{
+		Plan:       manifest.Plan,
+		Repo:       manifest.Repo,
+		SnapshotId: manifest.SnapshotId,
+		Path:       manifest.Path,
+		CurTime:    now,
+	}
+	if manifest.SnapshotTime != nil {
+		vars.SnapshotTime = *manifest.SnapshotTime
+	}
+	name, err := restorearchive.Name(tmpl, vars)
+	if err != nil {
+		return defaultName, err
+	}
+	return name, nil
+}
+
+// snapshotTimeFromOplog returns the time of the snapshot as indexed in the oplog.
+func snapshotTimeFromOplog(log *oplog.OpLog, snapshotID string) (time.Time, bool) {
+	var snapshotTime time.Time
+	var found bool
+	if err := log.ForEachBySnapshotId(snapshotID, indexutil.CollectAll(), func(op *v1.Operation) error {
+		if snapshot := op.GetOperationIndexSnapshot().GetSnapshot(); snapshot != nil {
+			snapshotTime, found = time.UnixMilli(snapshot.UnixTimeMs), true
+		}
+		return nil
+	}); err != nil {
+		zap.L().Warn("error finding snapshot in oplog", zap.String("snapshot", snapshotID), zap.Error(err))
+	}
+	return snapshotTime, found
 }
 
-func parseDownloadPath(p string) (int64, string, string, error) {
+// serveSnapshotFile streams the file at a path of the form <token>-<signature>/<repo id>/<snapshot id>/<file path> with
+// restic dump.
+func serveSnapshotFile(w http.ResponseWriter, r *http.Request, orchestrator *orchestrator.Orchestrator, p string) {
+	repoID, snapshotID, filePath, ok := parseSignedSnapshotPath(w, p, signSnapshotFileForDownload)
+	if !ok {
+		return
+	}
+	if filePath == "/" {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	repo, err := orchestrator.GetRepoOrchestrator(repoID)
+	if err != nil {
+		http.Error(w, "repo not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(filePath)}))
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+
+	zap.L().Info("streaming file from snapshot", zap.String("repo", repoID), zap.String("snapshot", snapshotID), zap.String("path", filePath))
+	cw := &countingWriter{w: w}
+	if err := repo.Dump(r.Context(), snapshotID, filePath, cw); err != nil {
+		zap.S().Errorf("error streaming file from snapshot: %v", err)
+		if cw.n == 0 {
+			// nothing was sent yet, the error can still be reported to the client.
+			http.Error(w, "error reading file from snapshot", http.StatusInternalServerError)
+		}
+	}
+}
+
+// serveSnapshotArchive streams the directory at a path of the form <token>-<signature>/<repo id>/<snapshot id>/<dir path>
+// as a tar archive with restic dump. restic writes a snapshot's archive the same way each time, an interrupted download is resumed
+// by passing the bytes already received as the offset query parameter, they are read again but not sent.
+func serveSnapshotArchive(w http.ResponseWriter, r *http.Request, orchestrator *orchestrator.Orchestrator, p string) {
+	repoID, snapshotID, dirPath, ok := parseSignedSnapshotPath(w, p, signSnapshotArchiveForDownload)
+	if !ok {
+		return
+	}
+	var offset int64
+	if o := r.URL.Query().Get("offset"); o != "" {
+		var err error
+		if offset, err = strconv.ParseInt(o, 10, 64); err != nil || offset < 0 {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+	// uncompressed by default, the archive is typically piped into tar -x. Only the uncompressed archive is the same on
+	// every download so only it can be resumed.
+	compression, level, err := archiveCompressionFromRequest(r, compressionNone)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if offset > 0 && compression != compressionNone {
+		http.Error(w, "offset is only supported for uncompressed archives", http.StatusBadRequest)
+		return
+	}
+
+	repo, err := orchestrator.GetRepoOrchestrator(repoID)
+	if err != nil {
+		http.Error(w, "repo not found", http.StatusNotFound)
+		return
+	}
+
+	name := "snapshot-" + snapshotID
+	if dirPath != "/" {
+		name += "-" + path.Base(dirPath)
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + compression.extension}))
+	w.Header().Set("Content-Type", compression.contentType)
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+	w.Header().Set("Vary", "Accept")
+
+	zap.L().Info("streaming snapshot archive", zap.String("repo", repoID), zap.String("snapshot", snapshotID), zap.String("path", dirPath), zap.Int64("offset", offset), zap.String("compression", compression.name))
+	cw := &countingWriter{w: w, skip: offset}
+	zw, err := compression.newWriter(cw, level)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("create %v writer: %v", compression.name, err), http.StatusInternalServerError)
+		return
+	}
+	err = repo.DumpArchive(r.Context(), snapshotID, dirPath, zw)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if cw.n > 0 {
+		if err != nil {
+			zap.S().Errorf("error streaming snapshot archive: %v", err)
+			// abort the response rather than ending it so that the client sees the archive as truncated and resumes it.
+			panic(http.ErrAbortHandler)
+		}
+		return
+	}
+	// nothing was sent yet, errors can still be reported to the client.
+	if err != nil {
+		zap.S().Errorf("error streaming snapshot archive: %v", err)
+		http.Error(w, "error reading archive from snapshot", http.StatusInternalServerError)
+	} else if cw.skip > 0 {
+		http.Error(w, "offset is beyond the end of the archive", http.StatusRequestedRangeNotSatisfiable)
+	}
+}
+
+// parseSignedSnapshotPath parses a path of the form <token>-<signature>/<repo id>/<snapshot id>/<path>, checks its
+// signature and that the URL hasn't expired or, if it's single use, been used. Errors are written to w.
+func parseSignedSnapshotPath(w http.ResponseWriter, p string, sign func(token downloadToken, repoID, snapshotID, filePath string) ([]byte, error)) (string, string, string, bool) {
+	parts := strings.SplitN(p, "/", 4)
+	if len(parts) != 4 {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return "", "", "", false
+	}
+	repoID, snapshotID, filePath := parts[1], parts[2], "/"+parts[3]
+	dash := strings.LastIndex(parts[0], "-")
+	if dash == -1 {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return "", "", "", false
+	}
+	token, err := parseDownloadToken(parts[0][:dash])
+	if err != nil {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return "", "", "", false
+	}
+
+	wantSignature, err := sign(token, repoID, snapshotID, filePath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid signature: %v", err), http.StatusForbidden)
+		return "", "", "", false
+	}
+	if signatureBytes, err := hex.DecodeString(parts[0][dash+1:]); err != nil || !hmac.Equal(wantSignature, signatureBytes) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return "", "", "", false
+	}
+	now := time.Now()
+	if now.Unix() >= token.expires {
+		http.Error(w, "download URL expired", http.StatusGone)
+		return "", "", "", false
+	}
+	if !usedDownloadNonces.consume(token, now) {
+		http.Error(w, "download URL was already used", http.StatusGone)
+		return "", "", "", false
+	}
+	return repoID, snapshotID, filePath, true
+}
+
+// countingWriter counts the bytes written to w, the first skip bytes are dropped rather than written.
+type countingWriter struct {
+	w    io.Writer
+	n    int64
+	skip int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	skipped := int(min(c.skip, int64(len(p))))
+	c.skip -= int64(skipped)
+	if skipped == len(p) {
+		// an empty write would still send the response's headers.
+		return skipped, nil
+	}
+	n, err := c.w.Write(p[skipped:])
+	c.n += int64(n)
+	return skipped + n, err
+}
+
+// parseDownloadPath parses a path of the form <token>-<signature>/<file path>, see downloadToken for the token's form.
+func parseDownloadPath(p string) (downloadToken, string, string, error) {
 	sep := strings.Index(p, "/")
 	if sep == -1 {
-		return 0, "", "", fmt.Errorf("invalid path")
+		return downloadToken{}, "", "", fmt.Errorf("invalid path")
 	}
 	restoreID := p[:sep]
 	filePath := p[sep+1:]
 
-	dash := strings.Index(restoreID, "-")
+	dash := strings.LastIndex(restoreID, "-")
 	if dash == -1 {
-		return 0, "", "", fmt.Errorf("invalid restore ID")
+		return downloadToken{}, "", "", fmt.Errorf("invalid restore ID")
 	}
-	opID, err := strconv.ParseInt(restoreID[:dash], 16, 64)
+	token, err := parseDownloadToken(restoreID[:dash])
 	if err != nil {
-		return 0, "", "", fmt.Errorf("invalid restore ID: %w", err)
+		return downloadToken{}, "", "", fmt.Errorf("invalid restore ID: %w", err)
 	}
 	signature := restoreID[dash+1:]
-	return opID, signature, filePath, nil
+	return token, signature, filePath, nil
 }
 
-func checkDownloadURLSignature(id int64, signature string) (bool, error) {
-	wantSignatureBytes, err := signOperationIDForDownload(id)
+// cleanRestoreDownloadPath returns the path relative to a restore's target in the form it's signed in, "" for the target.
+func cleanRestoreDownloadPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+func checkDownloadURLSignature(token downloadToken, filePath string, signature string) (bool, error) {
+	wantSignatureBytes, err := signRestoreForDownload(token, filePath)
 	if err != nil {
 		return false, err
 	}
@@ -139,12 +494,106 @@ func checkDownloadURLSignature(id int64, signature string) (bool, error) {
 	return hmac.Equal(wantSignatureBytes, signatureBytes), nil
 }
 
-func signOperationIDForDownload(id int64) ([]byte, error) {
-	opIDBytes := make([]byte, 8)
-	binary.BigEndian.PutUint64(opIDBytes, uint64(id))
-	signature, err := generateSignature(opIDBytes)
+func signSnapshotFileForDownload(token downloadToken, repoID, snapshotID, filePath string) ([]byte, error) {
+	// the prefixes keep the signatures of each kind of download distinct.
+	return generateSignature([]byte("dump\x00" + string(token.bytes()) + repoID + "\x00" + snapshotID + "\x00" + filePath))
+}
+
+func signSnapshotArchiveForDownload(token downloadToken, repoID, snapshotID, dirPath string) ([]byte, error) {
+	return generateSignature([]byte("archive\x00" + string(token.bytes()) + repoID + "\x00" + snapshotID + "\x00" + dirPath))
+}
+
+// signRestoreForDownload signs the token along with the path relative to the target of its restore operation, a URL
+// signed for a path can't be used to download the rest of the restore and its expiry can't be extended.
+func signRestoreForDownload(token downloadToken, filePath string) ([]byte, error) {
+	return generateSignature([]byte("restore\x00" + string(append(token.bytes(), filePath...))))
+}
+
+// defaultDownloadURLTTL is how long download URLs stay valid for unless the config sets a TTL.
+const defaultDownloadURLTTL = 1 * time.Hour
+
+// downloadNonceSize is the size of the nonce of a single use download URL.
+const downloadNonceSize = 16
+
+// downloadToken identifies the restore a download URL is for and how long it can be used for, it's written to the URL
+// as <op id>-<expires>-<nonce> in hex. The nonce is empty unless the URL is single use. The op ID of the URLs of
+// snapshot files and archives is 0, the snapshot is signed along with the token.
+type downloadToken struct {
+	opID    int64
+	expires int64  // unix time in seconds the URL expires at.
+	nonce   []byte // identifies a single use URL, consumed by its first download.
+}
+
+// newDownloadToken returns a token for a download URL of the restore operation that expires after ttl.
+func newDownloadToken(opID int64, now time.Time, ttl time.Duration, singleUse bool) (downloadToken, error) {
+	token := downloadToken{opID: opID, expires: now.Add(ttl).Unix()}
+	if singleUse {
+		token.nonce = make([]byte, downloadNonceSize)
+		if _, err := rand.Read(token.nonce); err != nil {
+			return downloadToken{}, fmt.Errorf("generate nonce: %w", err)
+		}
+	}
+	return token, nil
+}
+
+func parseDownloadToken(s string) (downloadToken, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return downloadToken{}, errors.New("malformed token")
+	}
+	opID, err := strconv.ParseInt(parts[0], 16, 64)
+	if err != nil {
+		return downloadToken{}, fmt.Errorf("operation ID: %w", err)
+	}
+	expires, err := strconv.ParseInt(parts[1], 16, 64)
 	if err != nil {
-		return nil, err
+		return downloadToken{}, fmt.Errorf("expiry: %w", err)
+	}
+	nonce, err := hex.DecodeString(parts[2])
+	if err != nil || (len(nonce) != 0 && len(nonce) != downloadNonceSize) {
+		return downloadToken{}, errors.New("invalid nonce")
+	}
+	return downloadToken{opID: opID, expires: expires, nonce: nonce}, nil
+}
+
+// bytes returns the token in the form it's signed in.
+func (t downloadToken) bytes() []byte {
+	data := binary.BigEndian.AppendUint64(nil, uint64(t.opID))
+	data = binary.BigEndian.AppendUint64(data, uint64(t.expires))
+	data = append(data, byte(len(t.nonce)))
+	return append(data, t.nonce...)
+}
+
+func (t downloadToken) String() string {
+	return fmt.Sprintf("%x-%x-%x", t.opID, t.expires, t.nonce)
+}
+
+// usedDownloadNonces are the nonces of the single use download URLs that have been downloaded. They're kept in memory
+// until their URLs expire, the URLs of a previous run can't be used anyway as the signing secret is generated on start.
+var usedDownloadNonces = &nonceSet{used: make(map[string]int64)}
+
+type nonceSet struct {
+	mu   sync.Mutex
+	used map[string]int64 // nonce to the expiry of its URL.
+}
+
+// consume marks the token's nonce used and returns false if it already was, tokens without a nonce can be used any
+// number of times. A nonce is used by the first request for its URL whether or not the download completes, so the
+// downloads of single use URLs can't be resumed with range requests. The nonces of expired URLs are forgotten.
+func (s *nonceSet) consume(token downloadToken, now time.Time) bool {
+	if len(token.nonce) == 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for nonce, expires := range s.used {
+		if now.Unix() >= expires {
+			delete(s.used, nonce)
+		}
+	}
+	if _, ok := s.used[string(token.nonce)]; ok {
+		return false
 	}
-	return signature, nil
+	s.used[string(token.nonce)] = token.expires
+	return true
 }
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+// globEscaper escapes the characters restic treats as glob syntax in find patterns.
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`)
+
+// ListFileVersions implements POST /v1.Backrest/ListFileVersions
+func (s *BackrestHandler) ListFileVersions(ctx context.Context, req *connect.Request[v1.ListFileVersionsRequest]) (*connect.Response[v1.FileVersionList], error) {
+	if req.Msg.PlanId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("plan ID is required"))
+	}
+	if !strings.HasPrefix(req.Msg.Path, "/") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path %q must be absolute", req.Msg.Path))
+	}
+	filePath := path.Clean(req.Msg.Path)
+
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+		return nil, err
+	}
+	plan, err := s.orchestrator.GetPlan(req.Msg.PlanId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan %q: %w", req.Msg.PlanId, err)
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	release, err := s.backend.acquire(req.Msg.RepoId)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	snapshots, err := repo.SnapshotsForPlan(ctx, plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// an absolute pattern is matched against the whole path of each file rather than its name.
+	var versions []*v1.FileVersion
+	err = repo.Find(ctx, globEscaper.Replace(filePath), nil, req.Msg.PlanId, false, func(snapshotID string, entries []*v1.LsEntry) error {
+		for _, entry := range entries {
+			if entry.Path == filePath {
+				versions = append(versions, &v1.FileVersion{SnapshotId: snapshotID, Entry: entry})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file versions: %w", err)
+	}
+
+	return connect.NewResponse(&v1.FileVersionList{
+		Versions: fileVersions(versions, snapshots),
+	}), nil
+}
+
+// fileVersions sets the snapshot time of each version, sorts them newest first and marks the versions that differ
+// from the next older one. Versions of snapshots not in snapshots e.g. because they were forgotten are dropped.
+func fileVersions(versions []*v1.FileVersion, snapshots []*restic.Snapshot) []*v1.FileVersion {
+	var list []*v1.FileVersion
+	for _, v := range versions {
+		// restic find may report short snapshot IDs.
+		idx := slices.IndexFunc(snapshots, func(s *restic.Snapshot) bool {
+			return strings.HasPrefix(s.Id, v.SnapshotId)
+		})
+		if idx == -1 {
+			continue
+		}
+		v.SnapshotId = snapshots[idx].Id
+		v.SnapshotTimeMs = snapshots[idx].UnixTimeMs()
+		list = append(list, v)
+	}
+
+	slices.SortFunc(list, func(a, b *v1.FileVersion) int {
+		return cmp.Compare(b.SnapshotTimeMs, a.SnapshotTimeMs)
+	})
+	for i, v := range list {
+		if i == len(list)-1 {
+			v.Changed = true
+			continue
+		}
+		older := list[i+1].Entry
+		v.Changed = v.Entry.Size != older.Size || v.Entry.Mtime != older.Mtime
+	}
+	return list
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"github.com/garethgeorge/backrest/internal/redact"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetHookDeliveries implements POST /v1.Backrest/GetHookDeliveries
+func (s *BackrestHandler) GetHookDeliveries(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.HookDeliveryList], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.oplog.GetHookDeliveries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hook deliveries: %w", err)
+	}
+
+	list := &v1.HookDeliveryList{}
+	for _, d := range deliveries {
+		if !access.canAccessRepo(d.RepoId) {
+			continue
+		}
+		// requests carry the receiver's URL and often a token, only the destination is returned.
+		d.Request = nil
+		d.LastError = redact.String(d.LastError)
+		if d.DeadLetter {
+			list.DeadLetters = append(list.DeadLetters, d)
+		} else {
+			list.Pending = append(list.Pending, d)
+		}
+	}
+	slices.Reverse(list.DeadLetters)
+	return connect.NewResponse(list), nil
+}
+
+// RetryHookDelivery implements POST /v1.Backrest/RetryHookDelivery
+func (s *BackrestHandler) RetryHookDelivery(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[emptypb.Empty], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.oplog.GetHookDeliveries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hook deliveries: %w", err)
+	}
+	idx := slices.IndexFunc(deliveries, func(d *v1.HookDelivery) bool { return d.Id == req.Msg.Value })
+	if idx == -1 || !access.canAccessRepo(deliveries[idx].RepoId) {
+		return nil, connect.NewError(connect.CodeNotFound, hook.ErrDeliveryNotFound)
+	}
+
+	if err := s.orchestrator.RetryHookDelivery(req.Msg.Value); err != nil {
+		if errors.Is(err, hook.ErrDeliveryNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to retry hook delivery: %w", err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator"
+	"github.com/garethgeorge/backrest/pkg/restic"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MountRepo implements POST /v1.Backrest/MountRepo
+func (s *BackrestHandler) MountRepo(ctx context.Context, req *connect.Request[v1.MountRepoRequest]) (*connect.Response[v1.RepoMount], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if req.Msg.IdleTimeoutMinutes < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("idle timeout must not be negative"))
+	}
+	mount, err := s.orchestrator.MountRepo(req.Msg.RepoId, time.Duration(req.Msg.IdleTimeoutMinutes)*time.Minute)
+	if errors.Is(err, restic.ErrMountUnsupported) {
+		return nil, connect.NewError(connect.CodeUnimplemented, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to mount repo %q: %w", req.Msg.RepoId, err)
+	}
+	return connect.NewResponse(mount), nil
+}
+
+// UnmountRepo implements POST /v1.Backrest/UnmountRepo
+func (s *BackrestHandler) UnmountRepo(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	if err := s.orchestrator.UnmountRepo(req.Msg.Value); errors.Is(err, orchestrator.ErrNotMounted) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to unmount repo %q: %w", req.Msg.Value, err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// GetMounts implements POST /v1.Backrest/GetMounts
+func (s *BackrestHandler) GetMounts(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.RepoMountList], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.RepoMountList{}
+	for _, m := range s.orchestrator.Mounts() {
+		if access.canAccessRepo(m.RepoId) {
+			list.Mounts = append(list.Mounts, m)
+		}
+	}
+	return connect.NewResponse(list), nil
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/alerts"
+	"github.com/garethgeorge/backrest/internal/auth"
+	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+)
+
+// namespaceAccess describes what the caller of an API may access. Callers outside of any namespace, including all callers
+// when authentication is disabled, may access everything.
+type namespaceAccess struct {
+	namespace string
+	repos     map[string]bool // repos in the namespace, nil if the caller may access every repo.
+}
+
+func namespaceFromContext(ctx context.Context) string {
+	if user, ok := ctx.Value(auth.UserContextKey).(*v1.User); ok {
+		return user.Namespace
+	}
+	return ""
+}
+
+func (s *BackrestHandler) namespaceAccess(ctx context.Context) (*namespaceAccess, error) {
+	ns := namespaceFromContext(ctx)
+	if ns == "" {
+		return &namespaceAccess{}, nil
+	}
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	access := &namespaceAccess{namespace: ns, repos: make(map[string]bool)}
+	for _, id := range config.ReposInNamespace(cfg, ns) {
+		access.repos[id] = true
+	}
+	return access, nil
+}
+
+func (a *namespaceAccess) unrestricted() bool {
+	return a.repos == nil
+}
+
+func (a *namespaceAccess) canAccessRepo(repoID string) bool {
+	return a.unrestricted() || a.repos[repoID]
+}
+
+func (a *namespaceAccess) canAccessOperation(op *v1.Operation) bool {
+	return a.canAccessRepo(op.RepoId)
+}
+
+func (a *namespaceAccess) canAccessAlert(alert *v1.Alert) bool {
+	if alert.Namespace != "" {
+		return a.unrestricted() || alert.Namespace == a.namespace
+	}
+	return a.canAccessRepo(alert.RepoId)
+}
+
+// checkRepoAccess returns a not found error if the caller may not access the repo, repos in other namespaces are
+// indistinguishable from repos that do not exist.
+func (s *BackrestHandler) checkRepoAccess(ctx context.Context, repoID string) error {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return err
+	}
+	if !access.canAccessRepo(repoID) {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q not found", repoID))
+	}
+	return nil
+}
+
+// checkPlanAccess returns a not found error if the caller may not access the plan's repo.
+func (s *BackrestHandler) checkPlanAccess(ctx context.Context, planID string) error {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return err
+	}
+	if access.unrestricted() {
+		return nil
+	}
+	cfg, err := s.config.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	idx := slices.IndexFunc(cfg.Plans, func(p *v1.Plan) bool { return p.Id == planID })
+	if idx < 0 || !access.canAccessRepo(cfg.Plans[idx].Repo) {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("plan %q not found", planID))
+	}
+	return nil
+}
+
+// checkOperationAccess returns a not found error if the caller may not access the operation.
+func (s *BackrestHandler) checkOperationAccess(ctx context.Context, op *v1.Operation) error {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return err
+	}
+	if !access.canAccessOperation(op) {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("operation %d not found", op.Id))
+	}
+	return nil
+}
+
+// checkLogAccess returns a not found error if the caller may not access any operation that wrote the log.
+func (s *BackrestHandler) checkLogAccess(ctx context.Context, ref string) error {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return err
+	}
+	if access.unrestricted() {
+		return nil
+	}
+	found := errors.New("found")
+	for repoID := range access.repos {
+		err := s.oplog.ForEachByRepo(repoID, indexutil.CollectAll(), func(op *v1.Operation) error {
+			if op.Logref == ref {
+				return found
+			}
+			return nil
+		})
+		if errors.Is(err, found) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to find operation for log %v: %w", ref, err)
+		}
+	}
+	return connect.NewError(connect.CodeNotFound, fmt.Errorf("log %v not found", ref))
+}
+
+// checkStagingQuota returns a resource exhausted error if the restores that are still on disk for the repos in the
+// caller's namespace total at least the namespace's staging quota.
+func (s *BackrestHandler) checkStagingQuota(ctx context.Context) error {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return err
+	}
+	if access.unrestricted() {
+		return nil
+	}
+	cfg, err := s.config.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	quota := config.FindNamespace(cfg, access.namespace).GetStagingQuotaBytes()
+	if quota <= 0 {
+		return nil
+	}
+
+	repoIDs := make([]string, 0, len(access.repos))
+	for repoID := range access.repos {
+		repoIDs = append(repoIDs, repoID)
+	}
+	staged, err := alerts.StagedRestoreBytes(s.oplog, repoIDs)
+	if err != nil {
+		return err
+	}
+	if staged >= quota {
+		return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("namespace %q has %d bytes of restores staged, at or above its quota of %d bytes, remove old restores and try again", access.namespace, staged, quota))
+	}
+	return nil
+}
+
+// stagingDir returns the directory the restores of users in the namespace are written to, each namespace's restores are
+// kept apart so that they can be accounted against its staging quota.
+func stagingDir(ns string) string {
+	return path.Join(os.Getenv("HOME"), "Downloads", ns)
+}
+
+// checkHostPathAccess returns a permission denied error if the caller may not list the path on the host, users in a
+// namespace may only list its root dir and staging dir.
+func (s *BackrestHandler) checkHostPathAccess(ctx context.Context, p string) error {
+	ns := namespaceFromContext(ctx)
+	if ns == "" {
+		return nil
+	}
+	cfg, err := s.config.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	if rootDir := config.FindNamespace(cfg, ns).GetRootDir(); rootDir != "" && config.InDir(rootDir, p) {
+		return nil
+	}
+	if config.InDir(stagingDir(ns), p) {
+		return nil
+	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("path %q is outside of namespace %q", p, ns))
+}
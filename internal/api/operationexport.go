@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplogexport"
+	"github.com/garethgeorge/backrest/internal/redact"
+)
+
+// ExportOperations implements POST /v1.Backrest/ExportOperations
+func (s *BackrestHandler) ExportOperations(ctx context.Context, req *connect.Request[v1.ExportOperationsRequest], resp *connect.ServerStream[types.BytesValue]) error {
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return err
+		}
+	}
+	if req.Msg.RepoId != "" {
+		if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+			return err
+		}
+	}
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return err
+	}
+
+	var redactor *redact.Redactor
+	if req.Msg.Sanitize {
+		cfg, err := s.config.Get()
+		if err != nil {
+			return fmt.Errorf("failed to get config: %w", err)
+		}
+		redactor = redact.New(redact.SecretsFromConfig(cfg))
+	}
+
+	if _, err := oplogexport.WriteOperations(&streamWriter{stream: resp}, s.oplog, func(op *v1.Operation) bool {
+		if req.Msg.PlanId != "" && op.PlanId != req.Msg.PlanId {
+			return false
+		}
+		if req.Msg.RepoId != "" && op.RepoId != req.Msg.RepoId {
+			return false
+		}
+		return access.canAccessOperation(op) && ctx.Err() == nil
+	}, redactor); err != nil {
+		return fmt.Errorf("failed to export operations: %w", err)
+	}
+	return ctx.Err()
+}
+
+// ImportOperations implements POST /v1.Backrest/ImportOperations
+func (s *BackrestHandler) ImportOperations(ctx context.Context, stream *connect.ClientStream[types.BytesValue]) (*connect.Response[v1.ImportOperationsResponse], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	im, err := oplogexport.NewImporter(s.oplog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import operations: %w", err)
+	}
+	// operations read before an error are still imported, importing the stream again after fixing it skips them.
+	if err := oplogexport.ReadOperations(&streamReader{stream: stream}, func(op *v1.Operation) error {
+		if !access.canAccessOperation(op) {
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q of operation %d not found", op.RepoId, op.Id))
+		}
+		return im.Add(op)
+	}); err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to import operations: %w", err))
+	}
+	if err := im.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to import operations: %w", err)
+	}
+
+	return connect.NewResponse(&v1.ImportOperationsResponse{
+		Imported:   int64(im.Stats.Imported),
+		Duplicates: int64(im.Stats.Duplicates),
+		Unfinished: int64(im.Stats.Unfinished),
+	}), nil
+}
+
+// streamWriter sends each write as a message of the stream.
+type streamWriter struct {
+	stream *connect.ServerStream[types.BytesValue]
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	// the message is sent before Write returns, p is not retained.
+	if err := w.stream.Send(&types.BytesValue{Value: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// streamReader reads the contents of the messages of the stream.
+type streamReader struct {
+	stream *connect.ClientStream[types.BytesValue]
+	buf    []byte
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		r.buf = r.stream.Msg().Value
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
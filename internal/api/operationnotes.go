@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/auth"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+// maxOperationNoteLength is the maximum length of an operation's note in characters.
+const maxOperationNoteLength = 4096
+
+// AnnotateOperation implements POST /v1.Backrest/AnnotateOperation
+func (s *BackrestHandler) AnnotateOperation(ctx context.Context, req *connect.Request[v1.AnnotateOperationRequest]) (*connect.Response[v1.Operation], error) {
+	text := strings.TrimSpace(req.Msg.Note)
+	if utf8.RuneCountInString(text) > maxOperationNoteLength {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("note is longer than %d characters", maxOperationNoteLength))
+	}
+
+	op, err := s.oplog.Get(req.Msg.OperationId)
+	if errors.Is(err, oplog.ErrNotExist) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("operation %d not found", req.Msg.OperationId))
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get operation %d: %w", req.Msg.OperationId, err)
+	}
+	if err := s.checkOperationAccess(ctx, op); err != nil {
+		return nil, err
+	}
+	// the task running an unfinished operation holds its own copy of it, a note would be lost when the task next saves it.
+	switch op.Status {
+	case v1.OperationStatus_STATUS_UNKNOWN, v1.OperationStatus_STATUS_PENDING, v1.OperationStatus_STATUS_INPROGRESS:
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("operation %d hasn't finished", op.Id))
+	}
+
+	if text == "" {
+		op.Note = nil
+	} else {
+		var author string
+		if u, ok := ctx.Value(auth.UserContextKey).(*v1.User); ok {
+			author = u.Name
+		}
+		op.Note = &v1.OperationNote{
+			Text:       text,
+			Author:     author,
+			UnixTimeMs: time.Now().UnixMilli(),
+		}
+	}
+	if err := s.oplog.Update(op); err != nil {
+		return nil, fmt.Errorf("failed to update operation %d: %w", op.Id, err)
+	}
+	return connect.NewResponse(op), nil
+}
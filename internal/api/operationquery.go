@@ -0,0 +1,109 @@
+package api
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// operationTypePrefix prefixes the names of the fields of an operation's oneof, operation types are named without it.
+const operationTypePrefix = "operation_"
+
+var operationTypes = (&v1.Operation{}).ProtoReflect().Descriptor().Oneofs().ByName("op")
+
+// operationType returns the type of the operation e.g. "backup", see GetOperationsRequest.types.
+func operationType(op *v1.Operation) string {
+	field := op.ProtoReflect().WhichOneof(operationTypes)
+	if field == nil {
+		return ""
+	}
+	return strings.TrimPrefix(string(field.Name()), operationTypePrefix)
+}
+
+// operationFilter holds the filters of a GetOperationsRequest applied after operations are selected.
+type operationFilter struct {
+	statuses      []v1.OperationStatus
+	types         []string
+	startAfterMs  int64
+	startBeforeMs int64
+}
+
+func newOperationFilter(req *v1.GetOperationsRequest) (*operationFilter, error) {
+	for _, t := range req.Types {
+		if operationTypes.Fields().ByName(protoreflect.Name(operationTypePrefix+t)) == nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown operation type %q", t))
+		}
+	}
+	if req.StartAfterMs != 0 && req.StartBeforeMs != 0 && req.StartAfterMs >= req.StartBeforeMs {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("start_after_ms must be before start_before_ms"))
+	}
+	return &operationFilter{
+		statuses:      req.Statuses,
+		types:         req.Types,
+		startAfterMs:  req.StartAfterMs,
+		startBeforeMs: req.StartBeforeMs,
+	}, nil
+}
+
+func (f *operationFilter) matches(op *v1.Operation) bool {
+	if len(f.statuses) > 0 && !slices.Contains(f.statuses, op.Status) {
+		return false
+	}
+	if len(f.types) > 0 && !slices.Contains(f.types, operationType(op)) {
+		return false
+	}
+	if f.startAfterMs != 0 && op.UnixTimeStartMs < f.startAfterMs {
+		return false
+	}
+	if f.startBeforeMs != 0 && op.UnixTimeStartMs >= f.startBeforeMs {
+		return false
+	}
+	return true
+}
+
+// pageOperations orders the operations as the request asks and returns the requested page of them along with the token
+// of the next page, empty if it's the last page. The page token is the ID of the last operation of the previous page.
+func pageOperations(req *v1.GetOperationsRequest, ops []*v1.Operation) ([]*v1.Operation, string, error) {
+	if !req.Descending && req.PageSize == 0 && req.PageToken == "" {
+		return ops, "", nil
+	}
+	if req.PageSize < 0 {
+		return nil, "", connect.NewError(connect.CodeInvalidArgument, errors.New("page_size must be non-negative"))
+	}
+
+	slices.SortFunc(ops, func(a, b *v1.Operation) int {
+		if req.Descending {
+			return cmp.Compare(b.Id, a.Id)
+		}
+		return cmp.Compare(a.Id, b.Id)
+	})
+	if req.PageToken != "" {
+		after, err := strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token %q", req.PageToken))
+		}
+		// the operation the token refers to may have been deleted since, find where it would be.
+		start, _ := slices.BinarySearchFunc(ops, after, func(op *v1.Operation, id int64) int {
+			if req.Descending {
+				return cmp.Compare(id, op.Id)
+			}
+			return cmp.Compare(op.Id, id)
+		})
+		if start < len(ops) && ops[start].Id == after {
+			start++
+		}
+		ops = ops[start:]
+	}
+	if req.PageSize == 0 || len(ops) <= int(req.PageSize) {
+		return ops, "", nil
+	}
+	ops = ops[:req.PageSize]
+	return ops, strconv.FormatInt(ops[len(ops)-1].Id, 10), nil
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/v1/v1connect"
+)
+
+// readReplicaProcedures are the procedures a read replica serves, they only read the config and the operation history
+// the replica mirrors from its primary.
+var readReplicaProcedures = map[string]bool{
+	v1connect.BackrestGetConfigProcedure:           true,
+	v1connect.BackrestGetOperationEventsProcedure:  true,
+	v1connect.BackrestSubscribeOperationsProcedure: true,
+	v1connect.BackrestGetOperationsProcedure:       true,
+	v1connect.BackrestGetRepoStatsHistoryProcedure: true,
+	v1connect.BackrestGetStatsProcedure:            true,
+	v1connect.BackrestExportOperationsProcedure:    true,
+	v1connect.BackrestDescribeCronProcedure:        true,
+	v1connect.BackrestValidateCronProcedure:        true,
+	v1connect.BackrestSearchProcedure:              true,
+	v1connect.BackrestGetAlertsProcedure:           true,
+}
+
+// NewReadReplicaInterceptor returns an interceptor rejecting the procedures a read replica doesn't serve, e.g. those
+// changing the config or running operations, which are only available on the primary.
+func NewReadReplicaInterceptor() connect.Interceptor {
+	return readReplicaInterceptor{}
+}
+
+type readReplicaInterceptor struct{}
+
+func (readReplicaInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := checkReadReplicaProcedure(req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (readReplicaInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (readReplicaInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := checkReadReplicaProcedure(conn.Spec().Procedure); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+func checkReadReplicaProcedure(procedure string) error {
+	if !readReplicaProcedures[procedure] {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("this instance is a read replica, %s is only available on its primary", procedure))
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListRepoKeys implements POST /v1.Backrest/ListRepoKeys
+func (s *BackrestHandler) ListRepoKeys(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.RepoKeyList], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
+	}
+	keys, err := repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	return connect.NewResponse(&v1.RepoKeyList{Keys: keys}), nil
+}
+
+// AddRepoKey implements POST /v1.Backrest/AddRepoKey
+func (s *BackrestHandler) AddRepoKey(ctx context.Context, req *connect.Request[v1.AddRepoKeyRequest]) (*connect.Response[v1.RepoKey], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if req.Msg.Password == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("password must not be empty"))
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+	key, err := repo.AddKey(ctx, req.Msg.Password, req.Msg.Username, req.Msg.Hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add key: %w", err)
+	}
+	zap.L().Info("added repo key", zap.String("repo", req.Msg.RepoId), zap.String("key", key.Id))
+	return connect.NewResponse(key), nil
+}
+
+// RemoveRepoKey implements POST /v1.Backrest/RemoveRepoKey
+func (s *BackrestHandler) RemoveRepoKey(ctx context.Context, req *connect.Request[v1.RemoveRepoKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+	keys, err := repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	idx := slices.IndexFunc(keys, func(k *v1.RepoKey) bool { return k.Id == req.Msg.KeyId })
+	if idx == -1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("key %q not found", req.Msg.KeyId))
+	}
+	if keys[idx].Current {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("key %q holds the repo's password in the config, change the password instead", req.Msg.KeyId))
+	}
+	if err := repo.RemoveKey(ctx, req.Msg.KeyId); err != nil {
+		return nil, fmt.Errorf("failed to remove key: %w", err)
+	}
+	zap.L().Info("removed repo key", zap.String("repo", req.Msg.RepoId), zap.String("key", req.Msg.KeyId))
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// ChangeRepoPassword implements POST /v1.Backrest/ChangeRepoPassword. A key holding the new password is added before
+// the config is updated and the old key is only removed after, the repo stays accessible with the password in the
+// config if any step fails.
+func (s *BackrestHandler) ChangeRepoPassword(ctx context.Context, req *connect.Request[v1.ChangeRepoPasswordRequest]) (*connect.Response[v1.Config], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if req.Msg.NewPassword == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password must not be empty"))
+	}
+
+	c, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	c = proto.Clone(c).(*v1.Config)
+	idx := slices.IndexFunc(c.Repos, func(r *v1.Repo) bool { return r.Id == req.Msg.RepoId })
+	if idx == -1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("repo %q not found", req.Msg.RepoId))
+	}
+	if c.Repos[idx].Password == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("the password of repo %q is not stored in the config e.g. it is set by the repo's env, it can't be changed by backrest", req.Msg.RepoId))
+	}
+
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+	keys, err := repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	oldIdx := slices.IndexFunc(keys, func(k *v1.RepoKey) bool { return k.Current })
+	if oldIdx == -1 {
+		return nil, errors.New("the key holding the repo's password was not found")
+	}
+	oldKey := keys[oldIdx]
+
+	// the following steps aren't cancelled with the request so that a disconnecting client doesn't leave them half done.
+	ctx = context.WithoutCancel(ctx)
+	newKey, err := repo.AddKey(ctx, req.Msg.NewPassword, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to add key with the new password: %w", err)
+	}
+
+	c.Repos[idx].Password = req.Msg.NewPassword
+	c.Modno += 1
+	if err := s.config.Update(c); err != nil {
+		if rmErr := repo.RemoveKey(ctx, newKey.Id); rmErr != nil {
+			zap.S().Errorf("remove key %v of repo %q after failing to update the config: %v", newKey.Id, req.Msg.RepoId, rmErr)
+		}
+		return nil, fmt.Errorf("failed to update config: %w", err)
+	}
+	if err := s.orchestrator.ApplyConfig(c); err != nil {
+		return nil, fmt.Errorf("failed to apply config: %w", err)
+	}
+
+	// the old key is removed with the new password, restic refuses to remove the key that opened the repo.
+	repo, err = s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err == nil {
+		err = repo.RemoveKey(ctx, oldKey.Id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("password changed but the old key %v was not removed, remove it with RemoveRepoKey: %w", oldKey.Id, err)
+	}
+
+	zap.L().Info("changed repo password", zap.String("repo", req.Msg.RepoId), zap.String("oldKey", oldKey.Id), zap.String("newKey", newKey.Id))
+	return connect.NewResponse(configForCaller(ctx, c)), nil
+}
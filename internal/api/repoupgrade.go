@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+// GetRepoUpgrade implements POST /v1.Backrest/GetRepoUpgrade
+func (s *BackrestHandler) GetRepoUpgrade(ctx context.Context, req *connect.Request[types.StringValue]) (*connect.Response[v1.RepoUpgradeAssessment], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.Value); err != nil {
+		return nil, err
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.Value, err)
+	}
+	version, err := repo.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := repo.Migrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats *v1.RepoStats
+	if err := s.oplog.ForEachByRepo(req.Msg.Value, indexutil.Reversed(indexutil.CollectAll()), func(op *v1.Operation) error {
+		if st := op.GetOperationStats().GetStats(); st != nil && op.Status == v1.OperationStatus_STATUS_SUCCESS {
+			stats = st
+			return oplog.ErrStopIteration
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get stats of repo %q: %w", req.Msg.Value, err)
+	}
+	return connect.NewResponse(repoUpgradeAssessment(req.Msg.Value, version, migrations, stats)), nil
+}
+
+// UpgradeRepo implements POST /v1.Backrest/UpgradeRepo
+func (s *BackrestHandler) UpgradeRepo(ctx context.Context, req *connect.Request[v1.UpgradeRepoRequest]) (*connect.Response[types.Int64Value], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if _, err := s.orchestrator.GetRepo(req.Msg.RepoId); err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+
+	opID, err := s.orchestrator.ScheduleOneoffTask(tasks.NewOneoffUpgradeTask(req.Msg.RepoId, req.Msg.Recompress, time.Now()), tasks.TaskPriorityInteractive+tasks.TaskPriorityDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule upgrade: %w", err)
+	}
+	return connect.NewResponse(&types.Int64Value{Value: opID}), nil
+}
+
+// repoUpgradeAssessment describes upgrading a repo of the version, to which the migrations can be applied, given its
+// last collected stats if there are any.
+func repoUpgradeAssessment(repoID string, version int, migrations []string, stats *v1.RepoStats) *v1.RepoUpgradeAssessment {
+	res := &v1.RepoUpgradeAssessment{
+		RepoId:           repoID,
+		Version:          int32(version),
+		Migrations:       migrations,
+		UpgradeAvailable: version < 2 && slices.Contains(migrations, tasks.DefaultMigration),
+		Stats:            stats,
+	}
+	// v1 repos store everything uncompressed, otherwise the stats tell how much of the data is compressed. Stats
+	// collected before the compression progress was recorded only have the compression ratio, which is 1 if nothing is
+	// compressed.
+	res.RecompressRecommended = version < 2 || (stats != nil && stats.TotalSize > 0 && stats.CompressionProgress < 100 &&
+		(stats.CompressionProgress > 0 || stats.CompressionRatio <= 1))
+
+	if res.UpgradeAvailable {
+		res.Benefits = append(res.Benefits,
+			"Data added to the repo is compressed, the repo grows more slowly and less data is uploaded by backups.",
+			"The v2 format stores the repo's metadata more compactly, indexes and snapshots load faster.",
+		)
+		res.Risks = append(res.Risks,
+			"restic versions older than 0.14.0 can't read the repo once it's upgraded, other machines using it must be updated first.",
+			"The upgrade can't be undone. restic checks the repo before upgrading it and refuses if it finds errors.",
+		)
+	}
+	if res.RecompressRecommended {
+		res.Benefits = append(res.Benefits, "Recompressing repacks the data stored uncompressed, which typically shrinks the repo considerably.")
+		res.Risks = append(res.Risks,
+			"Recompressing downloads and uploads all of the uncompressed data, this is slow and may be charged for by cloud storage providers.",
+			"The repo needs free space for the repacked data until the old packs are deleted.",
+			"An interrupted recompression is resumed when backrest next starts, the data already repacked is kept.",
+		)
+	}
+	return res
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+// ListRestorePoints implements POST /v1.Backrest/ListRestorePoints
+func (s *BackrestHandler) ListRestorePoints(ctx context.Context, req *connect.Request[v1.ListRestorePointsRequest]) (*connect.Response[v1.RestorePointList], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	loc := time.Local
+	if req.Msg.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(req.Msg.Timezone); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid timezone %q: %w", req.Msg.Timezone, err))
+		}
+	}
+
+	repoOrch, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+	var snapshots []*restic.Snapshot
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
+		plan, err := s.orchestrator.GetPlan(req.Msg.PlanId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get plan %q: %w", req.Msg.PlanId, err)
+		}
+		snapshots, err = repoOrch.SnapshotsForPlan(ctx, plan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+	} else if snapshots, err = repoOrch.Snapshots(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// snapshots of failed backups hold whatever was read before the backup failed, they are only chosen if there is no
+	// other snapshot for the period.
+	failed := make(map[string]bool)
+	if err := s.oplog.ForEachByRepo(req.Msg.RepoId, indexutil.CollectAll(), func(op *v1.Operation) error {
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); ok && op.SnapshotId != "" && op.Status == v1.OperationStatus_STATUS_ERROR {
+			failed[op.SnapshotId] = true
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get operations: %w", err)
+	}
+
+	var rs []*v1.ResticSnapshot
+	for _, snapshot := range snapshots {
+		rs = append(rs, protoutil.SnapshotToProto(snapshot))
+	}
+	week := req.Msg.Granularity == v1.ListRestorePointsRequest_GRANULARITY_WEEK
+	return connect.NewResponse(&v1.RestorePointList{
+		Points: restorePoints(rs, failed, week, loc),
+	}), nil
+}
+
+// restorePoints groups the snapshots by plan and by the day or week they were taken in loc and chooses the newest
+// snapshot of each group that is not from a failed backup. Restore points are returned newest first.
+func restorePoints(snapshots []*v1.ResticSnapshot, failed map[string]bool, week bool, loc *time.Location) []*v1.RestorePoint {
+	type key struct {
+		planID string
+		start  int64
+	}
+	points := make(map[key]*v1.RestorePoint)
+	for _, snapshot := range snapshots {
+		t := time.UnixMilli(snapshot.UnixTimeMs).In(loc)
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		if week {
+			// time.Weekday starts on Sunday, weeks start on Monday.
+			start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+		}
+
+		k := key{planID: repo.PlanFromTags(snapshot.Tags), start: start.UnixMilli()}
+		point, ok := points[k]
+		if !ok {
+			point = &v1.RestorePoint{
+				PlanId:        k.planID,
+				Label:         start.Format(time.DateOnly),
+				PeriodStartMs: k.start,
+				Partial:       true,
+			}
+			points[k] = point
+		}
+		point.SnapshotCount++
+
+		// a snapshot replaces the chosen one if it is from a successful backup and the chosen one isn't, or if both are
+		// equally good and it is newer.
+		partial := failed[snapshot.Id]
+		if point.Snapshot == nil || (point.Partial && !partial) || (point.Partial == partial && snapshot.UnixTimeMs > point.Snapshot.UnixTimeMs) {
+			point.Snapshot = snapshot
+			point.Partial = partial
+		}
+	}
+
+	var list []*v1.RestorePoint
+	for _, point := range points {
+		list = append(list, point)
+	}
+	slices.SortFunc(list, func(a, b *v1.RestorePoint) int {
+		if c := cmp.Compare(b.PeriodStartMs, a.PeriodStartMs); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PlanId, b.PlanId)
+	})
+	return list
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+)
+
+// PreviewRestore implements POST /v1.Backrest/PreviewRestore
+func (s *BackrestHandler) PreviewRestore(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestorePreview], error) {
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return nil, err
+	}
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
+	}
+	if req.Msg.SnapshotId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("snapshot_id is required"))
+	}
+	if req.Msg.Path == "" {
+		req.Msg.Path = "/"
+	}
+
+	target := "/"
+	if req.Msg.InPlace {
+		if namespaceFromContext(ctx) != "" {
+			return nil, connect.NewError(connect.CodePermissionDenied, errors.New("in place restores are not available to namespaced users"))
+		}
+	} else {
+		var err error
+		if target, err = restoreTarget(ctx, req.Msg, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo %q: %w", req.Msg.RepoId, err)
+	}
+	preview, err := repo.PreviewRestore(ctx, req.Msg.SnapshotId, req.Msg.Path, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview restore: %w", err)
+	}
+	if rate := s.restoreThroughput(req.Msg.RepoId); rate > 0 {
+		preview.EstimatedDurationMs = int64(float64(preview.TotalBytes) / rate * 1000)
+	}
+	return connect.NewResponse(preview), nil
+}
+
+// restoreThroughput returns the bytes per second restored by the repo's successful restores, 0 if there were none.
+func (s *BackrestHandler) restoreThroughput(repoID string) float64 {
+	var bytes int64
+	var seconds float64
+	s.oplog.ForEachByRepo(repoID, indexutil.CollectAll(), func(op *v1.Operation) error {
+		restore, ok := op.Op.(*v1.Operation_OperationRestore)
+		if !ok || op.Status != v1.OperationStatus_STATUS_SUCCESS {
+			return nil
+		}
+		if status := restore.OperationRestore.GetStatus(); status.GetSecondsElapsed() > 0 && status.GetBytesRestored() > 0 {
+			bytes += status.GetBytesRestored()
+			seconds += status.GetSecondsElapsed()
+		}
+		return nil
+	})
+	if seconds == 0 {
+		return 0
+	}
+	return float64(bytes) / seconds
+}
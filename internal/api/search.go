@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+	snippetContext     = 40 // bytes of context kept on either side of the match in a snippet.
+
+	defaultSnapshotSearchLimit = 1000
+	maxSnapshotSearchLimit     = 10000
+)
+
+// snapshotIDRegex matches full and short restic snapshot IDs.
+var snapshotIDRegex = regexp.MustCompile(`^[0-9a-f]{8,64}$`)
+
+// errSearchLimitReached stops a snapshot search once it has found the requested number of matches.
+var errSearchLimitReached = errors.New("search limit reached")
+
+// Search implements POST /v1.Backrest/Search
+func (s *BackrestHandler) Search(ctx context.Context, req *connect.Request[v1.SearchRequest]) (*connect.Response[v1.SearchResponse], error) {
+	query := strings.ToLower(strings.TrimSpace(req.Msg.Query))
+	if query == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("query is required"))
+	}
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	} else if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// operations are visited oldest first, only the newest matches are kept.
+	resp := &v1.SearchResponse{}
+	var results []*v1.SearchResult
+	if err := s.oplog.ForAll(func(op *v1.Operation) error {
+		if req.Msg.AfterMs != 0 && op.UnixTimeStartMs < req.Msg.AfterMs {
+			return nil
+		}
+		if req.Msg.BeforeMs != 0 && op.UnixTimeStartMs >= req.Msg.BeforeMs {
+			return nil
+		}
+		if !access.canAccessOperation(op) {
+			return nil
+		}
+		result := matchOperation(op, query)
+		if result == nil {
+			return nil
+		}
+		results = append(results, result)
+		if len(results) > limit {
+			results = results[1:]
+			resp.Truncated = true
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to search operations: %w", err)
+	}
+
+	for i := len(results) - 1; i >= 0; i-- {
+		resp.Results = append(resp.Results, results[i])
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// SearchSnapshots implements POST /v1.Backrest/SearchSnapshots
+func (s *BackrestHandler) SearchSnapshots(ctx context.Context, req *connect.Request[v1.SearchSnapshotsRequest], resp *connect.ServerStream[v1.SnapshotFileMatch]) error {
+	if strings.TrimSpace(req.Msg.Pattern) == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("pattern is required"))
+	}
+	if strings.HasPrefix(req.Msg.Pattern, "-") {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("pattern %q must not start with \"-\"", req.Msg.Pattern))
+	}
+	for _, id := range req.Msg.SnapshotIds {
+		if !snapshotIDRegex.MatchString(id) {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid snapshot ID %q", id))
+		}
+	}
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultSnapshotSearchLimit
+	} else if limit > maxSnapshotSearchLimit {
+		limit = maxSnapshotSearchLimit
+	}
+
+	if err := s.checkRepoAccess(ctx, req.Msg.RepoId); err != nil {
+		return err
+	}
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return err
+		}
+	}
+	repo, err := s.orchestrator.GetRepoOrchestrator(req.Msg.RepoId)
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	// each search streams its own results so searches aren't coalesced, they only take one of the repo's backend slots.
+	release, err := s.backend.acquire(req.Msg.RepoId)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var found int
+	err = repo.Find(ctx, req.Msg.Pattern, req.Msg.SnapshotIds, req.Msg.PlanId, req.Msg.IgnoreCase, func(snapshotID string, entries []*v1.LsEntry) error {
+		for _, entry := range entries {
+			if err := resp.Send(&v1.SnapshotFileMatch{SnapshotId: snapshotID, Entry: entry}); err != nil {
+				return err
+			}
+			if found++; found >= limit {
+				return errSearchLimitReached
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errSearchLimitReached) {
+		return fmt.Errorf("failed to search snapshots: %w", err)
+	}
+	return nil
+}
+
+// matchOperation returns a result for the first field of the operation that contains the lower case query, or nil if none do.
+func matchOperation(op *v1.Operation, query string) *v1.SearchResult {
+	kind := v1.SearchResult_KIND_OPERATION
+	type field struct {
+		name  string
+		value string
+	}
+	fields := []field{
+		{"snapshot_id", op.SnapshotId},
+		{"error", op.DisplayMessage},
+		{"note", op.GetNote().GetText()},
+	}
+	switch o := op.Op.(type) {
+	case *v1.Operation_OperationIndexSnapshot:
+		kind = v1.SearchResult_KIND_SNAPSHOT
+		snapshot := o.OperationIndexSnapshot.GetSnapshot()
+		fields = append(fields, field{"snapshot_id", snapshot.GetId()})
+		for _, path := range snapshot.GetPaths() {
+			fields = append(fields, field{"path", path})
+		}
+		for _, tag := range snapshot.GetTags() {
+			fields = append(fields, field{"tag", tag})
+		}
+	case *v1.Operation_OperationBackup:
+		for _, a := range o.OperationBackup.Annotations {
+			fields = append(fields, field{"annotation", a.Key + "=" + a.Value})
+		}
+		for _, e := range o.OperationBackup.Errors {
+			fields = append(fields, field{"path", e.Item}, field{"error", e.Message})
+		}
+	case *v1.Operation_OperationRestore:
+		fields = append(fields, field{"path", o.OperationRestore.Path}, field{"path", o.OperationRestore.Target})
+	}
+
+	for _, f := range fields {
+		idx := strings.Index(strings.ToLower(f.value), query)
+		if idx == -1 {
+			continue
+		}
+		return &v1.SearchResult{
+			Kind:      kind,
+			Operation: op,
+			Field:     f.name,
+			Snippet:   snippet(f.value, idx, len(query)),
+			Link:      searchResultLink(op),
+		}
+	}
+	return nil
+}
+
+// snippet returns the text around the match at [idx, idx+n), eliding the rest of long text.
+func snippet(text string, idx, n int) string {
+	if len(strings.ToLower(text)) != len(text) {
+		// lower casing changed byte offsets, there's no reliable position for the match.
+		idx, n = 0, 0
+	}
+	start := max(0, idx-snippetContext)
+	end := min(len(text), idx+n+snippetContext)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("...")
+	}
+	sb.WriteString(text[start:end])
+	if end < len(text) {
+		sb.WriteString("...")
+	}
+	return sb.String()
+}
+
+// searchResultLink returns a link to the view of the operation's plan, or its repo if it is not associated with a plan.
+func searchResultLink(op *v1.Operation) string {
+	if op.PlanId != "" && op.PlanId != tasks.PlanForUnassociatedOperations {
+		return fmt.Sprintf("#/plan/%s?op=%d", url.PathEscape(op.PlanId), op.Id)
+	}
+	return fmt.Sprintf("#/repo/%s?op=%d", url.PathEscape(op.RepoId), op.Id)
+}
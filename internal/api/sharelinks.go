@@ -0,0 +1,403 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/auth"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/orchestrator"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultShareLinkTTL          = 7 * 24 * time.Hour
+	maxShareLinkTTL              = 30 * 24 * time.Hour
+	minShareLinkPassphrase       = 8
+	maxShareLinkFailedAttempts   = 10                  // wrong passphrases after which a link is revoked.
+	shareLinkRetention           = 30 * 24 * time.Hour // how long links are listed after they expired or were revoked.
+	shareLinkTokenBytes          = 32
+	maxShareLinkPassphraseLength = 72 // bcrypt only uses the first 72 bytes.
+)
+
+// CreateShareLink implements POST /v1.Backrest/CreateShareLink
+func (s *BackrestHandler) CreateShareLink(ctx context.Context, req *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error) {
+	op, err := s.oplog.Get(req.Msg.RestoreOpId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operation %v: %w", req.Msg.RestoreOpId, err)
+	}
+	if err := s.checkOperationAccess(ctx, op); err != nil {
+		return nil, err
+	}
+	if _, err := sharedRestorePath(op, req.Msg.Path); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if len(req.Msg.Passphrase) < minShareLinkPassphrase || len(req.Msg.Passphrase) > maxShareLinkPassphraseLength {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("passphrase must be %d to %d characters", minShareLinkPassphrase, maxShareLinkPassphraseLength))
+	}
+	ttl := time.Duration(req.Msg.TtlSeconds) * time.Second
+	if ttl == 0 {
+		ttl = defaultShareLinkTTL
+	} else if ttl < 0 || ttl > maxShareLinkTTL {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ttl must be positive and at most %v", maxShareLinkTTL))
+	}
+	if req.Msg.MaxDownloads < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max downloads must not be negative"))
+	}
+
+	passphrase, err := auth.CreatePassword(req.Msg.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash passphrase: %w", err)
+	}
+	token := make([]byte, shareLinkTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	tokenHash := sha256.Sum256(token)
+
+	now := time.Now()
+	link := &v1.ShareLink{
+		RestoreOpId:      op.Id,
+		RepoId:           op.RepoId,
+		PlanId:           op.PlanId,
+		Path:             filepath.ToSlash(filepath.Clean("/" + req.Msg.Path))[1:],
+		CreatedMs:        now.UnixMilli(),
+		ExpiresMs:        now.Add(ttl).UnixMilli(),
+		MaxDownloads:     req.Msg.MaxDownloads,
+		TokenHash:        tokenHash[:],
+		PassphraseBcrypt: passphrase,
+	}
+	if user, ok := ctx.Value(auth.UserContextKey).(*v1.User); ok {
+		link.CreatedBy = user.Name
+	}
+	if err := s.oplog.AddShareLink(link); err != nil {
+		return nil, fmt.Errorf("failed to add share link: %w", err)
+	}
+	zap.L().Info("created share link", zap.Int64("link", link.Id), zap.Int64("restore", op.Id), zap.String("path", link.Path), zap.String("created_by", link.CreatedBy))
+
+	return connect.NewResponse(&v1.CreateShareLinkResponse{
+		Link: withoutShareLinkSecrets(link),
+		Url:  fmt.Sprintf("./share/%x-%s", link.Id, hex.EncodeToString(token)),
+	}), nil
+}
+
+// GetShareLinks implements POST /v1.Backrest/GetShareLinks
+func (s *BackrestHandler) GetShareLinks(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ShareLinkList], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.oplog.GetShareLinks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+
+	now := time.Now()
+	var expired []int64
+	list := &v1.ShareLinkList{}
+	for _, link := range links {
+		if ended := shareLinkEnded(link); ended.Add(shareLinkRetention).Before(now) {
+			expired = append(expired, link.Id)
+			continue
+		}
+		if access.canAccessRepo(link.RepoId) {
+			list.Links = append(list.Links, withoutShareLinkSecrets(link))
+		}
+	}
+	if len(expired) > 0 {
+		if err := s.oplog.DeleteShareLinks(expired...); err != nil {
+			zap.L().Warn("failed to delete expired share links", zap.Error(err))
+		}
+	}
+	slices.Reverse(list.Links)
+	return connect.NewResponse(list), nil
+}
+
+// RevokeShareLink implements POST /v1.Backrest/RevokeShareLink
+func (s *BackrestHandler) RevokeShareLink(ctx context.Context, req *connect.Request[types.Int64Value]) (*connect.Response[v1.ShareLink], error) {
+	access, err := s.namespaceAccess(ctx)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.oplog.UpdateShareLink(req.Msg.Value, func(link *v1.ShareLink) error {
+		if !access.canAccessRepo(link.RepoId) {
+			return oplog.ErrShareLinkNotFound
+		}
+		if link.RevokedMs == 0 {
+			link.RevokedMs = time.Now().UnixMilli()
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, oplog.ErrShareLinkNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	zap.L().Info("revoked share link", zap.Int64("link", link.Id))
+	return connect.NewResponse(withoutShareLinkSecrets(link)), nil
+}
+
+// withoutShareLinkSecrets returns a copy of the link without its token and passphrase hashes.
+func withoutShareLinkSecrets(link *v1.ShareLink) *v1.ShareLink {
+	link = proto.Clone(link).(*v1.ShareLink)
+	link.TokenHash = nil
+	link.PassphraseBcrypt = ""
+	return link
+}
+
+// shareLinkEnded returns the time the link was revoked or expired at, whichever is first.
+func shareLinkEnded(link *v1.ShareLink) time.Time {
+	if link.RevokedMs != 0 && link.RevokedMs < link.ExpiresMs {
+		return time.UnixMilli(link.RevokedMs)
+	}
+	return time.UnixMilli(link.ExpiresMs)
+}
+
+// shareLinkInactive returns why the link can't be used, or "" if it can.
+func shareLinkInactive(link *v1.ShareLink, now time.Time) string {
+	switch {
+	case link.RevokedMs != 0:
+		return "This link was revoked."
+	case now.UnixMilli() >= link.ExpiresMs:
+		return "This link has expired."
+	case link.MaxDownloads > 0 && link.Downloads >= link.MaxDownloads:
+		return "This link has reached its download limit."
+	}
+	return ""
+}
+
+// sharedRestorePath returns the directory or regular file at dirPath, relative to the target of the restore operation,
+// checking that it's part of a completed restore that can be shared.
+func sharedRestorePath(op *v1.Operation, dirPath string) (string, error) {
+	restoreOp := op.GetOperationRestore()
+	if restoreOp == nil {
+		return "", fmt.Errorf("operation %v is not a restore operation", op.Id)
+	}
+	if restoreOp.InPlace || restoreOp.Target == "" {
+		return "", fmt.Errorf("operation %v restored files to their original location, there is nothing to share", op.Id)
+	}
+	if op.Status != v1.OperationStatus_STATUS_SUCCESS {
+		return "", fmt.Errorf("restore %v didn't complete successfully", op.Id)
+	}
+	if dirPath != "" && !filepath.IsLocal(filepath.FromSlash(dirPath)) {
+		return "", fmt.Errorf("path %q must be relative to the restore's target", dirPath)
+	}
+	target, err := filepath.EvalSymlinks(restoreOp.Target)
+	if err != nil {
+		return "", fmt.Errorf("resolve restore target: %w", err)
+	}
+	dir := filepath.Join(target, filepath.FromSlash(dirPath))
+	// the path mustn't go through a restored symlink, it could point outside of the restore.
+	if resolved, err := filepath.EvalSymlinks(dir); err != nil {
+		return "", fmt.Errorf("resolve restored path: %w", err)
+	} else if resolved != dir {
+		return "", fmt.Errorf("%q is a symlink", dirPath)
+	}
+	if info, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("stat restored path: %w", err)
+	} else if !info.IsDir() && !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%q is not a directory or regular file", dirPath)
+	}
+	return dir, nil
+}
+
+// NewShareHandler serves the share links created by CreateShareLink. Guests are asked for the link's passphrase, entering
+// it downloads the shared directory as a gzip compressed tar archive, or the shared file as is.
+func NewShareHandler(log *oplog.OpLog, orchestrator *orchestrator.Orchestrator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the URL holds the link's token, it mustn't be cached or sent on to other sites.
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		link, ok := shareLinkFromPath(log, r.URL.Path[1:])
+		if !ok {
+			renderSharePage(w, http.StatusNotFound, sharePage{Message: "This link is invalid."})
+			return
+		}
+		page := sharePage{
+			Name:    path.Base("/" + link.Path),
+			Expires: time.UnixMilli(link.ExpiresMs).Format(time.RFC1123),
+		}
+		if link.Path == "" {
+			page.Name = "restored files"
+		}
+		if link.MaxDownloads > 0 {
+			page.DownloadsLeft = strconv.Itoa(int(link.MaxDownloads - link.Downloads))
+		}
+		if reason := shareLinkInactive(link, time.Now()); reason != "" {
+			renderSharePage(w, http.StatusGone, sharePage{Message: reason})
+			return
+		}
+		page.File = sharesFile(log, link)
+
+		switch r.Method {
+		case http.MethodGet:
+			page.Form = true
+			renderSharePage(w, http.StatusOK, page)
+			return
+		case http.MethodPost:
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, 4096)
+		passphrase := r.PostFormValue("passphrase")
+		hash, err := base64.StdEncoding.DecodeString(link.PassphraseBcrypt)
+		if err != nil || bcrypt.CompareHashAndPassword(hash, []byte(passphrase)) != nil {
+			if _, err := log.UpdateShareLink(link.Id, func(link *v1.ShareLink) error {
+				link.FailedAttempts++
+				if link.FailedAttempts >= maxShareLinkFailedAttempts && link.RevokedMs == 0 {
+					link.RevokedMs = time.Now().UnixMilli()
+					zap.L().Warn("revoked share link after too many wrong passphrases", zap.Int64("link", link.Id))
+				}
+				return nil
+			}); err != nil {
+				zap.L().Error("failed to record wrong passphrase for share link", zap.Int64("link", link.Id), zap.Error(err))
+			}
+			page.Form = true
+			page.Message = "Wrong passphrase."
+			renderSharePage(w, http.StatusForbidden, page)
+			return
+		}
+
+		op, err := log.Get(link.RestoreOpId)
+		if err != nil {
+			renderSharePage(w, http.StatusGone, sharePage{Message: "The shared files are no longer available."})
+			return
+		}
+		if _, err := sharedRestorePath(op, link.Path); err != nil {
+			zap.L().Warn("shared restore is no longer available", zap.Int64("link", link.Id), zap.Error(err))
+			renderSharePage(w, http.StatusGone, sharePage{Message: "The shared files are no longer available."})
+			return
+		}
+
+		// the download is counted before it starts so that concurrent downloads can't exceed the limit.
+		errInactive := errors.New("inactive")
+		if _, err := log.UpdateShareLink(link.Id, func(link *v1.ShareLink) error {
+			if page.Message = shareLinkInactive(link, time.Now()); page.Message != "" {
+				return errInactive
+			}
+			link.Downloads++
+			link.LastDownloadMs = time.Now().UnixMilli()
+			return nil
+		}); err != nil {
+			if !errors.Is(err, errInactive) {
+				zap.L().Error("failed to record share link download", zap.Int64("link", link.Id), zap.Error(err))
+				page.Message = "The download failed, please try again."
+			}
+			renderSharePage(w, http.StatusGone, sharePage{Message: page.Message})
+			return
+		}
+
+		zap.L().Info("downloading shared restore", zap.Int64("link", link.Id), zap.String("path", link.Path))
+		// guests are less likely to have zstd, gzip archives open everywhere. Symlinks could point outside of the shared
+		// directory, they're left out.
+		serveRestoreDownload(w, r, log, orchestrator, op, filepath.FromSlash(link.Path), compressionGzip, false)
+	})
+}
+
+// sharesFile returns true if the link shares a single restored file rather than a directory.
+func sharesFile(log *oplog.OpLog, link *v1.ShareLink) bool {
+	op, err := log.Get(link.RestoreOpId)
+	if err != nil {
+		return false
+	}
+	p, err := sharedRestorePath(op, link.Path)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(p)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// shareLinkFromPath returns the link for a path of the form <link id>-<token>, or false if there is none or the token is wrong.
+func shareLinkFromPath(log *oplog.OpLog, p string) (*v1.ShareLink, bool) {
+	idStr, token, ok := strings.Cut(strings.TrimSuffix(p, "/"), "-")
+	if !ok {
+		return nil, false
+	}
+	id, err := strconv.ParseInt(idStr, 16, 64)
+	if err != nil {
+		return nil, false
+	}
+	tokenBytes, err := hex.DecodeString(token)
+	if err != nil {
+		return nil, false
+	}
+	link, err := log.GetShareLink(id)
+	if err != nil {
+		if !errors.Is(err, oplog.ErrShareLinkNotFound) {
+			zap.L().Error("failed to get share link", zap.Int64("link", id), zap.Error(err))
+		}
+		return nil, false
+	}
+	tokenHash := sha256.Sum256(tokenBytes)
+	if subtle.ConstantTimeCompare(tokenHash[:], link.TokenHash) != 1 {
+		return nil, false
+	}
+	return link, true
+}
+
+type sharePage struct {
+	Name          string
+	Expires       string
+	DownloadsLeft string
+	Message       string
+	Form          bool
+	File          bool // the link shares a file, it's downloaded as is rather than as an archive.
+}
+
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Backrest shared files</title>
+<style>body { font-family: sans-serif; max-width: 32em; margin: 4em auto; padding: 0 1em; }</style>
+</head>
+<body>
+<h2>Backrest shared files</h2>
+{{ if .Message }}<p><strong>{{ .Message }}</strong></p>{{ end }}
+{{ if .Form }}
+<p>Enter the passphrase you were given to download <em>{{ .Name }}</em>{{ if not .File }} as a .tar.gz archive{{ end }}.</p>
+<form method="post">
+<input type="password" name="passphrase" autocomplete="off" autofocus required>
+<button type="submit">Download</button>
+</form>
+<p><small>This link expires {{ .Expires }}.{{ if .DownloadsLeft }} Downloads left: {{ .DownloadsLeft }}.{{ end }}</small></p>
+{{ end }}
+</body>
+</html>
+`))
+
+func renderSharePage(w http.ResponseWriter, status int, page sharePage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := sharePageTemplate.Execute(w, page); err != nil {
+		zap.L().Warn("failed to render share page", zap.Error(err))
+	}
+}
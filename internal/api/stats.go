@@ -0,0 +1,158 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+)
+
+// defaultStatsWindow is how far back GetStats aggregates backups by default.
+const defaultStatsWindow = 30 * 24 * time.Hour
+
+// GetStats implements POST /v1.Backrest/GetStats
+func (s *BackrestHandler) GetStats(ctx context.Context, req *connect.Request[v1.GetStatsRequest]) (*connect.Response[v1.PlanStatsList], error) {
+	loc := time.Local
+	if req.Msg.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(req.Msg.Timezone); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid timezone %q: %w", req.Msg.Timezone, err))
+		}
+	}
+	after := req.Msg.AfterMs
+	if after == 0 {
+		after = time.Now().Add(-defaultStatsWindow).UnixMilli()
+	}
+
+	// plans are looked up in the config rather than the orchestrator, a read replica serves the stats of its primary's
+	// plans without scheduling them.
+	cfg, err := s.config.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	var plans []*v1.Plan
+	if req.Msg.PlanId != "" {
+		if err := s.checkPlanAccess(ctx, req.Msg.PlanId); err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(cfg.Plans, func(p *v1.Plan) bool { return p.Id == req.Msg.PlanId })
+		if idx < 0 {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plan %q not found", req.Msg.PlanId))
+		}
+		plans = append(plans, cfg.Plans[idx])
+	} else {
+		access, err := s.namespaceAccess(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, plan := range cfg.Plans {
+			if access.canAccessRepo(plan.Repo) {
+				plans = append(plans, plan)
+			}
+		}
+	}
+
+	res := &v1.PlanStatsList{}
+	for _, plan := range plans {
+		agg := newPlanStatsAggregator(plan, after, loc)
+		if err := s.oplog.ForEachByPlan(plan.Id, indexutil.CollectAll(), func(op *v1.Operation) error {
+			agg.add(op)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to get operations of plan %q: %w", plan.Id, err)
+		}
+		res.Plans = append(res.Plans, agg.stats())
+	}
+	return connect.NewResponse(res), nil
+}
+
+// planStatsAggregator aggregates the statistics of a plan's backups one operation at a time.
+type planStatsAggregator struct {
+	after     int64
+	loc       *time.Location
+	res       *v1.PlanStats
+	durations []int64
+	days      map[int64]*v1.PlanStats_Day
+}
+
+func newPlanStatsAggregator(plan *v1.Plan, after int64, loc *time.Location) *planStatsAggregator {
+	return &planStatsAggregator{
+		after: after,
+		loc:   loc,
+		res:   &v1.PlanStats{PlanId: plan.Id, RepoId: plan.Repo},
+		days:  make(map[int64]*v1.PlanStats_Day),
+	}
+}
+
+func (a *planStatsAggregator) add(op *v1.Operation) {
+	backup, ok := op.Op.(*v1.Operation_OperationBackup)
+	if !ok {
+		return
+	}
+	succeeded := op.Status == v1.OperationStatus_STATUS_SUCCESS || op.Status == v1.OperationStatus_STATUS_WARNING
+	if succeeded && op.UnixTimeEndMs > a.res.LastSuccessMs {
+		a.res.LastSuccessMs = op.UnixTimeEndMs
+	}
+	if op.UnixTimeStartMs < a.after {
+		return
+	}
+
+	switch op.Status {
+	case v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_WARNING:
+		a.res.SuccessCount++
+		if op.Status == v1.OperationStatus_STATUS_WARNING {
+			a.res.WarningCount++
+		}
+	case v1.OperationStatus_STATUS_ERROR:
+		a.res.FailureCount++
+	case v1.OperationStatus_STATUS_USER_CANCELLED, v1.OperationStatus_STATUS_SYSTEM_CANCELLED:
+		a.res.CancelledCount++
+	default:
+		return // pending, running or skipped backups.
+	}
+
+	t := time.UnixMilli(op.UnixTimeStartMs).In(a.loc)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, a.loc).UnixMilli()
+	day, ok := a.days[start]
+	if !ok {
+		day = &v1.PlanStats_Day{UnixTimeMs: start}
+		a.days[start] = day
+	}
+	day.Backups++
+
+	if !succeeded {
+		return
+	}
+	added := backup.OperationBackup.GetLastStatus().GetSummary().GetDataAdded()
+	day.BytesAdded += added
+	a.res.BytesAdded += added
+	if op.UnixTimeEndMs >= op.UnixTimeStartMs {
+		a.durations = append(a.durations, op.UnixTimeEndMs-op.UnixTimeStartMs)
+	}
+}
+
+func (a *planStatsAggregator) stats() *v1.PlanStats {
+	for _, day := range a.days {
+		a.res.Days = append(a.res.Days, day)
+	}
+	slices.SortFunc(a.res.Days, func(x, y *v1.PlanStats_Day) int {
+		return cmp.Compare(x.UnixTimeMs, y.UnixTimeMs)
+	})
+
+	if len(a.durations) > 0 {
+		slices.Sort(a.durations)
+		var total int64
+		for _, d := range a.durations {
+			total += d
+		}
+		a.res.AvgDurationMs = total / int64(len(a.durations))
+		// the nearest rank, the smallest duration that at least 95% of the durations are less than or equal to.
+		a.res.P95DurationMs = a.durations[(len(a.durations)*95+99)/100-1]
+	}
+	return a.res
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	"go.uber.org/zap"
+)
+
+// NewSnapshotWebhookHandler returns a handler for POST /<repo id> that indexes the repo's snapshots e.g. when a
+// rest-server or another tool reports that a snapshot was created. Snapshots found that backrest didn't create run the
+// repo's CONDITION_SNAPSHOT_EXTERNAL hooks. The request body is ignored.
+func NewSnapshotWebhookHandler(s *BackrestHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		repoID := strings.TrimPrefix(r.URL.Path, "/")
+		if repoID == "" || strings.Contains(repoID, "/") {
+			http.Error(w, "invalid path", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := s.IndexSnapshots(r.Context(), connect.NewRequest(&types.StringValue{Value: repoID})); err != nil {
+			zap.S().Warnf("snapshot webhook for repo %q: %v", repoID, err)
+			http.Error(w, "repo not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
@@ -22,22 +23,60 @@ var (
 	}
 )
 
+const (
+	// maxFailedLogins is the number of consecutive failed logins after which a username is locked out.
+	maxFailedLogins = 5
+	// loginLockoutDuration is how long a username is locked out for, attempts during the lockout are rejected without
+	// checking the password.
+	loginLockoutDuration = 5 * time.Minute
+)
+
 type Authenticator struct {
 	config config.ConfigStore
 	key    []byte
+
+	mu       sync.Mutex
+	failures map[string]*failedLogins
+
+	now func() time.Time // overridden for testing.
+}
+
+type failedLogins struct {
+	count       int
+	lockedUntil time.Time
 }
 
 func NewAuthenticator(key []byte, config config.ConfigStore) *Authenticator {
 	return &Authenticator{
-		config: config,
-		key:    key,
+		config:   config,
+		key:      key,
+		failures: make(map[string]*failedLogins),
+		now:      time.Now,
 	}
 }
 
 var ErrUserNotFound = errors.New("user not found")
 var ErrInvalidPassword = errors.New("invalid password")
+var ErrTooManyAttempts = errors.New("too many failed login attempts, try again later")
 
 func (a *Authenticator) Login(username, password string) (*v1.User, error) {
+	if a.isLockedOut(username) {
+		return nil, ErrTooManyAttempts
+	}
+
+	user, err := a.login(username, password)
+	if errors.Is(err, ErrUserNotFound) || errors.Is(err, ErrInvalidPassword) {
+		// unknown usernames are tracked too so that lockouts don't reveal which users exist.
+		a.recordFailure(username)
+	} else if err == nil {
+		a.mu.Lock()
+		delete(a.failures, username)
+		a.mu.Unlock()
+	}
+	return user, err
+}
+
+func (a *Authenticator) login(username, password string) (*v1.User, error) {
 	config, err := a.config.Get()
 	if err != nil {
 		return nil, fmt.Errorf("get config: %w", err)
@@ -62,6 +101,28 @@ func (a *Authenticator) Login(username, password string) (*v1.User, error) {
 	return nil, ErrUserNotFound
 }
 
+func (a *Authenticator) isLockedOut(username string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f, ok := a.failures[username]
+	return ok && a.now().Before(f.lockedUntil)
+}
+
+func (a *Authenticator) recordFailure(username string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f, ok := a.failures[username]
+	if !ok {
+		f = &failedLogins{}
+		a.failures[username] = f
+	}
+	f.count++
+	if f.count >= maxFailedLogins {
+		f.count = 0
+		f.lockedUntil = a.now().Add(loginLockoutDuration)
+	}
+}
+
 func (a *Authenticator) VerifyJWT(token string) (*v1.User, error) {
 	config, err := a.config.Get()
 	if err != nil {
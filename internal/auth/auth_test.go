@@ -3,6 +3,7 @@ package auth
 import (
 	"errors"
 	"testing"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/config"
@@ -60,6 +61,42 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestLoginLockout(t *testing.T) {
+	config := &config.MemoryStore{
+		Config: &v1.Config{
+			Auth: &v1.Auth{
+				Users: []*v1.User{
+					{
+						Name: "test",
+						Password: &v1.User_PasswordBcrypt{
+							PasswordBcrypt: makePass(t, "testPass"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	auth := NewAuthenticator([]byte("key"), config)
+	now := time.Now()
+	auth.now = func() time.Time { return now }
+
+	for i := 0; i < maxFailedLogins; i++ {
+		if _, err := auth.Login("test", "wrong"); !errors.Is(err, ErrInvalidPassword) {
+			t.Fatalf("attempt %d: expected invalid password, got %v", i, err)
+		}
+	}
+
+	if _, err := auth.Login("test", "testPass"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("expected lockout after %d failures, got %v", maxFailedLogins, err)
+	}
+
+	now = now.Add(loginLockoutDuration)
+	if _, err := auth.Login("test", "testPass"); err != nil {
+		t.Fatalf("expected login to succeed after lockout expires, got %v", err)
+	}
+}
+
 func makePass(t *testing.T, pass string) string {
 	p, err := CreatePassword(pass)
 	if err != nil {
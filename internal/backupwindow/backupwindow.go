@@ -0,0 +1,119 @@
+package backupwindow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+// maxSearch bounds the search for the next time a backup window allows operations to run.
+// Every window repeats on a weekly cycle so a little over a week is always sufficient.
+const maxSearch = 8 * 24 * time.Hour
+
+// Validate verifies that the time ranges in the window are well formed.
+func Validate(w *v1.BackupWindow) error {
+	for _, ranges := range [][]*v1.BackupWindow_TimeRange{w.GetAllowed(), w.GetBlackouts()} {
+		for _, r := range ranges {
+			if _, err := parseTimeOfDay(r.Start); err != nil {
+				return fmt.Errorf("start %q: %w", r.Start, err)
+			}
+			if _, err := parseTimeOfDay(r.End); err != nil {
+				return fmt.Errorf("end %q: %w", r.End, err)
+			}
+			for _, d := range r.DaysOfWeek {
+				if d < 0 || d > 6 {
+					return fmt.Errorf("day of week %d out of range, must be 0 (Sunday) to 6 (Saturday)", d)
+				}
+			}
+		}
+	}
+	if !Allows(w, time.Now()) && NextStart(w, time.Now()).IsZero() {
+		return errors.New("window never allows operations to run")
+	}
+	return nil
+}
+
+// Allows returns true if the window allows operations to run at time t. A nil window always allows operations.
+func Allows(w *v1.BackupWindow, t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	for _, r := range w.Blackouts {
+		if timeRangeContains(r, t) {
+			return false
+		}
+	}
+	if len(w.Allowed) == 0 {
+		return true
+	}
+	for _, r := range w.Allowed {
+		if timeRangeContains(r, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns the earliest time after t at which the window allows operations to run,
+// or the zero time if the window never allows operations.
+func NextStart(w *v1.BackupWindow, t time.Time) time.Time {
+	candidate := t.Truncate(time.Minute).Add(time.Minute)
+	for end := t.Add(maxSearch); candidate.Before(end); candidate = candidate.Add(time.Minute) {
+		if Allows(w, candidate) {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
+
+// NextEnd returns the earliest time after t at which the window stops allowing operations to run,
+// or the zero time if the window always allows operations.
+func NextEnd(w *v1.BackupWindow, t time.Time) time.Time {
+	if w == nil {
+		return time.Time{}
+	}
+	candidate := t.Truncate(time.Minute).Add(time.Minute)
+	for end := t.Add(maxSearch); candidate.Before(end); candidate = candidate.Add(time.Minute) {
+		if !Allows(w, candidate) {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
+
+func timeRangeContains(r *v1.BackupWindow_TimeRange, t time.Time) bool {
+	start, err := parseTimeOfDay(r.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseTimeOfDay(r.End)
+	if err != nil {
+		return false
+	}
+
+	tod := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	today := int32(t.Weekday())
+	if start < end {
+		return onDay(r, today) && tod >= start && tod < end
+	}
+
+	// the range wraps past midnight, it may have started today or yesterday.
+	yesterday := (today + 6) % 7
+	return (onDay(r, today) && tod >= start) || (onDay(r, yesterday) && tod < end)
+}
+
+func onDay(r *v1.BackupWindow_TimeRange, day int32) bool {
+	return len(r.DaysOfWeek) == 0 || slices.Contains(r.DaysOfWeek, day)
+}
+
+// parseTimeOfDay parses a 24 hour HH:MM time and returns the offset from midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.New("must be a 24 hour time in HH:MM format")
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,87 @@
+package backupwindow
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+func TestAllows(t *testing.T) {
+	// 2024-01-01 is a Monday.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, 1, day, hour, min, 0, 0, time.Local)
+	}
+
+	nightly := &v1.BackupWindow{
+		Allowed: []*v1.BackupWindow_TimeRange{{Start: "22:00", End: "06:00"}},
+	}
+	notDuringWorkHours := &v1.BackupWindow{
+		Blackouts: []*v1.BackupWindow_TimeRange{{Start: "09:00", End: "17:00", DaysOfWeek: []int32{1, 2, 3, 4, 5}}},
+	}
+
+	tests := []struct {
+		name   string
+		window *v1.BackupWindow
+		time   time.Time
+		want   bool
+	}{
+		{name: "nil window", window: nil, time: at(1, 12, 0), want: true},
+		{name: "within wrapping range before midnight", window: nightly, time: at(1, 23, 0), want: true},
+		{name: "within wrapping range after midnight", window: nightly, time: at(2, 5, 59), want: true},
+		{name: "at end of wrapping range", window: nightly, time: at(2, 6, 0), want: false},
+		{name: "outside of allowed range", window: nightly, time: at(1, 12, 0), want: false},
+		{name: "blackout on weekday", window: notDuringWorkHours, time: at(1, 10, 0), want: false},
+		{name: "blackout does not apply on weekend", window: notDuringWorkHours, time: at(6, 10, 0), want: true},
+		{name: "outside of blackout on weekday", window: notDuringWorkHours, time: at(1, 17, 0), want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Allows(tc.window, tc.time); got != tc.want {
+				t.Errorf("Allows() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNextStart(t *testing.T) {
+	window := &v1.BackupWindow{
+		Allowed: []*v1.BackupWindow_TimeRange{{Start: "01:00", End: "06:00"}},
+	}
+
+	got := NextStart(window, time.Date(2024, 1, 1, 12, 30, 15, 0, time.Local))
+	want := time.Date(2024, 1, 2, 1, 0, 0, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("NextStart() = %v, want %v", got, want)
+	}
+
+	never := &v1.BackupWindow{
+		Blackouts: []*v1.BackupWindow_TimeRange{{Start: "00:00", End: "00:00"}},
+	}
+	if got := NextStart(never, time.Now()); !got.IsZero() {
+		t.Errorf("NextStart() = %v, want zero time for a window that never allows operations", got)
+	}
+}
+
+func TestNextEnd(t *testing.T) {
+	window := &v1.BackupWindow{
+		Allowed: []*v1.BackupWindow_TimeRange{{Start: "22:00", End: "06:00"}},
+	}
+
+	got := NextEnd(window, time.Date(2024, 1, 1, 23, 30, 15, 0, time.Local))
+	want := time.Date(2024, 1, 2, 6, 0, 0, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("NextEnd() = %v, want %v", got, want)
+	}
+
+	if got := NextEnd(nil, time.Now()); !got.IsZero() {
+		t.Errorf("NextEnd() = %v, want zero time for a nil window", got)
+	}
+	always := &v1.BackupWindow{
+		Blackouts: []*v1.BackupWindow_TimeRange{},
+	}
+	if got := NextEnd(always, time.Now()); !got.IsZero() {
+		t.Errorf("NextEnd() = %v, want zero time for a window that always allows operations", got)
+	}
+}
@@ -0,0 +1,20 @@
+// Package buildinfo describes the running backrest binary.
+package buildinfo
+
+import "runtime/debug"
+
+// Version is the backrest release version, set at build time with
+// -ldflags "-X github.com/garethgeorge/backrest/internal/buildinfo.Version=<version>".
+var Version = ""
+
+func init() {
+	if Version != "" {
+		return
+	}
+	// binaries built with `go install` carry the module version.
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		Version = info.Main.Version
+		return
+	}
+	Version = "dev-snapshot-build"
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/google/shlex"
+	"github.com/hashicorp/go-multierror"
+)
+
+// backends are the restic backends selected by a "<backend>:" prefix of the repo URI, other URIs are local paths.
+var backends = []string{"sftp", "rest", "s3", "swift", "b2", "azure", "gs", "rclone"}
+
+var (
+	optionNameRegex = regexp.MustCompile(`^([a-z0-9]+)\.[a-z0-9-]+$`)
+	envNameRegex    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// reservedEnv are set by backrest from the repo's config and may not be overridden by backend env vars.
+var reservedEnv = []string{"RESTIC_PASSWORD", "RESTIC_PASSWORD_FILE", "RESTIC_PASSWORD_COMMAND", "RESTIC_REPOSITORY", "RESTIC_REPOSITORY_FILE"}
+
+// repoBackend returns the restic backend of a repo URI e.g. "sftp" for "sftp:user@host:/srv/restic", "local" for paths.
+func repoBackend(uri string) string {
+	if prefix, _, ok := strings.Cut(uri, ":"); ok {
+		for _, b := range backends {
+			if prefix == b {
+				return b
+			}
+		}
+	}
+	return "local"
+}
+
+func validateBackendOptions(repo *v1.Repo) error {
+	var err error
+	backend := repoBackend(repo.Uri)
+	for _, o := range repo.GetBackendOptions().GetOptions() {
+		m := optionNameRegex.FindStringSubmatch(o.Name)
+		if m == nil {
+			err = multierror.Append(err, fmt.Errorf("backend option %q invalid, must take format BACKEND.OPTION e.g. sftp.command", o.Name))
+		} else if m[1] != backend {
+			err = multierror.Append(err, fmt.Errorf("backend option %q does not apply to the repo's %s backend", o.Name, backend))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range repo.GetBackendOptions().GetEnv() {
+		if !envNameRegex.MatchString(e.Name) {
+			err = multierror.Append(err, fmt.Errorf("backend env var name %q invalid", e.Name))
+		}
+		for _, r := range reservedEnv {
+			if e.Name == r {
+				err = multierror.Append(err, fmt.Errorf("backend env var %s is set by backrest from the repo's settings", e.Name))
+			}
+		}
+		if seen[e.Name] {
+			err = multierror.Append(err, fmt.Errorf("backend env var %s is set more than once", e.Name))
+		}
+		seen[e.Name] = true
+	}
+
+	for _, f := range repo.Flags {
+		if _, e := shlex.Split(f); e != nil {
+			err = multierror.Append(err, fmt.Errorf("flag %q invalid: %w", f, e))
+		}
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+func TestRepoBackend(t *testing.T) {
+	tcs := map[string]string{
+		"/srv/restic":                   "local",
+		`C:\restic`:                     "local",
+		"sftp:user@host:/srv/restic":    "sftp",
+		"rest:https://host:8000/restic": "rest",
+		"rclone:remote:restic":          "rclone",
+		"s3:s3.amazonaws.com/bucket":    "s3",
+	}
+	for uri, want := range tcs {
+		if got := repoBackend(uri); got != want {
+			t.Errorf("repoBackend(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
+
+func TestValidateBackendOptions(t *testing.T) {
+	tcs := []struct {
+		name    string
+		repo    *v1.Repo
+		wantErr string
+	}{
+		{
+			name: "valid",
+			repo: &v1.Repo{
+				Uri: "sftp:user@host:/srv/restic",
+				BackendOptions: &v1.BackendOptions{
+					Options: []*v1.BackendOptions_Option{{Name: "sftp.command", Value: "ssh -i /key user@host -s sftp"}},
+					Env:     []*v1.BackendOptions_EnvVar{{Name: "SSH_AUTH_SOCK", Value: "${SSH_AUTH_SOCK}"}},
+				},
+			},
+		},
+		{
+			name: "option for another backend",
+			repo: &v1.Repo{
+				Uri:            "rclone:remote:restic",
+				BackendOptions: &v1.BackendOptions{Options: []*v1.BackendOptions_Option{{Name: "sftp.command", Value: "ssh"}}},
+			},
+			wantErr: "does not apply to the repo's rclone backend",
+		},
+		{
+			name: "malformed option",
+			repo: &v1.Repo{
+				Uri:            "/srv/restic",
+				BackendOptions: &v1.BackendOptions{Options: []*v1.BackendOptions_Option{{Name: "connections", Value: "2"}}},
+			},
+			wantErr: "must take format BACKEND.OPTION",
+		},
+		{
+			name: "reserved env var",
+			repo: &v1.Repo{
+				Uri:            "/srv/restic",
+				BackendOptions: &v1.BackendOptions{Env: []*v1.BackendOptions_EnvVar{{Name: "RESTIC_PASSWORD", Value: "x"}}},
+			},
+			wantErr: "set by backrest",
+		},
+		{
+			name: "duplicate env var",
+			repo: &v1.Repo{
+				Uri: "rclone:remote:restic",
+				BackendOptions: &v1.BackendOptions{Env: []*v1.BackendOptions_EnvVar{
+					{Name: "RCLONE_CONFIG", Value: "/a"},
+					{Name: "RCLONE_CONFIG", Value: "/b"},
+				}},
+			},
+			wantErr: "more than once",
+		},
+		{
+			name:    "unparseable flag",
+			repo:    &v1.Repo{Uri: "/srv/restic", Flags: []string{`-o "sftp.command=ssh`}},
+			wantErr: "invalid",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateBackendOptions(tc.repo)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateBackendOptions() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("validateBackendOptions() error = %v, want an error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -84,6 +84,219 @@ func TestConfig(t *testing.T) {
 			wantErr:         true,
 			wantErrContains: "invalid cron \"bad cron\"",
 		},
+		{
+			name: "plan with cron and interval",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:       "test-plan",
+						Repo:     "test-repo",
+						Paths:    []string{"/tmp/foo"},
+						Cron:     "* * * * *",
+						Schedule: &v1.Schedule{Interval: "4h"},
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config5.json"}},
+			wantErr:         true,
+			wantErrContains: "mutually exclusive",
+		},
+		{
+			name: "plan with cron more frequent than its min interval",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:          "test-plan",
+						Repo:        "test-repo",
+						Paths:       []string{"/tmp/foo"},
+						Cron:        "*/5 * * * *",
+						MinInterval: "1h",
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config8.json"}},
+			wantErr:         true,
+			wantErrContains: "more often than the plan's min interval",
+		},
+		{
+			name: "plan with interval below its min interval",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:          "test-plan",
+						Repo:        "test-repo",
+						Paths:       []string{"/tmp/foo"},
+						Schedule:    &v1.Schedule{Interval: "30m"},
+						MinInterval: "1h",
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config9.json"}},
+			wantErr:         true,
+			wantErrContains: "schedule interval must be at least",
+		},
+		{
+			name: "plan with invalid timeout",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:      "test-plan",
+						Repo:    "test-repo",
+						Paths:   []string{"/tmp/foo"},
+						Cron:    "* * * * *",
+						Timeout: "forever",
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config6.json"}},
+			wantErr:         true,
+			wantErrContains: "invalid timeout",
+		},
+		{
+			name: "plan with malformed exclude",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:       "test-plan",
+						Repo:     "test-repo",
+						Paths:    []string{"/tmp/foo"},
+						Cron:     "* * * * *",
+						Excludes: []string{"*.log", "[unclosed"},
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config-exclude.json"}},
+			wantErr:         true,
+			wantErrContains: "exclude[1]",
+		},
+		{
+			name: "plan with invalid exclude larger than",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:                "test-plan",
+						Repo:              "test-repo",
+						Paths:             []string{"/tmp/foo"},
+						Cron:              "* * * * *",
+						ExcludeLargerThan: "1.5 GB",
+						ExcludeIfPresent:  []string{".nobackup", "CACHEDIR.TAG:Signature: 8a477f597d28d172789f06886806bc55"},
+						ExcludeFiles:      []string{"/etc/backup-excludes"},
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config-larger-than.json"}},
+			wantErr:         true,
+			wantErrContains: "exclude larger than",
+		},
+		{
+			name: "plan env overriding a backrest variable",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:    "test-plan",
+						Repo:  "test-repo",
+						Paths: []string{"/tmp/foo"},
+						Cron:  "* * * * *",
+						Env: []*v1.EnvVar{
+							{Name: "HTTPS_PROXY", Value: "http://proxy.lan:3128"},
+							{Name: "BACKREST_PLAN_ID", Value: "other-plan"},
+						},
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config-plan-env.json"}},
+			wantErr:         true,
+			wantErrContains: "env var BACKREST_PLAN_ID is set by backrest",
+		},
+		{
+			name: "chained plan without a schedule",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					testPlan,
+					{
+						Id:    "test-plan-2",
+						Repo:  "test-repo",
+						Paths: []string{"/tmp/foo"},
+						After: []string{"test-plan"},
+					},
+				},
+			},
+			store: &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/valid-config2.json"}},
+		},
+		{
+			name: "plans chained in a cycle",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					testRepo,
+				},
+				Plans: []*v1.Plan{
+					{
+						Id:    "test-plan",
+						Repo:  "test-repo",
+						Paths: []string{"/tmp/foo"},
+						After: []string{"test-plan-2"},
+					},
+					{
+						Id:    "test-plan-2",
+						Repo:  "test-repo",
+						Paths: []string{"/tmp/foo"},
+						After: []string{"test-plan"},
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config7.json"}},
+			wantErr:         true,
+			wantErrContains: "cycle: test-plan -> test-plan-2 -> test-plan",
+		},
+		{
+			name: "repo references non-existent namespace",
+			config: &v1.Config{
+				Repos: []*v1.Repo{
+					{
+						Id:        "test-repo",
+						Uri:       "/tmp/test",
+						Password:  "test",
+						Namespace: "family",
+					},
+				},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config4.json"}},
+			wantErr:         true,
+			wantErrContains: "namespace \"family\" not found",
+		},
+		{
+			name: "namespace root dir not absolute",
+			config: &v1.Config{
+				Namespaces: []*v1.Namespace{{Id: "family", RootDir: "srv/family"}},
+			},
+			store:           &CachingValidatingStore{ConfigStore: &JsonFileStore{Path: dir + "/invalid-config-namespace-root.json"}},
+			wantErr:         true,
+			wantErrContains: "root dir \"srv/family\" must be absolute",
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,302 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// FindNamespace returns the namespace with the given ID or nil if there is no such namespace.
+func FindNamespace(c *v1.Config, namespaceID string) *v1.Namespace {
+	for _, ns := range c.Namespaces {
+		if ns.Id == namespaceID {
+			return ns
+		}
+	}
+	return nil
+}
+
+// ReposInNamespace returns the IDs of the repos that belong to the given namespace.
+func ReposInNamespace(c *v1.Config, namespaceID string) []string {
+	var ids []string
+	for _, r := range c.Repos {
+		if r.Namespace == namespaceID {
+			ids = append(ids, r.Id)
+		}
+	}
+	return ids
+}
+
+// RepoNamespaces returns the namespace of each repo keyed by repo ID.
+func RepoNamespaces(c *v1.Config) map[string]string {
+	res := make(map[string]string, len(c.Repos))
+	for _, r := range c.Repos {
+		res[r.Id] = r.Namespace
+	}
+	return res
+}
+
+// ForNamespace returns a copy of the config containing only what users in the namespace may see: its repos, the plans
+// of those repos, the repo groups they are members of and the namespace itself. Auth settings are removed.
+func ForNamespace(c *v1.Config, namespaceID string) *v1.Config {
+	res := &v1.Config{
+		Modno:           c.Modno,
+		Version:         c.Version,
+		Instance:        c.Instance,
+		MaxParallelism:  c.MaxParallelism,
+		HookConcurrency: c.HookConcurrency,
+	}
+	if ns := FindNamespace(c, namespaceID); ns != nil {
+		res.Namespaces = []*v1.Namespace{proto.Clone(ns).(*v1.Namespace)}
+	}
+
+	groups := make(map[string]bool)
+	repos := make(map[string]bool)
+	for _, r := range c.Repos {
+		if r.Namespace != namespaceID {
+			continue
+		}
+		repos[r.Id] = true
+		res.Repos = append(res.Repos, proto.Clone(r).(*v1.Repo))
+		if r.Group != "" {
+			groups[r.Group] = true
+		}
+	}
+	for _, p := range c.Plans {
+		if repos[p.Repo] {
+			res.Plans = append(res.Plans, proto.Clone(p).(*v1.Plan))
+		}
+	}
+	for _, g := range c.RepoGroups {
+		if groups[g.Id] {
+			res.RepoGroups = append(res.RepoGroups, proto.Clone(g).(*v1.RepoGroup))
+		}
+	}
+	return res
+}
+
+// MergeNamespace returns a copy of the config in which the repos and plans of the namespace are replaced by those in
+// nsConfig, typically an edited copy of ForNamespace. Settings outside of the namespace are kept as is. Repos in
+// nsConfig without a namespace are assigned to it, an error is returned if nsConfig refers to another namespace's repos
+// or changes settings that CheckNamespaceRepo and CheckNamespacePlan reserve for admins.
+func MergeNamespace(c *v1.Config, nsConfig *v1.Config, namespaceID string) (*v1.Config, error) {
+	repoNamespaces := RepoNamespaces(c)
+	namespace := FindNamespace(c, namespaceID)
+	oldRepos := make(map[string]*v1.Repo)
+	for _, r := range c.Repos {
+		if r.Namespace == namespaceID {
+			oldRepos[r.Id] = r
+		}
+	}
+	oldPlans := make(map[string]*v1.Plan)
+	for _, p := range c.Plans {
+		if oldRepos[p.Repo] != nil {
+			oldPlans[p.Id] = p
+		}
+	}
+
+	res := proto.Clone(c).(*v1.Config)
+	res.Repos = nil
+	res.Plans = nil
+	for _, r := range c.Repos {
+		if r.Namespace != namespaceID {
+			res.Repos = append(res.Repos, proto.Clone(r).(*v1.Repo))
+		}
+	}
+	for _, p := range c.Plans {
+		if ns, ok := repoNamespaces[p.Repo]; !ok || ns != namespaceID {
+			res.Plans = append(res.Plans, proto.Clone(p).(*v1.Plan))
+		}
+	}
+
+	repos := make(map[string]bool)
+	for _, r := range nsConfig.Repos {
+		r = proto.Clone(r).(*v1.Repo)
+		if r.Namespace == "" {
+			r.Namespace = namespaceID
+		}
+		if r.Namespace != namespaceID {
+			return nil, fmt.Errorf("repo %q: namespace %q is not writable from namespace %q", r.Id, r.Namespace, namespaceID)
+		}
+		if ns, ok := repoNamespaces[r.Id]; ok && ns != namespaceID {
+			return nil, fmt.Errorf("repo %q: id is already in use", r.Id)
+		}
+		if err := CheckNamespaceRepo(namespace, r, oldRepos[r.Id]); err != nil {
+			return nil, fmt.Errorf("repo %q: %w", r.Id, err)
+		}
+		repos[r.Id] = true
+		res.Repos = append(res.Repos, r)
+	}
+	for _, p := range nsConfig.Plans {
+		if !repos[p.Repo] {
+			return nil, fmt.Errorf("plan %q: repo %q not found in namespace %q", p.Id, p.Repo, namespaceID)
+		}
+		if err := CheckNamespacePlan(namespace, p, oldPlans[p.Id]); err != nil {
+			return nil, fmt.Errorf("plan %q: %w", p.Id, err)
+		}
+		res.Plans = append(res.Plans, proto.Clone(p).(*v1.Plan))
+	}
+	return res, nil
+}
+
+// hostBackendOptions are the backend options setting programs that restic runs on the host.
+var hostBackendOptions = []string{"sftp.command", "sftp.args", "rclone.program", "rclone.args"}
+
+// isHostEnv returns whether an environment variable makes restic or the commands backrest runs execute programs or use
+// paths of the host e.g. RESTIC_PASSWORD_COMMAND or LD_PRELOAD.
+func isHostEnv(name string) bool {
+	switch name {
+	case "RESTIC_PASSWORD_COMMAND", "RESTIC_PASSWORD_FILE", "RESTIC_CACHE_DIR", "PATH", "BASH_ENV", "ENV":
+		return true
+	}
+	return strings.HasPrefix(name, "LD_") || strings.HasPrefix(name, "DYLD_")
+}
+
+// CheckNamespaceRepo returns an error if a user in the namespace may not save the repo, old is the repo's current
+// settings or nil if it's a new repo. Namespace users may not run programs on the host or use its files outside of the
+// namespace's root dir, settings that would are reserved for admins and must be kept as they are.
+func CheckNamespaceRepo(ns *v1.Namespace, r, old *v1.Repo) error {
+	if old == nil {
+		old = &v1.Repo{}
+	}
+	switch {
+	case !slices.EqualFunc(commandHooks(r.Hooks), commandHooks(old.Hooks), hookEqual):
+		return errors.New("command hooks may only be set by an admin")
+	case r.ResticBinaryPath != old.ResticBinaryPath:
+		return errors.New("restic binary path may only be set by an admin")
+	case r.CacheDir != old.CacheDir:
+		return errors.New("cache dir may only be set by an admin")
+	case !slices.Equal(r.Flags, old.Flags):
+		return errors.New("flags may only be set by an admin")
+	case !slices.Equal(repoHostEnv(r), repoHostEnv(old)):
+		return errors.New("env vars running programs or using paths of the host e.g. RESTIC_PASSWORD_COMMAND may only be set by an admin")
+	case !slices.Equal(repoHostBackendOptions(r), repoHostBackendOptions(old)):
+		return fmt.Errorf("backend options %v may only be set by an admin", hostBackendOptions)
+	}
+	if r.Uri != old.Uri && repoBackend(r.Uri) == "local" && !inRootDir(ns, strings.TrimPrefix(r.Uri, "local:")) {
+		return fmt.Errorf("local repo %q must be in the namespace's root dir", r.Uri)
+	}
+	return nil
+}
+
+// CheckNamespacePlan is CheckNamespaceRepo for plans.
+func CheckNamespacePlan(ns *v1.Namespace, p, old *v1.Plan) error {
+	if old == nil {
+		old = &v1.Plan{}
+	}
+	switch {
+	case !slices.EqualFunc(commandHooks(p.Hooks), commandHooks(old.Hooks), hookEqual):
+		return errors.New("command hooks may only be set by an admin")
+	case p.GetPreBackup().GetCommand() != old.GetPreBackup().GetCommand() || p.GetPostBackup().GetCommand() != old.GetPostBackup().GetCommand():
+		return errors.New("pre and post backup commands may only be set by an admin")
+	case p.GetFilesFrom().GetCommand() != old.GetFilesFrom().GetCommand():
+		return errors.New("files from command may only be set by an admin")
+	case p.GetCommandSource().GetCommand() != old.GetCommandSource().GetCommand():
+		return errors.New("command source may only be set by an admin")
+	case !slices.Equal(p.BackupFlags, old.BackupFlags):
+		return errors.New("backup flags may only be set by an admin")
+	case !slices.Equal(planHostEnv(p), planHostEnv(old)):
+		return errors.New("env vars running programs or using paths of the host e.g. LD_PRELOAD may only be set by an admin")
+	}
+	for _, paths := range [][2][]string{
+		{p.Paths, old.Paths},
+		{p.GetFilesFrom().GetPaths(), old.GetFilesFrom().GetPaths()},
+		{p.ExcludeFiles, old.ExcludeFiles},
+	} {
+		for _, path := range paths[0] {
+			if !slices.Contains(paths[1], path) && !inRootDir(ns, path) {
+				return fmt.Errorf("path %q must be in the namespace's root dir", path)
+			}
+		}
+	}
+	return nil
+}
+
+// InDir returns whether the absolute path is dir or inside of it. Symlinks in the existing part of the path are
+// resolved so that a link inside of dir can't lead out of it.
+func InDir(dir, path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	rel, err := filepath.Rel(resolveSymlinks(dir), resolveSymlinks(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resolveSymlinks returns the cleaned path with the symlinks in its longest existing prefix resolved.
+func resolveSymlinks(path string) string {
+	path = filepath.Clean(path)
+	var rest []string
+	for {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return filepath.Join(append([]string{path}, rest...)...)
+		}
+		rest = append([]string{filepath.Base(path)}, rest...)
+		path = parent
+	}
+}
+
+// inRootDir returns whether the path is in the namespace's root dir, it never is if the namespace has no root dir.
+func inRootDir(ns *v1.Namespace, path string) bool {
+	return ns.GetRootDir() != "" && InDir(ns.RootDir, path)
+}
+
+func commandHooks(hooks []*v1.Hook) []*v1.Hook {
+	var res []*v1.Hook
+	for _, h := range hooks {
+		if h.GetActionCommand() != nil {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+func hookEqual(a, b *v1.Hook) bool {
+	return proto.Equal(a, b)
+}
+
+// repoHostEnv returns the repo's env vars for which isHostEnv is true as NAME=value.
+func repoHostEnv(r *v1.Repo) []string {
+	var res []string
+	for _, e := range r.Env {
+		if name, _, _ := strings.Cut(e, "="); isHostEnv(name) {
+			res = append(res, e)
+		}
+	}
+	for _, e := range r.GetBackendOptions().GetEnv() {
+		if isHostEnv(e.Name) {
+			res = append(res, e.Name+"="+e.Value)
+		}
+	}
+	return res
+}
+
+// planHostEnv is repoHostEnv for plans.
+func planHostEnv(p *v1.Plan) []string {
+	var res []string
+	for _, e := range p.Env {
+		if isHostEnv(e.Name) {
+			res = append(res, e.Name+"="+e.Value)
+		}
+	}
+	return res
+}
+
+// repoHostBackendOptions returns the repo's backend options in hostBackendOptions as name=value.
+func repoHostBackendOptions(r *v1.Repo) []string {
+	var res []string
+	for _, o := range r.GetBackendOptions().GetOptions() {
+		if slices.Contains(hostBackendOptions, o.Name) {
+			res = append(res, o.Name+"="+o.Value)
+		}
+	}
+	return res
+}
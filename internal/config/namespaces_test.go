@@ -0,0 +1,177 @@
+package config
+
+import (
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func namespacedConfig() *v1.Config {
+	return &v1.Config{
+		Modno:      3,
+		Instance:   "test",
+		Namespaces: []*v1.Namespace{{Id: "family"}, {Id: "team"}},
+		RepoGroups: []*v1.RepoGroup{{Id: "cloud"}, {Id: "other"}},
+		Repos: []*v1.Repo{
+			{Id: "family-repo", Namespace: "family", Group: "cloud"},
+			{Id: "team-repo", Namespace: "team", Group: "other"},
+			{Id: "admin-repo"},
+		},
+		Plans: []*v1.Plan{
+			{Id: "family-plan", Repo: "family-repo"},
+			{Id: "team-plan", Repo: "team-repo"},
+			{Id: "admin-plan", Repo: "admin-repo"},
+		},
+		Auth: &v1.Auth{
+			Users: []*v1.User{{Name: "admin"}, {Name: "kid", Namespace: "family"}},
+		},
+	}
+}
+
+func TestForNamespace(t *testing.T) {
+	got := ForNamespace(namespacedConfig(), "family")
+
+	want := &v1.Config{
+		Modno:      3,
+		Instance:   "test",
+		Namespaces: []*v1.Namespace{{Id: "family"}},
+		RepoGroups: []*v1.RepoGroup{{Id: "cloud"}},
+		Repos:      []*v1.Repo{{Id: "family-repo", Namespace: "family", Group: "cloud"}},
+		Plans:      []*v1.Plan{{Id: "family-plan", Repo: "family-repo"}},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("ForNamespace() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeNamespace(t *testing.T) {
+	cfg := namespacedConfig()
+
+	nsCfg := ForNamespace(cfg, "family")
+	nsCfg.Repos = append(nsCfg.Repos, &v1.Repo{Id: "new-repo"})
+	nsCfg.Plans = []*v1.Plan{{Id: "new-plan", Repo: "new-repo"}}
+
+	got, err := MergeNamespace(cfg, nsCfg, "family")
+	if err != nil {
+		t.Fatalf("MergeNamespace() error = %v", err)
+	}
+
+	want := namespacedConfig()
+	want.Repos = []*v1.Repo{
+		{Id: "team-repo", Namespace: "team", Group: "other"},
+		{Id: "admin-repo"},
+		{Id: "family-repo", Namespace: "family", Group: "cloud"},
+		{Id: "new-repo", Namespace: "family"},
+	}
+	want.Plans = []*v1.Plan{
+		{Id: "team-plan", Repo: "team-repo"},
+		{Id: "admin-plan", Repo: "admin-repo"},
+		{Id: "new-plan", Repo: "new-repo"},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("MergeNamespace() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeNamespaceRejectsOtherNamespaces(t *testing.T) {
+	tests := []struct {
+		name  string
+		nsCfg *v1.Config
+	}{
+		{
+			name:  "repo in other namespace",
+			nsCfg: &v1.Config{Repos: []*v1.Repo{{Id: "team-repo", Namespace: "team"}}},
+		},
+		{
+			name:  "repo id used by other namespace",
+			nsCfg: &v1.Config{Repos: []*v1.Repo{{Id: "admin-repo"}}},
+		},
+		{
+			name:  "plan for repo in other namespace",
+			nsCfg: &v1.Config{Plans: []*v1.Plan{{Id: "family-plan", Repo: "team-repo"}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := MergeNamespace(namespacedConfig(), tc.nsCfg, "family"); err == nil {
+				t.Errorf("MergeNamespace() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestMergeNamespaceRejectsHostAccess(t *testing.T) {
+	commandHook := &v1.Hook{Action: &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "rm -rf /"}}}
+
+	tests := []struct {
+		name string
+		repo *v1.Repo
+		plan *v1.Plan
+	}{
+		{name: "repo command hook", repo: &v1.Repo{Hooks: []*v1.Hook{commandHook}}},
+		{name: "restic binary path", repo: &v1.Repo{ResticBinaryPath: "/tmp/evil"}},
+		{name: "cache dir", repo: &v1.Repo{CacheDir: "/etc"}},
+		{name: "repo flags", repo: &v1.Repo{Flags: []string{"--password-command=id"}}},
+		{name: "repo password command env", repo: &v1.Repo{Env: []string{"RESTIC_PASSWORD_COMMAND=id"}}},
+		{name: "backend env", repo: &v1.Repo{BackendOptions: &v1.BackendOptions{Env: []*v1.BackendOptions_EnvVar{{Name: "LD_PRELOAD", Value: "/tmp/evil.so"}}}}},
+		{name: "backend program option", repo: &v1.Repo{BackendOptions: &v1.BackendOptions{Options: []*v1.BackendOptions_Option{{Name: "sftp.command", Value: "id"}}}}},
+		{name: "local repo outside root dir", repo: &v1.Repo{Uri: "/srv/team/repo"}},
+		{name: "local: repo outside root dir", repo: &v1.Repo{Uri: "local:/srv/family/../team/repo"}},
+		{name: "plan command hook", plan: &v1.Plan{Hooks: []*v1.Hook{commandHook}}},
+		{name: "pre backup command", plan: &v1.Plan{PreBackup: &v1.BackupCommand{Command: "id"}}},
+		{name: "post backup command", plan: &v1.Plan{PostBackup: &v1.BackupCommand{Command: "id"}}},
+		{name: "files from command", plan: &v1.Plan{FilesFrom: &v1.FilesFrom{Command: "id"}}},
+		{name: "command source", plan: &v1.Plan{CommandSource: &v1.CommandSource{Command: "id"}}},
+		{name: "backup flags", plan: &v1.Plan{BackupFlags: []string{"--files-from=/etc/shadow"}}},
+		{name: "plan env", plan: &v1.Plan{Env: []*v1.EnvVar{{Name: "PATH", Value: "/tmp"}}}},
+		{name: "path outside root dir", plan: &v1.Plan{Paths: []string{"/etc"}}},
+		{name: "relative path", plan: &v1.Plan{Paths: []string{"../team"}}},
+		{name: "files from path outside root dir", plan: &v1.Plan{FilesFrom: &v1.FilesFrom{Paths: []string{"/srv/team"}}}},
+		{name: "exclude file outside root dir", plan: &v1.Plan{ExcludeFiles: []string{"/etc/shadow"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := namespacedConfig()
+			cfg.Namespaces[0].RootDir = "/srv/family"
+
+			nsCfg := ForNamespace(cfg, "family")
+			if tc.repo != nil {
+				tc.repo.Id = "new-repo"
+				nsCfg.Repos = append(nsCfg.Repos, tc.repo)
+			}
+			if tc.plan != nil {
+				tc.plan.Id = "family-plan"
+				tc.plan.Repo = "family-repo"
+				nsCfg.Plans = []*v1.Plan{tc.plan}
+			}
+			if _, err := MergeNamespace(cfg, nsCfg, "family"); err == nil {
+				t.Errorf("MergeNamespace() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestMergeNamespaceKeepsAdminSettings(t *testing.T) {
+	cfg := namespacedConfig()
+	cfg.Namespaces[0].RootDir = "/srv/family"
+	cfg.Repos[0].Uri = "/mnt/backups/family"
+	cfg.Repos[0].Flags = []string{"--insecure-tls"}
+	cfg.Plans[0].Paths = []string{"/home/kid"}
+	cfg.Plans[0].PreBackup = &v1.BackupCommand{Command: "dump-db"}
+	cfg.Plans[0].Hooks = []*v1.Hook{{Action: &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "notify"}}}}
+
+	nsCfg := ForNamespace(cfg, "family")
+	nsCfg.Plans[0].Paths = append(nsCfg.Plans[0].Paths, "/srv/family/photos")
+	nsCfg.Repos = append(nsCfg.Repos, &v1.Repo{Id: "new-repo", Uri: "local:/srv/family/repo"})
+
+	got, err := MergeNamespace(cfg, nsCfg, "family")
+	if err != nil {
+		t.Fatalf("MergeNamespace() error = %v", err)
+	}
+	if plan := got.Plans[len(got.Plans)-1]; !proto.Equal(plan, nsCfg.Plans[0]) {
+		t.Errorf("MergeNamespace() plan = %v, want %v", plan, nsCfg.Plans[0])
+	}
+}
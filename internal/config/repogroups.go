@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// FindRepoGroup returns the repo group with the given ID or nil if there is no such group.
+func FindRepoGroup(c *v1.Config, groupID string) *v1.RepoGroup {
+	for _, g := range c.RepoGroups {
+		if g.Id == groupID {
+			return g
+		}
+	}
+	return nil
+}
+
+// ReposInGroup returns the IDs of the repos that are members of the given group.
+func ReposInGroup(c *v1.Config, groupID string) []string {
+	var ids []string
+	for _, r := range c.Repos {
+		if r.Group == groupID {
+			ids = append(ids, r.Id)
+		}
+	}
+	return ids
+}
+
+// ResolveRepoGroups returns a copy of the config in which each repo's group settings are merged into the repo.
+// Settings set on the repo take precedence over settings inherited from its group.
+func ResolveRepoGroups(c *v1.Config) *v1.Config {
+	c = proto.Clone(c).(*v1.Config)
+	for _, repo := range c.Repos {
+		if repo.Group == "" {
+			continue
+		}
+		group := FindRepoGroup(c, repo.Group)
+		if group == nil {
+			continue
+		}
+		resolveRepoGroup(repo, group)
+	}
+	return c
+}
+
+func resolveRepoGroup(repo *v1.Repo, group *v1.RepoGroup) {
+	// group env vars are set first so that repo env vars override them.
+	repo.Env = append(slices.Clone(group.Env), repo.Env...)
+
+	// group flags are applied first, restic uses the last value when a flag is repeated.
+	var flags []string
+	flags = append(flags, group.Flags...)
+	if group.LimitUploadKbps > 0 {
+		flags = append(flags, fmt.Sprintf("--limit-upload=%d", group.LimitUploadKbps))
+	}
+	if group.LimitDownloadKbps > 0 {
+		flags = append(flags, fmt.Sprintf("--limit-download=%d", group.LimitDownloadKbps))
+	}
+	repo.Flags = append(flags, repo.Flags...)
+
+	if repo.PrunePolicy == nil && group.PrunePolicy != nil {
+		repo.PrunePolicy = proto.Clone(group.PrunePolicy).(*v1.PrunePolicy)
+	}
+
+	var hooks []*v1.Hook
+	for _, h := range group.Hooks {
+		hooks = append(hooks, proto.Clone(h).(*v1.Hook))
+	}
+	repo.Hooks = append(hooks, repo.Hooks...)
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestResolveRepoGroups(t *testing.T) {
+	groupHook := &v1.Hook{
+		Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_ANY_ERROR},
+		Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "echo group"}},
+	}
+	repoHook := &v1.Hook{
+		Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+		Action:     &v1.Hook_ActionCommand{ActionCommand: &v1.Hook_Command{Command: "echo repo"}},
+	}
+
+	cfg := &v1.Config{
+		RepoGroups: []*v1.RepoGroup{
+			{
+				Id:              "cloud",
+				Env:             []string{"FOO=group"},
+				Flags:           []string{"--option=a"},
+				PrunePolicy:     &v1.PrunePolicy{MaxFrequencyDays: 30},
+				Hooks:           []*v1.Hook{groupHook},
+				LimitUploadKbps: 1024,
+			},
+		},
+		Repos: []*v1.Repo{
+			{
+				Id:    "member",
+				Group: "cloud",
+				Env:   []string{"FOO=repo"},
+				Flags: []string{"--option=b"},
+				Hooks: []*v1.Hook{repoHook},
+			},
+			{
+				Id:          "member-with-prune-policy",
+				Group:       "cloud",
+				PrunePolicy: &v1.PrunePolicy{MaxFrequencyDays: 1},
+			},
+			{
+				Id: "not-a-member",
+			},
+		},
+	}
+
+	resolved := ResolveRepoGroups(cfg)
+
+	want := []*v1.Repo{
+		{
+			Id:          "member",
+			Group:       "cloud",
+			Env:         []string{"FOO=group", "FOO=repo"},
+			Flags:       []string{"--option=a", "--limit-upload=1024", "--option=b"},
+			PrunePolicy: &v1.PrunePolicy{MaxFrequencyDays: 30},
+			Hooks:       []*v1.Hook{groupHook, repoHook},
+		},
+		{
+			Id:          "member-with-prune-policy",
+			Group:       "cloud",
+			Env:         []string{"FOO=group"},
+			Flags:       []string{"--option=a", "--limit-upload=1024"},
+			PrunePolicy: &v1.PrunePolicy{MaxFrequencyDays: 1},
+			Hooks:       []*v1.Hook{groupHook},
+		},
+		{
+			Id: "not-a-member",
+		},
+	}
+
+	for i, repo := range resolved.Repos {
+		if !proto.Equal(repo, want[i]) {
+			t.Errorf("repo %d: got %v, want %v", i, repo, want[i])
+		}
+	}
+
+	if len(cfg.Repos[0].Flags) != 1 {
+		t.Errorf("expected input config to be unmodified, got flags %v", cfg.Repos[0].Flags)
+	}
+}
@@ -3,13 +3,19 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/backupwindow"
 	"github.com/garethgeorge/backrest/internal/config/validationutil"
-	"github.com/gitploy-io/cronexpr"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/restorearchive"
 	"github.com/hashicorp/go-multierror"
+	"golang.org/x/crypto/openpgp"
 )
 
 func ValidateConfig(c *v1.Config) error {
@@ -19,9 +25,68 @@ func ValidateConfig(c *v1.Config) error {
 		err = multierror.Append(err, fmt.Errorf("instance ID %q invalid: %w", c.Instance, e))
 	}
 
+	if c.MaxParallelism < 0 {
+		err = multierror.Append(err, fmt.Errorf("max parallelism must be non-negative, got %d", c.MaxParallelism))
+	}
+
+	if c.HookConcurrency < 0 {
+		err = multierror.Append(err, fmt.Errorf("hook concurrency must be non-negative, got %d", c.HookConcurrency))
+	}
+
+	groups := make(map[string]*v1.RepoGroup)
+	for _, group := range c.RepoGroups {
+		if e := validateRepoGroup(group); e != nil {
+			err = multierror.Append(err, fmt.Errorf("repo group %s: %w", group.GetId(), e))
+		}
+		if _, ok := groups[group.Id]; ok {
+			err = multierror.Append(err, fmt.Errorf("repo group %s: duplicate id", group.GetId()))
+		}
+		groups[group.Id] = group
+	}
+
+	if r := c.ConfigReplication; r.GetDestination() != "" {
+		if r.Passphrase == "" {
+			err = multierror.Append(err, errors.New("config replication: passphrase is required"))
+		}
+		if r.Cron != "" {
+			if _, e := cronutil.Parse(r.Cron); e != nil {
+				err = multierror.Append(err, fmt.Errorf("config replication: invalid cron %q: %w", r.Cron, e))
+			}
+		}
+	}
+
+	namespaces := make(map[string]*v1.Namespace)
+	for _, ns := range c.Namespaces {
+		if e := validateNamespace(ns); e != nil {
+			err = multierror.Append(err, fmt.Errorf("namespace %s: %w", ns.GetId(), e))
+		}
+		if _, ok := namespaces[ns.Id]; ok {
+			err = multierror.Append(err, fmt.Errorf("namespace %s: duplicate id", ns.GetId()))
+		}
+		namespaces[ns.Id] = ns
+	}
+
+	for _, user := range c.GetAuth().GetUsers() {
+		if _, ok := namespaces[user.Namespace]; user.Namespace != "" && !ok {
+			err = multierror.Append(err, fmt.Errorf("user %s: namespace %q not found", user.GetName(), user.Namespace))
+		}
+	}
+
+	if h := c.RepoHosting; h != nil {
+		if e := validateRepoHosting(h); e != nil {
+			err = multierror.Append(err, fmt.Errorf("repo hosting: %w", e))
+		}
+	}
+
 	repos := make(map[string]*v1.Repo)
 	if c.Repos != nil {
 		for _, repo := range c.Repos {
+			if _, ok := groups[repo.Group]; repo.Group != "" && !ok {
+				err = multierror.Append(err, fmt.Errorf("repo %s: group %q not found", repo.GetId(), repo.Group))
+			}
+			if _, ok := namespaces[repo.Namespace]; repo.Namespace != "" && !ok {
+				err = multierror.Append(err, fmt.Errorf("repo %s: namespace %q not found", repo.GetId(), repo.Namespace))
+			}
 			if e := validateRepo(repo); e != nil {
 				err = multierror.Append(e, fmt.Errorf("repo %s: %w", repo.GetId(), err))
 			}
@@ -38,6 +103,46 @@ func ValidateConfig(c *v1.Config) error {
 		})
 	}
 
+	if e := c.OplogExport; e != nil {
+		if e.Path == "" && e.Repo == "" {
+			err = multierror.Append(err, errors.New("oplog export: path or repo is required"))
+		}
+		if _, ok := repos[e.Repo]; e.Repo != "" && !ok {
+			err = multierror.Append(err, fmt.Errorf("oplog export: repo %q not found", e.Repo))
+		}
+		if e.Cron != "" {
+			if _, ce := cronutil.Parse(e.Cron); ce != nil {
+				err = multierror.Append(err, fmt.Errorf("oplog export: invalid cron %q: %w", e.Cron, ce))
+			}
+		}
+		if e.KeepLast < 0 {
+			err = multierror.Append(err, errors.New("oplog export: keep last must be non-negative"))
+		}
+	}
+
+	if r := c.OplogRetention; r != nil {
+		if r.Cron != "" {
+			if _, ce := cronutil.Parse(r.Cron); ce != nil {
+				err = multierror.Append(err, fmt.Errorf("oplog retention: invalid cron %q: %w", r.Cron, ce))
+			}
+		}
+		if r.KeepDays < 0 {
+			err = multierror.Append(err, errors.New("oplog retention: keep days must be non-negative"))
+		}
+		if r.KeepPerPlan < 0 {
+			err = multierror.Append(err, errors.New("oplog retention: keep per plan must be non-negative"))
+		}
+	}
+
+	if name := c.GetDownloads().GetArchiveName(); name != "" {
+		if e := restorearchive.ValidateNameTemplate(name); e != nil {
+			err = multierror.Append(err, fmt.Errorf("downloads: invalid archive name %q: %w", name, e))
+		}
+	}
+	if c.GetDownloads().GetUrlTtlSeconds() < 0 {
+		err = multierror.Append(err, errors.New("downloads: URL TTL must be non-negative"))
+	}
+
 	if c.Plans != nil {
 		plans := make(map[string]*v1.Plan)
 		for _, plan := range c.Plans {
@@ -49,6 +154,9 @@ func ValidateConfig(c *v1.Config) error {
 				err = multierror.Append(err, fmt.Errorf("plan %s: %w", plan.GetId(), e))
 			}
 		}
+		if e := validatePlanChains(plans); e != nil {
+			err = multierror.Append(err, e)
+		}
 		slices.SortFunc(c.Plans, func(a, b *v1.Plan) int {
 			if a.Id < b.Id {
 				return -1
@@ -60,6 +168,102 @@ func ValidateConfig(c *v1.Config) error {
 	return err
 }
 
+// validatePlanChains checks that the plans each plan runs after exist and that no plan ends up running after itself.
+func validatePlanChains(plans map[string]*v1.Plan) error {
+	var err error
+	for _, plan := range plans {
+		for _, upstream := range plan.After {
+			if upstream == plan.Id {
+				err = multierror.Append(err, fmt.Errorf("plan %s: cannot run after itself", plan.Id))
+			} else if _, ok := plans[upstream]; !ok {
+				err = multierror.Append(err, fmt.Errorf("plan %s: upstream plan %q not found", plan.Id, upstream))
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// depth first search for cycles, visiting is set while a plan's upstream plans are being searched.
+	visiting := make(map[string]bool)
+	done := make(map[string]bool)
+	var visit func(id string, chain []string) error
+	visit = func(id string, chain []string) error {
+		if done[id] {
+			return nil
+		}
+		chain = append(chain, id)
+		if visiting[id] {
+			return fmt.Errorf("plans run after each other in a cycle: %s", strings.Join(chain, " -> "))
+		}
+		visiting[id] = true
+		for _, upstream := range plans[id].After {
+			if e := visit(upstream, chain); e != nil {
+				return e
+			}
+		}
+		visiting[id] = false
+		done[id] = true
+		return nil
+	}
+	ids := make([]string, 0, len(plans))
+	for id := range plans {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if e := visit(id, nil); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// byteSizeRegex matches the sizes restic accepts for --exclude-larger-than, a number optionally followed by a unit.
+var byteSizeRegex = regexp.MustCompile(`^[0-9]+[bBkKmMgGtT]?$`)
+
+func validateExcludes(plan *v1.Plan) error {
+	var err error
+	for name, patterns := range map[string][]string{"exclude": plan.Excludes, "iexclude": plan.Iexcludes} {
+		for idx, p := range patterns {
+			if e := validateGlob(p); e != nil {
+				err = multierror.Append(err, fmt.Errorf("%s[%d] %q invalid: %w", name, idx, p, e))
+			}
+		}
+	}
+	for idx, f := range plan.ExcludeFiles {
+		if f == "" {
+			err = multierror.Append(err, fmt.Errorf("exclude file[%d] cannot be empty", idx))
+		}
+	}
+	for idx, f := range plan.ExcludeIfPresent {
+		name, _, _ := strings.Cut(f, ":")
+		if name == "" {
+			err = multierror.Append(err, fmt.Errorf("exclude if present[%d] must name a file", idx))
+		} else if strings.ContainsAny(name, `/\`) {
+			err = multierror.Append(err, fmt.Errorf("exclude if present[%d] %q must be a file name, not a path", idx, name))
+		}
+	}
+	if plan.ExcludeLargerThan != "" && !byteSizeRegex.MatchString(plan.ExcludeLargerThan) {
+		err = multierror.Append(err, fmt.Errorf("invalid exclude larger than size %q, must be a number optionally followed by K, M, G or T", plan.ExcludeLargerThan))
+	}
+	return err
+}
+
+// validateGlob checks that an exclude pattern is well formed, a leading "!" negates the pattern in restic.
+func validateGlob(pattern string) error {
+	pattern = strings.TrimPrefix(pattern, "!")
+	if pattern == "" {
+		return errors.New("pattern cannot be empty")
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+const minStaleLockMinutes = 10
+
 func validateRepo(repo *v1.Repo) error {
 	var err error
 	if e := validationutil.ValidateID(repo.Id, 0); e != nil {
@@ -76,11 +280,181 @@ func validateRepo(repo *v1.Repo) error {
 		}
 	}
 
+	if e := validateHooks(repo.Hooks); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if repo.RetryLock != "" {
+		if _, e := time.ParseDuration(repo.RetryLock); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid retry lock duration %q: %w", repo.RetryLock, e))
+		}
+	}
+
+	if e := validateBackendOptions(repo); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if repo.ResticBinaryPath != "" && !filepath.IsAbs(repo.ResticBinaryPath) {
+		err = multierror.Append(err, fmt.Errorf("restic binary path %q must be absolute", repo.ResticBinaryPath))
+	}
+
+	if repo.LimitUploadKbps < 0 || repo.LimitDownloadKbps < 0 {
+		err = multierror.Append(err, errors.New("bandwidth limits must be non-negative"))
+	}
+
+	if repo.GetPrunePolicy().GetCooldownDays() < 0 {
+		err = multierror.Append(err, errors.New("prune cooldown must be non-negative"))
+	}
+
+	if repo.IndexCron != "" {
+		if _, e := cronutil.Parse(repo.IndexCron); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid index cron %q: %w", repo.IndexCron, e))
+		}
+	}
+
+	if repo.StatsCron != "" {
+		if _, e := cronutil.Parse(repo.StatsCron); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid stats cron %q: %w", repo.StatsCron, e))
+		}
+	}
+
+	if repo.CacheCleanupCron != "" {
+		if _, e := cronutil.Parse(repo.CacheCleanupCron); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid cache cleanup cron %q: %w", repo.CacheCleanupCron, e))
+		}
+	}
+
+	if repo.CacheDir != "" && !filepath.IsAbs(repo.CacheDir) {
+		err = multierror.Append(err, fmt.Errorf("cache dir %q must be absolute", repo.CacheDir))
+	}
+
+	if repo.MaxCacheSizeMb < 0 {
+		err = multierror.Append(err, errors.New("max cache size must be non-negative"))
+	}
+
+	// restic refreshes the locks of running commands every 5 minutes, a shorter age would remove live locks.
+	if repo.StaleLockMinutes != 0 && repo.StaleLockMinutes < minStaleLockMinutes {
+		err = multierror.Append(err, fmt.Errorf("stale lock minutes must be 0 or at least %d", minStaleLockMinutes))
+	}
+
 	slices.Sort(repo.Env)
 
 	return err
 }
 
+// repoHostingClientNameRegex matches valid client names, a client's name is also the directory of its repo.
+var repoHostingClientNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+func validateRepoHosting(h *v1.RepoHosting) error {
+	var err error
+	if h.Path != "" && !filepath.IsAbs(h.Path) {
+		err = multierror.Append(err, fmt.Errorf("path %q must be absolute", h.Path))
+	}
+	names := make(map[string]bool)
+	for _, client := range h.Clients {
+		if !repoHostingClientNameRegex.MatchString(client.Name) {
+			err = multierror.Append(err, fmt.Errorf("client name %q invalid: must be letters, digits, '-' and '_'", client.Name))
+		}
+		if names[client.Name] {
+			err = multierror.Append(err, fmt.Errorf("client %s: duplicate name", client.Name))
+		}
+		names[client.Name] = true
+		if client.PasswordBcrypt == "" {
+			err = multierror.Append(err, fmt.Errorf("client %s: password is required", client.Name))
+		}
+	}
+	return err
+}
+
+func validateNamespace(ns *v1.Namespace) error {
+	var err error
+	if e := validationutil.ValidateID(ns.Id, 0); e != nil {
+		err = multierror.Append(err, fmt.Errorf("id %q invalid: %w", ns.Id, e))
+	}
+	if ns.MaxConcurrentOperations < 0 {
+		err = multierror.Append(err, fmt.Errorf("max concurrent operations must be non-negative, got %d", ns.MaxConcurrentOperations))
+	}
+	if ns.StagingQuotaBytes < 0 {
+		err = multierror.Append(err, fmt.Errorf("staging quota must be non-negative, got %d", ns.StagingQuotaBytes))
+	}
+	if ns.RootDir != "" && !filepath.IsAbs(ns.RootDir) {
+		err = multierror.Append(err, fmt.Errorf("root dir %q must be absolute", ns.RootDir))
+	}
+	return err
+}
+
+func validateRepoGroup(group *v1.RepoGroup) error {
+	var err error
+	if e := validationutil.ValidateID(group.Id, 0); e != nil {
+		err = multierror.Append(err, fmt.Errorf("id %q invalid: %w", group.Id, e))
+	}
+
+	for _, env := range group.Env {
+		if !strings.Contains(env, "=") {
+			err = multierror.Append(err, fmt.Errorf("invalid env var %s, must take format KEY=VALUE", env))
+		}
+	}
+
+	if group.LimitUploadKbps < 0 || group.LimitDownloadKbps < 0 {
+		err = multierror.Append(err, errors.New("bandwidth limits must be non-negative"))
+	}
+
+	return err
+}
+
+func validateHooks(hooks []*v1.Hook) error {
+	var err error
+	for idx, hook := range hooks {
+		if hook.TimeoutSeconds < 0 {
+			err = multierror.Append(err, fmt.Errorf("hook[%d] timeout must be non-negative", idx))
+		}
+		if hook.EncryptionKey != "" {
+			if _, ok := hook.Action.(*v1.Hook_ActionCommand); ok {
+				err = multierror.Append(err, fmt.Errorf("hook[%d] encryption key only applies to notification hooks", idx))
+			} else if _, e := openpgp.ReadArmoredKeyRing(strings.NewReader(hook.EncryptionKey)); e != nil {
+				err = multierror.Append(err, fmt.Errorf("hook[%d] encryption key invalid, must be an ASCII armored OpenPGP public key: %w", idx, e))
+			}
+		}
+	}
+	return err
+}
+
+// DefaultMinScheduleInterval is the minimum time between a plan's scheduled backups unless the plan sets its own.
+const DefaultMinScheduleInterval = time.Minute
+
+// minIntervalSampleRuns is the number of upcoming runs of a cron schedule checked against the plan's minimum interval.
+const minIntervalSampleRuns = 1000
+
+// MinScheduleInterval returns the minimum time between the plan's scheduled backups.
+func MinScheduleInterval(plan *v1.Plan) time.Duration {
+	if d, err := time.ParseDuration(plan.MinInterval); err == nil && d > 0 {
+		return d
+	}
+	return DefaultMinScheduleInterval
+}
+
+// planReservedEnv are set by backrest for the restic commands run for a plan in addition to reservedEnv, the plan's env
+// vars may not override them. Variables prefixed BACKREST_ are reserved too, they describe the plan and backup to commands.
+var planReservedEnv = []string{"RESTIC_FROM_REPOSITORY", "RESTIC_FROM_PASSWORD", "RESTIC_FROM_PASSWORD_FILE", "RESTIC_FROM_PASSWORD_COMMAND", "RESTIC_FROM_REPOSITORY_FILE", "RESTIC_PROGRESS_FPS"}
+
+func validatePlanEnv(plan *v1.Plan) error {
+	var err error
+	seen := make(map[string]bool)
+	for _, e := range plan.Env {
+		if !envNameRegex.MatchString(e.Name) {
+			err = multierror.Append(err, fmt.Errorf("env var name %q invalid", e.Name))
+		}
+		if strings.HasPrefix(e.Name, "BACKREST_") || slices.Contains(reservedEnv, e.Name) || slices.Contains(planReservedEnv, e.Name) {
+			err = multierror.Append(err, fmt.Errorf("env var %s is set by backrest and can't be overridden", e.Name))
+		}
+		if seen[e.Name] {
+			err = multierror.Append(err, fmt.Errorf("env var %s is set more than once", e.Name))
+		}
+		seen[e.Name] = true
+	}
+	return err
+}
+
 func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 	var err error
 	if e := validationutil.ValidateID(plan.Id, 0); e != nil {
@@ -93,6 +467,24 @@ func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 		}
 	}
 
+	if src := plan.CommandSource; src != nil {
+		if src.Command == "" {
+			err = multierror.Append(err, errors.New("command source: command is required"))
+		}
+		if len(plan.Paths) > 0 || plan.FilesFrom != nil {
+			err = multierror.Append(err, errors.New("command source: paths and files from can't be set, the command's output is backed up instead"))
+		}
+		if strings.ContainsAny(src.Filename, "/\\") {
+			err = multierror.Append(err, fmt.Errorf("command source: filename %q must not contain path separators", src.Filename))
+		}
+	}
+
+	for idx, p := range plan.GetFilesFrom().GetPaths() {
+		if p == "" {
+			err = multierror.Append(err, fmt.Errorf("files from path[%d] cannot be empty", idx))
+		}
+	}
+
 	if plan.Repo == "" {
 		err = multierror.Append(err, fmt.Errorf("repo is required"))
 	}
@@ -101,8 +493,129 @@ func validatePlan(plan *v1.Plan, repos map[string]*v1.Repo) error {
 		err = multierror.Append(err, fmt.Errorf("repo %q not found", plan.Repo))
 	}
 
-	if _, e := cronexpr.Parse(plan.Cron); e != nil {
+	if e := validateExcludes(plan); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := validatePlanEnv(plan); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if plan.Timeout != "" {
+		if d, e := time.ParseDuration(plan.Timeout); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid timeout %q: %w", plan.Timeout, e))
+		} else if d <= 0 {
+			err = multierror.Append(err, errors.New("timeout must be positive"))
+		}
+	}
+
+	minInterval := MinScheduleInterval(plan)
+	if plan.MinInterval != "" {
+		if d, e := time.ParseDuration(plan.MinInterval); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid min interval %q: %w", plan.MinInterval, e))
+		} else if d < time.Second {
+			err = multierror.Append(err, errors.New("min interval must be at least 1s"))
+		}
+	}
+
+	if interval := plan.GetSchedule().GetInterval(); interval != "" {
+		if plan.Cron != "" {
+			err = multierror.Append(err, errors.New("cron and schedule interval are mutually exclusive"))
+		}
+		if d, e := time.ParseDuration(interval); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid schedule interval %q: %w", interval, e))
+		} else if d < minInterval {
+			err = multierror.Append(err, fmt.Errorf("schedule interval must be at least the plan's min interval of %v, lower the min interval to allow it", minInterval))
+		}
+	} else if plan.Cron == "" && len(plan.After) > 0 {
+		// the plan only runs after its upstream plans.
+	} else if sched, e := cronutil.Parse(plan.Cron); e != nil {
 		err = multierror.Append(err, fmt.Errorf("invalid cron %q: %w", plan.Cron, e))
+	} else if d := sched.ShortestInterval(time.Now(), minIntervalSampleRuns); d > 0 && d < minInterval {
+		err = multierror.Append(err, fmt.Errorf("cron %q runs as often as every %v, more often than the plan's min interval of %v, lower the min interval to allow it", plan.Cron, d, minInterval))
+	}
+
+	if t := plan.BackupTunables; t != nil {
+		if t.ReadConcurrency < 0 {
+			err = multierror.Append(err, errors.New("read concurrency must be non-negative"))
+		}
+		// restic accepts pack sizes between 4 and 128 MiB.
+		if t.PackSizeMb != 0 && (t.PackSizeMb < 4 || t.PackSizeMb > 128) {
+			err = multierror.Append(err, fmt.Errorf("pack size must be between 4 and 128 MiB, got %d", t.PackSizeMb))
+		}
+	}
+
+	for _, tag := range plan.Tags {
+		if e := validationutil.ValidateTag(tag); e != nil {
+			err = multierror.Append(err, fmt.Errorf("tag %q invalid: %w", tag, e))
+		}
+	}
+
+	for _, instance := range plan.GetHistory().GetFormerInstances() {
+		if e := validationutil.ValidateID(instance, validationutil.IDMaxLen); e != nil {
+			err = multierror.Append(err, fmt.Errorf("former instance ID %q invalid: %w", instance, e))
+		}
+	}
+	if strings.HasPrefix(plan.GetHistory().GetHostname(), "-") {
+		err = multierror.Append(err, fmt.Errorf("invalid hostname %q", plan.GetHistory().GetHostname()))
+	}
+
+	if plan.JitterMinutes < 0 {
+		err = multierror.Append(err, errors.New("jitter must be non-negative"))
+	}
+
+	if plan.CatchupPolicy == v1.Plan_CATCHUP_POLICY_RUN_ONCE_WITHIN_WINDOW && plan.CatchupWindowMinutes <= 0 {
+		err = multierror.Append(err, errors.New("catchup window must be positive when catchup policy is run once within window"))
+	}
+
+	if plan.BackupWindow != nil {
+		if e := backupwindow.Validate(plan.BackupWindow); e != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid backup window: %w", e))
+		}
+	}
+
+	if policy := plan.RetryPolicy; policy != nil {
+		if policy.MaxAttempts < 0 || policy.InitialBackoffSeconds < 0 || policy.MaxBackoffSeconds < 0 {
+			err = multierror.Append(err, errors.New("retry policy values must be non-negative"))
+		}
+	}
+
+	if copyTo := plan.CopyTo; copyTo != nil && copyTo.Repo != "" {
+		if _, ok := repos[copyTo.Repo]; !ok {
+			err = multierror.Append(err, fmt.Errorf("copy to repo %q not found", copyTo.Repo))
+		}
+		if copyTo.Repo == plan.Repo {
+			err = multierror.Append(err, errors.New("copy to repo must differ from the plan's repo"))
+		}
+		if src, dst := repos[plan.Repo], repos[copyTo.Repo]; src != nil && dst != nil && src.Namespace != dst.Namespace {
+			err = multierror.Append(err, errors.New("copy to repo must be in the same namespace as the plan's repo"))
+		}
+		if copyTo.Cron != "" {
+			if _, e := cronutil.Parse(copyTo.Cron); e != nil {
+				err = multierror.Append(err, fmt.Errorf("invalid copy to cron %q: %w", copyTo.Cron, e))
+			}
+		}
+	}
+
+	if e := validateHooks(plan.Hooks); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	for name, cmd := range map[string]*v1.BackupCommand{"pre": plan.PreBackup, "post": plan.PostBackup} {
+		if cmd.GetTimeoutSeconds() < 0 {
+			err = multierror.Append(err, fmt.Errorf("%s backup command timeout must be non-negative", name))
+		}
+	}
+
+	if criteria := plan.SuccessCriteria; criteria != nil {
+		if criteria.MinFilesProcessed < 0 || criteria.MinBytesProcessed < 0 {
+			err = multierror.Append(err, errors.New("success criteria minimums must be non-negative"))
+		}
+		for idx, p := range criteria.RequiredPaths {
+			if p == "" {
+				err = multierror.Append(err, fmt.Errorf("success criteria required path[%d] cannot be empty", idx))
+			}
+		}
 	}
 
 	if plan.Retention != nil && plan.Retention.Policy == nil {
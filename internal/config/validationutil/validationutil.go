@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 var (
@@ -16,6 +17,26 @@ func SanitizeID(id string) string {
 	return sanitizeIDRegex.ReplaceAllString(id, "_")
 }
 
+// reservedTagPrefixes are the prefixes of the tags backrest selects a plan's snapshots by.
+var reservedTagPrefixes = []string{"plan:", "created-by:"}
+
+// ValidateTag checks if a snapshot tag may be set by a user. It returns an error if the tag is empty, can't be passed
+// to restic as a tag or is one of the tags backrest manages.
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return errors.New("empty")
+	}
+	if strings.Contains(tag, ",") || strings.HasPrefix(tag, "-") {
+		return errors.New("must not contain commas or start with \"-\"")
+	}
+	for _, prefix := range reservedTagPrefixes {
+		if strings.HasPrefix(tag, prefix) {
+			return fmt.Errorf("tags starting with %q are reserved", prefix)
+		}
+	}
+	return nil
+}
+
 // ValidateID checks if an ID is valid.
 // It returns an error if the ID contains invalid characters, is empty, or is too long.
 // The maxLen parameter is the maximum length of the ID. If maxLen is 0, the ID length is not checked.
@@ -46,3 +46,16 @@ func TestSanitizeID(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTag(t *testing.T) {
+	for _, tag := range []string{"nightly", "keep forever", "a:b"} {
+		if err := ValidateTag(tag); err != nil {
+			t.Errorf("ValidateTag(%q) = %v, want nil", tag, err)
+		}
+	}
+	for _, tag := range []string{"", "a,b", "-x", "plan:test", "created-by:instance"} {
+		if err := ValidateTag(tag); err == nil {
+			t.Errorf("ValidateTag(%q) = nil, want an error", tag)
+		}
+	}
+}
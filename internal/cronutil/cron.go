@@ -0,0 +1,118 @@
+package cronutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gitploy-io/cronexpr"
+)
+
+// shorthands maps the supported @ shorthands to the equivalent 5 field expression.
+var shorthands = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron expression. Expressions are either standard 5 field cron expressions, 6 field
+// expressions with a leading seconds field, or one of the @ shorthands e.g. @daily.
+type Schedule struct {
+	seconds []int // sorted seconds of each matching minute at which the schedule fires.
+	minutes *cronexpr.Schedule
+}
+
+// Parse parses a cron expression, times are in UTC.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		std, ok := shorthands[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("unknown shorthand %q", expr)
+		}
+		expr = std
+	}
+
+	seconds := []int{0}
+	fields := strings.Fields(expr)
+	if len(fields) == 6 {
+		// seconds share the minute field's range, parse them as a minute field to reuse the field syntax.
+		secSched, err := cronexpr.Parse(fields[0] + " * * * *")
+		if err != nil {
+			return nil, fmt.Errorf("seconds field %q: %w", fields[0], err)
+		}
+		seconds = bitsToValues(uint64(secSched.Minute), 0, 59)
+		if len(seconds) == 0 {
+			return nil, fmt.Errorf("seconds field %q matches no seconds in 0-59", fields[0])
+		}
+		expr = strings.Join(fields[1:], " ")
+	}
+
+	sched, err := cronexpr.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &Schedule{seconds: seconds, minutes: sched}, nil
+}
+
+// ParseInLocation parses a cron expression whose times are in the named location.
+func ParseInLocation(expr string, locName string) (*Schedule, error) {
+	loc, err := time.LoadLocation(locName)
+	if err != nil {
+		return nil, err
+	}
+	sched, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	sched.minutes.Location = loc
+	return sched, nil
+}
+
+// Next returns the first time after t matching the schedule, or the zero time if there is none.
+func (s *Schedule) Next(t time.Time) time.Time {
+	minute := t.Truncate(time.Minute)
+	if s.minutes.Next(minute.Add(-time.Minute)).Equal(minute) {
+		for _, sec := range s.seconds {
+			if candidate := minute.Add(time.Duration(sec) * time.Second); candidate.After(t) {
+				return candidate
+			}
+		}
+	}
+
+	next := s.minutes.Next(minute)
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(time.Duration(s.seconds[0]) * time.Second)
+}
+
+// NextN returns the next n times after t matching the schedule.
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// ShortestInterval returns the shortest time between consecutive runs among the next n runs after t, or 0 if the
+// schedule runs fewer than twice.
+func (s *Schedule) ShortestInterval(t time.Time, n int) time.Duration {
+	var shortest time.Duration
+	times := s.NextN(t, n)
+	for i := 1; i < len(times); i++ {
+		if d := times[i].Sub(times[i-1]); shortest == 0 || d < shortest {
+			shortest = d
+		}
+	}
+	return shortest
+}
@@ -0,0 +1,90 @@
+package cronutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	at := func(hour, min, sec int) time.Time {
+		return time.Date(2024, 1, 1, hour, min, sec, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{expr: "0 2 * * *", from: at(1, 0, 0), want: at(2, 0, 0)},
+		{expr: "0 2 * * *", from: at(2, 0, 0), want: time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", from: at(1, 30, 0), want: at(2, 0, 0)},
+		{expr: "@DAILY", from: at(1, 30, 0), want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "*/15 * * * * *", from: at(1, 0, 20), want: at(1, 0, 30)},
+		{expr: "*/15 * * * * *", from: at(1, 0, 45), want: at(1, 1, 0)},
+		{expr: "30 0 2 * * *", from: at(2, 0, 10), want: at(2, 0, 30)},
+		{expr: "30 0 2 * * *", from: at(2, 0, 30), want: time.Date(2024, 1, 2, 2, 0, 30, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		sched, err := Parse(tc.expr)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tc.expr, err)
+			continue
+		}
+		if got := sched.Next(tc.from); !got.Equal(tc.want) {
+			t.Errorf("Parse(%q).Next(%v) = %v, want %v", tc.expr, tc.from, got, tc.want)
+		}
+	}
+}
+
+func TestScheduleNextN(t *testing.T) {
+	sched, err := Parse("0 */6 * * *")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	got := sched.NextN(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), 3)
+	want := []time.Time{
+		time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("NextN() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("NextN()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestScheduleShortestInterval(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Duration
+	}{
+		{expr: "0 2 * * *", want: 24 * time.Hour},
+		{expr: "0 2,3 * * *", want: time.Hour},
+		{expr: "* 2 * * *", want: time.Minute}, // a mistyped daily schedule runs every minute of the hour.
+		{expr: "*/10 * * * * *", want: 10 * time.Second},
+	}
+	for _, tc := range tests {
+		sched, err := Parse(tc.expr)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tc.expr, err)
+			continue
+		}
+		if got := sched.ShortestInterval(from, 100); got != tc.want {
+			t.Errorf("Parse(%q).ShortestInterval() = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{"@fortnightly", "60 * * * * *", "* * * *", "not a cron"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package cronutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var weekdayNames = []string{"Sundays", "Mondays", "Tuesdays", "Wednesdays", "Thursdays", "Fridays", "Saturdays"}
+
+var monthNames = []string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+
+// maxListedTimes is the number of times of day after which a description lists hours and minutes separately.
+const maxListedTimes = 4
+
+// Describe returns a human readable description of a cron expression e.g. "every day at 02:00 except Sundays".
+// The expression is parsed with the same parser used to schedule plans so invalid expressions return an error.
+func Describe(expr string) (string, error) {
+	sched, err := Parse(expr)
+	if err != nil {
+		return "", fmt.Errorf("parse cron %q: %w", expr, err)
+	}
+
+	minutes := bitsToValues(uint64(sched.minutes.Minute), 0, 59)
+	hours := bitsToValues(uint64(sched.minutes.Hour), 0, 23)
+	doms := bitsToValues(uint64(sched.minutes.Dom), 1, 31)
+	months := bitsToValues(uint64(sched.minutes.Month), 1, 12)
+	dows := bitsToValues(uint64(sched.minutes.Dow), 0, 6)
+
+	timePhrase := describeTime(minutes, hours)
+	dayPhrase, exceptPhrase := describeDays(doms, dows)
+
+	secondsSuffix := ""
+	if secondsPhrase := describeSeconds(sched.seconds); secondsPhrase != "" {
+		if timePhrase == "every minute" && strings.HasPrefix(secondsPhrase, "every ") {
+			timePhrase = secondsPhrase
+		} else {
+			secondsSuffix = " (" + secondsPhrase + ")"
+		}
+	}
+
+	var sb strings.Builder
+	if strings.HasPrefix(timePhrase, "at ") {
+		sb.WriteString(dayPhrase)
+		sb.WriteString(" ")
+		sb.WriteString(timePhrase)
+	} else {
+		sb.WriteString(timePhrase)
+		if dayPhrase != "every day" {
+			sb.WriteString(" ")
+			sb.WriteString(dayPhrase)
+		}
+	}
+	if exceptPhrase != "" {
+		sb.WriteString(" ")
+		sb.WriteString(exceptPhrase)
+	}
+	if len(months) != 12 {
+		names := make([]string, 0, len(months))
+		for _, m := range months {
+			names = append(names, monthNames[m])
+		}
+		sb.WriteString(" in ")
+		sb.WriteString(joinList(names))
+	}
+	sb.WriteString(secondsSuffix)
+	return sb.String(), nil
+}
+
+// describeSeconds returns the phrase describing the seconds field, or an empty string for schedules that fire at the
+// start of each minute.
+func describeSeconds(seconds []int) string {
+	switch {
+	case len(seconds) == 1 && seconds[0] == 0:
+		return ""
+	case len(seconds) == 60:
+		return "every second"
+	case stepOf(seconds, 60) > 1:
+		return fmt.Sprintf("every %d seconds", stepOf(seconds, 60))
+	case len(seconds) == 1:
+		return fmt.Sprintf("at second %d", seconds[0])
+	default:
+		return fmt.Sprintf("at seconds %s", joinInts(seconds))
+	}
+}
+
+func describeTime(minutes, hours []int) string {
+	allMinutes := len(minutes) == 60
+	allHours := len(hours) == 24
+
+	switch {
+	case allMinutes && allHours:
+		return "every minute"
+	case allHours:
+		if step := stepOf(minutes, 60); step > 1 {
+			return fmt.Sprintf("every %d minutes", step)
+		}
+		if len(minutes) == 1 {
+			return fmt.Sprintf("every hour at minute %d", minutes[0])
+		}
+		return fmt.Sprintf("every hour at minutes %s", joinInts(minutes))
+	case allMinutes:
+		return fmt.Sprintf("every minute during hours %s", joinInts(hours))
+	}
+
+	// two hours read better as a list of times e.g. "at 00:00 and 12:00".
+	if step := stepOf(hours, 24); step > 1 && len(hours) > 2 && len(minutes) == 1 {
+		return fmt.Sprintf("every %d hours at minute %d", step, minutes[0])
+	}
+
+	if len(minutes)*len(hours) > maxListedTimes {
+		return fmt.Sprintf("at minutes %s past hours %s", joinInts(minutes), joinInts(hours))
+	}
+	var times []string
+	for _, h := range hours {
+		for _, m := range minutes {
+			times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+		}
+	}
+	return "at " + joinList(times)
+}
+
+// describeDays returns the phrase describing the days a schedule runs on and, if the schedule runs on most days of
+// the week, a phrase describing the excluded days.
+func describeDays(doms, dows []int) (string, string) {
+	allDoms := len(doms) == 31
+	allDows := len(dows) == 7
+
+	if allDoms && allDows {
+		return "every day", ""
+	}
+
+	var dowNames []string
+	for _, d := range dows {
+		dowNames = append(dowNames, weekdayNames[d])
+	}
+	domPhrase := fmt.Sprintf("on day %s of the month", joinInts(doms))
+
+	if allDoms {
+		if len(dows) >= 5 {
+			var excluded []string
+			for d := 0; d < 7; d++ {
+				if !slices.Contains(dows, d) {
+					excluded = append(excluded, weekdayNames[d])
+				}
+			}
+			return "every day", "except " + joinList(excluded)
+		}
+		return "on " + joinList(dowNames), ""
+	}
+	if allDows {
+		return domPhrase, ""
+	}
+	// cron runs on days matching either field when both are restricted.
+	return fmt.Sprintf("%s or on %s", domPhrase, joinList(dowNames)), ""
+}
+
+// bitsToValues returns the values in [min, max] whose bits are set.
+func bitsToValues(bits uint64, min, max int) []int {
+	var values []int
+	for i := min; i <= max; i++ {
+		if bits&(1<<uint(i)) != 0 {
+			values = append(values, i)
+		}
+	}
+	return values
+}
+
+// stepOf returns the step between values if they form the sequence 0, step, 2*step, ... covering [0, size), or 0.
+func stepOf(values []int, size int) int {
+	if len(values) < 2 || values[0] != 0 {
+		return 0
+	}
+	step := values[1]
+	for i, v := range values {
+		if v != i*step {
+			return 0
+		}
+	}
+	if values[len(values)-1]+step < size {
+		return 0
+	}
+	return step
+}
+
+func joinInts(values []int) string {
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		strs = append(strs, fmt.Sprintf("%d", v))
+	}
+	return joinList(strs)
+}
+
+func joinList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+	}
+}
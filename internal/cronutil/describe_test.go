@@ -0,0 +1,44 @@
+package cronutil
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "* * * * *", want: "every minute"},
+		{expr: "*/15 * * * *", want: "every 15 minutes"},
+		{expr: "5 * * * *", want: "every hour at minute 5"},
+		{expr: "0 */6 * * *", want: "every 6 hours at minute 0"},
+		{expr: "0 2 * * *", want: "every day at 02:00"},
+		{expr: "0 2 * * 1-6", want: "every day at 02:00 except Sundays"},
+		{expr: "30 14 * * MON,FRI", want: "on Mondays and Fridays at 14:30"},
+		{expr: "0 0,12 * * *", want: "every day at 00:00 and 12:00"},
+		{expr: "0 3 1 * *", want: "on day 1 of the month at 03:00"},
+		{expr: "0 3 1 * 0", want: "on day 1 of the month or on Sundays at 03:00"},
+		{expr: "0 0 1 1,7 *", want: "on day 1 of the month at 00:00 in January and July"},
+		{expr: "*/30 * * * 6", want: "every 30 minutes on Saturdays"},
+		{expr: "@daily", want: "every day at 00:00"},
+		{expr: "@hourly", want: "every hour at minute 0"},
+		{expr: "*/10 * * * * *", want: "every 10 seconds"},
+		{expr: "30 0 2 * * *", want: "every day at 02:00 (at second 30)"},
+	}
+
+	for _, tc := range tests {
+		got, err := Describe(tc.expr)
+		if err != nil {
+			t.Errorf("Describe(%q) error: %v", tc.expr, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Describe(%q) = %q, want %q", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestDescribeInvalid(t *testing.T) {
+	if _, err := Describe("not a cron"); err == nil {
+		t.Errorf("expected error for invalid cron expression")
+	}
+}
@@ -0,0 +1,106 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"go.uber.org/zap"
+)
+
+// ExecuteBackupCommand runs a plan's pre or post backup command and records its output as a hook operation.
+// The event is used to label the operation, post backup commands are identified by CONDITION_SNAPSHOT_END.
+// The returned error is the command's failure, the caller decides how to apply the command's failure policy.
+func (e *HookExecutor) ExecuteBackupCommand(ctx context.Context, flowID int64, repo *v1.Repo, plan *v1.Plan, name string, cmd *v1.BackupCommand, event v1.Hook_Condition, vars HookVars) error {
+	vars.Repo = repo
+	vars.Plan = plan
+	vars.Event = event
+	vars.CurTime = time.Now()
+
+	op := &v1.Operation{
+		Status:          v1.OperationStatus_STATUS_INPROGRESS,
+		PlanId:          plan.GetId(),
+		RepoId:          repo.GetId(),
+		InstanceId:      e.config.Instance,
+		FlowId:          flowID,
+		DisplayMessage:  "running " + name,
+		UnixTimeStartMs: curTimeMs(),
+		Op: &v1.Operation_OperationRunHook{
+			OperationRunHook: &v1.OperationRunHook{
+				Name:      name,
+				Condition: event,
+			},
+		},
+	}
+	if err := e.oplog.Add(op); err != nil {
+		zap.S().Errorf("execute backup command: add operation: %v", err)
+		return errors.New("couldn't create operation")
+	}
+
+	zap.L().Info("running backup command", zap.String("plan", plan.GetId()), zap.Int64("opId", op.Id), zap.String("name", name))
+	output := &bytes.Buffer{}
+	cmdErr := e.runBackupCommand(ctx, cmd, event, vars, output)
+	if cmdErr != nil {
+		fmt.Fprintf(output, "Error: %v", cmdErr)
+		op.Status = v1.OperationStatus_STATUS_ERROR
+		op.DisplayMessage = cmdErr.Error()
+	} else {
+		op.Status = v1.OperationStatus_STATUS_SUCCESS
+	}
+
+	outputRef, err := e.logStore.Write(output.Bytes())
+	if err != nil {
+		zap.S().Errorf("execute backup command: write logstore: %v", err)
+	}
+	op.Logref = outputRef
+	op.UnixTimeEndMs = curTimeMs()
+	if err := e.oplog.Update(op); err != nil {
+		zap.S().Errorf("execute backup command: update oplog: %v", err)
+	}
+	return cmdErr
+}
+
+func (e *HookExecutor) runBackupCommand(ctx context.Context, cmd *v1.BackupCommand, event v1.Hook_Condition, vars HookVars, output *bytes.Buffer) error {
+	h := &Hook{}
+	command, err := h.renderTemplate(cmd.GetCommand(), vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	if cmd.GetTimeoutSeconds() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cmd.GetTimeoutSeconds())*time.Second)
+		defer cancel()
+	}
+
+	if err := runShellCommand(ctx, command, backupCommandEnv(event, vars), output); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %ds: %w", cmd.GetTimeoutSeconds(), err)
+		}
+		return err
+	}
+	return nil
+}
+
+// backupCommandEnv returns the environment variables describing the backup to a pre or post backup command.
+func backupCommandEnv(event v1.Hook_Condition, vars HookVars) []string {
+	env := append(repo.PlanEnv(vars.Plan),
+		"BACKREST_PLAN_ID="+vars.Plan.GetId(),
+		"BACKREST_REPO_ID="+vars.Repo.GetId(),
+	)
+	if vars.AnnotationsFile != "" {
+		env = append(env, "BACKREST_ANNOTATIONS_FILE="+vars.AnnotationsFile)
+	}
+	if event == v1.Hook_CONDITION_SNAPSHOT_END {
+		status := "success"
+		if vars.Error != "" {
+			status = "error"
+		}
+		env = append(env, "BACKREST_SNAPSHOT_ID="+vars.SnapshotId, "BACKREST_BACKUP_STATUS="+status)
+	}
+	return env
+}
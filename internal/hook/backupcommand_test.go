@@ -0,0 +1,50 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+func TestBackupCommandEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+
+	e := &HookExecutor{}
+	vars := HookVars{
+		Plan:       &v1.Plan{Id: "myplan", Env: []*v1.EnvVar{{Name: "MY_PROXY", Value: "proxy.lan"}}},
+		Repo:       &v1.Repo{Id: "myrepo"},
+		SnapshotId: "abc123",
+	}
+	cmd := &v1.BackupCommand{Command: "echo $BACKREST_PLAN_ID $BACKREST_REPO_ID $BACKREST_SNAPSHOT_ID $BACKREST_BACKUP_STATUS $MY_PROXY"}
+
+	var output bytes.Buffer
+	if err := e.runBackupCommand(context.Background(), cmd, v1.Hook_CONDITION_SNAPSHOT_END, vars, &output); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output.String(), "myplan myrepo abc123 success proxy.lan") {
+		t.Errorf("expected output to contain backup env vars, got: %s", output.String())
+	}
+}
+
+func TestBackupCommandTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+
+	e := &HookExecutor{}
+	cmd := &v1.BackupCommand{Command: "sleep 10", TimeoutSeconds: 1}
+
+	err := e.runBackupCommand(context.Background(), cmd, v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected timeout error, got: %v", err)
+	}
+}
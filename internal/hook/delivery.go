@@ -0,0 +1,300 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/containrrr/shoutrrr"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/metrics"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"go.uber.org/zap"
+)
+
+const (
+	// deliveryTimeout bounds a single retry of a queued delivery.
+	deliveryTimeout = 30 * time.Second
+
+	// deliveryInitialBackoff is the wait before a delivery's first retry, it doubles after each attempt up to deliveryMaxBackoff.
+	deliveryInitialBackoff = 30 * time.Second
+	deliveryMaxBackoff     = time.Hour
+
+	// maxDeliveryAttempts is the attempts made, including the hook's own, before a delivery becomes a dead letter.
+	maxDeliveryAttempts = 10
+
+	// maxDeadLetters bounds the dead letters kept, the oldest are dropped first.
+	maxDeadLetters = 100
+)
+
+var ErrDeliveryNotFound = errors.New("hook delivery not found")
+
+// deliver sends the notification, failures are returned as a HookErrorUndelivered so that the notification can be queued
+// for retry. The receiver's response, if any, is returned.
+func deliver(ctx context.Context, d *v1.HookDelivery) (string, error) {
+	body, err := send(ctx, d)
+	if err != nil {
+		return "", &HookErrorUndelivered{Err: err, Delivery: d}
+	}
+	return body, nil
+}
+
+func send(ctx context.Context, d *v1.HookDelivery) (string, error) {
+	switch r := d.Request.(type) {
+	case *v1.HookDelivery_Http:
+		return request(ctx, r.Http.Method, r.Http.Url, r.Http.ContentType, r.Http.Body)
+	case *v1.HookDelivery_Shoutrrr:
+		if err := shoutrrr.Send(r.Shoutrrr.Url, r.Shoutrrr.Message); err != nil {
+			return "", fmt.Errorf("send notification to %q: %w", r.Shoutrrr.Url, err)
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("unknown delivery request: %v", r)
+	}
+}
+
+func httpDelivery(method, url, contentType string, body []byte) *v1.HookDelivery {
+	return &v1.HookDelivery{
+		Destination: destination(url),
+		Request: &v1.HookDelivery_Http{
+			Http: &v1.HookDelivery_HttpRequest{
+				Method:      method,
+				Url:         url,
+				ContentType: contentType,
+				Body:        body,
+			},
+		},
+	}
+}
+
+func shoutrrrDelivery(url, message string) *v1.HookDelivery {
+	return &v1.HookDelivery{
+		Destination: destination(url),
+		Request: &v1.HookDelivery_Shoutrrr{
+			Shoutrrr: &v1.HookDelivery_ShoutrrrMessage{
+				Url:     url,
+				Message: message,
+			},
+		},
+	}
+}
+
+// destination returns the scheme and host of a notification URL, dropping the credentials, path and query that often
+// hold tokens.
+func destination(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" {
+		return "unknown"
+	}
+	return u.Scheme + "://" + u.Hostname()
+}
+
+// DeliveryQueue retries hook notifications that failed to send, with exponential backoff. Deliveries are persisted in the
+// oplog's database so that they are retried across restarts, deliveries that run out of attempts are kept as dead letters.
+type DeliveryQueue struct {
+	oplog  *oplog.OpLog
+	wakeup chan struct{}
+
+	initialBackoff time.Duration
+}
+
+func NewDeliveryQueue(oplog *oplog.OpLog) *DeliveryQueue {
+	return &DeliveryQueue{
+		oplog:          oplog,
+		wakeup:         make(chan struct{}, 1),
+		initialBackoff: deliveryInitialBackoff,
+	}
+}
+
+// Enqueue queues a delivery whose first attempt failed with err, it is retried once its backoff expires.
+func (q *DeliveryQueue) Enqueue(d *v1.HookDelivery, err error) error {
+	metrics.HookDeliveryFailures.WithLabelValues(d.RepoId, d.PlanId).Inc()
+	now := time.Now()
+	d.Id = 0
+	d.CreatedMs = now.UnixMilli()
+	d.Attempts = 1
+	d.LastError = err.Error()
+	d.NextAttemptMs = now.Add(q.backoff(d.Attempts)).UnixMilli()
+	if err := q.oplog.PutHookDelivery(d); err != nil {
+		return fmt.Errorf("queue hook delivery: %w", err)
+	}
+	q.notify()
+	return nil
+}
+
+// Retry moves a dead letter back to the queue to be attempted immediately, it gets a fresh set of attempts.
+func (q *DeliveryQueue) Retry(id int64) error {
+	deliveries, err := q.oplog.GetHookDeliveries()
+	if err != nil {
+		return fmt.Errorf("get hook deliveries: %w", err)
+	}
+	for _, d := range deliveries {
+		if d.Id != id || !d.DeadLetter {
+			continue
+		}
+		d.DeadLetter = false
+		d.Attempts = 0
+		d.NextAttemptMs = time.Now().UnixMilli()
+		if err := q.oplog.PutHookDelivery(d); err != nil {
+			return fmt.Errorf("requeue hook delivery %v: %w", id, err)
+		}
+		q.notify()
+		return nil
+	}
+	return ErrDeliveryNotFound
+}
+
+func (q *DeliveryQueue) notify() {
+	select {
+	case q.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Run attempts queued deliveries as they come due until ctx is cancelled.
+func (q *DeliveryQueue) Run(ctx context.Context) {
+	for {
+		next, err := q.deliverDue(ctx)
+		if err != nil {
+			zap.S().Errorf("hook delivery queue: %v", err)
+			next = time.Now().Add(time.Minute)
+		}
+
+		var timer *time.Timer
+		var due <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			due = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-q.wakeup:
+		case <-due:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// deliverDue attempts the deliveries that are due and returns when the next one comes due, zero if none are queued.
+func (q *DeliveryQueue) deliverDue(ctx context.Context) (time.Time, error) {
+	deliveries, err := q.oplog.GetHookDeliveries()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get hook deliveries: %w", err)
+	}
+
+	var next time.Time
+	for _, d := range deliveries {
+		if d.DeadLetter {
+			continue
+		}
+		if time.Now().UnixMilli() >= d.NextAttemptMs {
+			pending, err := q.attempt(ctx, d)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if ctx.Err() != nil {
+				return time.Time{}, nil
+			}
+			if !pending {
+				continue
+			}
+		}
+		if due := time.UnixMilli(d.NextAttemptMs); next.IsZero() || due.Before(next) {
+			next = due
+		}
+	}
+	return next, nil
+}
+
+// attempt sends the delivery and records the outcome, it returns true if the delivery is still queued for another attempt.
+func (q *DeliveryQueue) attempt(ctx context.Context, d *v1.HookDelivery) (bool, error) {
+	sendCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	_, err := send(sendCtx, d)
+	cancel()
+	if ctx.Err() != nil {
+		// shutting down, the attempt is retried after the restart.
+		return true, nil
+	}
+	d.Attempts++
+
+	if err == nil {
+		zap.S().Infof("delivered notification of hook %v to %v after %d attempts", d.Hook, d.Destination, d.Attempts)
+		if err := q.oplog.DeleteHookDeliveries(d.Id); err != nil {
+			return false, fmt.Errorf("delete hook delivery %v: %w", d.Id, err)
+		}
+		q.updateOperation(d, v1.OperationStatus_STATUS_SUCCESS, fmt.Sprintf("delivered to %v after %d attempts", d.Destination, d.Attempts))
+		return false, nil
+	}
+
+	metrics.HookDeliveryFailures.WithLabelValues(d.RepoId, d.PlanId).Inc()
+	d.LastError = err.Error()
+	if d.Attempts < maxDeliveryAttempts {
+		d.NextAttemptMs = time.Now().Add(q.backoff(d.Attempts)).UnixMilli()
+		if err := q.oplog.PutHookDelivery(d); err != nil {
+			return false, fmt.Errorf("update hook delivery %v: %w", d.Id, err)
+		}
+		return true, nil
+	}
+
+	zap.S().Warnf("giving up on notification of hook %v to %v after %d attempts: %v", d.Hook, d.Destination, d.Attempts, err)
+	metrics.HookDeliveryDeadLetters.WithLabelValues(d.RepoId, d.PlanId).Inc()
+	d.DeadLetter = true
+	d.NextAttemptMs = 0
+	if err := q.oplog.PutHookDelivery(d); err != nil {
+		return false, fmt.Errorf("update hook delivery %v: %w", d.Id, err)
+	}
+	q.updateOperation(d, v1.OperationStatus_STATUS_ERROR, fmt.Sprintf("gave up delivering to %v after %d attempts: %v", d.Destination, d.Attempts, err))
+	return false, q.pruneDeadLetters()
+}
+
+func (q *DeliveryQueue) backoff(attempts int32) time.Duration {
+	backoff := q.initialBackoff
+	for i := int32(1); i < attempts && backoff < deliveryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, deliveryMaxBackoff)
+}
+
+// updateOperation records the delivery's outcome on its hook's operation, if the operation still exists.
+func (q *DeliveryQueue) updateOperation(d *v1.HookDelivery, status v1.OperationStatus, message string) {
+	op, err := q.oplog.Get(d.OperationId)
+	if err != nil {
+		if !errors.Is(err, oplog.ErrNotExist) {
+			zap.S().Errorf("get operation %v of hook delivery: %v", d.OperationId, err)
+		}
+		return
+	}
+	op.Status = status
+	op.DisplayMessage = message
+	if err := q.oplog.Update(op); err != nil {
+		zap.S().Errorf("update operation %v of hook delivery: %v", d.OperationId, err)
+	}
+}
+
+// pruneDeadLetters drops the oldest dead letters beyond maxDeadLetters.
+func (q *DeliveryQueue) pruneDeadLetters() error {
+	deliveries, err := q.oplog.GetHookDeliveries()
+	if err != nil {
+		return fmt.Errorf("get hook deliveries: %w", err)
+	}
+	var dead []int64
+	for _, d := range deliveries {
+		if d.DeadLetter {
+			dead = append(dead, d.Id)
+		}
+	}
+	if len(dead) <= maxDeadLetters {
+		return nil
+	}
+	if err := q.oplog.DeleteHookDeliveries(dead[:len(dead)-maxDeadLetters]...); err != nil {
+		return fmt.Errorf("prune dead letters: %w", err)
+	}
+	return nil
+}
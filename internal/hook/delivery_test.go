@@ -0,0 +1,116 @@
+package hook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/rotatinglog"
+)
+
+func TestDeliveryQueue(t *testing.T) {
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("NewOpLog() error: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	var down atomic.Bool
+	var received atomic.Int32
+	down.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	config := &v1.Config{Instance: "test"}
+	repo := &v1.Repo{
+		Id: "repo",
+		Hooks: []*v1.Hook{{
+			Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+			Action: &v1.Hook_ActionWebhook{
+				ActionWebhook: &v1.Hook_Webhook{WebhookUrl: srv.URL + "/notify?token=secret", Method: v1.Hook_Webhook_POST},
+			},
+		}},
+	}
+
+	// the queue is only run after the hook, as if backrest restarted in between.
+	queue := NewDeliveryQueue(log)
+	queue.initialBackoff = time.Millisecond
+	executor := NewHookExecutor(config, log, rotatinglog.NewRotatingLog(t.TempDir(), 10), nil, queue)
+	if err := executor.ExecuteHooks(0, repo, &v1.Plan{Id: "plan", Repo: "repo"}, []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END}, HookVars{}); err != nil {
+		t.Fatalf("ExecuteHooks() error: %v", err)
+	}
+
+	deliveries, err := log.GetHookDeliveries()
+	if err != nil {
+		t.Fatalf("GetHookDeliveries() error: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Attempts != 1 || deliveries[0].Destination != "http://127.0.0.1" {
+		t.Fatalf("expected the failed delivery to be queued, got %v", deliveries)
+	}
+	op, err := log.Get(deliveries[0].OperationId)
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_WARNING {
+		t.Errorf("expected the hook's operation to warn of the retry, got %v", op.Status)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	queue = NewDeliveryQueue(log)
+	queue.initialBackoff = time.Millisecond
+	go queue.Run(ctx)
+
+	// the receiver stays down until the delivery runs out of attempts.
+	waitFor(t, ctx, func() bool {
+		deliveries, _ := log.GetHookDeliveries()
+		return len(deliveries) == 1 && deliveries[0].DeadLetter
+	})
+	if deliveries, _ := log.GetHookDeliveries(); deliveries[0].Attempts != maxDeliveryAttempts {
+		t.Errorf("expected %d attempts, got %d", maxDeliveryAttempts, deliveries[0].Attempts)
+	}
+	if op, _ := log.Get(op.Id); op.Status != v1.OperationStatus_STATUS_ERROR {
+		t.Errorf("expected the hook's operation to fail once the delivery is dead lettered, got %v", op.Status)
+	}
+
+	if err := queue.Retry(12345); err != ErrDeliveryNotFound {
+		t.Errorf("expected ErrDeliveryNotFound, got %v", err)
+	}
+	down.Store(false)
+	if err := queue.Retry(deliveries[0].Id); err != nil {
+		t.Fatalf("Retry() error: %v", err)
+	}
+	waitFor(t, ctx, func() bool {
+		deliveries, _ := log.GetHookDeliveries()
+		return len(deliveries) == 0
+	})
+	if received.Load() != 1 {
+		t.Errorf("expected the notification to be received once, got %d", received.Load())
+	}
+	if op, _ := log.Get(op.Id); op.Status != v1.OperationStatus_STATUS_SUCCESS {
+		t.Errorf("expected the hook's operation to succeed once delivered, got %v", op.Status)
+	}
+}
+
+func waitFor(t *testing.T, ctx context.Context, cond func() bool) {
+	t.Helper()
+	for !cond() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for condition")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package hook
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	_ "golang.org/x/crypto/ripemd160" // the hash openpgp falls back to for keys that don't list preferred hashes.
+)
+
+// encryptedTitle replaces templated titles of encrypted notifications, titles aren't encrypted by the services.
+const encryptedTitle = "Backrest Event"
+
+// renderNotification renders the content of a notification, encrypting it to the hook's key if it has one.
+func (h *Hook) renderNotification(template string, defaultTmpl string, vars HookVars) (string, error) {
+	payload, err := h.renderTemplateOrDefault(template, defaultTmpl, vars)
+	if err != nil {
+		return "", err
+	}
+	if h.EncryptionKey == "" {
+		return payload, nil
+	}
+	encrypted, err := encrypt(h.EncryptionKey, payload)
+	if err != nil {
+		return "", fmt.Errorf("encrypt notification: %w", err)
+	}
+	return encrypted, nil
+}
+
+// encrypt returns the payload as an ASCII armored OpenPGP message readable by the holders of the armored public key.
+func encrypt(armoredKey string, payload string) (string, error) {
+	keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKey))
+	if err != nil {
+		return "", fmt.Errorf("read public key: %w", err)
+	}
+
+	var buf bytes.Buffer
+	armored, err := armor.Encode(&buf, "PGP MESSAGE", nil)
+	if err != nil {
+		return "", err
+	}
+	w, err := openpgp.Encrypt(armored, keys, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte(payload)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	if err := armored.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,65 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestEncryptedNotification(t *testing.T) {
+	entity, err := openpgp.NewEntity("backrest", "", "backrest@example.com", nil)
+	if err != nil {
+		t.Fatalf("NewEntity() error: %v", err)
+	}
+	var publicKey bytes.Buffer
+	w, _ := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("Serialize() error: %v", err)
+	}
+	w.Close()
+
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	hook := Hook(v1.Hook{
+		Conditions:    []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_END},
+		EncryptionKey: publicKey.String(),
+		Action: &v1.Hook_ActionWebhook{
+			ActionWebhook: &v1.Hook_Webhook{WebhookUrl: srv.URL, Method: v1.Hook_Webhook_POST, Template: "backed up /home/alice"},
+		},
+	})
+	if err := hook.Do(context.Background(), v1.Hook_CONDITION_SNAPSHOT_END, HookVars{}, io.Discard); err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	if bytes.Contains(body, []byte("/home/alice")) {
+		t.Fatalf("expected the notification to be encrypted, got %s", body)
+	}
+
+	block, err := armor.Decode(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("armor.Decode() error: %v", err)
+	}
+	md, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("ReadMessage() error: %v", err)
+	}
+	plaintext, _ := io.ReadAll(md.UnverifiedBody)
+	if string(plaintext) != "backed up /home/alice" {
+		t.Errorf("expected the rendered template, got %q", plaintext)
+	}
+
+	if _, err := encrypt("not a key", "payload"); err == nil || !strings.Contains(err.Error(), "read public key") {
+		t.Errorf("expected an invalid key error, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package hook
 
-import "fmt"
+import (
+	"fmt"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
 
 // HookErrorCancel requests that the calling operation cancel itself. It must be handled explicitly caller. Subsequent hooks will be skipped.
 type HookErrorRequestCancel struct {
@@ -27,3 +31,18 @@ func (e HookErrorFatal) Error() string {
 func (e HookErrorFatal) Unwrap() error {
 	return e.Err
 }
+
+// HookErrorUndelivered is returned by notification hooks that failed to send their notification, the delivery holds the
+// rendered request so that it can be retried later.
+type HookErrorUndelivered struct {
+	Err      error
+	Delivery *v1.HookDelivery
+}
+
+func (e HookErrorUndelivered) Error() string {
+	return e.Err.Error()
+}
+
+func (e HookErrorUndelivered) Unwrap() error {
+	return e.Err
+}
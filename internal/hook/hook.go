@@ -2,6 +2,7 @@ package hook
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/ioutil"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/rotatinglog"
 	"go.uber.org/zap"
@@ -21,17 +23,35 @@ var (
 	defaultTemplate = `{{ .Summary }}`
 )
 
+const (
+	// defaultHookTimeout is used for hooks that do not set a timeout.
+	defaultHookTimeout = 5 * time.Minute
+
+	// hookOutputLimit bounds the output kept for a single hook, a hook that hangs may write indefinitely.
+	hookOutputLimit = 64 * 1024
+
+	// unassociatedPlanID records the hooks of repo level operations, it matches tasks.PlanForUnassociatedOperations.
+	unassociatedPlanID = "_unassociated_"
+)
+
 type HookExecutor struct {
 	config   *v1.Config
 	oplog    *oplog.OpLog
 	logStore *rotatinglog.RotatingLog
+	pool     *WorkerPool
+	queue    *DeliveryQueue
 }
 
-func NewHookExecutor(config *v1.Config, oplog *oplog.OpLog, bigOutputStore *rotatinglog.RotatingLog) *HookExecutor {
+// NewHookExecutor creates a hook executor. Hooks that can not affect the outcome of the operation (i.e. ON_ERROR_IGNORE)
+// are run in the background on the pool, if pool is nil all hooks are run synchronously. Notifications of such hooks
+// that fail to send are retried by the queue, if queue is nil they are not retried.
+func NewHookExecutor(config *v1.Config, oplog *oplog.OpLog, bigOutputStore *rotatinglog.RotatingLog, pool *WorkerPool, queue *DeliveryQueue) *HookExecutor {
 	return &HookExecutor{
 		config:   config,
 		oplog:    oplog,
 		logStore: bigOutputStore,
+		pool:     pool,
+		queue:    queue,
 	}
 }
 
@@ -45,6 +65,9 @@ func (e *HookExecutor) ExecuteHooks(flowID int64, repo *v1.Repo, plan *v1.Plan,
 		InstanceId: e.config.Instance,
 		FlowId:     flowID,
 	}
+	if operationBase.PlanId == "" {
+		operationBase.PlanId = unassociatedPlanID
+	}
 
 	vars.Repo = repo
 	vars.Plan = plan
@@ -120,20 +143,40 @@ func (e *HookExecutor) executeHook(op *v1.Operation, hook *Hook, event v1.Hook_C
 		return errors.New("couldn't create operation")
 	}
 
-	output := &bytes.Buffer{}
+	if hook.OnError == v1.Hook_ON_ERROR_IGNORE && e.pool != nil {
+		e.pool.Go(func() {
+			if err := e.runHook(op, hook, event, vars); err != nil {
+				zap.S().Errorf("error on background hook %v on condition %v: %v", op.GetOperationRunHook().GetName(), event.String(), err)
+			}
+		})
+		return nil
+	}
+	return e.runHook(op, hook, event, vars)
+}
+
+// runHook runs the hook for an operation that has already been added to the oplog and records the result.
+func (e *HookExecutor) runHook(op *v1.Operation, hook *Hook, event v1.Hook_Condition, vars HookVars) error {
+	output := ioutil.NewOutputCapturer(hookOutputLimit)
 	fmt.Fprintf(output, "triggering condition: %v\n", event.String())
 
 	var retErr error
-	if err := hook.Do(event, vars, io.MultiWriter(output)); err != nil {
+	var undelivered *HookErrorUndelivered
+	if err := hook.doWithTimeout(event, vars, output); err != nil {
 		output.Write([]byte(fmt.Sprintf("Error: %v", err)))
 		err = applyHookErrorPolicy(hook.OnError, err)
 		var cancelErr *HookErrorRequestCancel
-		if errors.As(err, &cancelErr) {
+		if e.queue != nil && hook.OnError == v1.Hook_ON_ERROR_IGNORE && errors.As(err, &undelivered) {
+			// the delivery is queued below, once the operation is recorded, and retried in the background.
+			op.Status = v1.OperationStatus_STATUS_WARNING
+			op.DisplayMessage = fmt.Sprintf("delivery failed, will retry: %v", err)
+			fmt.Fprintf(output, "\nQueued for retry.")
+		} else if errors.As(err, &cancelErr) {
 			// if it was a cancel then it successfully indicated it's intent to the caller
 			// no error should be displayed in the UI.
 			op.Status = v1.OperationStatus_STATUS_SUCCESS
 		} else {
 			op.Status = v1.OperationStatus_STATUS_ERROR
+			op.DisplayMessage = err.Error()
 		}
 		retErr = err
 	} else {
@@ -150,6 +193,17 @@ func (e *HookExecutor) executeHook(op *v1.Operation, hook *Hook, event v1.Hook_C
 	if err := e.oplog.Update(op); err != nil {
 		retErr = errors.Join(retErr, fmt.Errorf("update oplog: %w", err))
 	}
+
+	if undelivered != nil {
+		d := undelivered.Delivery
+		d.Hook = op.GetOperationRunHook().GetName()
+		d.RepoId = op.RepoId
+		d.PlanId = op.PlanId
+		d.OperationId = op.Id
+		if err := e.queue.Enqueue(d, undelivered.Err); err != nil {
+			retErr = errors.Join(retErr, err)
+		}
+	}
 	return retErr
 }
 
@@ -159,7 +213,38 @@ func curTimeMs() int64 {
 
 type Hook v1.Hook
 
-func (h *Hook) Do(event v1.Hook_Condition, vars HookVars, output io.Writer) error {
+// doWithTimeout runs the hook, giving up on it once its timeout expires. Not all actions can be interrupted, an action
+// still running when the timeout expires is abandoned and any further output it writes is discarded with the operation.
+func (h *Hook) doWithTimeout(event v1.Hook_Condition, vars HookVars, output io.Writer) error {
+	timeout := defaultHookTimeout
+	if h.TimeoutSeconds > 0 {
+		timeout = time.Duration(h.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("hook panicked: %v", r)
+			}
+		}()
+		done <- h.Do(ctx, event, vars, output)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %v: %w", timeout, err)
+		}
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
+
+func (h *Hook) Do(ctx context.Context, event v1.Hook_Condition, vars HookVars, output io.Writer) error {
 	if !slices.Contains(h.Conditions, event) {
 		return nil
 	}
@@ -168,15 +253,17 @@ func (h *Hook) Do(event v1.Hook_Condition, vars HookVars, output io.Writer) erro
 
 	switch action := h.Action.(type) {
 	case *v1.Hook_ActionCommand:
-		return h.doCommand(action, vars, output)
+		return h.doCommand(ctx, action, vars, output)
 	case *v1.Hook_ActionDiscord:
-		return h.doDiscord(action, vars, output)
+		return h.doDiscord(ctx, action, vars, output)
 	case *v1.Hook_ActionGotify:
-		return h.doGotify(action, vars, output)
+		return h.doGotify(ctx, action, vars, output)
 	case *v1.Hook_ActionSlack:
-		return h.doSlack(action, vars, output)
+		return h.doSlack(ctx, action, vars, output)
 	case *v1.Hook_ActionShoutrrr:
-		return h.doShoutrrr(action, vars, output)
+		return h.doShoutrrr(ctx, action, vars, output)
+	case *v1.Hook_ActionWebhook:
+		return h.doWebhook(ctx, action, vars, output)
 	default:
 		return fmt.Errorf("unknown hook action: %v", action)
 	}
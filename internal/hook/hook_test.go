@@ -2,10 +2,14 @@ package hook
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"os/exec"
 	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 )
@@ -20,7 +24,7 @@ func TestHookCommandInDefaultShell(t *testing.T) {
 		},
 	})
 
-	err := hook.Do(v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{})
+	err := hook.Do(context.Background(), v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{})
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -49,7 +53,7 @@ exit $counter`,
 		},
 	})
 
-	err := hook.Do(v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{})
+	err := hook.Do(context.Background(), v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{})
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -75,7 +79,7 @@ func TestCommandHookErrorHandling(t *testing.T) {
 		OnError: v1.Hook_ON_ERROR_CANCEL,
 	})
 
-	err := applyHookErrorPolicy(hook.OnError, hook.Do(v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{}))
+	err := applyHookErrorPolicy(hook.OnError, hook.Do(context.Background(), v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{}))
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -84,3 +88,60 @@ func TestCommandHookErrorHandling(t *testing.T) {
 		t.Fatalf("expected HookErrorRequestCancel, got %v", err)
 	}
 }
+
+func TestHookTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+
+	hook := Hook(v1.Hook{
+		Conditions: []v1.Hook_Condition{v1.Hook_CONDITION_SNAPSHOT_START},
+		Action: &v1.Hook_ActionCommand{
+			ActionCommand: &v1.Hook_Command{
+				Command: "sleep 10",
+			},
+		},
+		TimeoutSeconds: 1,
+	})
+
+	start := time.Now()
+	err := hook.doWithTimeout(v1.Hook_CONDITION_SNAPSHOT_START, HookVars{}, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected timeout error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected hook to be abandoned after its timeout, took %v", elapsed)
+	}
+}
+
+func TestWorkerPoolLimit(t *testing.T) {
+	pool := NewWorkerPool(2)
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	for i := 0; i < 10; i++ {
+		pool.Go(func() {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+	}
+	pool.Go(func() {
+		panic("a panicking hook should not crash the pool")
+	})
+	pool.Wait()
+
+	if maxRunning != 2 {
+		t.Errorf("expected at most 2 concurrent workers, got %d", maxRunning)
+	}
+}
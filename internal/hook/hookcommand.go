@@ -1,20 +1,66 @@
 package hook
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
 	"strings"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 )
 
-func (h *Hook) doCommand(cmd *v1.Hook_ActionCommand, vars HookVars, output io.Writer) error {
+func (h *Hook) doCommand(ctx context.Context, cmd *v1.Hook_ActionCommand, vars HookVars, output io.Writer) error {
 	command, err := h.renderTemplate(cmd.ActionCommand.Command, vars)
 	if err != nil {
 		return fmt.Errorf("template rendering: %w", err)
 	}
 
+	return runShellCommand(ctx, command, repo.PlanEnv(vars.Plan), output)
+}
+
+// runShellCommand runs the command in the shell named by its #! prefix or sh by default. env is appended to the
+// environment of the current process.
+func runShellCommand(ctx context.Context, command string, env []string, output io.Writer) error {
+	execCmd := shellCommand(ctx, command, env, output)
+	execCmd.Stderr = output
+	execCmd.Stdout = output
+
+	return execCmd.Run()
+}
+
+// CommandOutput runs the command like runShellCommand and returns what it printed to stdout. The script and what it
+// printed to stderr are written to log.
+func CommandOutput(ctx context.Context, command string, env []string, log io.Writer) ([]byte, error) {
+	execCmd := shellCommand(ctx, command, env, log)
+	stdout := &bytes.Buffer{}
+	execCmd.Stderr = log
+	execCmd.Stdout = stdout
+
+	if err := execCmd.Run(); err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// StartCommand starts the command like runShellCommand with its stdout written to stdout, the script and what it
+// prints to stderr are written to log. The caller waits for the returned command to exit.
+func StartCommand(ctx context.Context, command string, env []string, stdout io.Writer, log io.Writer) (*exec.Cmd, error) {
+	execCmd := shellCommand(ctx, command, env, log)
+	execCmd.Stderr = log
+	execCmd.Stdout = stdout
+	if err := execCmd.Start(); err != nil {
+		return nil, err
+	}
+	return execCmd, nil
+}
+
+// shellCommand returns a command running the script in the shell named by its #! prefix or sh by default, the script
+// is written to log.
+func shellCommand(ctx context.Context, command string, env []string, log io.Writer) *exec.Cmd {
 	// Parse out the shell to use if a #! prefix is present
 	shell := "sh"
 	if len(command) > 2 && command[0:2] == "#!" {
@@ -26,15 +72,16 @@ func (h *Hook) doCommand(cmd *v1.Hook_ActionCommand, vars HookVars, output io.Wr
 		command = command[nextLine+1:]
 	}
 
-	output.Write([]byte(fmt.Sprintf("------- script -------\n#! %v\n%v\n", shell, command)))
-	output.Write([]byte("------- output -------\n"))
+	log.Write([]byte(fmt.Sprintf("------- script -------\n#! %v\n%v\n", shell, command)))
+	log.Write([]byte("------- output -------\n"))
 
 	// Run the command in the specified shell
-	execCmd := exec.Command(shell)
+	execCmd := exec.CommandContext(ctx, shell)
 	execCmd.Stdin = strings.NewReader(command)
-
-	execCmd.Stderr = output
-	execCmd.Stdout = output
-
-	return execCmd.Run()
+	if len(env) > 0 {
+		execCmd.Env = append(execCmd.Environ(), env...)
+	}
+	// children of the shell may keep the output open after it is killed, don't wait on them once the context is done.
+	execCmd.WaitDelay = time.Second
+	return execCmd
 }
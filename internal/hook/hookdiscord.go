@@ -1,16 +1,17 @@
 package hook
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 )
 
-func (h *Hook) doDiscord(cmd *v1.Hook_ActionDiscord, vars HookVars, output io.Writer) error {
-	payload, err := h.renderTemplateOrDefault(cmd.ActionDiscord.GetTemplate(), defaultTemplate, vars)
+func (h *Hook) doDiscord(ctx context.Context, cmd *v1.Hook_ActionDiscord, vars HookVars, output io.Writer) error {
+	payload, err := h.renderNotification(cmd.ActionDiscord.GetTemplate(), defaultTemplate, vars)
 	if err != nil {
 		return fmt.Errorf("template rendering: %w", err)
 	}
@@ -28,6 +29,6 @@ func (h *Hook) doDiscord(cmd *v1.Hook_ActionDiscord, vars HookVars, output io.Wr
 	fmt.Fprintf(output, "Sending Discord message to %s\n---- payload ----\n", cmd.ActionDiscord.GetWebhookUrl())
 	output.Write(requestBytes)
 
-	_, err = post(cmd.ActionDiscord.GetWebhookUrl(), "application/json", bytes.NewReader(requestBytes))
+	_, err = deliver(ctx, httpDelivery(http.MethodPost, cmd.ActionDiscord.GetWebhookUrl(), "application/json", requestBytes))
 	return err
 }
@@ -1,25 +1,29 @@
 package hook
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"strings"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 )
 
-func (h *Hook) doGotify(cmd *v1.Hook_ActionGotify, vars HookVars, output io.Writer) error {
-	payload, err := h.renderTemplateOrDefault(cmd.ActionGotify.GetTemplate(), defaultTemplate, vars)
+func (h *Hook) doGotify(ctx context.Context, cmd *v1.Hook_ActionGotify, vars HookVars, output io.Writer) error {
+	payload, err := h.renderNotification(cmd.ActionGotify.GetTemplate(), defaultTemplate, vars)
 	if err != nil {
 		return fmt.Errorf("template rendering: %w", err)
 	}
 
-	title, err := h.renderTemplateOrDefault(cmd.ActionGotify.GetTitleTemplate(), "Backrest Event", vars)
-	if err != nil {
-		return fmt.Errorf("title template rendering: %w", err)
+	title := encryptedTitle
+	if h.EncryptionKey == "" {
+		title, err = h.renderTemplateOrDefault(cmd.ActionGotify.GetTitleTemplate(), "Backrest Event", vars)
+		if err != nil {
+			return fmt.Errorf("title template rendering: %w", err)
+		}
 	}
 
 	message := struct {
@@ -48,10 +52,9 @@ func (h *Hook) doGotify(cmd *v1.Hook_ActionGotify, vars HookVars, output io.Writ
 	fmt.Fprintf(output, "---- payload ----\n")
 	output.Write(b)
 
-	body, err := post(postUrl, "application/json", bytes.NewReader(b))
-
+	body, err := deliver(ctx, httpDelivery(http.MethodPost, postUrl, "application/json", b))
 	if err != nil {
-		return fmt.Errorf("send gotify message: %w", err)
+		return err
 	}
 
 	if body != "" {
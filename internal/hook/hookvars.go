@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"text/template"
 	"time"
 
 	"github.com/alessio/shellescape"
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
 	"github.com/garethgeorge/backrest/pkg/restic"
 )
 
@@ -24,6 +26,10 @@ type HookVars struct {
 	SnapshotStats *restic.BackupProgressEntry // the summary of the backup operation.
 	CurTime       time.Time                   // the current time as time.Time
 	Error         string                      // the error that caused the hook to run as a string.
+	Annotations   []*v1.SnapshotAnnotation    // the annotations of the backup's snapshot.
+	// AnnotationsFile is the path of the file a pre backup command writes "<key>=<value>" lines to, each line annotates
+	// the backup's snapshot. Only set for pre backup commands.
+	AnnotationsFile string
 }
 
 func (v HookVars) EventName(cond v1.Hook_Condition) string {
@@ -36,11 +42,34 @@ func (v HookVars) EventName(cond v1.Hook_Condition) string {
 		return "error"
 	case v1.Hook_CONDITION_SNAPSHOT_ERROR:
 		return "snapshot error"
+	case v1.Hook_CONDITION_SNAPSHOT_EXTERNAL:
+		return "external snapshot"
 	default:
 		return "unknown"
 	}
 }
 
+// DescribeCron returns a human readable description of a cron expression or the expression itself if it is invalid.
+func (v HookVars) DescribeCron(expr string) string {
+	desc, err := cronutil.Describe(expr)
+	if err != nil {
+		return expr
+	}
+	return desc
+}
+
+// DescribeSchedule returns a human readable description of the plan's interval or cron schedule, or of the plans it
+// runs after if it has no schedule of its own.
+func (v HookVars) DescribeSchedule(plan *v1.Plan) string {
+	if interval := plan.GetSchedule().GetInterval(); interval != "" {
+		return "every " + interval + " after the last successful backup"
+	}
+	if plan.GetCron() == "" && len(plan.GetAfter()) > 0 {
+		return "after successful backups of " + strings.Join(plan.GetAfter(), ", ")
+	}
+	return v.DescribeCron(plan.GetCron())
+}
+
 func (v HookVars) FormatTime(t time.Time) string {
 	return t.Format(time.RFC3339)
 }
@@ -162,6 +191,7 @@ Task: "{{ .Task }}" at {{ .FormatTime .CurTime }}
 Event: {{ .EventName .Event }}
 Repo: {{ .Repo.Id }} 
 Plan: {{ .Plan.Id }} 
+Schedule: {{ .DescribeSchedule .Plan }}
 Paths: 
 {{ range .Plan.Paths -}}
  - {{ . }}
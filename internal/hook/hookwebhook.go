@@ -0,0 +1,36 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+func (h *Hook) doWebhook(ctx context.Context, cmd *v1.Hook_ActionWebhook, vars HookVars, output io.Writer) error {
+	if cmd.ActionWebhook.GetMethod() == v1.Hook_Webhook_GET {
+		fmt.Fprintf(output, "Sending GET request to %s\n", cmd.ActionWebhook.GetWebhookUrl())
+		_, err := deliver(ctx, httpDelivery(http.MethodGet, cmd.ActionWebhook.GetWebhookUrl(), "", nil))
+		return err
+	}
+
+	payload, err := h.renderNotification(cmd.ActionWebhook.GetTemplate(), defaultTemplate, vars)
+	if err != nil {
+		return fmt.Errorf("template rendering: %w", err)
+	}
+
+	// templates commonly render a JSON document, anything else is sent as plain text.
+	contentType := "text/plain"
+	if json.Valid([]byte(payload)) {
+		contentType = "application/json"
+	}
+
+	fmt.Fprintf(output, "Sending POST request to %s\n---- payload ----\n", cmd.ActionWebhook.GetWebhookUrl())
+	output.Write([]byte(payload))
+
+	_, err = deliver(ctx, httpDelivery(http.MethodPost, cmd.ActionWebhook.GetWebhookUrl(), contentType, []byte(payload)))
+	return err
+}
@@ -1,22 +1,35 @@
 package hook
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 )
 
-func post(url string, contentType string, body io.Reader) (string, error) {
-	r, err := http.Post(url, contentType, body)
+func request(ctx context.Context, method string, url string, contentType string, body []byte) (string, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return "", fmt.Errorf("create request %v: %w", url, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	r, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("send request %v: %w", url, err)
 	}
+	defer r.Body.Close()
 	if r.StatusCode == 204 {
 		return "", nil
-	} else if r.StatusCode != 200 {
+	} else if r.StatusCode < 200 || r.StatusCode > 299 {
 		return "", fmt.Errorf("unexpected status %v: %s", r.StatusCode, r.Status)
 	}
-	defer r.Body.Close()
 	bodyBytes, err := io.ReadAll(r.Body)
 	if err != nil {
 		return "", fmt.Errorf("read response: %w", err)
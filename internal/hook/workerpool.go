@@ -0,0 +1,68 @@
+package hook
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// WorkerPool runs hooks in the background on a bounded number of goroutines so that slow hooks do not delay the
+// operations that trigger them. The limit may be changed at any time.
+type WorkerPool struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	running int
+	limit   int
+	wg      sync.WaitGroup
+}
+
+func NewWorkerPool(limit int) *WorkerPool {
+	p := &WorkerPool{limit: limit}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+func (p *WorkerPool) SetLimit(limit int) {
+	p.mu.Lock()
+	p.limit = limit
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+// Go queues f to run once a worker is available, it returns immediately. A panic in f is logged rather than
+// crashing the process.
+func (p *WorkerPool) Go(f func()) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.acquire()
+		defer p.release()
+		defer func() {
+			if r := recover(); r != nil {
+				zap.S().Errorf("hook worker panicked: %v", r)
+			}
+		}()
+		f()
+	}()
+}
+
+// Wait blocks until all queued work has completed.
+func (p *WorkerPool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *WorkerPool) acquire() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.running >= p.limit {
+		p.cond.Wait()
+	}
+	p.running++
+}
+
+func (p *WorkerPool) release() {
+	p.mu.Lock()
+	p.running--
+	p.mu.Unlock()
+	p.cond.Signal()
+}
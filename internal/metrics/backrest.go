@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+// backrest's metrics, served by the /metrics endpoint.
+var (
+	TaskQueueDepth = newGaugeFunc("backrest_task_queue_depth",
+		"Tasks queued for each repo, including tasks scheduled to run in the future.", "repo")
+	TaskQueueReady = newGaugeFunc("backrest_task_queue_ready",
+		"Tasks queued for each repo that are due but haven't started.", "repo")
+	TaskWaitSeconds = newHistogramVec("backrest_task_wait_seconds",
+		"Time from a task being due to it starting, e.g. while it waits for the repo's other tasks or a free slot.", DurationBuckets, "repo")
+	SchedulerTickLatencySeconds = newHistogram("backrest_scheduler_tick_latency_seconds",
+		"Time from a task being due to the scheduler taking it off its repo's queue, before it waits for a free slot.", DurationBuckets)
+	OplogWriteSeconds = newHistogramVec("backrest_oplog_write_seconds",
+		"Time taken by writes to the operation log by kind of write.", DurationBuckets, "write")
+	HookDeliveryFailures = newCounterVec("backrest_hook_delivery_failures_total",
+		"Failed attempts to deliver hook notifications.", "repo", "plan")
+	HookDeliveryDeadLetters = newCounterVec("backrest_hook_delivery_dead_letters_total",
+		"Hook notifications given up on after running out of delivery attempts.", "repo", "plan")
+	Backups = newCounterVec("backrest_backups_total",
+		"Backups finished by status.", "repo", "plan", "status")
+	LastSuccessfulBackup = newGaugeVec("backrest_last_successful_backup_timestamp_seconds",
+		"Time the last successful backup of each plan finished, backups with warnings are successful.", "repo", "plan")
+)
+
+// StatusLabel returns the value of status labels for the operation status e.g. "success".
+func StatusLabel(status v1.OperationStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), "STATUS_"))
+}
@@ -0,0 +1,112 @@
+// Package metrics collects backrest's metrics with the Prometheus client library and serves them for Prometheus to
+// scrape.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DurationBuckets are the upper bounds in seconds of the buckets of histograms of latencies, from 1ms to ~1h.
+var DurationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600}
+
+// Registry is the registry backrest's metrics are registered with and that the metrics endpoint serves. Registering a
+// metric name twice panics, so metrics are registered once when the package is initialized rather than by the
+// components reporting them: e.g. each orchestrator created in a process, as tests do, replaces the source of the
+// queue gauges with GaugeFunc.SetSource instead of registering its own.
+var Registry = prometheus.NewRegistry()
+
+// Handler serves the registry's metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+func newCounterVec(name, help string, labelNames ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labelNames)
+	Registry.MustRegister(c)
+	return c
+}
+
+func newGaugeVec(name, help string, labelNames ...string) *prometheus.GaugeVec {
+	g := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labelNames)
+	Registry.MustRegister(g)
+	return g
+}
+
+func newHistogram(name, help string, buckets []float64) prometheus.Histogram {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets})
+	Registry.MustRegister(h)
+	return h
+}
+
+func newHistogramVec(name, help string, buckets []float64, labelNames ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labelNames)
+	Registry.MustRegister(h)
+	return h
+}
+
+func newGaugeFunc(name, help string, labelNames ...string) *GaugeFunc {
+	g := &GaugeFunc{desc: prometheus.NewDesc(name, help, labelNames, nil), labels: len(labelNames)}
+	Registry.MustRegister(g)
+	return g
+}
+
+// GaugeFunc is a gauge whose samples are computed when the metrics are collected, e.g. from the orchestrator's state.
+// Unlike prometheus.GaugeFunc its samples may have labels whose values are only known when they are computed.
+type GaugeFunc struct {
+	desc    *prometheus.Desc
+	labels  int
+	mu      sync.Mutex
+	collect func(observe func(v float64, labelValues ...string))
+}
+
+var _ prometheus.Collector = &GaugeFunc{}
+
+// SetSource sets the function computing the samples, it replaces any previous source. collect calls observe with each
+// sample's value and label values, values observed for the same label values are summed.
+func (g *GaugeFunc) SetSource(collect func(observe func(v float64, labelValues ...string))) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.collect = collect
+}
+
+func (g *GaugeFunc) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+func (g *GaugeFunc) Collect(ch chan<- prometheus.Metric) {
+	g.mu.Lock()
+	collect := g.collect
+	g.mu.Unlock()
+	if collect == nil {
+		return
+	}
+
+	type sample struct {
+		labelValues []string
+		value       float64
+	}
+	samples := make(map[string]*sample)
+	var order []string
+	collect(func(v float64, labelValues ...string) {
+		if len(labelValues) != g.labels {
+			panic(fmt.Sprintf("metric %v has %d labels, got values %v", g.desc, g.labels, labelValues))
+		}
+		key := fmt.Sprintf("%q", labelValues)
+		s, ok := samples[key]
+		if !ok {
+			s = &sample{labelValues: labelValues}
+			samples[key] = s
+			order = append(order, key)
+		}
+		s.value += v
+	})
+	for _, key := range order {
+		s := samples[key]
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, s.value, s.labelValues...)
+	}
+}
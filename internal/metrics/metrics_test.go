@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestGaugeFunc(t *testing.T) {
+	g := &GaugeFunc{desc: prometheus.NewDesc("test_func", "A gauge func.", []string{"repo"}, nil), labels: 1}
+	if n := testutil.CollectAndCount(g); n != 0 {
+		t.Errorf("expected no samples without a source, got %d", n)
+	}
+
+	g.SetSource(func(observe func(v float64, labelValues ...string)) {
+		observe(0, "a")
+		observe(1, "b")
+		observe(1, "b")
+	})
+	want := `# HELP test_func A gauge func.
+# TYPE test_func gauge
+test_func{repo="a"} 0
+test_func{repo="b"} 2
+`
+	if err := testutil.CollectAndCompare(g, strings.NewReader(want)); err != nil {
+		t.Errorf("unexpected samples: %v", err)
+	}
+
+	g.SetSource(func(observe func(v float64, labelValues ...string)) {
+		observe(3, "c")
+	})
+	want = `# HELP test_func A gauge func.
+# TYPE test_func gauge
+test_func{repo="c"} 3
+`
+	if err := testutil.CollectAndCompare(g, strings.NewReader(want)); err != nil {
+		t.Errorf("unexpected samples after replacing the source: %v", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	if _, err := Registry.Gather(); err != nil {
+		t.Fatalf("Gather() error: %v", err)
+	}
+	if err := Registry.Register(prometheus.NewCounter(prometheus.CounterOpts{Name: "backrest_backups_total", Help: "A counter."})); err == nil {
+		t.Errorf("expected registering a metric name twice to fail")
+	}
+}
@@ -0,0 +1,48 @@
+package oplog
+
+import (
+	"fmt"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// GetAlertStates returns the state of every acknowledged or snoozed alert keyed by alert ID.
+func (o *OpLog) GetAlertStates() (map[string]*v1.AlertState, error) {
+	states := make(map[string]*v1.AlertState)
+	if err := o.store.ForEachValue(AlertStateBucket, func(k, v []byte) error {
+		state := &v1.AlertState{}
+		if err := proto.Unmarshal(v, state); err != nil {
+			return fmt.Errorf("unmarshalling state of alert %v: %w", string(k), err)
+		}
+		states[string(k)] = state
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// PutAlertState replaces the state of the alert.
+func (o *OpLog) PutAlertState(alertID string, state *v1.AlertState) error {
+	bytes, err := proto.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshalling state of alert %v: %w", alertID, err)
+	}
+	if err := o.store.PutValue(AlertStateBucket, []byte(alertID), bytes); err != nil {
+		return fmt.Errorf("putting state of alert %v: %w", alertID, err)
+	}
+	return nil
+}
+
+// DeleteAlertStates removes the states of the alerts, e.g. once they are resolved.
+func (o *OpLog) DeleteAlertStates(alertIDs ...string) error {
+	keys := make([][]byte, 0, len(alertIDs))
+	for _, id := range alertIDs {
+		keys = append(keys, []byte(id))
+	}
+	if err := o.store.DeleteValues(AlertStateBucket, keys...); err != nil {
+		return fmt.Errorf("deleting states of alerts %v: %w", alertIDs, err)
+	}
+	return nil
+}
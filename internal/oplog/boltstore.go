@@ -0,0 +1,634 @@
+package oplog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"github.com/garethgeorge/backrest/internal/oplog/serializationutil"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// BoltStore is a Store backed by a bbolt database, it's the default backend. Mutations of operations are written to
+// a journal first, see journal.
+type BoltStore struct {
+	mu      sync.RWMutex // held for writing while Compact replaces db.
+	db      *bolt.DB
+	journal *journal
+}
+
+var _ Store = &BoltStore{}
+
+// journalSeqKey is the key in the system bucket of the seq of the last journal record applied to the database.
+var journalSeqKey = []byte("journal_seq")
+
+// boltBuckets are the buckets of a bbolt database.
+var boltBuckets = [][]byte{
+	SystemBucket, OpLogBucket, RepoIndexBucket, PlanIndexBucket, SnapshotIndexBucket, FlowIdIndexBucket, InstanceIndexBucket, SnapshotStatsBucket, AlertStateBucket, HookDeliveryBucket, ShareLinkBucket, StatusIndexBucket, StartTimeIndexBucket,
+}
+
+// NewBoltStore opens or creates the bbolt database at databasePath. If the database can't be opened, or backrest
+// wasn't shut down cleanly and the database fails its integrity check, it's repaired with RepairBoltStore. Mutations
+// interrupted by the shutdown are then replayed from the journal.
+func NewBoltStore(databasePath string) (*BoltStore, error) {
+	if err := os.MkdirAll(path.Dir(databasePath), 0700); err != nil {
+		return nil, fmt.Errorf("error creating database directory: %s", err)
+	}
+
+	journalPath := databasePath + ".journal"
+	fi, err := os.Stat(journalPath)
+	unclean := err == nil && fi.Size() > 0 // the journal is emptied by a clean shutdown.
+
+	db, err := bolt.Open(databasePath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil && !errors.Is(err, bolt.ErrTimeout) {
+		zap.L().Warn("error opening oplog database, repairing it", zap.String("path", databasePath), zap.Error(err))
+		db, err = repairAndOpen(databasePath)
+	} else if err == nil && unclean {
+		if report := checkBoltDB(db); !report.OK() {
+			zap.L().Warn("oplog database is inconsistent after an unclean shutdown, repairing it", zap.String("path", databasePath), zap.Stringer("report", report))
+			db.Close()
+			db, err = repairAndOpen(databasePath)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+
+	s := &BoltStore{
+		db: db,
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if err := createBoltBuckets(tx); err != nil {
+			return err
+		}
+
+		if err := ApplyMigrations(s, tx); err != nil {
+			return fmt.Errorf("applying migrations: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	journal, records, err := openJournal(journalPath)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	s.journal = journal
+	if err := s.replayJournal(records); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("replaying journal: %w", err)
+	}
+
+	return s, nil
+}
+
+// repairAndOpen repairs the database at databasePath and opens it.
+func repairAndOpen(databasePath string) (*bolt.DB, error) {
+	report, err := RepairBoltStore(databasePath)
+	if err != nil {
+		return nil, fmt.Errorf("repair database: %w", err)
+	}
+	zap.L().Warn("repaired oplog database", zap.String("path", databasePath), zap.Stringer("report", report))
+	return bolt.Open(databasePath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+}
+
+func createBoltBuckets(tx *bolt.Tx) error {
+	// Create the buckets if they don't exist
+	for _, bucket := range boltBuckets {
+		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+			return fmt.Errorf("creating bucket %s: %s", string(bucket), err)
+		}
+	}
+	return nil
+}
+
+// replayJournal applies the journal's records the database hasn't recorded as applied and empties the journal.
+func (s *BoltStore) replayJournal(records []journalRecord) error {
+	var applied uint64
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(SystemBucket).Get(journalSeqKey); v != nil {
+			seq, err := serializationutil.Btoi(v)
+			applied = uint64(seq)
+			return err
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("read applied journal seq: %w", err)
+	}
+
+	replayed := 0
+	for _, rec := range records {
+		if rec.seq <= applied {
+			continue
+		}
+		if err := s.db.Update(func(tx *bolt.Tx) error {
+			if err := s.applyJournalRecord(tx, rec); err != nil {
+				return err
+			}
+			return tx.Bucket(SystemBucket).Put(journalSeqKey, serializationutil.Itob(int64(rec.seq)))
+		}); err != nil {
+			return fmt.Errorf("record %d: %w", rec.seq, err)
+		}
+		applied = rec.seq
+		replayed++
+	}
+	if replayed > 0 {
+		zap.L().Warn("replayed oplog writes interrupted by an unclean shutdown", zap.Int("records", replayed))
+	}
+	return s.journal.reset(applied + 1)
+}
+
+// applyJournalRecord applies the record, replaying it after it was applied leaves the operations in the same state.
+func (s *BoltStore) applyJournalRecord(tx *bolt.Tx, rec journalRecord) error {
+	switch rec.kind {
+	case journalKindPut:
+		for _, op := range rec.ops {
+			if op.Id != 0 {
+				if _, err := s.deleteOperationHelper(tx, op.Id); err != nil && !errors.Is(err, ErrNotExist) {
+					return err
+				}
+			}
+			if err := s.addOperationHelper(tx, op); err != nil {
+				return err
+			}
+		}
+	case journalKindDelete:
+		for _, id := range rec.ids {
+			if _, err := s.deleteOperationHelper(tx, id); err != nil && !errors.Is(err, ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// journaledUpdate records the mutation in the journal and applies it by running fn in a write transaction, which
+// also records that the journal record was applied.
+func (s *BoltStore) journaledUpdate(rec journalRecord, fn func(tx *bolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.journal.write(rec, func(seq uint64) error {
+		return s.db.Update(func(tx *bolt.Tx) error {
+			if err := fn(tx); err != nil {
+				return err
+			}
+			return tx.Bucket(SystemBucket).Put(journalSeqKey, serializationutil.Itob(int64(seq)))
+		})
+	})
+}
+
+func (s *BoltStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.db.Close()
+	if s.journal != nil {
+		err = errors.Join(err, s.journal.close())
+	}
+	return err
+}
+
+func (s *BoltStore) update(fn func(tx *bolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.db.Update(fn)
+}
+
+func (s *BoltStore) view(fn func(tx *bolt.Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.db.View(fn)
+}
+
+// compactTxMaxSize bounds the bytes copied per transaction by Compact.
+const compactTxMaxSize = 64 * 1024 * 1024
+
+// Compact rewrites the database to a new file that replaces the current one. bbolt reuses the pages of deleted
+// operations but never shrinks its file, rewriting it returns the space to the filesystem. Other calls block meanwhile.
+func (s *BoltStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dbPath := s.db.Path()
+	tmpPath := dbPath + ".compact"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove leftover compacted database: %w", err)
+	}
+	dst, err := bolt.Open(tmpPath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return fmt.Errorf("create compacted database: %w", err)
+	}
+	if err := bolt.Compact(dst, s.db, compactTxMaxSize); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("compact database: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("close compacted database: %w", err)
+	}
+
+	if err := s.db.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("close database: %w", err)
+	}
+	renameErr := os.Rename(tmpPath, dbPath)
+	if renameErr != nil {
+		os.Remove(tmpPath)
+	}
+	// reopen the database even if the rename failed, the original file is still in place.
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return fmt.Errorf("reopen database: %w", err)
+	}
+	s.db = db
+	if renameErr != nil {
+		return fmt.Errorf("replace database with compacted copy: %w", renameErr)
+	}
+	return nil
+}
+
+func (s *BoltStore) Scan(onIncomplete func(op *v1.Operation)) error {
+	return s.update(func(tx *bolt.Tx) error {
+		sysBucket := tx.Bucket(SystemBucket)
+		opLogBucket := tx.Bucket(OpLogBucket)
+		c := opLogBucket.Cursor()
+		k, v := c.First()
+		if lastValidated := sysBucket.Get([]byte("last_validated")); lastValidated != nil {
+			k, v = c.Seek(lastValidated)
+		}
+		for ; k != nil; k, v = c.Next() {
+			op := &v1.Operation{}
+			if err := proto.Unmarshal(v, op); err != nil {
+				zap.L().Error("error unmarshalling operation, there may be corruption in the oplog", zap.Error(err))
+				continue
+			}
+
+			if isIncomplete(op) {
+				onIncomplete(op)
+			} else if isDiscardedOnScan(op) {
+				s.deleteOperationHelper(tx, op.Id)
+				continue
+			}
+
+			if err := s.addOperationHelper(tx, op); err != nil {
+				zap.L().Error("error re-adding operation, there may be corruption in the oplog", zap.Error(err))
+			}
+		}
+		if lastValidated, _ := c.Last(); lastValidated != nil {
+			zap.L().Debug("checkpointing last_validated key")
+			if err := sysBucket.Put([]byte("last_validated"), lastValidated); err != nil {
+				return fmt.Errorf("checkpointing last_validated key: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) Add(ops ...*v1.Operation) error {
+	return s.journaledUpdate(journalRecord{kind: journalKindPut, ops: ops}, func(tx *bolt.Tx) error {
+		for _, op := range ops {
+			if err := s.addOperationHelper(tx, op); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) Update(op *v1.Operation) (*v1.Operation, error) {
+	var oldOp *v1.Operation
+	err := s.journaledUpdate(journalRecord{kind: journalKindPut, ops: []*v1.Operation{op}}, func(tx *bolt.Tx) error {
+		var err error
+		oldOp, err = s.deleteOperationHelper(tx, op.Id)
+		if err != nil {
+			return fmt.Errorf("deleting existing value prior to update: %w", err)
+		}
+		if err := s.addOperationHelper(tx, op); err != nil {
+			return fmt.Errorf("adding updated value: %w", err)
+		}
+		return nil
+	})
+	return oldOp, err
+}
+
+func (s *BoltStore) Delete(ids ...int64) ([]*v1.Operation, error) {
+	removedOps := make([]*v1.Operation, 0, len(ids))
+	err := s.journaledUpdate(journalRecord{kind: journalKindDelete, ids: ids}, func(tx *bolt.Tx) error {
+		for _, id := range ids {
+			removed, err := s.deleteOperationHelper(tx, id)
+			if err != nil {
+				return fmt.Errorf("deleting operation %v: %w", id, err)
+			}
+			removedOps = append(removedOps, removed)
+		}
+		return nil
+	})
+	return removedOps, err
+}
+
+func (s *BoltStore) getOperationHelper(b *bolt.Bucket, id int64) (*v1.Operation, error) {
+	bytes := b.Get(serializationutil.Itob(id))
+	if bytes == nil {
+		return nil, fmt.Errorf("opid %v: %w", id, ErrNotExist)
+	}
+
+	var op v1.Operation
+	if err := proto.Unmarshal(bytes, &op); err != nil {
+		return nil, fmt.Errorf("error unmarshalling operation: %w", err)
+	}
+
+	return &op, nil
+}
+
+func (s *BoltStore) addOperationHelper(tx *bolt.Tx, op *v1.Operation) error {
+	b := tx.Bucket(OpLogBucket)
+	if op.Id == 0 {
+		seq, err := b.NextSequence()
+		if err != nil {
+			return fmt.Errorf("create next operation ID: %w", err)
+		}
+		op.Id = operationID(time.Now().UnixMilli(), seq)
+	}
+
+	if err := prepareOperation(op); err != nil {
+		return err
+	}
+
+	bytes, err := proto.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("error marshalling operation: %w", err)
+	}
+
+	if err := b.Put(serializationutil.Itob(op.Id), bytes); err != nil {
+		return fmt.Errorf("error putting operation into bucket: %w", err)
+	}
+
+	// Update always universal indices
+	if op.RepoId != "" {
+		if err := indexutil.IndexByteValue(tx.Bucket(RepoIndexBucket), []byte(op.RepoId), op.Id); err != nil {
+			return fmt.Errorf("error adding operation to repo index: %w", err)
+		}
+	}
+	if op.PlanId != "" {
+		if err := indexutil.IndexByteValue(tx.Bucket(PlanIndexBucket), []byte(op.PlanId), op.Id); err != nil {
+			return fmt.Errorf("error adding operation to repo index: %w", err)
+		}
+	}
+	if op.SnapshotId != "" {
+		if err := indexutil.IndexByteValue(tx.Bucket(SnapshotIndexBucket), []byte(op.SnapshotId), op.Id); err != nil {
+			return fmt.Errorf("error adding operation to snapshot index: %w", err)
+		}
+	}
+	if op.FlowId != 0 {
+		if err := indexutil.IndexByteValue(tx.Bucket(FlowIdIndexBucket), serializationutil.Itob(op.FlowId), op.Id); err != nil {
+			return fmt.Errorf("error adding operation to flow index: %w", err)
+		}
+	}
+	if op.InstanceId != "" {
+		if err := indexutil.IndexByteValue(tx.Bucket(InstanceIndexBucket), []byte(op.InstanceId), op.Id); err != nil {
+			return fmt.Errorf("error adding operation to instance index: %w", err)
+		}
+	}
+	if err := indexutil.IndexByteValue(tx.Bucket(StatusIndexBucket), serializationutil.Itob(int64(op.Status)), op.Id); err != nil {
+		return fmt.Errorf("error adding operation to status index: %w", err)
+	}
+	if err := indexutil.IndexInt64Value(tx.Bucket(StartTimeIndexBucket), op.UnixTimeStartMs, op.Id); err != nil {
+		return fmt.Errorf("error adding operation to start time index: %w", err)
+	}
+
+	return nil
+}
+
+func (s *BoltStore) deleteOperationHelper(tx *bolt.Tx, id int64) (*v1.Operation, error) {
+	b := tx.Bucket(OpLogBucket)
+
+	prevValue, err := s.getOperationHelper(b, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting operation %v: %w", id, err)
+	}
+
+	if prevValue.PlanId != "" {
+		if err := indexutil.IndexRemoveByteValue(tx.Bucket(PlanIndexBucket), []byte(prevValue.PlanId), id); err != nil {
+			return nil, fmt.Errorf("removing operation %v from plan index: %w", id, err)
+		}
+	}
+
+	if prevValue.RepoId != "" {
+		if err := indexutil.IndexRemoveByteValue(tx.Bucket(RepoIndexBucket), []byte(prevValue.RepoId), id); err != nil {
+			return nil, fmt.Errorf("removing operation %v from repo index: %w", id, err)
+		}
+	}
+
+	if prevValue.SnapshotId != "" {
+		if err := indexutil.IndexRemoveByteValue(tx.Bucket(SnapshotIndexBucket), []byte(prevValue.SnapshotId), id); err != nil {
+			return nil, fmt.Errorf("removing operation %v from snapshot index: %w", id, err)
+		}
+	}
+
+	if prevValue.FlowId != 0 {
+		if err := indexutil.IndexRemoveByteValue(tx.Bucket(FlowIdIndexBucket), serializationutil.Itob(prevValue.FlowId), id); err != nil {
+			return nil, fmt.Errorf("removing operation %v from flow index: %w", id, err)
+		}
+	}
+
+	if prevValue.InstanceId != "" {
+		if err := indexutil.IndexRemoveByteValue(tx.Bucket(InstanceIndexBucket), []byte(prevValue.InstanceId), id); err != nil {
+			return nil, fmt.Errorf("removing operation %v from instance index: %w", id, err)
+		}
+	}
+
+	if err := indexutil.IndexRemoveByteValue(tx.Bucket(StatusIndexBucket), serializationutil.Itob(int64(prevValue.Status)), id); err != nil {
+		return nil, fmt.Errorf("removing operation %v from status index: %w", id, err)
+	}
+
+	if err := indexutil.IndexRemoveInt64Value(tx.Bucket(StartTimeIndexBucket), prevValue.UnixTimeStartMs, id); err != nil {
+		return nil, fmt.Errorf("removing operation %v from start time index: %w", id, err)
+	}
+
+	if err := b.Delete(serializationutil.Itob(id)); err != nil {
+		return nil, fmt.Errorf("deleting operation %v from bucket: %w", id, err)
+	}
+
+	return prevValue, nil
+}
+
+func (s *BoltStore) Get(id int64) (*v1.Operation, error) {
+	var op *v1.Operation
+	if err := s.view(func(tx *bolt.Tx) error {
+		var err error
+		op, err = s.getOperationHelper(tx.Bucket(OpLogBucket), id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (s *BoltStore) ForEachByIndex(index Index, value string, collector indexutil.Collector, do func(op *v1.Operation) error) error {
+	var bucket []byte
+	key := []byte(value)
+	switch index {
+	case IndexRepo:
+		bucket = RepoIndexBucket
+	case IndexPlan:
+		bucket = PlanIndexBucket
+	case IndexSnapshot:
+		bucket = SnapshotIndexBucket
+	case IndexInstance:
+		bucket = InstanceIndexBucket
+	case IndexFlow:
+		bucket = FlowIdIndexBucket
+		flowId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid flow ID %q: %w", value, err)
+		}
+		key = serializationutil.Itob(flowId)
+	case IndexStatus:
+		bucket = StatusIndexBucket
+		status, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid status %q: %w", value, err)
+		}
+		key = serializationutil.Itob(status)
+	default:
+		return fmt.Errorf("unknown index %v", index)
+	}
+	return s.view(func(tx *bolt.Tx) error {
+		ids := collector(indexutil.IndexSearchByteValue(tx.Bucket(bucket), key))
+		return s.forOpsByIds(tx, ids, do)
+	})
+}
+
+func (s *BoltStore) ForEachByStartTime(startAfterMs, startBeforeMs int64, collector indexutil.Collector, do func(op *v1.Operation) error) error {
+	return s.view(func(tx *bolt.Tx) error {
+		ids := collector(indexutil.IndexSearchInt64Range(tx.Bucket(StartTimeIndexBucket), max(startAfterMs, 0), startBeforeMs))
+		return s.forOpsByIds(tx, ids, do)
+	})
+}
+
+func (s *BoltStore) forOpsByIds(tx *bolt.Tx, ids []int64, do func(*v1.Operation) error) error {
+	b := tx.Bucket(OpLogBucket)
+	for _, id := range ids {
+		op, err := s.getOperationHelper(b, id)
+		if err != nil {
+			return err
+		}
+		if err := do(op); err != nil {
+			if err == ErrStopIteration {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BoltStore) ForAll(do func(op *v1.Operation) error) error {
+	return s.view(func(tx *bolt.Tx) error {
+		c := tx.Bucket(OpLogBucket).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			op := &v1.Operation{}
+			if err := proto.Unmarshal(v, op); err != nil {
+				return fmt.Errorf("error unmarshalling operation: %w", err)
+			}
+			if err := do(op); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) GetValue(bucket, key []byte) ([]byte, error) {
+	var value []byte
+	if err := s.view(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucket).Get(key)
+		if v == nil {
+			return ErrKeyNotFound
+		}
+		// values are only valid for the life of the transaction.
+		value = append([]byte{}, v...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (s *BoltStore) ForEachValue(bucket []byte, do func(key, value []byte) error) error {
+	return s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(do)
+	})
+}
+
+func (s *BoltStore) PutValue(bucket, key, value []byte) error {
+	return s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, value)
+	})
+}
+
+func (s *BoltStore) UpdateValue(bucket, key []byte, update func(value []byte) ([]byte, error)) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		var value []byte
+		if v := b.Get(key); v != nil {
+			// v is only valid for the life of the transaction and mustn't be modified.
+			value = append([]byte{}, v...)
+		}
+		value, err := update(value)
+		if err != nil {
+			return err
+		}
+		return b.Put(key, value)
+	})
+}
+
+func (s *BoltStore) DeleteValues(bucket []byte, keys ...[]byte) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for _, key := range keys {
+			if err := b.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) NextSequence(bucket []byte) (uint64, error) {
+	var seq uint64
+	err := s.update(func(tx *bolt.Tx) error {
+		var err error
+		seq, err = tx.Bucket(bucket).NextSequence()
+		return err
+	})
+	return seq, err
+}
+
+func (s *BoltStore) Sequence(bucket []byte) (uint64, error) {
+	var seq uint64
+	err := s.view(func(tx *bolt.Tx) error {
+		seq = tx.Bucket(bucket).Sequence()
+		return nil
+	})
+	return seq, err
+}
+
+func (s *BoltStore) SetSequence(bucket []byte, seq uint64) error {
+	return s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).SetSequence(seq)
+	})
+}
@@ -0,0 +1,56 @@
+package oplog
+
+import (
+	"fmt"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/serializationutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// GetHookDeliveries returns the queued and dead lettered hook deliveries ordered by ID.
+func (o *OpLog) GetHookDeliveries() ([]*v1.HookDelivery, error) {
+	var deliveries []*v1.HookDelivery
+	if err := o.store.ForEachValue(HookDeliveryBucket, func(k, v []byte) error {
+		d := &v1.HookDelivery{}
+		if err := proto.Unmarshal(v, d); err != nil {
+			return fmt.Errorf("unmarshalling hook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// PutHookDelivery adds or replaces the hook delivery, an ID is assigned to deliveries that don't have one.
+func (o *OpLog) PutHookDelivery(d *v1.HookDelivery) error {
+	if d.Id == 0 {
+		seq, err := o.store.NextSequence(HookDeliveryBucket)
+		if err != nil {
+			return fmt.Errorf("next sequence: %w", err)
+		}
+		d.Id = int64(seq)
+	}
+	bytes, err := proto.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshalling hook delivery %v: %w", d.Id, err)
+	}
+	if err := o.store.PutValue(HookDeliveryBucket, serializationutil.Itob(d.Id), bytes); err != nil {
+		return fmt.Errorf("putting hook delivery %v: %w", d.Id, err)
+	}
+	return nil
+}
+
+// DeleteHookDeliveries removes the hook deliveries, e.g. once they are delivered.
+func (o *OpLog) DeleteHookDeliveries(ids ...int64) error {
+	keys := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, serializationutil.Itob(id))
+	}
+	if err := o.store.DeleteValues(HookDeliveryBucket, keys...); err != nil {
+		return fmt.Errorf("deleting hook deliveries %v: %w", ids, err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package indexutil
 
 import (
 	"bytes"
+	"slices"
 	"sort"
 
 	"github.com/garethgeorge/backrest/internal/oplog/serializationutil"
@@ -26,6 +27,40 @@ func IndexSearchByteValue(b *bolt.Bucket, value []byte) IndexIterator {
 	return newSearchIterator(b, serializationutil.BytesToKey(value))
 }
 
+// IndexInt64Value indexes a value and recordId tuple such that recordIds can be searched for by ranges of values.
+func IndexInt64Value(b *bolt.Bucket, value int64, recordId int64) error {
+	key := serializationutil.Itob(value)
+	key = append(key, serializationutil.Itob(recordId)...)
+	return b.Put(key, []byte{})
+}
+
+func IndexRemoveInt64Value(b *bolt.Bucket, value int64, recordId int64) error {
+	key := serializationutil.Itob(value)
+	key = append(key, serializationutil.Itob(recordId)...)
+	return b.Delete(key)
+}
+
+// IndexSearchInt64Range searches the index for values in [min, max) and returns an iterator over the associated
+// recordIds in ascending order. Values must be non-negative as keys are ordered by their unsigned encoding.
+func IndexSearchInt64Range(b *bolt.Bucket, min, max int64) *SliceIterator {
+	var ids []int64
+	c := b.Cursor()
+	for k, _ := c.Seek(serializationutil.Itob(min)); k != nil; k, _ = c.Next() {
+		if len(k) != 16 {
+			// this should never happen, if it does it indicates database corruption.
+			break
+		}
+		value, _ := serializationutil.Btoi(k[:8])
+		if value >= max {
+			break
+		}
+		id, _ := serializationutil.Btoi(k[8:])
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return NewSliceIterator(ids)
+}
+
 type IndexIterator interface {
 	Next() (int64, bool)
 }
@@ -81,6 +116,37 @@ func (i *IndexSearchIterator) Seek(id int64) (int64, bool) {
 	return id, true
 }
 
+// SliceIterator iterates over recordIds already looked up, they must be in ascending order.
+type SliceIterator struct {
+	ids []int64
+}
+
+var _ SeekableIndexIterator = &SliceIterator{}
+
+func NewSliceIterator(ids []int64) *SliceIterator {
+	return &SliceIterator{ids: ids}
+}
+
+func (i *SliceIterator) Next() (int64, bool) {
+	if len(i.ids) == 0 {
+		return 0, false
+	}
+	id := i.ids[0]
+	i.ids = i.ids[1:]
+	return id, true
+}
+
+func (i *SliceIterator) Seek(id int64) (int64, bool) {
+	idx, _ := slices.BinarySearch(i.ids, id)
+	if idx == len(i.ids) {
+		i.ids = nil
+		return 0, false
+	}
+	id = i.ids[idx]
+	i.ids = i.ids[idx+1:]
+	return id, true
+}
+
 type JoinIterator struct {
 	iters     []IndexIterator
 	seekables []SeekableIndexIterator
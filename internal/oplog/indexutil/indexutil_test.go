@@ -100,3 +100,51 @@ func TestIndexJoin(t *testing.T) {
 		t.Fatalf("db.View error: %v", err)
 	}
 }
+
+func TestSliceIterator(t *testing.T) {
+	evens := NewSliceIterator([]int64{0, 2, 4, 6, 8, 10})
+	thirds := NewSliceIterator([]int64{0, 3, 6, 9})
+	if ids := CollectAll()(NewJoinIterator(evens, thirds)); !reflect.DeepEqual(ids, []int64{0, 6}) {
+		t.Errorf("want [0 6], got %v", ids)
+	}
+	if ids := CollectLastN(2)(NewSliceIterator([]int64{1, 2, 3})); !reflect.DeepEqual(ids, []int64{2, 3}) {
+		t.Errorf("want [2 3], got %v", ids)
+	}
+}
+
+func TestIndexInt64Range(t *testing.T) {
+	db, err := bbolt.Open(t.TempDir()+"/test.boltdb", 0600, nil)
+	if err != nil {
+		t.Fatalf("error opening database: %s", err)
+	}
+	defer db.Close()
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("test"))
+		if err != nil {
+			return fmt.Errorf("error creating bucket: %s", err)
+		}
+		// record ids are indexed by values that are out of order with them.
+		for id := 0; id < 10; id += 1 {
+			if err := IndexInt64Value(b, int64(1000-id*100), int64(id)); err != nil {
+				return err
+			}
+		}
+		return IndexRemoveInt64Value(b, 500, 5)
+	}); err != nil {
+		t.Fatalf("db.Update error: %v", err)
+	}
+
+	if err := db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("test"))
+		if ids := CollectAll()(IndexSearchInt64Range(b, 300, 800)); !reflect.DeepEqual(ids, []int64{3, 4, 6, 7}) {
+			t.Errorf("want [3 4 6 7], got %v", ids)
+		}
+		if ids := CollectAll()(IndexSearchInt64Range(b, 1001, 2000)); len(ids) != 0 {
+			t.Errorf("want no ids, got %v", ids)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("db.View error: %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package oplog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// journalMaxSize is the size in bytes past which the journal is truncated after a write, every record in it has been
+// applied by then.
+const journalMaxSize = 4 * 1024 * 1024
+
+// journalHeaderSize is the size of a record's header, the length and CRC-32C checksum of its payload.
+const journalHeaderSize = 8
+
+var journalCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+var errTornRecord = errors.New("torn journal record")
+
+type journalKind byte
+
+const (
+	journalKindPut    = journalKind(1) // puts the operations, operations without an ID are added with a new ID.
+	journalKindDelete = journalKind(2) // deletes the operations with the IDs.
+)
+
+// journalRecord is a mutation of the operations in a store. Records are numbered by seq, a record is applied once the
+// store has recorded its seq.
+type journalRecord struct {
+	seq  uint64
+	kind journalKind
+	ops  []*v1.Operation
+	ids  []int64
+}
+
+// journal is an append-only log of the mutations being made to a store. Each mutation is written and synced to the
+// journal before the store's transaction applying it begins, mutations interrupted e.g. by a power loss are replayed
+// from the journal when the store is next opened. The journal is emptied when the store is closed cleanly.
+type journal struct {
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+	next uint64 // seq of the next record.
+}
+
+// openJournal opens or creates the journal at path and returns it with the records it holds, a torn record at the end
+// of the journal and any records after it are discarded.
+func openJournal(path string) (*journal, []journalRecord, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open journal: %w", err)
+	}
+	records, size, err := readJournal(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("discard torn journal records: %w", err)
+	}
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("seek journal: %w", err)
+	}
+	return &journal{f: f, size: size}, records, nil
+}
+
+// readJournal reads the records from the start of the journal file and returns them with the size of the valid part
+// of the file.
+func readJournal(f *os.File) ([]journalRecord, int64, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, fmt.Errorf("seek journal: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read journal: %w", err)
+	}
+	var records []journalRecord
+	var offset int64
+	for int64(len(data)) > offset {
+		rec, n, err := decodeJournalRecord(data[offset:])
+		if err != nil {
+			break
+		}
+		records = append(records, rec)
+		offset += n
+	}
+	return records, offset, nil
+}
+
+// write appends the record, syncs the journal and then calls apply with the record's seq. The record is removed again
+// if apply fails. Writes are serialized.
+func (j *journal) write(rec journalRecord, apply func(seq uint64) error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	rec.seq = j.next
+	data, err := encodeJournalRecord(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := j.f.Write(data); err != nil {
+		j.truncate(j.size)
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := j.f.Sync(); err != nil {
+		j.truncate(j.size)
+		return fmt.Errorf("sync journal: %w", err)
+	}
+	if err := apply(rec.seq); err != nil {
+		// the record must not be replayed, the caller is told the mutation failed.
+		if e := j.truncate(j.size); e != nil {
+			return errors.Join(err, e)
+		}
+		return err
+	}
+	j.next++
+	j.size += int64(len(data))
+	if j.size > journalMaxSize {
+		if err := j.truncate(0); err != nil {
+			return err
+		}
+		j.size = 0
+	}
+	return nil
+}
+
+// truncate cuts the journal file to size, j.mu must be held.
+func (j *journal) truncate(size int64) error {
+	if err := j.f.Truncate(size); err != nil {
+		return fmt.Errorf("truncate journal: %w", err)
+	}
+	if _, err := j.f.Seek(size, io.SeekStart); err != nil {
+		return fmt.Errorf("seek journal: %w", err)
+	}
+	return nil
+}
+
+// reset empties the journal and sets the seq of the next record.
+func (j *journal) reset(next uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.truncate(0); err != nil {
+		return err
+	}
+	j.size = 0
+	j.next = next
+	return nil
+}
+
+// close empties the journal, every record has been applied, and closes it.
+func (j *journal) close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	err := j.truncate(0)
+	return errors.Join(err, j.f.Close())
+}
+
+// encodeJournalRecord encodes the record as its header followed by the payload: the seq, the kind, the number of
+// entries and each entry prefixed with its length. Entries are serialized operations or 8 byte IDs.
+func encodeJournalRecord(rec journalRecord) ([]byte, error) {
+	payload := binary.BigEndian.AppendUint64(nil, rec.seq)
+	payload = append(payload, byte(rec.kind))
+	switch rec.kind {
+	case journalKindPut:
+		payload = binary.BigEndian.AppendUint32(payload, uint32(len(rec.ops)))
+		for _, op := range rec.ops {
+			bytes, err := proto.Marshal(op)
+			if err != nil {
+				return nil, fmt.Errorf("marshal operation: %w", err)
+			}
+			payload = binary.BigEndian.AppendUint32(payload, uint32(len(bytes)))
+			payload = append(payload, bytes...)
+		}
+	case journalKindDelete:
+		payload = binary.BigEndian.AppendUint32(payload, uint32(len(rec.ids)))
+		for _, id := range rec.ids {
+			payload = binary.BigEndian.AppendUint32(payload, 8)
+			payload = binary.BigEndian.AppendUint64(payload, uint64(id))
+		}
+	default:
+		return nil, fmt.Errorf("unknown journal record kind %d", rec.kind)
+	}
+
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(payload)))
+	data = binary.BigEndian.AppendUint32(data, crc32.Checksum(payload, journalCRCTable))
+	return append(data, payload...), nil
+}
+
+// decodeJournalRecord decodes the record at the start of data and returns it with its encoded size. errTornRecord is
+// returned if the record is incomplete or its checksum doesn't match e.g. because the write was interrupted.
+func decodeJournalRecord(data []byte) (journalRecord, int64, error) {
+	if len(data) < journalHeaderSize {
+		return journalRecord{}, 0, errTornRecord
+	}
+	length := int64(binary.BigEndian.Uint32(data))
+	if int64(len(data)) < journalHeaderSize+length {
+		return journalRecord{}, 0, errTornRecord
+	}
+	payload := data[journalHeaderSize : journalHeaderSize+length]
+	if crc32.Checksum(payload, journalCRCTable) != binary.BigEndian.Uint32(data[4:]) {
+		return journalRecord{}, 0, errTornRecord
+	}
+	if len(payload) < 13 {
+		return journalRecord{}, 0, errTornRecord
+	}
+
+	rec := journalRecord{
+		seq:  binary.BigEndian.Uint64(payload),
+		kind: journalKind(payload[8]),
+	}
+	count := binary.BigEndian.Uint32(payload[9:])
+	entries := payload[13:]
+	for i := uint32(0); i < count; i++ {
+		if len(entries) < 4 {
+			return journalRecord{}, 0, errTornRecord
+		}
+		n := binary.BigEndian.Uint32(entries)
+		if uint32(len(entries)-4) < n {
+			return journalRecord{}, 0, errTornRecord
+		}
+		entry := entries[4 : 4+n]
+		entries = entries[4+n:]
+		switch rec.kind {
+		case journalKindPut:
+			op := &v1.Operation{}
+			if err := proto.Unmarshal(entry, op); err != nil {
+				return journalRecord{}, 0, errTornRecord
+			}
+			rec.ops = append(rec.ops, op)
+		case journalKindDelete:
+			if len(entry) != 8 {
+				return journalRecord{}, 0, errTornRecord
+			}
+			rec.ids = append(rec.ids, int64(binary.BigEndian.Uint64(entry)))
+		default:
+			return journalRecord{}, 0, errTornRecord
+		}
+	}
+	return rec, journalHeaderSize + length, nil
+}
@@ -5,20 +5,22 @@ import (
 	"fmt"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
 	"github.com/garethgeorge/backrest/internal/oplog/serializationutil"
 	"go.etcd.io/bbolt"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 )
 
-var migrations = []func(*OpLog, *bbolt.Tx) error{
+var migrations = []func(*BoltStore, *bbolt.Tx) error{
 	migration001FlowID,
 	migration002InstanceID,
+	migration003StatusAndStartTimeIndex,
 }
 
 var CurrentVersion = int64(len(migrations))
 
-func ApplyMigrations(oplog *OpLog, tx *bbolt.Tx) error {
+func ApplyMigrations(oplog *BoltStore, tx *bbolt.Tx) error {
 	var version int64
 	versionBytes := tx.Bucket(SystemBucket).Get([]byte("version"))
 	if versionBytes == nil {
@@ -49,7 +51,7 @@ func ApplyMigrations(oplog *OpLog, tx *bbolt.Tx) error {
 	return nil
 }
 
-func transformOperations(oplog *OpLog, tx *bbolt.Tx, f func(op *v1.Operation) error) error {
+func transformOperations(oplog *BoltStore, tx *bbolt.Tx, f func(op *v1.Operation) error) error {
 	opLogBucket := tx.Bucket(OpLogBucket)
 
 	if opLogBucket == nil {
@@ -86,7 +88,7 @@ func transformOperations(oplog *OpLog, tx *bbolt.Tx, f func(op *v1.Operation) er
 
 // migration001FlowID sets the flow ID for operations that are missing it.
 // All operations with the same snapshot ID will have the same flow ID.
-func migration001FlowID(oplog *OpLog, tx *bbolt.Tx) error {
+func migration001FlowID(oplog *BoltStore, tx *bbolt.Tx) error {
 	snapshotIdToFlow := make(map[string]int64)
 
 	return transformOperations(oplog, tx, func(op *v1.Operation) error {
@@ -110,7 +112,7 @@ func migration001FlowID(oplog *OpLog, tx *bbolt.Tx) error {
 	})
 }
 
-func migration002InstanceID(oplog *OpLog, tx *bbolt.Tx) error {
+func migration002InstanceID(oplog *BoltStore, tx *bbolt.Tx) error {
 	return transformOperations(oplog, tx, func(op *v1.Operation) error {
 		if op.InstanceId != "" {
 			return nil
@@ -120,3 +122,20 @@ func migration002InstanceID(oplog *OpLog, tx *bbolt.Tx) error {
 		return nil
 	})
 }
+
+// migration003StatusAndStartTimeIndex adds the existing operations to the status and start time indexes.
+func migration003StatusAndStartTimeIndex(oplog *BoltStore, tx *bbolt.Tx) error {
+	return tx.Bucket(OpLogBucket).ForEach(func(k, v []byte) error {
+		op := &v1.Operation{}
+		if err := proto.Unmarshal(v, op); err != nil {
+			return fmt.Errorf("unmarshal operation: %w", err)
+		}
+		if err := indexutil.IndexByteValue(tx.Bucket(StatusIndexBucket), serializationutil.Itob(int64(op.Status)), op.Id); err != nil {
+			return fmt.Errorf("index status: %w", err)
+		}
+		if err := indexutil.IndexInt64Value(tx.Bucket(StartTimeIndexBucket), op.UnixTimeStartMs, op.Id); err != nil {
+			return fmt.Errorf("index start time: %w", err)
+		}
+		return nil
+	})
+}
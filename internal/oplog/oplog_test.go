@@ -1,11 +1,13 @@
 package oplog
 
 import (
+	"errors"
 	"slices"
 	"testing"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -381,6 +383,140 @@ func countByRepoHelper(t *testing.T, log *OpLog, repo string, expected int) {
 	}
 }
 
+func TestSnapshotStatsCache(t *testing.T) {
+	t.Parallel()
+	log, err := NewOpLog(t.TempDir() + "/test.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %s", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	if _, err := log.GetSnapshotStats(snapshotId); !errors.Is(err, ErrNotExist) {
+		t.Fatalf("want ErrNotExist for uncached snapshot, got %v", err)
+	}
+
+	stats := &v1.SnapshotStats{FileCount: 10, TotalSize: 100, RestoreSize: 200}
+	if err := log.PutSnapshotStats(snapshotId, stats); err != nil {
+		t.Fatalf("error putting snapshot stats: %s", err)
+	}
+
+	got, err := log.GetSnapshotStats(snapshotId)
+	if err != nil {
+		t.Fatalf("error getting snapshot stats: %s", err)
+	}
+	if !proto.Equal(got, stats) {
+		t.Errorf("want stats %v, got %v", stats, got)
+	}
+
+	if _, err := log.GetSnapshotStats(snapshotId2); !errors.Is(err, ErrNotExist) {
+		t.Errorf("want ErrNotExist for a different snapshot, got %v", err)
+	}
+}
+
+func TestOperationOriginStamped(t *testing.T) {
+	t.Parallel()
+	log, err := NewOpLog(t.TempDir() + "/test.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %s", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	log.SetOrigin(Origin{Hostname: "host1", BackrestVersion: "1.0.0", ResticVersion: "0.16.4"})
+
+	op := &v1.Operation{
+		UnixTimeStartMs: 1234,
+		PlanId:          "plan1",
+		RepoId:          "repo1",
+		InstanceId:      "instance1",
+		Op:              &v1.Operation_OperationBackup{},
+	}
+	imported := &v1.Operation{
+		UnixTimeStartMs: 1234,
+		PlanId:          "plan1",
+		RepoId:          "repo1",
+		InstanceId:      "instance2",
+		Hostname:        "host2",
+		BackrestVersion: "0.9.0",
+		ResticVersion:   "0.16.0",
+		Op:              &v1.Operation_OperationBackup{},
+	}
+	if err := log.BulkAdd([]*v1.Operation{op, imported}); err != nil {
+		t.Fatalf("error adding operations: %s", err)
+	}
+
+	got, err := log.Get(op.Id)
+	if err != nil {
+		t.Fatalf("error getting operation: %s", err)
+	}
+	if got.Hostname != "host1" || got.BackrestVersion != "1.0.0" || got.ResticVersion != "0.16.4" {
+		t.Errorf("want operation stamped with origin, got hostname %q backrest %q restic %q", got.Hostname, got.BackrestVersion, got.ResticVersion)
+	}
+
+	got, err = log.Get(imported.Id)
+	if err != nil {
+		t.Fatalf("error getting operation: %s", err)
+	}
+	if got.Hostname != "host2" || got.BackrestVersion != "0.9.0" || got.ResticVersion != "0.16.0" {
+		t.Errorf("want existing origin kept, got hostname %q backrest %q restic %q", got.Hostname, got.BackrestVersion, got.ResticVersion)
+	}
+}
+
+func TestPutOperation(t *testing.T) {
+	t.Parallel()
+	log, err := NewOpLog(t.TempDir() + "/test.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %s", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	var events []string
+	callback := func(oldOp, newOp *v1.Operation) {
+		if oldOp == nil {
+			events = append(events, "created")
+		} else {
+			events = append(events, "updated")
+		}
+	}
+	log.Subscribe(&callback)
+	defer log.Unsubscribe(&callback)
+
+	op := &v1.Operation{
+		Id:              42,
+		UnixTimeStartMs: 1234,
+		PlanId:          "plan1",
+		RepoId:          "repo1",
+		InstanceId:      "primary",
+		Status:          v1.OperationStatus_STATUS_INPROGRESS,
+		Op:              &v1.Operation_OperationBackup{},
+	}
+	if err := log.Put(op); err != nil {
+		t.Fatalf("error putting operation: %s", err)
+	}
+	op = proto.Clone(op).(*v1.Operation)
+	op.Status = v1.OperationStatus_STATUS_SUCCESS
+	op.PlanId = "plan2"
+	if err := log.Put(op); err != nil {
+		t.Fatalf("error putting operation: %s", err)
+	}
+
+	got, err := log.Get(42)
+	if err != nil {
+		t.Fatalf("error getting operation: %s", err)
+	}
+	if !proto.Equal(got, op) {
+		t.Errorf("want operation %v, got %v", op, got)
+	}
+	countByPlanHelper(t, log, "plan1", 0)
+	countByPlanHelper(t, log, "plan2", 1)
+	if !slices.Equal(events, []string{"created", "updated"}) {
+		t.Errorf("want events created, updated, got %v", events)
+	}
+
+	if err := log.Put(&v1.Operation{UnixTimeStartMs: 1234}); err == nil {
+		t.Errorf("want error putting operation without an ID")
+	}
+}
+
 func countByPlanHelper(t *testing.T, log *OpLog, plan string, expected int) {
 	t.Helper()
 	count := 0
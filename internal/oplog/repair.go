@@ -0,0 +1,312 @@
+package oplog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/serializationutil"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// indexBuckets are the buckets indexing operations, they're rebuilt from the operations when the store is repaired.
+var indexBuckets = [][]byte{RepoIndexBucket, PlanIndexBucket, SnapshotIndexBucket, FlowIdIndexBucket, InstanceIndexBucket, StatusIndexBucket, StartTimeIndexBucket}
+
+// IntegrityReport describes the problems CheckBoltStore found in a store, or the state of a store RepairBoltStore
+// rebuilt.
+type IntegrityReport struct {
+	Operations     int      // operations that could be read.
+	TornRecords    int      // operation records that couldn't be read, they're discarded by a repair.
+	IndexErrors    int      // index entries missing or not matching an operation, indexes are rebuilt by a repair.
+	DatabaseErrors []string // pages or buckets bbolt couldn't read, the records on them are lost by a repair.
+}
+
+// OK returns true if no problems were found.
+func (r *IntegrityReport) OK() bool {
+	return r.TornRecords == 0 && r.IndexErrors == 0 && len(r.DatabaseErrors) == 0
+}
+
+func (r *IntegrityReport) String() string {
+	s := fmt.Sprintf("%d operations, %d torn records, %d index errors", r.Operations, r.TornRecords, r.IndexErrors)
+	if len(r.DatabaseErrors) > 0 {
+		s += ", database errors: " + strings.Join(r.DatabaseErrors, "; ")
+	}
+	return s
+}
+
+// CheckBoltStore checks the integrity of the bbolt database at databasePath, which must not be open.
+func CheckBoltStore(databasePath string) (*IntegrityReport, error) {
+	db, err := bolt.Open(databasePath, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, err
+	} else if err != nil {
+		return &IntegrityReport{DatabaseErrors: []string{err.Error()}}, nil
+	}
+	defer db.Close()
+	return checkBoltDB(db), nil
+}
+
+// checkBoltDB checks the pages of the database, that each operation record can be read and that the indexes match
+// the operations.
+func checkBoltDB(db *bolt.DB) (report *IntegrityReport) {
+	report = &IntegrityReport{}
+	defer func() {
+		// bbolt panics reading some corrupted pages.
+		if r := recover(); r != nil {
+			report.DatabaseErrors = append(report.DatabaseErrors, fmt.Sprintf("unreadable page: %v", r))
+		}
+	}()
+
+	db.View(func(tx *bolt.Tx) error {
+		for err := range tx.Check() {
+			report.DatabaseErrors = append(report.DatabaseErrors, err.Error())
+		}
+		if len(report.DatabaseErrors) > 0 {
+			return nil // reading the records of inconsistent pages may panic.
+		}
+
+		opLog := tx.Bucket(OpLogBucket)
+		if opLog == nil {
+			report.DatabaseErrors = append(report.DatabaseErrors, "missing bucket "+string(OpLogBucket))
+			return nil
+		}
+		expected := make(map[string]map[string]bool) // bucket to index keys.
+		opLog.ForEach(func(k, v []byte) error {
+			op, ok := decodeOperationRecord(k, v)
+			if !ok {
+				report.TornRecords++
+				return nil
+			}
+			report.Operations++
+			for _, entry := range boltIndexEntries(op) {
+				if expected[string(entry.bucket)] == nil {
+					expected[string(entry.bucket)] = make(map[string]bool)
+				}
+				expected[string(entry.bucket)][string(entry.key)] = true
+			}
+			return nil
+		})
+
+		for _, bucket := range indexBuckets {
+			b := tx.Bucket(bucket)
+			if b == nil {
+				report.DatabaseErrors = append(report.DatabaseErrors, "missing bucket "+string(bucket))
+				continue
+			}
+			keys := expected[string(bucket)]
+			b.ForEach(func(k, v []byte) error {
+				if keys[string(k)] {
+					delete(keys, string(k))
+				} else {
+					report.IndexErrors++ // an entry for an operation that doesn't exist or has changed.
+				}
+				return nil
+			})
+			report.IndexErrors += len(keys) // operations missing from the index.
+		}
+		return nil
+	})
+	return report
+}
+
+// RepairBoltStore rebuilds the bbolt database at databasePath, which must not be open, from the records that can be
+// read. Torn operation records are discarded and the indexes are rebuilt, records on pages bbolt can't read are lost.
+// The original database is kept next to the rebuilt one with the suffix ".corrupt-<unix time>".
+func RepairBoltStore(databasePath string) (*IntegrityReport, error) {
+	report := &IntegrityReport{}
+	tmpPath := databasePath + ".repair"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("remove leftover repaired database: %w", err)
+	}
+	dst, err := bolt.Open(tmpPath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("create repaired database: %w", err)
+	}
+	rebuilt := &BoltStore{db: dst}
+	if err := dst.Update(func(tx *bolt.Tx) error {
+		return createBoltBuckets(tx)
+	}); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return nil, err
+	}
+
+	src, err := bolt.Open(databasePath, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if errors.Is(err, bolt.ErrTimeout) {
+		dst.Close()
+		os.Remove(tmpPath)
+		return nil, err
+	} else if err != nil {
+		report.DatabaseErrors = append(report.DatabaseErrors, fmt.Sprintf("database can't be opened, its records are lost: %v", err))
+	} else {
+		err := salvageBoltDB(src, rebuilt, report)
+		src.Close()
+		if err != nil {
+			dst.Close()
+			os.Remove(tmpPath)
+			return nil, err
+		}
+	}
+
+	if err := dst.Update(func(tx *bolt.Tx) error {
+		return ApplyMigrations(rebuilt, tx)
+	}); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("apply migrations: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("close repaired database: %w", err)
+	}
+
+	if _, err := os.Stat(databasePath); err == nil {
+		if err := os.Rename(databasePath, fmt.Sprintf("%s.corrupt-%d", databasePath, time.Now().Unix())); err != nil {
+			os.Remove(tmpPath)
+			return nil, fmt.Errorf("move corrupted database aside: %w", err)
+		}
+	}
+	if err := os.Rename(tmpPath, databasePath); err != nil {
+		return nil, fmt.Errorf("replace database with repaired copy: %w", err)
+	}
+	return report, nil
+}
+
+// salvageBoltDB copies the operations and key value records that can be read from src to dst, indexing the
+// operations as they're added.
+func salvageBoltDB(src *bolt.DB, dst *BoltStore, report *IntegrityReport) error {
+	batch := make([]*v1.Operation, 0, copyBatchSize)
+	flush := func() error {
+		err := dst.db.Update(func(tx *bolt.Tx) error {
+			for _, op := range batch {
+				if err := dst.addOperationHelper(tx, op); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		batch = batch[:0]
+		return err
+	}
+	if err := readSalvaging(src, OpLogBucket, func(k, v []byte) error {
+		op, ok := decodeOperationRecord(k, v)
+		if !ok || prepareOperation(op) != nil {
+			report.TornRecords++
+			return nil
+		}
+		report.Operations++
+		batch = append(batch, op)
+		if len(batch) >= copyBatchSize {
+			return flush()
+		}
+		return nil
+	}); err != nil {
+		report.DatabaseErrors = append(report.DatabaseErrors, err.Error())
+	}
+	if err := flush(); err != nil {
+		return fmt.Errorf("add operations: %w", err)
+	}
+
+	// the key value records and the bucket sequences, which keep IDs from being reused, are copied in one transaction.
+	tx, err := dst.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, bucket := range append([][]byte{SystemBucket}, copiedBuckets...) {
+		if err := readSalvaging(src, bucket, func(k, v []byte) error {
+			if string(bucket) == string(SystemBucket) && string(k) == "last_validated" {
+				return nil // the rebuilt oplog is scanned in full.
+			}
+			return tx.Bucket(bucket).Put(append([]byte{}, k...), append([]byte{}, v...))
+		}); err != nil {
+			report.DatabaseErrors = append(report.DatabaseErrors, err.Error())
+		}
+	}
+	if err := viewSalvaging(src, func(srcTx *bolt.Tx) error {
+		for _, bucket := range append([][]byte{OpLogBucket}, copiedBuckets...) {
+			if b := srcTx.Bucket(bucket); b != nil {
+				if err := tx.Bucket(bucket).SetSequence(b.Sequence()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		report.DatabaseErrors = append(report.DatabaseErrors, err.Error())
+	}
+	return tx.Commit()
+}
+
+// readSalvaging calls do with each record of the bucket up to the first page bbolt can't read.
+func readSalvaging(db *bolt.DB, bucket []byte, do func(k, v []byte) error) error {
+	if err := viewSalvaging(db, func(tx *bolt.Tx) error {
+		if b := tx.Bucket(bucket); b != nil {
+			return b.ForEach(do)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("bucket %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// viewSalvaging runs fn in a read transaction, recovering from the panics bbolt raises reading corrupted pages.
+func viewSalvaging(db *bolt.DB, fn func(tx *bolt.Tx) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unreadable page: %v", r)
+		}
+	}()
+	return db.View(fn)
+}
+
+// decodeOperationRecord decodes the operation stored under the key, ok is false if the record is torn.
+func decodeOperationRecord(k, v []byte) (op *v1.Operation, ok bool) {
+	id, err := serializationutil.Btoi(k)
+	if err != nil {
+		return nil, false
+	}
+	op = &v1.Operation{}
+	if err := proto.Unmarshal(v, op); err != nil || op.Id != id {
+		return nil, false
+	}
+	return op, true
+}
+
+type boltIndexEntry struct {
+	bucket []byte
+	key    []byte
+}
+
+// boltIndexEntries returns the index entries addOperationHelper writes for the operation.
+func boltIndexEntries(op *v1.Operation) []boltIndexEntry {
+	var entries []boltIndexEntry
+	byteValue := func(bucket, value []byte) {
+		key := serializationutil.BytesToKey(value)
+		entries = append(entries, boltIndexEntry{bucket, append(key, serializationutil.Itob(op.Id)...)})
+	}
+	if op.RepoId != "" {
+		byteValue(RepoIndexBucket, []byte(op.RepoId))
+	}
+	if op.PlanId != "" {
+		byteValue(PlanIndexBucket, []byte(op.PlanId))
+	}
+	if op.SnapshotId != "" {
+		byteValue(SnapshotIndexBucket, []byte(op.SnapshotId))
+	}
+	if op.FlowId != 0 {
+		byteValue(FlowIdIndexBucket, serializationutil.Itob(op.FlowId))
+	}
+	if op.InstanceId != "" {
+		byteValue(InstanceIndexBucket, []byte(op.InstanceId))
+	}
+	byteValue(StatusIndexBucket, serializationutil.Itob(int64(op.Status)))
+	startKey := append(serializationutil.Itob(op.UnixTimeStartMs), serializationutil.Itob(op.Id)...)
+	entries = append(entries, boltIndexEntry{StartTimeIndexBucket, startKey})
+	return entries
+}
@@ -0,0 +1,210 @@
+package oplog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"github.com/garethgeorge/backrest/internal/oplog/serializationutil"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestBoltStoreReplaysJournal(t *testing.T) {
+	t.Parallel()
+	dbPath := filepath.Join(t.TempDir(), "oplog.boltdb")
+	store, err := NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error: %v", err)
+	}
+	kept := testOperation("plan", v1.OperationStatus_STATUS_SUCCESS)
+	if err := store.Add(kept); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if fi, err := os.Stat(dbPath + ".journal"); err != nil || fi.Size() != 0 {
+		t.Fatalf("expected the journal to be emptied by a clean shutdown, got %v %v", fi, err)
+	}
+
+	// simulate a shutdown interrupting a write: the journal holds a record already applied, one that wasn't and a
+	// torn record.
+	var data []byte
+	for _, rec := range []journalRecord{
+		{seq: 1, kind: journalKindDelete, ids: []int64{kept.Id}},
+		{seq: 2, kind: journalKindPut, ops: []*v1.Operation{testOperation("plan", v1.OperationStatus_STATUS_ERROR)}},
+	} {
+		encoded, err := encodeJournalRecord(rec)
+		if err != nil {
+			t.Fatalf("encodeJournalRecord() error: %v", err)
+		}
+		data = append(data, encoded...)
+	}
+	torn, _ := encodeJournalRecord(journalRecord{seq: 3, kind: journalKindDelete, ids: []int64{kept.Id}})
+	data = append(data, torn[:len(torn)-3]...)
+	if err := os.WriteFile(dbPath+".journal", data, 0600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	store, err = NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error: %v", err)
+	}
+	defer store.Close()
+
+	var statuses []v1.OperationStatus
+	if err := store.ForEachByIndex(IndexPlan, "plan", indexutil.CollectAll(), func(op *v1.Operation) error {
+		statuses = append(statuses, op.Status)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachByIndex() error: %v", err)
+	}
+	if len(statuses) != 2 || statuses[0] != v1.OperationStatus_STATUS_SUCCESS || statuses[1] != v1.OperationStatus_STATUS_ERROR {
+		t.Errorf("expected only the unapplied record to be replayed, got operations with statuses %v", statuses)
+	}
+	if report := checkBoltDB(store.db); !report.OK() {
+		t.Errorf("expected the replayed store to be consistent, got %v", report)
+	}
+
+	// writes after the replay continue the journal's sequence.
+	if err := store.Add(testOperation("plan", v1.OperationStatus_STATUS_SUCCESS)); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if store.journal.next != 4 {
+		t.Errorf("expected the next journal record to be 4, got %d", store.journal.next)
+	}
+}
+
+func TestJournalDiscardsFailedWrites(t *testing.T) {
+	t.Parallel()
+	j, records, err := openJournal(filepath.Join(t.TempDir(), "journal"))
+	if err != nil {
+		t.Fatalf("openJournal() error: %v", err)
+	}
+	defer j.close()
+	if len(records) != 0 {
+		t.Fatalf("expected a new journal to be empty, got %v", records)
+	}
+
+	rec := journalRecord{kind: journalKindDelete, ids: []int64{1}}
+	if err := j.write(rec, func(seq uint64) error { return nil }); err != nil {
+		t.Fatalf("write() error: %v", err)
+	}
+	wantErr := errors.New("transaction failed")
+	if err := j.write(rec, func(seq uint64) error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Fatalf("expected the apply error, got %v", err)
+	}
+
+	records, _, err = readJournal(j.f)
+	if err != nil {
+		t.Fatalf("readJournal() error: %v", err)
+	}
+	if len(records) != 1 || records[0].seq != 0 {
+		t.Errorf("expected only the applied record in the journal, got %v", records)
+	}
+}
+
+func TestRepairBoltStore(t *testing.T) {
+	t.Parallel()
+	dbPath := filepath.Join(t.TempDir(), "oplog.boltdb")
+	store, err := NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error: %v", err)
+	}
+	var ops []*v1.Operation
+	for i := 0; i < 5; i++ {
+		op := testOperation("plan", v1.OperationStatus_STATUS_SUCCESS)
+		if err := store.Add(op); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+		ops = append(ops, op)
+	}
+	if err := store.PutValue(SnapshotStatsBucket, []byte("snapshot"), []byte("stats")); err != nil {
+		t.Fatalf("PutValue() error: %v", err)
+	}
+	store.Close()
+
+	if report, err := CheckBoltStore(dbPath); err != nil || !report.OK() {
+		t.Fatalf("expected a new store to pass the check, got %v %v", report, err)
+	}
+
+	// tear an operation record and drop one of the index entries of another.
+	db, err := bolt.Open(dbPath, 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open() error: %v", err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(OpLogBucket).Put(serializationutil.Itob(ops[0].Id), []byte{0xff, 0xff, 0xff}); err != nil {
+			return err
+		}
+		return indexutil.IndexRemoveByteValue(tx.Bucket(PlanIndexBucket), []byte("plan"), ops[1].Id)
+	}); err != nil {
+		t.Fatalf("corrupting database: %v", err)
+	}
+	db.Close()
+
+	report, err := CheckBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("CheckBoltStore() error: %v", err)
+	}
+	// the torn operation's 7 index entries are dangling and the other operation is missing from the plan index.
+	if report.Operations != 4 || report.TornRecords != 1 || report.IndexErrors != 8 {
+		t.Errorf("unexpected check report %v", report)
+	}
+
+	report, err = RepairBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("RepairBoltStore() error: %v", err)
+	}
+	if report.Operations != 4 || report.TornRecords != 1 {
+		t.Errorf("unexpected repair report %v", report)
+	}
+	if report, err := CheckBoltStore(dbPath); err != nil || !report.OK() {
+		t.Errorf("expected the repaired store to pass the check, got %v %v", report, err)
+	}
+	if matches, _ := filepath.Glob(dbPath + ".corrupt-*"); len(matches) != 1 {
+		t.Errorf("expected the corrupted database to be kept, got %v", matches)
+	}
+
+	store, err = NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error: %v", err)
+	}
+	defer store.Close()
+	count := 0
+	if err := store.ForEachByIndex(IndexPlan, "plan", indexutil.CollectAll(), func(op *v1.Operation) error {
+		count++
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachByIndex() error: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 operations in the rebuilt plan index, got %d", count)
+	}
+	if v, err := store.GetValue(SnapshotStatsBucket, []byte("snapshot")); err != nil || string(v) != "stats" {
+		t.Errorf("expected key value records to be kept, got %q %v", v, err)
+	}
+	if _, err := store.Get(ops[0].Id); !errors.Is(err, ErrNotExist) {
+		t.Errorf("expected the torn operation to be discarded, got %v", err)
+	}
+}
+
+func TestBoltStoreRepairsUnreadableDatabase(t *testing.T) {
+	t.Parallel()
+	dbPath := filepath.Join(t.TempDir(), "oplog.boltdb")
+	if err := os.WriteFile(dbPath, []byte("not a bbolt database, e.g. a file truncated by a power loss"), 0600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	store, err := NewBoltStore(dbPath)
+	if err != nil {
+		t.Fatalf("expected the store to be repaired rather than refusing to open, got %v", err)
+	}
+	defer store.Close()
+	if err := store.Add(testOperation("plan", v1.OperationStatus_STATUS_SUCCESS)); err != nil {
+		t.Errorf("Add() error: %v", err)
+	}
+}
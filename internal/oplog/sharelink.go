@@ -0,0 +1,97 @@
+package oplog
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/serializationutil"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrShareLinkNotFound = errors.New("share link not found")
+
+// GetShareLinks returns the share links ordered by ID.
+func (o *OpLog) GetShareLinks() ([]*v1.ShareLink, error) {
+	var links []*v1.ShareLink
+	if err := o.store.ForEachValue(ShareLinkBucket, func(k, v []byte) error {
+		link := &v1.ShareLink{}
+		if err := proto.Unmarshal(v, link); err != nil {
+			return fmt.Errorf("unmarshalling share link: %w", err)
+		}
+		links = append(links, link)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetShareLink returns the share link with the given ID or ErrShareLinkNotFound.
+func (o *OpLog) GetShareLink(id int64) (*v1.ShareLink, error) {
+	v, err := o.store.GetValue(ShareLinkBucket, serializationutil.Itob(id))
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, ErrShareLinkNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	link := &v1.ShareLink{}
+	if err := proto.Unmarshal(v, link); err != nil {
+		return nil, fmt.Errorf("unmarshalling share link %v: %w", id, err)
+	}
+	return link, nil
+}
+
+// AddShareLink adds the share link and assigns its ID.
+func (o *OpLog) AddShareLink(link *v1.ShareLink) error {
+	seq, err := o.store.NextSequence(ShareLinkBucket)
+	if err != nil {
+		return fmt.Errorf("next sequence: %w", err)
+	}
+	link.Id = int64(seq)
+	bytes, err := proto.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("marshalling share link %v: %w", link.Id, err)
+	}
+	if err := o.store.PutValue(ShareLinkBucket, serializationutil.Itob(link.Id), bytes); err != nil {
+		return fmt.Errorf("putting share link %v: %w", link.Id, err)
+	}
+	return nil
+}
+
+// UpdateShareLink applies update to the share link with the given ID in a single transaction and returns the updated
+// link, nothing is saved if update returns an error.
+func (o *OpLog) UpdateShareLink(id int64, update func(link *v1.ShareLink) error) (*v1.ShareLink, error) {
+	link := &v1.ShareLink{}
+	if err := o.store.UpdateValue(ShareLinkBucket, serializationutil.Itob(id), func(v []byte) ([]byte, error) {
+		if v == nil {
+			return nil, ErrShareLinkNotFound
+		}
+		if err := proto.Unmarshal(v, link); err != nil {
+			return nil, fmt.Errorf("unmarshalling share link %v: %w", id, err)
+		}
+		if err := update(link); err != nil {
+			return nil, err
+		}
+		bytes, err := proto.Marshal(link)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling share link %v: %w", id, err)
+		}
+		return bytes, nil
+	}); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// DeleteShareLinks removes the share links, e.g. once they expired long ago.
+func (o *OpLog) DeleteShareLinks(ids ...int64) error {
+	keys := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, serializationutil.Itob(id))
+	}
+	if err := o.store.DeleteValues(ShareLinkBucket, keys...); err != nil {
+		return fmt.Errorf("deleting share links %v: %w", ids, err)
+	}
+	return nil
+}
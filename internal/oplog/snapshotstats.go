@@ -0,0 +1,36 @@
+package oplog
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// GetSnapshotStats returns the cached stats for the snapshot or ErrNotExist if none are cached.
+func (o *OpLog) GetSnapshotStats(snapshotID string) (*v1.SnapshotStats, error) {
+	bytes, err := o.store.GetValue(SnapshotStatsBucket, []byte(snapshotID))
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, ErrNotExist
+	} else if err != nil {
+		return nil, err
+	}
+	stats := &v1.SnapshotStats{}
+	if err := proto.Unmarshal(bytes, stats); err != nil {
+		return nil, fmt.Errorf("unmarshalling stats for snapshot %v: %w", snapshotID, err)
+	}
+	return stats, nil
+}
+
+// PutSnapshotStats caches the stats for the snapshot. Snapshots are immutable so cached stats never expire.
+func (o *OpLog) PutSnapshotStats(snapshotID string, stats *v1.SnapshotStats) error {
+	bytes, err := proto.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("marshalling stats for snapshot %v: %w", snapshotID, err)
+	}
+	if err := o.store.PutValue(SnapshotStatsBucket, []byte(snapshotID), bytes); err != nil {
+		return fmt.Errorf("putting stats for snapshot %v: %w", snapshotID, err)
+	}
+	return nil
+}
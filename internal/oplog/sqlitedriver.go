@@ -0,0 +1,7 @@
+//go:build sqlite
+
+package oplog
+
+// the sqlite oplog backend needs a SQLite driver, it's only linked into builds with the sqlite tag to keep the default
+// build free of the large pure Go SQLite implementation.
+import _ "modernc.org/sqlite"
@@ -0,0 +1,570 @@
+package oplog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// sqliteDriverName is the database/sql driver the SQLite store uses, it's registered by builds with the sqlite tag.
+const sqliteDriverName = "sqlite"
+
+// sqliteSchemaVersion is stored in the database's user_version, it's bumped by changes to sqliteSchema.
+const sqliteSchemaVersion = 2
+
+// sqliteScannedCondition selects the operations Scan looks at, those unknown, pending, in progress or cancelled.
+const sqliteScannedCondition = "status IN (0, 1, 2, 5, 6) OR resume_on_start"
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS operations (
+	id INTEGER PRIMARY KEY,
+	repo_id TEXT NOT NULL,
+	plan_id TEXT NOT NULL,
+	snapshot_id TEXT NOT NULL,
+	flow_id INTEGER NOT NULL,
+	instance_id TEXT NOT NULL,
+	status INTEGER NOT NULL,
+	unix_time_start_ms INTEGER NOT NULL,
+	resume_on_start INTEGER NOT NULL,
+	data BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS operations_repo_id ON operations (repo_id, id);
+CREATE INDEX IF NOT EXISTS operations_plan_id ON operations (plan_id, id);
+CREATE INDEX IF NOT EXISTS operations_snapshot_id ON operations (snapshot_id, id) WHERE snapshot_id != '';
+CREATE INDEX IF NOT EXISTS operations_flow_id ON operations (flow_id, id);
+CREATE INDEX IF NOT EXISTS operations_instance_id ON operations (instance_id, id);
+CREATE INDEX IF NOT EXISTS operations_status ON operations (status, id);
+CREATE INDEX IF NOT EXISTS operations_start_time ON operations (unix_time_start_ms);
+CREATE INDEX IF NOT EXISTS operations_scanned ON operations (id) WHERE ` + sqliteScannedCondition + `;
+CREATE TABLE IF NOT EXISTS kv (
+	bucket TEXT NOT NULL,
+	key BLOB NOT NULL,
+	value BLOB NOT NULL,
+	PRIMARY KEY (bucket, key)
+) WITHOUT ROWID;
+CREATE TABLE IF NOT EXISTS sequences (
+	bucket TEXT PRIMARY KEY,
+	value INTEGER NOT NULL
+) WITHOUT ROWID;
+`
+
+// sqliteIndexColumns are the columns of the operations table holding each index's value.
+var sqliteIndexColumns = map[Index]string{
+	IndexRepo:     "repo_id",
+	IndexPlan:     "plan_id",
+	IndexSnapshot: "snapshot_id",
+	IndexFlow:     "flow_id",
+	IndexInstance: "instance_id",
+	IndexStatus:   "status",
+}
+
+// sqliteFetchBatchSize bounds the number of operations fetched by a single query.
+const sqliteFetchBatchSize = 500
+
+// SQLiteStore is a Store backed by a SQLite database. Reads don't wait for writes and the space of deleted operations is
+// reclaimed, which suits instances with a long operation history better than bbolt.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+var _ Store = &SQLiteStore{}
+
+func NewSQLiteStore(databasePath string) (*SQLiteStore, error) {
+	if !slices.Contains(sql.Drivers(), sqliteDriverName) {
+		return nil, errors.New("this build of backrest doesn't include the sqlite driver, build it with -tags sqlite to use the sqlite oplog backend")
+	}
+	if err := os.MkdirAll(path.Dir(databasePath), 0700); err != nil {
+		return nil, fmt.Errorf("error creating database directory: %s", err)
+	}
+
+	query := url.Values{}
+	for _, pragma := range []string{"busy_timeout(10000)", "journal_mode(WAL)", "synchronous(NORMAL)", "auto_vacuum(FULL)"} {
+		query.Add("_pragma", pragma)
+	}
+	query.Set("_txlock", "immediate") // write transactions wait for the lock up front rather than failing to upgrade.
+	dsn := (&url.URL{Scheme: "file", Path: databasePath, RawQuery: query.Encode()}).String()
+	db, err := sql.Open(sqliteDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQLiteStore) migrate() error {
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("get schema version: %w", err)
+	}
+	if version > sqliteSchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than this version of backrest supports (%d)", version, sqliteSchemaVersion)
+	}
+	return s.update(func(tx *sql.Tx) error {
+		if version == 1 {
+			if err := migrateSQLiteStartTime(tx); err != nil {
+				return fmt.Errorf("migrate schema version 1: %w", err)
+			}
+		}
+		if _, err := tx.Exec(sqliteSchema); err != nil {
+			return fmt.Errorf("create schema: %w", err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", sqliteSchemaVersion)); err != nil {
+			return fmt.Errorf("set schema version: %w", err)
+		}
+		return nil
+	})
+}
+
+// migrateSQLiteStartTime adds the unix_time_start_ms column added by schema version 2 and fills it in from the
+// operations.
+func migrateSQLiteStartTime(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE operations ADD COLUMN unix_time_start_ms INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return fmt.Errorf("add column: %w", err)
+	}
+	rows, err := tx.Query("SELECT data FROM operations")
+	if err != nil {
+		return fmt.Errorf("query operations: %w", err)
+	}
+	var ops []*v1.Operation
+	for rows.Next() {
+		var bytes []byte
+		if err := rows.Scan(&bytes); err != nil {
+			rows.Close()
+			return fmt.Errorf("query operations: %w", err)
+		}
+		op := &v1.Operation{}
+		if err := proto.Unmarshal(bytes, op); err != nil {
+			rows.Close()
+			return fmt.Errorf("error unmarshalling operation: %w", err)
+		}
+		ops = append(ops, op)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("query operations: %w", err)
+	}
+	for _, op := range ops {
+		if _, err := tx.Exec("UPDATE operations SET unix_time_start_ms = ? WHERE id = ?", op.UnixTimeStartMs, op.Id); err != nil {
+			return fmt.Errorf("set start time of operation %v: %w", op.Id, err)
+		}
+	}
+	return nil
+}
+
+// update runs fn in a write transaction, the transaction is committed if fn returns nil.
+func (s *SQLiteStore) update(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// Compact defragments the database with VACUUM and truncates the write ahead log. Pages of deleted operations are
+// already returned to the filesystem as they're freed (auto_vacuum).
+func (s *SQLiteStore) Compact() error {
+	if _, err := s.db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("vacuum database: %w", err)
+	}
+	if _, err := s.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("checkpoint write ahead log: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Add(ops ...*v1.Operation) error {
+	return s.update(func(tx *sql.Tx) error {
+		for _, op := range ops {
+			if err := s.putOperation(tx, op); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) Update(op *v1.Operation) (*v1.Operation, error) {
+	var oldOp *v1.Operation
+	err := s.update(func(tx *sql.Tx) error {
+		var err error
+		oldOp, err = getSQLiteOperation(tx, op.Id)
+		if err != nil {
+			return fmt.Errorf("getting existing value prior to update: %w", err)
+		}
+		if err := s.putOperation(tx, op); err != nil {
+			return fmt.Errorf("adding updated value: %w", err)
+		}
+		return nil
+	})
+	return oldOp, err
+}
+
+func (s *SQLiteStore) Delete(ids ...int64) ([]*v1.Operation, error) {
+	removedOps := make([]*v1.Operation, 0, len(ids))
+	err := s.update(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			removed, err := getSQLiteOperation(tx, id)
+			if err != nil {
+				return fmt.Errorf("deleting operation %v: %w", id, err)
+			}
+			if _, err := tx.Exec("DELETE FROM operations WHERE id = ?", id); err != nil {
+				return fmt.Errorf("deleting operation %v: %w", id, err)
+			}
+			removedOps = append(removedOps, removed)
+		}
+		return nil
+	})
+	return removedOps, err
+}
+
+// putOperation adds or replaces the operation, it's assigned an ID if it doesn't have one.
+func (s *SQLiteStore) putOperation(tx *sql.Tx, op *v1.Operation) error {
+	if op.Id == 0 {
+		seq, err := nextSQLiteSequence(tx, OpLogBucket)
+		if err != nil {
+			return fmt.Errorf("create next operation ID: %w", err)
+		}
+		op.Id = operationID(time.Now().UnixMilli(), seq)
+	}
+
+	if err := prepareOperation(op); err != nil {
+		return err
+	}
+
+	bytes, err := proto.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("error marshalling operation: %w", err)
+	}
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO operations (id, repo_id, plan_id, snapshot_id, flow_id, instance_id, status, unix_time_start_ms, resume_on_start, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		op.Id, op.RepoId, op.PlanId, op.SnapshotId, op.FlowId, op.InstanceId, int32(op.Status), op.UnixTimeStartMs, op.ResumeOnStart, bytes); err != nil {
+		return fmt.Errorf("error putting operation into table: %w", err)
+	}
+	return nil
+}
+
+// sqlQueryer is implemented by both *sql.DB and *sql.Tx.
+type sqlQueryer interface {
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func getSQLiteOperation(q sqlQueryer, id int64) (*v1.Operation, error) {
+	var bytes []byte
+	if err := q.QueryRow("SELECT data FROM operations WHERE id = ?", id).Scan(&bytes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("opid %v: %w", id, ErrNotExist)
+		}
+		return nil, fmt.Errorf("opid %v: %w", id, err)
+	}
+	var op v1.Operation
+	if err := proto.Unmarshal(bytes, &op); err != nil {
+		return nil, fmt.Errorf("error unmarshalling operation: %w", err)
+	}
+	return &op, nil
+}
+
+func (s *SQLiteStore) Get(id int64) (*v1.Operation, error) {
+	return getSQLiteOperation(s.db, id)
+}
+
+func (s *SQLiteStore) ForEachByIndex(index Index, value string, collector indexutil.Collector, do func(op *v1.Operation) error) error {
+	column, ok := sqliteIndexColumns[index]
+	if !ok {
+		return fmt.Errorf("unknown index %v", index)
+	}
+	var arg any = value
+	if index == IndexFlow {
+		flowId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid flow ID %q: %w", value, err)
+		}
+		arg = flowId
+	} else if index == IndexStatus {
+		status, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid status %q: %w", value, err)
+		}
+		arg = status
+	}
+	return s.forEachByQuery("SELECT id FROM operations WHERE "+column+" = ? ORDER BY id", []any{arg}, collector, do)
+}
+
+func (s *SQLiteStore) ForEachByStartTime(startAfterMs, startBeforeMs int64, collector indexutil.Collector, do func(op *v1.Operation) error) error {
+	return s.forEachByQuery("SELECT id FROM operations WHERE unix_time_start_ms >= ? AND unix_time_start_ms < ? ORDER BY id",
+		[]any{startAfterMs, startBeforeMs}, collector, do)
+}
+
+// forEachByQuery calls do for the operations with the IDs selected by collector from those the query returns, the query
+// must return IDs in ascending order.
+func (s *SQLiteStore) forEachByQuery(query string, args []any, collector indexutil.Collector, do func(op *v1.Operation) error) error {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("query index: %w", err)
+	}
+	var matched []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("query index: %w", err)
+		}
+		matched = append(matched, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("query index: %w", err)
+	}
+
+	ids := collector(indexutil.NewSliceIterator(matched))
+	for len(ids) > 0 {
+		batch := ids[:min(len(ids), sqliteFetchBatchSize)]
+		ids = ids[len(batch):]
+		ops, err := s.getOperations(batch)
+		if err != nil {
+			return err
+		}
+		for _, op := range ops {
+			if err := do(op); err != nil {
+				if err == ErrStopIteration {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getOperations returns the operations with the IDs in the order of the IDs, operations deleted since their IDs were
+// looked up are left out.
+func (s *SQLiteStore) getOperations(ids []int64) ([]*v1.Operation, error) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := s.db.Query("SELECT id, data FROM operations WHERE id IN (?"+strings.Repeat(", ?", len(ids)-1)+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query operations: %w", err)
+	}
+	defer rows.Close()
+	byID := make(map[int64]*v1.Operation, len(ids))
+	for rows.Next() {
+		var id int64
+		var bytes []byte
+		if err := rows.Scan(&id, &bytes); err != nil {
+			return nil, fmt.Errorf("query operations: %w", err)
+		}
+		op := &v1.Operation{}
+		if err := proto.Unmarshal(bytes, op); err != nil {
+			return nil, fmt.Errorf("error unmarshalling operation: %w", err)
+		}
+		byID[id] = op
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query operations: %w", err)
+	}
+	ops := make([]*v1.Operation, 0, len(ids))
+	for _, id := range ids {
+		if op, ok := byID[id]; ok {
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
+
+func (s *SQLiteStore) ForAll(do func(op *v1.Operation) error) error {
+	// page through the operations rather than holding a single query open while do runs.
+	var lastID int64 = -1 << 63
+	for {
+		rows, err := s.db.Query("SELECT id, data FROM operations WHERE id > ? ORDER BY id LIMIT ?", lastID, sqliteFetchBatchSize)
+		if err != nil {
+			return fmt.Errorf("query operations: %w", err)
+		}
+		var ops []*v1.Operation
+		for rows.Next() {
+			var bytes []byte
+			if err := rows.Scan(&lastID, &bytes); err != nil {
+				rows.Close()
+				return fmt.Errorf("query operations: %w", err)
+			}
+			op := &v1.Operation{}
+			if err := proto.Unmarshal(bytes, op); err != nil {
+				rows.Close()
+				return fmt.Errorf("error unmarshalling operation: %w", err)
+			}
+			ops = append(ops, op)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("query operations: %w", err)
+		}
+		for _, op := range ops {
+			if err := do(op); err != nil {
+				return err
+			}
+		}
+		if len(ops) < sqliteFetchBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *SQLiteStore) Scan(onIncomplete func(op *v1.Operation)) error {
+	return s.update(func(tx *sql.Tx) error {
+		rows, err := tx.Query("SELECT data FROM operations WHERE " + sqliteScannedCondition + " ORDER BY id")
+		if err != nil {
+			return fmt.Errorf("query incomplete operations: %w", err)
+		}
+		var ops []*v1.Operation
+		for rows.Next() {
+			var bytes []byte
+			if err := rows.Scan(&bytes); err != nil {
+				rows.Close()
+				return fmt.Errorf("query incomplete operations: %w", err)
+			}
+			op := &v1.Operation{}
+			if err := proto.Unmarshal(bytes, op); err != nil {
+				zap.L().Error("error unmarshalling operation, there may be corruption in the oplog", zap.Error(err))
+				continue
+			}
+			ops = append(ops, op)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("query incomplete operations: %w", err)
+		}
+
+		for _, op := range ops {
+			if isIncomplete(op) {
+				onIncomplete(op)
+			} else if isDiscardedOnScan(op) {
+				if _, err := tx.Exec("DELETE FROM operations WHERE id = ?", op.Id); err != nil {
+					return fmt.Errorf("deleting operation %v: %w", op.Id, err)
+				}
+				continue
+			}
+			if err := s.putOperation(tx, op); err != nil {
+				zap.L().Error("error re-adding operation, there may be corruption in the oplog", zap.Error(err))
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) GetValue(bucket, key []byte) ([]byte, error) {
+	var value []byte
+	if err := s.db.QueryRow("SELECT value FROM kv WHERE bucket = ? AND key = ?", string(bucket), key).Scan(&value); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+func (s *SQLiteStore) ForEachValue(bucket []byte, do func(key, value []byte) error) error {
+	rows, err := s.db.Query("SELECT key, value FROM kv WHERE bucket = ? ORDER BY key", string(bucket))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key, value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+		if err := do(key, value); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *SQLiteStore) PutValue(bucket, key, value []byte) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO kv (bucket, key, value) VALUES (?, ?, ?)", string(bucket), key, value)
+	return err
+}
+
+func (s *SQLiteStore) UpdateValue(bucket, key []byte, update func(value []byte) ([]byte, error)) error {
+	return s.update(func(tx *sql.Tx) error {
+		var value []byte
+		if err := tx.QueryRow("SELECT value FROM kv WHERE bucket = ? AND key = ?", string(bucket), key).Scan(&value); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		value, err := update(value)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("INSERT OR REPLACE INTO kv (bucket, key, value) VALUES (?, ?, ?)", string(bucket), key, value)
+		return err
+	})
+}
+
+func (s *SQLiteStore) DeleteValues(bucket []byte, keys ...[]byte) error {
+	return s.update(func(tx *sql.Tx) error {
+		for _, key := range keys {
+			if _, err := tx.Exec("DELETE FROM kv WHERE bucket = ? AND key = ?", string(bucket), key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func nextSQLiteSequence(tx *sql.Tx, bucket []byte) (uint64, error) {
+	var seq uint64
+	err := tx.QueryRow("INSERT INTO sequences (bucket, value) VALUES (?, 1) ON CONFLICT (bucket) DO UPDATE SET value = value + 1 RETURNING value", string(bucket)).Scan(&seq)
+	return seq, err
+}
+
+func (s *SQLiteStore) NextSequence(bucket []byte) (uint64, error) {
+	var seq uint64
+	err := s.update(func(tx *sql.Tx) error {
+		var err error
+		seq, err = nextSQLiteSequence(tx, bucket)
+		return err
+	})
+	return seq, err
+}
+
+func (s *SQLiteStore) Sequence(bucket []byte) (uint64, error) {
+	var seq uint64
+	if err := s.db.QueryRow("SELECT value FROM sequences WHERE bucket = ?", string(bucket)).Scan(&seq); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+	return seq, nil
+}
+
+func (s *SQLiteStore) SetSequence(bucket []byte, seq uint64) error {
+	_, err := s.db.Exec("INSERT OR REPLACE INTO sequences (bucket, value) VALUES (?, ?)", string(bucket), seq)
+	return err
+}
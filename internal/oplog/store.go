@@ -0,0 +1,187 @@
+package oplog
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"github.com/garethgeorge/backrest/internal/protoutil"
+	"github.com/garethgeorge/backrest/internal/redact"
+)
+
+var ErrKeyNotFound = errors.New("key not found")
+
+// Index identifies a field of operations that a Store indexes them by.
+type Index int
+
+const (
+	IndexRepo Index = iota
+	IndexPlan
+	IndexSnapshot
+	IndexFlow
+	IndexInstance
+	IndexStatus // the value is the number of the operation's status
+)
+
+// Store persists operations for an OpLog along with the small key value records kept next to them e.g. cached
+// snapshot stats. Buckets group the key value records, see the *Bucket variables. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Add adds the operations in a single transaction. Operations without an ID are assigned one, operations with an ID
+	// replace any operation stored with that ID.
+	Add(ops ...*v1.Operation) error
+	// Update replaces the operation with the same ID and returns the replaced operation.
+	Update(op *v1.Operation) (*v1.Operation, error)
+	// Delete removes the operations in a single transaction and returns them.
+	Delete(ids ...int64) ([]*v1.Operation, error)
+	// Get returns the operation with the ID or an error wrapping ErrNotExist.
+	Get(id int64) (*v1.Operation, error)
+	// ForEachByIndex calls do for the operations with the value of the index, in the order of the IDs selected by
+	// collector from the matching IDs in ascending order. do may return ErrStopIteration to stop early.
+	ForEachByIndex(index Index, value string, collector indexutil.Collector, do func(op *v1.Operation) error) error
+	// ForEachByStartTime is like ForEachByIndex for the operations started in [startAfterMs, startBeforeMs).
+	ForEachByStartTime(startAfterMs, startBeforeMs int64, collector indexutil.Collector, do func(op *v1.Operation) error) error
+	// ForAll calls do for every operation in ascending order of ID.
+	ForAll(do func(op *v1.Operation) error) error
+	// Scan calls onIncomplete for operations left in progress or marked to resume on start and saves the changes it
+	// makes, operations that never completed e.g. pending or cancelled are removed.
+	Scan(onIncomplete func(op *v1.Operation)) error
+
+	// GetValue returns the value of the key in the bucket or ErrKeyNotFound.
+	GetValue(bucket, key []byte) ([]byte, error)
+	// ForEachValue calls do for each key and value in the bucket in ascending order of key.
+	ForEachValue(bucket []byte, do func(key, value []byte) error) error
+	PutValue(bucket, key, value []byte) error
+	// UpdateValue replaces the value of the key with the value update returns in a single transaction, value is nil
+	// if the key isn't set. Nothing is saved if update returns an error.
+	UpdateValue(bucket, key []byte, update func(value []byte) ([]byte, error)) error
+	DeleteValues(bucket []byte, keys ...[]byte) error
+	// NextSequence increments the bucket's sequence and returns the new value.
+	NextSequence(bucket []byte) (uint64, error)
+	// Sequence returns the bucket's sequence without incrementing it.
+	Sequence(bucket []byte) (uint64, error)
+	SetSequence(bucket []byte, seq uint64) error
+
+	// Compact shrinks the database file after operations are deleted.
+	Compact() error
+	Close() error
+}
+
+// Storage backends selectable for the oplog.
+const (
+	BackendBolt   = "bbolt"
+	BackendSQLite = "sqlite"
+)
+
+// StorePath returns the path of the database file the backend stores the oplog in under dataDir.
+func StorePath(backend, dataDir string) (string, error) {
+	switch backend {
+	case BackendBolt, "":
+		return path.Join(dataDir, "oplog.boltdb"), nil
+	case BackendSQLite:
+		return path.Join(dataDir, "oplog.sqlite"), nil
+	default:
+		return "", fmt.Errorf("unknown oplog backend %q, expected %q or %q", backend, BackendBolt, BackendSQLite)
+	}
+}
+
+// OpenStore opens or creates the oplog store of the backend under dataDir.
+func OpenStore(backend, dataDir string) (Store, error) {
+	p, err := StorePath(backend, dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if backend == BackendSQLite {
+		return NewSQLiteStore(p)
+	}
+	return NewBoltStore(p)
+}
+
+// copiedBuckets are the key value buckets CopyStore copies, buckets not listed hold backend specific metadata.
+var copiedBuckets = [][]byte{SnapshotStatsBucket, AlertStateBucket, HookDeliveryBucket, ShareLinkBucket}
+
+// copyBatchSize is the number of operations CopyStore adds per transaction.
+const copyBatchSize = 1000
+
+// CopyStore copies every operation and key value record from src to dst keeping their IDs, e.g. to migrate the oplog
+// to another backend. dst is expected to be empty.
+func CopyStore(dst, src Store) error {
+	batch := make([]*v1.Operation, 0, copyBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := dst.Add(batch...); err != nil {
+			return fmt.Errorf("add operations: %w", err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+	if err := src.ForAll(func(op *v1.Operation) error {
+		batch = append(batch, op)
+		if len(batch) >= copyBatchSize {
+			return flush()
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("copy operations: %w", err)
+	}
+	if err := flush(); err != nil {
+		return fmt.Errorf("copy operations: %w", err)
+	}
+
+	for _, bucket := range copiedBuckets {
+		if err := src.ForEachValue(bucket, func(k, v []byte) error {
+			return dst.PutValue(bucket, k, v)
+		}); err != nil {
+			return fmt.Errorf("copy bucket %s: %w", bucket, err)
+		}
+	}
+	for _, bucket := range append([][]byte{OpLogBucket}, copiedBuckets...) {
+		seq, err := src.Sequence(bucket)
+		if err != nil {
+			return fmt.Errorf("get sequence of bucket %s: %w", bucket, err)
+		}
+		if err := dst.SetSequence(bucket, seq); err != nil {
+			return fmt.Errorf("set sequence of bucket %s: %w", bucket, err)
+		}
+	}
+	return nil
+}
+
+// operationID returns the ID of an operation created at unixTimeMs, seq disambiguates operations created in the same
+// millisecond.
+func operationID(unixTimeMs int64, seq uint64) int64 {
+	return int64(unixTimeMs<<20) | int64(seq&((1<<20)-1))
+}
+
+// prepareOperation fills in the derived fields of an operation about to be stored and validates it, op must have an ID.
+func prepareOperation(op *v1.Operation) error {
+	if op.FlowId == 0 {
+		op.FlowId = op.Id
+	}
+
+	// operation errors often embed restic's output which may echo credentials.
+	op.DisplayMessage = redact.String(op.DisplayMessage)
+
+	if err := protoutil.ValidateOperation(op); err != nil {
+		return fmt.Errorf("validating operation: %w", err)
+	}
+	return nil
+}
+
+// isIncomplete returns true if Scan should hand the operation to its onIncomplete callback.
+func isIncomplete(op *v1.Operation) bool {
+	return op.ResumeOnStart || op.Status == v1.OperationStatus_STATUS_INPROGRESS
+}
+
+// isDiscardedOnScan returns true if Scan should remove the operation, it never ran to completion.
+func isDiscardedOnScan(op *v1.Operation) bool {
+	switch op.Status {
+	case v1.OperationStatus_STATUS_PENDING, v1.OperationStatus_STATUS_SYSTEM_CANCELLED, v1.OperationStatus_STATUS_USER_CANCELLED, v1.OperationStatus_STATUS_UNKNOWN:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,320 @@
+package oplog
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// forEachBackend runs the test against a new store of each backend in this build.
+func forEachBackend(t *testing.T, test func(t *testing.T, store Store)) {
+	for _, backend := range []string{BackendBolt, BackendSQLite} {
+		t.Run(backend, func(t *testing.T) {
+			store := newTestStore(t, backend)
+			test(t, store)
+		})
+	}
+}
+
+func newTestStore(t *testing.T, backend string) Store {
+	if backend == BackendSQLite && !slices.Contains(sql.Drivers(), sqliteDriverName) {
+		t.Skip("sqlite driver not linked, run the tests with -tags sqlite")
+	}
+	store, err := OpenStore(backend, t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenStore(%q) error: %v", backend, err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func testOperation(planID string, status v1.OperationStatus) *v1.Operation {
+	return &v1.Operation{
+		UnixTimeStartMs: 1234,
+		RepoId:          "repo",
+		PlanId:          planID,
+		InstanceId:      "instance",
+		SnapshotId:      snapshotId,
+		Status:          status,
+		Op:              &v1.Operation_OperationBackup{},
+	}
+}
+
+func TestStoreOperations(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		ops := []*v1.Operation{
+			testOperation("plan1", v1.OperationStatus_STATUS_SUCCESS),
+			testOperation("plan2", v1.OperationStatus_STATUS_SUCCESS),
+			testOperation("plan1", v1.OperationStatus_STATUS_ERROR),
+		}
+		if err := store.Add(ops...); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+		for _, op := range ops {
+			if op.Id == 0 || op.FlowId != op.Id {
+				t.Fatalf("expected ID and flow ID to be assigned, got %v and %v", op.Id, op.FlowId)
+			}
+		}
+
+		var got []int64
+		if err := store.ForEachByIndex(IndexPlan, "plan1", indexutil.Reversed(indexutil.CollectAll()), func(op *v1.Operation) error {
+			got = append(got, op.Id)
+			return nil
+		}); err != nil {
+			t.Fatalf("ForEachByIndex() error: %v", err)
+		}
+		if want := []int64{ops[2].Id, ops[0].Id}; !slices.Equal(got, want) {
+			t.Errorf("ForEachByIndex(plan1) = %v, want %v", got, want)
+		}
+
+		got = nil
+		if err := store.ForEachByIndex(IndexRepo, "repo", indexutil.CollectAll(), func(op *v1.Operation) error {
+			got = append(got, op.Id)
+			return ErrStopIteration
+		}); err != nil {
+			t.Fatalf("ForEachByIndex() error: %v", err)
+		}
+		if len(got) != 1 {
+			t.Errorf("expected iteration to stop after the first operation, got %v", got)
+		}
+
+		updated := proto.Clone(ops[0]).(*v1.Operation)
+		updated.PlanId = "plan3"
+		old, err := store.Update(updated)
+		if err != nil {
+			t.Fatalf("Update() error: %v", err)
+		}
+		if old.PlanId != "plan1" {
+			t.Errorf("Update() returned %v, want the replaced operation", old)
+		}
+		count := 0
+		store.ForEachByIndex(IndexPlan, "plan1", indexutil.CollectAll(), func(op *v1.Operation) error {
+			count++
+			return nil
+		})
+		if count != 1 {
+			t.Errorf("expected the updated operation to be removed from its old plan's index, got %d operations", count)
+		}
+
+		if _, err := store.Delete(ops[1].Id); err != nil {
+			t.Fatalf("Delete() error: %v", err)
+		}
+		if _, err := store.Get(ops[1].Id); !errors.Is(err, ErrNotExist) {
+			t.Errorf("Get() of a deleted operation: got error %v, want ErrNotExist", err)
+		}
+		if _, err := store.Delete(ops[1].Id); !errors.Is(err, ErrNotExist) {
+			t.Errorf("Delete() of a deleted operation: got error %v, want ErrNotExist", err)
+		}
+	})
+}
+
+func TestStoreStatusAndStartTimeIndexes(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		var ops []*v1.Operation
+		for i, status := range []v1.OperationStatus{v1.OperationStatus_STATUS_ERROR, v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_ERROR, v1.OperationStatus_STATUS_ERROR} {
+			op := testOperation("plan", status)
+			op.UnixTimeStartMs = int64(4000 - i*1000) // start times in the opposite order of the IDs.
+			ops = append(ops, op)
+		}
+		if err := store.Add(ops...); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+
+		collect := func(forEach func(do func(op *v1.Operation) error) error) []int64 {
+			var got []int64
+			if err := forEach(func(op *v1.Operation) error {
+				got = append(got, op.Id)
+				return nil
+			}); err != nil {
+				t.Fatalf("ForEach error: %v", err)
+			}
+			return got
+		}
+		byStatus := func(status v1.OperationStatus) []int64 {
+			return collect(func(do func(op *v1.Operation) error) error {
+				return store.ForEachByIndex(IndexStatus, strconv.Itoa(int(status)), indexutil.CollectAll(), do)
+			})
+		}
+		byStartTime := func(afterMs, beforeMs int64) []int64 {
+			return collect(func(do func(op *v1.Operation) error) error {
+				return store.ForEachByStartTime(afterMs, beforeMs, indexutil.CollectAll(), do)
+			})
+		}
+
+		if got, want := byStatus(v1.OperationStatus_STATUS_ERROR), []int64{ops[0].Id, ops[2].Id, ops[3].Id}; !slices.Equal(got, want) {
+			t.Errorf("ForEachByIndex(IndexStatus) = %v, want %v", got, want)
+		}
+		if got, want := byStartTime(2000, 4000), []int64{ops[1].Id, ops[2].Id}; !slices.Equal(got, want) {
+			t.Errorf("ForEachByStartTime(2000, 4000) = %v, want %v", got, want)
+		}
+
+		updated := proto.Clone(ops[2]).(*v1.Operation)
+		updated.Status = v1.OperationStatus_STATUS_SUCCESS
+		updated.UnixTimeStartMs = 5000
+		if _, err := store.Update(updated); err != nil {
+			t.Fatalf("Update() error: %v", err)
+		}
+		if _, err := store.Delete(ops[0].Id); err != nil {
+			t.Fatalf("Delete() error: %v", err)
+		}
+		if got, want := byStatus(v1.OperationStatus_STATUS_ERROR), []int64{ops[3].Id}; !slices.Equal(got, want) {
+			t.Errorf("ForEachByIndex(IndexStatus) after update and delete = %v, want %v", got, want)
+		}
+		if got, want := byStartTime(2000, 6000), []int64{ops[1].Id, ops[2].Id}; !slices.Equal(got, want) {
+			t.Errorf("ForEachByStartTime(2000, 6000) after update and delete = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestStoreScan(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		inProgress := testOperation("plan", v1.OperationStatus_STATUS_INPROGRESS)
+		pending := testOperation("plan", v1.OperationStatus_STATUS_PENDING)
+		done := testOperation("plan", v1.OperationStatus_STATUS_SUCCESS)
+		if err := store.Add(inProgress, pending, done); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+
+		var incomplete []int64
+		if err := store.Scan(func(op *v1.Operation) {
+			incomplete = append(incomplete, op.Id)
+			op.Status = v1.OperationStatus_STATUS_ERROR
+		}); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if !slices.Equal(incomplete, []int64{inProgress.Id}) {
+			t.Errorf("Scan() reported incomplete operations %v, want %v", incomplete, []int64{inProgress.Id})
+		}
+		if op, err := store.Get(inProgress.Id); err != nil || op.Status != v1.OperationStatus_STATUS_ERROR {
+			t.Errorf("expected the change made to the incomplete operation to be saved, got %v, %v", op, err)
+		}
+		if _, err := store.Get(pending.Id); !errors.Is(err, ErrNotExist) {
+			t.Errorf("expected the pending operation to be removed, got error %v", err)
+		}
+		if _, err := store.Get(done.Id); err != nil {
+			t.Errorf("expected the completed operation to be kept, got error %v", err)
+		}
+	})
+}
+
+func TestStoreValues(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		if _, err := store.GetValue(AlertStateBucket, []byte("a")); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("GetValue() of an unset key: got error %v, want ErrKeyNotFound", err)
+		}
+		for _, k := range []string{"b", "a"} {
+			if err := store.PutValue(AlertStateBucket, []byte(k), []byte("value "+k)); err != nil {
+				t.Fatalf("PutValue() error: %v", err)
+			}
+		}
+		var keys []string
+		store.ForEachValue(AlertStateBucket, func(k, v []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+		if !slices.Equal(keys, []string{"a", "b"}) {
+			t.Errorf("ForEachValue() keys = %v, want [a b]", keys)
+		}
+
+		errUpdate := errors.New("update failed")
+		if err := store.UpdateValue(AlertStateBucket, []byte("a"), func(v []byte) ([]byte, error) {
+			return nil, errUpdate
+		}); !errors.Is(err, errUpdate) {
+			t.Errorf("UpdateValue() error = %v, want the update's error", err)
+		}
+		if err := store.UpdateValue(AlertStateBucket, []byte("a"), func(v []byte) ([]byte, error) {
+			return append(v, '!'), nil
+		}); err != nil {
+			t.Fatalf("UpdateValue() error: %v", err)
+		}
+		if v, err := store.GetValue(AlertStateBucket, []byte("a")); err != nil || string(v) != "value a!" {
+			t.Errorf("GetValue() = %q, %v, want the updated value", v, err)
+		}
+
+		if err := store.DeleteValues(AlertStateBucket, []byte("a"), []byte("b")); err != nil {
+			t.Fatalf("DeleteValues() error: %v", err)
+		}
+		if _, err := store.GetValue(AlertStateBucket, []byte("b")); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("GetValue() of a deleted key: got error %v, want ErrKeyNotFound", err)
+		}
+
+		for want := uint64(1); want <= 2; want++ {
+			if seq, err := store.NextSequence(ShareLinkBucket); err != nil || seq != want {
+				t.Errorf("NextSequence() = %v, %v, want %v", seq, err, want)
+			}
+		}
+	})
+}
+
+func TestCopyStore(t *testing.T) {
+	src := newTestStore(t, BackendBolt)
+	ops := []*v1.Operation{
+		testOperation("plan1", v1.OperationStatus_STATUS_SUCCESS),
+		testOperation("plan2", v1.OperationStatus_STATUS_WARNING),
+	}
+	if err := src.Add(ops...); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := src.PutValue(SnapshotStatsBucket, []byte(snapshotId), []byte("stats")); err != nil {
+		t.Fatalf("PutValue() error: %v", err)
+	}
+	if _, err := src.NextSequence(HookDeliveryBucket); err != nil {
+		t.Fatalf("NextSequence() error: %v", err)
+	}
+
+	forEachBackend(t, func(t *testing.T, dst Store) {
+		if err := CopyStore(dst, src); err != nil {
+			t.Fatalf("CopyStore() error: %v", err)
+		}
+		for _, op := range ops {
+			got, err := dst.Get(op.Id)
+			if err != nil {
+				t.Fatalf("Get(%v) error: %v", op.Id, err)
+			}
+			if !proto.Equal(got, op) {
+				t.Errorf("copied operation = %v, want %v", got, op)
+			}
+		}
+		if v, err := dst.GetValue(SnapshotStatsBucket, []byte(snapshotId)); err != nil || string(v) != "stats" {
+			t.Errorf("copied value = %q, %v", v, err)
+		}
+		if seq, err := dst.NextSequence(HookDeliveryBucket); err != nil || seq != 2 {
+			t.Errorf("expected the copied sequence to continue from the source's, got %v, %v", seq, err)
+		}
+	})
+}
+
+func TestStoreCompact(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store Store) {
+		var ops []*v1.Operation
+		for i := 0; i < 100; i++ {
+			ops = append(ops, testOperation("plan", v1.OperationStatus_STATUS_SUCCESS))
+		}
+		if err := store.Add(ops...); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+		var ids []int64
+		for _, op := range ops[1:] {
+			ids = append(ids, op.Id)
+		}
+		if _, err := store.Delete(ids...); err != nil {
+			t.Fatalf("Delete() error: %v", err)
+		}
+
+		if err := store.Compact(); err != nil {
+			t.Fatalf("Compact() error: %v", err)
+		}
+		if got, err := store.Get(ops[0].Id); err != nil || !proto.Equal(got, ops[0]) {
+			t.Errorf("Get() after Compact() = %v, %v, want the remaining operation", got, err)
+		}
+		if err := store.Add(testOperation("plan", v1.OperationStatus_STATUS_SUCCESS)); err != nil {
+			t.Errorf("Add() after Compact() error: %v", err)
+		}
+	})
+}
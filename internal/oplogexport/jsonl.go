@@ -0,0 +1,228 @@
+package oplogexport
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/redact"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxLineSize bounds the size of a single operation read by ReadOperations.
+const maxLineSize = 16 * 1024 * 1024
+
+// SanitizeOperation returns a copy of the operation without the secrets known to the redactor or the output of its
+// commands, e.g. to share it for debugging.
+func SanitizeOperation(op *v1.Operation, r *redact.Redactor) *v1.Operation {
+	op = proto.Clone(op).(*v1.Operation)
+	op.Logref = ""
+	op.LogTail = ""
+	op.FailureDiagnostics = nil
+	redactStrings(op.ProtoReflect(), r)
+	return op
+}
+
+// WriteOperation writes the operation to w as a line of JSON.
+func WriteOperation(w io.Writer, op *v1.Operation) error {
+	data, err := protojson.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("marshal operation %d: %w", op.Id, err)
+	}
+	// protojson never writes newlines unless it's asked for multiline output.
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write operation %d: %w", op.Id, err)
+	}
+	return nil
+}
+
+// WriteOperations writes the operations in the log that match the filter to w as JSON lines in chunks of up to 64 KiB, a
+// nil filter matches every operation. Operations are sanitized with the redactor if it isn't nil. It returns the number of operations
+// written.
+func WriteOperations(w io.Writer, log *oplog.OpLog, filter func(op *v1.Operation) bool, r *redact.Redactor) (int, error) {
+	bw := bufio.NewWriterSize(w, 64*1024)
+	count := 0
+	if err := log.ForAll(func(op *v1.Operation) error {
+		if filter != nil && !filter(op) {
+			return nil
+		}
+		if r != nil {
+			op = SanitizeOperation(op, r)
+		}
+		count++
+		return WriteOperation(bw, op)
+	}); err != nil {
+		return count, err
+	}
+	return count, bw.Flush()
+}
+
+// ReadOperations calls do for each operation in the JSON lines read from r, blank lines are skipped.
+func ReadOperations(r io.Reader, do func(op *v1.Operation) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		op := &v1.Operation{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(scanner.Bytes(), op); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := do(op); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("operation longer than %d bytes: %w", maxLineSize, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// ImportStats counts the operations handled by an Importer.
+type ImportStats struct {
+	Imported   int
+	Duplicates int // operations already in the log e.g. from an earlier import.
+	Unfinished int // operations that were pending or in progress when they were exported.
+}
+
+// Importer adds exported operations to a log. Operations are given new IDs, operations that shared a flow when they
+// were exported share a flow afterwards. Operations already in the log, identified by their instance, repo, plan, type
+// and start time, are skipped so that importing the same export twice is harmless.
+type Importer struct {
+	log   *oplog.OpLog
+	Stats ImportStats
+
+	existing map[importKey]int64 // flow IDs of the operations in the log by their key.
+	flows    map[int64]int64     // exported flow IDs to flow IDs in the log.
+	batch    []*v1.Operation
+	flowOf   map[*v1.Operation]int64 // exported flow IDs of the operations in the batch.
+}
+
+type importKey struct {
+	instanceID string
+	repoID     string
+	planID     string
+	opType     string
+	startMs    int64
+}
+
+func keyOf(op *v1.Operation) importKey {
+	return importKey{
+		instanceID: op.InstanceId,
+		repoID:     op.RepoId,
+		planID:     op.PlanId,
+		opType:     fmt.Sprintf("%T", op.Op),
+		startMs:    op.UnixTimeStartMs,
+	}
+}
+
+// importBatchSize is the number of operations added to the log per transaction.
+const importBatchSize = 500
+
+func NewImporter(log *oplog.OpLog) (*Importer, error) {
+	existing := make(map[importKey]int64)
+	if err := log.ForAll(func(op *v1.Operation) error {
+		existing[keyOf(op)] = op.FlowId
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list operations: %w", err)
+	}
+	return &Importer{
+		log:      log,
+		existing: existing,
+		flows:    make(map[int64]int64),
+		flowOf:   make(map[*v1.Operation]int64),
+	}, nil
+}
+
+// Add queues the operation to be added to the log, call Flush once every operation is added. Operations should be added
+// in the order of their original IDs, as they are exported.
+func (im *Importer) Add(op *v1.Operation) error {
+	switch op.Status {
+	case v1.OperationStatus_STATUS_PENDING, v1.OperationStatus_STATUS_INPROGRESS:
+		im.Stats.Unfinished++
+		return nil
+	}
+	key := keyOf(op)
+	if flowID, ok := im.existing[key]; ok {
+		im.Stats.Duplicates++
+		if op.FlowId != 0 {
+			im.flows[op.FlowId] = flowID
+		}
+		return nil
+	}
+
+	op = proto.Clone(op).(*v1.Operation)
+	originalFlow := op.FlowId
+	op.Id = 0
+	op.FlowId = 0
+	op.ResumeOnStart = false
+	if flowID, ok := im.flows[originalFlow]; ok {
+		op.FlowId = flowID
+	} else if originalFlow != 0 && im.batchHasFlow(originalFlow) {
+		// the flow's first operation is still in the batch, flush it to learn its new flow ID.
+		if err := im.Flush(); err != nil {
+			return err
+		}
+		op.FlowId = im.flows[originalFlow]
+	}
+	im.existing[key] = 0
+	im.batch = append(im.batch, op)
+	im.flowOf[op] = originalFlow
+	if len(im.batch) >= importBatchSize {
+		return im.Flush()
+	}
+	return nil
+}
+
+func (im *Importer) batchHasFlow(flowID int64) bool {
+	for _, op := range im.batch {
+		if im.flowOf[op] == flowID {
+			return true
+		}
+	}
+	return false
+}
+
+// Flush adds the queued operations to the log.
+func (im *Importer) Flush() error {
+	if len(im.batch) == 0 {
+		return nil
+	}
+	if err := im.log.BulkAdd(im.batch); err != nil {
+		return fmt.Errorf("add operations: %w", err)
+	}
+	for _, op := range im.batch {
+		if original := im.flowOf[op]; original != 0 {
+			if _, ok := im.flows[original]; !ok {
+				im.flows[original] = op.FlowId
+			}
+		}
+		im.existing[keyOf(op)] = op.FlowId
+		delete(im.flowOf, op)
+	}
+	im.Stats.Imported += len(im.batch)
+	im.batch = im.batch[:0]
+	return nil
+}
+
+// Import adds the operations in the JSON lines read from r to the log, see Importer.
+func Import(log *oplog.OpLog, r io.Reader) (ImportStats, error) {
+	im, err := NewImporter(log)
+	if err != nil {
+		return ImportStats{}, err
+	}
+	if err := ReadOperations(r, im.Add); err != nil {
+		return im.Stats, err
+	}
+	err = im.Flush()
+	return im.Stats, err
+}
@@ -0,0 +1,139 @@
+package oplogexport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/redact"
+)
+
+func newTestLog(t *testing.T) *oplog.OpLog {
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+	return log
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	src := newTestLog(t)
+	backup := &v1.Operation{
+		InstanceId:      "test",
+		RepoId:          "repo",
+		PlanId:          "plan",
+		UnixTimeStartMs: 1000,
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		Op:              &v1.Operation_OperationBackup{},
+	}
+	if err := src.Add(backup); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	for _, op := range []*v1.Operation{
+		{
+			InstanceId:      "test",
+			RepoId:          "repo",
+			PlanId:          "plan",
+			FlowId:          backup.FlowId,
+			UnixTimeStartMs: 2000,
+			Status:          v1.OperationStatus_STATUS_SUCCESS,
+			Op:              &v1.Operation_OperationIndexSnapshot{},
+		},
+		{
+			InstanceId:      "test",
+			RepoId:          "repo",
+			PlanId:          "plan",
+			UnixTimeStartMs: 3000,
+			Status:          v1.OperationStatus_STATUS_INPROGRESS,
+			Op:              &v1.Operation_OperationBackup{},
+		},
+	} {
+		if err := src.Add(op); err != nil {
+			t.Fatalf("error adding operation: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	count, err := WriteOperations(&buf, src, nil, nil)
+	if err != nil {
+		t.Fatalf("WriteOperations() error: %v", err)
+	}
+	if count != 3 || strings.Count(buf.String(), "\n") != 3 {
+		t.Fatalf("expected 3 lines, got %d operations:\n%s", count, buf.String())
+	}
+
+	dst := newTestLog(t)
+	stats, err := Import(dst, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if stats != (ImportStats{Imported: 2, Unfinished: 1}) {
+		t.Errorf("unexpected import stats %+v", stats)
+	}
+	var imported []*v1.Operation
+	if err := dst.ForAll(func(op *v1.Operation) error {
+		imported = append(imported, op)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForAll() error: %v", err)
+	}
+	if len(imported) != 2 || imported[0].FlowId != imported[0].Id || imported[1].FlowId != imported[0].FlowId {
+		t.Errorf("expected the imported operations to share a flow, got %v", imported)
+	}
+
+	// importing the same export again skips the operations already imported.
+	stats, err = Import(dst, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if stats != (ImportStats{Duplicates: 2, Unfinished: 1}) {
+		t.Errorf("unexpected stats importing again %+v", stats)
+	}
+}
+
+func TestExportSanitized(t *testing.T) {
+	t.Parallel()
+
+	log := newTestLog(t)
+	if err := log.Add(&v1.Operation{
+		RepoId:         "repo",
+		PlanId:         "plan",
+		DisplayMessage: "failed to connect with password hunter22",
+		Logref:         "log-1",
+		Op:             &v1.Operation_OperationBackup{},
+	}); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := WriteOperations(&buf, log, nil, redact.New([]string{"hunter22"})); err != nil {
+		t.Fatalf("WriteOperations() error: %v", err)
+	}
+	var ops []*v1.Operation
+	if err := ReadOperations(&buf, func(op *v1.Operation) error {
+		ops = append(ops, op)
+		return nil
+	}); err != nil {
+		t.Fatalf("ReadOperations() error: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("expected 1 operation, got %v", ops)
+	}
+	if strings.Contains(ops[0].DisplayMessage, "hunter22") || ops[0].Logref != "" {
+		t.Errorf("expected the operation to be sanitized, got %v", ops[0])
+	}
+}
+
+func TestReadOperationsInvalid(t *testing.T) {
+	t.Parallel()
+
+	err := ReadOperations(strings.NewReader("{\"id\":\"1\"}\n\nnot json\n"), func(op *v1.Operation) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("expected an error on line 3, got %v", err)
+	}
+}
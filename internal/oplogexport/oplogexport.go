@@ -0,0 +1,182 @@
+// Package oplogexport exports the operation history and config to versioned, gzip compressed JSON archives. Archives
+// are a disaster recovery record of backup activity that can be read without backrest or restic.
+package oplogexport
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/redact"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ArchiveVersion is the version of the archive format written by this package.
+const ArchiveVersion = 1
+
+const (
+	filePrefix = "backrest-oplog-"
+	fileSuffix = ".json.gz"
+	timeFormat = "20060102T150405Z"
+)
+
+// Build returns an archive of every operation in the log and of the config with its secrets redacted.
+func Build(cfg *v1.Config, log *oplog.OpLog, now time.Time) (*v1.OplogArchive, error) {
+	archive := &v1.OplogArchive{
+		Version:      ArchiveVersion,
+		ExportedAtMs: now.UnixMilli(),
+		Instance:     cfg.Instance,
+		Config:       redactConfig(cfg),
+	}
+	if err := log.ForAll(func(op *v1.Operation) error {
+		archive.Operations = append(archive.Operations, op)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list operations: %w", err)
+	}
+	return archive, nil
+}
+
+// redactConfig returns a copy of the config without the secrets known to the redact package or the users' password
+// hashes.
+func redactConfig(cfg *v1.Config) *v1.Config {
+	cfg = proto.Clone(cfg).(*v1.Config)
+	for _, user := range cfg.GetAuth().GetUsers() {
+		user.Password = nil
+	}
+	redactStrings(cfg.ProtoReflect(), redact.New(redact.SecretsFromConfig(cfg)))
+	return cfg
+}
+
+// redactStrings redacts every string field of the message and of the messages nested in it.
+func redactStrings(m protoreflect.Message, r *redact.Redactor) {
+	var strs []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if fd.Kind() == protoreflect.StringKind {
+					list.Set(i, protoreflect.ValueOfString(r.String(list.Get(i).String())))
+				} else if fd.Kind() == protoreflect.MessageKind {
+					redactStrings(list.Get(i).Message(), r)
+				}
+			}
+		case fd.IsMap():
+			// the config has no maps.
+		case fd.Kind() == protoreflect.StringKind:
+			strs = append(strs, fd)
+		case fd.Kind() == protoreflect.MessageKind:
+			redactStrings(v.Message(), r)
+		}
+		return true
+	})
+	// fields other than the one being visited may not be set while ranging over the message.
+	for _, fd := range strs {
+		m.Set(fd, protoreflect.ValueOfString(r.String(m.Get(fd).String())))
+	}
+}
+
+// Write writes the archive to w as gzip compressed JSON.
+func Write(w io.Writer, archive *v1.OplogArchive) error {
+	data, err := protojson.MarshalOptions{Indent: "  "}.Marshal(archive)
+	if err != nil {
+		return fmt.Errorf("marshal archive: %w", err)
+	}
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(data); err != nil {
+		return fmt.Errorf("compress archive: %w", err)
+	}
+	return zw.Close()
+}
+
+// Read reads an archive written by Write, archives written by newer versions of backrest are refused.
+func Read(r io.Reader) (*v1.OplogArchive, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("decompress archive: %w", err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress archive: %w", err)
+	}
+	archive := &v1.OplogArchive{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, archive); err != nil {
+		return nil, fmt.Errorf("unmarshal archive: %w", err)
+	}
+	if archive.Version > ArchiveVersion {
+		return nil, fmt.Errorf("archive version %d is newer than the supported version %d", archive.Version, ArchiveVersion)
+	}
+	return archive, nil
+}
+
+// FileName returns the name of the archive exported by the instance at the given time. Names sort by export time.
+func FileName(instance string, at time.Time) string {
+	return filePrefix + instance + "-" + at.UTC().Format(timeFormat) + fileSuffix
+}
+
+// WriteFile writes the archive to a new file in dir and returns its path. The file is written under a temporary name
+// and renamed into place, a partially written archive is never visible.
+func WriteFile(dir string, archive *v1.OplogArchive) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("create directory %q: %w", dir, err)
+	}
+	path := filepath.Join(dir, FileName(archive.Instance, time.UnixMilli(archive.ExportedAtMs)))
+	f, err := os.CreateTemp(dir, ".tmp-"+filePrefix)
+	if err != nil {
+		return "", fmt.Errorf("create archive: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if err := Write(f, archive); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write archive: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", fmt.Errorf("rename archive: %w", err)
+	}
+	return path, nil
+}
+
+// Prune removes all but the newest keepLast archives of the instance in dir.
+func Prune(dir, instance string, keepLast int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("list archives: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		// instance IDs may contain dashes, the timestamp is checked so that another instance's archives are kept.
+		ts, ok := strings.CutPrefix(strings.TrimSuffix(name, fileSuffix), filePrefix+instance+"-")
+		if _, err := time.Parse(timeFormat, ts); !ok || err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) <= keepLast {
+		return nil
+	}
+	slices.Sort(names)
+	for _, name := range names[:len(names)-keepLast] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("remove archive %q: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package oplogexport
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+func TestBuildWriteRead(t *testing.T) {
+	t.Parallel()
+
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+	for i := 0; i < 3; i++ {
+		if err := log.Add(&v1.Operation{
+			RepoId:          "repo",
+			PlanId:          "plan",
+			UnixTimeStartMs: int64(1000 * i),
+			Op:              &v1.Operation_OperationBackup{},
+		}); err != nil {
+			t.Fatalf("error adding operation: %v", err)
+		}
+	}
+
+	cfg := &v1.Config{
+		Instance: "test",
+		Repos:    []*v1.Repo{{Id: "repo", Uri: "/backups", Password: "hunter22", Env: []string{"AWS_SECRET_ACCESS_KEY=abcdefgh"}}},
+		Auth: &v1.Auth{Users: []*v1.User{
+			{Name: "alice", Password: &v1.User_PasswordBcrypt{PasswordBcrypt: "$2a$10$hash"}},
+		}},
+	}
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	archive, err := Build(cfg, log, now)
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	dir := t.TempDir()
+	path, err := WriteFile(dir, archive)
+	if err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	if filepath.Base(path) != "backrest-oplog-test-20240510T120000Z.json.gz" {
+		t.Errorf("unexpected archive name %q", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("error opening archive: %v", err)
+	}
+	defer f.Close()
+	got, err := Read(f)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+
+	if got.Version != ArchiveVersion || got.Instance != "test" || got.ExportedAtMs != now.UnixMilli() {
+		t.Errorf("unexpected archive header %v", got)
+	}
+	if len(got.Operations) != 3 {
+		t.Errorf("expected 3 operations, got %d", len(got.Operations))
+	}
+	repo := got.Config.Repos[0]
+	if repo.Password != "[redacted]" || strings.Contains(repo.Env[0], "abcdefgh") || repo.Uri != "/backups" {
+		t.Errorf("expected the repo's secrets to be redacted, got %v", repo)
+	}
+	if got.Config.Auth.Users[0].Password != nil {
+		t.Errorf("expected the user's password hash to be removed, got %v", got.Config.Auth.Users[0])
+	}
+	if cfg.Repos[0].Password != "hunter22" {
+		t.Errorf("expected the exported config not to be modified")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	start := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		for _, instance := range []string{"test", "test-other"} {
+			if err := os.WriteFile(filepath.Join(dir, FileName(instance, start.Add(time.Duration(i)*time.Hour))), nil, 0600); err != nil {
+				t.Fatalf("error writing archive: %v", err)
+			}
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "unrelated.json.gz"), nil, 0600); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	if err := Prune(dir, "test", 2); err != nil {
+		t.Fatalf("Prune() error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("error listing archives: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	want := []string{
+		FileName("test", start.Add(2*time.Hour)),
+		FileName("test", start.Add(3*time.Hour)),
+	}
+	for i := 0; i < 4; i++ {
+		want = append(want, FileName("test-other", start.Add(time.Duration(i)*time.Hour)))
+	}
+	want = append(want, "unrelated.json.gz")
+	slices.Sort(want)
+	if !slices.Equal(names, want) {
+		t.Errorf("unexpected archives after prune, got %v, want %v", names, want)
+	}
+}
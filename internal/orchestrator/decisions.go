@@ -0,0 +1,87 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxSchedulerDecisions bounds the decisions kept by the decision log, the oldest are dropped first.
+const maxSchedulerDecisions = 2000
+
+// decisionLog records why the scheduler did or didn't run tasks so that e.g. a backup that didn't run can be explained
+// after the fact. It's kept in memory only, decisions are lost on restart.
+type decisionLog struct {
+	mu        sync.Mutex
+	decisions []*v1.SchedulerDecision // oldest first.
+}
+
+func (l *decisionLog) add(d *v1.SchedulerDecision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.decisions) >= maxSchedulerDecisions {
+		l.decisions = slices.Delete(l.decisions, 0, 1)
+	}
+	l.decisions = append(l.decisions, d)
+}
+
+// list returns copies of the decisions newest first.
+func (l *decisionLog) list() []*v1.SchedulerDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res := make([]*v1.SchedulerDecision, 0, len(l.decisions))
+	for i := len(l.decisions) - 1; i >= 0; i-- {
+		res = append(res, proto.Clone(l.decisions[i]).(*v1.SchedulerDecision))
+	}
+	return res
+}
+
+// SchedulerDecisions returns the scheduler's recent decisions newest first.
+func (o *Orchestrator) SchedulerDecisions() []*v1.SchedulerDecision {
+	return o.decisions.list()
+}
+
+// recordDecision adds a decision about the task to the decision log, runAt is the time the task is queued to run at if
+// the decision queues it.
+func (o *Orchestrator) recordDecision(t tasks.Task, kind v1.SchedulerDecision_Kind, runAt time.Time, reason string) {
+	d := &v1.SchedulerDecision{
+		UnixTimeMs: o.curTime().UnixMilli(),
+		Kind:       kind,
+		Task:       t.Name(),
+		PlanId:     t.PlanID(),
+		RepoId:     t.RepoID(),
+		Reason:     reason,
+	}
+	if !runAt.IsZero() {
+		d.RunAtMs = runAt.UnixMilli()
+	}
+	o.decisions.add(d)
+}
+
+// recordNotScheduled adds a decision to the decision log about a plan or repo whose tasks are not queued.
+func (o *Orchestrator) recordNotScheduled(planID, repoID, reason string) {
+	o.decisions.add(&v1.SchedulerDecision{
+		UnixTimeMs: o.curTime().UnixMilli(),
+		Kind:       v1.SchedulerDecision_KIND_NOT_SCHEDULED,
+		PlanId:     planID,
+		RepoId:     repoID,
+		Reason:     reason,
+	})
+}
+
+// pausedReason describes why the plan or repo, as named by what, is paused.
+func pausedReason(what string, paused *v1.PauseState) string {
+	reason := fmt.Sprintf("the %s is paused", what)
+	if paused.PausedBy != "" {
+		reason += " by " + paused.PausedBy
+	}
+	if paused.Reason != "" {
+		reason += ": " + paused.Reason
+	}
+	return reason
+}
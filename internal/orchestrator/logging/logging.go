@@ -3,8 +3,13 @@ package logging
 import (
 	"context"
 	"io"
+
+	"github.com/garethgeorge/backrest/internal/ioutil"
 )
 
+// DaemonLog keeps the tail of backrest's own log in memory, it's attached to the diagnostics of failed operations.
+var DaemonLog = &ioutil.TailWriter{Limit: 64 * 1024}
+
 type contextKey int
 
 const (
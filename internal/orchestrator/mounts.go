@@ -0,0 +1,228 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrNotMounted = errors.New("repo is not mounted")
+
+const (
+	defaultMountIdleTimeout = 30 * time.Minute
+	mountReadyTimeout       = 30 * time.Second // time restic is given to mount a repo before mounting fails.
+)
+
+// mountManager runs restic mount for the repos mounted through the API. Each repo is mounted at a directory of its own
+// under dir and is unmounted once it wasn't requested for its idle timeout, or when the orchestrator stops.
+type mountManager struct {
+	mu     sync.Mutex
+	dir    string
+	mounts map[string]*mount
+
+	// mountFn runs restic mount for the repo until ctx is cancelled, replaced in tests.
+	mountFn func(ctx context.Context, repoID string, mountpoint string) error
+}
+
+type mount struct {
+	state     *v1.RepoMount
+	idleTimer *time.Timer
+	stop      context.CancelFunc
+	done      chan struct{} // closed once restic exited and the mountpoint was removed.
+	err       error         // the error restic exited with, set before done is closed.
+}
+
+func newMountManager(dir string, mountFn func(ctx context.Context, repoID string, mountpoint string) error) *mountManager {
+	return &mountManager{
+		dir:     dir,
+		mounts:  make(map[string]*mount),
+		mountFn: mountFn,
+	}
+}
+
+// MountRepo mounts the repo's snapshots and returns the mount once the mountpoint is ready. If the repo is already
+// mounted its idle timeout is restarted, a zero idle timeout uses the default of 30 minutes.
+func (o *Orchestrator) MountRepo(repoID string, idleTimeout time.Duration) (*v1.RepoMount, error) {
+	if _, err := o.GetRepo(repoID); err != nil {
+		return nil, err
+	}
+	return o.mounts.mount(repoID, idleTimeout)
+}
+
+// UnmountRepo unmounts the repo and waits for restic to exit, returns ErrNotMounted if the repo isn't mounted.
+func (o *Orchestrator) UnmountRepo(repoID string) error {
+	return o.mounts.unmount(repoID)
+}
+
+// Mounts returns the mounted repos ordered by repo ID.
+func (o *Orchestrator) Mounts() []*v1.RepoMount {
+	return o.mounts.list()
+}
+
+func (o *Orchestrator) runMount(ctx context.Context, repoID string, mountpoint string) error {
+	repo, err := o.GetRepoOrchestrator(repoID)
+	if err != nil {
+		return err
+	}
+	return repo.Mount(ctx, mountpoint)
+}
+
+func (m *mountManager) mount(repoID string, idleTimeout time.Duration) (*v1.RepoMount, error) {
+	if idleTimeout <= 0 {
+		idleTimeout = defaultMountIdleTimeout
+	}
+	now := time.Now()
+
+	m.mu.Lock()
+	mnt, ok := m.mounts[repoID]
+	if ok {
+		mnt.idleTimer.Reset(idleTimeout)
+	} else {
+		mnt = m.start(repoID, idleTimeout, now)
+		m.mounts[repoID] = mnt
+	}
+	mnt.state.UnmountAtMs = now.Add(idleTimeout).UnixMilli()
+	m.mu.Unlock()
+
+	if err := m.awaitMounted(mnt); err != nil {
+		return nil, fmt.Errorf("mount repo %q: %w", repoID, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return proto.Clone(mnt.state).(*v1.RepoMount), nil
+}
+
+// start runs restic mount for the repo in the background, m.mu must be held.
+func (m *mountManager) start(repoID string, idleTimeout time.Duration, now time.Time) *mount {
+	ctx, cancel := context.WithCancel(context.Background())
+	mnt := &mount{
+		state: &v1.RepoMount{
+			RepoId:      repoID,
+			Mountpoint:  filepath.Join(m.dir, repoID),
+			State:       v1.RepoMount_STATE_MOUNTING,
+			MountedAtMs: now.UnixMilli(),
+		},
+		stop: cancel,
+		done: make(chan struct{}),
+	}
+	mnt.idleTimer = time.AfterFunc(idleTimeout, func() {
+		zap.L().Info("unmounting idle repo", zap.String("repo", repoID))
+		cancel()
+	})
+
+	zap.L().Info("mounting repo", zap.String("repo", repoID), zap.String("mountpoint", mnt.state.Mountpoint))
+	go func() {
+		err := os.MkdirAll(mnt.state.Mountpoint, 0700)
+		if err == nil {
+			err = m.mountFn(ctx, repoID, mnt.state.Mountpoint)
+		}
+		if err != nil {
+			zap.L().Error("restic mount failed", zap.String("repo", repoID), zap.Error(err))
+		} else {
+			zap.L().Info("repo unmounted", zap.String("repo", repoID))
+		}
+		if e := os.Remove(mnt.state.Mountpoint); e != nil && !errors.Is(e, os.ErrNotExist) {
+			zap.L().Warn("failed to remove mountpoint", zap.String("mountpoint", mnt.state.Mountpoint), zap.Error(e))
+		}
+
+		m.mu.Lock()
+		mnt.idleTimer.Stop()
+		mnt.err = err
+		if m.mounts[repoID] == mnt {
+			delete(m.mounts, repoID)
+		}
+		m.mu.Unlock()
+		cancel()
+		close(mnt.done)
+	}()
+	return mnt
+}
+
+// awaitMounted waits for restic to populate the mountpoint, the mount is stopped if it isn't within mountReadyTimeout.
+func (m *mountManager) awaitMounted(mnt *mount) error {
+	deadline := time.NewTimer(mountReadyTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		m.mu.Lock()
+		mounted := mnt.state.State == v1.RepoMount_STATE_MOUNTED
+		if !mounted {
+			// the mountpoint is created empty, restic lists the snapshots in it once the repo is mounted.
+			if entries, err := os.ReadDir(mnt.state.Mountpoint); err == nil && len(entries) > 0 {
+				mnt.state.State = v1.RepoMount_STATE_MOUNTED
+				mounted = true
+			}
+		}
+		m.mu.Unlock()
+		if mounted {
+			return nil
+		}
+
+		select {
+		case <-mnt.done:
+			if mnt.err != nil {
+				return mnt.err
+			}
+			return errors.New("unmounted before the mount completed")
+		case <-deadline.C:
+			mnt.stop()
+			<-mnt.done
+			return fmt.Errorf("not mounted within %v", mountReadyTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *mountManager) unmount(repoID string) error {
+	m.mu.Lock()
+	mnt, ok := m.mounts[repoID]
+	m.mu.Unlock()
+	if !ok {
+		return ErrNotMounted
+	}
+	mnt.stop()
+	<-mnt.done
+	return nil
+}
+
+// unmountAll unmounts every mounted repo and waits for restic to exit.
+func (m *mountManager) unmountAll() {
+	m.mu.Lock()
+	mounts := make([]*mount, 0, len(m.mounts))
+	for _, mnt := range m.mounts {
+		mounts = append(mounts, mnt)
+	}
+	m.mu.Unlock()
+
+	for _, mnt := range mounts {
+		mnt.stop()
+	}
+	for _, mnt := range mounts {
+		<-mnt.done
+	}
+}
+
+func (m *mountManager) list() []*v1.RepoMount {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mounts := make([]*v1.RepoMount, 0, len(m.mounts))
+	for _, mnt := range m.mounts {
+		mounts = append(mounts, proto.Clone(mnt.state).(*v1.RepoMount))
+	}
+	sort.Slice(mounts, func(i, j int) bool {
+		return mounts[i].RepoId < mounts[j].RepoId
+	})
+	return mounts
+}
@@ -1,23 +1,32 @@
 package orchestrator
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"path"
+	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/backupwindow"
+	"github.com/garethgeorge/backrest/internal/config"
 	"github.com/garethgeorge/backrest/internal/hook"
-	"github.com/garethgeorge/backrest/internal/ioutil"
+	"github.com/garethgeorge/backrest/internal/metrics"
 	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
 	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
 	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
-	"github.com/garethgeorge/backrest/internal/queue"
+	"github.com/garethgeorge/backrest/internal/redact"
 	"github.com/garethgeorge/backrest/internal/rotatinglog"
+	"github.com/garethgeorge/backrest/internal/runconditions"
+	"github.com/garethgeorge/backrest/pkg/restic"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 )
@@ -26,27 +35,62 @@ var ErrRepoNotFound = errors.New("repo not found")
 var ErrRepoInitializationFailed = errors.New("repo initialization failed")
 var ErrPlanNotFound = errors.New("plan not found")
 
+// CommandLogRefPrefix prefixes the log refs of operations whose output is kept in a command log, see OpenCommandLog.
+const CommandLogRefPrefix = "commands/"
+
+// maxCommandLogs is the number of operation logs kept, the oldest are removed first.
+const maxCommandLogs = 1000
+
+// commandLogTailLimit is the size of the end of an operation's log kept in the oplog.
+const commandLogTailLimit = 4 * 1024
+
+// defaultShutdownGracePeriod is how long Run waits for running tasks to stop after its context is cancelled.
+const defaultShutdownGracePeriod = 30 * time.Second
+
 // Orchestrator is responsible for managing repos and backups.
 type Orchestrator struct {
-	mu           sync.Mutex
-	config       *v1.Config
-	OpLog        *oplog.OpLog
-	repoPool     *resticRepoPool
-	taskQueue    *queue.TimePriorityQueue[stContainer]
-	hookExecutor *hook.HookExecutor
-	logStore     *rotatinglog.RotatingLog
+	mu        sync.Mutex
+	config    *v1.Config
+	OpLog     *oplog.OpLog
+	repoPool  *resticRepoPool
+	taskQueue *taskQueue
+	limiter   *concurrencyLimiter
+	hookPool  *hook.WorkerPool
+	hookQueue *hook.DeliveryQueue // retries hook notifications that failed to send, nil if there is no oplog.
+	mounts    *mountManager
+
+	// namespaceLimiters bounds the tasks that may run at once for the repos of each namespace that sets a limit.
+	namespaceLimiters map[string]*concurrencyLimiter
+	logStore          *rotatinglog.RotatingLog
+	commandLogs       *restic.CommandLogDir // the output of each operation's commands, nil if there is no log store.
 
 	// cancelNotify is a list of channels that are notified when a task should be cancelled.
 	cancelNotify []chan int64
 
+	// inFlight holds a copy of the operation of each running task by operation ID, operations still running when the
+	// shutdown grace period ends are recorded as interrupted.
+	inFlight            map[int64]*v1.Operation
+	shutdownGracePeriod time.Duration
+
+	// restarting holds the IDs of running backups stopped by RestartBackups, they are queued again once stopped.
+	restarting map[int64]bool
+
+	// decisions records why tasks were or weren't run, see GetSchedulerDecisions.
+	decisions decisionLog
+
 	// now for the purpose of testing; used by Run() to get the current time.
 	now func() time.Time
+
+	// runConditionsProbe reports the system state that plans' run conditions are checked against, replaced in tests.
+	runConditionsProbe runconditions.Probe
 }
 
 type stContainer struct {
 	tasks.ScheduledTask
-	configModno int32
-	callbacks   []func(error)
+	configModno  int32
+	priority     int
+	retryAttempt int // non-zero if this execution is a retry of a failed execution.
+	callbacks    []func(error)
 }
 
 func (st stContainer) Eq(other stContainer) bool {
@@ -59,6 +103,7 @@ func (st stContainer) Less(other stContainer) bool {
 
 func NewOrchestrator(resticBin string, cfg *v1.Config, oplog *oplog.OpLog, logStore *rotatinglog.RotatingLog) (*Orchestrator, error) {
 	cfg = proto.Clone(cfg).(*v1.Config)
+	redact.SetSecrets(redact.SecretsFromConfig(cfg))
 
 	// create the orchestrator.
 	var o *Orchestrator
@@ -66,48 +111,166 @@ func NewOrchestrator(resticBin string, cfg *v1.Config, oplog *oplog.OpLog, logSt
 		OpLog:  oplog,
 		config: cfg,
 		// repoPool created with a memory store to ensure the config is updated in an atomic operation with the repo pool's config value.
-		repoPool:  newResticRepoPool(resticBin, cfg),
-		taskQueue: queue.NewTimePriorityQueue[stContainer](),
+		repoPool:  newResticRepoPool(resticBin, config.ResolveRepoGroups(cfg)),
+		taskQueue: newTaskQueue(),
+		limiter:   newConcurrencyLimiter(maxParallelism(cfg)),
+		hookPool:  hook.NewWorkerPool(hookConcurrency(cfg)),
 		logStore:  logStore,
+
+		namespaceLimiters: make(map[string]*concurrencyLimiter),
+
+		inFlight:            make(map[int64]*v1.Operation),
+		shutdownGracePeriod: defaultShutdownGracePeriod,
+		restarting:          make(map[int64]bool),
+
+		runConditionsProbe: runconditions.SystemProbe,
 	}
+	if logStore != nil {
+		o.commandLogs = restic.NewCommandLogDir(path.Join(logStore.Dir(), "commands"), maxCommandLogs)
+		o.commandLogs.TailLimit = commandLogTailLimit
+		o.commandLogs.Redact = redact.Bytes
+	}
+	o.mounts = newMountManager(filepath.Join(config.DataDir(), "mounts"), o.runMount)
 
-	// verify the operation log and mark any incomplete operations as failed.
+	var resumePlans []string
+	var resumeUpgrades []*v1.Operation
 	if oplog != nil { // oplog may be nil for testing.
-		var incompleteOpRepos []string
-		if err := oplog.Scan(func(incomplete *v1.Operation) {
-			incomplete.Status = v1.OperationStatus_STATUS_ERROR
-			incomplete.DisplayMessage = "Failed, orchestrator killed while operation was in progress."
+		var err error
+		if resumePlans, resumeUpgrades, err = o.reconcileIncompleteOperations(); err != nil {
+			return nil, err
+		}
+		o.hookQueue = hook.NewDeliveryQueue(oplog)
+	}
+
+	// apply starting configuration which also queues initial tasks.
+	if err := o.ApplyConfig(cfg); err != nil {
+		return nil, fmt.Errorf("apply initial config: %w", err)
+	}
+	o.resumeInterruptedBackups(resumePlans)
+	o.resumeInterruptedUpgrades(resumeUpgrades)
+	o.exportQueueMetrics()
 
-			if incomplete.RepoId != "" && !slices.Contains(incompleteOpRepos, incomplete.RepoId) {
-				incompleteOpRepos = append(incompleteOpRepos, incomplete.RepoId)
+	zap.L().Info("orchestrator created")
+
+	return o, nil
+}
+
+// exportQueueMetrics computes the queue depth metrics from the orchestrator's task queue when metrics are collected.
+// Every configured repo is reported, including those with no tasks queued. The gauges are registered once per process,
+// an orchestrator created later replaces the sources of the previous one rather than registering its own.
+func (o *Orchestrator) exportQueueMetrics() {
+	collect := func(ready bool) func(observe func(v float64, labelValues ...string)) {
+		return func(observe func(v float64, labelValues ...string)) {
+			for _, repo := range o.Config().GetRepos() {
+				observe(0, repo.Id)
+			}
+			now := o.curTime()
+			for _, t := range o.taskQueue.GetAll() {
+				if !ready || !t.RunAt.After(now) {
+					observe(1, t.Task.RepoID())
+				}
 			}
-		}); err != nil {
-			return nil, fmt.Errorf("scan oplog: %w", err)
 		}
+	}
+	metrics.TaskQueueDepth.SetSource(collect(false))
+	metrics.TaskQueueReady.SetSource(collect(true))
+}
 
-		for _, repoId := range incompleteOpRepos {
-			repo, err := o.GetRepoOrchestrator(repoId)
-			if err != nil {
-				if errors.Is(err, ErrRepoNotFound) {
-					zap.L().Warn("repo not found for incomplete operation. Possibly just deleted.", zap.String("repo", repoId))
-				}
-				return nil, fmt.Errorf("get repo %q: %w", repoId, err)
+// reconcileIncompleteOperations scans the oplog for operations left unfinished by the last run and returns the IDs of
+// the plans whose backups were interrupted and the repo upgrades that were interrupted. Backups and upgrades that were
+// running when backrest was killed are marked as interrupted, other operations that were running are marked as failed
+// and their repos are unlocked.
+func (o *Orchestrator) reconcileIncompleteOperations() ([]string, []*v1.Operation, error) {
+	var resumePlans, incompleteOpRepos []string
+	var resumeUpgrades []*v1.Operation
+	if err := o.OpLog.Scan(func(incomplete *v1.Operation) {
+		if incomplete.ResumeOnStart {
+			// the backup or upgrade was interrupted by a graceful shutdown.
+			incomplete.ResumeOnStart = false
+			if tasks.IsUpgrade(incomplete) {
+				resumeUpgrades = append(resumeUpgrades, proto.Clone(incomplete).(*v1.Operation))
+			} else if !slices.Contains(resumePlans, incomplete.PlanId) {
+				resumePlans = append(resumePlans, incomplete.PlanId)
 			}
+			return
+		}
 
-			if err := repo.Unlock(context.Background()); err != nil {
-				zap.L().Error("failed to unlock repo", zap.String("repo", repoId), zap.Error(err))
+		if tasks.IsUpgrade(incomplete) {
+			incomplete.Status = v1.OperationStatus_STATUS_SYSTEM_CANCELLED
+			incomplete.DisplayMessage = "Interrupted, backrest was killed while the upgrade was in progress. The upgrade is resumed on startup."
+			resumeUpgrades = append(resumeUpgrades, proto.Clone(incomplete).(*v1.Operation))
+		} else if _, ok := incomplete.Op.(*v1.Operation_OperationBackup); ok && incomplete.PlanId != "" {
+			incomplete.Status = v1.OperationStatus_STATUS_SYSTEM_CANCELLED
+			incomplete.DisplayMessage = "Interrupted, backrest was killed while the backup was in progress. The backup is resumed on startup."
+			if !slices.Contains(resumePlans, incomplete.PlanId) {
+				resumePlans = append(resumePlans, incomplete.PlanId)
 			}
+		} else {
+			incomplete.Status = v1.OperationStatus_STATUS_ERROR
+			incomplete.DisplayMessage = "Failed, orchestrator killed while operation was in progress."
 		}
+		if incomplete.UnixTimeEndMs == 0 {
+			incomplete.UnixTimeEndMs = time.Now().UnixMilli()
+		}
+
+		if incomplete.RepoId != "" && !slices.Contains(incompleteOpRepos, incomplete.RepoId) {
+			incompleteOpRepos = append(incompleteOpRepos, incomplete.RepoId)
+		}
+	}); err != nil {
+		return nil, nil, fmt.Errorf("scan oplog: %w", err)
 	}
 
-	// apply starting configuration which also queues initial tasks.
-	if err := o.ApplyConfig(cfg); err != nil {
-		return nil, fmt.Errorf("apply initial config: %w", err)
+	for _, repoId := range incompleteOpRepos {
+		repo, err := o.GetRepoOrchestrator(repoId)
+		if err != nil {
+			if errors.Is(err, ErrRepoNotFound) {
+				zap.L().Warn("repo not found for incomplete operation. Possibly just deleted.", zap.String("repo", repoId))
+			}
+			return nil, nil, fmt.Errorf("get repo %q: %w", repoId, err)
+		}
+
+		if err := repo.Unlock(context.Background()); err != nil {
+			zap.L().Error("failed to unlock repo", zap.String("repo", repoId), zap.Error(err))
+		}
 	}
+	return resumePlans, resumeUpgrades, nil
+}
 
-	zap.L().Info("orchestrator created")
+// resumeInterruptedBackups queues a backup for each plan whose backup was interrupted when backrest last stopped,
+// restic reuses the data the interrupted backup already uploaded.
+func (o *Orchestrator) resumeInterruptedBackups(planIDs []string) {
+	for _, planID := range planIDs {
+		plan, err := o.GetPlan(planID)
+		if err != nil {
+			zap.L().Warn("plan of interrupted backup not found, not resuming", zap.String("plan", planID))
+			continue
+		}
+		repo, err := o.GetRepo(plan.Repo)
+		if err != nil || plan.Disabled || plan.Paused != nil || repo.Paused != nil {
+			zap.L().Info("plan of interrupted backup is not scheduled, not resuming", zap.String("plan", planID))
+			continue
+		}
+		zap.L().Info("resuming backup interrupted by shutdown", zap.String("plan", planID))
+		if err := o.ScheduleTask(tasks.NewOneoffBackupTask(plan, o.curTime()), tasks.TaskPriorityDefault); err != nil {
+			zap.L().Error("failed to schedule resumed backup", zap.String("plan", planID), zap.Error(err))
+		}
+	}
+}
 
-	return o, nil
+// resumeInterruptedUpgrades queues the repo upgrades that were interrupted when backrest last stopped, the migration is
+// skipped if it was applied and restic only repacks the data that is still uncompressed.
+func (o *Orchestrator) resumeInterruptedUpgrades(ops []*v1.Operation) {
+	for _, op := range ops {
+		repo, err := o.GetRepo(op.RepoId)
+		if err != nil || repo.Paused != nil {
+			zap.L().Info("repo of interrupted upgrade is not found or paused, not resuming", zap.String("repo", op.RepoId))
+			continue
+		}
+		zap.L().Info("resuming interrupted repo upgrade", zap.String("repo", op.RepoId))
+		if err := o.ScheduleTask(tasks.NewOneoffUpgradeTask(op.RepoId, op.GetOperationMigrate().GetRecompress(), o.curTime()), tasks.TaskPriorityDefault); err != nil {
+			zap.L().Error("failed to schedule resumed upgrade", zap.String("repo", op.RepoId), zap.Error(err))
+		}
+	}
 }
 
 func (o *Orchestrator) curTime() time.Time {
@@ -118,13 +281,72 @@ func (o *Orchestrator) curTime() time.Time {
 }
 
 func (o *Orchestrator) ApplyConfig(cfg *v1.Config) error {
+	redact.SetSecrets(redact.SecretsFromConfig(cfg))
+
 	o.mu.Lock()
-	o.config = proto.Clone(cfg).(*v1.Config)
+	o.config = config.ResolveRepoGroups(cfg)
 	o.repoPool = newResticRepoPool(o.repoPool.resticPath, o.config)
+	o.applyNamespaceLimits(cfg)
 	o.mu.Unlock()
+	o.limiter.setLimit(maxParallelism(cfg))
+	o.hookPool.SetLimit(hookConcurrency(cfg))
 	return o.ScheduleDefaultTasks(cfg)
 }
 
+// maxParallelism returns the number of tasks that may run concurrently given the config.
+func maxParallelism(cfg *v1.Config) int {
+	if cfg.MaxParallelism <= 0 {
+		return 1
+	}
+	return int(cfg.MaxParallelism)
+}
+
+// applyNamespaceLimits updates the per namespace limiters to match the config, o.mu must be held. Limiters are kept
+// across config changes so that running tasks continue to count against their namespace's limit.
+func (o *Orchestrator) applyNamespaceLimits(cfg *v1.Config) {
+	limits := make(map[string]int)
+	for _, ns := range cfg.Namespaces {
+		if ns.MaxConcurrentOperations > 0 {
+			limits[ns.Id] = int(ns.MaxConcurrentOperations)
+		}
+	}
+	for id := range o.namespaceLimiters {
+		if _, ok := limits[id]; !ok {
+			delete(o.namespaceLimiters, id)
+		}
+	}
+	for id, limit := range limits {
+		if l, ok := o.namespaceLimiters[id]; ok {
+			l.setLimit(limit)
+		} else {
+			o.namespaceLimiters[id] = newConcurrencyLimiter(limit)
+		}
+	}
+}
+
+// limiterForRepo returns the limiter that tasks for the repo must acquire a slot from before running.
+func (o *Orchestrator) limiterForRepo(repoID string) slotLimiter {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, r := range o.config.Repos {
+		if r.Id != repoID || r.Namespace == "" {
+			continue
+		}
+		if l, ok := o.namespaceLimiters[r.Namespace]; ok {
+			return limiterChain{l, o.limiter}
+		}
+	}
+	return o.limiter
+}
+
+// hookConcurrency returns the number of hooks that may run in the background concurrently given the config.
+func hookConcurrency(cfg *v1.Config) int {
+	if cfg.HookConcurrency <= 0 {
+		return 4
+	}
+	return int(cfg.HookConcurrency)
+}
+
 // rescheduleTasksIfNeeded checks if any tasks need to be rescheduled based on config changes.
 func (o *Orchestrator) ScheduleDefaultTasks(config *v1.Config) error {
 	zap.L().Info("scheduling default tasks, waiting for task queue reset.")
@@ -138,26 +360,123 @@ func (o *Orchestrator) ScheduleDefaultTasks(config *v1.Config) error {
 			zap.L().Error("failed to cancel queued task", zap.String("task", t.Task.Name()), zap.Error(err))
 		} else {
 			zap.L().Debug("queued task cancelled due to config change", zap.String("task", t.Task.Name()))
+			o.recordDecision(t.Task, v1.SchedulerDecision_KIND_CANCELLED, time.Time{}, "the config changed, the task is queued again if the new config schedules it")
 		}
 	}
 
 	zap.L().Info("reset task queue, scheduling new task set.")
 
 	// Requeue tasks that are affected by the config change.
-	if err := o.ScheduleTask(tasks.NewCollectGarbageTask(), tasks.TaskPriorityDefault); err != nil {
+	gt, err := tasks.NewCollectGarbageTask(config)
+	if err != nil {
+		return fmt.Errorf("schedule collect garbage task: %w", err)
+	}
+	if err := o.ScheduleTask(gt, tasks.TaskPriorityDefault); err != nil {
 		return fmt.Errorf("schedule collect garbage task: %w", err)
 	}
 
+	if config.ConfigReplication.GetDestination() != "" {
+		t, err := tasks.NewReplicateConfigTask(config)
+		if err != nil {
+			return fmt.Errorf("schedule replicate config task: %w", err)
+		}
+		if err := o.ScheduleTask(t, tasks.TaskPriorityDefault); err != nil {
+			return fmt.Errorf("schedule replicate config task: %w", err)
+		}
+	}
+
+	if e := config.OplogExport; e != nil {
+		t, err := tasks.NewExportOplogTask(config)
+		if err != nil {
+			return fmt.Errorf("schedule oplog export task: %w", err)
+		}
+		if err := o.ScheduleTask(t, tasks.TaskPriorityDefault); err != nil {
+			return fmt.Errorf("schedule oplog export task: %w", err)
+		}
+	}
+
+	pausedRepos := make(map[string]bool)
+	for _, repo := range config.Repos {
+		if repo.Paused != nil {
+			zap.L().Info("repo is paused, its plans will not be scheduled", zap.String("repo", repo.Id), zap.String("reason", repo.Paused.Reason))
+			o.recordNotScheduled("", repo.Id, pausedReason("repo", repo.Paused))
+			pausedRepos[repo.Id] = true
+			continue
+		}
+		st, err := tasks.NewScheduledStatsTask(repo)
+		if err != nil {
+			return fmt.Errorf("schedule stats task for repo %q: %w", repo.Id, err)
+		}
+		if err := o.ScheduleTask(st, tasks.TaskPriorityStats); err != nil {
+			return fmt.Errorf("schedule stats task for repo %q: %w", repo.Id, err)
+		}
+		if repo.IndexCron != "" {
+			it, err := tasks.NewScheduledIndexSnapshotsTask(repo)
+			if err != nil {
+				return fmt.Errorf("schedule index task for repo %q: %w", repo.Id, err)
+			}
+			if err := o.ScheduleTask(it, tasks.TaskPriorityIndexSnapshots); err != nil {
+				return fmt.Errorf("schedule index task for repo %q: %w", repo.Id, err)
+			}
+		}
+		rt, err := tasks.NewScheduledReconcileOplogTask(repo)
+		if err != nil {
+			return fmt.Errorf("schedule reconcile oplog task for repo %q: %w", repo.Id, err)
+		}
+		if err := o.ScheduleTask(rt, tasks.TaskPriorityStats); err != nil {
+			return fmt.Errorf("schedule reconcile oplog task for repo %q: %w", repo.Id, err)
+		}
+		vt, err := tasks.NewScheduledVerifyOplogTask(repo)
+		if err != nil {
+			return fmt.Errorf("schedule verify oplog task for repo %q: %w", repo.Id, err)
+		}
+		if err := o.ScheduleTask(vt, tasks.TaskPriorityStats); err != nil {
+			return fmt.Errorf("schedule verify oplog task for repo %q: %w", repo.Id, err)
+		}
+		if repo.CacheCleanupCron != "" || repo.MaxCacheSizeMb > 0 {
+			ct, err := tasks.NewScheduledCacheCleanupTask(repo)
+			if err != nil {
+				return fmt.Errorf("schedule cache cleanup task for repo %q: %w", repo.Id, err)
+			}
+			if err := o.ScheduleTask(ct, tasks.TaskPriorityDefault); err != nil {
+				return fmt.Errorf("schedule cache cleanup task for repo %q: %w", repo.Id, err)
+			}
+		}
+	}
+
 	for _, plan := range config.Plans {
 		if plan.Disabled {
+			o.recordNotScheduled(plan.Id, plan.Repo, "the plan is disabled")
 			continue
 		}
-		t, err := tasks.NewScheduledBackupTask(plan)
-		if err != nil {
-			return fmt.Errorf("schedule backup task for plan %q: %w", plan.Id, err)
+		if pausedRepos[plan.Repo] {
+			o.recordNotScheduled(plan.Id, plan.Repo, "the plan's repo is paused")
+			continue
 		}
-		if err := o.ScheduleTask(t, tasks.TaskPriorityDefault); err != nil {
-			return fmt.Errorf("schedule backup task for plan %q: %w", plan.Id, err)
+		if plan.Paused != nil {
+			zap.L().Info("plan is paused, it will not be scheduled", zap.String("plan", plan.Id), zap.String("reason", plan.Paused.Reason))
+			o.recordNotScheduled(plan.Id, plan.Repo, pausedReason("plan", plan.Paused))
+			continue
+		}
+		if plan.Cron != "" || plan.GetSchedule().GetInterval() != "" || len(plan.After) == 0 {
+			// plans without a schedule of their own only run after their upstream plans.
+			t, err := tasks.NewScheduledBackupTask(plan)
+			if err != nil {
+				return fmt.Errorf("schedule backup task for plan %q: %w", plan.Id, err)
+			}
+			if err := o.ScheduleTask(t, tasks.TaskPriorityDefault); err != nil {
+				return fmt.Errorf("schedule backup task for plan %q: %w", plan.Id, err)
+			}
+		}
+
+		if plan.CopyTo.GetRepo() != "" && plan.CopyTo.GetCron() != "" && !pausedRepos[plan.CopyTo.GetRepo()] {
+			ct, err := tasks.NewScheduledCopyTask(plan)
+			if err != nil {
+				return fmt.Errorf("schedule copy task for plan %q: %w", plan.Id, err)
+			}
+			if err := o.ScheduleTask(ct, tasks.TaskPriorityDefault); err != nil {
+				return fmt.Errorf("schedule copy task for plan %q: %w", plan.Id, err)
+			}
 		}
 	}
 
@@ -225,12 +544,45 @@ func (o *Orchestrator) CancelOperation(operationId int64, status v1.OperationSta
 	}
 	o.taskQueue.Remove(t)
 
+	if t.retryAttempt != 0 {
+		return nil // retries are not rescheduled, the task's regular schedule is still queued.
+	}
 	if err := o.scheduleTaskHelper(t.Task, tasks.TaskPriorityDefault, t.RunAt); err != nil {
 		return fmt.Errorf("reschedule cancelled task: %w", err)
 	}
 	return nil
 }
 
+// RestartBackups stops the running backups of the repo's plans and queues them to run again immediately, used to apply
+// repo settings restic only reads when a command starts. The restarted backups reuse the data already uploaded.
+func (o *Orchestrator) RestartBackups(repoID string) error {
+	o.mu.Lock()
+	var ids []int64
+	for id, op := range o.inFlight {
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); ok && op.RepoId == repoID {
+			o.restarting[id] = true
+			ids = append(ids, id)
+		}
+	}
+	o.mu.Unlock()
+
+	for _, id := range ids {
+		zap.L().Info("restarting backup", zap.String("repo", repoID), zap.Int64("operation", id))
+		if err := o.CancelOperation(id, v1.OperationStatus_STATUS_SYSTEM_CANCELLED); err != nil {
+			return fmt.Errorf("restart operation %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// RetryHookDelivery queues the dead lettered hook notification with the given ID to be sent again.
+func (o *Orchestrator) RetryHookDelivery(id int64) error {
+	if o.hookQueue == nil {
+		return hook.ErrDeliveryNotFound
+	}
+	return o.hookQueue.Retry(id)
+}
+
 func (o *Orchestrator) cancelHelper(op *v1.Operation, status v1.OperationStatus) error {
 	op.Status = status
 	op.UnixTimeEndMs = time.Now().UnixMilli()
@@ -244,6 +596,112 @@ func (o *Orchestrator) cancelHelper(op *v1.Operation, status v1.OperationStatus)
 func (o *Orchestrator) Run(ctx context.Context) {
 	zap.L().Info("starting orchestrator loop")
 
+	var wg sync.WaitGroup
+	if o.hookQueue != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.hookQueue.Run(ctx)
+		}()
+	}
+	started := make(map[*repoQueue]bool)
+	for {
+		// start a worker for each repo queue that doesn't have one yet.
+		for _, rq := range o.taskQueue.repoQueues() {
+			if started[rq] {
+				continue
+			}
+			started[rq] = true
+			wg.Add(1)
+			go func(rq *repoQueue) {
+				defer wg.Done()
+				o.runRepoQueue(ctx, rq)
+			}(rq)
+		}
+
+		select {
+		case <-ctx.Done():
+			zap.L().Info("shutting down orchestrator loop, context cancelled.")
+			o.mounts.unmountAll()
+			o.awaitShutdown(&wg)
+			return
+		case <-o.taskQueue.added:
+		}
+	}
+}
+
+// awaitShutdown waits up to the shutdown grace period for the running tasks to stop. The operations of tasks that are
+// still running afterwards are recorded as interrupted, Run's caller may close the oplog once it returns.
+func (o *Orchestrator) awaitShutdown(wg *sync.WaitGroup) {
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return
+	case <-time.After(o.shutdownGracePeriod):
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, op := range o.inFlight {
+		zap.L().Warn("task did not stop within the shutdown grace period", zap.Int64("operation", op.Id), zap.Duration("grace_period", o.shutdownGracePeriod))
+		markInterrupted(op)
+		op.UnixTimeEndMs = time.Now().UnixMilli()
+		if err := o.OpLog.Update(op); err != nil {
+			zap.L().Error("failed to record interrupted operation", zap.Int64("operation", op.Id), zap.Error(err))
+		}
+	}
+}
+
+// markInterrupted records that the operation was stopped by a shutdown, backups are resumed on the next start.
+func markInterrupted(op *v1.Operation) {
+	op.Status = v1.OperationStatus_STATUS_SYSTEM_CANCELLED
+	op.DisplayMessage = "interrupted by shutdown"
+	if _, ok := op.Op.(*v1.Operation_OperationBackup); ok {
+		op.ResumeOnStart = true
+		op.DisplayMessage = "interrupted by shutdown, the backup is resumed when backrest next starts"
+	} else if tasks.IsUpgrade(op) {
+		op.ResumeOnStart = true
+		op.DisplayMessage = "interrupted by shutdown, the upgrade is resumed when backrest next starts"
+	}
+}
+
+// runRepoQueue runs the tasks in a repo's queue one at a time until the context is cancelled.
+func (o *Orchestrator) runRepoQueue(ctx context.Context, rq *repoQueue) {
+	for {
+		limiter := o.limiterForRepo(rq.repoID)
+		t, ok := o.taskQueue.dequeue(ctx, rq, limiter)
+		if !ok {
+			return
+		}
+		o.runTask(ctx, t)
+		limiter.release()
+	}
+}
+
+func (o *Orchestrator) runTask(ctx context.Context, t stContainer) {
+	if o.deferForBackupWindow(t) {
+		return
+	}
+	if o.skipForRunConditions(t) {
+		return
+	}
+	if o.skipForOverlappingBackup(t) {
+		return
+	}
+
+	zap.L().Info("running task", zap.String("task", t.Task.Name()))
+	var reason string
+	if late := o.curTime().Sub(t.RunAt); late >= time.Minute {
+		reason = fmt.Sprintf("started %v late, waiting for the repo's other tasks or for a free slot", late.Round(time.Second))
+	}
+	o.recordDecision(t.Task, v1.SchedulerDecision_KIND_STARTED, time.Time{}, reason)
+	metrics.TaskWaitSeconds.WithLabelValues(t.Task.RepoID()).Observe(max(0, o.curTime().Sub(t.RunAt).Seconds()))
+
 	// subscribe to cancel notifications.
 	o.mu.Lock()
 	cancelNotifyChan := make(chan int64, 10) // buffered to queue up cancel notifications.
@@ -258,109 +716,374 @@ func (o *Orchestrator) Run(ctx context.Context) {
 		o.mu.Unlock()
 	}()
 
-	for {
-		if ctx.Err() != nil {
-			zap.L().Info("shutting down orchestrator loop, context cancelled.")
-			break
-		}
+	taskCtx, cancelTaskCtx := context.WithCancel(ctx)
+	defer cancelTaskCtx()
+
+	var windowClosed atomic.Bool
+	if windowEnd := o.stopAtWindowEnd(t); !windowEnd.IsZero() {
+		stopTimer := time.AfterFunc(windowEnd.Sub(o.curTime()), func() {
+			zap.L().Info("backup window closed, stopping task", zap.String("task", t.Task.Name()))
+			o.recordDecision(t.Task, v1.SchedulerDecision_KIND_STOPPED, time.Time{}, "the plan's backup window closed")
+			windowClosed.Store(true)
+			cancelTaskCtx()
+		})
+		defer stopTimer.Stop()
+	}
 
-		t := o.taskQueue.Dequeue(ctx)
-		if t.Task == nil {
-			continue
+	go func() {
+		for {
+			select {
+			case <-taskCtx.Done():
+				return
+			case opID := <-cancelNotifyChan:
+				if t.Op != nil && opID == t.Op.GetId() {
+					cancelTaskCtx()
+				}
+			}
 		}
+	}()
 
-		zap.L().Info("running task", zap.String("task", t.Task.Name()))
-
-		logs := bytes.NewBuffer(nil)
-		taskCtx, cancelTaskCtx := context.WithCancel(ctx)
-		taskCtx = logging.ContextWithWriter(taskCtx, &ioutil.SynchronizedWriter{W: logs})
+	start := time.Now()
+	runner := newTaskRunnerImpl(o, t.Task, t.Op)
 
-		go func() {
-			for {
-				select {
-				case <-taskCtx.Done():
-					return
-				case opID := <-cancelNotifyChan:
-					if t.Op != nil && opID == t.Op.GetId() {
-						cancelTaskCtx()
-					}
-				}
+	op := t.Op
+	var pendingOp *v1.Operation // copy of the operation before it runs, used to create retries.
+	if op != nil {
+		pendingOp = proto.Clone(op).(*v1.Operation)
+		op.UnixTimeStartMs = time.Now().UnixMilli()
+		if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_UNKNOWN {
+			op.Status = v1.OperationStatus_STATUS_INPROGRESS
+		}
+		if op.Id != 0 {
+			if err := o.OpLog.Update(op); err != nil {
+				zap.S().Errorf("failed to add operation to oplog: %w", err)
+			}
+		} else {
+			if err := o.OpLog.Add(op); err != nil {
+				zap.S().Errorf("failed to add operation to oplog: %w", err)
 			}
+		}
+
+		o.mu.Lock()
+		o.inFlight[op.Id] = proto.Clone(op).(*v1.Operation)
+		o.mu.Unlock()
+		defer func() {
+			o.mu.Lock()
+			delete(o.inFlight, op.Id)
+			o.mu.Unlock()
 		}()
+	}
+
+	// stream the task's logs to its operation's command log as they're written.
+	var logs *restic.CommandLog
+	if op != nil && o.commandLogs != nil {
+		var err error
+		if logs, err = o.commandLogs.Create(commandLogName(op)); err != nil {
+			zap.S().Errorf("failed to create command log for task %q: %v", t.Task.Name(), err)
+		}
+	}
+	if logs != nil {
+		taskCtx = logging.ContextWithWriter(taskCtx, logs)
+	} else {
+		taskCtx = logging.ContextWithWriter(taskCtx, io.Discard)
+	}
+
+	// record the CPU and memory used by the restic commands the task runs.
+	usage := &restic.Usage{}
+	taskCtx = restic.ContextWithUsage(taskCtx, usage)
 
-		start := time.Now()
-		runner := newTaskRunnerImpl(o, t.Task, t.Op)
+	err := t.Task.Run(taskCtx, t.ScheduledTask, runner)
+
+	var restarted bool
+	if op != nil {
+		o.mu.Lock()
+		restarted = o.restarting[op.Id] && taskCtx.Err() != nil && ctx.Err() == nil
+		delete(o.restarting, op.Id)
+		o.mu.Unlock()
+	}
 
-		op := t.Op
-		if op != nil {
-			op.UnixTimeStartMs = time.Now().UnixMilli()
-			if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_UNKNOWN {
-				op.Status = v1.OperationStatus_STATUS_INPROGRESS
+	if op != nil {
+		// reference the task's command log, its tail is kept with the operation.
+		if logs != nil {
+			if err := logs.Close(); err != nil {
+				zap.S().Errorf("failed to write command log for task %q: %v", t.Task.Name(), err)
 			}
-			if op.Id != 0 {
-				if err := o.OpLog.Update(op); err != nil {
-					zap.S().Errorf("failed to add operation to oplog: %w", err)
-				}
-			} else {
-				if err := o.OpLog.Add(op); err != nil {
-					zap.S().Errorf("failed to add operation to oplog: %w", err)
-				}
+			if tail := logs.Tail(); len(tail) > 0 {
+				op.Logref = CommandLogRefPrefix + commandLogName(op)
+				op.LogTail = string(tail)
 			}
 		}
 
-		err := t.Task.Run(taskCtx, t.ScheduledTask, runner)
-
-		if op != nil {
-			// write logs to log storage for this task.
-			if logs.Len() > 0 {
-				ref, err := o.logStore.Write(logs.Bytes())
-				if err != nil {
-					zap.S().Errorf("failed to write logs for task %q to log store: %v", t.Task.Name(), err)
-				} else {
-					op.Logref = ref
-				}
-			}
+		if summary := usage.Summary(); summary.Commands > 0 {
+			op.ResourceUsage = resourceUsageProto(summary)
+		}
 
-			if err != nil {
-				if taskCtx.Err() != nil {
-					// task was cancelled
-					op.Status = v1.OperationStatus_STATUS_USER_CANCELLED
-				} else {
-					op.Status = v1.OperationStatus_STATUS_ERROR
-				}
-				op.DisplayMessage = err.Error()
-			}
-			op.UnixTimeEndMs = time.Now().UnixMilli()
-			if op.Status == v1.OperationStatus_STATUS_INPROGRESS {
-				op.Status = v1.OperationStatus_STATUS_SUCCESS
+		if err != nil {
+			op.DisplayMessage = err.Error()
+			if windowClosed.Load() {
+				op.Status = v1.OperationStatus_STATUS_SYSTEM_CANCELLED
+				op.DisplayMessage = "interrupted when the backup window closed, the next backup reuses the data already uploaded"
+			} else if ctx.Err() != nil {
+				markInterrupted(op)
+			} else if restarted {
+				op.Status = v1.OperationStatus_STATUS_SYSTEM_CANCELLED
+				op.DisplayMessage = "restarted to apply the repo's new settings, the next backup reuses the data already uploaded"
+			} else if taskCtx.Err() != nil {
+				// task was cancelled
+				op.Status = v1.OperationStatus_STATUS_USER_CANCELLED
+			} else {
+				op.Status = v1.OperationStatus_STATUS_ERROR
 			}
-			if e := o.OpLog.Update(op); e != nil {
-				zap.S().Errorf("failed to update operation in oplog: %v", e)
+		}
+		op.UnixTimeEndMs = time.Now().UnixMilli()
+		if op.Status == v1.OperationStatus_STATUS_INPROGRESS {
+			op.Status = v1.OperationStatus_STATUS_SUCCESS
+		}
+		if e := o.OpLog.Update(op); e != nil {
+			zap.S().Errorf("failed to update operation in oplog: %v", e)
+		}
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); ok {
+			metrics.Backups.WithLabelValues(op.RepoId, op.PlanId, metrics.StatusLabel(op.Status)).Inc()
+			if op.Status == v1.OperationStatus_STATUS_SUCCESS || op.Status == v1.OperationStatus_STATUS_WARNING {
+				metrics.LastSuccessfulBackup.WithLabelValues(op.RepoId, op.PlanId).Set(float64(op.UnixTimeEndMs) / 1000)
 			}
 		}
+	}
 
-		if err != nil {
-			zap.L().Error("task failed", zap.String("task", t.Task.Name()), zap.Error(err), zap.Duration("duration", time.Since(start)))
-		} else {
-			zap.L().Info("task finished", zap.String("task", t.Task.Name()), zap.Duration("duration", time.Since(start)))
+	if err != nil {
+		zap.L().Error("task failed", zap.String("task", t.Task.Name()), zap.Error(err), zap.Duration("duration", time.Since(start)))
+		if taskCtx.Err() == nil {
+			o.scheduleRetry(t, pendingOp, err)
 		}
+	} else {
+		zap.L().Info("task finished", zap.String("task", t.Task.Name()), zap.Duration("duration", time.Since(start)))
+	}
 
-		o.mu.Lock()
-		if t.configModno == o.config.Modno {
-			// Only reschedule tasks if the config hasn't changed since the task was scheduled.
-			if err := o.ScheduleTask(t.Task, tasks.TaskPriorityDefault); err != nil {
-				zap.L().Error("reschedule task", zap.String("task", t.Task.Name()), zap.Error(err))
-			}
+	if restarted {
+		if plan, err := o.GetPlan(t.Task.PlanID()); err != nil {
+			zap.L().Warn("plan of restarted backup not found, not restarting", zap.String("plan", t.Task.PlanID()))
+		} else if err := o.ScheduleTask(tasks.NewOneoffBackupTask(plan, o.curTime()), tasks.TaskPriorityDefault); err != nil {
+			zap.L().Error("failed to schedule restarted backup", zap.String("plan", plan.Id), zap.Error(err))
 		}
-		o.mu.Unlock()
-		cancelTaskCtx()
+	}
 
-		go func() {
-			for _, cb := range t.callbacks {
-				cb(err)
+	o.mu.Lock()
+	if t.configModno == o.config.Modno && t.retryAttempt == 0 {
+		// Only reschedule tasks if the config hasn't changed since the task was scheduled.
+		// Retries are not rescheduled, the task's regular schedule was queued after the first attempt.
+		if err := o.ScheduleTask(t.Task, tasks.TaskPriorityDefault); err != nil {
+			zap.L().Error("reschedule task", zap.String("task", t.Task.Name()), zap.Error(err))
+		}
+	}
+	o.mu.Unlock()
+
+	go func() {
+		for _, cb := range t.callbacks {
+			cb(err)
+		}
+	}()
+}
+
+// scheduleRetry queues another attempt of a failed task if the retry policy of the task's plan allows it.
+func (o *Orchestrator) scheduleRetry(t stContainer, pendingOp *v1.Operation, err error) {
+	if pendingOp == nil || t.Task.PlanID() == "" {
+		return
+	}
+	plan, e := o.GetPlan(t.Task.PlanID())
+	if e != nil {
+		return
+	}
+
+	attempts := max(t.retryAttempt, 1)
+	delay, ok := retryDelay(plan.RetryPolicy, attempts, err)
+	if !ok {
+		return
+	}
+	runAt := o.curTime().Add(delay)
+
+	retryOp := pendingOp
+	retryOp.Id = 0
+	retryOp.FlowId = 0
+	retryOp.Status = v1.OperationStatus_STATUS_PENDING
+	retryOp.UnixTimeStartMs = runAt.UnixMilli()
+	retryOp.UnixTimeEndMs = 0
+	retryOp.DisplayMessage = ""
+	retryOp.Logref = ""
+	retryOp.LogTail = ""
+	retryOp.ResourceUsage = nil
+	retryOp.RetryAttempt = int32(attempts + 1)
+	if err := o.OpLog.Add(retryOp); err != nil {
+		zap.L().Error("failed to add retry operation to oplog", zap.String("task", t.Task.Name()), zap.Error(err))
+		return
+	}
+
+	zap.L().Info("scheduling retry of failed task", zap.String("task", t.Task.Name()), zap.Int("attempt", attempts+1), zap.String("runAt", runAt.Format(time.RFC3339)))
+	o.recordDecision(t.Task, v1.SchedulerDecision_KIND_SCHEDULED, runAt, fmt.Sprintf("attempt %d, retrying after the task failed: %v", attempts+1, err))
+	o.taskQueue.Enqueue(runAt, t.priority, stContainer{
+		ScheduledTask: tasks.ScheduledTask{
+			Task:  t.Task,
+			RunAt: runAt,
+			Op:    retryOp,
+		},
+		configModno:  t.configModno,
+		priority:     t.priority,
+		retryAttempt: attempts + 1,
+	})
+}
+
+// stopAtWindowEnd returns the time at which a backup task must be stopped because its plan's backup window closes, or
+// the zero time if the task may run to completion.
+func (o *Orchestrator) stopAtWindowEnd(t stContainer) time.Time {
+	if _, ok := t.Task.(*tasks.BackupTask); !ok {
+		return time.Time{}
+	}
+	plan, err := o.GetPlan(t.Task.PlanID())
+	if err != nil || plan.BackupWindow.GetWindowEndAction() != v1.BackupWindow_WINDOW_END_ACTION_STOP {
+		return time.Time{}
+	}
+	return backupwindow.NextEnd(plan.BackupWindow, o.curTime())
+}
+
+// deferForBackupWindow re-queues the task at the start of its plan's next backup window if the window does not allow
+// the task to run now. Returns true if the task was deferred.
+func (o *Orchestrator) deferForBackupWindow(t stContainer) bool {
+	if t.Task.PlanID() == "" {
+		return false
+	}
+	plan, err := o.GetPlan(t.Task.PlanID())
+	if err != nil {
+		return false
+	}
+
+	now := o.curTime()
+	if backupwindow.Allows(plan.BackupWindow, now) {
+		return false
+	}
+	next := backupwindow.NextStart(plan.BackupWindow, now)
+	if next.IsZero() {
+		zap.L().Warn("backup window never allows task to run, running it anyway", zap.String("task", t.Task.Name()))
+		return false
+	}
+
+	zap.L().Info("task is outside of its plan's backup window, deferring", zap.String("task", t.Task.Name()), zap.String("runAt", next.Format(time.RFC3339)))
+	o.recordDecision(t.Task, v1.SchedulerDecision_KIND_DEFERRED, next, "the plan's backup window is closed, deferred to its next start")
+	t.RunAt = next
+	if t.Op != nil {
+		t.Op.UnixTimeStartMs = next.UnixMilli()
+		if err := o.OpLog.Update(t.Op); err != nil {
+			zap.S().Errorf("failed to update deferred operation in oplog: %v", err)
+		}
+	}
+	o.taskQueue.Enqueue(next, t.priority, t)
+	return true
+}
+
+// skipForRunConditions marks a scheduled backup as skipped and queues the plan's next backup if the plan's run conditions
+// are not met. Backups requested interactively are never skipped. Returns true if the task was skipped.
+func (o *Orchestrator) skipForRunConditions(t stContainer) bool {
+	if _, ok := t.Task.(*tasks.BackupTask); !ok || t.priority&tasks.TaskPriorityInteractive != 0 {
+		return false
+	}
+	plan, err := o.GetPlan(t.Task.PlanID())
+	if err != nil || plan.RunConditions == nil {
+		return false
+	}
+
+	reason, ok := runconditions.Check(context.Background(), o.runConditionsProbe, plan.RunConditions)
+	if ok {
+		return false
+	}
+
+	zap.L().Info("run conditions not met, skipping task", zap.String("task", t.Task.Name()), zap.String("reason", reason))
+	o.recordDecision(t.Task, v1.SchedulerDecision_KIND_SKIPPED, time.Time{}, "run conditions not met: "+reason)
+	if t.Op != nil {
+		t.Op.Status = v1.OperationStatus_STATUS_SKIPPED
+		t.Op.DisplayMessage = reason
+		t.Op.UnixTimeStartMs = o.curTime().UnixMilli()
+		t.Op.UnixTimeEndMs = t.Op.UnixTimeStartMs
+		if err := o.OpLog.Update(t.Op); err != nil {
+			zap.S().Errorf("failed to update skipped operation in oplog: %v", err)
+		}
+	}
+
+	o.mu.Lock()
+	if t.configModno == o.config.Modno && t.retryAttempt == 0 {
+		if err := o.ScheduleTask(t.Task, tasks.TaskPriorityDefault); err != nil {
+			zap.L().Error("reschedule task", zap.String("task", t.Task.Name()), zap.Error(err))
+		}
+	}
+	o.mu.Unlock()
+
+	go func() {
+		for _, cb := range t.callbacks {
+			cb(nil)
+		}
+	}()
+	return true
+}
+
+// skipForOverlappingBackup marks a scheduled backup as skipped and queues the plan's next backup if another backup of the
+// plan is running or finished after the skipped backup was due, the runs are coalesced into one rather than backing up
+// the same files twice in a row. Backups requested interactively are never skipped. Returns true if the task was skipped.
+func (o *Orchestrator) skipForOverlappingBackup(t stContainer) bool {
+	if _, ok := t.Task.(*tasks.BackupTask); !ok || t.priority&tasks.TaskPriorityInteractive != 0 || t.retryAttempt != 0 {
+		return false
+	}
+	if t.Task.PlanID() == "" || o.OpLog == nil {
+		return false
+	}
+
+	var overlapping *v1.Operation
+	if err := o.OpLog.ForEachByPlan(t.Task.PlanID(), indexutil.Reversed(indexutil.CollectAll()), func(op *v1.Operation) error {
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); !ok || (t.Op != nil && op.Id == t.Op.Id) {
+			return nil
+		}
+		switch op.Status {
+		case v1.OperationStatus_STATUS_PENDING, v1.OperationStatus_STATUS_SKIPPED, v1.OperationStatus_STATUS_SYSTEM_CANCELLED:
+			return nil
+		case v1.OperationStatus_STATUS_INPROGRESS:
+			overlapping = op
+		case v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_WARNING:
+			if op.UnixTimeEndMs >= t.RunAt.UnixMilli() {
+				overlapping = op
 			}
-		}()
+		}
+		return oplog.ErrStopIteration
+	}); err != nil {
+		zap.S().Errorf("failed to check for overlapping backups: %v", err)
+		return false
+	}
+	if overlapping == nil {
+		return false
+	}
+
+	reason := fmt.Sprintf("coalesced with the backup started at %v", time.UnixMilli(overlapping.UnixTimeStartMs).Format(time.RFC3339))
+	zap.L().Info("backup overlaps another backup of the plan, skipping task", zap.String("task", t.Task.Name()), zap.Int64("overlapping_op", overlapping.Id))
+	o.recordDecision(t.Task, v1.SchedulerDecision_KIND_SKIPPED, time.Time{}, reason)
+	if t.Op != nil {
+		t.Op.Status = v1.OperationStatus_STATUS_SKIPPED
+		t.Op.DisplayMessage = reason
+		t.Op.UnixTimeStartMs = o.curTime().UnixMilli()
+		t.Op.UnixTimeEndMs = t.Op.UnixTimeStartMs
+		if err := o.OpLog.Update(t.Op); err != nil {
+			zap.S().Errorf("failed to update skipped operation in oplog: %v", err)
+		}
+	}
+
+	o.mu.Lock()
+	if t.configModno == o.config.Modno {
+		if err := o.ScheduleTask(t.Task, tasks.TaskPriorityDefault); err != nil {
+			zap.L().Error("reschedule task", zap.String("task", t.Task.Name()), zap.Error(err))
+		}
 	}
+	o.mu.Unlock()
+
+	go func() {
+		for _, cb := range t.callbacks {
+			cb(nil)
+		}
+	}()
+	return true
 }
 
 // ScheduleTask schedules a task to run at the next available time.
@@ -369,15 +1092,29 @@ func (o *Orchestrator) ScheduleTask(t tasks.Task, priority int, callbacks ...fun
 	return o.scheduleTaskHelper(t, priority, o.curTime(), callbacks...)
 }
 
+// ScheduleOneoffTask schedules a task like ScheduleTask and returns the ID of the operation created for it.
+// The ID is 0 if the task is not scheduled or does not create an operation.
+func (o *Orchestrator) ScheduleOneoffTask(t tasks.Task, priority int, callbacks ...func(error)) (int64, error) {
+	op, err := o.scheduleTaskOp(t, priority, o.curTime(), callbacks...)
+	return op.GetId(), err
+}
+
 func (o *Orchestrator) scheduleTaskHelper(t tasks.Task, priority int, curTime time.Time, callbacks ...func(error)) error {
+	_, err := o.scheduleTaskOp(t, priority, curTime, callbacks...)
+	return err
+}
+
+// scheduleTaskOp schedules the next run of the task and returns the operation added to the oplog for it, if any.
+func (o *Orchestrator) scheduleTaskOp(t tasks.Task, priority int, curTime time.Time, callbacks ...func(error)) (*v1.Operation, error) {
 	nextRun := t.Next(curTime, newTaskRunnerImpl(o, t, nil))
 	if nextRun.Eq(tasks.NeverScheduledTask) {
-		return nil
+		return nil, nil
 	}
 	nextRun.Task = t
 	stc := stContainer{
 		ScheduledTask: nextRun,
 		configModno:   o.config.Modno,
+		priority:      priority,
 		callbacks:     callbacks,
 	}
 
@@ -389,13 +1126,41 @@ func (o *Orchestrator) scheduleTaskHelper(t tasks.Task, priority int, curTime ti
 		stc.Op.UnixTimeStartMs = nextRun.RunAt.UnixMilli()
 
 		if err := o.OpLog.Add(nextRun.Op); err != nil {
-			return fmt.Errorf("add operation to oplog: %w", err)
+			return nil, fmt.Errorf("add operation to oplog: %w", err)
 		}
 	}
 
 	zap.L().Info("scheduling task", zap.String("task", t.Name()), zap.String("runAt", nextRun.RunAt.Format(time.RFC3339)))
+	o.recordDecision(t, v1.SchedulerDecision_KIND_SCHEDULED, nextRun.RunAt, nextRun.Reason)
 	o.taskQueue.Enqueue(nextRun.RunAt, priority, stc)
-	return nil
+	return stc.Op, nil
+}
+
+// OpenCommandLog opens the command log of an operation for reading, ref is the operation's logref and must have the
+// CommandLogRefPrefix.
+func (o *Orchestrator) OpenCommandLog(ref string) (io.ReadCloser, error) {
+	name, ok := strings.CutPrefix(ref, CommandLogRefPrefix)
+	if !ok || o.commandLogs == nil {
+		return nil, fmt.Errorf("%q is not a command log", ref)
+	}
+	return o.commandLogs.Open(name)
+}
+
+// commandLogName returns the name of the command log an operation's output is written to.
+func commandLogName(op *v1.Operation) string {
+	return fmt.Sprintf("op-%d.log", op.Id)
+}
+
+func resourceUsageProto(s restic.UsageSummary) *v1.ResourceUsage {
+	return &v1.ResourceUsage{
+		Commands:     int32(s.Commands),
+		RunTimeMs:    s.RunTime.Milliseconds(),
+		CpuTimeMs:    s.CPUTime.Milliseconds(),
+		AvgCpuCores:  s.AvgCPUCores,
+		PeakCpuCores: s.PeakCPUCores,
+		AvgRssBytes:  int64(s.AvgRSSBytes),
+		PeakRssBytes: int64(s.PeakRSSBytes),
+	}
 }
 
 func (o *Orchestrator) Config() *v1.Config {
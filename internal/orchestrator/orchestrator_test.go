@@ -2,12 +2,25 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"github.com/garethgeorge/backrest/internal/rotatinglog"
+	"github.com/garethgeorge/backrest/internal/runconditions"
+	"github.com/garethgeorge/backrest/test/helpers"
 )
 
 type testTask struct {
@@ -209,3 +222,1006 @@ func TestSchedulerWait(t *testing.T) {
 	case <-ran:
 	}
 }
+
+func TestTasksForDifferentReposRunConcurrently(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cfg := config.NewDefaultConfig()
+	cfg.MaxParallelism = 2
+	orch, err := NewOrchestrator("", cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	orch.taskQueue.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// each task blocks until both have started, this only completes if they run concurrently.
+	var started sync.WaitGroup
+	started.Add(2)
+	var done sync.WaitGroup
+	done.Add(2)
+	for _, repoID := range []string{"repo1", "repo2"} {
+		task := newTestTask(
+			func() error {
+				started.Done()
+				started.Wait()
+				done.Done()
+				return nil
+			},
+			oneoffAt(time.Now()),
+		).(*testTask)
+		task.TaskRepoID = repoID
+		orch.ScheduleTask(task, tasks.TaskPriorityDefault)
+	}
+
+	// Act
+	go orch.Run(ctx)
+
+	// Assert
+	waitCh := make(chan struct{})
+	go func() {
+		done.Wait()
+		close(waitCh)
+	}()
+	select {
+	case <-waitCh:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected tasks for different repos to run concurrently")
+	}
+}
+
+func TestTasksForSameRepoRunSerially(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cfg := config.NewDefaultConfig()
+	cfg.MaxParallelism = 4
+	orch, err := NewOrchestrator("", cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	orch.taskQueue.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	running := 0
+	maxRunning := 0
+	var done sync.WaitGroup
+	done.Add(4)
+	for i := 0; i < 4; i++ {
+		task := newTestTask(
+			func() error {
+				mu.Lock()
+				running++
+				maxRunning = max(maxRunning, running)
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				done.Done()
+				return nil
+			},
+			oneoffAt(time.Now()),
+		).(*testTask)
+		task.TaskName = fmt.Sprintf("test task %d", i)
+		orch.ScheduleTask(task, tasks.TaskPriorityDefault)
+	}
+
+	// Act
+	go orch.Run(ctx)
+	done.Wait()
+
+	// Assert
+	if maxRunning != 1 {
+		t.Errorf("expected tasks for the same repo to run serially, got %d running at once", maxRunning)
+	}
+}
+
+func TestNamespaceLimitsConcurrentTasks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cfg := config.NewDefaultConfig()
+	cfg.MaxParallelism = 4
+	cfg.Namespaces = []*v1.Namespace{{Id: "family", MaxConcurrentOperations: 1}}
+	cfg.Repos = []*v1.Repo{
+		{Id: "repo1", Namespace: "family"},
+		{Id: "repo2", Namespace: "family"},
+		{Id: "repo3", Namespace: "family"},
+	}
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+	orch, err := NewOrchestrator("", cfg, log, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	orch.taskQueue.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	running := 0
+	maxRunning := 0
+	var done sync.WaitGroup
+	done.Add(len(cfg.Repos))
+	for _, repo := range cfg.Repos {
+		task := newTestTask(
+			func() error {
+				mu.Lock()
+				running++
+				maxRunning = max(maxRunning, running)
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				done.Done()
+				return nil
+			},
+			oneoffAt(time.Now()),
+		).(*testTask)
+		task.TaskRepoID = repo.Id
+		orch.ScheduleTask(task, tasks.TaskPriorityDefault)
+	}
+
+	// Act
+	go orch.Run(ctx)
+	done.Wait()
+
+	// Assert
+	if maxRunning != 1 {
+		t.Errorf("expected the namespace's limit of 1 concurrent task, got %d running at once", maxRunning)
+	}
+}
+
+func TestInteractiveTasksRunBeforeScheduledTasks(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name            string
+		interactiveRepo string
+		maintenanceRepo string
+		waiters         int // number of repo queues waiting on the limiter before the blocking task completes.
+	}{
+		{name: "same repo", interactiveRepo: "repo2", maintenanceRepo: "repo2", waiters: 1},
+		{name: "different repos", interactiveRepo: "repo2", maintenanceRepo: "repo3", waiters: 2},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+
+			// Arrange
+			cfg := config.NewDefaultConfig()
+			cfg.MaxParallelism = 1
+			orch, err := NewOrchestrator("", cfg, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to create orchestrator: %v", err)
+			}
+			orch.taskQueue.Reset()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// a task for another repo holds the only slot until released.
+			started := make(chan struct{})
+			release := make(chan struct{})
+			blocker := newTestTask(func() error {
+				close(started)
+				<-release
+				return nil
+			}, oneoffAt(time.Now())).(*testTask)
+			blocker.TaskRepoID = "repo1"
+			orch.ScheduleTask(blocker, tasks.TaskPriorityDefault)
+			go orch.Run(ctx)
+			<-started
+
+			var mu sync.Mutex
+			var order []string
+			var done sync.WaitGroup
+			done.Add(2)
+			newRecordingTask := func(name, repoID string) tasks.Task {
+				task := newTestTask(func() error {
+					mu.Lock()
+					order = append(order, name)
+					mu.Unlock()
+					done.Done()
+					return nil
+				}, oneoffAt(time.Now())).(*testTask)
+				task.TaskName = name
+				task.TaskRepoID = repoID
+				return task
+			}
+
+			// Act
+			orch.ScheduleTask(newRecordingTask("prune", tc.maintenanceRepo), tasks.TaskPriorityPrune)
+			waitForLimiterWaiters(t, orch.limiter, 1)
+			orch.ScheduleTask(newRecordingTask("restore", tc.interactiveRepo), tasks.TaskPriorityInteractive)
+			waitForLimiterWaiters(t, orch.limiter, tc.waiters)
+			close(release)
+			done.Wait()
+
+			// Assert
+			if len(order) != 2 || order[0] != "restore" {
+				t.Errorf("expected interactive task to run first, got order %v", order)
+			}
+		})
+	}
+}
+
+func waitForLimiterWaiters(t *testing.T, l *concurrencyLimiter, count int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		l.mu.Lock()
+		waiting := 0
+		for _, n := range l.waiting {
+			waiting += n
+		}
+		l.mu.Unlock()
+		if waiting >= count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d tasks to wait on the concurrency limiter", count)
+}
+
+// oneoffAt returns a scheduling function that schedules a task exactly once at the given time.
+func oneoffAt(at time.Time) func(curTime time.Time) *time.Time {
+	didRun := false
+	return func(curTime time.Time) *time.Time {
+		if didRun {
+			return nil
+		}
+		didRun = true
+		return &at
+	}
+}
+
+type fakeRunConditionsProbe struct {
+	power runconditions.PowerSource
+}
+
+func (p fakeRunConditionsProbe) PowerSource(ctx context.Context) runconditions.PowerSource {
+	return p.power
+}
+
+func (p fakeRunConditionsProbe) NetworkCost(ctx context.Context) runconditions.NetworkCost {
+	return runconditions.NetworkCostUnknown
+}
+
+func TestBackupSkippedWhenRunConditionsNotMet(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	plan := &v1.Plan{
+		Id:            "plan",
+		Repo:          "repo",
+		Paths:         []string{t.TempDir()},
+		Cron:          "0 0 1 1 *",
+		RunConditions: &v1.RunConditions{SkipOnBattery: true},
+	}
+	cfg.Plans = []*v1.Plan{plan}
+
+	orch, err := NewOrchestrator("", cfg, log, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	orch.runConditionsProbe = fakeRunConditionsProbe{power: runconditions.PowerSourceBattery}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	opID, err := orch.ScheduleOneoffTask(tasks.NewOneoffBackupTask(plan, time.Now()), tasks.TaskPriorityDefault, func(err error) {
+		done <- err
+	})
+	if err != nil {
+		t.Fatalf("failed to schedule backup: %v", err)
+	}
+
+	// Act
+	go orch.Run(ctx)
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected skipped backup to complete without error, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected backup to be skipped")
+	}
+
+	op, err := log.Get(opID)
+	if err != nil {
+		t.Fatalf("failed to get operation: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_SKIPPED {
+		t.Errorf("expected operation status SKIPPED, got %v", op.Status)
+	}
+}
+
+func TestOverlappingBackupIsCoalesced(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	plan := &v1.Plan{
+		Id:    "plan",
+		Repo:  "repo",
+		Paths: []string{t.TempDir()},
+		Cron:  "0 0 1 1 *",
+	}
+	cfg.Plans = []*v1.Plan{plan}
+
+	// a backup of the plan finished after the scheduled backup was due.
+	now := time.Now()
+	if err := log.Add(&v1.Operation{
+		RepoId:          "repo",
+		PlanId:          "plan",
+		UnixTimeStartMs: now.Add(-time.Hour).UnixMilli(),
+		UnixTimeEndMs:   now.UnixMilli(),
+		Status:          v1.OperationStatus_STATUS_SUCCESS,
+		Op:              &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}},
+	}); err != nil {
+		t.Fatalf("failed to add operation: %v", err)
+	}
+
+	orch, err := NewOrchestrator("", cfg, log, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	opID, err := orch.ScheduleOneoffTask(tasks.NewOneoffBackupTask(plan, now.Add(-time.Minute)), tasks.TaskPriorityDefault, func(err error) {
+		done <- err
+	})
+	if err != nil {
+		t.Fatalf("failed to schedule backup: %v", err)
+	}
+
+	// Act
+	go orch.Run(ctx)
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected coalesced backup to complete without error, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected backup to be coalesced")
+	}
+
+	op, err := log.Get(opID)
+	if err != nil {
+		t.Fatalf("failed to get operation: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_SKIPPED || !strings.HasPrefix(op.DisplayMessage, "coalesced") {
+		t.Errorf("expected operation to be skipped as coalesced, got status %v message %q", op.Status, op.DisplayMessage)
+	}
+}
+
+func TestPausedPlansAreNotScheduled(t *testing.T) {
+	t.Parallel()
+
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Repos = []*v1.Repo{
+		{Id: "repo", Uri: t.TempDir(), Password: "test"},
+		{Id: "paused-repo", Uri: t.TempDir(), Password: "test", Paused: &v1.PauseState{Reason: "maintenance"}},
+	}
+	cfg.Plans = []*v1.Plan{
+		{Id: "active", Repo: "repo", Paths: []string{t.TempDir()}, Cron: "0 0 1 1 *"},
+		{Id: "paused-plan", Repo: "repo", Paths: []string{t.TempDir()}, Cron: "0 0 1 1 *", Paused: &v1.PauseState{Reason: "travelling"}},
+		{Id: "plan-in-paused-repo", Repo: "paused-repo", Paths: []string{t.TempDir()}, Cron: "0 0 1 1 *"},
+	}
+
+	orch, err := NewOrchestrator("", cfg, log, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	scheduled := make(map[string]bool)
+	for _, st := range orch.taskQueue.GetAll() {
+		if _, ok := st.Task.(*tasks.BackupTask); ok {
+			scheduled[st.Task.PlanID()] = true
+		}
+	}
+
+	if !scheduled["active"] {
+		t.Errorf("expected backup for plan %q to be scheduled", "active")
+	}
+	for _, planID := range []string{"paused-plan", "plan-in-paused-repo"} {
+		if scheduled[planID] {
+			t.Errorf("expected backup for paused plan %q not to be scheduled", planID)
+		}
+	}
+}
+
+func TestSchedulerDecisions(t *testing.T) {
+	t.Parallel()
+
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	plan := &v1.Plan{
+		Id:            "plan",
+		Repo:          "repo",
+		Paths:         []string{t.TempDir()},
+		Cron:          "0 0 1 1 *",
+		RunConditions: &v1.RunConditions{SkipOnBattery: true},
+	}
+	cfg.Plans = []*v1.Plan{
+		plan,
+		{Id: "paused-plan", Repo: "repo", Paths: []string{t.TempDir()}, Cron: "0 0 1 1 *", Paused: &v1.PauseState{Reason: "travelling"}},
+	}
+
+	orch, err := NewOrchestrator("", cfg, log, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	orch.runConditionsProbe = fakeRunConditionsProbe{power: runconditions.PowerSourceBattery}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	if _, err := orch.ScheduleOneoffTask(tasks.NewOneoffBackupTask(plan, time.Now()), tasks.TaskPriorityDefault, func(err error) {
+		done <- err
+	}); err != nil {
+		t.Fatalf("failed to schedule backup: %v", err)
+	}
+	go orch.Run(ctx)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected backup to be skipped")
+	}
+
+	kinds := make(map[string][]v1.SchedulerDecision_Kind)
+	var reasons []string
+	for _, d := range orch.SchedulerDecisions() {
+		kinds[d.PlanId] = append(kinds[d.PlanId], d.Kind)
+		reasons = append(reasons, d.Reason)
+	}
+	if got := kinds["paused-plan"]; len(got) != 1 || got[0] != v1.SchedulerDecision_KIND_NOT_SCHEDULED {
+		t.Errorf("expected the paused plan not to be scheduled, got decisions %v", got)
+	}
+	// newest first: the oneoff backup is skipped after the plan's scheduled backup and the oneoff backup are queued.
+	want := []v1.SchedulerDecision_Kind{v1.SchedulerDecision_KIND_SKIPPED, v1.SchedulerDecision_KIND_SCHEDULED, v1.SchedulerDecision_KIND_SCHEDULED}
+	if got := kinds["plan"]; !slices.Equal(got, want) {
+		t.Errorf("expected decisions %v for the plan, got %v", want, got)
+	}
+	if !slices.ContainsFunc(reasons, func(r string) bool { return strings.Contains(r, "travelling") }) {
+		t.Errorf("expected the pause reason to be recorded, got reasons %q", reasons)
+	}
+}
+
+func TestDecisionLogIsBounded(t *testing.T) {
+	t.Parallel()
+
+	var l decisionLog
+	for i := 0; i < maxSchedulerDecisions+10; i++ {
+		l.add(&v1.SchedulerDecision{UnixTimeMs: int64(i)})
+	}
+	got := l.list()
+	if len(got) != maxSchedulerDecisions {
+		t.Fatalf("expected %d decisions, got %d", maxSchedulerDecisions, len(got))
+	}
+	if got[0].UnixTimeMs != maxSchedulerDecisions+9 || got[len(got)-1].UnixTimeMs != 10 {
+		t.Errorf("expected the newest decisions first and the oldest dropped, got %d to %d", got[0].UnixTimeMs, got[len(got)-1].UnixTimeMs)
+	}
+}
+
+func TestBackupStoppedAtWindowEnd(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Instance = "test"
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	plan := &v1.Plan{
+		Id:    "plan",
+		Repo:  "repo",
+		Paths: []string{t.TempDir()},
+		Cron:  "0 0 1 1 *",
+		BackupWindow: &v1.BackupWindow{
+			Allowed:         []*v1.BackupWindow_TimeRange{{Start: "22:00", End: "06:00"}},
+			WindowEndAction: v1.BackupWindow_WINDOW_END_ACTION_STOP,
+		},
+		PreBackup: &v1.BackupCommand{Command: "sleep 10"}, // stands in for a long running backup.
+	}
+	cfg.Plans = []*v1.Plan{plan}
+
+	orch, err := NewOrchestrator("", cfg, log, rotatinglog.NewRotatingLog(t.TempDir()+"/log", 10))
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	// the window closes in 200ms.
+	windowEnd := time.Date(2024, 1, 2, 6, 0, 0, 0, time.Local)
+	orch.now = func() time.Time { return windowEnd.Add(-200 * time.Millisecond) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	opID, err := orch.ScheduleOneoffTask(tasks.NewOneoffBackupTask(plan, windowEnd.Add(-time.Second)), tasks.TaskPriorityDefault, func(err error) {
+		done <- err
+	})
+	if err != nil {
+		t.Fatalf("failed to schedule backup: %v", err)
+	}
+
+	// Act
+	go orch.Run(ctx)
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected backup to be stopped when the window closed")
+	}
+
+	op, err := log.Get(opID)
+	if err != nil {
+		t.Fatalf("failed to get operation: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_SYSTEM_CANCELLED {
+		t.Errorf("expected operation status SYSTEM_CANCELLED, got %v", op.Status)
+	}
+	if !strings.Contains(op.DisplayMessage, "backup window closed") {
+		t.Errorf("expected display message to mention the backup window, got %q", op.DisplayMessage)
+	}
+}
+
+func TestBackupInterruptedByShutdownIsResumed(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Instance = "test"
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	plan := &v1.Plan{
+		Id:        "plan",
+		Repo:      "repo",
+		Paths:     []string{t.TempDir()},
+		Cron:      "0 0 1 1 *",
+		PreBackup: &v1.BackupCommand{Command: "sleep 10"}, // stands in for a long running backup.
+	}
+	cfg.Plans = []*v1.Plan{plan}
+
+	orch, err := NewOrchestrator("", cfg, log, rotatinglog.NewRotatingLog(t.TempDir()+"/log", 10))
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	opID, err := orch.ScheduleOneoffTask(tasks.NewOneoffBackupTask(plan, time.Now()), tasks.TaskPriorityDefault)
+	if err != nil {
+		t.Fatalf("failed to schedule backup: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		orch.Run(ctx)
+		close(stopped)
+	}()
+	for {
+		op, err := log.Get(opID)
+		if err != nil {
+			t.Fatalf("failed to get operation: %v", err)
+		}
+		if op.Status == v1.OperationStatus_STATUS_INPROGRESS {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Act
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected orchestrator to stop after the context was cancelled")
+	}
+
+	// Assert
+	op, err := log.Get(opID)
+	if err != nil {
+		t.Fatalf("failed to get operation: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_SYSTEM_CANCELLED || !op.ResumeOnStart {
+		t.Fatalf("expected operation to be SYSTEM_CANCELLED and marked for resume, got %v", op)
+	}
+
+	if _, err := NewOrchestrator("", cfg, log, nil); err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	var pending []*v1.Operation
+	if err := log.ForEachByPlan("plan", indexutil.CollectAll(), func(op *v1.Operation) error {
+		// the plan's regular cron backup is also pending, it's scheduled for next year.
+		if op.Status == v1.OperationStatus_STATUS_PENDING && op.GetOperationBackup() != nil && op.UnixTimeStartMs <= time.Now().UnixMilli() {
+			pending = append(pending, op)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to list operations: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Errorf("expected 1 pending backup resuming the interrupted one, got %d", len(pending))
+	}
+	if op, _ := log.Get(opID); op.GetResumeOnStart() {
+		t.Errorf("expected resume mark to be cleared once the backup is resumed")
+	}
+}
+
+func TestBackupKilledWhileInProgressIsResumed(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Instance = "test"
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	cfg.Plans = []*v1.Plan{{Id: "plan", Repo: "repo", Paths: []string{t.TempDir()}, Cron: "0 0 1 1 *"}}
+
+	killed := &v1.Operation{
+		RepoId:          "repo",
+		PlanId:          "plan",
+		Status:          v1.OperationStatus_STATUS_INPROGRESS,
+		UnixTimeStartMs: time.Now().Add(-time.Minute).UnixMilli(),
+		Op:              &v1.Operation_OperationBackup{},
+	}
+	if err := log.Add(killed); err != nil {
+		t.Fatalf("failed to add operation: %v", err)
+	}
+
+	// Act
+	if _, err := NewOrchestrator("", cfg, log, nil); err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	// Assert
+	op, err := log.Get(killed.Id)
+	if err != nil {
+		t.Fatalf("failed to get operation: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_SYSTEM_CANCELLED || op.UnixTimeEndMs == 0 {
+		t.Errorf("expected the killed backup to be marked as interrupted, got %v", op)
+	}
+
+	var resumed int
+	if err := log.ForEachByPlan("plan", indexutil.CollectAll(), func(op *v1.Operation) error {
+		if op.Status == v1.OperationStatus_STATUS_PENDING && op.GetOperationBackup() != nil && op.UnixTimeStartMs <= time.Now().UnixMilli() {
+			resumed++
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to list operations: %v", err)
+	}
+	if resumed != 1 {
+		t.Errorf("expected 1 backup queued to resume the killed one, got %d", resumed)
+	}
+}
+
+func TestUpgradeKilledWhileInProgressIsResumed(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Instance = "test"
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+
+	killed := &v1.Operation{
+		RepoId:          "repo",
+		PlanId:          tasks.PlanForUnassociatedOperations,
+		Status:          v1.OperationStatus_STATUS_INPROGRESS,
+		UnixTimeStartMs: time.Now().Add(-time.Minute).UnixMilli(),
+		Op: &v1.Operation_OperationMigrate{OperationMigrate: &v1.OperationMigrate{
+			Migration:  tasks.DefaultMigration,
+			Recompress: true,
+			Phase:      v1.OperationMigrate_PHASE_RECOMPRESS,
+		}},
+	}
+	migrate := &v1.Operation{
+		RepoId:          "repo",
+		PlanId:          tasks.PlanForUnassociatedOperations,
+		Status:          v1.OperationStatus_STATUS_INPROGRESS,
+		UnixTimeStartMs: time.Now().Add(-time.Minute).UnixMilli(),
+		Op:              &v1.Operation_OperationMigrate{OperationMigrate: &v1.OperationMigrate{Migration: "refresh_index"}},
+	}
+	for _, op := range []*v1.Operation{killed, migrate} {
+		if err := log.Add(op); err != nil {
+			t.Fatalf("failed to add operation: %v", err)
+		}
+	}
+
+	// Act
+	if _, err := NewOrchestrator("", cfg, log, nil); err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	// Assert
+	if op, err := log.Get(killed.Id); err != nil || op.Status != v1.OperationStatus_STATUS_SYSTEM_CANCELLED {
+		t.Errorf("expected the killed upgrade to be marked as interrupted, got %v %v", op, err)
+	}
+	if op, err := log.Get(migrate.Id); err != nil || op.Status != v1.OperationStatus_STATUS_ERROR {
+		t.Errorf("expected the killed migration to be marked as failed, got %v %v", op, err)
+	}
+
+	var resumed []*v1.Operation
+	if err := log.ForEachByRepo("repo", indexutil.CollectAll(), func(op *v1.Operation) error {
+		if op.Status == v1.OperationStatus_STATUS_PENDING && op.GetOperationMigrate() != nil {
+			resumed = append(resumed, op)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to list operations: %v", err)
+	}
+	if len(resumed) != 1 || !resumed[0].GetOperationMigrate().Recompress {
+		t.Errorf("expected 1 upgrade queued to resume the killed one, got %v", resumed)
+	}
+}
+
+func TestBackupTimeout(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Instance = "test"
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	plan := &v1.Plan{
+		Id:        "plan",
+		Repo:      "repo",
+		Paths:     []string{t.TempDir()},
+		Cron:      "0 0 1 1 *",
+		Timeout:   "200ms",
+		PreBackup: &v1.BackupCommand{Command: "sleep 10"}, // stands in for a wedged backup.
+	}
+	cfg.Plans = []*v1.Plan{plan}
+
+	orch, err := NewOrchestrator("", cfg, log, rotatinglog.NewRotatingLog(t.TempDir()+"/log", 10))
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	opID, err := orch.ScheduleOneoffTask(tasks.NewOneoffBackupTask(plan, time.Now()), tasks.TaskPriorityDefault, func(err error) {
+		done <- err
+	})
+	if err != nil {
+		t.Fatalf("failed to schedule backup: %v", err)
+	}
+
+	// Act
+	go orch.Run(ctx)
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected backup to be stopped when it timed out")
+	}
+
+	op, err := log.Get(opID)
+	if err != nil {
+		t.Fatalf("failed to get operation: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_ERROR {
+		t.Errorf("expected operation status ERROR, got %v", op.Status)
+	}
+	if !strings.Contains(op.DisplayMessage, "timed out after 200ms") {
+		t.Errorf("expected display message to mention the timeout, got %q", op.DisplayMessage)
+	}
+}
+
+func TestFailedBackupCapturesDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	repoDir := t.TempDir()
+	cfg := config.NewDefaultConfig()
+	cfg.Instance = "test"
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: repoDir, Password: "test"}}
+	plan := &v1.Plan{
+		Id:    "plan",
+		Repo:  "repo",
+		Paths: []string{t.TempDir() + "/missing"}, // fails the backup after the repo is initialized.
+		Cron:  "0 0 1 1 *",
+	}
+	cfg.Plans = []*v1.Plan{plan}
+
+	orch, err := NewOrchestrator(helpers.ResticBinary(t), cfg, log, rotatinglog.NewRotatingLog(t.TempDir()+"/log", 10))
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	opID, err := orch.ScheduleOneoffTask(tasks.NewOneoffBackupTask(plan, time.Now()), tasks.TaskPriorityDefault, func(err error) {
+		done <- err
+	})
+	if err != nil {
+		t.Fatalf("failed to schedule backup: %v", err)
+	}
+
+	// Act
+	go orch.Run(ctx)
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatalf("expected backup to fail")
+	}
+
+	// Assert
+	op, err := log.Get(opID)
+	if err != nil {
+		t.Fatalf("failed to get operation: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_ERROR {
+		t.Fatalf("expected operation status ERROR, got %v", op.Status)
+	}
+	d := op.GetFailureDiagnostics()
+	if d == nil {
+		t.Fatalf("expected diagnostics on the failed operation")
+	}
+	if len(d.Disks) != 3 || d.Disks[0].Path != plan.Paths[0] || d.Disks[1].Path != repoDir {
+		t.Fatalf("expected disk usage of the plan's path, the repo and the data dir, got %v", d.Disks)
+	}
+	if d.Disks[0].Error == "" {
+		t.Errorf("expected an error reading the disk usage of the missing path")
+	}
+	if d.Disks[1].Error != "" || d.Disks[1].TotalBytes == 0 {
+		t.Errorf("expected the disk usage of the repo, got %v", d.Disks[1])
+	}
+	if d.DaemonMemoryBytes == 0 {
+		t.Errorf("expected the daemon's memory usage")
+	}
+	if d.BackendError != "" {
+		t.Errorf("expected the backend probe to succeed, got %v", d.BackendError)
+	}
+}
+
+func TestMountedRepoIsUnmountedWhenIdle(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("failed to create oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.NewDefaultConfig()
+	cfg.Repos = []*v1.Repo{{Id: "repo", Uri: t.TempDir(), Password: "test"}}
+	orch, err := NewOrchestrator("", cfg, log, nil)
+	if err != nil {
+		t.Fatalf("failed to create orchestrator: %v", err)
+	}
+	var mountCount atomic.Int32
+	orch.mounts = newMountManager(t.TempDir(), func(ctx context.Context, repoID string, mountpoint string) error {
+		mountCount.Add(1)
+		// like restic, list the snapshots in the mountpoint until unmounted.
+		if err := os.Mkdir(filepath.Join(mountpoint, "snapshots"), 0700); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		return os.Remove(filepath.Join(mountpoint, "snapshots"))
+	})
+
+	// Act
+	mount, err := orch.MountRepo("repo", 500*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to mount repo: %v", err)
+	}
+	if _, err := orch.MountRepo("repo", 500*time.Millisecond); err != nil {
+		t.Fatalf("failed to mount mounted repo: %v", err)
+	}
+
+	// Assert
+	if mount.State != v1.RepoMount_STATE_MOUNTED {
+		t.Errorf("expected mount state MOUNTED, got %v", mount.State)
+	}
+	if _, err := os.Stat(filepath.Join(mount.Mountpoint, "snapshots")); err != nil {
+		t.Errorf("expected mountpoint to be populated: %v", err)
+	}
+	if mounts := orch.Mounts(); len(mounts) != 1 || mounts[0].RepoId != "repo" {
+		t.Errorf("expected the repo to be listed as mounted, got %v", mounts)
+	}
+	if n := mountCount.Load(); n != 1 {
+		t.Errorf("expected the mounted repo to be mounted once, got %d mounts", n)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(orch.Mounts()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected idle repo to be unmounted")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if _, err := os.Stat(mount.Mountpoint); !os.IsNotExist(err) {
+		t.Errorf("expected mountpoint to be removed, got %v", err)
+	}
+	if err := orch.UnmountRepo("repo"); !errors.Is(err, ErrNotMounted) {
+		t.Errorf("expected ErrNotMounted unmounting an unmounted repo, got %v", err)
+	}
+}
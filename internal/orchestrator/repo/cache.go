@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"go.uber.org/zap"
+)
+
+// CacheDir returns the directory restic caches the repo's metadata in: the repo's cache dir if set, otherwise restic's
+// default of $RESTIC_CACHE_DIR or the user's cache directory.
+func (r *RepoOrchestrator) CacheDir() (string, error) {
+	if d := r.repoConfig.GetCacheDir(); d != "" {
+		return d, nil
+	}
+	for _, e := range r.repoConfig.GetBackendOptions().GetEnv() {
+		if e.Name == "RESTIC_CACHE_DIR" && e.Value != "" {
+			return ExpandEnv(e.Value), nil
+		}
+	}
+	for _, e := range r.repoConfig.GetEnv() {
+		if v, ok := strings.CutPrefix(ExpandEnv(e), "RESTIC_CACHE_DIR="); ok && v != "" {
+			return v, nil
+		}
+	}
+	if d := os.Getenv("RESTIC_CACHE_DIR"); d != "" {
+		return d, nil
+	}
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("find cache dir: %w", err)
+	}
+	return filepath.Join(d, "restic"), nil
+}
+
+// CacheUsage returns the size of the repo's cache, restic keeps it in a directory of the cache dir named after the
+// repo's ID.
+func (r *RepoOrchestrator) CacheUsage(ctx context.Context) (*v1.RepoCacheUsage, error) {
+	cacheDir, dir, err := r.repoCacheDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.cacheUsage(cacheDir, dir)
+}
+
+func (r *RepoOrchestrator) cacheUsage(cacheDir, dir string) (*v1.RepoCacheUsage, error) {
+	size, err := dirSize(dir)
+	if err != nil {
+		return nil, fmt.Errorf("get size of cache dir %q: %w", dir, err)
+	}
+	return &v1.RepoCacheUsage{
+		RepoId:       r.repoConfig.Id,
+		CacheDir:     cacheDir,
+		SizeBytes:    size,
+		MaxSizeBytes: int64(r.repoConfig.GetMaxCacheSizeMb()) << 20,
+	}, nil
+}
+
+// CleanupCache removes the caches of repos unused for 30 days from the cache dir with restic cache --cleanup, then
+// clears the repo's own cache if it's larger than its max cache size. restic rebuilds a cleared cache as it's needed.
+func (r *RepoOrchestrator) CleanupCache(ctx context.Context, output io.Writer) (*v1.RepoCacheUsage, error) {
+	// exclusive so that no restic command is reading the cache while it's cleared.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.l.Debug("cleanup cache")
+	if err := r.repo.CacheCleanup(ctx, output); err != nil {
+		return nil, fmt.Errorf("cleanup cache of repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	cacheDir, dir, err := r.repoCacheDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := r.cacheUsage(cacheDir, dir)
+	if err != nil {
+		return nil, err
+	}
+	if usage.MaxSizeBytes == 0 || usage.SizeBytes <= usage.MaxSizeBytes {
+		return usage, nil
+	}
+
+	r.l.Info("clearing cache larger than the max cache size", zap.String("dir", dir), zap.Int64("size_bytes", usage.SizeBytes), zap.Int64("max_size_bytes", usage.MaxSizeBytes))
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("clear cache dir %q: %w", dir, err)
+	}
+	usage.SizeBytes = 0
+	return usage, nil
+}
+
+// repoCacheDir returns the cache dir and the directory the repo's cache is kept in within it.
+func (r *RepoOrchestrator) repoCacheDir(ctx context.Context) (cacheDir string, repoDir string, err error) {
+	cacheDir, err = r.CacheDir()
+	if err != nil {
+		return "", "", err
+	}
+	id, err := r.repo.ID(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("get ID of repo %v: %w", r.repoConfig.Id, err)
+	}
+	return cacheDir, filepath.Join(cacheDir, id), nil
+}
+
+// dirSize returns the total size of the files in dir, 0 if it doesn't exist.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // the dir, or a file restic removed while walking, doesn't exist.
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
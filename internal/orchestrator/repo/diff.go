@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"go.uber.org/zap"
+)
+
+// maxDiffSizeDirs caps the directories listed to look up the sizes of a diff's files, each is an argument of a single
+// restic ls call per snapshot.
+const maxDiffSizeDirs = 256
+
+// Diff lists the paths that changed from baseSnapshotID to snapshotID along with the sizes of changed files in each.
+func (r *RepoOrchestrator) Diff(ctx context.Context, baseSnapshotID, snapshotID string) (*v1.SnapshotDiff, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.l.Debug("diff snapshots", zap.String("base", baseSnapshotID), zap.String("snapshot", snapshotID))
+	result, err := r.repo.Diff(ctx, baseSnapshotID, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("diff snapshots %v and %v: %w", baseSnapshotID, snapshotID, err)
+	}
+
+	diff := &v1.SnapshotDiff{
+		BaseSnapshotId: baseSnapshotID,
+		SnapshotId:     snapshotID,
+		FilesAdded:     int64(result.Added.Files),
+		FilesRemoved:   int64(result.Removed.Files),
+		FilesChanged:   int64(result.ChangedFiles),
+		BytesAdded:     result.Added.Bytes,
+		BytesRemoved:   result.Removed.Bytes,
+	}
+
+	// restic diff doesn't report sizes, they are read from listings of the directories holding the changed files.
+	var newDirs, oldDirs []string
+	seenNew, seenOld := make(map[string]bool), make(map[string]bool)
+	for _, c := range result.Changes {
+		change := diffChange(c.Modifier)
+		diff.Entries = append(diff.Entries, &v1.DiffEntry{
+			Path:   c.Path,
+			Change: change,
+		})
+		if strings.HasSuffix(c.Path, "/") {
+			continue
+		}
+		dir := path.Dir(c.Path)
+		if change != v1.DiffEntry_CHANGE_REMOVED && !seenNew[dir] {
+			seenNew[dir] = true
+			newDirs = append(newDirs, dir)
+		}
+		if change != v1.DiffEntry_CHANGE_ADDED && !seenOld[dir] {
+			seenOld[dir] = true
+			oldDirs = append(oldDirs, dir)
+		}
+	}
+	if len(newDirs) > maxDiffSizeDirs || len(oldDirs) > maxDiffSizeDirs {
+		diff.SizesOmitted = true
+		return diff, nil
+	}
+
+	newSizes, err := r.fileSizes(ctx, snapshotID, newDirs)
+	if err != nil {
+		return nil, err
+	}
+	oldSizes, err := r.fileSizes(ctx, baseSnapshotID, oldDirs)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range diff.Entries {
+		e.Size = newSizes[e.Path]
+		e.PreviousSize = oldSizes[e.Path]
+	}
+	return diff, nil
+}
+
+// fileSizes returns the sizes of the files in the snapshot's directories by path.
+func (r *RepoOrchestrator) fileSizes(ctx context.Context, snapshotID string, dirs []string) (map[string]int64, error) {
+	sizes := make(map[string]int64)
+	if len(dirs) == 0 {
+		return sizes, nil
+	}
+	_, entries, err := r.repo.ListDirectories(ctx, snapshotID, dirs)
+	if err != nil {
+		return nil, fmt.Errorf("list files of snapshot %v: %w", snapshotID, err)
+	}
+	for _, e := range entries {
+		if e.Type != "dir" {
+			sizes[e.Path] = e.Size
+		}
+	}
+	return sizes, nil
+}
+
+// diffChange maps restic diff's modifiers to changes, see restic.DiffChange.
+func diffChange(modifier string) v1.DiffEntry_Change {
+	switch modifier {
+	case "+":
+		return v1.DiffEntry_CHANGE_ADDED
+	case "-":
+		return v1.DiffEntry_CHANGE_REMOVED
+	case "M":
+		return v1.DiffEntry_CHANGE_MODIFIED
+	case "T":
+		return v1.DiffEntry_CHANGE_TYPE_CHANGED
+	case "U":
+		return v1.DiffEntry_CHANGE_METADATA_CHANGED
+	default:
+		return v1.DiffEntry_CHANGE_UNKNOWN
+	}
+}
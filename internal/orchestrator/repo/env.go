@@ -3,6 +3,8 @@ package repo
 import (
 	"os"
 	"regexp"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 )
 
 var (
@@ -16,3 +18,12 @@ func ExpandEnv(s string) string {
 		return e
 	})
 }
+
+// PlanEnv returns the plan's env vars as NAME=VALUE pairs with their values expanded.
+func PlanEnv(plan *v1.Plan) []string {
+	env := make([]string, 0, len(plan.GetEnv()))
+	for _, e := range plan.GetEnv() {
+		env = append(env, e.Name+"="+ExpandEnv(e.Value))
+	}
+	return env
+}
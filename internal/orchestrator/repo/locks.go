@@ -0,0 +1,26 @@
+package repo
+
+import "sync"
+
+// repoLocks holds a lock for each repository shared by every RepoOrchestrator for the repository. RepoOrchestrators
+// are recreated when the config changes, an operation started by a previous instance must still exclude operations
+// started by the new one. Repos are identified by URI so that repo configs pointing at the same repository share a lock.
+var repoLocks = &lockManager{locks: make(map[string]*sync.RWMutex)}
+
+// lockManager hands out a lock per key. Operations that take a shared restic lock on the repo hold the read lock,
+// operations that take an exclusive restic lock e.g. prune hold the write lock.
+type lockManager struct {
+	mu    sync.Mutex
+	locks map[string]*sync.RWMutex
+}
+
+func (m *lockManager) get(key string) *sync.RWMutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.locks[key]
+	if !ok {
+		l = &sync.RWMutex{}
+		m.locks[key] = l
+	}
+	return l
+}
@@ -20,13 +20,18 @@ import (
 
 // RepoOrchestrator is responsible for managing a single repo.
 type RepoOrchestrator struct {
-	mu sync.Mutex
+	mu     *sync.RWMutex // shared with other orchestrators for the same repository, see repoLocks.
+	initMu sync.Mutex
 
 	l           *zap.Logger
 	config      *v1.Config
 	repoConfig  *v1.Repo
 	repo        *restic.Repo
 	initialized bool
+
+	resticPath        string
+	versionOnce       sync.Once
+	skipIfUnchangedOK bool // the restic binary supports --skip-if-unchanged.
 }
 
 // NewRepoOrchestrator accepts a config and a repo that is configured with the properties of that config object.
@@ -35,6 +40,10 @@ func NewRepoOrchestrator(config *v1.Config, repoConfig *v1.Repo, resticPath stri
 		return nil, errors.New("instance is a required field in the backrest config")
 	}
 
+	if p := repoConfig.GetResticBinaryPath(); p != "" {
+		resticPath = p
+	}
+
 	var opts []restic.GenericOption
 	if p := repoConfig.GetPassword(); p != "" {
 		opts = append(opts, restic.WithEnv("RESTIC_PASSWORD="+p))
@@ -57,9 +66,29 @@ func NewRepoOrchestrator(config *v1.Config, repoConfig *v1.Repo, resticPath stri
 		opts = append(opts, restic.WithFlags(args...))
 	}
 
+	for _, e := range repoConfig.GetBackendOptions().GetEnv() {
+		opts = append(opts, restic.WithEnv(e.Name+"="+ExpandEnv(e.Value)))
+	}
+	for _, o := range repoConfig.GetBackendOptions().GetOptions() {
+		opts = append(opts, restic.WithFlags("-o", o.Name+"="+o.Value))
+	}
+
+	if cacheDir := repoConfig.GetCacheDir(); cacheDir != "" {
+		opts = append(opts, restic.WithFlags("--cache-dir", cacheDir))
+	}
+
+	if retryLock := repoConfig.GetRetryLock(); retryLock != "" {
+		opts = append(opts, restic.WithFlags("--retry-lock", retryLock))
+	}
+
+	// applied after the flags so that the repo's limits take precedence over limits set by flags or inherited from its group.
+	opts = append(opts, restic.WithBandwidthLimits(int(repoConfig.GetLimitUploadKbps()), int(repoConfig.GetLimitDownloadKbps())))
+
 	// Add BatchMode=yes to sftp.args if it's not already set.
 	if slices.IndexFunc(repoConfig.GetFlags(), func(a string) bool {
 		return strings.Contains(a, "sftp.args")
+	}) == -1 && slices.IndexFunc(repoConfig.GetBackendOptions().GetOptions(), func(o *v1.BackendOptions_Option) bool {
+		return o.Name == "sftp.args"
 	}) == -1 {
 		opts = append(opts, restic.WithFlags("-o", "sftp.args=-oBatchMode=yes"))
 	}
@@ -67,21 +96,36 @@ func NewRepoOrchestrator(config *v1.Config, repoConfig *v1.Repo, resticPath stri
 	repo := restic.NewRepo(resticPath, repoConfig.GetUri(), opts...)
 
 	return &RepoOrchestrator{
+		mu:         repoLocks.get(repoConfig.GetUri()),
 		config:     config,
 		repoConfig: repoConfig,
 		repo:       repo,
+		resticPath: resticPath,
 		l:          zap.L().With(zap.String("repo", repoConfig.Id)),
 	}, nil
 }
 
-func (r *RepoOrchestrator) Init(ctx context.Context) error {
+// Init initializes the repo if it doesn't exist yet, restic's output is also written to output if it is not nil.
+func (r *RepoOrchestrator) Init(ctx context.Context, output io.Writer) error {
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
+	if output != nil {
+		w := output
+		if l := restic.LoggerFromContext(ctx); l != nil {
+			w = io.MultiWriter(l, output)
+		}
+		ctx = restic.ContextWithLogger(ctx, w)
+	}
 
-	return r.repo.Init(ctx)
+	if err := r.repo.Init(ctx); err != nil {
+		return fmt.Errorf("init repo %v: %w", r.repoConfig.Id, err)
+	}
+	return nil
 }
 
 func (r *RepoOrchestrator) Snapshots(ctx context.Context) ([]*restic.Snapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
@@ -94,10 +138,16 @@ func (r *RepoOrchestrator) Snapshots(ctx context.Context) ([]*restic.Snapshot, e
 }
 
 func (r *RepoOrchestrator) SnapshotsForPlan(ctx context.Context, plan *v1.Plan) ([]*restic.Snapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.snapshotsForPlan(ctx, plan)
+}
+
+func (r *RepoOrchestrator) snapshotsForPlan(ctx context.Context, plan *v1.Plan) ([]*restic.Snapshot, error) {
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
-	snapshots, err := r.repo.Snapshots(ctx, restic.WithFlags("--tag", TagForPlan(plan.Id)+","+TagForInstance(r.config.Instance)))
+	snapshots, err := r.repo.Snapshots(ctx, restic.WithEnv(PlanEnv(plan)...), restic.WithFlags(planSnapshotFlags(plan, r.config.Instance)...))
 	if err != nil {
 		return nil, fmt.Errorf("get snapshots for plan %q: %w", plan.Id, err)
 	}
@@ -105,20 +155,22 @@ func (r *RepoOrchestrator) SnapshotsForPlan(ctx context.Context, plan *v1.Plan)
 	return snapshots, nil
 }
 
-func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCallback func(event *restic.BackupProgressEntry)) (*restic.BackupProgressEntry, error) {
+// Backup backs up the plan's paths, extraOpts are applied to the backup command after the plan's own flags.
+func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCallback func(event *restic.BackupProgressEntry), extraOpts ...restic.GenericOption) (*restic.BackupProgressEntry, error) {
 	zap.L().Debug("repo orchestrator starting backup", zap.String("repo", r.repoConfig.Id))
 
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	if err := r.removeStaleLocks(ctx); err != nil {
+		return nil, err
+	}
 
-	if !r.initialized {
-		if err := r.repo.Init(ctx, restic.WithEnviron()); err != nil {
-			return nil, fmt.Errorf("failed to initialize repo: %w", err)
-		}
-		r.initialized = true
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.ensureInitialized(ctx); err != nil {
+		return nil, err
 	}
 
-	snapshots, err := r.SnapshotsForPlan(ctx, plan)
+	snapshots, err := r.snapshotsForPlan(ctx, plan)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get snapshots for plan: %w", err)
 	}
@@ -128,11 +180,18 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCa
 	startTime := time.Now()
 
 	var opts []restic.GenericOption
+	opts = append(opts, restic.WithEnv(PlanEnv(plan)...))
 	opts = append(opts, restic.WithFlags(
-		"--exclude-caches",
 		"--tag", TagForPlan(plan.Id),
 		"--tag", TagForInstance(r.config.Instance)),
 	)
+	opts = append(opts, restic.WithBackupTunables(backupTunables(plan.GetBackupTunables())))
+	for _, tag := range plan.Tags {
+		opts = append(opts, restic.WithFlags("--tag", tag))
+	}
+	if hostname := plan.GetHistory().GetHostname(); hostname != "" {
+		opts = append(opts, restic.WithFlags("--host", hostname))
+	}
 
 	for _, exclude := range plan.Excludes {
 		opts = append(opts, restic.WithFlags("--exclude", exclude))
@@ -140,9 +199,21 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCa
 	for _, iexclude := range plan.Iexcludes {
 		opts = append(opts, restic.WithFlags("--iexclude", iexclude))
 	}
+	for _, excludeFile := range plan.ExcludeFiles {
+		opts = append(opts, restic.WithFlags("--exclude-file", excludeFile))
+	}
+	for _, excludeIfPresent := range plan.ExcludeIfPresent {
+		opts = append(opts, restic.WithFlags("--exclude-if-present", excludeIfPresent))
+	}
+	if plan.ExcludeLargerThan != "" {
+		opts = append(opts, restic.WithFlags("--exclude-larger-than", plan.ExcludeLargerThan))
+	}
 	if len(snapshots) > 0 {
 		opts = append(opts, restic.WithFlags("--parent", snapshots[len(snapshots)-1].Id))
 	}
+	if plan.SkipIfUnchanged && r.supportsSkipIfUnchanged(ctx) {
+		opts = append(opts, restic.WithFlags("--skip-if-unchanged"))
+	}
 
 	for _, f := range plan.GetBackupFlags() {
 		args, err := shlex.Split(f)
@@ -151,6 +222,7 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCa
 		}
 		opts = append(opts, restic.WithFlags(args...))
 	}
+	opts = append(opts, extraOpts...)
 
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
@@ -164,7 +236,126 @@ func (r *RepoOrchestrator) Backup(ctx context.Context, plan *v1.Plan, progressCa
 	return summary, nil
 }
 
+// BackupFiles backs up files that do not belong to any plan e.g. backrest's own exports, the snapshot is tagged with the
+// given tags and the instance's tag.
+func (r *RepoOrchestrator) BackupFiles(ctx context.Context, paths []string, tags ...string) (*restic.BackupProgressEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.ensureInitialized(ctx); err != nil {
+		return nil, err
+	}
+
+	flags := []string{"--tag", TagForInstance(r.config.Instance)}
+	for _, tag := range tags {
+		flags = append(flags, "--tag", tag)
+	}
+
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+	r.l.Debug("backing up files", zap.String("repo", r.repoConfig.Id), zap.Strings("paths", paths))
+	summary, err := r.repo.Backup(ctx, paths, nil, restic.WithFlags(flags...))
+	if err != nil {
+		return summary, fmt.Errorf("failed to backup: %w", err)
+	}
+	return summary, nil
+}
+
+// CheckBeforeBackup fails fast if a backup can't succeed, before the backup spends time scanning its paths: the repo's
+// backend must be reachable and, unless restic is configured to wait for locks, the repo must not be locked
+// exclusively e.g. by a prune from another host.
+func (r *RepoOrchestrator) CheckBeforeBackup(ctx context.Context) error {
+	if err := r.removeStaleLocks(ctx); err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err := r.ensureInitialized(ctx); err != nil {
+		return fmt.Errorf("repo %v unreachable: %w", r.repoConfig.Id, err)
+	}
+
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+	if err := r.repo.Reachable(ctx); err != nil {
+		return fmt.Errorf("repo %v unreachable: %w", r.repoConfig.Id, err)
+	}
+	if r.repoConfig.RetryLock != "" {
+		return nil
+	}
+	locks, err := r.repo.Locks(ctx)
+	if err != nil {
+		return fmt.Errorf("list locks of repo %v: %w", r.repoConfig.Id, err)
+	}
+	for _, lock := range locks {
+		if lock.Exclusive {
+			return fmt.Errorf("repo %v is locked exclusively by %v@%v (pid %d) since %v, set a retry lock duration to wait for locks", r.repoConfig.Id, lock.Username, lock.Hostname, lock.PID, lock.Time.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// ProbeBackend measures the time taken to read the repo's config from its backend, it returns the time until the
+// failure if the config can't be read.
+func (r *RepoOrchestrator) ProbeBackend(ctx context.Context) (time.Duration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	start := time.Now()
+	if err := r.repo.Reachable(ctx); err != nil {
+		return time.Since(start), fmt.Errorf("repo %v unreachable: %w", r.repoConfig.Id, err)
+	}
+	return time.Since(start), nil
+}
+
+// backupTunables converts the plan's tunables to restic's, caches are excluded unless the plan includes them.
+func backupTunables(t *v1.BackupTunables) restic.BackupTunables {
+	var compression string
+	if t.GetCompression() != v1.BackupTunables_COMPRESSION_AUTO {
+		compression = strings.ToLower(strings.TrimPrefix(t.GetCompression().String(), "COMPRESSION_"))
+	}
+	return restic.BackupTunables{
+		ReadConcurrency: int(t.GetReadConcurrency()),
+		PackSizeMiB:     int(t.GetPackSizeMb()),
+		Compression:     compression,
+		ExcludeCaches:   !t.GetIncludeCaches(),
+		OneFileSystem:   t.GetOneFileSystem(),
+		IgnoreInode:     t.GetIgnoreInode(),
+	}
+}
+
+// ResticPath returns the path of the restic binary the repo's commands are run with.
+func (r *RepoOrchestrator) ResticPath() string {
+	return r.resticPath
+}
+
+// supportsSkipIfUnchanged returns true if the restic binary is recent enough to accept --skip-if-unchanged, older
+// versions always create a snapshot.
+func (r *RepoOrchestrator) supportsSkipIfUnchanged(ctx context.Context) bool {
+	r.versionOnce.Do(func() {
+		version, err := restic.Version(ctx, r.resticPath)
+		if err != nil {
+			r.l.Warn("failed to get restic version, assuming --skip-if-unchanged is not supported", zap.Error(err))
+			return
+		}
+		r.skipIfUnchangedOK = versionAtLeast(version, 0, 17)
+	})
+	return r.skipIfUnchangedOK
+}
+
+// versionAtLeast returns true if a restic version of the form "0.17.1" is at least major.minor.
+func versionAtLeast(version string, major, minor int) bool {
+	var gotMajor, gotMinor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &gotMajor, &gotMinor); err != nil {
+		return false
+	}
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
 func (r *RepoOrchestrator) ListSnapshotFiles(ctx context.Context, snapshotId string, path string) ([]*v1.LsEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
@@ -181,9 +372,90 @@ func (r *RepoOrchestrator) ListSnapshotFiles(ctx context.Context, snapshotId str
 	return lsEnts, nil
 }
 
+// Dump streams the content of the file at path in the snapshot to w without restoring it to disk.
+func (r *RepoOrchestrator) Dump(ctx context.Context, snapshotID string, path string, w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	r.l.Debug("dump file", zap.String("snapshot", snapshotID), zap.String("path", path))
+	if err := r.repo.Dump(ctx, snapshotID, path, w); err != nil {
+		return fmt.Errorf("dump %v from snapshot %v: %w", path, snapshotID, err)
+	}
+	return nil
+}
+
+// DumpArchive writes the directory at path in the snapshot, "/" for the whole snapshot, to w as a tar archive.
+func (r *RepoOrchestrator) DumpArchive(ctx context.Context, snapshotID string, path string, w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	r.l.Debug("dump archive", zap.String("snapshot", snapshotID), zap.String("path", path))
+	if err := r.repo.Dump(ctx, snapshotID, path, w, restic.WithFlags("--archive", "tar")); err != nil {
+		return fmt.Errorf("dump %v from snapshot %v as archive: %w", path, snapshotID, err)
+	}
+	return nil
+}
+
+// Mount mounts the repo's snapshots at mountpoint and blocks until the repo is unmounted by cancelling ctx. restic holds
+// a shared lock on the repo while it's mounted.
+func (r *RepoOrchestrator) Mount(ctx context.Context, mountpoint string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	r.l.Debug("mount repo", zap.String("mountpoint", mountpoint))
+	if err := r.repo.Mount(ctx, mountpoint); err != nil {
+		return fmt.Errorf("mount repo %v: %w", r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+// Find searches the repo's snapshots for files whose name matches pattern. The search is limited to snapshotIDs and to
+// the snapshots of planID if set. Matches are passed to callback a snapshot at a time, an error returned by callback
+// stops the search and is returned.
+func (r *RepoOrchestrator) Find(ctx context.Context, pattern string, snapshotIDs []string, planID string, ignoreCase bool, callback func(snapshotID string, entries []*v1.LsEntry) error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	var flags []string
+	for _, id := range snapshotIDs {
+		flags = append(flags, "--snapshot", id)
+	}
+	if planID != "" {
+		flags = append(flags, "--tag", TagForPlan(planID))
+	}
+	if ignoreCase {
+		flags = append(flags, "--ignore-case")
+	}
+
+	err := r.repo.Find(ctx, pattern, func(result *restic.FindResult) error {
+		entries := make([]*v1.LsEntry, 0, len(result.Matches))
+		for _, m := range result.Matches {
+			entries = append(entries, m.ToProto())
+		}
+		return callback(result.Snapshot, entries)
+	}, restic.WithFlags(flags...))
+	if err != nil {
+		return fmt.Errorf("find %q: %w", pattern, err)
+	}
+	return nil
+}
+
 func (r *RepoOrchestrator) Forget(ctx context.Context, plan *v1.Plan, tags []string) ([]*v1.ResticSnapshot, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	return r.forget(ctx, plan, tags)
+}
+
+// SnapshotsDueForForget returns the snapshots the plan's retention policy would remove without forgetting them.
+func (r *RepoOrchestrator) SnapshotsDueForForget(ctx context.Context, plan *v1.Plan, tags []string) ([]*v1.ResticSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.forget(ctx, plan, tags, restic.WithFlags("--dry-run"))
+}
+
+func (r *RepoOrchestrator) forget(ctx context.Context, plan *v1.Plan, tags []string, opts ...restic.GenericOption) ([]*v1.ResticSnapshot, error) {
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
@@ -196,11 +468,14 @@ func (r *RepoOrchestrator) Forget(ctx context.Context, plan *v1.Plan, tags []str
 		return nil, errors.New("instance is a required field in the backrest config")
 	}
 
-	result, err := r.repo.Forget(
-		ctx, protoutil.RetentionPolicyFromProto(plan.Retention),
+	// the snapshots of the plan's former instances are in the same group, they count towards the retention policy.
+	opts = append([]restic.GenericOption{
+		restic.WithEnv(PlanEnv(plan)...),
 		restic.WithFlags("--tag", strings.Join(tags, ",")),
+		restic.WithFlags(formerInstanceFlags(plan)...),
 		restic.WithFlags("--group-by", ""),
-	)
+	}, opts...)
+	result, err := r.repo.Forget(ctx, protoutil.RetentionPolicyFromProto(plan.Retention), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get snapshots for repo %v: %w", r.repoConfig.Id, err)
 	}
@@ -235,6 +510,33 @@ func (r *RepoOrchestrator) Prune(ctx context.Context, output io.Writer) error {
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
+	r.l.Debug("prune snapshots")
+	err := r.repo.Prune(ctx, output, r.pruneOpts()...)
+	if err != nil {
+		return fmt.Errorf("prune snapshots for repo %v: %w", r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+// Recompress prunes the repo repacking the data stored uncompressed, e.g. written before the repo was upgraded to the v2
+// format, so that it's compressed. An interrupted recompress resumes where it stopped when run again, packs already
+// repacked are compressed.
+func (r *RepoOrchestrator) Recompress(ctx context.Context, output io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.l.Debug("recompress repo")
+	opts := append(r.pruneOpts(), restic.WithFlags("--repack-uncompressed"))
+	if err := r.repo.Prune(ctx, output, opts...); err != nil {
+		return fmt.Errorf("recompress repo %v: %w", r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+// pruneOpts returns the flags applying the repo's prune policy.
+func (r *RepoOrchestrator) pruneOpts() []restic.GenericOption {
 	policy := r.repoConfig.PrunePolicy
 	if policy == nil {
 		policy = &v1.PrunePolicy{
@@ -248,21 +550,191 @@ func (r *RepoOrchestrator) Prune(ctx context.Context, output io.Writer) error {
 	} else if policy.MaxUnusedPercent != 0 {
 		opts = append(opts, restic.WithFlags("--max-unused", fmt.Sprintf("%v%%", policy.MaxUnusedPercent)))
 	}
+	return opts
+}
 
-	r.l.Debug("prune snapshots")
-	err := r.repo.Prune(ctx, output, opts...)
+// CopyFrom copies the plan's snapshots from the source repo into this repo, initializing this repo if needed. Only the
+// source's URI and password are used, the source's env and flags are not applied to the command.
+func (r *RepoOrchestrator) CopyFrom(ctx context.Context, source *v1.Repo, plan *v1.Plan) ([]restic.CopiedSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if source.GetUri() != r.repoConfig.GetUri() {
+		sourceMu := repoLocks.get(source.GetUri())
+		sourceMu.RLock()
+		defer sourceMu.RUnlock()
+	}
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	if err := r.ensureInitialized(ctx); err != nil {
+		return nil, err
+	}
+
+	opts := []restic.GenericOption{
+		restic.WithEnv(PlanEnv(plan)...),
+		restic.WithEnv("RESTIC_FROM_REPOSITORY="+source.GetUri(), "RESTIC_FROM_PASSWORD="+source.GetPassword()),
+		restic.WithFlags(planSnapshotFlags(plan, r.config.Instance)...),
+	}
+
+	r.l.Debug("copy snapshots", zap.String("source", source.GetId()), zap.String("plan", plan.Id))
+	copied, err := r.repo.Copy(ctx, opts...)
 	if err != nil {
-		return fmt.Errorf("prune snapshots for repo %v: %w", r.repoConfig.Id, err)
+		return nil, fmt.Errorf("copy snapshots from repo %v to repo %v: %w", source.GetId(), r.repoConfig.Id, err)
+	}
+	return copied, nil
+}
+
+func (r *RepoOrchestrator) Check(ctx context.Context, output io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.l.Debug("check repo")
+	if err := r.repo.Check(ctx, output); err != nil {
+		return fmt.Errorf("check repo %v: %w", r.repoConfig.Id, err)
 	}
 	return nil
 }
 
-func (r *RepoOrchestrator) Restore(ctx context.Context, snapshotId string, path string, target string, progressCallback func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error) {
+func (r *RepoOrchestrator) ListKeys(ctx context.Context) ([]*v1.RepoKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	keys, err := r.repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list keys of repo %v: %w", r.repoConfig.Id, err)
+	}
+	var repoKeys []*v1.RepoKey
+	for _, k := range keys {
+		repoKeys = append(repoKeys, repoKeyProto(k))
+	}
+	return repoKeys, nil
+}
+
+// AddKey adds a key to the repo opening it with password, the user and host of the key default to backrest's.
+func (r *RepoOrchestrator) AddKey(ctx context.Context, password, user, host string) (*v1.RepoKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.l.Debug("add key", zap.String("user", user), zap.String("host", host))
+	key, err := r.repo.AddKey(ctx, password, user, host)
+	if err != nil {
+		return nil, fmt.Errorf("add key to repo %v: %w", r.repoConfig.Id, err)
+	}
+	return repoKeyProto(key), nil
+}
+
+func (r *RepoOrchestrator) RemoveKey(ctx context.Context, id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
+	r.l.Debug("remove key", zap.String("key", id))
+	if err := r.repo.RemoveKey(ctx, id); err != nil {
+		return fmt.Errorf("remove key %v from repo %v: %w", id, r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+func repoKeyProto(k *restic.Key) *v1.RepoKey {
+	key := &v1.RepoKey{
+		Id:       k.ID,
+		Current:  k.Current,
+		Username: k.UserName,
+		Hostname: k.HostName,
+	}
+	// restic lists the creation time in local time.
+	if created, err := time.ParseInLocation("2006-01-02 15:04:05", k.Created, time.Local); err == nil {
+		key.CreatedUnixMs = created.UnixMilli()
+	}
+	return key
+}
+
+// Recover saves the directories of the repo that aren't referenced by any snapshot into a new snapshot, restic's output
+// is also written to output. The snapshot is tagged with the instance's tag so that it isn't reported as created
+// elsewhere, the returned result holds its full ID.
+func (r *RepoOrchestrator) Recover(ctx context.Context, output io.Writer) (*restic.RecoverResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	result, err := r.repo.Recover(ctx, output)
+	if err != nil {
+		return nil, fmt.Errorf("recover repo %v: %w", r.repoConfig.Id, err)
+	}
+	if result.SnapshotID == "" {
+		return result, nil
+	}
+
+	// tagging rewrites the snapshot, the tagged snapshot is found by its tags afterwards.
+	if err := r.repo.AddTags(ctx, []string{result.SnapshotID}, []string{TagForInstance(r.config.Instance)}); err != nil {
+		return nil, fmt.Errorf("tag recovered snapshot %v: %w", result.SnapshotID, err)
+	}
+	snapshots, err := r.repo.Snapshots(ctx, restic.WithFlags("--tag", "recovered,"+TagForInstance(r.config.Instance)))
+	if err != nil {
+		return nil, fmt.Errorf("get recovered snapshot: %w", err)
+	}
+	sortSnapshotsByTime(snapshots)
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("recovered snapshot %v not found after tagging it", result.SnapshotID)
+	}
+	result.SnapshotID = snapshots[len(snapshots)-1].Id
+	r.l.Debug("recovered unreferenced directories", zap.Int("roots", result.Roots), zap.String("snapshot", result.SnapshotID))
+	return result, nil
+}
+
+// Version returns the repo's format version, repos of version 2 or later support compression.
+func (r *RepoOrchestrator) Version(ctx context.Context) (int, error) {
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	version, err := r.repo.Version(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get version of repo %v: %w", r.repoConfig.Id, err)
+	}
+	return version, nil
+}
+
+// Migrations returns the names of the restic migrations that can be applied to the repo.
+func (r *RepoOrchestrator) Migrations(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	migrations, err := r.repo.Migrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list migrations of repo %v: %w", r.repoConfig.Id, err)
+	}
+	return migrations, nil
+}
+
+func (r *RepoOrchestrator) Migrate(ctx context.Context, migration string, output io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.l.Debug("migrate repo", zap.String("migration", migration))
+	if err := r.repo.Migrate(ctx, migration, output); err != nil {
+		return fmt.Errorf("migrate repo %v: %w", r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+func (r *RepoOrchestrator) Restore(ctx context.Context, snapshotId string, path string, target string, progressCallback func(event *v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
 	r.l.Debug("restore snapshot", zap.String("snapshot", snapshotId), zap.String("target", target))
 
 	var opts []restic.GenericOption
@@ -283,10 +755,11 @@ func (r *RepoOrchestrator) Restore(ctx context.Context, snapshotId string, path
 	return protoutil.RestoreProgressEntryToProto(summary), nil
 }
 
-// UnlockIfAutoEnabled unlocks the repo if the auto unlock feature is enabled.
+// UnlockIfAutoEnabled unlocks the repo if the auto unlock feature is enabled, otherwise removes stale locks if the
+// repo is configured with a stale lock age.
 func (r *RepoOrchestrator) UnlockIfAutoEnabled(ctx context.Context) error {
 	if !r.repoConfig.AutoUnlock {
-		return nil
+		return r.removeStaleLocks(ctx)
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -298,6 +771,59 @@ func (r *RepoOrchestrator) UnlockIfAutoEnabled(ctx context.Context) error {
 	return r.repo.Unlock(ctx)
 }
 
+// removeStaleLocks removes locks that have not been refreshed for longer than the repo's stale lock age. restic can
+// only remove specific locks by its own definition of stale, all locks are removed if every lock is past the
+// configured age and restic's own stale lock removal is used otherwise.
+func (r *RepoOrchestrator) removeStaleLocks(ctx context.Context) error {
+	maxAge := time.Duration(r.repoConfig.GetStaleLockMinutes()) * time.Minute
+	if maxAge <= 0 {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	locks, err := r.repo.Locks(ctx)
+	if err != nil {
+		return fmt.Errorf("list locks for repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	stale := 0
+	for _, lock := range locks {
+		if time.Since(lock.Time) > maxAge {
+			stale++
+		}
+	}
+	if stale == 0 {
+		return nil
+	}
+
+	var opts []restic.GenericOption
+	if stale == len(locks) {
+		opts = append(opts, restic.WithFlags("--remove-all"))
+	}
+	r.l.Info("removing stale locks", zap.Int("stale", stale), zap.Int("total", len(locks)), zap.Duration("maxAge", maxAge))
+	if err := r.repo.Unlock(ctx, opts...); err != nil {
+		return fmt.Errorf("remove stale locks for repo %v: %w", r.repoConfig.Id, err)
+	}
+	return nil
+}
+
+// ensureInitialized initializes the repo if it has not been initialized by this orchestrator yet.
+func (r *RepoOrchestrator) ensureInitialized(ctx context.Context) error {
+	r.initMu.Lock()
+	defer r.initMu.Unlock()
+	if r.initialized {
+		return nil
+	}
+	if err := r.repo.Init(ctx, restic.WithEnviron()); err != nil {
+		return fmt.Errorf("failed to initialize repo: %w", err)
+	}
+	r.initialized = true
+	return nil
+}
+
 func (r *RepoOrchestrator) Unlock(ctx context.Context) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -309,8 +835,8 @@ func (r *RepoOrchestrator) Unlock(ctx context.Context) error {
 }
 
 func (r *RepoOrchestrator) Stats(ctx context.Context) (*v1.RepoStats, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	ctx, flush := forwardResticLogs(ctx)
 	defer flush()
 
@@ -319,17 +845,50 @@ func (r *RepoOrchestrator) Stats(ctx context.Context) (*v1.RepoStats, error) {
 	if err != nil {
 		return nil, fmt.Errorf("stats for repo %v: %w", r.repoConfig.Id, err)
 	}
+	restoreStats, err := r.repo.RestoreSizeStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("restore size stats for repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	s := protoutil.RepoStatsToProto(stats)
+	s.RestoreSize = restoreStats.TotalSize
+	s.TotalFileCount = restoreStats.TotalFileCount
+	return s, nil
+}
+
+func (r *RepoOrchestrator) SnapshotStats(ctx context.Context, snapshotID string) (*v1.SnapshotStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	r.l.Debug("snapshot stats", zap.String("snapshot", snapshotID))
+	stats, err := r.repo.SnapshotStats(ctx, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("stats for snapshot %v: %w", snapshotID, err)
+	}
 
-	return protoutil.RepoStatsToProto(stats), nil
+	return &v1.SnapshotStats{
+		FileCount:   stats.FileCount,
+		TotalSize:   stats.TotalSize,
+		RestoreSize: stats.RestoreSize,
+	}, nil
 }
 
 func (r *RepoOrchestrator) AddTags(ctx context.Context, snapshotIDs []string, tags []string) error {
+	return r.EditTags(ctx, snapshotIDs, tags, nil)
+}
+
+// EditTags adds and removes tags of the snapshots, restic saves each snapshot it changes under a new ID.
+func (r *RepoOrchestrator) EditTags(ctx context.Context, snapshotIDs []string, add []string, remove []string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
 
 	for idx, snapshotIDs := range chunkBy(snapshotIDs, 20) {
-		r.l.Debug("adding tag to snapshots", zap.Strings("snapshots", snapshotIDs), zap.Strings("tags", tags))
-		if err := r.repo.AddTags(ctx, snapshotIDs, tags); err != nil {
+		r.l.Debug("editing tags of snapshots", zap.Strings("snapshots", snapshotIDs), zap.Strings("add", add), zap.Strings("remove", remove))
+		if err := r.repo.EditTags(ctx, snapshotIDs, add, remove); err != nil {
 			return fmt.Errorf("batch %v: %w", idx, err)
 		}
 	}
@@ -2,10 +2,13 @@ package repo
 
 import (
 	"context"
+	"maps"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/test/helpers"
@@ -55,6 +58,63 @@ func TestBackup(t *testing.T) {
 	}
 }
 
+func TestBackupExcludes(t *testing.T) {
+	t.Parallel()
+
+	testData := t.TempDir()
+	files := map[string]string{
+		"keep.txt":        "kept",
+		"big.bin":         strings.Repeat("x", 4096),
+		"scratch.tmp":     "excluded by the exclude file",
+		"sub/.nobackup":   "",
+		"sub/skipped.txt": "excluded with its directory",
+	}
+	for name, contents := range files {
+		path := filepath.Join(testData, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	excludeFile := filepath.Join(t.TempDir(), "excludes.txt")
+	if err := os.WriteFile(excludeFile, []byte("*.tmp\n"), 0644); err != nil {
+		t.Fatalf("failed to write exclude file: %v", err)
+	}
+
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      t.TempDir(),
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+
+	plan := &v1.Plan{
+		Id:                "test",
+		Repo:              "test",
+		Paths:             []string{testData},
+		ExcludeFiles:      []string{excludeFile},
+		ExcludeIfPresent:  []string{".nobackup"},
+		ExcludeLargerThan: "1K",
+	}
+
+	orchestrator, err := NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+
+	summary, err := orchestrator.Backup(context.Background(), plan, nil)
+	if err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	// restic keeps the .nobackup marker itself, the rest of its directory is excluded.
+	if summary.FilesNew != 2 {
+		t.Fatalf("expected 2 new files, got %d", summary.FilesNew)
+	}
+}
+
 func TestSnapshotParenting(t *testing.T) {
 	t.Parallel()
 
@@ -143,6 +203,138 @@ func TestSnapshotParenting(t *testing.T) {
 	}
 }
 
+func TestSnapshotHistoryAfterMigration(t *testing.T) {
+	t.Parallel()
+
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      t.TempDir(),
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "test",
+		Paths: []string{test.CreateTestData(t)},
+	}
+
+	old, err := NewRepoOrchestrator(&v1.Config{Instance: "old-machine"}, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	if _, err := old.Backup(context.Background(), plan, nil); err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	// after the migration the plan runs on another instance, the old instance's snapshot is still part of its history.
+	migrated, err := NewRepoOrchestrator(&v1.Config{Instance: "new-machine"}, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	if snapshots, err := migrated.SnapshotsForPlan(context.Background(), plan); err != nil || len(snapshots) != 0 {
+		t.Fatalf("expected no snapshots without a mapping, got %v %v", snapshots, err)
+	}
+	plan.History = &v1.SnapshotHistory{
+		Hostname:        "old-host",
+		FormerInstances: []string{"old-machine"},
+	}
+	if _, err := migrated.Backup(context.Background(), plan, nil); err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	snapshots, err := migrated.SnapshotsForPlan(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("failed to get snapshots for plan: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected the snapshots of both instances, got %d", len(snapshots))
+	}
+	if snapshots[1].Parent != snapshots[0].Id {
+		t.Errorf("expected the old instance's snapshot to be the parent, got %q", snapshots[1].Parent)
+	}
+	if snapshots[1].Hostname != "old-host" {
+		t.Errorf("expected the new snapshot to record the configured hostname, got %q", snapshots[1].Hostname)
+	}
+
+	plan.Retention = &v1.RetentionPolicy{Policy: &v1.RetentionPolicy_PolicyKeepLastN{PolicyKeepLastN: 1}}
+	forgotten, err := migrated.Forget(context.Background(), plan, []string{TagForPlan(plan.Id), TagForInstance("new-machine")})
+	if err != nil {
+		t.Fatalf("forget error: %v", err)
+	}
+	if len(forgotten) != 1 || forgotten[0].Id != snapshots[0].Id {
+		t.Errorf("expected the old instance's snapshot to be forgotten, got %v", forgotten)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	testData := test.CreateTestData(t)
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      t.TempDir(),
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "test",
+		Paths: []string{testData},
+	}
+
+	orchestrator, err := NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	before, err := orchestrator.Backup(context.Background(), plan, nil)
+	if err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(testData, "file10")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testData, "file11"), []byte("changed contents"), 0644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(testData, "sub"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testData, "sub", "new"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to add file: %v", err)
+	}
+	after, err := orchestrator.Backup(context.Background(), plan, nil)
+	if err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	diff, err := orchestrator.Diff(context.Background(), before.SnapshotId, after.SnapshotId)
+	if err != nil {
+		t.Fatalf("diff error: %v", err)
+	}
+	if diff.FilesAdded != 1 || diff.FilesRemoved != 1 || diff.FilesChanged != 1 || diff.SizesOmitted {
+		t.Errorf("unexpected diff stats %v", diff)
+	}
+
+	type entry struct {
+		change             v1.DiffEntry_Change
+		size, previousSize int64
+	}
+	got := make(map[string]entry)
+	for _, e := range diff.Entries {
+		got[strings.TrimPrefix(e.Path, filepath.ToSlash(testData))] = entry{e.Change, e.Size, e.PreviousSize}
+	}
+	want := map[string]entry{
+		"/file10":  {v1.DiffEntry_CHANGE_REMOVED, 0, int64(len("test data 10"))},
+		"/file11":  {v1.DiffEntry_CHANGE_MODIFIED, int64(len("changed contents")), int64(len("test data 11"))},
+		"/sub/":    {v1.DiffEntry_CHANGE_ADDED, 0, 0},
+		"/sub/new": {v1.DiffEntry_CHANGE_ADDED, 3, 0},
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("unexpected diff entries, got %v, want %v", got, want)
+	}
+}
+
 func TestEnvVarPropagation(t *testing.T) {
 	t.Parallel()
 
@@ -190,3 +382,244 @@ func TestEnvVarPropagation(t *testing.T) {
 		t.Fatal("expected snapshot id")
 	}
 }
+
+func TestBackendOptions(t *testing.T) {
+	t.Parallel()
+
+	testData := test.CreateTestData(t)
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "test",
+		Paths: []string{testData},
+	}
+
+	// options are passed to restic, it rejects the invalid connection count.
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      t.TempDir(),
+		Password: "test",
+		BackendOptions: &v1.BackendOptions{
+			Options: []*v1.BackendOptions_Option{{Name: "local.connections", Value: "many"}},
+		},
+	}
+	orchestrator, err := NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	if _, err := orchestrator.Backup(context.Background(), plan, nil); err == nil || !strings.Contains(err.Error(), "many") {
+		t.Fatalf("expected the backend option to be rejected by restic, got: %v", err)
+	}
+
+	// env vars are passed to restic, it creates its cache in the configured directory.
+	cacheDir := t.TempDir()
+	r.BackendOptions = &v1.BackendOptions{
+		Options: []*v1.BackendOptions_Option{{Name: "local.connections", Value: "2"}},
+		Env:     []*v1.BackendOptions_EnvVar{{Name: "RESTIC_CACHE_DIR", Value: cacheDir}},
+	}
+	orchestrator, err = NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	if _, err := orchestrator.Backup(context.Background(), plan, nil); err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+	if entries, err := os.ReadDir(cacheDir); err != nil || len(entries) == 0 {
+		t.Errorf("expected restic to use the cache dir from the backend env, got %v %v", entries, err)
+	}
+}
+
+func TestCacheCleanup(t *testing.T) {
+	t.Parallel()
+
+	testData := test.CreateTestData(t)
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "test",
+		Paths: []string{testData},
+	}
+
+	cacheDir := t.TempDir()
+	r := &v1.Repo{
+		Id:             "test",
+		Uri:            t.TempDir(),
+		Password:       "test",
+		CacheDir:       cacheDir,
+		MaxCacheSizeMb: 1,
+	}
+	orchestrator, err := NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	if _, err := orchestrator.Backup(context.Background(), plan, nil); err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	usage, err := orchestrator.CacheUsage(context.Background())
+	if err != nil {
+		t.Fatalf("cache usage error: %v", err)
+	}
+	if usage.CacheDir != cacheDir || usage.SizeBytes == 0 || usage.MaxSizeBytes != 1<<20 {
+		t.Fatalf("expected the repo's cache in %q, got %v", cacheDir, usage)
+	}
+
+	// a cache within its max size is kept.
+	if usage, err := orchestrator.CleanupCache(context.Background(), nil); err != nil || usage.SizeBytes == 0 {
+		t.Fatalf("expected the cache to be kept, got %v %v", usage, err)
+	}
+
+	// grow the cache beyond its max size, the cleanup clears it.
+	id, err := orchestrator.repo.ID(context.Background())
+	if err != nil {
+		t.Fatalf("repo ID error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, id, "padding"), make([]byte, 2<<20), 0600); err != nil {
+		t.Fatalf("write padding: %v", err)
+	}
+	if usage, err := orchestrator.CleanupCache(context.Background(), nil); err != nil || usage.SizeBytes != 0 {
+		t.Fatalf("expected the cache to be cleared, got %v %v", usage, err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, id)); !os.IsNotExist(err) {
+		t.Errorf("expected the repo's cache dir to be removed, got %v", err)
+	}
+}
+
+func TestRepoLockSharedByOrchestrators(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      repo,
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+
+	first, err := NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	if err := first.Init(context.Background(), nil); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	// e.g. the orchestrator for the repo after a config change.
+	second, err := NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+
+	// an exclusive operation e.g. prune is running through the first orchestrator.
+	first.mu.Lock()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := second.Snapshots(context.Background())
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("expected snapshots to wait for the exclusive operation to finish")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	first.mu.Unlock()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to list snapshots: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected snapshots to complete once the exclusive operation finished")
+	}
+}
+
+func TestRestoreConflicts(t *testing.T) {
+	t.Parallel()
+
+	testData := test.CreateTestData(t)
+	r := &v1.Repo{
+		Id:       "test",
+		Uri:      t.TempDir(),
+		Password: "test",
+		Flags:    []string{"--no-cache"},
+	}
+	plan := &v1.Plan{
+		Id:    "test",
+		Repo:  "test",
+		Paths: []string{testData},
+	}
+
+	orchestrator, err := NewRepoOrchestrator(configForTest, r, helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to create repo orchestrator: %v", err)
+	}
+	summary, err := orchestrator.Backup(context.Background(), plan, nil)
+	if err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+
+	// one file is edited after the backup and one is deleted.
+	edited := testData + "/file 1"
+	if err := os.WriteFile(edited, []byte("edited"), 0644); err != nil {
+		t.Fatalf("failed to edit file: %v", err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(edited, future, future); err != nil {
+		t.Fatalf("failed to set file time: %v", err)
+	}
+	if err := os.Remove(testData + "/file 2"); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+
+	report, err := orchestrator.RestoreConflicts(context.Background(), summary.SnapshotId, testData, "/", false)
+	if err != nil {
+		t.Fatalf("restore conflicts error: %v", err)
+	}
+
+	if report.FilesChecked != 100 || report.OverwrittenFiles != 99 || report.NewerLocalFiles != 1 {
+		t.Errorf("expected 100 files checked, 99 overwritten and 1 newer locally, got %v", report)
+	}
+	if len(report.Conflicts) == 0 || report.Conflicts[0].Path != edited || !report.Conflicts[0].LocalNewer {
+		t.Errorf("expected the edited file to be listed first, got %v", report.Conflicts)
+	}
+	if report.Digest == "" {
+		t.Errorf("expected a digest")
+	}
+
+	// swapping in the restore removes the local files that aren't in the snapshot.
+	localOnly := testData + "/local only"
+	if err := os.WriteFile(localOnly, []byte("not backed up"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	swapReport, err := orchestrator.RestoreConflicts(context.Background(), summary.SnapshotId, testData, "/", true)
+	if err != nil {
+		t.Fatalf("restore conflicts error: %v", err)
+	}
+	if swapReport.OverwrittenFiles != 99 || swapReport.RemovedFiles != 1 {
+		t.Errorf("expected 99 files overwritten and 1 removed, got %v", swapReport)
+	}
+	if len(swapReport.Conflicts) == 0 || swapReport.Conflicts[0].Path != localOnly || !swapReport.Conflicts[0].Removed {
+		t.Errorf("expected the local only file to be listed first, got %v", swapReport.Conflicts)
+	}
+	if swapReport.Digest == report.Digest {
+		t.Errorf("expected the removed files to change the digest")
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tcs := []struct {
+		version string
+		want    bool
+	}{
+		{"0.16.4", false},
+		{"0.17.0", true},
+		{"0.18.1", true},
+		{"1.0.0", true},
+		{"unknown", false},
+	}
+	for _, tc := range tcs {
+		if got := versionAtLeast(tc.version, 0, 17); got != tc.want {
+			t.Errorf("versionAtLeast(%q, 0, 17) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+// maxReportedConflicts bounds the conflicts listed in a report, all conflicts count towards its totals and digest.
+const maxReportedConflicts = 1000
+
+// RestoreConflicts reports the files under target that restoring path from the snapshot would overwrite. If swap is
+// set the restore replaces path as a whole, the local files under it that aren't in the snapshot are reported as
+// removed.
+func (r *RepoOrchestrator) RestoreConflicts(ctx context.Context, snapshotId string, path string, target string, swap bool) (*v1.RestoreConflictReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	_, entries, err := r.repo.ListDirectory(ctx, snapshotId, path, restic.WithFlags("--recursive"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshot files: %w", err)
+	}
+	var removed []*v1.RestoreConflict
+	if swap {
+		if removed, err = filesNotInSnapshot(entries, path, target); err != nil {
+			return nil, fmt.Errorf("failed to list local files: %w", err)
+		}
+	}
+	return conflictReport(entries, target, removed), nil
+}
+
+// filesNotInSnapshot returns the local files under path, restored under target, that aren't among the files listed
+// from the snapshot.
+func filesNotInSnapshot(entries []*restic.LsEntry, path, target string) ([]*v1.RestoreConflict, error) {
+	inSnapshot := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		inSnapshot[filepath.Join(target, filepath.FromSlash(entry.Path))] = true
+	}
+
+	var removed []*v1.RestoreConflict
+	err := filepath.WalkDir(filepath.Join(target, filepath.FromSlash(path)), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // nothing to remove.
+			}
+			return err
+		}
+		if d.IsDir() || inSnapshot[p] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(target, p)
+		if err != nil {
+			return err
+		}
+		removed = append(removed, &v1.RestoreConflict{
+			Path:         "/" + filepath.ToSlash(rel),
+			LocalSize:    info.Size(),
+			LocalMtimeMs: info.ModTime().UnixMilli(),
+			Removed:      true,
+		})
+		return nil
+	})
+	return removed, err
+}
+
+// conflictReport compares the files listed from a snapshot with the files they would be restored over under target,
+// removed are the local files the restore would remove.
+func conflictReport(entries []*restic.LsEntry, target string, removed []*v1.RestoreConflict) *v1.RestoreConflictReport {
+	report := &v1.RestoreConflictReport{}
+	var conflicts []*v1.RestoreConflict
+	for _, entry := range entries {
+		if entry.Type != "file" {
+			continue
+		}
+		report.FilesChecked++
+
+		info, err := os.Lstat(filepath.Join(target, filepath.FromSlash(entry.Path)))
+		if err != nil {
+			continue // nothing to overwrite.
+		}
+		conflict := &v1.RestoreConflict{
+			Path:         entry.Path,
+			LocalSize:    info.Size(),
+			SnapshotSize: entry.Size,
+			LocalMtimeMs: info.ModTime().UnixMilli(),
+		}
+		if mtime, err := time.Parse(time.RFC3339Nano, entry.Mtime); err == nil {
+			conflict.SnapshotMtimeMs = mtime.UnixMilli()
+			conflict.LocalNewer = conflict.LocalMtimeMs > conflict.SnapshotMtimeMs
+		}
+		if conflict.LocalNewer {
+			report.NewerLocalFiles++
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	report.OverwrittenFiles = int64(len(conflicts))
+	report.RemovedFiles = int64(len(removed))
+	conflicts = append(conflicts, removed...)
+
+	// removed files are listed first, they're lost rather than replaced by an older copy.
+	slices.SortFunc(conflicts, func(a, b *v1.RestoreConflict) int {
+		if a.Removed != b.Removed {
+			if a.Removed {
+				return -1
+			}
+			return 1
+		}
+		if a.LocalNewer != b.LocalNewer {
+			if a.LocalNewer {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	h := sha256.New()
+	for _, c := range conflicts {
+		fmt.Fprintf(h, "%s\x00%d\x00%d\x00%t\x00", c.Path, c.LocalSize, c.LocalMtimeMs, c.Removed)
+	}
+	report.Digest = hex.EncodeToString(h.Sum(nil)[:16])
+
+	if len(conflicts) > maxReportedConflicts {
+		conflicts = conflicts[:maxReportedConflicts]
+		report.Truncated = true
+	}
+	report.Conflicts = conflicts
+	return report
+}
@@ -0,0 +1,201 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/sysinfo"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+const (
+	// maxPreviewFiles bounds the files listed in a restore preview, all files count towards its totals.
+	maxPreviewFiles = 1000
+	// maxPreviewProblems bounds the problems listed in a restore preview.
+	maxPreviewProblems = 100
+)
+
+// PreviewRestore lists the files restoring path from the snapshot under target would write and checks that they can be
+// written, nothing is written.
+func (r *RepoOrchestrator) PreviewRestore(ctx context.Context, snapshotId string, path string, target string) (*v1.RestorePreview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	_, entries, err := r.repo.ListDirectory(ctx, snapshotId, path, restic.WithFlags("--recursive"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshot files: %w", err)
+	}
+	return restorePreview(entries, target, sysinfo.IsRoot()), nil
+}
+
+// restorePreview describes restoring the entries listed from a snapshot under target. Restores don't restore the
+// ownership of files unless isRoot.
+func restorePreview(entries []*restic.LsEntry, target string, isRoot bool) *v1.RestorePreview {
+	preview := &v1.RestorePreview{Target: target}
+	checker := newTargetChecker()
+	uid := os.Getuid()
+	var overwrittenBytes int64
+	var otherOwner int64
+
+	for _, entry := range entries {
+		dest := filepath.Join(target, filepath.FromSlash(entry.Path))
+		switch entry.Type {
+		case "dir":
+			preview.TotalDirs++
+			checker.checkParent(dest, 0)
+			continue
+		case "file":
+			checker.checkParent(dest, 1)
+		default:
+			checker.checkParent(dest, 0)
+			continue // e.g. symlinks, they're created but hold no data.
+		}
+
+		preview.TotalFiles++
+		preview.TotalBytes += entry.Size
+		file := &v1.RestorePreviewFile{Path: dest, Size: entry.Size}
+		if info, err := os.Lstat(dest); err == nil {
+			file.Overwrites = true
+			overwrittenBytes += info.Size()
+			checker.checkFile(dest)
+		}
+		if !isRoot && uid >= 0 && entry.Uid != int64(uid) {
+			otherOwner++
+		}
+		if len(preview.Files) < maxPreviewFiles {
+			preview.Files = append(preview.Files, file)
+		} else {
+			preview.Truncated = true
+		}
+	}
+	slices.SortFunc(preview.Files, func(a, b *v1.RestorePreviewFile) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	if otherOwner > 0 {
+		checker.add(v1.RestoreProblem_KIND_OWNERSHIP, target, "backrest doesn't run as root, files owned by other users in the snapshot would be owned by backrest's user").Files = otherOwner
+	}
+	if needed := preview.TotalBytes - overwrittenBytes; needed > 0 {
+		if dir := checker.existingAncestor(target); dir != "" {
+			if usage, err := sysinfo.Disk(dir); err == nil && usage.FreeBytes < uint64(needed) {
+				checker.add(v1.RestoreProblem_KIND_INSUFFICIENT_SPACE, dir, fmt.Sprintf("the restore needs %d bytes, %d bytes are free", needed, usage.FreeBytes)).Files = preview.TotalFiles
+			}
+		}
+	}
+	preview.Problems = checker.problems
+	return preview
+}
+
+// targetChecker checks the directories and files a restore would write to, each directory is checked once.
+type targetChecker struct {
+	dirs     map[string]*dirState
+	files    map[fileProblemKey]*v1.RestoreProblem // problems overwriting files, reported once per directory.
+	problems []*v1.RestoreProblem
+}
+
+type fileProblemKey struct {
+	dir      string
+	readOnly bool
+}
+
+type dirState struct {
+	existing string             // the nearest existing ancestor of the directory including itself, "" if there is none.
+	problem  *v1.RestoreProblem // the problem writing files to the directory, if any.
+}
+
+func newTargetChecker() *targetChecker {
+	return &targetChecker{
+		dirs:  make(map[string]*dirState),
+		files: make(map[fileProblemKey]*v1.RestoreProblem),
+	}
+}
+
+// checkParent checks that the directory path would be written to exists or can be created, the problem found counts
+// the files written to the directory.
+func (c *targetChecker) checkParent(path string, files int64) {
+	if st := c.dir(filepath.Dir(path)); st.problem != nil {
+		st.problem.Files += files
+	}
+}
+
+// checkFile checks that an existing file can be overwritten, files in a directory with a problem aren't checked again.
+func (c *targetChecker) checkFile(path string) {
+	dir := filepath.Dir(path)
+	if c.dir(dir).problem != nil {
+		return
+	}
+	err := sysinfo.Writable(path)
+	if err == nil {
+		return
+	}
+	key := fileProblemKey{dir: dir, readOnly: errors.Is(err, syscall.EROFS)}
+	p, ok := c.files[key]
+	if !ok {
+		p = c.accessProblem(path, err)
+		c.files[key] = p
+	}
+	p.Files++
+}
+
+// existingAncestor returns the nearest existing ancestor of dir including dir itself, "" if there is none.
+func (c *targetChecker) existingAncestor(dir string) string {
+	return c.dir(dir).existing
+}
+
+func (c *targetChecker) dir(dir string) *dirState {
+	if st, ok := c.dirs[dir]; ok {
+		return st
+	}
+	st := &dirState{}
+	info, err := os.Stat(dir)
+	switch {
+	case err == nil && !info.IsDir():
+		st.existing = dir
+		st.problem = c.add(v1.RestoreProblem_KIND_NOT_DIRECTORY, dir, "a file exists where the restore needs a directory")
+	case err == nil:
+		st.existing = dir
+		if err := sysinfo.Writable(dir); err != nil {
+			st.problem = c.accessProblem(dir, err)
+		}
+	case errors.Is(err, fs.ErrNotExist) && filepath.Dir(dir) != dir:
+		parent := c.dir(filepath.Dir(dir))
+		st.existing = parent.existing
+		if parent.problem != nil || parent.existing != filepath.Dir(dir) {
+			// the directory can't be created or is created along with its missing parent.
+			st.problem = parent.problem
+		} else {
+			// reported for the missing directory closest to the existing one, that's where the restore starts creating them.
+			st.problem = c.add(v1.RestoreProblem_KIND_MISSING_PARENT, dir, "the directory doesn't exist, it would be created")
+		}
+	default:
+		st.problem = c.accessProblem(dir, err)
+	}
+	c.dirs[dir] = st
+	return st
+}
+
+func (c *targetChecker) accessProblem(path string, err error) *v1.RestoreProblem {
+	if errors.Is(err, syscall.EROFS) {
+		return c.add(v1.RestoreProblem_KIND_READ_ONLY_FILESYSTEM, path, "the filesystem is mounted read-only")
+	}
+	return c.add(v1.RestoreProblem_KIND_NOT_WRITABLE, path, err.Error())
+}
+
+// add reports a problem and returns it to count the files it affects, problems past the limit are not reported.
+func (c *targetChecker) add(kind v1.RestoreProblem_Kind, path string, message string) *v1.RestoreProblem {
+	p := &v1.RestoreProblem{Kind: kind, Path: path, Message: message}
+	if len(c.problems) < maxPreviewProblems {
+		c.problems = append(c.problems, p)
+	}
+	return p
+}
@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+func TestRestorePreview(t *testing.T) {
+	t.Parallel()
+
+	target := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(target, "data"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "data", "existing"), []byte("local"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	// a file where the snapshot has a directory.
+	if err := os.WriteFile(filepath.Join(target, "blocked"), nil, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	uid := int64(os.Getuid())
+	entries := []*restic.LsEntry{
+		{Type: "dir", Path: "/data", Uid: uid},
+		{Type: "file", Path: "/data/existing", Size: 10, Uid: uid},
+		{Type: "file", Path: "/data/new/a", Size: 20, Uid: uid},
+		{Type: "file", Path: "/data/new/b", Size: 30, Uid: uid + 1},
+		{Type: "file", Path: "/blocked/c", Size: 40, Uid: uid},
+		{Type: "symlink", Path: "/data/link", Uid: uid},
+	}
+	preview := restorePreview(entries, target, false)
+
+	if preview.TotalFiles != 4 || preview.TotalDirs != 1 || preview.TotalBytes != 100 {
+		t.Errorf("expected 4 files, 1 dir and 100 bytes, got %v", preview)
+	}
+	if len(preview.Files) != 4 || preview.Files[1].Path != filepath.Join(target, "data", "existing") || !preview.Files[1].Overwrites || preview.Files[2].Overwrites {
+		t.Errorf("expected the files sorted by path with the existing file overwritten, got %v", preview.Files)
+	}
+
+	problems := make(map[v1.RestoreProblem_Kind]*v1.RestoreProblem)
+	for _, p := range preview.Problems {
+		problems[p.Kind] = p
+	}
+	if p := problems[v1.RestoreProblem_KIND_MISSING_PARENT]; p == nil || p.Path != filepath.Join(target, "data", "new") || p.Files != 2 {
+		t.Errorf("expected the missing directory to be reported once for its 2 files, got %v", p)
+	}
+	if p := problems[v1.RestoreProblem_KIND_NOT_DIRECTORY]; p == nil || p.Path != filepath.Join(target, "blocked") || p.Files != 1 {
+		t.Errorf("expected the file blocking a directory to be reported, got %v", p)
+	}
+	if p := problems[v1.RestoreProblem_KIND_OWNERSHIP]; p == nil || p.Files != 1 {
+		t.Errorf("expected the file owned by another user to be reported, got %v", p)
+	}
+	if p := restorePreview(entries, target, true); len(p.Problems) != 2 {
+		t.Errorf("expected no ownership problem when running as root, got %v", p.Problems)
+	}
+
+	if os.Geteuid() != 0 {
+		if err := os.Chmod(filepath.Join(target, "data"), 0555); err != nil {
+			t.Fatalf("failed to make directory read-only: %v", err)
+		}
+		defer os.Chmod(filepath.Join(target, "data"), 0755)
+		preview := restorePreview(entries, target, true)
+		var found bool
+		for _, p := range preview.Problems {
+			found = found || p.Kind == v1.RestoreProblem_KIND_NOT_WRITABLE && p.Path == filepath.Join(target, "data")
+		}
+		if !found {
+			t.Errorf("expected the read-only directory to be reported, got %v", preview.Problems)
+		}
+	}
+}
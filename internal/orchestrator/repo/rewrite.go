@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+// maxRewritePreviewOutput bounds the dry run output included in a rewrite preview, all of it counts towards its digest.
+const maxRewritePreviewOutput = 8 * 1024
+
+// PreviewRewrite runs restic rewrite --dry-run to report which of the snapshots hold files matching the excludes. The
+// preview's digest covers the rewrite's arguments and everything the dry run would change, it changes whenever a rewrite
+// with the same arguments would do something else.
+func (r *RepoOrchestrator) PreviewRewrite(ctx context.Context, snapshotIDs []string, excludes []string, forget bool) (*v1.RewritePreview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	opts := []restic.GenericOption{restic.WithFlags("--dry-run")}
+	if forget {
+		opts = append(opts, restic.WithFlags("--forget"))
+	}
+	output := bytes.NewBuffer(nil)
+	result, err := r.repo.Rewrite(ctx, snapshotIDs, excludes, output, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("dry run rewrite of snapshots in repo %v: %w", r.repoConfig.Id, err)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%q\x00%q\x00%t\x00", snapshotIDs, excludes, forget)
+	h.Write(output.Bytes())
+
+	preview := &v1.RewritePreview{
+		SnapshotsRewritten: int32(result.Rewritten),
+		Output:             output.String(),
+		Digest:             hex.EncodeToString(h.Sum(nil)[:16]),
+	}
+	if len(preview.Output) > maxRewritePreviewOutput {
+		preview.Output = preview.Output[:maxRewritePreviewOutput]
+	}
+	return preview, nil
+}
+
+// Rewrite removes the files matching the excludes from the snapshots, restic's output is also written to output. The
+// changed snapshots are saved under new IDs, the originals are forgotten if forget is set.
+func (r *RepoOrchestrator) Rewrite(ctx context.Context, snapshotIDs []string, excludes []string, forget bool, output io.Writer) (*restic.RewriteResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, flush := forwardResticLogs(ctx)
+	defer flush()
+
+	var opts []restic.GenericOption
+	if forget {
+		opts = append(opts, restic.WithFlags("--forget"))
+	}
+	result, err := r.repo.Rewrite(ctx, snapshotIDs, excludes, output, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite snapshots in repo %v: %w", r.repoConfig.Id, err)
+	}
+	return result, nil
+}
@@ -2,7 +2,11 @@ package repo
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 )
 
 // TagForPlan returns a tag for the plan.
@@ -15,6 +19,22 @@ func TagForInstance(instanceId string) string {
 	return fmt.Sprintf("created-by:%s", instanceId)
 }
 
+// planSnapshotFlags returns --tag flags selecting the plan's snapshots created by the instance or by any of the plan's
+// former instances, restic selects snapshots matching any of the tag lists.
+func planSnapshotFlags(plan *v1.Plan, instanceId string) []string {
+	flags := []string{"--tag", TagForPlan(plan.Id) + "," + TagForInstance(instanceId)}
+	return append(flags, formerInstanceFlags(plan)...)
+}
+
+// formerInstanceFlags returns --tag flags selecting the plan's snapshots created by its former instances.
+func formerInstanceFlags(plan *v1.Plan) []string {
+	var flags []string
+	for _, instance := range plan.GetHistory().GetFormerInstances() {
+		flags = append(flags, "--tag", TagForPlan(plan.Id)+","+TagForInstance(instance))
+	}
+	return flags
+}
+
 // InstanceIDFromTags returns the instance ID from the tags, or an empty string if not found.
 func InstanceIDFromTags(tags []string) string {
 	for _, tag := range tags {
@@ -34,3 +54,56 @@ func PlanFromTags(tags []string) string {
 	}
 	return ""
 }
+
+const (
+	annotationTagPrefix = "annotation:"
+
+	// MaxAnnotations is the maximum number of annotations attached to a snapshot.
+	MaxAnnotations     = 32
+	maxAnnotationValue = 256
+)
+
+var annotationKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`)
+
+// TagForAnnotation returns the tag attaching the annotation to a snapshot.
+func TagForAnnotation(a *v1.SnapshotAnnotation) string {
+	return annotationTagPrefix + a.Key + "=" + a.Value
+}
+
+// ValidateAnnotation returns an error if the annotation can't be attached to a snapshot as a tag. restic splits the
+// values of --tag at commas.
+func ValidateAnnotation(a *v1.SnapshotAnnotation) error {
+	if !annotationKeyRegex.MatchString(a.Key) {
+		return fmt.Errorf("annotation key %q must be 1 to 64 letters, digits, '_', '.' or '-'", a.Key)
+	}
+	if len(a.Value) > maxAnnotationValue {
+		return fmt.Errorf("annotation %q: value is longer than %d characters", a.Key, maxAnnotationValue)
+	}
+	if strings.ContainsFunc(a.Value, func(r rune) bool { return r == ',' || unicode.IsControl(r) }) {
+		return fmt.Errorf("annotation %q: value must not contain commas or control characters", a.Key)
+	}
+	return nil
+}
+
+// MergeAnnotations returns the annotations of base with those of overrides added, an annotation of overrides replaces
+// the one of base with the same key. It returns an error if the result has too many annotations.
+func MergeAnnotations(base, overrides []*v1.SnapshotAnnotation) ([]*v1.SnapshotAnnotation, error) {
+	merged := append([]*v1.SnapshotAnnotation{}, base...)
+	for _, a := range overrides {
+		replaced := false
+		for i, b := range merged {
+			if b.Key == a.Key {
+				merged[i] = a
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, a)
+		}
+	}
+	if len(merged) > MaxAnnotations {
+		return nil, fmt.Errorf("too many annotations, at most %d are attached to a snapshot", MaxAnnotations)
+	}
+	return merged, nil
+}
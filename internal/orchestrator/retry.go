@@ -0,0 +1,47 @@
+package orchestrator
+
+import (
+	"slices"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+const (
+	defaultRetryInitialBackoff = 60 * time.Second
+	defaultRetryMaxBackoff     = 1 * time.Hour
+)
+
+// retryDelay returns the delay before the next attempt of an operation that failed with err after the given number
+// of attempts. Returns false if the policy does not retry the failure.
+func retryDelay(policy *v1.RetryPolicy, attempts int, err error) (time.Duration, bool) {
+	if policy == nil || attempts >= int(policy.MaxAttempts) {
+		return 0, false
+	}
+
+	retryOn := policy.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = []v1.RetryPolicy_ErrorClass{v1.RetryPolicy_ERROR_CLASS_TRANSIENT}
+	}
+	class := v1.RetryPolicy_ERROR_CLASS_PERMANENT
+	if restic.ClassifyError(err) == restic.ErrorClassTransient {
+		class = v1.RetryPolicy_ERROR_CLASS_TRANSIENT
+	}
+	if !slices.Contains(retryOn, class) {
+		return 0, false
+	}
+
+	backoff := defaultRetryInitialBackoff
+	if policy.InitialBackoffSeconds > 0 {
+		backoff = time.Duration(policy.InitialBackoffSeconds) * time.Second
+	}
+	maxBackoff := defaultRetryMaxBackoff
+	if policy.MaxBackoffSeconds > 0 {
+		maxBackoff = time.Duration(policy.MaxBackoffSeconds) * time.Second
+	}
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff), true
+}
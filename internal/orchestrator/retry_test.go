@@ -0,0 +1,61 @@
+package orchestrator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	transientErr := errors.New("dial tcp: connection refused")
+	permanentErr := errors.New("wrong password or no key found")
+
+	policy := &v1.RetryPolicy{
+		MaxAttempts:           4,
+		InitialBackoffSeconds: 10,
+		MaxBackoffSeconds:     30,
+	}
+
+	tests := []struct {
+		name      string
+		policy    *v1.RetryPolicy
+		attempts  int
+		err       error
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{name: "no policy", policy: nil, attempts: 1, err: transientErr, wantRetry: false},
+		{name: "first retry", policy: policy, attempts: 1, err: transientErr, wantDelay: 10 * time.Second, wantRetry: true},
+		{name: "backoff doubles", policy: policy, attempts: 2, err: transientErr, wantDelay: 20 * time.Second, wantRetry: true},
+		{name: "backoff is capped", policy: policy, attempts: 3, err: transientErr, wantDelay: 30 * time.Second, wantRetry: true},
+		{name: "max attempts reached", policy: policy, attempts: 4, err: transientErr, wantRetry: false},
+		{name: "permanent errors not retried by default", policy: policy, attempts: 1, err: permanentErr, wantRetry: false},
+		{
+			name: "permanent errors retried if configured",
+			policy: &v1.RetryPolicy{
+				MaxAttempts: 2,
+				RetryOn:     []v1.RetryPolicy_ErrorClass{v1.RetryPolicy_ERROR_CLASS_PERMANENT},
+			},
+			attempts:  1,
+			err:       permanentErr,
+			wantDelay: defaultRetryInitialBackoff,
+			wantRetry: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, retry := retryDelay(tc.policy, tc.attempts, tc.err)
+			if retry != tc.wantRetry {
+				t.Fatalf("retryDelay() retry = %v, want %v", retry, tc.wantRetry)
+			}
+			if retry && delay != tc.wantDelay {
+				t.Errorf("retryDelay() delay = %v, want %v", delay, tc.wantDelay)
+			}
+		})
+	}
+}
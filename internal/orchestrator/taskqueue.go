@@ -0,0 +1,252 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/garethgeorge/backrest/internal/metrics"
+	"github.com/garethgeorge/backrest/internal/queue"
+)
+
+// taskQueue holds scheduled tasks in a separate queue for each repo. Each repo's queue is drained by its own worker
+// so that tasks for different repos can run concurrently while tasks for the same repo are always run serially.
+type taskQueue struct {
+	mu     sync.Mutex
+	queues map[string]*repoQueue
+	added  chan struct{} // notified when a new repo queue is created.
+}
+
+// repoQueue is the queue of tasks for a single repo.
+type repoQueue struct {
+	repoID string
+	tasks  *queue.TimePriorityQueue[stContainer]
+
+	// waiting is a task that has been dequeued but is waiting for a concurrency slot. It is still considered part of the
+	// queue and may be removed (e.g. cancelled) until it starts running.
+	waiting *stContainer
+}
+
+func newTaskQueue() *taskQueue {
+	return &taskQueue{
+		queues: make(map[string]*repoQueue),
+		added:  make(chan struct{}, 1),
+	}
+}
+
+func (q *taskQueue) repoQueue(repoID string) *repoQueue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	rq, ok := q.queues[repoID]
+	if !ok {
+		rq = &repoQueue{repoID: repoID, tasks: queue.NewTimePriorityQueue[stContainer]()}
+		q.queues[repoID] = rq
+		select {
+		case q.added <- struct{}{}:
+		default:
+		}
+	}
+	return rq
+}
+
+// repoQueues returns a snapshot of the queues that currently exist.
+func (q *taskQueue) repoQueues() []*repoQueue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	res := make([]*repoQueue, 0, len(q.queues))
+	for _, rq := range q.queues {
+		res = append(res, rq)
+	}
+	return res
+}
+
+func (q *taskQueue) Enqueue(at time.Time, priority int, t stContainer) {
+	q.repoQueue(t.Task.RepoID()).tasks.Enqueue(at, priority, t)
+}
+
+func (q *taskQueue) GetAll() []stContainer {
+	var res []stContainer
+	for _, rq := range q.repoQueues() {
+		res = append(res, rq.tasks.GetAll()...)
+		q.mu.Lock()
+		if rq.waiting != nil {
+			res = append(res, *rq.waiting)
+		}
+		q.mu.Unlock()
+	}
+	return res
+}
+
+func (q *taskQueue) Remove(t stContainer) {
+	rq := q.repoQueue(t.Task.RepoID())
+	q.mu.Lock()
+	if rq.waiting != nil && rq.waiting.Eq(t) {
+		rq.waiting = nil
+		q.mu.Unlock()
+		return
+	}
+	q.mu.Unlock()
+	rq.tasks.Remove(t)
+}
+
+func (q *taskQueue) Reset() []stContainer {
+	var res []stContainer
+	for _, rq := range q.repoQueues() {
+		res = append(res, rq.tasks.Reset()...)
+		q.mu.Lock()
+		if rq.waiting != nil {
+			res = append(res, *rq.waiting)
+			rq.waiting = nil
+		}
+		q.mu.Unlock()
+	}
+	return res
+}
+
+// dequeue blocks until a task in the repo's queue is ready to run and a slot is acquired from the limiter.
+// The caller must release the slot when the task completes. Returns false if the context is cancelled.
+func (q *taskQueue) dequeue(ctx context.Context, rq *repoQueue, limiter slotLimiter) (stContainer, bool) {
+	for {
+		t := rq.tasks.Dequeue(ctx)
+		if ctx.Err() != nil {
+			return stContainer{}, false
+		}
+		if t.Task == nil {
+			continue
+		}
+		metrics.SchedulerTickLatencySeconds.Observe(max(0, time.Since(t.RunAt).Seconds()))
+
+		q.mu.Lock()
+		rq.waiting = &t
+		q.mu.Unlock()
+
+		if !limiter.acquire(ctx, t.priority) {
+			return stContainer{}, false
+		}
+
+		q.mu.Lock()
+		removed := rq.waiting != &t
+		rq.waiting = nil
+		if !removed {
+			// a higher priority task may have been scheduled while waiting for a slot, requeue the task so that the
+			// highest priority ready task runs first.
+			rq.tasks.Enqueue(t.RunAt, t.priority, t)
+		}
+		q.mu.Unlock()
+
+		if removed {
+			limiter.release()
+			continue
+		}
+
+		t = rq.tasks.Dequeue(ctx)
+		if t.Task == nil {
+			limiter.release()
+			if ctx.Err() != nil {
+				return stContainer{}, false
+			}
+			continue
+		}
+		return t, true
+	}
+}
+
+// slotLimiter hands out the slots that tasks hold while they run.
+type slotLimiter interface {
+	acquire(ctx context.Context, priority int) bool
+	release()
+}
+
+// limiterChain holds a slot from each of its limiters, slots are acquired in order so that a task waiting on a narrow
+// limit (e.g. its namespace's) does not hold a slot of a wider one meanwhile.
+type limiterChain []*concurrencyLimiter
+
+func (c limiterChain) acquire(ctx context.Context, priority int) bool {
+	for i, l := range c {
+		if !l.acquire(ctx, priority) {
+			for _, acquired := range c[:i] {
+				acquired.release()
+			}
+			return false
+		}
+	}
+	return true
+}
+
+func (c limiterChain) release() {
+	for _, l := range c {
+		l.release()
+	}
+}
+
+// concurrencyLimiter bounds the number of tasks that may run at once. The limit may be changed at any time.
+// When slots are contended they are granted to the highest priority waiter first.
+type concurrencyLimiter struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	running int
+	limit   int
+	waiting map[int]int // count of waiters by priority.
+}
+
+func newConcurrencyLimiter(limit int) *concurrencyLimiter {
+	l := &concurrencyLimiter{limit: limit, waiting: make(map[int]int)}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+func (l *concurrencyLimiter) setLimit(limit int) {
+	l.mu.Lock()
+	l.limit = limit
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// acquire blocks until a slot is available and no higher priority task is waiting for one, returns false if the
+// context is cancelled first.
+func (l *concurrencyLimiter) acquire(ctx context.Context, priority int) bool {
+	stop := context.AfterFunc(ctx, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.cond.Broadcast()
+	})
+	defer stop()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waiting[priority]++
+	defer func() {
+		if l.waiting[priority]--; l.waiting[priority] == 0 {
+			delete(l.waiting, priority)
+		}
+		// a waiter of lower priority may now be first in line.
+		l.cond.Broadcast()
+	}()
+	for l.running >= l.limit || l.higherPriorityWaiting(priority) {
+		if ctx.Err() != nil {
+			return false
+		}
+		l.cond.Wait()
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+	l.running++
+	return true
+}
+
+func (l *concurrencyLimiter) higherPriorityWaiting(priority int) bool {
+	for p := range l.waiting {
+		if p > priority {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *concurrencyLimiter) release() {
+	l.mu.Lock()
+	l.running--
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
@@ -1,11 +1,17 @@
 package orchestrator
 
 import (
+	"context"
+	"fmt"
+	"slices"
+
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/alerts"
 	"github.com/garethgeorge/backrest/internal/hook"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"github.com/garethgeorge/backrest/internal/orchestrator/tasks"
+	"go.uber.org/zap"
 )
 
 // taskRunnerImpl is an implementation of TaskRunner for the default orchestrator.
@@ -76,7 +82,7 @@ func (t *taskRunnerImpl) ExecuteHooks(events []v1.Hook_Condition, vars hook.Hook
 			return err
 		}
 	}
-	if planID != "" {
+	if planID != "" && planID != tasks.PlanForUnassociatedOperations {
 		var err error
 		plan, err = t.FindPlan()
 		if err != nil {
@@ -87,10 +93,49 @@ func (t *taskRunnerImpl) ExecuteHooks(events []v1.Hook_Condition, vars hook.Hook
 	if t.op != nil {
 		flowID = t.op.FlowId
 	}
-	executor := hook.NewHookExecutor(t.Config(), t.orchestrator.OpLog, t.orchestrator.logStore)
+	if events = t.unmutedEvents(events); len(events) == 0 {
+		return nil
+	}
+	executor := hook.NewHookExecutor(t.Config(), t.orchestrator.OpLog, t.orchestrator.logStore, t.orchestrator.hookPool, t.orchestrator.hookQueue)
 	return executor.ExecuteHooks(flowID, repo, plan, events, vars)
 }
 
+// unmutedEvents drops the error events of a backup whose plan's failed backup alert is acknowledged or snoozed, the
+// plan's hooks already notified of the failure.
+func (t *taskRunnerImpl) unmutedEvents(events []v1.Hook_Condition) []v1.Hook_Condition {
+	if t.op.GetOperationBackup() == nil || t.orchestrator.OpLog == nil {
+		return events
+	}
+	muted, err := alerts.BackupFailuresMuted(t.Config(), t.orchestrator.OpLog, t.t.PlanID(), t.orchestrator.curTime())
+	if err != nil {
+		zap.L().Warn("failed to check whether the plan's alerts are muted", zap.String("plan", t.t.PlanID()), zap.Error(err))
+		return events
+	}
+	if !muted {
+		return events
+	}
+	return slices.DeleteFunc(slices.Clone(events), func(e v1.Hook_Condition) bool {
+		return e == v1.Hook_CONDITION_ANY_ERROR || e == v1.Hook_CONDITION_SNAPSHOT_ERROR
+	})
+}
+
+func (t *taskRunnerImpl) ExecuteBackupCommand(ctx context.Context, name string, cmd *v1.BackupCommand, event v1.Hook_Condition, vars hook.HookVars) error {
+	repo, err := t.FindRepo()
+	if err != nil {
+		return err
+	}
+	plan, err := t.FindPlan()
+	if err != nil {
+		return err
+	}
+	var flowID int64
+	if t.op != nil {
+		flowID = t.op.FlowId
+	}
+	executor := hook.NewHookExecutor(t.Config(), t.orchestrator.OpLog, t.orchestrator.logStore, t.orchestrator.hookPool, t.orchestrator.hookQueue)
+	return executor.ExecuteBackupCommand(ctx, flowID, repo, plan, fmt.Sprintf("plan/%v/%v", plan.Id, name), cmd, event, vars)
+}
+
 func (t *taskRunnerImpl) GetRepo(repoID string) (*v1.Repo, error) {
 	return t.orchestrator.GetRepo(repoID)
 }
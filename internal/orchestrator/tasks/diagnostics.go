@@ -0,0 +1,109 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
+	"github.com/garethgeorge/backrest/internal/sysinfo"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+const (
+	diagnosticsMaxLines = 50       // lines kept of restic's output and of the daemon log.
+	diagnosticsMaxBytes = 8 * 1024 // bytes kept of restic's output and of the daemon log.
+	backendProbeTimeout = 30 * time.Second
+)
+
+// captureFailureDiagnostics records the state of the system and of the repo's backend after the plan's backup failed
+// with err. Each part is captured independently, a part that can't be read is left empty.
+func captureFailureDiagnostics(ctx context.Context, plan *v1.Plan, repoCfg *v1.Repo, repo *repo.RepoOrchestrator, err error) *v1.FailureDiagnostics {
+	d := &v1.FailureDiagnostics{
+		UnixTimeMs: time.Now().UnixMilli(),
+		DaemonLog:  tailLines(string(logging.DaemonLog.Bytes()), diagnosticsMaxLines, diagnosticsMaxBytes),
+	}
+
+	var cmdErr *restic.CmdError
+	if errors.As(err, &cmdErr) {
+		d.ResticOutput = resticOutputTail(cmdErr.Output)
+	}
+
+	paths := append([]string{}, plan.GetPaths()...)
+	if p := localRepoPath(repoCfg.GetUri()); p != "" {
+		paths = append(paths, p)
+	}
+	paths = append(paths, config.DataDir())
+	for _, p := range paths {
+		usage := &v1.DiskUsage{Path: p}
+		if du, err := sysinfo.Disk(p); err != nil {
+			usage.Error = err.Error()
+		} else {
+			usage.FreeBytes = int64(du.FreeBytes)
+			usage.TotalBytes = int64(du.TotalBytes)
+		}
+		d.Disks = append(d.Disks, usage)
+	}
+
+	mem := sysinfo.Memory()
+	d.MemoryTotalBytes = int64(mem.TotalBytes)
+	d.MemoryAvailableBytes = int64(mem.AvailableBytes)
+	d.DaemonMemoryBytes = int64(mem.ProcessBytes)
+
+	if repo != nil {
+		probeCtx, cancel := context.WithTimeout(ctx, backendProbeTimeout)
+		defer cancel()
+		latency, err := repo.ProbeBackend(probeCtx)
+		d.BackendLatencyMs = latency.Milliseconds()
+		if err != nil {
+			d.BackendError = err.Error()
+		}
+	}
+	return d
+}
+
+// resticOutputTail returns the last lines of a restic command's output without the progress messages of --json
+// output, the messages restic prints before it fails are typically the cause of the failure.
+func resticOutputTail(output string) string {
+	var kept []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, `{"message_type":"status"`) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return tailLines(strings.Join(kept, "\n"), diagnosticsMaxLines, diagnosticsMaxBytes)
+}
+
+// tailLines returns up to the last maxLines complete lines of s that fit in maxBytes.
+func tailLines(s string, maxLines, maxBytes int) string {
+	s = strings.TrimRight(s, "\n")
+	if len(s) > maxBytes {
+		s = s[len(s)-maxBytes:]
+		// drop the partial first line.
+		if i := strings.IndexByte(s, '\n'); i != -1 {
+			s = s[i+1:]
+		} else {
+			s = ""
+		}
+	}
+	lines := strings.Split(s, "\n")
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// localRepoPath returns the path of a repo stored on a local filesystem, or "" if the repo is stored remotely.
+func localRepoPath(uri string) string {
+	uri = strings.TrimPrefix(uri, "local:")
+	if !filepath.IsAbs(uri) {
+		return ""
+	}
+	return uri
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTailLines(t *testing.T) {
+	tcs := []struct {
+		name     string
+		s        string
+		maxLines int
+		maxBytes int
+		want     string
+	}{
+		{name: "short", s: "a\nb\n", maxLines: 5, maxBytes: 100, want: "a\nb"},
+		{name: "max lines", s: "a\nb\nc\nd", maxLines: 2, maxBytes: 100, want: "c\nd"},
+		{name: "max bytes drops partial line", s: "aaaa\nbbbb\ncccc", maxLines: 5, maxBytes: 7, want: "cccc"},
+		{name: "single line over max bytes", s: "aaaaaaaa", maxLines: 5, maxBytes: 4, want: ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tailLines(tc.s, tc.maxLines, tc.maxBytes); got != tc.want {
+				t.Errorf("tailLines() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResticOutputTail(t *testing.T) {
+	output := strings.Join([]string{
+		`{"message_type":"status","percent_done":0.5}`,
+		`Fatal: unable to save snapshot: no space left on device`,
+		`{"message_type":"status","percent_done":0.6}`,
+	}, "\n")
+	if got := resticOutputTail(output); got != "Fatal: unable to save snapshot: no space left on device" {
+		t.Errorf("resticOutputTail() = %q", got)
+	}
+}
+
+func TestLocalRepoPath(t *testing.T) {
+	for uri, want := range map[string]string{
+		"/data/repo":       "/data/repo",
+		"local:/data/repo": "/data/repo",
+		"s3:host/bucket":   "",
+		"rest:http://host": "",
+		"relative/repo":    "",
+	} {
+		if got := localRepoPath(uri); got != want {
+			t.Errorf("localRepoPath(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
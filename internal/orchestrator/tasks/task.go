@@ -18,10 +18,12 @@ const (
 
 	TaskPriorityStats          = -1
 	TaskPriorityDefault        = 0
-	TaskPriorityInteractive    = 1 << 1
-	TaskPriorityForget         = 1 << 2
-	TaskPriorityIndexSnapshots = 1 << 3
-	TaskPriorityPrune          = 1 << 4
+	TaskPriorityForget         = 1 << 1
+	TaskPriorityIndexSnapshots = 1 << 2
+	TaskPriorityPrune          = 1 << 3
+	// TaskPriorityInteractive is added to the priority of tasks triggered by a user. It outranks any combination of the
+	// priorities above so that user initiated tasks run before scheduled maintenance.
+	TaskPriorityInteractive = 1 << 8
 )
 
 // TaskRunner is an interface for running tasks. It is used by tasks to create operations and write logs.
@@ -32,6 +34,8 @@ type TaskRunner interface {
 	UpdateOperation(*v1.Operation) error
 	// ExecuteHooks
 	ExecuteHooks(events []v1.Hook_Condition, vars hook.HookVars) error
+	// ExecuteBackupCommand runs a plan's pre or post backup command, the event identifies which.
+	ExecuteBackupCommand(ctx context.Context, name string, cmd *v1.BackupCommand, event v1.Hook_Condition, vars hook.HookVars) error
 	// OpLog returns the oplog for the operations.
 	OpLog() *oplog.OpLog
 	// GetRepo returns the repo with the given ID.
@@ -51,6 +55,10 @@ type ScheduledTask struct {
 	Task  Task          // the task to run
 	RunAt time.Time     // the time at which the task should be run.
 	Op    *v1.Operation // operation associated with this execution of the task.
+
+	// Reason optionally explains the choice of RunAt e.g. a missed run being caught up, it's recorded in the scheduler's
+	// decision log.
+	Reason string
 }
 
 func (s ScheduledTask) Eq(other ScheduledTask) bool {
@@ -1,18 +1,31 @@
 package tasks
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/cronutil"
 	"github.com/garethgeorge/backrest/internal/hook"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"github.com/garethgeorge/backrest/internal/orchestrator/logging"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"github.com/garethgeorge/backrest/internal/protoutil"
 	"github.com/garethgeorge/backrest/pkg/restic"
-	"github.com/gitploy-io/cronexpr"
 	"go.uber.org/zap"
 )
 
@@ -21,26 +34,89 @@ var maxBackupErrorHistoryLength = 20 // arbitrary limit on the number of file re
 // BackupTask is a scheduled backup operation.
 type BackupTask struct {
 	BaseTask
-	scheduler func(curTime time.Time) *time.Time
+	// scheduler returns the next run and, if notable, the reason for its time.
+	scheduler func(curTime time.Time, runner TaskRunner) (*time.Time, string)
+	jitter    time.Duration // maximum random delay added to each scheduled run.
+
+	// Annotations are attached to the snapshots of the task's backups along with those written by the plan's pre backup
+	// command.
+	Annotations []*v1.SnapshotAnnotation
 }
 
 var _ Task = &BackupTask{}
 
+// maxIntervalRetryDelay bounds how long an unsuccessful backup delays the next attempt of a plan scheduled by interval.
+const maxIntervalRetryDelay = time.Hour
+
 func NewScheduledBackupTask(plan *v1.Plan) (*BackupTask, error) {
-	sched, err := cronexpr.ParseInLocation(plan.Cron, time.Now().Location().String())
+	if interval := plan.GetSchedule().GetInterval(); interval != "" {
+		return newIntervalBackupTask(plan, interval)
+	}
+
+	sched, err := cronutil.ParseInLocation(plan.Cron, time.Now().Location().String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse schedule %q: %w", plan.Cron, err)
 	}
 
+	minInterval := config.MinScheduleInterval(plan)
 	return &BackupTask{
 		BaseTask: BaseTask{
 			TaskName:   fmt.Sprintf("backup for plan %q", plan.Id),
 			TaskRepoID: plan.Repo,
 			TaskPlanID: plan.Id,
 		},
-		scheduler: func(curTime time.Time) *time.Time {
+		jitter: time.Duration(plan.JitterMinutes) * time.Minute,
+		scheduler: func(curTime time.Time, runner TaskRunner) (*time.Time, string) {
 			next := sched.Next(curTime)
-			return &next
+			if runner == nil || runner.OpLog() == nil {
+				return &next, ""
+			}
+			lastRun, ok := lastBackupTime(runner, plan.Id)
+			if !ok {
+				return &next, ""
+			}
+			// guard against a schedule firing more often than the plan allows e.g. a cron typo that passed validation
+			// before the plan's min interval was raised.
+			earliest := lastRun.Add(minInterval)
+			if next.Before(earliest) {
+				next = sched.Next(earliest.Add(-time.Nanosecond))
+				return &next, fmt.Sprintf("deferred to keep the plan's minimum interval of %v since the last backup", minInterval)
+			}
+			missed := sched.Next(lastRun)
+			if missed.IsZero() || !missed.Before(curTime) {
+				return &next, ""
+			}
+			if !curTime.Before(earliest) && catchupMissedRun(sched, plan.CatchupPolicy, time.Duration(plan.CatchupWindowMinutes)*time.Minute, lastRun, curTime) {
+				return &curTime, fmt.Sprintf("catching up the run missed at %v", missed.Format(time.RFC3339))
+			}
+			return &next, fmt.Sprintf("the run missed at %v is skipped by the plan's catch up policy", missed.Format(time.RFC3339))
+		},
+	}, nil
+}
+
+func newIntervalBackupTask(plan *v1.Plan, interval string) (*BackupTask, error) {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse interval %q: %w", interval, err)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("interval %q must be positive", interval)
+	}
+
+	return &BackupTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("backup for plan %q", plan.Id),
+			TaskRepoID: plan.Repo,
+			TaskPlanID: plan.Id,
+		},
+		jitter: time.Duration(plan.JitterMinutes) * time.Minute,
+		scheduler: func(curTime time.Time, runner TaskRunner) (*time.Time, string) {
+			if runner == nil || runner.OpLog() == nil {
+				next := curTime.Add(d)
+				return &next, ""
+			}
+			next := nextIntervalRun(runner.OpLog(), plan.Id, d, curTime)
+			return &next, ""
 		},
 	}, nil
 }
@@ -53,42 +129,312 @@ func NewOneoffBackupTask(plan *v1.Plan, at time.Time) *BackupTask {
 			TaskRepoID: plan.Repo,
 			TaskPlanID: plan.Id,
 		},
-		scheduler: func(curTime time.Time) *time.Time {
+		scheduler: func(curTime time.Time, runner TaskRunner) (*time.Time, string) {
 			if didOnce {
-				return nil
+				return nil, ""
 			}
 			didOnce = true
-			return &at
+			return &at, ""
 		},
 	}
 }
 
 func (t *BackupTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
-	next := t.scheduler(now)
+	next, reason := t.scheduler(now, runner)
 	if next == nil {
 		return NeverScheduledTask
 	}
 
+	var jitter time.Duration
+	if t.jitter > 0 {
+		jitter = time.Duration(rand.Int63n(int64(t.jitter)))
+	}
+	runAt := next.Add(jitter)
+
+	backupOp := &v1.OperationBackup{Annotations: t.Annotations}
+	if jitter > 0 {
+		backupOp.ScheduledTimeMs = runAt.UnixMilli()
+		backupOp.JitterMs = jitter.Milliseconds()
+		if reason != "" {
+			reason += ", "
+		}
+		reason += fmt.Sprintf("jitter of %v applied", jitter.Round(time.Second))
+	}
 	return ScheduledTask{
-		Task:  t,
-		RunAt: *next,
+		Task:   t,
+		RunAt:  runAt,
+		Reason: reason,
 		Op: &v1.Operation{
 			PlanId:          t.PlanID(),
 			RepoId:          t.RepoID(),
-			UnixTimeStartMs: (*next).UnixMilli(),
+			UnixTimeStartMs: runAt.UnixMilli(),
 			Status:          v1.OperationStatus_STATUS_PENDING,
-			Op:              &v1.Operation_OperationBackup{},
+			Op:              &v1.Operation_OperationBackup{OperationBackup: backupOp},
 		},
 	}
 }
 
+// nextIntervalRun returns when a plan scheduled by interval should next back up: the interval after its last successful
+// backup ended. Unsuccessful backups since then delay the next attempt by the interval or maxIntervalRetryDelay,
+// whichever is shorter, so that a plan that keeps failing or being skipped is not run continuously. Runs that are
+// already due are scheduled at curTime.
+func nextIntervalRun(log *oplog.OpLog, planID string, interval time.Duration, curTime time.Time) time.Time {
+	var lastSuccess, lastAttempt time.Time
+	log.ForEachByPlan(planID, indexutil.Reversed(indexutil.CollectAll()), func(op *v1.Operation) error {
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); !ok || op.UnixTimeEndMs == 0 {
+			return nil
+		}
+		end := time.UnixMilli(op.UnixTimeEndMs)
+		if op.Status == v1.OperationStatus_STATUS_SUCCESS || op.Status == v1.OperationStatus_STATUS_WARNING {
+			lastSuccess = end
+			return oplog.ErrStopIteration
+		}
+		if lastAttempt.IsZero() {
+			lastAttempt = end
+		}
+		return nil
+	})
+
+	next := curTime
+	if !lastSuccess.IsZero() {
+		next = lastSuccess.Add(interval)
+	}
+	if !lastAttempt.IsZero() {
+		if retryAt := lastAttempt.Add(min(interval, maxIntervalRetryDelay)); retryAt.After(next) {
+			next = retryAt
+		}
+	}
+	if next.Before(curTime) {
+		return curTime
+	}
+	return next
+}
+
+// lastBackupTime returns the start time of the most recent backup operation for the plan that actually ran.
+// Operations that were cancelled before running, or that never finished because backrest was stopped, are ignored.
+func lastBackupTime(runner TaskRunner, planID string) (time.Time, bool) {
+	var lastRun time.Time
+	var found bool
+	runner.OpLog().ForEachByPlan(planID, indexutil.Reversed(indexutil.CollectAll()), func(op *v1.Operation) error {
+		if _, ok := op.Op.(*v1.Operation_OperationBackup); !ok {
+			return nil
+		}
+		if op.Status == v1.OperationStatus_STATUS_INPROGRESS || (op.UnixTimeEndMs != 0 && op.Status != v1.OperationStatus_STATUS_SYSTEM_CANCELLED) {
+			lastRun = time.UnixMilli(op.UnixTimeStartMs)
+			found = true
+			return oplog.ErrStopIteration
+		}
+		return nil
+	})
+	return lastRun, found
+}
+
+// catchupMissedRun returns true if a run scheduled after lastRun was missed as of curTime and the policy requires it to be run now.
+func catchupMissedRun(sched *cronutil.Schedule, policy v1.Plan_CatchupPolicy, window time.Duration, lastRun time.Time, curTime time.Time) bool {
+	missed := sched.Next(lastRun)
+	if missed.IsZero() || !missed.Before(curTime) {
+		return false
+	}
+
+	switch policy {
+	case v1.Plan_CATCHUP_POLICY_RUN_IMMEDIATELY:
+		return true
+	case v1.Plan_CATCHUP_POLICY_RUN_ONCE_WITHIN_WINDOW:
+		// find the most recent missed run, only it is a candidate for catch up.
+		for next := sched.Next(missed); !next.IsZero() && next.Before(curTime); next = sched.Next(next) {
+			missed = next
+		}
+		return curTime.Sub(missed) <= window
+	default:
+		return false
+	}
+}
+
+// planCommandEnv returns the environment of the commands generating a plan's backup e.g. its file list command.
+func planCommandEnv(plan *v1.Plan) []string {
+	return append(repo.PlanEnv(plan), "BACKREST_PLAN_ID="+plan.Id, "BACKREST_REPO_ID="+plan.Repo)
+}
+
+// WriteFilesFrom writes the plan's files from list to a temporary file for restic's --files-from-verbatim and returns
+// its path, the caller removes it. The list's command is run to generate paths, its output is written to log.
+func WriteFilesFrom(ctx context.Context, plan *v1.Plan, log io.Writer) (string, error) {
+	if log == nil {
+		log = io.Discard
+	}
+	paths := slices.Clone(plan.FilesFrom.Paths)
+	if cmd := plan.FilesFrom.Command; cmd != "" {
+		output, err := hook.CommandOutput(ctx, cmd, planCommandEnv(plan), log)
+		if err != nil {
+			return "", fmt.Errorf("command: %w", err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(output))
+		for scanner.Scan() {
+			if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+				paths = append(paths, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("read command output: %w", err)
+		}
+		fmt.Fprintf(log, "command listed %d paths\n", len(paths)-len(plan.FilesFrom.Paths))
+	}
+
+	f, err := os.CreateTemp("", "backrest-files-from-*")
+	if err != nil {
+		return "", fmt.Errorf("create list: %w", err)
+	}
+	defer f.Close()
+	for _, p := range paths {
+		if _, err := fmt.Fprintln(f, p); err != nil {
+			os.Remove(f.Name())
+			return "", fmt.Errorf("write list: %w", err)
+		}
+	}
+	return f.Name(), nil
+}
+
+// annotateFromFile returns the annotations with those in the file written by a pre backup command added, see
+// hook.HookVars.AnnotationsFile. The file has a "<key>=<value>" line per annotation, blank lines and lines starting
+// with '#' are ignored.
+func annotateFromFile(annotations []*v1.SnapshotAnnotation, path string) ([]*v1.SnapshotAnnotation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var added []*v1.SnapshotAnnotation
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected <key>=<value>", i+1)
+		}
+		a := &v1.SnapshotAnnotation{Key: strings.TrimSpace(key), Value: value}
+		if err := repo.ValidateAnnotation(a); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		added = append(added, a)
+	}
+	return repo.MergeAnnotations(annotations, added)
+}
+
+// annotationTagFlags returns the flags tagging a backup's snapshot with the annotations.
+func annotationTagFlags(annotations []*v1.SnapshotAnnotation) []string {
+	var flags []string
+	for _, a := range annotations {
+		flags = append(flags, "--tag", repo.TagForAnnotation(a))
+	}
+	return flags
+}
+
+// BackupCommandOutput runs the shell command and backs up what it prints to stdout, backup is called with the
+// command's stdout as restic's stdin. restic is stopped before it sees the end of the output if the command exits with
+// a non-zero code, so that no snapshot of partial output is saved, and the command's exit code is reported in the
+// returned error. The command is killed if the backup fails before reading all of its output.
+func BackupCommandOutput(ctx context.Context, command string, env []string, log io.Writer, backup func(ctx context.Context, stdin io.Reader) (*restic.BackupProgressEntry, error)) (*restic.BackupProgressEntry, error) {
+	if log == nil {
+		log = io.Discard
+	}
+	// the command writes directly to the pipe read by restic. restic only sees the end of the output once every copy of
+	// the write end is closed, the copy held here is closed once the command is known to have succeeded.
+	stdin, stdout, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("create pipe: %w", err)
+	}
+	defer stdin.Close()
+	var closeStdout sync.Once
+	defer closeStdout.Do(func() { stdout.Close() })
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd, err := hook.StartCommand(ctx, command, env, stdout, log)
+	if err != nil {
+		return nil, fmt.Errorf("start command: %w", err)
+	}
+	cmdErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		if err != nil {
+			cancel()
+		} else {
+			closeStdout.Do(func() { stdout.Close() })
+		}
+		cmdErr <- err
+	}()
+
+	summary, err := backup(ctx, stdin)
+	cancel() // kills the command if the backup stopped before reading all of its output.
+	closeStdout.Do(func() { stdout.Close() })
+
+	var exitErr *exec.ExitError
+	if e := <-cmdErr; e != nil && (err == nil || errors.As(e, &exitErr) && exitErr.Exited()) {
+		if errors.As(e, &exitErr) && exitErr.Exited() {
+			return summary, fmt.Errorf("command exited with code %d, the backup was stopped without a snapshot: %w", exitErr.ExitCode(), e)
+		}
+		return summary, fmt.Errorf("command: %w", e)
+	}
+	return summary, err
+}
+
+// backupUnchanged returns true if the backup summarized by summary found no new or changed files since its parent
+// snapshot. Directory changes are ignored, a directory's metadata changes whenever entries are added or removed.
+// Backups without a parent report every file as new so they're never unchanged.
+func backupUnchanged(summary *restic.BackupProgressEntry) bool {
+	return summary.FilesNew == 0 && summary.FilesChanged == 0 && summary.FilesUnmodified > 0
+}
+
+// checkSuccessCriteria returns an error describing the first criteria the backup summarized by summary fails to meet.
+// listFiles lists the entries at a path in the backup's snapshot.
+func checkSuccessCriteria(criteria *v1.SuccessCriteria, summary *restic.BackupProgressEntry, listFiles func(path string) ([]*v1.LsEntry, error)) error {
+	if summary.TotalFilesProcessed < criteria.MinFilesProcessed {
+		return fmt.Errorf("processed %d files, expected at least %d", summary.TotalFilesProcessed, criteria.MinFilesProcessed)
+	}
+	if summary.TotalBytesProcessed < criteria.MinBytesProcessed {
+		return fmt.Errorf("processed %d bytes, expected at least %d", summary.TotalBytesProcessed, criteria.MinBytesProcessed)
+	}
+	for _, p := range criteria.RequiredPaths {
+		if summary.SnapshotId == "" {
+			return errors.New("no snapshot was created")
+		}
+		p = path.Clean(p)
+		entries, err := listFiles(p)
+		if err != nil {
+			return fmt.Errorf("list required path %q: %w", p, err)
+		}
+		if !slices.ContainsFunc(entries, func(e *v1.LsEntry) bool { return e.Path == p }) {
+			return fmt.Errorf("required path %q not found in snapshot", p)
+		}
+	}
+	return nil
+}
+
 func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	err := t.run(ctx, st, runner)
+	if (err != nil || st.Op.Status == v1.OperationStatus_STATUS_ERROR) && ctx.Err() == nil {
+		// a cancelled backup didn't fail, there's nothing to diagnose.
+		repoCfg, _ := runner.GetRepo(t.RepoID())
+		repo, _ := runner.GetRepoOrchestrator(t.RepoID())
+		plan, planErr := runner.GetPlan(t.PlanID())
+		if planErr == nil {
+			st.Op.FailureDiagnostics = captureFailureDiagnostics(ctx, plan, repoCfg, repo, err)
+		}
+	}
+	return err
+}
+
+func (t *BackupTask) run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
 	l := Logger(ctx, st.Task)
 
 	startTime := time.Now()
 	op := st.Op
 	backupOp := &v1.Operation_OperationBackup{
-		OperationBackup: &v1.OperationBackup{},
+		OperationBackup: &v1.OperationBackup{
+			ScheduledTimeMs: op.GetOperationBackup().GetScheduledTimeMs(),
+			JitterMs:        op.GetOperationBackup().GetJitterMs(),
+			Annotations:     op.GetOperationBackup().GetAnnotations(),
+		},
 	}
 	op.Op = backupOp
 
@@ -102,6 +448,19 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 		return err
 	}
 
+	// backupCtx bounds the pre backup command and the backup by the plan's timeout. The post backup command runs after
+	// a timeout, it typically undoes the work of the pre backup command.
+	backupCtx := ctx
+	var timeout time.Duration
+	if plan.Timeout != "" {
+		if timeout, err = time.ParseDuration(plan.Timeout); err != nil {
+			return fmt.Errorf("parse timeout %q: %w", plan.Timeout, err)
+		}
+		var cancel context.CancelFunc
+		backupCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	if err := runner.ExecuteHooks([]v1.Hook_Condition{
 		v1.Hook_CONDITION_SNAPSHOT_START,
 	}, hook.HookVars{}); err != nil {
@@ -114,10 +473,80 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 		return fmt.Errorf("hook failed: %w", err)
 	}
 
+	var commandWarnings []string
+	if cmd := plan.GetPreBackup(); cmd.GetCommand() != "" {
+		annotationsFile, err := os.CreateTemp("", "backrest-annotations-*")
+		if err != nil {
+			return fmt.Errorf("create annotations file: %w", err)
+		}
+		annotationsFile.Close()
+		defer os.Remove(annotationsFile.Name())
+
+		if err := runner.ExecuteBackupCommand(backupCtx, "pre_backup", cmd, v1.Hook_CONDITION_SNAPSHOT_START, hook.HookVars{Task: t.Name(), AnnotationsFile: annotationsFile.Name()}); err != nil {
+			if cmd.OnFailure == v1.BackupCommand_FAILURE_POLICY_ABORT {
+				err = fmt.Errorf("pre backup command: %w", err)
+				if ctx.Err() == nil && errors.Is(backupCtx.Err(), context.DeadlineExceeded) {
+					err = fmt.Errorf("timed out after %v, %w", timeout, err)
+				}
+				runner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_SNAPSHOT_ERROR,
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  t.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			l.Warn("pre backup command failed, continuing with backup", zap.String("plan", plan.Id), zap.Error(err))
+			commandWarnings = append(commandWarnings, fmt.Sprintf("pre backup command failed: %v", err))
+		}
+
+		annotations, err := annotateFromFile(backupOp.OperationBackup.Annotations, annotationsFile.Name())
+		if err != nil {
+			l.Warn("ignoring the annotations written by the pre backup command", zap.String("plan", plan.Id), zap.Error(err))
+			commandWarnings = append(commandWarnings, fmt.Sprintf("pre backup command annotations: %v", err))
+		} else {
+			backupOp.OperationBackup.Annotations = annotations
+		}
+	}
+
+	// the check follows the pre backup command, which may make the repo available e.g. by mounting its storage.
+	if err := repo.CheckBeforeBackup(backupCtx); err != nil {
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_SNAPSHOT_ERROR,
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, hook.HookVars{
+			Task:  t.Name(),
+			Error: err.Error(),
+		})
+		return err
+	}
+
+	var backupOpts []restic.GenericOption
+	if plan.FilesFrom != nil {
+		filesFrom, err := WriteFilesFrom(backupCtx, plan, logging.WriterFromContext(ctx))
+		if err != nil {
+			err = fmt.Errorf("files from: %w", err)
+			runner.ExecuteHooks([]v1.Hook_Condition{
+				v1.Hook_CONDITION_SNAPSHOT_ERROR,
+				v1.Hook_CONDITION_ANY_ERROR,
+			}, hook.HookVars{
+				Task:  t.Name(),
+				Error: err.Error(),
+			})
+			return err
+		}
+		defer os.Remove(filesFrom)
+		backupOpts = append(backupOpts, restic.WithFlags("--files-from-verbatim", filesFrom))
+	}
+	if flags := annotationTagFlags(backupOp.OperationBackup.Annotations); len(flags) > 0 {
+		backupOpts = append(backupOpts, restic.WithFlags(flags...))
+	}
+
 	var sendWg sync.WaitGroup
 	lastSent := time.Now() // debounce progress updates, these can endup being very frequent.
 	var lastFiles []string
-	summary, err := repo.Backup(ctx, plan, func(entry *restic.BackupProgressEntry) {
+	progressCallback := func(entry *restic.BackupProgressEntry) {
 		sendWg.Wait()
 		if entry.MessageType == "status" {
 			// prevents flickering output when a status entry omits the CurrentFiles property. Largely cosmetic.
@@ -129,19 +558,15 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 
 			backupOp.OperationBackup.LastStatus = protoutil.BackupProgressEntryToProto(entry)
 		} else if entry.MessageType == "error" {
-			zap.S().Warnf("an unknown error was encountered in processing item: %v", entry.Item)
-			backupError, err := protoutil.BackupProgressEntryToBackupError(entry)
-			if err != nil {
-				zap.S().Errorf("failed to convert backup progress entry to backup error: %v", err)
+			zap.S().Warnf("an error was encountered in processing item %v: %v", entry.Item, entry.ErrorMessage())
+			if !recordBackupError(backupOp.OperationBackup, entry) {
 				return
 			}
-			if len(backupOp.OperationBackup.Errors) > maxBackupErrorHistoryLength ||
-				slices.ContainsFunc(backupOp.OperationBackup.Errors, func(i *v1.BackupProgressError) bool {
-					return i.Item == backupError.Item
-				}) {
+		} else if entry.MessageType == "warning" {
+			l.Warn("restic printed a warning", zap.String("plan", t.PlanID()), zap.String("warning", entry.Message))
+			if !recordBackupWarning(backupOp.OperationBackup, entry.Message) {
 				return
 			}
-			backupOp.OperationBackup.Errors = append(backupOp.OperationBackup.Errors, backupError)
 		} else if entry.MessageType != "summary" {
 			zap.S().Warnf("unexpected message type %q in backup progress entry", entry.MessageType)
 		}
@@ -158,8 +583,31 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 			}
 			sendWg.Done()
 		}()
-	})
+	}
+
+	backup := func() (*restic.BackupProgressEntry, error) {
+		if src := plan.GetCommandSource(); src != nil {
+			return BackupCommandOutput(backupCtx, src.Command, planCommandEnv(plan), logging.WriterFromContext(ctx), func(ctx context.Context, stdin io.Reader) (*restic.BackupProgressEntry, error) {
+				return repo.Backup(ctx, plan, progressCallback, append(slices.Clone(backupOpts), restic.WithStdin(stdin, src.Filename))...)
+			})
+		}
+		return repo.Backup(backupCtx, plan, progressCallback, backupOpts...)
+	}
+
+	summary, err := backup()
 	sendWg.Wait()
+	if err != nil && backupCtx.Err() == nil && restic.ClassifyError(err) == restic.ErrorClassTransient {
+		// restic reuses data that was already uploaded and indexed, an immediate retry resumes the interrupted upload.
+		l.Warn("backup failed with a transient error, retrying once", zap.String("plan", plan.Id), zap.Error(err))
+		// the problems reported by the retry replace those of the failed attempt.
+		backupOp.OperationBackup.Errors, backupOp.OperationBackup.ErrorCount = nil, 0
+		backupOp.OperationBackup.Warnings, backupOp.OperationBackup.WarningCount = nil, 0
+		summary, err = backup()
+		sendWg.Wait()
+	}
+	if err != nil && ctx.Err() == nil && errors.Is(backupCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("backup timed out after %v and was stopped: %w", timeout, err)
+	}
 
 	if summary == nil {
 		summary = &restic.BackupProgressEntry{}
@@ -169,9 +617,27 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 		Task:          t.Name(),
 		SnapshotStats: summary,
 		SnapshotId:    summary.SnapshotId,
+		Annotations:   backupOp.OperationBackup.Annotations,
 	}
 	if err != nil {
 		vars.Error = err.Error()
+	}
+
+	var postCommandErr error
+	if cmd := plan.GetPostBackup(); cmd.GetCommand() != "" {
+		// the post backup command runs even if the backup failed, it typically undoes the work of the pre backup command.
+		if cmdErr := runner.ExecuteBackupCommand(ctx, "post_backup", cmd, v1.Hook_CONDITION_SNAPSHOT_END, vars); cmdErr != nil {
+			cmdErr = fmt.Errorf("post backup command: %w", cmdErr)
+			if cmd.OnFailure == v1.BackupCommand_FAILURE_POLICY_ABORT {
+				postCommandErr = cmdErr
+			} else {
+				l.Warn("post backup command failed", zap.String("plan", plan.Id), zap.Error(cmdErr))
+				commandWarnings = append(commandWarnings, cmdErr.Error())
+			}
+		}
+	}
+
+	if err != nil {
 		if !errors.Is(err, restic.ErrPartialBackup) {
 			runner.ExecuteHooks([]v1.Hook_Condition{
 				v1.Hook_CONDITION_SNAPSHOT_ERROR,
@@ -179,13 +645,86 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 			}, vars)
 			return err
 		} else {
-			vars.Error = fmt.Sprintf("partial backup, %d files may not have been read completely.", len(backupOp.OperationBackup.Errors))
+			vars.Error = fmt.Sprintf("partial backup, %d files may not have been read completely.", backupOp.OperationBackup.ErrorCount)
 			runner.ExecuteHooks([]v1.Hook_Condition{
 				v1.Hook_CONDITION_SNAPSHOT_WARNING,
 			}, vars)
 		}
 		op.Status = v1.OperationStatus_STATUS_WARNING
-		op.DisplayMessage = "Partial backup, some files may not have been read completely."
+		op.DisplayMessage = fmt.Sprintf("Partial backup, %d file(s) may not have been read completely.", backupOp.OperationBackup.ErrorCount)
+	} else if msg := backupProblemsMessage(backupOp.OperationBackup); msg != "" {
+		// restic succeeded but reported problems that may need attention, the backup isn't reported as a plain success.
+		vars.Error = msg
+		op.Status = v1.OperationStatus_STATUS_WARNING
+		op.DisplayMessage = msg
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_SNAPSHOT_WARNING,
+		}, vars)
+	}
+
+	if postCommandErr != nil {
+		// the snapshot was created so followup tasks are still scheduled, but the backup is reported as failed.
+		vars.Error = postCommandErr.Error()
+		op.Status = v1.OperationStatus_STATUS_ERROR
+		op.DisplayMessage = vars.Error
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_SNAPSHOT_ERROR,
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, vars)
+	} else if len(commandWarnings) > 0 {
+		vars.Error = strings.Join(commandWarnings, "; ")
+		if op.Status != v1.OperationStatus_STATUS_WARNING {
+			op.Status = v1.OperationStatus_STATUS_WARNING
+			op.DisplayMessage = vars.Error
+		}
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_SNAPSHOT_WARNING,
+		}, vars)
+	}
+
+	if err == nil && plan.SkipIfUnchanged && backupUnchanged(summary) {
+		// restic versions without --skip-if-unchanged still create a snapshot, it's identical to its parent.
+		if summary.SnapshotId != "" {
+			if err := repo.ForgetSnapshot(ctx, summary.SnapshotId); err != nil {
+				return fmt.Errorf("forget unchanged snapshot %q: %w", summary.SnapshotId, err)
+			}
+			summary.SnapshotId = ""
+		}
+		backupOp.OperationBackup.Unchanged = true
+		backupOp.OperationBackup.LastStatus = protoutil.BackupProgressEntryToProto(summary)
+		if op.DisplayMessage == "" {
+			op.DisplayMessage = "No changes since the last snapshot, no snapshot was kept."
+		}
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_SNAPSHOT_END,
+		}, vars)
+		l.Info("backup found no changes", zap.String("plan", plan.Id), zap.Duration("duration", time.Since(startTime)))
+		if op.Status != v1.OperationStatus_STATUS_WARNING {
+			return scheduleChainedBackups(runner, plan.Id, time.Now())
+		}
+		return nil
+	}
+
+	if criteria := plan.GetSuccessCriteria(); criteria != nil {
+		if err := checkSuccessCriteria(criteria, summary, func(path string) ([]*v1.LsEntry, error) {
+			return repo.ListSnapshotFiles(ctx, summary.SnapshotId, path)
+		}); err != nil {
+			l.Warn("backup did not meet success criteria", zap.String("plan", plan.Id), zap.Error(err))
+			vars.Error = fmt.Sprintf("backup did not meet success criteria: %v", err)
+			op.DisplayMessage = vars.Error
+			if criteria.OnFailure == v1.SuccessCriteria_ON_FAILURE_ERROR {
+				op.Status = v1.OperationStatus_STATUS_ERROR
+				runner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_SNAPSHOT_ERROR,
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, vars)
+			} else {
+				op.Status = v1.OperationStatus_STATUS_WARNING
+				runner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_SNAPSHOT_WARNING,
+				}, vars)
+			}
+		}
 	}
 
 	runner.ExecuteHooks([]v1.Hook_Condition{
@@ -210,6 +749,79 @@ func (t *BackupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunne
 	if err := runner.ScheduleTask(NewOneoffIndexSnapshotsTask(t.RepoID(), at), TaskPriorityIndexSnapshots); err != nil {
 		return fmt.Errorf("failed to schedule index snapshots task: %w", err)
 	}
+	if plan.CopyTo.GetRepo() != "" && plan.CopyTo.GetCron() == "" {
+		if err := runner.ScheduleTask(NewOneoffCopyTask(plan, op.FlowId, at), TaskPriorityDefault); err != nil {
+			return fmt.Errorf("failed to schedule copy task: %w", err)
+		}
+	}
+	if op.Status != v1.OperationStatus_STATUS_ERROR && op.Status != v1.OperationStatus_STATUS_WARNING {
+		if err := scheduleChainedBackups(runner, plan.Id, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+// recordBackupError counts the error entry of restic's backup output and keeps it unless enough errors are kept or one
+// was already kept for the same item. It returns true if the error was kept.
+func recordBackupError(b *v1.OperationBackup, entry *restic.BackupProgressEntry) bool {
+	b.ErrorCount++
+	backupError, err := protoutil.BackupProgressEntryToBackupError(entry)
+	if err != nil {
+		zap.S().Errorf("failed to convert backup progress entry to backup error: %v", err)
+		return false
+	}
+	if len(b.Errors) >= maxBackupErrorHistoryLength || slices.ContainsFunc(b.Errors, func(i *v1.BackupProgressError) bool {
+		return i.Item == backupError.Item
+	}) {
+		return false
+	}
+	b.Errors = append(b.Errors, backupError)
+	return true
+}
+
+// recordBackupWarning counts a warning restic printed during the backup and keeps it unless enough warnings are kept or
+// it's a repeat. It returns true if the warning was kept.
+func recordBackupWarning(b *v1.OperationBackup, warning string) bool {
+	b.WarningCount++
+	if len(b.Warnings) >= maxBackupErrorHistoryLength || slices.Contains(b.Warnings, warning) {
+		return false
+	}
+	b.Warnings = append(b.Warnings, warning)
+	return true
+}
+
+// backupProblemsMessage summarizes the errors and warnings restic reported during a backup that otherwise succeeded,
+// it returns "" if there were none.
+func backupProblemsMessage(b *v1.OperationBackup) string {
+	var problems []string
+	if b.ErrorCount > 0 {
+		problems = append(problems, fmt.Sprintf("%d item(s) could not be read", b.ErrorCount))
+	}
+	if b.WarningCount > 0 {
+		problems = append(problems, fmt.Sprintf("restic printed %d warning(s), the first: %v", b.WarningCount, b.Warnings[0]))
+	}
+	if len(problems) == 0 {
+		return ""
+	}
+	return "Backup completed with problems: " + strings.Join(problems, "; ") + "."
+}
+
+// scheduleChainedBackups schedules a backup of each plan that runs after the plan, it's called once the plan's backup
+// succeeded. Disabled and paused plans are skipped.
+func scheduleChainedBackups(runner TaskRunner, planID string, at time.Time) error {
+	for _, plan := range runner.Config().GetPlans() {
+		if !slices.Contains(plan.After, planID) || plan.Disabled || plan.Paused != nil {
+			continue
+		}
+		if repo, err := runner.GetRepo(plan.Repo); err != nil || repo.Paused != nil {
+			continue
+		}
+		zap.L().Info("scheduling backup of chained plan", zap.String("plan", plan.Id), zap.String("after", planID))
+		if err := runner.ScheduleTask(NewOneoffBackupTask(plan, at), TaskPriorityDefault); err != nil {
+			return fmt.Errorf("failed to schedule backup of chained plan %q: %w", plan.Id, err)
+		}
+	}
 	return nil
 }
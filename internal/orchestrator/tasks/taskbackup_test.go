@@ -0,0 +1,444 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/pkg/restic"
+)
+
+func TestCatchupMissedRun(t *testing.T) {
+	t.Parallel()
+
+	sched, err := cronutil.Parse("0 3 * * *") // daily at 3am
+	if err != nil {
+		t.Fatalf("failed to parse cron: %v", err)
+	}
+
+	lastRun := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		policy  v1.Plan_CatchupPolicy
+		window  time.Duration
+		curTime time.Time
+		want    bool
+	}{
+		{
+			name:    "skip policy never catches up",
+			policy:  v1.Plan_CATCHUP_POLICY_SKIP,
+			curTime: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		{
+			name:    "run immediately with no missed run",
+			policy:  v1.Plan_CATCHUP_POLICY_RUN_IMMEDIATELY,
+			curTime: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		{
+			name:    "run immediately after missed run",
+			policy:  v1.Plan_CATCHUP_POLICY_RUN_IMMEDIATELY,
+			curTime: time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "run within window",
+			policy:  v1.Plan_CATCHUP_POLICY_RUN_ONCE_WITHIN_WINDOW,
+			window:  8 * time.Hour,
+			curTime: time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "missed run outside of window",
+			policy:  v1.Plan_CATCHUP_POLICY_RUN_ONCE_WITHIN_WINDOW,
+			window:  1 * time.Hour,
+			curTime: time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := catchupMissedRun(sched, tc.policy, tc.window, lastRun, tc.curTime)
+			if got != tc.want {
+				t.Errorf("catchupMissedRun() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackupJitter(t *testing.T) {
+	t.Parallel()
+
+	plan := &v1.Plan{Id: "plan", Repo: "repo", Cron: "0 2 * * *", JitterMinutes: 30}
+	task, err := NewScheduledBackupTask(plan)
+	if err != nil {
+		t.Fatalf("NewScheduledBackupTask() error: %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	due := time.Date(2024, 1, 2, 2, 0, 0, 0, time.Local)
+	for i := 0; i < 20; i++ {
+		st := task.Next(now, nil)
+		if st.RunAt.Before(due) || !st.RunAt.Before(due.Add(30*time.Minute)) {
+			t.Fatalf("expected the run to be within 30 minutes after %v, got %v", due, st.RunAt)
+		}
+		backup := st.Op.GetOperationBackup()
+		if backup.GetScheduledTimeMs() != st.RunAt.UnixMilli() || due.Add(time.Duration(backup.GetJitterMs())*time.Millisecond).UnixMilli() != st.RunAt.UnixMilli() {
+			t.Errorf("expected the scheduled time and jitter to be recorded, got %v for run at %v", backup, st.RunAt)
+		}
+	}
+
+	task, err = NewScheduledBackupTask(&v1.Plan{Id: "plan", Repo: "repo", Cron: "0 2 * * *"})
+	if err != nil {
+		t.Fatalf("NewScheduledBackupTask() error: %v", err)
+	}
+	if st := task.Next(now, nil); !st.RunAt.Equal(due) {
+		t.Errorf("expected a plan without jitter to run at %v, got %v", due, st.RunAt)
+	}
+
+	task, err = NewScheduledBackupTask(&v1.Plan{Id: "plan", Repo: "repo", Schedule: &v1.Schedule{Interval: "4h"}})
+	if err != nil {
+		t.Fatalf("NewScheduledBackupTask() error: %v", err)
+	}
+	if st := task.Next(now, nil); !st.RunAt.Equal(now.Add(4 * time.Hour)) {
+		t.Errorf("expected an interval plan without a runner to run an interval from now, got %v", st.RunAt)
+	}
+}
+
+func TestNextIntervalRun(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	backup := func(status v1.OperationStatus, end time.Time) *v1.Operation {
+		return &v1.Operation{
+			RepoId:          "repo",
+			PlanId:          "plan",
+			UnixTimeStartMs: end.Add(-time.Minute).UnixMilli(),
+			UnixTimeEndMs:   end.UnixMilli(),
+			Status:          status,
+			Op:              &v1.Operation_OperationBackup{},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ops     []*v1.Operation
+		curTime time.Time
+		want    time.Time
+	}{
+		{
+			name:    "first backup runs immediately",
+			curTime: base,
+			want:    base,
+		},
+		{
+			name:    "interval after last success",
+			ops:     []*v1.Operation{backup(v1.OperationStatus_STATUS_SUCCESS, base)},
+			curTime: base.Add(time.Hour),
+			want:    base.Add(4 * time.Hour),
+		},
+		{
+			name:    "overdue backup runs immediately",
+			ops:     []*v1.Operation{backup(v1.OperationStatus_STATUS_SUCCESS, base)},
+			curTime: base.Add(5 * time.Hour),
+			want:    base.Add(5 * time.Hour),
+		},
+		{
+			name: "failure delays overdue backup",
+			ops: []*v1.Operation{
+				backup(v1.OperationStatus_STATUS_SUCCESS, base),
+				backup(v1.OperationStatus_STATUS_ERROR, base.Add(5*time.Hour)),
+			},
+			curTime: base.Add(5 * time.Hour),
+			want:    base.Add(6 * time.Hour),
+		},
+		{
+			name: "success after failure resets interval",
+			ops: []*v1.Operation{
+				backup(v1.OperationStatus_STATUS_ERROR, base),
+				backup(v1.OperationStatus_STATUS_WARNING, base.Add(30*time.Minute)),
+			},
+			curTime: base.Add(time.Hour),
+			want:    base.Add(30*time.Minute + 4*time.Hour),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+			if err != nil {
+				t.Fatalf("failed to create oplog: %v", err)
+			}
+			t.Cleanup(func() { log.Close() })
+			for _, op := range tc.ops {
+				if err := log.Add(op); err != nil {
+					t.Fatalf("failed to add operation: %v", err)
+				}
+			}
+
+			got := nextIntervalRun(log, "plan", 4*time.Hour, tc.curTime)
+			if !got.Equal(tc.want) {
+				t.Errorf("nextIntervalRun() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckSuccessCriteria(t *testing.T) {
+	t.Parallel()
+
+	summary := &restic.BackupProgressEntry{
+		MessageType:         "summary",
+		TotalFilesProcessed: 10,
+		TotalBytesProcessed: 1000,
+		SnapshotId:          "snapshot",
+	}
+	listFiles := func(p string) ([]*v1.LsEntry, error) {
+		if p == "/data" {
+			return []*v1.LsEntry{{Path: "/data"}, {Path: "/data/file"}}, nil
+		}
+		return nil, nil
+	}
+
+	tests := []struct {
+		name     string
+		criteria *v1.SuccessCriteria
+		wantErr  bool
+	}{
+		{name: "empty criteria", criteria: &v1.SuccessCriteria{}, wantErr: false},
+		{name: "min files met", criteria: &v1.SuccessCriteria{MinFilesProcessed: 10}, wantErr: false},
+		{name: "min files not met", criteria: &v1.SuccessCriteria{MinFilesProcessed: 11}, wantErr: true},
+		{name: "min bytes not met", criteria: &v1.SuccessCriteria{MinBytesProcessed: 1001}, wantErr: true},
+		{name: "required path present", criteria: &v1.SuccessCriteria{RequiredPaths: []string{"/data/"}}, wantErr: false},
+		{name: "required path missing", criteria: &v1.SuccessCriteria{RequiredPaths: []string{"/other"}}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkSuccessCriteria(tc.criteria, summary, listFiles)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("checkSuccessCriteria() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteFilesFrom(t *testing.T) {
+	t.Parallel()
+
+	plan := &v1.Plan{
+		Id:   "plan",
+		Repo: "repo",
+		FilesFrom: &v1.FilesFrom{
+			Paths:   []string{"/static/path with spaces"},
+			Command: `printf '/generated/%s\n' "$BACKREST_PLAN_ID" ""`,
+		},
+	}
+
+	filesFrom, err := WriteFilesFrom(context.Background(), plan, nil)
+	if err != nil {
+		t.Fatalf("WriteFilesFrom() error: %v", err)
+	}
+	defer os.Remove(filesFrom)
+
+	data, err := os.ReadFile(filesFrom)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	want := "/static/path with spaces\n/generated/plan\n/generated/\n"
+	if string(data) != want {
+		t.Errorf("WriteFilesFrom() wrote %q, want %q", data, want)
+	}
+}
+
+func TestAnnotateFromFile(t *testing.T) {
+	t.Parallel()
+
+	base := []*v1.SnapshotAnnotation{{Key: "app-version", Value: "2.2"}, {Key: "trigger", Value: "deploy"}}
+	path := t.TempDir() + "/annotations"
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write annotations: %v", err)
+		}
+	}
+
+	write("# written by the pre backup command\napp-version=2.3\r\n\ngit-commit=abc123\n")
+	got, err := annotateFromFile(base, path)
+	if err != nil {
+		t.Fatalf("annotateFromFile() error: %v", err)
+	}
+	var pairs []string
+	for _, a := range got {
+		pairs = append(pairs, a.Key+"="+a.Value)
+	}
+	if want := "app-version=2.3 trigger=deploy git-commit=abc123"; strings.Join(pairs, " ") != want {
+		t.Errorf("annotateFromFile() = %v, want %v", pairs, want)
+	}
+	if flags := annotationTagFlags(got[:1]); strings.Join(flags, " ") != "--tag annotation:app-version=2.3" {
+		t.Errorf("unexpected tag flags %v", flags)
+	}
+
+	for _, content := range []string{"no separator\n", "bad key!=1\n", "key=a,b\n"} {
+		write(content)
+		if _, err := annotateFromFile(base, path); err == nil {
+			t.Errorf("expected annotations %q to be rejected", content)
+		}
+	}
+}
+
+func TestBackupCommandOutput(t *testing.T) {
+	t.Parallel()
+
+	// readAll reads stdin like restic, it stops without a snapshot if the backup is cancelled before the end of stdin.
+	readAll := func(got *string) func(ctx context.Context, stdin io.Reader) (*restic.BackupProgressEntry, error) {
+		return func(ctx context.Context, stdin io.Reader) (*restic.BackupProgressEntry, error) {
+			done := make(chan []byte, 1)
+			go func() {
+				data, _ := io.ReadAll(stdin)
+				done <- data
+			}()
+			select {
+			case data := <-done:
+				*got = string(data)
+				return &restic.BackupProgressEntry{SnapshotId: "snapshot"}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	var got string
+	summary, err := BackupCommandOutput(context.Background(), `echo "dump of $BACKREST_PLAN_ID"`, []string{"BACKREST_PLAN_ID=plan"}, nil, readAll(&got))
+	if err != nil {
+		t.Fatalf("BackupCommandOutput() error: %v", err)
+	}
+	if got != "dump of plan\n" || summary.SnapshotId != "snapshot" {
+		t.Errorf("BackupCommandOutput() backed up %q with summary %v", got, summary)
+	}
+
+	got = ""
+	_, err = BackupCommandOutput(context.Background(), "echo partial; exit 3", nil, nil, readAll(&got))
+	if err == nil || !strings.Contains(err.Error(), "code 3") {
+		t.Errorf("BackupCommandOutput() error = %v, want the command's exit code", err)
+	}
+	if got != "" {
+		t.Errorf("expected the backup to be stopped before the end of the failed command's output, it read %q", got)
+	}
+
+	_, err = BackupCommandOutput(context.Background(), "sleep 60", nil, nil, func(ctx context.Context, stdin io.Reader) (*restic.BackupProgressEntry, error) {
+		return nil, errors.New("repo unreachable")
+	})
+	if err == nil || err.Error() != "repo unreachable" {
+		t.Errorf("BackupCommandOutput() error = %v, want the backup's error", err)
+	}
+}
+
+func TestRecordBackupProblems(t *testing.T) {
+	b := &v1.OperationBackup{}
+	if msg := backupProblemsMessage(b); msg != "" {
+		t.Errorf("expected no message without problems, got %q", msg)
+	}
+
+	for i := 0; i < maxBackupErrorHistoryLength+5; i++ {
+		recordBackupError(b, &restic.BackupProgressEntry{
+			MessageType: "error",
+			Item:        fmt.Sprintf("/data/%d", i%(maxBackupErrorHistoryLength+2)),
+			Error:       map[string]any{"message": "permission denied"},
+		})
+	}
+	recordBackupWarning(b, "unable to open cache")
+	recordBackupWarning(b, "unable to open cache")
+
+	if b.ErrorCount != int64(maxBackupErrorHistoryLength+5) {
+		t.Errorf("expected every error to be counted, got %d", b.ErrorCount)
+	}
+	if len(b.Errors) != maxBackupErrorHistoryLength {
+		t.Errorf("expected %d errors kept, got %d", maxBackupErrorHistoryLength, len(b.Errors))
+	}
+	if b.Errors[0].Message != "permission denied" {
+		t.Errorf("expected the error's message to be kept, got %q", b.Errors[0].Message)
+	}
+	if b.WarningCount != 2 || len(b.Warnings) != 1 {
+		t.Errorf("expected 2 warnings counted and 1 kept, got %d and %v", b.WarningCount, b.Warnings)
+	}
+	want := "Backup completed with problems: 25 item(s) could not be read; restic printed 2 warning(s), the first: unable to open cache."
+	if msg := backupProblemsMessage(b); msg != want {
+		t.Errorf("backupProblemsMessage() = %q, want %q", msg, want)
+	}
+}
+
+// oplogRunner is a TaskRunner that only provides an oplog.
+type oplogRunner struct {
+	TaskRunner
+	log *oplog.OpLog
+}
+
+func (r *oplogRunner) OpLog() *oplog.OpLog {
+	return r.log
+}
+
+func TestScheduledBackupMinInterval(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name        string
+		minInterval string
+		want        time.Time
+		wantReason  bool
+	}{
+		{
+			name: "default min interval",
+			want: base.Add(10 * time.Minute),
+		},
+		{
+			name:        "deferred by min interval",
+			minInterval: "1h",
+			want:        base.Add(time.Hour),
+			wantReason:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+			if err != nil {
+				t.Fatalf("failed to create oplog: %v", err)
+			}
+			t.Cleanup(func() { log.Close() })
+			if err := log.Add(&v1.Operation{
+				RepoId:          "repo",
+				PlanId:          "plan",
+				UnixTimeStartMs: base.UnixMilli(),
+				UnixTimeEndMs:   base.Add(time.Minute).UnixMilli(),
+				Status:          v1.OperationStatus_STATUS_SUCCESS,
+				Op:              &v1.Operation_OperationBackup{},
+			}); err != nil {
+				t.Fatalf("failed to add operation: %v", err)
+			}
+
+			task, err := NewScheduledBackupTask(&v1.Plan{Id: "plan", Repo: "repo", Cron: "*/10 * * * *", MinInterval: tc.minInterval})
+			if err != nil {
+				t.Fatalf("NewScheduledBackupTask() error = %v", err)
+			}
+			got, reason := task.scheduler(base.Add(5*time.Minute), &oplogRunner{log: log})
+			if got == nil || !got.Equal(tc.want) {
+				t.Errorf("next run = %v, want %v", got, tc.want)
+			}
+			if (reason != "") != tc.wantReason {
+				t.Errorf("unexpected reason %q", reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"go.uber.org/zap"
+)
+
+const defaultCacheCleanupCron = "@daily"
+
+// CacheCleanupTask removes old caches from a repo's cache dir with restic cache --cleanup on a schedule, and clears the
+// repo's cache once it grows beyond the repo's max cache size.
+type CacheCleanupTask struct {
+	BaseTask
+	sched *cronutil.Schedule
+}
+
+var _ Task = &CacheCleanupTask{}
+
+// NewScheduledCacheCleanupTask returns a task cleaning up the repo's cache on its cache cleanup schedule, daily if it
+// has none.
+func NewScheduledCacheCleanupTask(repo *v1.Repo) (*CacheCleanupTask, error) {
+	expr := repo.CacheCleanupCron
+	if expr == "" {
+		expr = defaultCacheCleanupCron
+	}
+	sched, err := cronutil.ParseInLocation(expr, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cache cleanup schedule %q: %w", expr, err)
+	}
+
+	return &CacheCleanupTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("scheduled cache cleanup for repo %q", repo.Id),
+			TaskRepoID: repo.Id,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		sched: sched,
+	}, nil
+}
+
+func (t *CacheCleanupTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	return ScheduledTask{
+		Task:  t,
+		RunAt: t.sched.Next(now),
+	}
+}
+
+func (t *CacheCleanupTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	if err := cacheCleanupHelper(ctx, st, runner); err != nil {
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, hook.HookVars{
+			Task:  st.Task.Name(),
+			Error: err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+func cacheCleanupHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("get repo %q: %w", t.RepoID(), err)
+	}
+
+	usage, err := repo.CleanupCache(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cleanup cache: %w", err)
+	}
+	zap.L().Info("cleaned up repo cache", zap.String("repo", t.RepoID()), zap.String("cache_dir", usage.CacheDir), zap.Int64("size_bytes", usage.SizeBytes))
+	return nil
+}
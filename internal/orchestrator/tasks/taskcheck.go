@@ -0,0 +1,104 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"go.uber.org/zap"
+)
+
+func NewOneoffCheckTask(repoID, planID string, flowID int64, at time.Time) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("check for repo %q", repoID),
+			TaskRepoID: repoID,
+			TaskPlanID: planID,
+		},
+		OneoffTask: OneoffTask{
+			FlowID: flowID,
+			RunAt:  at,
+			ProtoOp: &v1.Operation{
+				Op: &v1.Operation_OperationCheck{},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if err := checkHelper(ctx, st, taskRunner); err != nil {
+				taskRunner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  st.Task.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func checkHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+	op := st.Op
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	opCheck := &v1.Operation_OperationCheck{
+		OperationCheck: &v1.OperationCheck{},
+	}
+	op.Op = opCheck
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	interval := time.NewTicker(1 * time.Second)
+	defer interval.Stop()
+	var buf synchronizedBuffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-interval.C:
+				output := truncateCheckOutput(buf.String())
+				if opCheck.OperationCheck.Output != output {
+					opCheck.OperationCheck.Output = output
+
+					if err := taskRunner.OpLog().Update(op); err != nil {
+						zap.L().Error("update check operation with status output", zap.Error(err))
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err = repo.Check(ctx, &buf)
+	cancel()
+	wg.Wait()
+
+	opCheck.OperationCheck.Output = truncateCheckOutput(buf.String())
+	if err != nil {
+		return fmt.Errorf("check: %w", err)
+	}
+	return nil
+}
+
+// truncateCheckOutput keeps only the first 8K of check output, checks of large repos can produce a lot of output.
+func truncateCheckOutput(output string) string {
+	if len(output) > 8*1024 {
+		return output[:8*1024]
+	}
+	return output
+}
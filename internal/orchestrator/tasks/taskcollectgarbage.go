@@ -6,34 +6,50 @@ import (
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"go.uber.org/zap"
 )
 
 const (
-	gcStartupDelay = 5 * time.Second
-	gcInterval     = 24 * time.Hour
-	// keep operations that are eligible for gc for 30 days OR up to a limit of 100 for any one plan.
-	// an operation is eligible for gc if:
-	// - it has no snapshot associated with it
-	// - it has a forgotten snapshot associated with it
-	gcHistoryAge      = 30 * 24 * time.Hour
-	gcHistoryMaxCount = 1000
-	// keep stats operations for 1 year (they're small and useful for long term trends)
+	gcStartupDelay            = 5 * time.Second
+	defaultOplogRetentionCron = "@daily"
+	// keep operations that are eligible for gc for 30 days unless the oplog retention policy says otherwise.
+	// an operation is eligible for gc if it has no snapshot associated with it, operations of snapshots no longer in the
+	// repo are removed once the oplog retention policy's orphan grace period passed.
+	gcHistoryAge = 30 * 24 * time.Hour
+	// keep stats operations for at least 1 year (they're small and useful for long term trends)
 	gcHistoryStatsAge = 365 * 24 * time.Hour
 )
 
+// CollectGarbageTask removes operations from the oplog according to the config's oplog retention policy and compacts
+// the oplog's database once operations are removed.
 type CollectGarbageTask struct {
 	BaseTask
-	firstRun bool
+	retention *v1.OplogRetention
+	sched     *cronutil.Schedule
+	firstRun  bool
 }
 
-func NewCollectGarbageTask() *CollectGarbageTask {
+// NewCollectGarbageTask returns a task collecting garbage shortly after it's scheduled and then on the retention
+// policy's schedule, it is rescheduled with the new config whenever the config changes.
+func NewCollectGarbageTask(cfg *v1.Config) (*CollectGarbageTask, error) {
+	expr := cfg.GetOplogRetention().GetCron()
+	if expr == "" {
+		expr = defaultOplogRetentionCron
+	}
+	sched, err := cronutil.ParseInLocation(expr, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse oplog retention schedule %q: %w", expr, err)
+	}
+
 	return &CollectGarbageTask{
 		BaseTask: BaseTask{
 			TaskName: "collect garbage",
 		},
-	}
+		retention: cfg.GetOplogRetention(),
+		sched:     sched,
+	}, nil
 }
 
 var _ Task = &CollectGarbageTask{}
@@ -48,64 +64,141 @@ func (t *CollectGarbageTask) Next(now time.Time, runner TaskRunner) ScheduledTas
 		}
 	}
 
-	runAt := now.Add(gcInterval)
 	return ScheduledTask{
 		Task:  t,
-		RunAt: runAt,
+		RunAt: t.sched.Next(now),
 	}
 }
 
 func (t *CollectGarbageTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
 	oplog := runner.OpLog()
 
-	if err := t.gcOperations(oplog); err != nil {
+	removed, err := gcOperations(oplog, t.retention, time.Now())
+	if err != nil {
 		return fmt.Errorf("collecting garbage: %w", err)
 	}
+	if removed == 0 {
+		return nil
+	}
 
+	start := time.Now()
+	if err := oplog.Compact(); err != nil {
+		return fmt.Errorf("compacting oplog: %w", err)
+	}
+	zap.L().Info("compacted oplog", zap.Duration("duration", time.Since(start)))
 	return nil
 }
 
-func (t *CollectGarbageTask) gcOperations(oplog *oplog.OpLog) error {
-	// snapshotForgottenForFlow returns whether the snapshot associated with the flow is forgotten
-	snapshotForgottenForFlow := make(map[int64]bool)
-	if err := oplog.ForAll(func(op *v1.Operation) error {
-		if snapshotOp, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok {
-			snapshotForgottenForFlow[op.FlowId] = snapshotOp.OperationIndexSnapshot.Forgot
+// gcOperations removes the operations the retention policy doesn't keep and returns the number removed.
+func gcOperations(log *oplog.OpLog, retention *v1.OplogRetention, now time.Time) (int, error) {
+	maxAge := gcHistoryAge
+	if retention.GetKeepDays() > 0 {
+		maxAge = time.Duration(retention.GetKeepDays()) * 24 * time.Hour
+	}
+	maxStatsAge := max(maxAge, gcHistoryStatsAge)
+
+	// snapshotForFlow is the index snapshot operation of the snapshot associated with the flow.
+	snapshotForFlow := make(map[int64]*v1.Operation)
+	if err := log.ForAll(func(op *v1.Operation) error {
+		if _, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok {
+			snapshotForFlow[op.FlowId] = op
 		}
 		return nil
 	}); err != nil {
-		return fmt.Errorf("identifying forgotten snapshots: %w", err)
+		return 0, fmt.Errorf("identifying forgotten snapshots: %w", err)
 	}
 
-	forgetIDs := []int64{}
-	curTime := curTimeMillis()
-	if err := oplog.ForAll(func(op *v1.Operation) error {
-		forgot, ok := snapshotForgottenForFlow[op.FlowId]
-		if !ok {
-			// no snapshot associated with this flow; check if it's old enough to be gc'd
-			maxAgeForType := gcHistoryAge.Milliseconds()
-			if _, isStats := op.Op.(*v1.Operation_OperationStats); isStats {
-				maxAgeForType = gcHistoryStatsAge.Milliseconds()
-			}
-			if curTime-op.UnixTimeStartMs > maxAgeForType {
-				forgetIDs = append(forgetIDs, op.Id)
+	var ops []gcOpInfo
+	lastSuccess := make(map[gcKindKey]int64)
+	lastFailure := make(map[gcKindKey]int64)
+	if err := log.ForAll(func(op *v1.Operation) error {
+		info := gcOpInfo{
+			id:        op.Id,
+			planID:    op.PlanId,
+			timestamp: op.UnixTimeStartMs,
+		}
+		_, info.isStats = op.Op.(*v1.Operation_OperationStats)
+		if indexOp, ok := snapshotForFlow[op.FlowId]; ok {
+			// operations of snapshots still in the repo are kept, those of forgotten snapshots are orphaned.
+			if indexOp.GetOperationIndexSnapshot().GetForgot() {
+				info.orphaned = true
+				info.orphanedMs = indexOp.OrphanedMs
+			} else {
+				info.keep = true
 			}
-		} else if forgot {
-			// snapshot is forgotten; this operation is eligible for gc
-			forgetIDs = append(forgetIDs, op.Id)
+		}
+		if op.OrphanedMs != 0 {
+			info.keep = false
+			info.orphaned = true
+			info.orphanedMs = op.OrphanedMs
+		}
+		ops = append(ops, info)
+
+		// operations are iterated in ascending order of ID so later operations replace earlier ones.
+		key := gcKindKey{repoID: op.RepoId, planID: op.PlanId, kind: fmt.Sprintf("%T", op.Op)}
+		switch op.Status {
+		case v1.OperationStatus_STATUS_SUCCESS, v1.OperationStatus_STATUS_WARNING:
+			lastSuccess[key] = op.Id
+		case v1.OperationStatus_STATUS_ERROR:
+			lastFailure[key] = op.Id
 		}
 		return nil
 	}); err != nil {
-		return fmt.Errorf("identifying gc eligible operations: %w", err)
+		return 0, fmt.Errorf("identifying gc eligible operations: %w", err)
+	}
+
+	protected := make(map[int64]bool)
+	for _, id := range lastSuccess {
+		protected[id] = true
 	}
+	for _, id := range lastFailure {
+		protected[id] = true
+	}
+
+	orphanGrace := time.Duration(retention.GetOrphanGraceDays()) * 24 * time.Hour
+
+	forgetIDs := []int64{}
+	keptPerPlan := make(map[string]int)
+	// newest first so that the per plan limit keeps the newest operations.
+	for i := len(ops) - 1; i >= 0; i-- {
+		info := ops[i]
+		if info.keep || protected[info.id] {
+			keptPerPlan[info.planID]++
+			continue
+		}
+
+		if info.orphaned && !retention.GetKeepOrphaned() {
+			// operations marked orphaned before the grace period was recorded, orphanedMs 0, are past it.
+			if now.UnixMilli()-info.orphanedMs >= orphanGrace.Milliseconds() {
+				forgetIDs = append(forgetIDs, info.id)
+			} else {
+				keptPerPlan[info.planID]++
+			}
+			continue
+		}
 
-	if err := oplog.Delete(forgetIDs...); err != nil {
-		return fmt.Errorf("removing gc eligible operations: %w", err)
+		maxAgeForType := maxAge
+		if info.isStats {
+			maxAgeForType = maxStatsAge
+		}
+		if now.UnixMilli()-info.timestamp > maxAgeForType.Milliseconds() {
+			forgetIDs = append(forgetIDs, info.id)
+			continue
+		}
+		if limit := int(retention.GetKeepPerPlan()); limit > 0 && info.planID != PlanForUnassociatedOperations && keptPerPlan[info.planID] >= limit {
+			forgetIDs = append(forgetIDs, info.id)
+			continue
+		}
+		keptPerPlan[info.planID]++
+	}
+
+	if err := log.Delete(forgetIDs...); err != nil {
+		return 0, fmt.Errorf("removing gc eligible operations: %w", err)
 	}
 
 	zap.L().Info("collecting garbage",
 		zap.Any("operations_removed", len(forgetIDs)))
-	return nil
+	return len(forgetIDs), nil
 }
 
 func (t *CollectGarbageTask) Cancel(withStatus v1.OperationStatus) error {
@@ -117,7 +210,19 @@ func (t *CollectGarbageTask) OperationId() int64 {
 }
 
 type gcOpInfo struct {
-	id        int64 // operation ID
-	timestamp int64 // unix time milliseconds
-	isStats   bool  // true if this is a stats operation
+	id         int64  // operation ID
+	planID     string // plan of the operation
+	timestamp  int64  // unix time milliseconds
+	isStats    bool   // true if this is a stats operation
+	keep       bool   // true if the operation belongs to a snapshot still in the repo
+	orphaned   bool   // true if the operation belongs to a snapshot no longer in the repo
+	orphanedMs int64  // unix time milliseconds the snapshot was found missing, 0 if unknown
+}
+
+// gcKindKey identifies a plan's operations of one kind e.g. its backups, or a repo's operations of one kind not run
+// for a plan e.g. its stats.
+type gcKindKey struct {
+	repoID string
+	planID string
+	kind   string
 }
@@ -0,0 +1,143 @@
+package tasks
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+func TestGcOperations(t *testing.T) {
+	t.Parallel()
+
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	add := func(planID string, age time.Duration, status v1.OperationStatus, op *v1.Operation) *v1.Operation {
+		op.RepoId = "repo"
+		op.PlanId = planID
+		op.InstanceId = "instance"
+		op.Status = status
+		op.UnixTimeStartMs = now.Add(-age).UnixMilli()
+		if err := log.Add(op); err != nil {
+			t.Fatalf("error adding operation: %v", err)
+		}
+		return op
+	}
+	backup := func() *v1.Operation {
+		return &v1.Operation{Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}}}
+	}
+	day := 24 * time.Hour
+
+	snapshotID := strings.Repeat("a", 64)
+	snapshotBackup := add("plan", 40*day, v1.OperationStatus_STATUS_SUCCESS, &v1.Operation{SnapshotId: snapshotID, Op: &v1.Operation_OperationBackup{}})
+	add("plan", 40*day, v1.OperationStatus_STATUS_SUCCESS, &v1.Operation{
+		SnapshotId: snapshotID,
+		FlowId:     snapshotBackup.FlowId,
+		Op: &v1.Operation_OperationIndexSnapshot{OperationIndexSnapshot: &v1.OperationIndexSnapshot{
+			Snapshot: &v1.ResticSnapshot{Id: snapshotID},
+		}},
+	})
+	lastFailure := add("plan", 20*day, v1.OperationStatus_STATUS_ERROR, backup())
+	oldSuccess := add("plan", 15*day, v1.OperationStatus_STATUS_SUCCESS, backup())
+	lastSuccess := add("plan", 12*day, v1.OperationStatus_STATUS_SUCCESS, backup())
+	stats := add(PlanForUnassociatedOperations, 20*day, v1.OperationStatus_STATUS_SUCCESS, &v1.Operation{Op: &v1.Operation_OperationStats{}})
+	var recent []*v1.Operation
+	for i := 0; i < 3; i++ {
+		recent = append(recent, add("plan", time.Duration(3-i)*time.Hour, v1.OperationStatus_STATUS_USER_CANCELLED, backup()))
+	}
+	otherPlan := add("other", time.Hour, v1.OperationStatus_STATUS_USER_CANCELLED, &v1.Operation{Op: &v1.Operation_OperationPrune{}})
+
+	removed, err := gcOperations(log, &v1.OplogRetention{KeepDays: 10, KeepPerPlan: 1}, now)
+	if err != nil {
+		t.Fatalf("gcOperations() error: %v", err)
+	}
+
+	var remaining []int64
+	log.ForAll(func(op *v1.Operation) error {
+		remaining = append(remaining, op.Id)
+		return nil
+	})
+	// the plan keeps the snapshot's flow, its last success and failure regardless of their age and its newest other
+	// operation. Operations not run for a plan aren't limited by count.
+	for _, op := range []*v1.Operation{lastFailure, lastSuccess, stats, snapshotBackup, recent[2], otherPlan} {
+		if !slices.Contains(remaining, op.Id) {
+			t.Errorf("expected operation %v to be kept", op)
+		}
+	}
+	for _, op := range []*v1.Operation{oldSuccess, recent[0], recent[1]} {
+		if slices.Contains(remaining, op.Id) {
+			t.Errorf("expected operation %v to be removed", op)
+		}
+	}
+	if removed != 3 {
+		t.Errorf("gcOperations() removed %d operations, want 3", removed)
+	}
+}
+
+func TestGcOrphanedOperations(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	newLog := func(t *testing.T) (*oplog.OpLog, map[string]*v1.Operation) {
+		log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+		if err != nil {
+			t.Fatalf("error creating oplog: %v", err)
+		}
+		t.Cleanup(func() { log.Close() })
+		add := func(orphanedAge time.Duration, op *v1.Operation) *v1.Operation {
+			op.RepoId = "repo"
+			op.PlanId = "plan"
+			op.Status = v1.OperationStatus_STATUS_USER_CANCELLED // not protected as the plan's last success or failure.
+			op.UnixTimeStartMs = now.Add(-day).UnixMilli()
+			if orphanedAge > 0 {
+				op.OrphanedMs = now.Add(-orphanedAge).UnixMilli()
+			}
+			if err := log.Add(op); err != nil {
+				t.Fatalf("error adding operation: %v", err)
+			}
+			return op
+		}
+		ops := make(map[string]*v1.Operation)
+		ops["recent"] = add(2*day, &v1.Operation{SnapshotId: strings.Repeat("r", 64), Op: &v1.Operation_OperationRestore{}})
+		ops["old"] = add(10*day, &v1.Operation{SnapshotId: strings.Repeat("o", 64), Op: &v1.Operation_OperationRestore{}})
+		// the flow of a snapshot found forgotten before orphaned operations were marked.
+		ops["legacy"] = add(0, &v1.Operation{SnapshotId: strings.Repeat("l", 64), Op: &v1.Operation_OperationBackup{}})
+		add(0, &v1.Operation{SnapshotId: strings.Repeat("l", 64), FlowId: ops["legacy"].FlowId, Status: v1.OperationStatus_STATUS_SUCCESS, Op: &v1.Operation_OperationIndexSnapshot{
+			OperationIndexSnapshot: &v1.OperationIndexSnapshot{Snapshot: &v1.ResticSnapshot{Id: strings.Repeat("l", 64)}, Forgot: true},
+		}})
+		return log, ops
+	}
+
+	tcs := []struct {
+		name      string
+		retention *v1.OplogRetention
+		kept      []string
+	}{
+		{name: "no grace", retention: &v1.OplogRetention{}, kept: nil},
+		{name: "grace", retention: &v1.OplogRetention{OrphanGraceDays: 7}, kept: []string{"recent"}},
+		{name: "keep orphaned", retention: &v1.OplogRetention{OrphanGraceDays: 7, KeepOrphaned: true}, kept: []string{"recent", "old", "legacy"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			log, ops := newLog(t)
+			if _, err := gcOperations(log, tc.retention, now); err != nil {
+				t.Fatalf("gcOperations() error: %v", err)
+			}
+			for name, op := range ops {
+				_, err := log.Get(op.Id)
+				if kept := err == nil; kept != slices.Contains(tc.kept, name) {
+					t.Errorf("operation %q kept: %v, want %v", name, kept, !kept)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"go.uber.org/zap"
+)
+
+// CopyTask copies a plan's snapshots to the repo named by the plan's copy_to config. The task runs in the destination
+// repo's queue and its operations are recorded against the destination repo.
+type CopyTask struct {
+	BaseTask
+	flowID    int64
+	scheduler func(curTime time.Time) *time.Time
+}
+
+var _ Task = &CopyTask{}
+
+func NewScheduledCopyTask(plan *v1.Plan) (*CopyTask, error) {
+	sched, err := cronutil.ParseInLocation(plan.GetCopyTo().GetCron(), time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse copy schedule %q: %w", plan.GetCopyTo().GetCron(), err)
+	}
+
+	return &CopyTask{
+		BaseTask: copyBaseTask(plan),
+		scheduler: func(curTime time.Time) *time.Time {
+			next := sched.Next(curTime)
+			return &next
+		},
+	}, nil
+}
+
+func NewOneoffCopyTask(plan *v1.Plan, flowID int64, at time.Time) *CopyTask {
+	didOnce := false
+	return &CopyTask{
+		BaseTask: copyBaseTask(plan),
+		flowID:   flowID,
+		scheduler: func(curTime time.Time) *time.Time {
+			if didOnce {
+				return nil
+			}
+			didOnce = true
+			return &at
+		},
+	}
+}
+
+func copyBaseTask(plan *v1.Plan) BaseTask {
+	return BaseTask{
+		TaskName:   fmt.Sprintf("copy for plan %q to repo %q", plan.Id, plan.GetCopyTo().GetRepo()),
+		TaskRepoID: plan.GetCopyTo().GetRepo(),
+		TaskPlanID: plan.Id,
+	}
+}
+
+func (t *CopyTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	next := t.scheduler(now)
+	if next == nil {
+		return NeverScheduledTask
+	}
+
+	return ScheduledTask{
+		Task:  t,
+		RunAt: *next,
+		Op: &v1.Operation{
+			PlanId:          t.PlanID(),
+			RepoId:          t.RepoID(),
+			FlowId:          t.flowID,
+			UnixTimeStartMs: (*next).UnixMilli(),
+			Status:          v1.OperationStatus_STATUS_PENDING,
+			Op:              &v1.Operation_OperationCopy{},
+		},
+	}
+}
+
+func (t *CopyTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	if err := t.runHelper(ctx, st, runner); err != nil {
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, hook.HookVars{
+			Task:  t.Name(),
+			Error: err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+func (t *CopyTask) runHelper(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	op := st.Op
+
+	plan, err := runner.GetPlan(t.PlanID())
+	if err != nil {
+		return err
+	}
+	source, err := runner.GetRepo(plan.Repo)
+	if err != nil {
+		return fmt.Errorf("couldn't get source repo %q: %w", plan.Repo, err)
+	}
+	dest, err := runner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := dest.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	opCopy := &v1.Operation_OperationCopy{
+		OperationCopy: &v1.OperationCopy{
+			SourceRepo: plan.Repo,
+		},
+	}
+	op.Op = opCopy
+
+	copied, err := dest.CopyFrom(ctx, source, plan)
+	if err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+	for _, c := range copied {
+		opCopy.OperationCopy.CopiedSnapshots = append(opCopy.OperationCopy.CopiedSnapshots, &v1.CopiedSnapshot{
+			SourceId:      c.SourceID,
+			DestinationId: c.DestinationID,
+		})
+	}
+	zap.L().Info("copied snapshots", zap.String("plan", plan.Id), zap.String("source", plan.Repo), zap.String("destination", t.RepoID()), zap.Int("count", len(copied)))
+
+	// index the destination so that the copies appear under it.
+	if len(copied) > 0 {
+		if err := runner.ScheduleTask(NewOneoffIndexSnapshotsTask(t.RepoID(), time.Now()), TaskPriorityIndexSnapshots); err != nil {
+			return fmt.Errorf("failed to schedule index snapshots task: %w", err)
+		}
+	}
+	return nil
+}
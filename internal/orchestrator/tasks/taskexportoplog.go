@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/oplogexport"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultOplogExportCron = "@daily"
+
+// OplogExportTag tags the snapshots of oplog exports backed up to a repo.
+const OplogExportTag = "backrest-oplog-export"
+
+// ExportOplogTask exports the operation history and config to an archive in the directory and/or repo from the
+// config's oplog export settings.
+type ExportOplogTask struct {
+	BaseTask
+	config *v1.Config
+	sched  *cronutil.Schedule
+}
+
+var _ Task = &ExportOplogTask{}
+
+// NewExportOplogTask returns a task exporting the oplog, it is rescheduled with the new config whenever the config
+// changes.
+func NewExportOplogTask(cfg *v1.Config) (*ExportOplogTask, error) {
+	expr := cfg.GetOplogExport().GetCron()
+	if expr == "" {
+		expr = defaultOplogExportCron
+	}
+	sched, err := cronutil.ParseInLocation(expr, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse oplog export schedule %q: %w", expr, err)
+	}
+
+	return &ExportOplogTask{
+		BaseTask: BaseTask{
+			TaskName: "export oplog",
+		},
+		config: proto.Clone(cfg).(*v1.Config),
+		sched:  sched,
+	}, nil
+}
+
+func (t *ExportOplogTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	return ScheduledTask{
+		Task:  t,
+		RunAt: t.sched.Next(now),
+	}
+}
+
+func (t *ExportOplogTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	export := t.config.GetOplogExport()
+	archive, err := oplogexport.Build(t.config, runner.OpLog(), time.Now())
+	if err != nil {
+		return fmt.Errorf("build archive: %w", err)
+	}
+
+	// archives only backed up to a repo are staged in a temporary directory and removed once backed up.
+	dir := export.Path
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "backrest-oplog-export")
+	}
+	path, err := oplogexport.WriteFile(dir, archive)
+	if err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	if export.Path == "" {
+		defer os.Remove(path)
+	} else if export.KeepLast > 0 {
+		if err := oplogexport.Prune(dir, archive.Instance, int(export.KeepLast)); err != nil {
+			zap.L().Error("failed to remove old oplog exports", zap.String("path", dir), zap.Error(err))
+		}
+	}
+
+	if export.Repo != "" {
+		repo, err := runner.GetRepoOrchestrator(export.Repo)
+		if err != nil {
+			return fmt.Errorf("get repo %q: %w", export.Repo, err)
+		}
+		if _, err := repo.BackupFiles(ctx, []string{path}, OplogExportTag); err != nil {
+			return fmt.Errorf("backup archive to repo %q: %w", export.Repo, err)
+		}
+	}
+	zap.L().Info("exported oplog", zap.String("path", path), zap.String("repo", export.Repo), zap.Int("operations", len(archive.Operations)))
+	return nil
+}
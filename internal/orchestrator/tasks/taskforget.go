@@ -79,10 +79,9 @@ func forgetHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner)
 			"restic tag --set '%s' --set '%s' --tag '%s'", repo.TagForPlan(t.PlanID()), repo.TagForInstance(taskRunner.Config().Instance), repo.TagForPlan(t.PlanID()))
 	}
 
-	// check if any other instance IDs exist in the repo (unassociated don't count)
-	forgot, err := r.Forget(ctx, plan, tags)
+	repoCfg, err := taskRunner.GetRepo(t.RepoID())
 	if err != nil {
-		return fmt.Errorf("forget: %w", err)
+		return fmt.Errorf("get repo %q: %w", t.RepoID(), err)
 	}
 
 	forgetOp := &v1.Operation_OperationForget{
@@ -90,6 +89,18 @@ func forgetHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner)
 	}
 	st.Op.Op = forgetOp
 
+	var forgot []*v1.ResticSnapshot
+	if cooldown := time.Duration(repoCfg.GetPrunePolicy().GetCooldownDays()) * 24 * time.Hour; cooldown > 0 {
+		var retained []*v1.ResticSnapshot
+		forgot, retained, err = forgetAfterCooldown(ctx, taskRunner, r, plan, tags, cooldown, time.Now())
+		forgetOp.OperationForget.Retained = retained
+	} else {
+		forgot, err = r.Forget(ctx, plan, tags)
+	}
+	if err != nil {
+		return fmt.Errorf("forget: %w", err)
+	}
+
 	forgetOp.OperationForget.Forget = append(forgetOp.OperationForget.Forget, forgot...)
 	forgetOp.OperationForget.Policy = plan.Retention
 
@@ -108,6 +119,7 @@ func forgetHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner)
 	for _, op := range ops {
 		if indexOp, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok {
 			indexOp.OperationIndexSnapshot.Forgot = true
+			op.OrphanedMs = time.Now().UnixMilli()
 			if e := taskRunner.UpdateOperation(op); err != nil {
 				err = multierror.Append(err, fmt.Errorf("mark index snapshot %v as forgotten: %w", op.Id, e))
 				continue
@@ -124,6 +136,72 @@ func forgetHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner)
 	return err
 }
 
+// forgetAfterCooldown forgets the snapshots the plan's retention policy selected for removal at least cooldown ago, the
+// time each snapshot was first selected is recorded on its index snapshot operation. It returns the snapshots it forgot
+// and those it retained. Snapshots that are no longer selected e.g. because the policy was fixed are unmarked.
+func forgetAfterCooldown(ctx context.Context, taskRunner TaskRunner, r *repo.RepoOrchestrator, plan *v1.Plan, tags []string, cooldown time.Duration, now time.Time) (forgot, retained []*v1.ResticSnapshot, err error) {
+	due, err := r.SnapshotsDueForForget(ctx, plan, tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	dueIDs := make(map[string]*v1.ResticSnapshot, len(due))
+	for _, snapshot := range due {
+		dueIDs[snapshot.Id] = snapshot
+	}
+
+	var indexOps []*v1.Operation
+	if err := taskRunner.OpLog().ForEachByPlan(plan.Id, indexutil.CollectAll(), func(op *v1.Operation) error {
+		if _, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok {
+			indexOps = append(indexOps, op)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("list index snapshot operations: %w", err)
+	}
+
+	indexed := make(map[string]bool)
+	for _, op := range indexOps {
+		indexOp := op.GetOperationIndexSnapshot()
+		snapshot, ok := dueIDs[indexOp.GetSnapshot().GetId()]
+		if !ok {
+			if indexOp.ForgetDueMs != 0 && !indexOp.Forgot {
+				indexOp.ForgetDueMs = 0
+				if err := taskRunner.UpdateOperation(op); err != nil {
+					return nil, nil, fmt.Errorf("unmark snapshot %v: %w", indexOp.GetSnapshot().GetId(), err)
+				}
+			}
+			continue
+		}
+		if indexed[snapshot.Id] {
+			continue
+		}
+		indexed[snapshot.Id] = true
+
+		if indexOp.ForgetDueMs == 0 {
+			indexOp.ForgetDueMs = now.UnixMilli()
+			if err := taskRunner.UpdateOperation(op); err != nil {
+				return nil, nil, fmt.Errorf("mark snapshot %v as due for forget: %w", snapshot.Id, err)
+			}
+		}
+		if now.Sub(time.UnixMilli(indexOp.ForgetDueMs)) < cooldown {
+			retained = append(retained, snapshot)
+			continue
+		}
+		if err := r.ForgetSnapshot(ctx, snapshot.Id); err != nil {
+			return forgot, retained, fmt.Errorf("forget snapshot %v: %w", snapshot.Id, err)
+		}
+		forgot = append(forgot, snapshot)
+	}
+
+	// a snapshot that isn't indexed yet can't be marked, it's kept until it is.
+	for _, snapshot := range due {
+		if !indexed[snapshot.Id] {
+			retained = append(retained, snapshot)
+		}
+	}
+	return forgot, retained, nil
+}
+
 // useLegacyCompatMode checks if there are any snapshots that were created without a `created-by` tag still exist in the repo.
 // The property is overridden if mixed `created-by` tag values are found.
 func useLegacyCompatMode(oplog *oplog.OpLog, planID string) (bool, error) {
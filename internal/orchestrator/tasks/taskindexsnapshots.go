@@ -8,6 +8,7 @@ import (
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
 	"github.com/garethgeorge/backrest/internal/hook"
 	"github.com/garethgeorge/backrest/internal/oplog"
 	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
@@ -22,24 +23,64 @@ func NewOneoffIndexSnapshotsTask(repoID string, at time.Time) Task {
 		BaseTask: BaseTask{
 			TaskName:   fmt.Sprintf("index snapshots for repo %q", repoID),
 			TaskRepoID: repoID,
+			TaskPlanID: PlanForUnassociatedOperations,
 		},
 		OneoffTask: OneoffTask{
 			RunAt:   at,
 			ProtoOp: nil,
 		},
-		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
-			if err := indexSnapshotsHelper(ctx, st, taskRunner); err != nil {
-				taskRunner.ExecuteHooks([]v1.Hook_Condition{
-					v1.Hook_CONDITION_ANY_ERROR,
-				}, hook.HookVars{
-					Task:  st.Task.Name(),
-					Error: err.Error(),
-				})
-				return err
-			}
-			return nil
+		Do: runIndexSnapshots,
+	}
+}
+
+// IndexSnapshotsTask indexes a repo's snapshots on a schedule so that snapshots created outside of backrest are found
+// without waiting for the next backup.
+type IndexSnapshotsTask struct {
+	BaseTask
+	sched *cronutil.Schedule
+}
+
+var _ Task = &IndexSnapshotsTask{}
+
+// NewScheduledIndexSnapshotsTask returns a task indexing the repo's snapshots on its index schedule.
+func NewScheduledIndexSnapshotsTask(repo *v1.Repo) (*IndexSnapshotsTask, error) {
+	sched, err := cronutil.ParseInLocation(repo.IndexCron, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse index schedule %q: %w", repo.IndexCron, err)
+	}
+
+	return &IndexSnapshotsTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("scheduled index snapshots for repo %q", repo.Id),
+			TaskRepoID: repo.Id,
+			TaskPlanID: PlanForUnassociatedOperations,
 		},
+		sched: sched,
+	}, nil
+}
+
+func (t *IndexSnapshotsTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	return ScheduledTask{
+		Task:  t,
+		RunAt: t.sched.Next(now),
+	}
+}
+
+func (t *IndexSnapshotsTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	return runIndexSnapshots(ctx, st, runner)
+}
+
+func runIndexSnapshots(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	if err := indexSnapshotsHelper(ctx, st, taskRunner); err != nil {
+		taskRunner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, hook.HookVars{
+			Task:  st.Task.Name(),
+			Error: err.Error(),
+		})
+		return err
 	}
+	return nil
 }
 
 // indexSnapshotsHelper indexes all snapshots for a plan.
@@ -122,6 +163,26 @@ func indexSnapshotsHelper(ctx context.Context, st ScheduledTask, taskRunner Task
 		return fmt.Errorf("BulkAdd snapshot operations: %w", err)
 	}
 
+	// new snapshots that this instance didn't create were made by another tool or instance. The first index of a repo
+	// finds all of its existing snapshots, they are not reported.
+	if len(currentIds) > 0 {
+		instance := taskRunner.Config().Instance
+		for _, op := range indexOps {
+			if op.InstanceId == instance {
+				continue
+			}
+			zap.L().Info("found snapshot created outside of this instance", zap.String("repo", t.RepoID()), zap.String("snapshot", op.SnapshotId))
+			if err := taskRunner.ExecuteHooks([]v1.Hook_Condition{
+				v1.Hook_CONDITION_SNAPSHOT_EXTERNAL,
+			}, hook.HookVars{
+				Task:       t.Name(),
+				SnapshotId: op.SnapshotId,
+			}); err != nil {
+				zap.L().Error("failed to run external snapshot hooks", zap.String("snapshot", op.SnapshotId), zap.Error(err))
+			}
+		}
+	}
+
 	// Mark missing operations as newly forgotten.
 	for id, opId := range currentIds {
 		if _, ok := foundIds[id]; ok {
@@ -141,6 +202,7 @@ func indexSnapshotsHelper(ctx context.Context, st ScheduledTask, taskRunner Task
 			return fmt.Errorf("operation %v is not an index snapshot operation", opId)
 		}
 		snapshotOp.OperationIndexSnapshot.Forgot = true
+		op.OrphanedMs = time.Now().UnixMilli()
 
 		if err := oplog.Update(op); err != nil {
 			return fmt.Errorf("mark index snapshot operation %v as forgotten: %w", opId, err)
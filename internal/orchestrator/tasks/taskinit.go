@@ -0,0 +1,60 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook"
+)
+
+func NewOneoffInitTask(repoID string, at time.Time) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("init repo %q", repoID),
+			TaskRepoID: repoID,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		OneoffTask: OneoffTask{
+			RunAt: at,
+			ProtoOp: &v1.Operation{
+				Op: &v1.Operation_OperationInit{},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if err := initHelper(ctx, st, taskRunner); err != nil {
+				taskRunner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  st.Task.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func initHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	opInit := &v1.Operation_OperationInit{
+		OperationInit: &v1.OperationInit{},
+	}
+	st.Op.Op = opInit
+
+	var buf synchronizedBuffer
+	err = repo.Init(ctx, &buf)
+	opInit.OperationInit.Output = truncateCheckOutput(buf.String())
+	if err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"go.uber.org/zap"
+)
+
+// DefaultMigration is the restic migration applied when none is given, it upgrades a repo to the v2 format which
+// supports compression.
+const DefaultMigration = "upgrade_repo_v2"
+
+func NewOneoffMigrateTask(repoID, migration string, at time.Time) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("migrate repo %q with %q", repoID, migration),
+			TaskRepoID: repoID,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		OneoffTask: OneoffTask{
+			RunAt: at,
+			ProtoOp: &v1.Operation{
+				Op: &v1.Operation_OperationMigrate{
+					OperationMigrate: &v1.OperationMigrate{
+						Migration: migration,
+					},
+				},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if err := migrateHelper(ctx, st, taskRunner, migration); err != nil {
+				taskRunner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  st.Task.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func migrateHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner, migration string) error {
+	t := st.Task
+	op := st.Op
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	opMigrate := &v1.Operation_OperationMigrate{
+		OperationMigrate: &v1.OperationMigrate{
+			Migration: migration,
+		},
+	}
+	op.Op = opMigrate
+
+	var buf synchronizedBuffer
+	err = withOutputUpdates(ctx, taskRunner, op, &buf, opMigrate.OperationMigrate, func(ctx context.Context) error {
+		return repo.Migrate(ctx, migration, &buf)
+	})
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	return nil
+}
+
+// NewOneoffUpgradeTask upgrades the repo to the v2 format if it's older and, if recompress is set, then repacks the data
+// stored uncompressed. The task is resumed when backrest next starts if it's interrupted, both steps pick up where they
+// stopped when run again.
+func NewOneoffUpgradeTask(repoID string, recompress bool, at time.Time) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("upgrade repo %q", repoID),
+			TaskRepoID: repoID,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		OneoffTask: OneoffTask{
+			RunAt: at,
+			ProtoOp: &v1.Operation{
+				Op: &v1.Operation_OperationMigrate{
+					OperationMigrate: &v1.OperationMigrate{
+						Recompress: recompress,
+					},
+				},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if err := upgradeHelper(ctx, st, taskRunner, recompress); err != nil {
+				taskRunner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  st.Task.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// IsUpgrade returns whether the operation was created by an upgrade task. Upgrades only name their migration once they
+// apply it, and then record their phase, plain migrations always name theirs and have no phase.
+func IsUpgrade(op *v1.Operation) bool {
+	migrate := op.GetOperationMigrate()
+	return migrate != nil && (migrate.Migration == "" || migrate.Phase != v1.OperationMigrate_PHASE_UNKNOWN)
+}
+
+func upgradeHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner, recompress bool) error {
+	t := st.Task
+	op := st.Op
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	opMigrate := &v1.OperationMigrate{Recompress: recompress}
+	op.Op = &v1.Operation_OperationMigrate{OperationMigrate: opMigrate}
+	setPhase := func(phase v1.OperationMigrate_Phase) {
+		opMigrate.Phase = phase
+		if err := taskRunner.OpLog().Update(op); err != nil {
+			zap.L().Error("update upgrade operation with phase", zap.Error(err))
+		}
+	}
+
+	var buf synchronizedBuffer
+	version, err := repo.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version < 2 {
+		opMigrate.Migration = DefaultMigration
+		setPhase(v1.OperationMigrate_PHASE_MIGRATE)
+		if err := withOutputUpdates(ctx, taskRunner, op, &buf, opMigrate, func(ctx context.Context) error {
+			return repo.Migrate(ctx, DefaultMigration, &buf)
+		}); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+	} else {
+		fmt.Fprintf(&buf, "repo is already at version %d, no migration needed.\n", version)
+	}
+
+	if recompress {
+		setPhase(v1.OperationMigrate_PHASE_RECOMPRESS)
+		if err := withOutputUpdates(ctx, taskRunner, op, &buf, opMigrate, func(ctx context.Context) error {
+			return repo.Recompress(ctx, &buf)
+		}); err != nil {
+			return fmt.Errorf("recompress: %w", err)
+		}
+	}
+	opMigrate.Phase = v1.OperationMigrate_PHASE_DONE
+	return nil
+}
+
+// withOutputUpdates runs do, saving the output it writes to buf in the migrate operation every second so that its
+// progress can be followed.
+func withOutputUpdates(ctx context.Context, taskRunner TaskRunner, op *v1.Operation, buf *synchronizedBuffer, opMigrate *v1.OperationMigrate, do func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	interval := time.NewTicker(1 * time.Second)
+	defer interval.Stop()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-interval.C:
+				output := truncateCheckOutput(buf.String())
+				if opMigrate.Output != output {
+					opMigrate.Output = output
+
+					if err := taskRunner.OpLog().Update(op); err != nil {
+						zap.L().Error("update migrate operation with status output", zap.Error(err))
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err := do(ctx)
+	cancel()
+	wg.Wait()
+
+	opMigrate.Output = truncateCheckOutput(buf.String())
+	return err
+}
@@ -0,0 +1,118 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"go.uber.org/zap"
+)
+
+const defaultReconcileOplogCron = "@daily"
+
+// ReconcileOplogTask marks the operations of snapshots that are no longer in the repo as orphaned on a schedule, e.g.
+// snapshots forgotten by another tool or instance. Orphaned operations are removed by the collect garbage task once the
+// oplog retention policy's grace period passed.
+type ReconcileOplogTask struct {
+	BaseTask
+	sched *cronutil.Schedule
+}
+
+var _ Task = &ReconcileOplogTask{}
+
+// NewScheduledReconcileOplogTask returns a task reconciling the repo's operations with its snapshots daily.
+func NewScheduledReconcileOplogTask(repo *v1.Repo) (*ReconcileOplogTask, error) {
+	sched, err := cronutil.ParseInLocation(defaultReconcileOplogCron, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reconcile schedule %q: %w", defaultReconcileOplogCron, err)
+	}
+
+	return &ReconcileOplogTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("reconcile oplog for repo %q", repo.Id),
+			TaskRepoID: repo.Id,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		sched: sched,
+	}, nil
+}
+
+func (t *ReconcileOplogTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	return ScheduledTask{
+		Task:  t,
+		RunAt: t.sched.Next(now),
+	}
+}
+
+func (t *ReconcileOplogTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	if err := reconcileOplogHelper(ctx, st, runner); err != nil {
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, hook.HookVars{
+			Task:  st.Task.Name(),
+			Error: err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+func reconcileOplogHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("get repo %q: %w", t.RepoID(), err)
+	}
+	snapshots, err := repo.Snapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("get snapshots for repo %q: %w", t.RepoID(), err)
+	}
+	snapshotIDs := make(map[string]bool, len(snapshots))
+	for _, s := range snapshots {
+		snapshotIDs[s.Id] = true
+	}
+
+	orphaned, err := reconcileOplog(taskRunner.OpLog(), t.RepoID(), snapshotIDs, time.Now())
+	if err != nil {
+		return fmt.Errorf("reconcile oplog: %w", err)
+	}
+	if orphaned > 0 {
+		zap.L().Info("marked operations of snapshots no longer in the repo as orphaned", zap.String("repo", t.RepoID()), zap.Int("orphaned", orphaned))
+	}
+	return nil
+}
+
+// reconcileOplog marks the repo's finished operations whose snapshot isn't one of snapshotIDs as orphaned at now and
+// returns the number newly marked. Index snapshot operations are also marked forgotten.
+func reconcileOplog(log *oplog.OpLog, repoID string, snapshotIDs map[string]bool, now time.Time) (int, error) {
+	var orphaned []*v1.Operation
+	if err := log.ForEachByRepo(repoID, indexutil.CollectAll(), func(op *v1.Operation) error {
+		if op.SnapshotId == "" || op.OrphanedMs != 0 || snapshotIDs[op.SnapshotId] {
+			return nil
+		}
+		if op.Status == v1.OperationStatus_STATUS_PENDING || op.Status == v1.OperationStatus_STATUS_INPROGRESS {
+			return nil
+		}
+		orphaned = append(orphaned, op)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	for _, op := range orphaned {
+		op.OrphanedMs = now.UnixMilli()
+		if indexOp, ok := op.Op.(*v1.Operation_OperationIndexSnapshot); ok && indexOp.OperationIndexSnapshot != nil {
+			indexOp.OperationIndexSnapshot.Forgot = true
+		}
+		if err := log.Update(op); err != nil {
+			return 0, fmt.Errorf("mark operation %v orphaned: %w", op.Id, err)
+		}
+	}
+	return len(orphaned), nil
+}
@@ -0,0 +1,75 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+func TestReconcileOplog(t *testing.T) {
+	t.Parallel()
+
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	kept, gone := strings.Repeat("a", 64), strings.Repeat("b", 64)
+	add := func(op *v1.Operation) *v1.Operation {
+		op.RepoId = "repo"
+		op.PlanId = "plan"
+		op.UnixTimeStartMs = now.Add(-time.Hour).UnixMilli()
+		if op.Status == v1.OperationStatus_STATUS_UNKNOWN {
+			op.Status = v1.OperationStatus_STATUS_SUCCESS
+		}
+		if err := log.Add(op); err != nil {
+			t.Fatalf("error adding operation: %v", err)
+		}
+		return op
+	}
+	indexed := func(id string) *v1.Operation {
+		return add(&v1.Operation{SnapshotId: id, Op: &v1.Operation_OperationIndexSnapshot{OperationIndexSnapshot: &v1.OperationIndexSnapshot{
+			Snapshot: &v1.ResticSnapshot{Id: id},
+		}}})
+	}
+
+	keptIndex := indexed(kept)
+	keptRestore := add(&v1.Operation{SnapshotId: kept, Op: &v1.Operation_OperationRestore{}})
+	goneIndex := indexed(gone)
+	goneBackup := add(&v1.Operation{SnapshotId: gone, Op: &v1.Operation_OperationBackup{}})
+	running := add(&v1.Operation{SnapshotId: gone, Status: v1.OperationStatus_STATUS_INPROGRESS, Op: &v1.Operation_OperationRestore{}})
+	noSnapshot := add(&v1.Operation{Op: &v1.Operation_OperationPrune{}})
+
+	snapshotIDs := map[string]bool{kept: true}
+	orphaned, err := reconcileOplog(log, "repo", snapshotIDs, now)
+	if err != nil {
+		t.Fatalf("reconcileOplog() error: %v", err)
+	}
+	if orphaned != 2 {
+		t.Errorf("expected 2 operations marked orphaned, got %d", orphaned)
+	}
+
+	for _, op := range []*v1.Operation{keptIndex, keptRestore, running, noSnapshot} {
+		if got, _ := log.Get(op.Id); got.OrphanedMs != 0 {
+			t.Errorf("expected operation %v not to be marked orphaned", op.Id)
+		}
+	}
+	for _, op := range []*v1.Operation{goneIndex, goneBackup} {
+		if got, _ := log.Get(op.Id); got.OrphanedMs != now.UnixMilli() {
+			t.Errorf("expected operation %v to be marked orphaned at %v, got %v", op.Id, now.UnixMilli(), got.OrphanedMs)
+		}
+	}
+	if got, _ := log.Get(goneIndex.Id); !got.GetOperationIndexSnapshot().GetForgot() {
+		t.Errorf("expected the index operation of the missing snapshot to be marked forgotten")
+	}
+
+	// operations already marked keep the time they were first found orphaned.
+	if orphaned, err := reconcileOplog(log, "repo", snapshotIDs, now.Add(time.Hour)); err != nil || orphaned != 0 {
+		t.Errorf("expected no operations newly marked orphaned, got %d %v", orphaned, err)
+	}
+}
@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"go.uber.org/zap"
+)
+
+// NewOneoffRecoverTask returns a task that runs restic recover on the repo and indexes the snapshot it saves. Recover
+// only finds the data of forgotten snapshots that wasn't removed by a prune.
+func NewOneoffRecoverTask(repoID string, at time.Time) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("recover repo %q", repoID),
+			TaskRepoID: repoID,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		OneoffTask: OneoffTask{
+			RunAt: at,
+			ProtoOp: &v1.Operation{
+				Op: &v1.Operation_OperationRecover{},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if err := recoverHelper(ctx, st, taskRunner); err != nil {
+				taskRunner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  st.Task.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func recoverHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+	op := st.Op
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	opRecover := &v1.Operation_OperationRecover{
+		OperationRecover: &v1.OperationRecover{},
+	}
+	op.Op = opRecover
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	interval := time.NewTicker(1 * time.Second)
+	defer interval.Stop()
+	var buf synchronizedBuffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-interval.C:
+				output := truncateCheckOutput(buf.String())
+				if opRecover.OperationRecover.Output != output {
+					opRecover.OperationRecover.Output = output
+
+					if err := taskRunner.OpLog().Update(op); err != nil {
+						zap.L().Error("update recover operation with status output", zap.Error(err))
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	result, err := repo.Recover(ctx, &buf)
+	cancel()
+	wg.Wait()
+
+	opRecover.OperationRecover.Output = truncateCheckOutput(buf.String())
+	if err != nil {
+		return fmt.Errorf("recover: %w", err)
+	}
+
+	opRecover.OperationRecover.Roots = int32(result.Roots)
+	opRecover.OperationRecover.SnapshotId = result.SnapshotID
+	if result.SnapshotID == "" {
+		op.DisplayMessage = "Nothing to recover, the data of forgotten snapshots was already pruned or no snapshot was forgotten."
+		return nil
+	}
+	op.SnapshotId = result.SnapshotID
+	op.DisplayMessage = fmt.Sprintf("Recovered %d directories into snapshot %v, restore files from its /recover directory. The original snapshot times, hosts and tags are not recovered.", result.Roots, result.SnapshotID[:8])
+
+	if err := taskRunner.ScheduleTask(NewOneoffIndexSnapshotsTask(t.RepoID(), time.Now()), TaskPriorityIndexSnapshots); err != nil {
+		return fmt.Errorf("schedule index snapshots task: %w", err)
+	}
+	return nil
+}
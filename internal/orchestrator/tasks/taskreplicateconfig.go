@@ -0,0 +1,70 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/replica"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultReplicationCron = "@hourly"
+
+// ReplicateConfigTask pushes an encrypted replica of the config and recent operations to the destination from the
+// config's replication settings.
+type ReplicateConfigTask struct {
+	BaseTask
+	config *v1.Config // the config as written by the user, repo group settings are not resolved into its repos.
+	sched  *cronutil.Schedule
+}
+
+var _ Task = &ReplicateConfigTask{}
+
+// NewReplicateConfigTask returns a task replicating the config, it is rescheduled with the new config whenever the
+// config changes.
+func NewReplicateConfigTask(cfg *v1.Config) (*ReplicateConfigTask, error) {
+	expr := cfg.GetConfigReplication().GetCron()
+	if expr == "" {
+		expr = defaultReplicationCron
+	}
+	sched, err := cronutil.ParseInLocation(expr, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse replication schedule %q: %w", expr, err)
+	}
+
+	return &ReplicateConfigTask{
+		BaseTask: BaseTask{
+			TaskName: "replicate config",
+		},
+		config: proto.Clone(cfg).(*v1.Config),
+		sched:  sched,
+	}, nil
+}
+
+func (t *ReplicateConfigTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	return ScheduledTask{
+		Task:  t,
+		RunAt: t.sched.Next(now),
+	}
+}
+
+func (t *ReplicateConfigTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	replication := t.config.GetConfigReplication()
+	r, err := replica.Build(t.config, runner.OpLog())
+	if err != nil {
+		return fmt.Errorf("build replica: %w", err)
+	}
+	sealed, err := replica.Seal(r, replication.Passphrase, time.Now())
+	if err != nil {
+		return fmt.Errorf("seal replica: %w", err)
+	}
+	if err := replica.Push(ctx, replication.Destination, sealed); err != nil {
+		return fmt.Errorf("push replica: %w", err)
+	}
+	zap.L().Info("replicated config", zap.String("destination", replication.Destination), zap.Int("operations", len(r.Operations)))
+	return nil
+}
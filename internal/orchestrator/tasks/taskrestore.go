@@ -4,15 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
 	"github.com/garethgeorge/backrest/internal/hook"
+	"github.com/garethgeorge/backrest/internal/orchestrator/repo"
 	"go.uber.org/zap"
 )
 
 func NewOneoffRestoreTask(repoID, planID string, flowID int64, at time.Time, snapshotID, path, target string) Task {
+	return newOneoffRestoreTask(repoID, planID, flowID, at, snapshotID, path, target, false, false)
+}
+
+// NewOneoffInPlaceRestoreTask returns a task restoring path from the snapshot to its original location, overwriting
+// the local files. If swap is set the files are restored next to path first and swapped in once the restore succeeded,
+// see swapRestore.
+func NewOneoffInPlaceRestoreTask(repoID, planID string, flowID int64, at time.Time, snapshotID, path string, swap bool) Task {
+	return newOneoffRestoreTask(repoID, planID, flowID, at, snapshotID, path, "/", true, swap)
+}
+
+func newOneoffRestoreTask(repoID, planID string, flowID int64, at time.Time, snapshotID, path, target string, inPlace, swap bool) Task {
 	return &GenericOneoffTask{
 		BaseTask: BaseTask{
 			TaskName:   fmt.Sprintf("restore snapshot %q in repo %q", snapshotID, repoID),
@@ -26,8 +42,10 @@ func NewOneoffRestoreTask(repoID, planID string, flowID int64, at time.Time, sna
 				SnapshotId: snapshotID,
 				Op: &v1.Operation_OperationRestore{
 					OperationRestore: &v1.OperationRestore{
-						Path:   path,
-						Target: target,
+						Path:    path,
+						Target:  target,
+						InPlace: inPlace,
+						Swap:    swap,
 					},
 				},
 			},
@@ -68,7 +86,7 @@ func restoreHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner,
 
 	var sendWg sync.WaitGroup
 	lastSent := time.Now() // debounce progress updates, these can endup being very frequent.
-	summary, err := repo.Restore(ctx, snapshotID, path, target, func(entry *v1.RestoreProgressEntry) {
+	progress := func(entry *v1.RestoreProgressEntry) {
 		sendWg.Wait()
 		if time.Since(lastSent) < 1*time.Second {
 			return
@@ -86,8 +104,14 @@ func restoreHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner,
 			}
 			sendWg.Done()
 		}()
-	})
+	}
 
+	var summary *v1.RestoreProgressEntry
+	if restoreOp.Swap {
+		summary, err = swapRestore(ctx, repo, op.Id, snapshotID, path, progress)
+	} else {
+		summary, err = repo.Restore(ctx, snapshotID, path, target, progress)
+	}
 	if err != nil {
 		return fmt.Errorf("restore failed: %w", err)
 	}
@@ -95,3 +119,79 @@ func restoreHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner,
 
 	return nil
 }
+
+// swapRestore restores path from the snapshot to a staging directory next to it, on the same filesystem, and swaps the
+// restored files in once the restore succeeded. The staging directory is checked for space and access problems before
+// the restore starts. If the restore fails the staging directory is removed and path is left untouched.
+func swapRestore(ctx context.Context, r *repo.RepoOrchestrator, opID int64, snapshotID, path string, progress func(*v1.RestoreProgressEntry)) (*v1.RestoreProgressEntry, error) {
+	local := filepath.FromSlash(path)
+	if filepath.Dir(local) == local {
+		return nil, errors.New("the filesystem root can't be swapped")
+	}
+	staging := filepath.Join(filepath.Dir(local), fmt.Sprintf(".backrest-restore-%d", opID))
+	previous := filepath.Join(filepath.Dir(local), fmt.Sprintf(".backrest-previous-%d", opID))
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, fmt.Errorf("remove leftover staging directory: %w", err)
+	}
+
+	preview, err := r.PreviewRestore(ctx, snapshotID, path, staging)
+	if err != nil {
+		return nil, fmt.Errorf("check staging directory: %w", err)
+	}
+	var problems []string
+	for _, p := range preview.Problems {
+		switch p.Kind {
+		case v1.RestoreProblem_KIND_MISSING_PARENT, v1.RestoreProblem_KIND_OWNERSHIP:
+			// informational, the staging directory is created by the restore.
+		default:
+			problems = append(problems, fmt.Sprintf("%s: %s", p.Path, p.Message))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("can't stage the restore next to %q: %s", path, strings.Join(problems, "; "))
+	}
+
+	defer func() {
+		if err := os.RemoveAll(staging); err != nil {
+			zap.S().Warnf("failed to remove restore staging directory %q: %v", staging, err)
+		}
+	}()
+	summary, err := r.Restore(ctx, snapshotID, path, staging, progress)
+	if err != nil {
+		return nil, err
+	}
+	if err := swapIntoPlace(local, filepath.Join(staging, local), previous); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(previous); err != nil {
+		zap.S().Warnf("failed to remove the files replaced by the restore from %q: %v", previous, err)
+	}
+	return summary, nil
+}
+
+// swapIntoPlace moves path, if it exists, aside to previous and moves restored to path. If restored can't be moved the
+// original files are moved back to path.
+func swapIntoPlace(path, restored, previous string) error {
+	movedAside := false
+	if _, err := os.Lstat(path); err == nil {
+		if err := os.Rename(path, previous); err != nil {
+			return fmt.Errorf("move %q aside: %w", path, err)
+		}
+		movedAside = true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	} else if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	if err := os.Rename(restored, path); err != nil {
+		err = fmt.Errorf("move restored files to %q: %w", path, err)
+		if movedAside {
+			if rollbackErr := os.Rename(previous, path); rollbackErr != nil {
+				return fmt.Errorf("%w, moving the original files back from %q also failed: %v", err, previous, rollbackErr)
+			}
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSwapIntoPlace(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data")
+	restored := filepath.Join(dir, "staging", "data")
+	previous := filepath.Join(dir, "previous")
+	writeFile(t, filepath.Join(path, "file"), "local")
+	writeFile(t, filepath.Join(path, "local-only"), "local")
+	writeFile(t, filepath.Join(restored, "file"), "restored")
+
+	if err := swapIntoPlace(path, restored, previous); err != nil {
+		t.Fatalf("swapIntoPlace() error: %v", err)
+	}
+	if got := readFile(t, filepath.Join(path, "file")); got != "restored" {
+		t.Errorf("expected the restored file in place, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(path, "local-only")); !os.IsNotExist(err) {
+		t.Errorf("expected files not in the snapshot to be swapped out, got %v", err)
+	}
+	if got := readFile(t, filepath.Join(previous, "file")); got != "local" {
+		t.Errorf("expected the original file to be moved aside, got %q", got)
+	}
+}
+
+func TestSwapIntoPlaceMissingPath(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "parent", "data")
+	restored := filepath.Join(dir, "staging", "data")
+	writeFile(t, filepath.Join(restored, "file"), "restored")
+
+	if err := swapIntoPlace(path, restored, filepath.Join(dir, "previous")); err != nil {
+		t.Fatalf("swapIntoPlace() error: %v", err)
+	}
+	if got := readFile(t, filepath.Join(path, "file")); got != "restored" {
+		t.Errorf("expected the restored file in place, got %q", got)
+	}
+}
+
+func TestSwapIntoPlaceRollsBack(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data")
+	previous := filepath.Join(dir, "previous")
+	writeFile(t, filepath.Join(path, "file"), "local")
+
+	// the restored files are missing, e.g. removed while the restore ran, so they can't be moved into place.
+	if err := swapIntoPlace(path, filepath.Join(dir, "staging", "data"), previous); err == nil {
+		t.Fatalf("expected an error moving missing restored files into place")
+	}
+	if got := readFile(t, filepath.Join(path, "file")); got != "local" {
+		t.Errorf("expected the original file to be moved back, got %q", got)
+	}
+	if _, err := os.Stat(previous); !os.IsNotExist(err) {
+		t.Errorf("expected nothing left aside after the rollback, got %v", err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	return string(data)
+}
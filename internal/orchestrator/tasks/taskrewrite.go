@@ -0,0 +1,113 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"go.uber.org/zap"
+)
+
+// NewOneoffRewriteTask returns a task removing the files matching the excludes from snapshots in the repo with restic
+// rewrite. restic saves the changed snapshots under new IDs, the repo's snapshots are indexed again afterwards.
+func NewOneoffRewriteTask(repoID, planID string, snapshotIDs []string, excludes []string, forget bool, at time.Time) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("rewrite snapshots in repo %q", repoID),
+			TaskRepoID: repoID,
+			TaskPlanID: planID,
+		},
+		OneoffTask: OneoffTask{
+			RunAt: at,
+			ProtoOp: &v1.Operation{
+				Op: &v1.Operation_OperationRewrite{
+					OperationRewrite: &v1.OperationRewrite{
+						SnapshotIds: snapshotIDs,
+						Excludes:    excludes,
+						Forget:      forget,
+					},
+				},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if err := rewriteHelper(ctx, st, taskRunner); err != nil {
+				taskRunner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  st.Task.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func rewriteHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+	op := st.Op
+	rewrite := op.GetOperationRewrite()
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	interval := time.NewTicker(1 * time.Second)
+	defer interval.Stop()
+	var buf synchronizedBuffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-interval.C:
+				output := truncateCheckOutput(buf.String())
+				if rewrite.Output != output {
+					rewrite.Output = output
+
+					if err := taskRunner.OpLog().Update(op); err != nil {
+						zap.L().Error("update rewrite operation with status output", zap.Error(err))
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	result, err := repo.Rewrite(ctx, rewrite.SnapshotIds, rewrite.Excludes, rewrite.Forget, &buf)
+	cancel()
+	wg.Wait()
+
+	rewrite.Output = truncateCheckOutput(buf.String())
+	if err != nil {
+		return fmt.Errorf("rewrite: %w", err)
+	}
+
+	rewrite.SnapshotsRewritten = int32(result.Rewritten)
+	if result.Rewritten == 0 {
+		op.DisplayMessage = "No snapshot held files matching the excludes, nothing was rewritten."
+		return nil
+	}
+	op.DisplayMessage = fmt.Sprintf("Rewrote %d snapshots, they are saved under new IDs.", result.Rewritten)
+	if rewrite.Forget {
+		op.DisplayMessage += " The original snapshots were forgotten, their data is removed by the next prune."
+	}
+
+	if err := taskRunner.ScheduleTask(NewOneoffIndexSnapshotsTask(t.RepoID(), time.Now()), TaskPriorityIndexSnapshots); err != nil {
+		return fmt.Errorf("schedule index snapshots task: %w", err)
+	}
+	return nil
+}
@@ -6,9 +6,61 @@ import (
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
 	"github.com/garethgeorge/backrest/internal/hook"
 )
 
+const defaultStatsCron = "@daily"
+
+// StatsTask collects the stats of a repo on a schedule, the results are kept in the oplog as a history of the repo's
+// size.
+type StatsTask struct {
+	BaseTask
+	sched *cronutil.Schedule
+}
+
+var _ Task = &StatsTask{}
+
+// NewScheduledStatsTask returns a task collecting the repo's stats on its stats schedule, daily if it has none.
+func NewScheduledStatsTask(repo *v1.Repo) (*StatsTask, error) {
+	expr := repo.StatsCron
+	if expr == "" {
+		expr = defaultStatsCron
+	}
+	sched, err := cronutil.ParseInLocation(expr, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stats schedule %q: %w", expr, err)
+	}
+
+	return &StatsTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("scheduled stats for repo %q", repo.Id),
+			TaskRepoID: repo.Id,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		sched: sched,
+	}, nil
+}
+
+func (t *StatsTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	next := t.sched.Next(now)
+	return ScheduledTask{
+		Task:  t,
+		RunAt: next,
+		Op: &v1.Operation{
+			PlanId:          t.PlanID(),
+			RepoId:          t.RepoID(),
+			UnixTimeStartMs: next.UnixMilli(),
+			Status:          v1.OperationStatus_STATUS_PENDING,
+			Op:              &v1.Operation_OperationStats{},
+		},
+	}
+}
+
+func (t *StatsTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	return runStats(ctx, st, runner)
+}
+
 func NewOneoffStatsTask(repoID, planID string, at time.Time) Task {
 	return &GenericOneoffTask{
 		BaseTask: BaseTask{
@@ -22,21 +74,23 @@ func NewOneoffStatsTask(repoID, planID string, at time.Time) Task {
 				Op: &v1.Operation_OperationStats{},
 			},
 		},
-		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
-			if err := statsHelper(ctx, st, taskRunner); err != nil {
-				taskRunner.ExecuteHooks([]v1.Hook_Condition{
-					v1.Hook_CONDITION_ANY_ERROR,
-				}, hook.HookVars{
-					Task:  st.Task.Name(),
-					Error: err.Error(),
-				})
-				return err
-			}
-			return nil
-		},
+		Do: runStats,
 	}
 }
 
+func runStats(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	if err := statsHelper(ctx, st, taskRunner); err != nil {
+		taskRunner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, hook.HookVars{
+			Task:  st.Task.Name(),
+			Error: err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
 func statsHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
 	t := st.Task
 
@@ -0,0 +1,70 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/hook"
+)
+
+// NewOneoffTagTask returns a task adding and removing tags of snapshots in the repo. restic saves the tagged snapshots
+// under new IDs, the repo's snapshots are indexed again afterwards.
+func NewOneoffTagTask(repoID, planID string, snapshotIDs []string, add []string, remove []string, at time.Time) Task {
+	return &GenericOneoffTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("tag snapshots in repo %q", repoID),
+			TaskRepoID: repoID,
+			TaskPlanID: planID,
+		},
+		OneoffTask: OneoffTask{
+			RunAt: at,
+			ProtoOp: &v1.Operation{
+				Op: &v1.Operation_OperationTag{
+					OperationTag: &v1.OperationTag{
+						SnapshotIds: snapshotIDs,
+						Add:         add,
+						Remove:      remove,
+					},
+				},
+			},
+		},
+		Do: func(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+			if err := tagHelper(ctx, st, taskRunner); err != nil {
+				taskRunner.ExecuteHooks([]v1.Hook_Condition{
+					v1.Hook_CONDITION_ANY_ERROR,
+				}, hook.HookVars{
+					Task:  st.Task.Name(),
+					Error: err.Error(),
+				})
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func tagHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+	tag := st.Op.GetOperationTag()
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("couldn't get repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := repo.UnlockIfAutoEnabled(ctx); err != nil {
+		return fmt.Errorf("auto unlock repo %q: %w", t.RepoID(), err)
+	}
+
+	if err := repo.EditTags(ctx, tag.SnapshotIds, tag.Add, tag.Remove); err != nil {
+		return fmt.Errorf("tag snapshots: %w", err)
+	}
+	st.Op.DisplayMessage = fmt.Sprintf("Updated the tags of %d snapshots, changed snapshots are saved under new IDs.", len(tag.SnapshotIds))
+
+	if err := taskRunner.ScheduleTask(NewOneoffIndexSnapshotsTask(t.RepoID(), time.Now()), TaskPriorityIndexSnapshots); err != nil {
+		return fmt.Errorf("schedule index snapshots task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/cronutil"
+	"github.com/garethgeorge/backrest/internal/hook"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+)
+
+const defaultVerifyOplogCron = "@daily"
+
+// stuckPendingAge is how long after its scheduled time an operation may still be pending before it's reported as stuck,
+// operations wait while the repo's other tasks run.
+const stuckPendingAge = 6 * time.Hour
+
+// maxListedInconsistencies bounds the inconsistencies listed in a verify operation.
+const maxListedInconsistencies = 20
+
+// VerifyOplogTask checks the invariants of a repo's operations in the oplog on a schedule. It runs on the repo's queue
+// so the repo's snapshots are indexed, and the oplog reconciled with the repo, before the check whenever a backup or
+// forget ran. Inconsistencies fail the task, running the error hooks and raising an alert.
+type VerifyOplogTask struct {
+	BaseTask
+	sched *cronutil.Schedule
+}
+
+var _ Task = &VerifyOplogTask{}
+
+// NewScheduledVerifyOplogTask returns a task verifying the repo's operations daily.
+func NewScheduledVerifyOplogTask(repo *v1.Repo) (*VerifyOplogTask, error) {
+	sched, err := cronutil.ParseInLocation(defaultVerifyOplogCron, time.Now().Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse verify schedule %q: %w", defaultVerifyOplogCron, err)
+	}
+
+	return &VerifyOplogTask{
+		BaseTask: BaseTask{
+			TaskName:   fmt.Sprintf("verify oplog for repo %q", repo.Id),
+			TaskRepoID: repo.Id,
+			TaskPlanID: PlanForUnassociatedOperations,
+		},
+		sched: sched,
+	}, nil
+}
+
+func (t *VerifyOplogTask) Next(now time.Time, runner TaskRunner) ScheduledTask {
+	next := t.sched.Next(now)
+	return ScheduledTask{
+		Task:  t,
+		RunAt: next,
+		Op: &v1.Operation{
+			PlanId:          t.PlanID(),
+			RepoId:          t.RepoID(),
+			UnixTimeStartMs: next.UnixMilli(),
+			Status:          v1.OperationStatus_STATUS_PENDING,
+			Op:              &v1.Operation_OperationVerifyOplog{},
+		},
+	}
+}
+
+func (t *VerifyOplogTask) Run(ctx context.Context, st ScheduledTask, runner TaskRunner) error {
+	if err := verifyOplogHelper(ctx, st, runner); err != nil {
+		runner.ExecuteHooks([]v1.Hook_Condition{
+			v1.Hook_CONDITION_ANY_ERROR,
+		}, hook.HookVars{
+			Task:  st.Task.Name(),
+			Error: err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+func verifyOplogHelper(ctx context.Context, st ScheduledTask, taskRunner TaskRunner) error {
+	t := st.Task
+
+	repo, err := taskRunner.GetRepoOrchestrator(t.RepoID())
+	if err != nil {
+		return fmt.Errorf("get repo %q: %w", t.RepoID(), err)
+	}
+	snapshots, err := repo.Snapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("get snapshots for repo %q: %w", t.RepoID(), err)
+	}
+	snapshotIDs := make(map[string]bool, len(snapshots))
+	for _, s := range snapshots {
+		snapshotIDs[s.Id] = true
+	}
+
+	verify, err := verifyOplog(taskRunner.OpLog(), t.RepoID(), taskRunner.Config().Instance, snapshotIDs, time.Now())
+	if err != nil {
+		return fmt.Errorf("verify oplog: %w", err)
+	}
+	st.Op.Op = &v1.Operation_OperationVerifyOplog{OperationVerifyOplog: verify}
+	if verify.InconsistencyCount > 0 {
+		return fmt.Errorf("found %d inconsistencies in %d operations, the first: %v", verify.InconsistencyCount, verify.OperationsChecked, verify.Inconsistencies[0].Message)
+	}
+	st.Op.DisplayMessage = fmt.Sprintf("checked %d operations, no inconsistencies found", verify.OperationsChecked)
+	return nil
+}
+
+// verifyOplog checks the invariants of the repo's operations:
+//   - every successful backup that was not skipped as unchanged has a snapshot ID.
+//   - every indexed snapshot not marked forgotten is one of the repo's snapshots, snapshotIDs. The oplog is reconciled
+//     with the repo whenever its snapshots are indexed.
+//   - no operation of the instance is still pending stuckPendingAge after it was scheduled to run.
+func verifyOplog(log *oplog.OpLog, repoID string, instance string, snapshotIDs map[string]bool, now time.Time) (*v1.OperationVerifyOplog, error) {
+	verify := &v1.OperationVerifyOplog{}
+	report := func(kind v1.OplogInconsistency_Kind, op *v1.Operation, format string, args ...any) {
+		verify.InconsistencyCount++
+		if len(verify.Inconsistencies) < maxListedInconsistencies {
+			verify.Inconsistencies = append(verify.Inconsistencies, &v1.OplogInconsistency{
+				Kind:        kind,
+				OperationId: op.Id,
+				Message:     fmt.Sprintf("operation %d: ", op.Id) + fmt.Sprintf(format, args...),
+			})
+		}
+	}
+
+	err := log.ForEachByRepo(repoID, indexutil.CollectAll(), func(op *v1.Operation) error {
+		verify.OperationsChecked++
+		switch o := op.Op.(type) {
+		case *v1.Operation_OperationBackup:
+			if op.Status == v1.OperationStatus_STATUS_SUCCESS && op.SnapshotId == "" && !o.OperationBackup.GetUnchanged() {
+				report(v1.OplogInconsistency_KIND_BACKUP_WITHOUT_SNAPSHOT, op, "backup for plan %q succeeded without a snapshot ID", op.PlanId)
+			}
+		case *v1.Operation_OperationIndexSnapshot:
+			if o.OperationIndexSnapshot == nil {
+				return fmt.Errorf("operation %v has nil OperationIndexSnapshot", op.Id)
+			}
+			if id := o.OperationIndexSnapshot.GetSnapshot().GetId(); !o.OperationIndexSnapshot.Forgot && !snapshotIDs[id] {
+				report(v1.OplogInconsistency_KIND_SNAPSHOT_NOT_IN_REPO, op, "snapshot %v is indexed but not in the repo, it may have been forgotten by another tool. Index the repo's snapshots to reconcile", id)
+			}
+		}
+		if op.Status == v1.OperationStatus_STATUS_PENDING && op.InstanceId == instance {
+			if scheduled := time.UnixMilli(op.UnixTimeStartMs); now.Sub(scheduled) > stuckPendingAge {
+				report(v1.OplogInconsistency_KIND_STUCK_PENDING, op, "pending since it was scheduled at %v", scheduled.Format(time.RFC3339))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return verify, nil
+}
@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+)
+
+func TestVerifyOplog(t *testing.T) {
+	t.Parallel()
+
+	log, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	snapshotA, snapshotB, snapshotC := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)
+	add := func(op *v1.Operation) *v1.Operation {
+		op.RepoId = "repo"
+		op.PlanId = "plan"
+		op.InstanceId = "instance"
+		if op.UnixTimeStartMs == 0 {
+			op.UnixTimeStartMs = now.Add(-time.Hour).UnixMilli()
+		}
+		if err := log.Add(op); err != nil {
+			t.Fatalf("error adding operation: %v", err)
+		}
+		return op
+	}
+	indexed := func(id string, forgot bool) *v1.Operation {
+		return add(&v1.Operation{
+			Status:     v1.OperationStatus_STATUS_SUCCESS,
+			SnapshotId: id,
+			Op: &v1.Operation_OperationIndexSnapshot{OperationIndexSnapshot: &v1.OperationIndexSnapshot{
+				Snapshot: &v1.ResticSnapshot{Id: id},
+				Forgot:   forgot,
+			}},
+		})
+	}
+
+	// consistent operations.
+	add(&v1.Operation{Status: v1.OperationStatus_STATUS_SUCCESS, SnapshotId: snapshotA, Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}}})
+	add(&v1.Operation{Status: v1.OperationStatus_STATUS_SUCCESS, Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{Unchanged: true}}})
+	add(&v1.Operation{Status: v1.OperationStatus_STATUS_ERROR, Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}}})
+	add(&v1.Operation{Status: v1.OperationStatus_STATUS_PENDING, UnixTimeStartMs: now.Add(time.Hour).UnixMilli(), Op: &v1.Operation_OperationBackup{}})
+	indexed(snapshotA, false)
+	indexed(snapshotB, true)
+
+	// inconsistent operations.
+	noSnapshot := add(&v1.Operation{Status: v1.OperationStatus_STATUS_SUCCESS, Op: &v1.Operation_OperationBackup{OperationBackup: &v1.OperationBackup{}}})
+	missing := indexed(snapshotC, false)
+	stuck := add(&v1.Operation{Status: v1.OperationStatus_STATUS_PENDING, UnixTimeStartMs: now.Add(-7 * time.Hour).UnixMilli(), Op: &v1.Operation_OperationStats{}})
+
+	verify, err := verifyOplog(log, "repo", "instance", map[string]bool{snapshotA: true}, now)
+	if err != nil {
+		t.Fatalf("verifyOplog() error: %v", err)
+	}
+	if verify.OperationsChecked != 9 {
+		t.Errorf("expected 9 operations checked, got %d", verify.OperationsChecked)
+	}
+
+	type inconsistency struct {
+		kind v1.OplogInconsistency_Kind
+		opID int64
+	}
+	var got []inconsistency
+	for _, i := range verify.Inconsistencies {
+		got = append(got, inconsistency{i.Kind, i.OperationId})
+	}
+	want := []inconsistency{
+		{v1.OplogInconsistency_KIND_BACKUP_WITHOUT_SNAPSHOT, noSnapshot.Id},
+		{v1.OplogInconsistency_KIND_SNAPSHOT_NOT_IN_REPO, missing.Id},
+		{v1.OplogInconsistency_KIND_STUCK_PENDING, stuck.Id},
+	}
+	if verify.InconsistencyCount != 3 || !slices.Equal(got, want) {
+		t.Errorf("expected inconsistencies %v, got %v (count %d)", want, got, verify.InconsistencyCount)
+	}
+}
@@ -61,12 +61,17 @@ func BackupProgressEntryToProto(b *restic.BackupProgressEntry) *v1.BackupProgres
 		return &v1.BackupProgressEntry{
 			Entry: &v1.BackupProgressEntry_Status{
 				Status: &v1.BackupProgressStatusEntry{
-					PercentDone: b.PercentDone,
-					TotalFiles:  int64(b.TotalFiles),
-					FilesDone:   int64(b.FilesDone),
-					TotalBytes:  int64(b.TotalBytes),
-					BytesDone:   int64(b.BytesDone),
-					CurrentFile: b.CurrentFiles,
+					PercentDone:      b.PercentDone,
+					TotalFiles:       int64(b.TotalFiles),
+					FilesDone:        int64(b.FilesDone),
+					TotalBytes:       int64(b.TotalBytes),
+					BytesDone:        int64(b.BytesDone),
+					CurrentFile:      b.CurrentFiles,
+					FilesRemaining:   max(b.TotalFiles-b.FilesDone, 0),
+					BytesRemaining:   max(b.TotalBytes-b.BytesDone, 0),
+					SecondsElapsed:   b.SecondsElapsed,
+					SecondsRemaining: b.SecondsRemaining,
+					ErrorCount:       b.ErrorCount,
 				},
 			},
 		}
@@ -82,8 +87,9 @@ func BackupProgressEntryToBackupError(b *restic.BackupProgressEntry) (*v1.Backup
 	}
 
 	return &v1.BackupProgressError{
-		Item:   b.Item,
-		During: b.During,
+		Item:    b.Item,
+		During:  b.During,
+		Message: b.ErrorMessage(),
 	}, nil
 }
 
@@ -146,6 +152,7 @@ func RepoStatsToProto(s *restic.RepoStats) *v1.RepoStats {
 		TotalSize:             int64(s.TotalSize),
 		TotalUncompressedSize: int64(s.TotalUncompressedSize),
 		CompressionRatio:      s.CompressionRatio,
+		CompressionProgress:   s.CompressionProgress,
 		TotalBlobCount:        int64(s.TotalBlobCount),
 		SnapshotCount:         int64(s.SnapshotsCount),
 	}
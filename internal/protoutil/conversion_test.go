@@ -105,6 +105,38 @@ func TestBackupProgressEntryToProto(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "status with estimate",
+			entry: &restic.BackupProgressEntry{
+				MessageType:      "status",
+				PercentDone:      0.5,
+				TotalFiles:       90102,
+				FilesDone:        3214,
+				TotalBytes:       2000,
+				BytesDone:        1000,
+				CurrentFiles:     []string{"/home/user/video.mp4"},
+				SecondsElapsed:   30,
+				SecondsRemaining: 30,
+				ErrorCount:       1,
+			},
+			want: &v1.BackupProgressEntry{
+				Entry: &v1.BackupProgressEntry_Status{
+					Status: &v1.BackupProgressStatusEntry{
+						PercentDone:      0.5,
+						TotalFiles:       90102,
+						FilesDone:        3214,
+						TotalBytes:       2000,
+						BytesDone:        1000,
+						CurrentFile:      []string{"/home/user/video.mp4"},
+						FilesRemaining:   86888,
+						BytesRemaining:   1000,
+						SecondsElapsed:   30,
+						SecondsRemaining: 30,
+						ErrorCount:       1,
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -145,6 +177,7 @@ func TestRepoStatsToProto(t *testing.T) {
 				CompressionRatio:      3,
 				TotalBlobCount:        5,
 				SnapshotCount:         8,
+				CompressionProgress:   6,
 			},
 		},
 	}
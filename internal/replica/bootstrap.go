@@ -0,0 +1,52 @@
+package replica
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrConfigNotEmpty = errors.New("refusing to bootstrap over a config that already has repos or plans")
+
+// Bootstrap restores the config and operation history of a replica on an instance that has no repos or plans yet.
+// Operations are added with new IDs, operations that shared a flow in the replica share a flow afterwards.
+func Bootstrap(replica *v1.ConfigReplica, store config.ConfigStore, log *oplog.OpLog) error {
+	current, err := store.Get()
+	if err != nil {
+		return fmt.Errorf("get current config: %w", err)
+	}
+	if len(current.Repos) > 0 || len(current.Plans) > 0 {
+		return ErrConfigNotEmpty
+	}
+
+	cfg := proto.Clone(replica.GetConfig()).(*v1.Config)
+	cfg.Modno = current.Modno + 1
+	if err := store.Update(cfg); err != nil {
+		return fmt.Errorf("update config: %w", err)
+	}
+
+	ops := slices.Clone(replica.Operations)
+	slices.SortFunc(ops, func(a, b *v1.Operation) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	flows := make(map[int64]int64) // flow IDs in the replica to flow IDs in the oplog.
+	for _, op := range ops {
+		op = proto.Clone(op).(*v1.Operation)
+		originalFlow := op.FlowId
+		op.Id = 0
+		op.FlowId = flows[originalFlow]
+		if err := log.Add(op); err != nil {
+			return fmt.Errorf("add operation: %w", err)
+		}
+		if originalFlow != 0 {
+			flows[originalFlow] = op.FlowId
+		}
+	}
+	return nil
+}
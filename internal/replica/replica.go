@@ -0,0 +1,125 @@
+// Package replica builds encrypted copies of an instance's config and recent operation history, pushes them off-site
+// and bootstraps a replacement instance from them. A Follower mirrors the operation history of a primary instance onto
+// a read replica.
+package replica
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"golang.org/x/crypto/scrypt"
+	"google.golang.org/protobuf/proto"
+)
+
+// sealVersion identifies the encryption scheme: an scrypt derived key used with AES-256-GCM.
+const sealVersion = 1
+
+const (
+	saltLen = 16
+	keyLen  = 32
+	// scrypt parameters recommended for interactive use, deriving a key takes well under a second.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// operationsPerPlan is the number of recent operations of each plan included in a replica.
+const operationsPerPlan = 20
+
+var ErrBadPassphrase = errors.New("wrong passphrase or corrupted replica")
+
+// Build returns a replica of the config and the most recent operations of each of its plans.
+func Build(cfg *v1.Config, log *oplog.OpLog) (*v1.ConfigReplica, error) {
+	replica := &v1.ConfigReplica{Config: proto.Clone(cfg).(*v1.Config)}
+	for _, plan := range cfg.Plans {
+		if err := log.ForEachByPlan(plan.Id, indexutil.CollectLastN(operationsPerPlan), func(op *v1.Operation) error {
+			op = proto.Clone(op).(*v1.Operation)
+			op.Logref = "" // logs stay on this instance.
+			if d := op.GetFailureDiagnostics(); d != nil {
+				d.DaemonLog = ""
+			}
+			replica.Operations = append(replica.Operations, op)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("operations for plan %q: %w", plan.Id, err)
+		}
+	}
+	return replica, nil
+}
+
+// Seal encrypts the replica with a key derived from the passphrase.
+func Seal(replica *v1.ConfigReplica, passphrase string, now time.Time) (*v1.SealedReplica, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+	plaintext, err := proto.Marshal(replica)
+	if err != nil {
+		return nil, fmt.Errorf("marshal replica: %w", err)
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	instance := replica.GetConfig().GetInstance()
+	return &v1.SealedReplica{
+		Version:    sealVersion,
+		Instance:   instance,
+		UnixTimeMs: now.UnixMilli(),
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, []byte(instance)),
+	}, nil
+}
+
+// Open decrypts a sealed replica, ErrBadPassphrase is returned if the passphrase is wrong or the replica was modified.
+func Open(sealed *v1.SealedReplica, passphrase string) (*v1.ConfigReplica, error) {
+	if sealed.Version != sealVersion {
+		return nil, fmt.Errorf("unsupported replica version %d", sealed.Version)
+	}
+	aead, err := newAEAD(passphrase, sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, ErrBadPassphrase
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(sealed.Instance))
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+
+	replica := &v1.ConfigReplica{}
+	if err := proto.Unmarshal(plaintext, replica); err != nil {
+		return nil, fmt.Errorf("unmarshal replica: %w", err)
+	}
+	return replica, nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,137 @@
+package replica
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/config"
+	"github.com/garethgeorge/backrest/internal/oplog"
+	"github.com/garethgeorge/backrest/internal/oplog/indexutil"
+	"google.golang.org/protobuf/proto"
+)
+
+func testConfig() *v1.Config {
+	return &v1.Config{
+		Modno:    5,
+		Instance: "old-machine",
+		Repos: []*v1.Repo{
+			{Id: "repo", Uri: "/tmp/repo", Password: "hunter2"},
+		},
+		Plans: []*v1.Plan{
+			{Id: "plan", Repo: "repo", Paths: []string{"/tmp"}, Cron: "0 * * * *"},
+		},
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	t.Parallel()
+
+	replica := &v1.ConfigReplica{Config: testConfig()}
+	sealed, err := Seal(replica, "correct horse", time.Now())
+	if err != nil {
+		t.Fatalf("Seal() error: %v", err)
+	}
+	if sealed.Instance != "old-machine" {
+		t.Errorf("expected instance to be recorded, got %q", sealed.Instance)
+	}
+
+	opened, err := Open(sealed, "correct horse")
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if !proto.Equal(opened, replica) {
+		t.Errorf("Open() = %v, want %v", opened, replica)
+	}
+
+	if _, err := Open(sealed, "wrong passphrase"); !errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("expected ErrBadPassphrase for the wrong passphrase, got %v", err)
+	}
+
+	tampered := proto.Clone(sealed).(*v1.SealedReplica)
+	tampered.Instance = "other-machine"
+	if _, err := Open(tampered, "correct horse"); !errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("expected ErrBadPassphrase for a replica with a modified instance, got %v", err)
+	}
+}
+
+func TestPushToDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	sealed, err := Seal(&v1.ConfigReplica{Config: testConfig()}, "correct horse", time.Now())
+	if err != nil {
+		t.Fatalf("Seal() error: %v", err)
+	}
+	if err := Push(context.Background(), dir, sealed); err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+
+	got, err := NewStore(dir).Get("old-machine")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if !proto.Equal(got, sealed) {
+		t.Errorf("Get() = %v, want %v", got, sealed)
+	}
+}
+
+func TestBuildAndBootstrap(t *testing.T) {
+	t.Parallel()
+
+	oldLog, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { oldLog.Close() })
+
+	backup := &v1.Operation{RepoId: "repo", PlanId: "plan", UnixTimeStartMs: 1, Logref: "log", Op: &v1.Operation_OperationBackup{}}
+	if err := oldLog.Add(backup); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+	forget := &v1.Operation{RepoId: "repo", PlanId: "plan", FlowId: backup.FlowId, UnixTimeStartMs: 2, Op: &v1.Operation_OperationForget{}}
+	if err := oldLog.Add(forget); err != nil {
+		t.Fatalf("error adding operation: %v", err)
+	}
+
+	replica, err := Build(testConfig(), oldLog)
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	if len(replica.Operations) != 2 || replica.Operations[0].Logref != "" {
+		t.Fatalf("expected both operations without log refs, got %v", replica.Operations)
+	}
+
+	// Act
+	newLog, err := oplog.NewOpLog(t.TempDir() + "/oplog.boltdb")
+	if err != nil {
+		t.Fatalf("error creating oplog: %v", err)
+	}
+	t.Cleanup(func() { newLog.Close() })
+	store := &config.MemoryStore{Config: config.NewDefaultConfig()}
+	if err := Bootstrap(replica, store, newLog); err != nil {
+		t.Fatalf("Bootstrap() error: %v", err)
+	}
+
+	// Assert
+	cfg, _ := store.Get()
+	if cfg.Instance != "old-machine" || len(cfg.Repos) != 1 || len(cfg.Plans) != 1 {
+		t.Errorf("expected the replica's config, got %v", cfg)
+	}
+	var ops []*v1.Operation
+	if err := newLog.ForEachByPlan("plan", indexutil.CollectAll(), func(op *v1.Operation) error {
+		ops = append(ops, op)
+		return nil
+	}); err != nil {
+		t.Fatalf("error listing operations: %v", err)
+	}
+	if len(ops) != 2 || ops[0].FlowId != ops[1].FlowId {
+		t.Errorf("expected 2 operations sharing a flow, got %v", ops)
+	}
+
+	if err := Bootstrap(replica, store, newLog); !errors.Is(err, ErrConfigNotEmpty) {
+		t.Errorf("expected ErrConfigNotEmpty bootstrapping twice, got %v", err)
+	}
+}
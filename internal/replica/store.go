@@ -0,0 +1,110 @@
+package replica
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/gen/go/v1/v1connect"
+	"github.com/garethgeorge/backrest/internal/config/validationutil"
+	"github.com/natefinch/atomic"
+	"google.golang.org/protobuf/proto"
+)
+
+// fileExt is the extension of replica files, each is named after the instance that created it.
+const fileExt = ".backrest-replica"
+
+// Store keeps the latest replica of each instance as a file in a directory.
+type Store struct {
+	Dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// Put replaces the stored replica of the sealed replica's instance.
+func (s *Store) Put(sealed *v1.SealedReplica) error {
+	if err := validationutil.ValidateID(sealed.Instance, validationutil.IDMaxLen); err != nil {
+		return fmt.Errorf("instance ID %q invalid: %w", sealed.Instance, err)
+	}
+	data, err := proto.Marshal(sealed)
+	if err != nil {
+		return fmt.Errorf("marshal replica: %w", err)
+	}
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return fmt.Errorf("create replica directory: %w", err)
+	}
+	path := filepath.Join(s.Dir, sealed.Instance+fileExt)
+	if err := atomic.WriteFile(path, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("write replica: %w", err)
+	}
+	// replicas are encrypted but only the user running backrest has any use for them.
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("chmod(0600) replica: %w", err)
+	}
+	return nil
+}
+
+// Get returns the stored replica of the instance.
+func (s *Store) Get(instance string) (*v1.SealedReplica, error) {
+	if err := validationutil.ValidateID(instance, validationutil.IDMaxLen); err != nil {
+		return nil, fmt.Errorf("instance ID %q invalid: %w", instance, err)
+	}
+	return ReadFile(filepath.Join(s.Dir, instance+fileExt))
+}
+
+// ReadFile reads a sealed replica written by a Store.
+func ReadFile(path string) (*v1.SealedReplica, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sealed := &v1.SealedReplica{}
+	if err := proto.Unmarshal(data, sealed); err != nil {
+		return nil, fmt.Errorf("unmarshal replica %q: %w", path, err)
+	}
+	return sealed, nil
+}
+
+// Push sends the sealed replica to the destination from the config replication settings, either the URL of a peer
+// instance or a directory.
+func Push(ctx context.Context, destination string, sealed *v1.SealedReplica) error {
+	if destination == "" {
+		return errors.New("destination is required")
+	}
+	if strings.HasPrefix(destination, "http://") || strings.HasPrefix(destination, "https://") {
+		return pushToPeer(ctx, destination, sealed)
+	}
+	return NewStore(strings.TrimPrefix(destination, "file://")).Put(sealed)
+}
+
+func pushToPeer(ctx context.Context, peerURL string, sealed *v1.SealedReplica) error {
+	u, err := url.Parse(peerURL)
+	if err != nil {
+		return fmt.Errorf("parse peer URL: %w", err)
+	}
+	user := u.User
+	u.User = nil
+
+	req := connect.NewRequest(sealed)
+	if user != nil {
+		password, _ := user.Password()
+		req.Header().Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password)))
+	}
+
+	client := v1connect.NewBackrestClient(http.DefaultClient, strings.TrimSuffix(u.String(), "/"))
+	if _, err := client.PutReplica(ctx, req); err != nil {
+		return fmt.Errorf("push to peer %s: %w", u, err)
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+// Package resthost serves restic repos over restic's REST protocol so that a backrest instance can be the storage
+// target of other machines' backups, see https://restic.readthedocs.io/en/latest/100_references.html#rest-backend.
+package resthost
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/config"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// mediaTypeV2 is requested by restic clients that understand version 2 of the protocol, listings include sizes.
+	mediaTypeV2 = "application/vnd.x.restic.rest.v2"
+
+	// loginCacheTTL is how long a verified password is remembered, restic makes many requests and checking a bcrypt
+	// hash for each would be slow.
+	loginCacheTTL = 5 * time.Minute
+)
+
+// fileTypes are the directories of a restic repo, the config is a single file at the repo's root.
+var fileTypes = []string{"data", "keys", "locks", "snapshots", "index"}
+
+// fileNameRegex matches the names of restic's files, the SHA-256 of their contents.
+var fileNameRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Server serves the repos of the clients in the config's repo hosting settings, it should be mounted under /restic/
+// with the prefix stripped. It looks up the config for each request so that changes to the clients apply immediately.
+type Server struct {
+	config config.ConfigStore
+
+	mu     sync.Mutex
+	logins map[string]cachedLogin // by client name.
+}
+
+type cachedLogin struct {
+	passwordBcrypt string   // the hash the password was verified against, a changed password invalidates the entry.
+	passwordSHA    [32]byte // SHA-256 of the verified password.
+	expires        time.Time
+}
+
+func NewServer(config config.ConfigStore) *Server {
+	return &Server{
+		config: config,
+		logins: make(map[string]cachedLogin),
+	}
+}
+
+// Root returns the directory the hosted repos are stored in.
+func Root(hosting *v1.RepoHosting) string {
+	if hosting.GetPath() != "" {
+		return hosting.GetPath()
+	}
+	return filepath.Join(config.DataDir(), "hosted-repos")
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.config.Get()
+	if err != nil {
+		zap.S().Errorf("repo hosting failed to get config: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	hosting := cfg.GetRepoHosting()
+	if len(hosting.GetClients()) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	// paths are /<client>/, /<client>/config, /<client>/<type>/ and /<client>/<type>/<name>.
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	client := s.authenticate(hosting, r)
+	if client == nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="restic"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if parts[0] != client.Name {
+		// clients only access their own repo.
+		http.NotFound(w, r)
+		return
+	}
+
+	repo := &repoHandler{
+		dir:        filepath.Join(Root(hosting), client.Name),
+		appendOnly: client.AppendOnly,
+	}
+	switch rest := parts[1:]; {
+	case len(rest) == 1 && rest[0] == "":
+		repo.serveRoot(w, r)
+	case len(rest) == 1 && rest[0] == "config":
+		repo.serveFile(w, r, "config", filepath.Join(repo.dir, "config"))
+	case len(rest) == 2 && slices.Contains(fileTypes, rest[0]) && rest[1] == "":
+		repo.serveList(w, r, rest[0])
+	case len(rest) == 2 && slices.Contains(fileTypes, rest[0]) && fileNameRegex.MatchString(rest[1]):
+		repo.serveFile(w, r, rest[0], repo.filePath(rest[0], rest[1]))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// authenticate returns the client whose credentials the request carries or nil.
+func (s *Server) authenticate(hosting *v1.RepoHosting, r *http.Request) *v1.RepoHostingClient {
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return nil
+	}
+	var client *v1.RepoHostingClient
+	for _, c := range hosting.Clients {
+		if c.Name == name {
+			client = c
+			break
+		}
+	}
+	if client == nil {
+		return nil
+	}
+
+	passwordSHA := sha256.Sum256([]byte(password))
+	s.mu.Lock()
+	login, ok := s.logins[name]
+	s.mu.Unlock()
+	if ok && login.passwordBcrypt == client.PasswordBcrypt && time.Now().Before(login.expires) &&
+		subtle.ConstantTimeCompare(login.passwordSHA[:], passwordSHA[:]) == 1 {
+		return client
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(client.PasswordBcrypt)
+	if err != nil || bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil {
+		zap.L().Warn("repo hosting login failed", zap.String("client", name), zap.String("remote", r.RemoteAddr))
+		return nil
+	}
+	s.mu.Lock()
+	s.logins[name] = cachedLogin{
+		passwordBcrypt: client.PasswordBcrypt,
+		passwordSHA:    passwordSHA,
+		expires:        time.Now().Add(loginCacheTTL),
+	}
+	s.mu.Unlock()
+	return client
+}
+
+// repoHandler serves the requests for a single client's repo.
+type repoHandler struct {
+	dir        string
+	appendOnly bool
+}
+
+// filePath returns the path of a file in the repo, data files are spread over subdirectories by their first byte.
+func (h *repoHandler) filePath(fileType, name string) string {
+	if fileType == "data" {
+		return filepath.Join(h.dir, fileType, name[:2], name)
+	}
+	return filepath.Join(h.dir, fileType, name)
+}
+
+// serveRoot creates the repo's directories for restic init (POST /?create=true).
+func (h *repoHandler) serveRoot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Query().Get("create") != "true" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	dirs := []string{h.dir}
+	for _, t := range fileTypes {
+		dirs = append(dirs, filepath.Join(h.dir, t))
+	}
+	for i := 0; i < 256; i++ {
+		dirs = append(dirs, filepath.Join(h.dir, "data", fmt.Sprintf("%02x", i)))
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			h.serverError(w, fmt.Errorf("create repo directory: %w", err))
+			return
+		}
+	}
+}
+
+type listEntry struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+func (h *repoHandler) serveList(w http.ResponseWriter, r *http.Request, fileType string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	dir := filepath.Join(h.dir, fileType)
+	var entries []listEntry
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// only data files are in subdirectories.
+			if p != dir && fileType != "data" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !fileNameRegex.MatchString(d.Name()) {
+			return nil // e.g. a partially written upload.
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, listEntry{Name: d.Name(), Size: info.Size()})
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		h.serverError(w, fmt.Errorf("list %s: %w", fileType, err))
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), mediaTypeV2) {
+		if entries == nil {
+			entries = []listEntry{}
+		}
+		w.Header().Set("Content-Type", mediaTypeV2)
+		json.NewEncoder(w).Encode(entries)
+		return
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	w.Header().Set("Content-Type", "application/vnd.x.restic.rest.v1")
+	json.NewEncoder(w).Encode(names)
+}
+
+func (h *repoHandler) serveFile(w http.ResponseWriter, r *http.Request, fileType, path string) {
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		f, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			h.serverError(w, err)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			h.serverError(w, err)
+			return
+		}
+		// ServeContent answers the range requests restic reads parts of pack files with.
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, "", info.ModTime(), f)
+	case http.MethodPost:
+		h.saveFile(w, r, fileType, path)
+	case http.MethodDelete:
+		if h.appendOnly && fileType != "locks" {
+			http.Error(w, "repo is append-only", http.StatusForbidden)
+			return
+		}
+		if err := os.Remove(path); errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+		} else if err != nil {
+			h.serverError(w, err)
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// saveFile writes the request's body to the file, files are never overwritten. The contents of files other than the
+// config must hash to their name, which catches corrupted uploads.
+func (h *repoHandler) saveFile(w http.ResponseWriter, r *http.Request, fileType, path string) {
+	if _, err := os.Stat(path); err == nil {
+		http.Error(w, "file already exists", http.StatusForbidden)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		h.serverError(w, err)
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), r.Body)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		h.serverError(w, fmt.Errorf("write %s: %w", path, err))
+		return
+	}
+	if fileType != "config" && hex.EncodeToString(hash.Sum(nil)) != filepath.Base(path) {
+		http.Error(w, "file content does not match its name", http.StatusBadRequest)
+		return
+	}
+
+	// a hard link rather than a rename fails if another upload of the file won the race, the file is not overwritten.
+	if err := os.Link(tmp.Name(), path); errors.Is(err, fs.ErrExist) {
+		http.Error(w, "file already exists", http.StatusForbidden)
+		return
+	} else if err != nil {
+		h.serverError(w, fmt.Errorf("save %s: %w", path, err))
+		return
+	}
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+}
+
+func (h *repoHandler) serverError(w http.ResponseWriter, err error) {
+	zap.L().Error("repo hosting request failed", zap.String("repo", h.dir), zap.Error(err))
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
@@ -0,0 +1,136 @@
+package resthost
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/internal/auth"
+	"github.com/garethgeorge/backrest/internal/config"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	password, err := auth.CreatePassword("password")
+	if err != nil {
+		t.Fatalf("CreatePassword() error: %v", err)
+	}
+	store := &config.MemoryStore{Config: &v1.Config{
+		RepoHosting: &v1.RepoHosting{
+			Path: t.TempDir(),
+			Clients: []*v1.RepoHostingClient{
+				{Name: "laptop", PasswordBcrypt: password},
+				{Name: "server", PasswordBcrypt: password, AppendOnly: true},
+			},
+		},
+	}}
+	srv := httptest.NewServer(http.StripPrefix("/restic", NewServer(store)))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+type testClient struct {
+	t        *testing.T
+	url      string
+	user     string
+	password string
+}
+
+func (c *testClient) do(method, path string, body []byte, header ...string) (int, []byte) {
+	c.t.Helper()
+	req, err := http.NewRequest(method, c.url+"/restic"+path, bytes.NewReader(body))
+	if err != nil {
+		c.t.Fatalf("NewRequest() error: %v", err)
+	}
+	req.SetBasicAuth(c.user, c.password)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s error: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func (c *testClient) expect(want int, method, path string, body []byte, header ...string) []byte {
+	c.t.Helper()
+	got, data := c.do(method, path, body, header...)
+	if got != want {
+		c.t.Errorf("%s %s: got status %d (%s), want %d", method, path, got, bytes.TrimSpace(data), want)
+	}
+	return data
+}
+
+func fileName(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRepoHosting(t *testing.T) {
+	srv := newTestServer(t)
+	c := &testClient{t: t, url: srv.URL, user: "laptop", password: "password"}
+
+	c.expect(http.StatusNotFound, http.MethodHead, "/laptop/config", nil)
+	c.expect(http.StatusOK, http.MethodPost, "/laptop/?create=true", nil)
+	c.expect(http.StatusOK, http.MethodPost, "/laptop/config", []byte("config"))
+	c.expect(http.StatusForbidden, http.MethodPost, "/laptop/config", []byte("other config"))
+	if got := c.expect(http.StatusOK, http.MethodGet, "/laptop/config", nil); string(got) != "config" {
+		t.Errorf("GET config = %q, want %q", got, "config")
+	}
+
+	pack := []byte("pack file contents")
+	packName := fileName(pack)
+	c.expect(http.StatusBadRequest, http.MethodPost, "/laptop/data/"+packName, []byte("corrupted"))
+	c.expect(http.StatusOK, http.MethodPost, "/laptop/data/"+packName, pack)
+	if got := c.expect(http.StatusPartialContent, http.MethodGet, "/laptop/data/"+packName, nil, "Range", "bytes=5-8"); string(got) != "file" {
+		t.Errorf("ranged GET = %q, want %q", got, "file")
+	}
+
+	var entries []listEntry
+	if err := json.Unmarshal(c.expect(http.StatusOK, http.MethodGet, "/laptop/data/", nil, "Accept", mediaTypeV2), &entries); err != nil {
+		t.Fatalf("decode listing: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != packName || entries[0].Size != int64(len(pack)) {
+		t.Errorf("v2 listing = %v, want the pack file and its size", entries)
+	}
+	var names []string
+	if err := json.Unmarshal(c.expect(http.StatusOK, http.MethodGet, "/laptop/data/", nil), &names); err != nil {
+		t.Fatalf("decode listing: %v", err)
+	}
+	if len(names) != 1 || names[0] != packName {
+		t.Errorf("v1 listing = %v, want the pack file", names)
+	}
+
+	c.expect(http.StatusOK, http.MethodDelete, "/laptop/data/"+packName, nil)
+	c.expect(http.StatusNotFound, http.MethodHead, "/laptop/data/"+packName, nil)
+
+	// clients only access their own repo with their own password.
+	c.expect(http.StatusNotFound, http.MethodGet, "/server/config", nil)
+	wrongPassword := &testClient{t: t, url: srv.URL, user: "laptop", password: "wrong"}
+	wrongPassword.expect(http.StatusUnauthorized, http.MethodGet, "/laptop/config", nil)
+	unknown := &testClient{t: t, url: srv.URL, user: "unknown", password: "password"}
+	unknown.expect(http.StatusUnauthorized, http.MethodGet, "/unknown/config", nil)
+}
+
+func TestRepoHostingAppendOnly(t *testing.T) {
+	srv := newTestServer(t)
+	c := &testClient{t: t, url: srv.URL, user: "server", password: "password"}
+
+	c.expect(http.StatusOK, http.MethodPost, "/server/?create=true", nil)
+	snapshot, lock := []byte("snapshot"), []byte("lock")
+	c.expect(http.StatusOK, http.MethodPost, "/server/snapshots/"+fileName(snapshot), snapshot)
+	c.expect(http.StatusOK, http.MethodPost, "/server/locks/"+fileName(lock), lock)
+
+	c.expect(http.StatusForbidden, http.MethodDelete, "/server/snapshots/"+fileName(snapshot), nil)
+	c.expect(http.StatusForbidden, http.MethodPost, "/server/snapshots/"+fileName(snapshot), snapshot)
+	c.expect(http.StatusOK, http.MethodHead, "/server/snapshots/"+fileName(snapshot), nil)
+	c.expect(http.StatusOK, http.MethodDelete, "/server/locks/"+fileName(lock), nil)
+}
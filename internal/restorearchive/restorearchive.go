@@ -0,0 +1,227 @@
+// Package restorearchive writes downloaded restores as self-describing tar or zip archives, named by a configurable
+// template and holding a manifest of the snapshot they were restored from and of the checksums of their files.
+package restorearchive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ManifestVersion is the version of the manifest format written by this package.
+const ManifestVersion = 1
+
+// ManifestName is the name of the manifest at the root of an archive.
+const ManifestName = "MANIFEST.json"
+
+// fallbackManifestName is used if the restore holds a file named ManifestName at its root.
+const fallbackManifestName = "MANIFEST.backrest.json"
+
+// NameVars are the variables available to an archive name template.
+type NameVars struct {
+	Plan         string    // ID of the plan the snapshot was created by.
+	Repo         string    // ID of the repo holding the snapshot.
+	SnapshotId   string    // ID of the restored snapshot.
+	SnapshotTime time.Time // time the snapshot was created at, zero if unknown.
+	Path         string    // path in the snapshot that was restored.
+	CurTime      time.Time // time of the download.
+}
+
+// ShortId returns the snapshot's ID shortened the way restic displays it.
+func (v NameVars) ShortId() string {
+	if len(v.SnapshotId) > 8 {
+		return v.SnapshotId[:8]
+	}
+	return v.SnapshotId
+}
+
+// FormatTime formats t for use in a filename e.g. 2006-01-02-15-04-05.
+func (v NameVars) FormatTime(t time.Time) string {
+	return t.Format("2006-01-02-15-04-05")
+}
+
+// Name renders the archive name template, characters that aren't safe in a filename are replaced. The name doesn't
+// include the archive's extension.
+func Name(text string, vars NameVars) (string, error) {
+	tmpl, err := template.New("archive name").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, vars); err != nil {
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+	name := strings.Trim(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, buf.String()), "._")
+	if name == "" {
+		return "", errors.New("template rendered an empty name")
+	}
+	return name, nil
+}
+
+// ValidateNameTemplate checks that the template renders a name for a typical archive.
+func ValidateNameTemplate(text string) error {
+	now := time.Now()
+	_, err := Name(text, NameVars{
+		Plan:         "plan",
+		Repo:         "repo",
+		SnapshotId:   strings.Repeat("0", 64),
+		SnapshotTime: now,
+		Path:         "/home/user",
+		CurTime:      now,
+	})
+	return err
+}
+
+// Manifest describes an archive's contents and the snapshot they were restored from, it's written as JSON.
+type Manifest struct {
+	Version      int            `json:"version"`
+	SnapshotId   string         `json:"snapshotId"`
+	SnapshotTime *time.Time     `json:"snapshotTime,omitempty"`
+	Repo         string         `json:"repo"`
+	Plan         string         `json:"plan,omitempty"`
+	Path         string         `json:"path"` // path in the snapshot that was restored, the root of the archive.
+	CreatedAt    time.Time      `json:"createdAt"`
+	Include      []string       `json:"include,omitempty"` // patterns the archived files were selected by, if any.
+	Exclude      []string       `json:"exclude,omitempty"` // patterns of the files left out of the archive, if any.
+	Files        []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a file in the archive.
+type ManifestFile struct {
+	Path   string `json:"path"` // path in the archive.
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"` // hex encoded SHA-256 of the file's contents.
+}
+
+// Writer writes files to a tar or zip archive and records them in its manifest, the manifest is written as the
+// archive's last entry on Close.
+type Writer struct {
+	t        *tar.Writer
+	z        *zip.Writer
+	method   uint16 // method the files of a zip archive are compressed with.
+	manifest *Manifest
+}
+
+// NewWriter returns a writer of a tar archive to w, the manifest's Files are filled in as files are added.
+func NewWriter(w io.Writer, manifest *Manifest) *Writer {
+	manifest.Version = ManifestVersion
+	return &Writer{t: tar.NewWriter(w), manifest: manifest}
+}
+
+// NewZipWriter returns a writer of a zip archive to w whose files are stored, or deflated at level if deflate is set. A
+// level of 0 uses flate's default level. The manifest's Files are filled in as files are added.
+func NewZipWriter(w io.Writer, manifest *Manifest, deflate bool, level int) *Writer {
+	manifest.Version = ManifestVersion
+	z := zip.NewWriter(w)
+	method := zip.Store
+	if deflate {
+		method = zip.Deflate
+		if level == 0 {
+			level = flate.DefaultCompression
+		}
+		z.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+	}
+	return &Writer{z: z, method: method, manifest: manifest}
+}
+
+// AddFile adds a file of the given info to the archive under name, its contents are read from r.
+func (w *Writer) AddFile(name string, info os.FileInfo, r io.Reader) error {
+	if w.z != nil {
+		name = filepath.ToSlash(name) // zip entries are always separated by slashes.
+	}
+	fw, err := w.create(name, info.Size(), info.Mode(), info.ModTime())
+	if err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	h := sha256.New()
+	n, err := io.CopyN(io.MultiWriter(fw, h), r, info.Size())
+	if err != nil {
+		return fmt.Errorf("copy contents: %w", err)
+	} else if n != info.Size() {
+		return errors.New("copy contents: short write")
+	}
+	w.manifest.Files = append(w.manifest.Files, ManifestFile{
+		Path:   name,
+		Size:   n,
+		Sha256: hex.EncodeToString(h.Sum(nil)),
+	})
+	return nil
+}
+
+// create writes the header of an entry and returns the writer of its contents.
+func (w *Writer) create(name string, size int64, mode os.FileMode, modTime time.Time) (io.Writer, error) {
+	if w.z == nil {
+		if err := w.t.WriteHeader(&tar.Header{
+			Name:    name,
+			Size:    size,
+			Mode:    int64(mode),
+			ModTime: modTime,
+		}); err != nil {
+			return nil, err
+		}
+		return w.t, nil
+	}
+
+	hdr := &zip.FileHeader{
+		Name:     name,
+		Method:   w.method,
+		Modified: modTime,
+	}
+	hdr.SetMode(zipFileMode(mode))
+	return w.z.CreateHeader(hdr)
+}
+
+// zipFileMode returns the mode a file is stored with in a zip archive. Zip archives are typically opened on Windows,
+// which only knows whether a file is read-only, so files are stored as writable and only their executable bit is kept
+// for extraction on other platforms. Ownership and special bits e.g. setuid aren't kept.
+func zipFileMode(mode os.FileMode) os.FileMode {
+	if mode&0111 != 0 {
+		return 0755
+	}
+	return 0644
+}
+
+// Close writes the manifest and closes the archive, it doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	name := ManifestName
+	for _, f := range w.manifest.Files {
+		if f.Path == ManifestName {
+			name = fallbackManifestName
+			break
+		}
+	}
+	fw, err := w.create(name, int64(len(data)), 0644, w.manifest.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("write manifest header: %w", err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	if w.z != nil {
+		return w.z.Close()
+	}
+	return w.t.Close()
+}
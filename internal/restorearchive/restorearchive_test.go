@@ -0,0 +1,183 @@
+package restorearchive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestName(t *testing.T) {
+	snapshotTime := time.Date(2024, 3, 10, 14, 10, 41, 0, time.UTC)
+	vars := NameVars{
+		Plan:         "docs",
+		Repo:         "local",
+		SnapshotId:   "2e1d7ad2a55c9a2c2c4b5e1a1c8b1a7b3c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f",
+		SnapshotTime: snapshotTime,
+		Path:         "/home/user/docs",
+	}
+
+	tcs := []struct {
+		name    string
+		tmpl    string
+		want    string
+		wantErr bool
+	}{
+		{name: "plan and time", tmpl: "{{ .Plan }}-{{ .FormatTime .SnapshotTime }}", want: "docs-2024-03-10-14-10-41"},
+		{name: "short id", tmpl: "{{ .Repo }}-{{ .ShortId }}", want: "local-2e1d7ad2"},
+		{name: "path is made safe", tmpl: "{{ .Path }}", want: "home_user_docs"},
+		{name: "empty", tmpl: "{{ if false }}x{{ end }}", wantErr: true},
+		{name: "unknown field", tmpl: "{{ .Unknown }}", wantErr: true},
+		{name: "invalid", tmpl: "{{ .Plan", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Name(tc.tmpl, vars)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Name() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Name() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.txt":         "a",
+		"MANIFEST.json": "not the manifest",
+	}
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf, &Manifest{SnapshotId: "2e1d7ad2", Repo: "local", Path: "/"})
+	for name, contents := range files {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			t.Fatalf("failed to stat test file: %v", err)
+		}
+		if err := w.AddFile(name, info, strings.NewReader(contents)); err != nil {
+			t.Fatalf("AddFile() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("failed to read archive: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("failed to read %v: %v", hdr.Name, err)
+		}
+		entries[hdr.Name] = data
+	}
+
+	// the restore holds a file named like the manifest, the manifest is written under the fallback name.
+	if string(entries[ManifestName]) != files[ManifestName] {
+		t.Errorf("expected the restored %v to be kept, got %q", ManifestName, entries[ManifestName])
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(entries[fallbackManifestName], &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+	if manifest.Version != ManifestVersion || manifest.SnapshotId != "2e1d7ad2" || len(manifest.Files) != len(files) {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	for _, f := range manifest.Files {
+		sum := sha256.Sum256([]byte(files[f.Path]))
+		if f.Sha256 != hex.EncodeToString(sum[:]) || f.Size != int64(len(files[f.Path])) {
+			t.Errorf("unexpected manifest entry for %v: %+v", f.Path, f)
+		}
+	}
+}
+
+func TestZipWriter(t *testing.T) {
+	dir := t.TempDir()
+	files := []struct {
+		name     string
+		contents string
+		mode     os.FileMode
+		wantMode os.FileMode
+	}{
+		{name: "readonly.txt", contents: "read only", mode: 0444, wantMode: 0644},
+		{name: filepath.Join("bin", "run.sh"), contents: "#!/bin/sh", mode: 0700, wantMode: 0755},
+	}
+
+	for _, deflate := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		w := NewZipWriter(buf, &Manifest{SnapshotId: "2e1d7ad2", Repo: "local", Path: "/"}, deflate, 0)
+		for _, f := range files {
+			p := filepath.Join(dir, filepath.Base(f.name))
+			if err := os.WriteFile(p, []byte(f.contents), f.mode); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+			info, err := os.Stat(p)
+			if err != nil {
+				t.Fatalf("failed to stat test file: %v", err)
+			}
+			if err := w.AddFile(f.name, info, strings.NewReader(f.contents)); err != nil {
+				t.Fatalf("AddFile() error = %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("failed to read archive: %v", err)
+		}
+		wantMethod := zip.Store
+		if deflate {
+			wantMethod = zip.Deflate
+		}
+		entries := make(map[string]*zip.File)
+		for _, f := range zr.File {
+			entries[f.Name] = f
+			if f.Method != wantMethod {
+				t.Errorf("expected %v to be compressed with method %d, got %d", f.Name, wantMethod, f.Method)
+			}
+		}
+		for _, f := range files {
+			entry := entries[filepath.ToSlash(f.name)]
+			if entry == nil {
+				t.Fatalf("expected %v in the archive, got %v", f.name, entries)
+			}
+			if entry.Mode() != f.wantMode {
+				t.Errorf("expected %v to have mode %v, got %v", f.name, f.wantMode, entry.Mode())
+			}
+			rc, err := entry.Open()
+			if err != nil {
+				t.Fatalf("failed to open %v: %v", f.name, err)
+			}
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			if string(data) != f.contents {
+				t.Errorf("expected %v to hold %q, got %q", f.name, f.contents, data)
+			}
+		}
+		if entries[ManifestName] == nil {
+			t.Errorf("expected the manifest in the archive")
+		}
+	}
+}
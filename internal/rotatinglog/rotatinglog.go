@@ -17,6 +17,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/garethgeorge/backrest/internal/redact"
 	"go.uber.org/zap"
 )
 
@@ -36,6 +37,11 @@ func NewRotatingLog(dir string, maxLogFiles int) *RotatingLog {
 	return &RotatingLog{dir: dir, maxLogFiles: maxLogFiles}
 }
 
+// Dir returns the directory the log files are stored in.
+func (r *RotatingLog) Dir() string {
+	return r.dir
+}
+
 func (r *RotatingLog) curfile() string {
 	t := time.Now()
 	if r.now != nil {
@@ -66,7 +72,7 @@ func (r *RotatingLog) Write(data []byte) (string, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	data, err := compress(data)
+	data, err := compress(redact.Bytes(data))
 	if err != nil {
 		return "", err
 	}
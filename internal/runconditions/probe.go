@@ -0,0 +1,159 @@
+package runconditions
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const probeTimeout = 5 * time.Second
+
+const linuxPowerSupplyDir = "/sys/class/power_supply"
+
+const windowsBatteryStatusScript = `(Get-CimInstance -ClassName Win32_Battery).BatteryStatus`
+
+const windowsNetworkCostScript = `[Windows.Networking.Connectivity.NetworkInformation,Windows.Networking.Connectivity,ContentType=WindowsRuntime] | Out-Null; ` +
+	`[Windows.Networking.Connectivity.NetworkInformation]::GetInternetConnectionProfile().GetConnectionCost().NetworkCostType`
+
+type systemProbe struct{}
+
+func (systemProbe) PowerSource(ctx context.Context) PowerSource {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	switch runtime.GOOS {
+	case "linux":
+		return linuxPowerSource(linuxPowerSupplyDir)
+	case "darwin":
+		output, err := exec.CommandContext(ctx, "pmset", "-g", "batt").Output()
+		if err != nil {
+			zap.S().Warnf("probe power source: pmset: %v", err)
+			return PowerSourceUnknown
+		}
+		return parsePmsetPowerSource(string(output))
+	case "windows":
+		output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", windowsBatteryStatusScript).Output()
+		if err != nil {
+			zap.S().Warnf("probe power source: powershell: %v", err)
+			return PowerSourceUnknown
+		}
+		return parseWindowsBatteryStatus(string(output))
+	default:
+		return PowerSourceUnknown
+	}
+}
+
+func (systemProbe) NetworkCost(ctx context.Context) NetworkCost {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	switch runtime.GOOS {
+	case "linux":
+		output, err := exec.CommandContext(ctx, "nmcli", "-t", "-f", "GENERAL.METERED", "device", "show").Output()
+		if err != nil {
+			// NetworkManager is not available on all distributions, the network cost is unknown without it.
+			return NetworkCostUnknown
+		}
+		return parseNmcliMetered(string(output))
+	case "windows":
+		output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", windowsNetworkCostScript).Output()
+		if err != nil {
+			zap.S().Warnf("probe network cost: powershell: %v", err)
+			return NetworkCostUnknown
+		}
+		return parseWindowsNetworkCost(string(output))
+	default:
+		// macOS does not expose whether a connection is metered to command line tools.
+		return NetworkCostUnknown
+	}
+}
+
+// linuxPowerSource reads the power supplies listed in sysfs. A system without a battery is assumed to be on AC power.
+func linuxPowerSource(dir string) PowerSource {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return PowerSourceUnknown
+	}
+
+	hasBattery := false
+	for _, entry := range entries {
+		supplyType := readSysfsValue(filepath.Join(dir, entry.Name(), "type"))
+		switch supplyType {
+		case "Battery":
+			if readSysfsValue(filepath.Join(dir, entry.Name(), "scope")) == "Device" {
+				continue // batteries of peripherals e.g. a wireless mouse.
+			}
+			hasBattery = true
+		case "Mains", "USB":
+			if readSysfsValue(filepath.Join(dir, entry.Name(), "online")) == "1" {
+				return PowerSourceAC
+			}
+		}
+	}
+	if hasBattery {
+		return PowerSourceBattery
+	}
+	return PowerSourceAC
+}
+
+func readSysfsValue(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func parsePmsetPowerSource(output string) PowerSource {
+	switch {
+	case strings.Contains(output, "'AC Power'"):
+		return PowerSourceAC
+	case strings.Contains(output, "'Battery Power'"):
+		return PowerSourceBattery
+	default:
+		return PowerSourceUnknown
+	}
+}
+
+// parseWindowsBatteryStatus parses Win32_Battery.BatteryStatus, 1 indicates the battery is discharging. No output
+// indicates the system has no battery.
+func parseWindowsBatteryStatus(output string) PowerSource {
+	if strings.TrimSpace(output) == "1" {
+		return PowerSourceBattery
+	}
+	return PowerSourceAC
+}
+
+// parseNmcliMetered parses the GENERAL.METERED lines of nmcli's output, the connection is metered if any device is.
+func parseNmcliMetered(output string) NetworkCost {
+	cost := NetworkCostUnknown
+	for _, line := range strings.Split(output, "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "GENERAL.METERED:")
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(value, "yes") {
+			return NetworkCostMetered
+		} else if strings.HasPrefix(value, "no") {
+			cost = NetworkCostUnmetered
+		}
+	}
+	return cost
+}
+
+func parseWindowsNetworkCost(output string) NetworkCost {
+	switch strings.TrimSpace(output) {
+	case "Unrestricted":
+		return NetworkCostUnmetered
+	case "Fixed", "Variable":
+		return NetworkCostMetered
+	default:
+		return NetworkCostUnknown
+	}
+}
@@ -0,0 +1,56 @@
+package runconditions
+
+import (
+	"context"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+type PowerSource int
+
+const (
+	PowerSourceUnknown = PowerSource(iota)
+	PowerSourceAC
+	PowerSourceBattery
+)
+
+type NetworkCost int
+
+const (
+	NetworkCostUnknown = NetworkCost(iota)
+	NetworkCostUnmetered
+	NetworkCostMetered
+)
+
+// Probe reports the state of the system that run conditions are evaluated against.
+type Probe interface {
+	PowerSource(ctx context.Context) PowerSource
+	NetworkCost(ctx context.Context) NetworkCost
+}
+
+// SystemProbe probes the platform backrest is running on.
+var SystemProbe Probe = systemProbe{}
+
+// Check evaluates the conditions against the probed system state. Returns a human readable reason and false if a
+// condition is not met.
+func Check(ctx context.Context, probe Probe, conds *v1.RunConditions) (string, bool) {
+	if conds == nil {
+		return "", true
+	}
+
+	if conds.SkipOnBattery || conds.RequireAcPower {
+		power := probe.PowerSource(ctx)
+		if conds.SkipOnBattery && power == PowerSourceBattery {
+			return "skipped, the system is running on battery power", false
+		}
+		if conds.RequireAcPower && power != PowerSourceAC {
+			return "skipped, the system is not known to be on AC power", false
+		}
+	}
+
+	if conds.SkipOnMeteredNetwork && probe.NetworkCost(ctx) == NetworkCostMetered {
+		return "skipped, the network connection is metered", false
+	}
+
+	return "", true
+}
@@ -0,0 +1,113 @@
+package runconditions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+type fakeProbe struct {
+	power PowerSource
+	cost  NetworkCost
+}
+
+func (p fakeProbe) PowerSource(ctx context.Context) PowerSource { return p.power }
+func (p fakeProbe) NetworkCost(ctx context.Context) NetworkCost { return p.cost }
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		conds *v1.RunConditions
+		probe fakeProbe
+		want  bool
+	}{
+		{name: "no conditions", conds: nil, probe: fakeProbe{power: PowerSourceBattery}, want: true},
+		{name: "skip on battery while on battery", conds: &v1.RunConditions{SkipOnBattery: true}, probe: fakeProbe{power: PowerSourceBattery}, want: false},
+		{name: "skip on battery with unknown power", conds: &v1.RunConditions{SkipOnBattery: true}, probe: fakeProbe{power: PowerSourceUnknown}, want: true},
+		{name: "require ac power with unknown power", conds: &v1.RunConditions{RequireAcPower: true}, probe: fakeProbe{power: PowerSourceUnknown}, want: false},
+		{name: "require ac power on ac", conds: &v1.RunConditions{RequireAcPower: true}, probe: fakeProbe{power: PowerSourceAC}, want: true},
+		{name: "skip on metered while metered", conds: &v1.RunConditions{SkipOnMeteredNetwork: true}, probe: fakeProbe{cost: NetworkCostMetered}, want: false},
+		{name: "skip on metered with unknown cost", conds: &v1.RunConditions{SkipOnMeteredNetwork: true}, probe: fakeProbe{cost: NetworkCostUnknown}, want: true},
+	}
+
+	for _, tc := range tests {
+		reason, got := Check(context.Background(), tc.probe, tc.conds)
+		if got != tc.want {
+			t.Errorf("%s: Check() = %v, want %v", tc.name, got, tc.want)
+		}
+		if !got && reason == "" {
+			t.Errorf("%s: expected a reason when a condition is not met", tc.name)
+		}
+	}
+}
+
+func TestLinuxPowerSource(t *testing.T) {
+	writeSupply := func(t *testing.T, dir, name string, values map[string]string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		for k, v := range values {
+			if err := os.WriteFile(filepath.Join(dir, name, k), []byte(v+"\n"), 0644); err != nil {
+				t.Fatalf("write %s: %v", k, err)
+			}
+		}
+	}
+
+	t.Run("no battery", func(t *testing.T) {
+		dir := t.TempDir()
+		if got := linuxPowerSource(dir); got != PowerSourceAC {
+			t.Errorf("linuxPowerSource() = %v, want AC", got)
+		}
+	})
+
+	t.Run("on battery", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSupply(t, dir, "AC", map[string]string{"type": "Mains", "online": "0"})
+		writeSupply(t, dir, "BAT0", map[string]string{"type": "Battery"})
+		if got := linuxPowerSource(dir); got != PowerSourceBattery {
+			t.Errorf("linuxPowerSource() = %v, want battery", got)
+		}
+	})
+
+	t.Run("on ac with battery", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSupply(t, dir, "AC", map[string]string{"type": "Mains", "online": "1"})
+		writeSupply(t, dir, "BAT0", map[string]string{"type": "Battery"})
+		if got := linuxPowerSource(dir); got != PowerSourceAC {
+			t.Errorf("linuxPowerSource() = %v, want AC", got)
+		}
+	})
+
+	t.Run("peripheral battery only", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSupply(t, dir, "hidpp_battery_0", map[string]string{"type": "Battery", "scope": "Device"})
+		if got := linuxPowerSource(dir); got != PowerSourceAC {
+			t.Errorf("linuxPowerSource() = %v, want AC", got)
+		}
+	})
+}
+
+func TestParseProbeOutput(t *testing.T) {
+	if got := parsePmsetPowerSource("Now drawing from 'Battery Power'\n -InternalBattery-0 85%; discharging"); got != PowerSourceBattery {
+		t.Errorf("parsePmsetPowerSource() = %v, want battery", got)
+	}
+	if got := parsePmsetPowerSource("Now drawing from 'AC Power'"); got != PowerSourceAC {
+		t.Errorf("parsePmsetPowerSource() = %v, want AC", got)
+	}
+	if got := parseNmcliMetered("GENERAL.METERED:no\nGENERAL.METERED:yes (guessed)\n"); got != NetworkCostMetered {
+		t.Errorf("parseNmcliMetered() = %v, want metered", got)
+	}
+	if got := parseNmcliMetered("GENERAL.METERED:unknown\nGENERAL.METERED:no\n"); got != NetworkCostUnmetered {
+		t.Errorf("parseNmcliMetered() = %v, want unmetered", got)
+	}
+	if got := parseWindowsNetworkCost("Fixed\r\n"); got != NetworkCostMetered {
+		t.Errorf("parseWindowsNetworkCost() = %v, want metered", got)
+	}
+	if got := parseWindowsBatteryStatus("1\r\n"); got != PowerSourceBattery {
+		t.Errorf("parseWindowsBatteryStatus() = %v, want battery", got)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package sysinfo
+
+import "golang.org/x/sys/unix"
+
+func writable(path string) error {
+	return unix.Access(path, unix.W_OK)
+}
+
+func isRoot() bool {
+	return unix.Geteuid() == 0
+}
@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package sysinfo
+
+import (
+	"errors"
+	"os"
+)
+
+func writable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		// the read-only attribute of directories doesn't prevent creating files in them.
+		return nil
+	}
+	if info.Mode().Perm()&0200 == 0 {
+		return errors.New("file is read-only")
+	}
+	return nil
+}
+
+// isRoot is false on Windows, restic doesn't restore ownership there.
+func isRoot() bool {
+	return false
+}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package sysinfo
+
+import "syscall"
+
+func disk(path string) (DiskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return DiskUsage{}, err
+	}
+	return DiskUsage{
+		FreeBytes:  uint64(stat.Bavail) * uint64(stat.Bsize),
+		TotalBytes: uint64(stat.Blocks) * uint64(stat.Bsize),
+	}, nil
+}
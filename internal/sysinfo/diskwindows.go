@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package sysinfo
+
+import "golang.org/x/sys/windows"
+
+func disk(path string) (DiskUsage, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+	var free, total uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &free, &total, nil); err != nil {
+		return DiskUsage{}, err
+	}
+	return DiskUsage{FreeBytes: free, TotalBytes: total}, nil
+}
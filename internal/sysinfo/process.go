@@ -0,0 +1,37 @@
+//go:build linux
+// +build linux
+
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func process(pid int) (ProcessUsage, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return ProcessUsage{}, err
+	}
+	cpu, err := parseProcStat(stat)
+	if err != nil {
+		return ProcessUsage{}, err
+	}
+	statm, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return ProcessUsage{}, err
+	}
+	rss, err := parseProcStatm(statm, os.Getpagesize())
+	if err != nil {
+		return ProcessUsage{}, err
+	}
+	return ProcessUsage{CPUTime: cpu, RSSBytes: rss}, nil
+}
+
+func peakRSS(state *os.ProcessState) uint64 {
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok && rusage.Maxrss > 0 {
+		return uint64(rusage.Maxrss) * 1024 // in KiB on Linux.
+	}
+	return 0
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+// +build !linux
+
+package sysinfo
+
+import (
+	"errors"
+	"os"
+)
+
+func process(pid int) (ProcessUsage, error) {
+	return ProcessUsage{}, errors.New("not supported on this platform")
+}
+
+func peakRSS(state *os.ProcessState) uint64 {
+	return 0
+}
@@ -0,0 +1,152 @@
+// Package sysinfo reads the resource usage of the system backrest runs on.
+package sysinfo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiskUsage is the usage of the filesystem holding a path.
+type DiskUsage struct {
+	FreeBytes  uint64 // bytes available to unprivileged users.
+	TotalBytes uint64
+}
+
+// Disk returns the usage of the filesystem holding path.
+func Disk(path string) (DiskUsage, error) {
+	usage, err := disk(path)
+	if err != nil {
+		return DiskUsage{}, fmt.Errorf("disk usage of %q: %w", path, err)
+	}
+	return usage, nil
+}
+
+// Writable returns an error if backrest can't write to the file or create files in the directory at path, e.g. an
+// error wrapping syscall.EROFS if it's on a read-only filesystem.
+func Writable(path string) error {
+	if err := writable(path); err != nil {
+		return fmt.Errorf("write access to %q: %w", path, err)
+	}
+	return nil
+}
+
+// IsRoot returns true if backrest runs with root privileges e.g. to restore the ownership of files.
+func IsRoot() bool {
+	return isRoot()
+}
+
+// MemoryUsage is the memory of the system and of the backrest process.
+type MemoryUsage struct {
+	TotalBytes     uint64 // total memory of the system, 0 if unknown.
+	AvailableBytes uint64 // memory available to new processes without swapping, 0 if unknown.
+	ProcessBytes   uint64 // memory obtained from the OS by this process.
+}
+
+// Memory returns the memory usage of the system and of this process. The system's memory is only read on Linux.
+func Memory() MemoryUsage {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	usage := MemoryUsage{ProcessBytes: stats.Sys}
+	if data, err := os.ReadFile("/proc/meminfo"); err == nil {
+		usage.TotalBytes, usage.AvailableBytes = parseMeminfo(data)
+	}
+	return usage
+}
+
+// parseMeminfo returns the MemTotal and MemAvailable of the contents of /proc/meminfo in bytes.
+func parseMeminfo(data []byte) (total, available uint64) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			n *= 1024
+		}
+		switch key {
+		case "MemTotal":
+			total = n
+		case "MemAvailable":
+			available = n
+		}
+	}
+	return total, available
+}
+
+// ProcessUsage is the resource usage of a running process.
+type ProcessUsage struct {
+	CPUTime  time.Duration // user and system CPU time used so far.
+	RSSBytes uint64        // resident memory.
+}
+
+// Process returns the usage of the running process with the pid. It's only read on Linux, an error is returned
+// elsewhere.
+func Process(pid int) (ProcessUsage, error) {
+	usage, err := process(pid)
+	if err != nil {
+		return ProcessUsage{}, fmt.Errorf("usage of process %d: %w", pid, err)
+	}
+	return usage, nil
+}
+
+// PeakRSS returns the highest resident memory of an exited process, 0 if it's not reported on this platform.
+func PeakRSS(state *os.ProcessState) uint64 {
+	if state == nil {
+		return 0
+	}
+	return peakRSS(state)
+}
+
+// clockTicksPerSecond is the unit of the CPU times in /proc/<pid>/stat, USER_HZ is 100 on every platform Linux supports.
+const clockTicksPerSecond = 100
+
+// parseProcStat returns the user and system CPU time in the contents of /proc/<pid>/stat.
+func parseProcStat(data []byte) (time.Duration, error) {
+	// the command name in the second field is in parentheses and may contain spaces.
+	i := bytes.LastIndexByte(data, ')')
+	if i < 0 {
+		return 0, fmt.Errorf("malformed stat %q", data)
+	}
+	fields := strings.Fields(string(data[i+1:]))
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("malformed stat %q", data)
+	}
+	// fields starts at the state, the third field, utime and stime are the 14th and 15th fields.
+	var ticks uint64
+	for _, f := range fields[11:13] {
+		n, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("malformed stat %q: %w", data, err)
+		}
+		ticks += n
+	}
+	return time.Duration(ticks) * time.Second / clockTicksPerSecond, nil
+}
+
+// parseProcStatm returns the resident memory in bytes in the contents of /proc/<pid>/statm.
+func parseProcStatm(data []byte, pageSize int) (uint64, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("malformed statm %q", data)
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed statm %q: %w", data, err)
+	}
+	return pages * uint64(pageSize), nil
+}
@@ -0,0 +1,63 @@
+package sysinfo
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestDisk(t *testing.T) {
+	usage, err := Disk(t.TempDir())
+	if err != nil {
+		t.Fatalf("Disk() error: %v", err)
+	}
+	if usage.TotalBytes == 0 || usage.FreeBytes > usage.TotalBytes {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+
+	if _, err := Disk(t.TempDir() + "/missing"); err == nil {
+		t.Errorf("expected an error for a missing path")
+	}
+}
+
+func TestParseMeminfo(t *testing.T) {
+	total, available := parseMeminfo([]byte("MemTotal:       16318440 kB\nMemFree:         1031264 kB\nMemAvailable:    9243220 kB\nHugePages_Total:       0\n"))
+	if total != 16318440*1024 {
+		t.Errorf("want total %d, got %d", 16318440*1024, total)
+	}
+	if available != 9243220*1024 {
+		t.Errorf("want available %d, got %d", 9243220*1024, available)
+	}
+}
+
+func TestParseProcStat(t *testing.T) {
+	cpu, err := parseProcStat([]byte("4242 (restic (v0.17)) S 1 4242 4242 0 -1 4194560 52617 0 0 0 1250 375 0 0 20 0 12 0 88261 1463119872 38018 18446744073709551615\n"))
+	if err != nil {
+		t.Fatalf("parseProcStat() error: %v", err)
+	}
+	if want := 16250 * time.Millisecond; cpu != want {
+		t.Errorf("want cpu time %v, got %v", want, cpu)
+	}
+
+	rss, err := parseProcStatm([]byte("357207 38018 3151 3960 0 47291 0\n"), 4096)
+	if err != nil {
+		t.Fatalf("parseProcStatm() error: %v", err)
+	}
+	if rss != 38018*4096 {
+		t.Errorf("want rss %d, got %d", 38018*4096, rss)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("process usage is only read on Linux")
+	}
+	usage, err := Process(os.Getpid())
+	if err != nil {
+		t.Fatalf("Process() error: %v", err)
+	}
+	if usage.RSSBytes == 0 {
+		t.Errorf("expected the test's resident memory, got %+v", usage)
+	}
+}
@@ -0,0 +1,277 @@
+// Package client is a Go client for the backrest API. It wraps the generated Connect clients with authentication,
+// retries of requests that failed to reach the server and helpers for following operations as they run.
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/gen/go/v1/v1connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultRetryAttempts = 3
+	defaultRetryBackoff  = 500 * time.Millisecond
+)
+
+// Client calls the backrest API of an instance. All of the API's methods are available through the embedded
+// BackrestClient.
+type Client struct {
+	v1connect.BackrestClient
+	Auth v1connect.AuthenticationClient
+
+	baseURL    string
+	httpClient connect.HTTPClient
+	creds      *credentials
+	retries    int
+	backoff    time.Duration
+}
+
+type options struct {
+	httpClient    connect.HTTPClient
+	creds         credentials
+	retryAttempts int
+	retryBackoff  time.Duration
+}
+
+type Option func(*options)
+
+// WithHTTPClient sets the HTTP client used for requests, defaults to http.DefaultClient.
+func WithHTTPClient(c connect.HTTPClient) Option {
+	return func(o *options) {
+		o.httpClient = c
+	}
+}
+
+// WithBasicAuth authenticates every request with the user's name and password.
+func WithBasicAuth(username, password string) Option {
+	return func(o *options) {
+		o.creds.basic = base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	}
+}
+
+// WithToken authenticates every request with a token returned by the Login API, see also Client.Login.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.creds.token = token
+	}
+}
+
+// WithRetries sets the attempts made of requests failing with connect.CodeUnavailable e.g. because the instance is
+// restarting. The backoff doubles after each attempt. Defaults to 3 attempts with a 500ms initial backoff, attempts
+// below 1 disable retries.
+func WithRetries(attempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.retryAttempts = attempts
+		o.retryBackoff = backoff
+	}
+}
+
+// New returns a client calling the API of the instance served at baseURL e.g. "http://localhost:9898".
+func New(baseURL string, opts ...Option) *Client {
+	o := &options{
+		httpClient:    http.DefaultClient,
+		retryAttempts: defaultRetryAttempts,
+		retryBackoff:  defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: o.httpClient,
+		creds:      &o.creds,
+		retries:    o.retryAttempts,
+		backoff:    o.retryBackoff,
+	}
+	interceptors := connect.WithInterceptors(&authInterceptor{creds: c.creds}, &retryInterceptor{attempts: o.retryAttempts, backoff: o.retryBackoff})
+	c.BackrestClient = v1connect.NewBackrestClient(o.httpClient, baseURL, interceptors)
+	c.Auth = v1connect.NewAuthenticationClient(o.httpClient, baseURL, interceptors)
+	return c
+}
+
+// Login exchanges the user's name and password for a token that authenticates the client's later requests.
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	res, err := c.Auth.Login(ctx, connect.NewRequest(&v1.LoginRequest{
+		Username: username,
+		Password: password,
+	}))
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	c.creds.setToken(res.Msg.Token)
+	return nil
+}
+
+// WatchOperations calls f with each operation event until ctx is done or f returns an error, which is returned.
+func (c *Client) WatchOperations(ctx context.Context, f func(*v1.OperationEvent) error) error {
+	stream, closeStream, err := c.operationEvents(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStream()
+	for stream.Receive() {
+		if err := f(stream.Msg()); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := stream.Err(); err != nil {
+		return fmt.Errorf("operation events: %w", err)
+	}
+	return errors.New("operation events: stream closed by the server")
+}
+
+// WaitForOperation blocks until the operation has finished and returns it in its final state.
+func (c *Client) WaitForOperation(ctx context.Context, opID int64) (*v1.Operation, error) {
+	// the stream is opened before the operation's current state is fetched so that an update in between isn't missed.
+	stream, closeStream, err := c.operationEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream()
+
+	res, err := c.GetOperations(ctx, connect.NewRequest(&v1.GetOperationsRequest{Ids: []int64{opID}}))
+	if err != nil {
+		return nil, fmt.Errorf("get operation %d: %w", opID, err)
+	}
+	if len(res.Msg.Operations) == 0 {
+		return nil, fmt.Errorf("operation %d not found", opID)
+	}
+	if op := res.Msg.Operations[0]; isDone(op) {
+		return op, nil
+	}
+
+	for stream.Receive() {
+		event := stream.Msg()
+		if event.Operation.GetId() != opID {
+			continue
+		}
+		if event.Type == v1.OperationEventType_EVENT_DELETED {
+			return nil, fmt.Errorf("operation %d was deleted", opID)
+		}
+		if isDone(event.Operation) {
+			return event.Operation, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, fmt.Errorf("operation events closed before operation %d finished: %w", opID, stream.Err())
+}
+
+// operationEvents opens a stream of operation events. The event stream only ends when the server stops, closing it
+// cancels the request rather than waiting for the server to finish the stream.
+func (c *Client) operationEvents(ctx context.Context) (*connect.ServerStreamForClient[v1.OperationEvent], func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.GetOperationEvents(ctx, connect.NewRequest(&emptypb.Empty{}))
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("get operation events: %w", err)
+	}
+	return stream, func() {
+		cancel()
+		stream.Close()
+	}, nil
+}
+
+func isDone(op *v1.Operation) bool {
+	return op.Status != v1.OperationStatus_STATUS_PENDING && op.Status != v1.OperationStatus_STATUS_INPROGRESS
+}
+
+// credentials are shared by a client's interceptors, the token is replaced by Login.
+type credentials struct {
+	mu    sync.RWMutex
+	basic string
+	token string
+}
+
+func (c *credentials) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+func (c *credentials) header() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.token != "" {
+		return "Bearer " + c.token
+	} else if c.basic != "" {
+		return "Basic " + c.basic
+	}
+	return ""
+}
+
+type authInterceptor struct {
+	creds *credentials
+}
+
+func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if h := i.creds.header(); h != "" {
+			req.Header().Set("Authorization", h)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		if h := i.creds.header(); h != "" {
+			conn.RequestHeader().Set("Authorization", h)
+		}
+		return conn
+	}
+}
+
+func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// retryInterceptor retries unary requests failing with connect.CodeUnavailable, which connect returns if the request
+// couldn't be sent e.g. the connection was refused.
+type retryInterceptor struct {
+	attempts int
+	backoff  time.Duration
+}
+
+func (i *retryInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		backoff := i.backoff
+		for attempt := 1; ; attempt++ {
+			res, err := next(ctx, req)
+			if err == nil || attempt >= i.attempts || connect.CodeOf(err) != connect.CodeUnavailable {
+				return res, err
+			}
+			select {
+			case <-ctx.Done():
+				return nil, err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+}
+
+func (i *retryInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *retryInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/garethgeorge/backrest/gen/go/types"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+	"github.com/garethgeorge/backrest/gen/go/v1/v1connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type fakeBackrest struct {
+	v1connect.UnimplementedBackrestHandler
+	v1connect.UnimplementedAuthenticationHandler
+
+	failures int      // GetConfig fails with CodeUnavailable this many times.
+	auth     []string // the Authorization header of each GetConfig request.
+	offsets  []int    // the offset of each archive download.
+	expire   bool     // archive URLs expire after their first download.
+	urls     []string // the archive URLs downloaded from.
+}
+
+func (f *fakeBackrest) GetConfig(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.Config], error) {
+	f.auth = append(f.auth, req.Header().Get("Authorization"))
+	if f.failures > 0 {
+		f.failures--
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("restarting"))
+	}
+	return connect.NewResponse(&v1.Config{Instance: "test"}), nil
+}
+
+func (f *fakeBackrest) Login(ctx context.Context, req *connect.Request[v1.LoginRequest]) (*connect.Response[v1.LoginResponse], error) {
+	if req.Msg.Username != "alice" || req.Msg.Password != "hunter22" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("bad credentials"))
+	}
+	return connect.NewResponse(&v1.LoginResponse{Token: "token"}), nil
+}
+
+func (f *fakeBackrest) GetOperations(ctx context.Context, req *connect.Request[v1.GetOperationsRequest]) (*connect.Response[v1.OperationList], error) {
+	return connect.NewResponse(&v1.OperationList{Operations: []*v1.Operation{
+		{Id: 2, Status: v1.OperationStatus_STATUS_INPROGRESS},
+	}}), nil
+}
+
+func (f *fakeBackrest) GetOperationEvents(ctx context.Context, req *connect.Request[emptypb.Empty], stream *connect.ServerStream[v1.OperationEvent]) error {
+	for _, op := range []*v1.Operation{
+		{Id: 1, Status: v1.OperationStatus_STATUS_SUCCESS},
+		{Id: 2, Status: v1.OperationStatus_STATUS_INPROGRESS},
+		{Id: 2, Status: v1.OperationStatus_STATUS_ERROR, DisplayMessage: "failed"},
+	} {
+		if err := stream.Send(&v1.OperationEvent{Type: v1.OperationEventType_EVENT_UPDATED, Operation: op}); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (f *fakeBackrest) GetSnapshotArchiveURL(ctx context.Context, req *connect.Request[v1.ListSnapshotFilesRequest]) (*connect.Response[types.StringValue], error) {
+	sig := fmt.Sprintf("sig%d", len(f.urls))
+	return connect.NewResponse(&types.StringValue{Value: "./download/archive/" + sig + "/" + req.Msg.RepoId + "/" + req.Msg.SnapshotId + "/"}), nil
+}
+
+// serveArchive serves archive, the first response is cut off after half of it as if the connection dropped.
+func (f *fakeBackrest) serveArchive(w http.ResponseWriter, r *http.Request) {
+	if f.expire && slices.Contains(f.urls, r.URL.Path) {
+		http.Error(w, "download URL expired", http.StatusGone)
+		return
+	}
+	f.urls = append(f.urls, r.URL.Path)
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	f.offsets = append(f.offsets, offset)
+	data := archive[offset:]
+	if len(f.offsets) == 1 {
+		w.Write(data[:len(data)/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	w.Write(data)
+}
+
+var archive = []byte(strings.Repeat("tar archive ", 1000))
+
+func newTestServer(t *testing.T, f *fakeBackrest) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(v1connect.NewBackrestHandler(f))
+	mux.Handle(v1connect.NewAuthenticationHandler(f))
+	mux.HandleFunc("/download/archive/", f.serveArchive)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRetriesAndAuth(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeBackrest{failures: 2}
+	srv := newTestServer(t, f)
+	c := New(srv.URL+"/", WithBasicAuth("alice", "hunter22"), WithRetries(3, time.Millisecond))
+
+	res, err := c.GetConfig(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	if err != nil {
+		t.Fatalf("GetConfig() error: %v", err)
+	}
+	if res.Msg.Instance != "test" {
+		t.Errorf("unexpected config %v", res.Msg)
+	}
+	if len(f.auth) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(f.auth))
+	}
+	if f.auth[2] != "Basic YWxpY2U6aHVudGVyMjI=" {
+		t.Errorf("expected basic auth, got %q", f.auth[2])
+	}
+
+	f.failures = 3
+	if _, err := c.GetConfig(context.Background(), connect.NewRequest(&emptypb.Empty{})); connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Errorf("expected the last attempt's error, got %v", err)
+	}
+
+	if err := c.Login(context.Background(), "alice", "wrong"); err == nil {
+		t.Errorf("expected a login error")
+	}
+	if err := c.Login(context.Background(), "alice", "hunter22"); err != nil {
+		t.Fatalf("Login() error: %v", err)
+	}
+	f.auth = nil
+	if _, err := c.GetConfig(context.Background(), connect.NewRequest(&emptypb.Empty{})); err != nil {
+		t.Fatalf("GetConfig() error: %v", err)
+	}
+	if f.auth[0] != "Bearer token" {
+		t.Errorf("expected the login token to be used, got %q", f.auth[0])
+	}
+}
+
+func TestWaitForOperation(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, &fakeBackrest{})
+	c := New(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	op, err := c.WaitForOperation(ctx, 2)
+	if err != nil {
+		t.Fatalf("WaitForOperation() error: %v", err)
+	}
+	if op.Status != v1.OperationStatus_STATUS_ERROR || op.DisplayMessage != "failed" {
+		t.Errorf("expected the operation's final state, got %v", op)
+	}
+
+	var events int
+	err = c.WatchOperations(ctx, func(e *v1.OperationEvent) error {
+		if events++; events == 3 {
+			return errors.New("done")
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "done" {
+		t.Errorf("expected the callback's error, got %v", err)
+	}
+}
+
+func TestDownloadSnapshotArchive(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeBackrest{}
+	srv := newTestServer(t, f)
+	c := New(srv.URL, WithRetries(3, time.Millisecond))
+
+	var buf bytes.Buffer
+	n, err := c.DownloadSnapshotArchive(context.Background(), "repo", "abcdef", "", &buf)
+	if err != nil {
+		t.Fatalf("DownloadSnapshotArchive() error: %v", err)
+	}
+	if n != int64(len(archive)) || !bytes.Equal(buf.Bytes(), archive) {
+		t.Errorf("expected the whole archive, got %d bytes", n)
+	}
+	if len(f.offsets) != 2 || f.offsets[0] != 0 || f.offsets[1] != len(archive)/2 {
+		t.Errorf("expected the download to resume after the received bytes, got offsets %v", f.offsets)
+	}
+
+	// a URL that expired is replaced by a new one to resume the download.
+	f = &fakeBackrest{expire: true}
+	srv = newTestServer(t, f)
+	c = New(srv.URL, WithRetries(3, time.Millisecond))
+	buf.Reset()
+	if n, err := c.DownloadSnapshotArchive(context.Background(), "repo", "abcdef", "", &buf); err != nil || n != int64(len(archive)) || !bytes.Equal(buf.Bytes(), archive) {
+		t.Errorf("expected the whole archive, got %d bytes: %v", n, err)
+	}
+	if len(f.urls) != 2 || f.urls[0] == f.urls[1] || len(f.offsets) != 2 {
+		t.Errorf("expected the download to resume with a new URL, got URLs %v and offsets %v", f.urls, f.offsets)
+	}
+}
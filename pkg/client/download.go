@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/garethgeorge/backrest/gen/go/v1"
+)
+
+// DownloadSnapshotArchive writes the directory at dirPath in the snapshot, the whole snapshot if dirPath is empty, to w
+// as an uncompressed tar archive e.g. to pipe it into tar -x on a new machine. Interrupted downloads are resumed where
+// they stopped, with the client's retry attempts and backoff, and with a new URL if the archive's URL expired. It
+// returns the bytes written to w.
+func (c *Client) DownloadSnapshotArchive(ctx context.Context, repoID, snapshotID, dirPath string, w io.Writer) (int64, error) {
+	archiveURL, err := c.snapshotArchiveURL(ctx, repoID, snapshotID, dirPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var written int64
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		n, err := c.downloadFrom(ctx, archiveURL, written, w)
+		written += n
+		var httpErr *downloadStatusError
+		if errors.As(err, &httpErr) && httpErr.code == http.StatusGone && written > 0 && attempt < c.retries {
+			// the URL expired while the archive was downloading.
+			if archiveURL, err = c.snapshotArchiveURL(ctx, repoID, snapshotID, dirPath); err != nil {
+				return written, err
+			}
+			continue
+		}
+		if err == nil || ctx.Err() != nil || errors.As(err, &httpErr) || attempt >= c.retries {
+			return written, err
+		}
+		select {
+		case <-ctx.Done():
+			return written, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) snapshotArchiveURL(ctx context.Context, repoID, snapshotID, dirPath string) (string, error) {
+	res, err := c.GetSnapshotArchiveURL(ctx, connect.NewRequest(&v1.ListSnapshotFilesRequest{
+		RepoId:     repoID,
+		SnapshotId: snapshotID,
+		Path:       dirPath,
+	}))
+	if err != nil {
+		return "", fmt.Errorf("get snapshot archive URL: %w", err)
+	}
+	return c.baseURL + strings.TrimPrefix(res.Msg.Value, "."), nil
+}
+
+type downloadStatusError struct {
+	code   int
+	status string
+	body   string
+}
+
+func (e *downloadStatusError) Error() string {
+	return fmt.Sprintf("download failed with status %v: %v", e.status, e.body)
+}
+
+// downloadFrom copies the archive at url to w starting at offset, it returns the bytes copied.
+func (c *Client) downloadFrom(ctx context.Context, url string, offset int64, w io.Writer) (int64, error) {
+	if offset > 0 {
+		url += "?offset=" + strconv.FormatInt(offset, 10)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("download snapshot archive: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, &downloadStatusError{code: resp.StatusCode, status: resp.Status, body: strings.TrimSpace(string(body))}
+	}
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("download snapshot archive: %w", err)
+	}
+	return n, nil
+}
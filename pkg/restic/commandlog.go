@@ -0,0 +1,203 @@
+package restic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/garethgeorge/backrest/internal/ioutil"
+)
+
+// CommandLogDir stores the output of commands in a directory, one log file per name e.g. per operation. Logs are
+// streamed to disk as the commands run so long outputs, e.g. of a prune, aren't kept in memory. The oldest logs are
+// removed once the directory holds more than maxFiles.
+type CommandLogDir struct {
+	mu       sync.Mutex
+	dir      string
+	maxFiles int
+
+	MaxFileSize int64               // output past this size is dropped, 0 for no limit.
+	TailLimit   int                 // bytes of the end of each log kept in memory.
+	Redact      func([]byte) []byte // optional, applied to each line before it's written.
+}
+
+func NewCommandLogDir(dir string, maxFiles int) *CommandLogDir {
+	return &CommandLogDir{
+		dir:         dir,
+		maxFiles:    maxFiles,
+		MaxFileSize: 16 * 1024 * 1024,
+		TailLimit:   4 * 1024,
+	}
+}
+
+// Create creates the log with the given name, replacing any previous log of that name.
+func (d *CommandLogDir) Create(name string) (*CommandLog, error) {
+	if err := validateLogName(name); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return nil, fmt.Errorf("create log dir: %w", err)
+	}
+	if err := d.removeExpired(); err != nil {
+		return nil, fmt.Errorf("remove expired logs: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(d.dir, name), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("create log: %w", err)
+	}
+	return &CommandLog{
+		f:       f,
+		path:    f.Name(),
+		maxSize: d.MaxFileSize,
+		tail:    ioutil.TailWriter{Limit: d.TailLimit},
+		redact:  d.Redact,
+	}, nil
+}
+
+// Open opens the log with the given name for reading.
+func (d *CommandLogDir) Open(name string) (*os.File, error) {
+	if err := validateLogName(name); err != nil {
+		return nil, err
+	}
+	return os.Open(filepath.Join(d.dir, name))
+}
+
+// removeExpired removes the oldest logs so that a new log can be created without exceeding maxFiles.
+func (d *CommandLogDir) removeExpired() error {
+	if d.maxFiles <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+	entries = slices.DeleteFunc(entries, func(e fs.DirEntry) bool { return !e.Type().IsRegular() })
+	if len(entries) < d.maxFiles {
+		return nil
+	}
+	type logFile struct {
+		name    string
+		modTime int64
+	}
+	files := make([]logFile, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue // removed concurrently.
+		}
+		files = append(files, logFile{name: e.Name(), modTime: info.ModTime().UnixNano()})
+	}
+	slices.SortFunc(files, func(a, b logFile) int {
+		if a.modTime != b.modTime {
+			return int(a.modTime - b.modTime)
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	for _, f := range files[:len(files)-d.maxFiles+1] {
+		if err := os.Remove(filepath.Join(d.dir, f.name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLogName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid log name %q", name)
+	}
+	return nil
+}
+
+// CommandLog is a log file commands stream their output to, it's safe for concurrent use. Output is written a line at a
+// time so that each line can be redacted as a whole, the end of the log is also kept in memory.
+type CommandLog struct {
+	mu      sync.Mutex
+	f       *os.File
+	path    string
+	partial []byte // the last line written, until it's complete.
+	size    int64  // bytes written to the file.
+	dropped int64  // bytes dropped since the file reached maxSize, later output is only kept in the tail.
+	maxSize int64
+	tail    ioutil.TailWriter
+	redact  func([]byte) []byte
+	err     error // the first error writing the file, later output is only kept in the tail.
+}
+
+func (l *CommandLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.partial = append(l.partial, p...)
+	if idx := bytes.LastIndexByte(l.partial, '\n'); idx != -1 {
+		l.writeLines(l.partial[:idx+1])
+		l.partial = slices.Clone(l.partial[idx+1:])
+	}
+	return len(p), nil
+}
+
+// writeLines writes complete lines to the file and the tail.
+func (l *CommandLog) writeLines(lines []byte) {
+	if l.redact != nil {
+		lines = l.redact(lines)
+	}
+	l.tail.Write(lines)
+	if l.err != nil || l.f == nil {
+		return
+	}
+	if l.dropped > 0 || l.maxSize > 0 && l.size+int64(len(lines)) > l.maxSize {
+		l.dropped += int64(len(lines))
+		return
+	}
+	n, err := l.f.Write(lines)
+	l.size += int64(n)
+	if err != nil {
+		l.err = err
+	}
+}
+
+// Size returns the number of bytes written to the log file.
+func (l *CommandLog) Size() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.size
+}
+
+// Tail returns the end of the output written so far.
+func (l *CommandLog) Tail() []byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append(l.tail.Bytes(), l.partial...)
+}
+
+// Close writes any incomplete last line and closes the file, it returns the first error writing the file. Logs that
+// nothing was written to are removed.
+func (l *CommandLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return l.err
+	}
+	if len(l.partial) > 0 {
+		l.writeLines(append(l.partial, '\n'))
+		l.partial = nil
+	}
+	if l.dropped > 0 && l.err == nil {
+		_, l.err = fmt.Fprintf(l.f, "...[%d bytes dropped, the log reached its size limit of %d bytes]...\n", l.dropped, l.maxSize)
+	}
+	if err := l.f.Close(); err != nil && l.err == nil {
+		l.err = err
+	}
+	l.f = nil
+	if l.size == 0 && l.dropped == 0 {
+		os.Remove(l.path)
+	}
+	return l.err
+}
@@ -0,0 +1,125 @@
+package restic
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCommandLog(t *testing.T) {
+	dir := NewCommandLogDir(t.TempDir(), 0)
+	dir.MaxFileSize = 64
+	dir.TailLimit = 16
+	dir.Redact = func(b []byte) []byte { return bytes.ReplaceAll(b, []byte("secret"), []byte("******")) }
+
+	log, err := dir.Create("op-1.log")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	// the secret is split across writes, it's still redacted as lines are redacted whole.
+	io.WriteString(log, "first line sec")
+	io.WriteString(log, "ret\n")
+	io.WriteString(log, strings.Repeat("x", 60)+"\n")
+	io.WriteString(log, "last")
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got := string(log.Tail()); got != strings.Repeat("x", 10)+"\nlast\n" {
+		t.Errorf("Tail() = %q", got)
+	}
+	f, err := dir.Open("op-1.log")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "first line ******\n") {
+		t.Errorf("log doesn't start with the redacted first line: %q", data)
+	}
+	if !strings.Contains(string(data), "66 bytes dropped") {
+		t.Errorf("log doesn't note the output dropped past its size limit: %q", data)
+	}
+}
+
+func TestCommandLogConcurrentWrites(t *testing.T) {
+	dir := NewCommandLogDir(t.TempDir(), 0)
+	log, err := dir.Create("op-1.log")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				fmt.Fprintf(log, "writer %d line %d\n", i, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir.dir, "op-1.log"))
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1000 {
+		t.Errorf("log has %d lines, want 1000", lines)
+	}
+}
+
+func TestCommandLogDir(t *testing.T) {
+	dir := NewCommandLogDir(t.TempDir(), 2)
+	for i := 0; i < 3; i++ {
+		log, err := dir.Create(fmt.Sprintf("op-%d.log", i))
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		fmt.Fprintf(log, "log %d\n", i)
+		if err := log.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	// only the newest logs are kept.
+	if _, err := dir.Open("op-0.log"); !os.IsNotExist(err) {
+		t.Errorf("expected the oldest log to be removed, got error %v", err)
+	}
+	for _, name := range []string{"op-1.log", "op-2.log"} {
+		f, err := dir.Open(name)
+		if err != nil {
+			t.Errorf("Open(%q) error = %v", name, err)
+			continue
+		}
+		f.Close()
+	}
+
+	// empty logs aren't kept.
+	log, err := dir.Create("empty.log")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := dir.Open("empty.log"); !os.IsNotExist(err) {
+		t.Errorf("expected the empty log to be removed, got error %v", err)
+	}
+
+	for _, name := range []string{"", "..", "../op-1.log", "a/b"} {
+		if _, err := dir.Open(name); err == nil {
+			t.Errorf("Open(%q) expected an error", name)
+		}
+	}
+}
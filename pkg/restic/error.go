@@ -2,8 +2,10 @@ package restic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
 )
 
 const outputBufferLimit = 1000
@@ -59,3 +61,55 @@ func newCmdErrorPreformatted(ctx context.Context, cmd *exec.Cmd, output string,
 	}
 	return cerr
 }
+
+// ErrorClass classifies a restic error by whether retrying the command may succeed.
+type ErrorClass int
+
+const (
+	ErrorClassPermanent ErrorClass = iota // retrying is not expected to help e.g. a bad password or a missing path.
+	ErrorClassTransient                   // the failure is likely temporary e.g. a network failure or a locked repo.
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassTransient:
+		return "transient"
+	default:
+		return "permanent"
+	}
+}
+
+// transientErrorMarkers are substrings of restic's output that indicate a failure that may succeed on retry.
+var transientErrorMarkers = []string{
+	"connection refused",
+	"connection reset",
+	"connection timed out",
+	"i/o timeout",
+	"no such host",
+	"temporary failure in name resolution",
+	"network is unreachable",
+	"tls handshake timeout",
+	"broken pipe",
+	"unexpected eof",
+	"server misbehaving",
+	"503 service unavailable",
+	"502 bad gateway",
+	"504 gateway timeout",
+	"429 too many requests",
+	"repository is already locked",
+	"unable to create lock",
+}
+
+// ClassifyError returns whether err is likely a transient failure that may succeed if the command is retried.
+func ClassifyError(err error) ErrorClass {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassPermanent
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range transientErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return ErrorClassTransient
+		}
+	}
+	return ErrorClassPermanent
+}
@@ -0,0 +1,30 @@
+package restic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{name: "nil", err: nil, want: ErrorClassPermanent},
+		{name: "wrong password", err: &CmdError{Command: "restic backup", Err: errors.New("exit status 1"), Output: "Fatal: wrong password or no key found"}, want: ErrorClassPermanent},
+		{name: "connection refused", err: &CmdError{Command: "restic backup", Err: errors.New("exit status 1"), Output: "Save(<data/abc>) returned error: dial tcp 10.0.0.1:443: connect: connection refused"}, want: ErrorClassTransient},
+		{name: "locked repo", err: fmt.Errorf("backup: %w", &CmdError{Command: "restic backup", Err: errors.New("exit status 1"), Output: "unable to create lock in backend: repository is already locked"}), want: ErrorClassTransient},
+		{name: "cancelled", err: fmt.Errorf("i/o timeout: %w", context.Canceled), want: ErrorClassPermanent},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ClassifyError(tc.err); got != tc.want {
+				t.Errorf("ClassifyError() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@ package restic
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	v1 "github.com/garethgeorge/backrest/gen/go/v1"
@@ -47,13 +50,16 @@ func (s *Snapshot) Validate() error {
 
 type BackupProgressEntry struct {
 	// Common fields
-	MessageType string `json:"message_type"` // "summary" or "status" or "error"
+	MessageType string `json:"message_type"` // "summary" or "status" or "error", or "warning" for a line restic printed outside of its JSON output.
 
 	// Error fields
 	Error  any    `json:"error"`
 	During string `json:"during"`
 	Item   string `json:"item"`
 
+	// Warning fields
+	Message string `json:"-"` // the line restic printed, typically a warning on stderr.
+
 	// Summary fields
 	FilesNew            int64   `json:"files_new"`
 	FilesChanged        int64   `json:"files_changed"`
@@ -68,18 +74,22 @@ type BackupProgressEntry struct {
 	TotalBytesProcessed int64   `json:"total_bytes_processed"`
 	TotalDuration       float64 `json:"total_duration"`
 	SnapshotId          string  `json:"snapshot_id"`
+	DryRun              bool    `json:"dry_run"` // set for a backup run with --dry-run, which saves no snapshot.
 
 	// Status fields
-	PercentDone  float64  `json:"percent_done"`
-	TotalFiles   int64    `json:"total_files"`
-	FilesDone    int64    `json:"files_done"`
-	TotalBytes   int64    `json:"total_bytes"`
-	BytesDone    int64    `json:"bytes_done"`
-	CurrentFiles []string `json:"current_files"`
+	PercentDone      float64  `json:"percent_done"`
+	TotalFiles       int64    `json:"total_files"`
+	FilesDone        int64    `json:"files_done"`
+	TotalBytes       int64    `json:"total_bytes"`
+	BytesDone        int64    `json:"bytes_done"`
+	CurrentFiles     []string `json:"current_files"`
+	SecondsElapsed   int64    `json:"seconds_elapsed"`
+	SecondsRemaining int64    `json:"seconds_remaining"`
+	ErrorCount       int64    `json:"error_count"`
 }
 
 func (b *BackupProgressEntry) Validate() error {
-	if b.MessageType == "summary" {
+	if b.MessageType == "summary" && !b.DryRun {
 		if b.SnapshotId == "" {
 			return errors.New("summary message must have snapshot_id")
 		}
@@ -91,7 +101,20 @@ func (b *BackupProgressEntry) Validate() error {
 	return nil
 }
 
-// readBackupProgressEntries returns the summary event or an error if the command failed.
+// ErrorMessage returns the message of an error entry, or "" if the entry has none.
+func (b *BackupProgressEntry) ErrorMessage() string {
+	switch e := b.Error.(type) {
+	case map[string]any:
+		msg, _ := e["message"].(string)
+		return msg
+	case string:
+		return e
+	}
+	return ""
+}
+
+// readBackupProgressEntries returns the summary event or an error if the command failed. Lines after the first that
+// aren't JSON are passed to the callback as "warning" entries.
 func readBackupProgressEntries(output io.Reader, callback func(event *BackupProgressEntry)) (*BackupProgressEntry, error) {
 	scanner := bufio.NewScanner(output)
 	scanner.Split(bufio.ScanLines)
@@ -119,7 +142,10 @@ func readBackupProgressEntries(output io.Reader, callback func(event *BackupProg
 	for scanner.Scan() {
 		var event BackupProgressEntry
 		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
-			// skip it. This is a best-effort attempt to parse the output.
+			// restic prints some warnings as plain text even with --json.
+			if line := strings.TrimSpace(scanner.Text()); line != "" && callback != nil {
+				callback(&BackupProgressEntry{MessageType: "warning", Message: line})
+			}
 			continue
 		}
 		if err := event.Validate(); err != nil {
@@ -301,4 +327,253 @@ type RepoStats struct {
 	CompressionSpaceSaving float64 `json:"compression_space_saving"`
 	TotalBlobCount         int64   `json:"total_blob_count"`
 	SnapshotsCount         int64   `json:"snapshots_count"`
+	TotalFileCount         int64   `json:"total_file_count"` // only reported in restore-size mode.
+}
+
+// SnapshotStats combines the raw-data and restore-size stats of a single snapshot.
+type SnapshotStats struct {
+	FileCount   int64
+	TotalSize   int64
+	RestoreSize int64
+}
+
+// Lock is a lock held on a repo, the time of a lock is refreshed periodically by the restic process holding it.
+type Lock struct {
+	ID        string    `json:"-"`
+	Time      time.Time `json:"time"`
+	Exclusive bool      `json:"exclusive"`
+	Hostname  string    `json:"hostname"`
+	Username  string    `json:"username"`
+	PID       int       `json:"pid"`
+}
+
+// CopiedSnapshot maps a snapshot in the source repo of a copy to its copy in the destination repo.
+type CopiedSnapshot struct {
+	SourceID      string
+	DestinationID string
+}
+
+// readCopyOutput parses the text output of restic copy, which does not support --json. Each copied snapshot is
+// reported as "snapshot <source id> of [<paths>] at <time>" followed by "snapshot <destination id> saved" once copied.
+func readCopyOutput(output io.Reader) ([]CopiedSnapshot, error) {
+	var copied []CopiedSnapshot
+	var source string
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "snapshot" {
+			continue
+		}
+		if fields[2] == "of" {
+			source = fields[1]
+		} else if fields[2] == "saved" && source != "" {
+			copied = append(copied, CopiedSnapshot{SourceID: source, DestinationID: fields[1]})
+			source = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read copy output: %w", err)
+	}
+	return copied, nil
+}
+
+// DiffChange is a path that differs between two snapshots. Directories' paths end in "/". Modifier is restic's type of
+// change: "+" added, "-" removed, "M" modified contents, "T" changed type, "U" changed metadata only.
+type DiffChange struct {
+	Path     string `json:"path"`
+	Modifier string `json:"modifier"`
+}
+
+// DiffStats counts the files and data only present in one of the two snapshots of a diff.
+type DiffStats struct {
+	Files     int   `json:"files"`
+	Dirs      int   `json:"dirs"`
+	Others    int   `json:"others"`
+	DataBlobs int   `json:"data_blobs"`
+	TreeBlobs int   `json:"tree_blobs"`
+	Bytes     int64 `json:"bytes"`
+}
+
+type DiffResult struct {
+	SourceSnapshot string `json:"source_snapshot"`
+	TargetSnapshot string `json:"target_snapshot"`
+	ChangedFiles   int    `json:"changed_files"`
+	Added          DiffStats
+	Removed        DiffStats
+	Changes        []*DiffChange
+}
+
+// readDiff parses the output of restic diff --json, a "change" message per changed path followed by a "statistics"
+// message.
+func readDiff(output io.Reader) (*DiffResult, error) {
+	result := &DiffResult{}
+	var gotStats bool
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var msg struct {
+			MessageType string `json:"message_type"`
+			DiffChange
+			SourceSnapshot string    `json:"source_snapshot"`
+			TargetSnapshot string    `json:"target_snapshot"`
+			ChangedFiles   int       `json:"changed_files"`
+			Added          DiffStats `json:"added"`
+			Removed        DiffStats `json:"removed"`
+		}
+		if err := json.Unmarshal(line, &msg); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON %q: %w", line, err)
+		}
+		switch msg.MessageType {
+		case "change":
+			change := msg.DiffChange
+			result.Changes = append(result.Changes, &change)
+		case "statistics":
+			gotStats = true
+			result.SourceSnapshot = msg.SourceSnapshot
+			result.TargetSnapshot = msg.TargetSnapshot
+			result.ChangedFiles = msg.ChangedFiles
+			result.Added = msg.Added
+			result.Removed = msg.Removed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read diff output: %w", err)
+	}
+	if !gotStats {
+		return nil, errors.New("no statistics in diff output")
+	}
+	return result, nil
+}
+
+// FindMatch is a file matched by restic find.
+type FindMatch struct {
+	Path  string `json:"path"`
+	Type  string `json:"type"`
+	Uid   int64  `json:"uid"`
+	Gid   int64  `json:"gid"`
+	Size  int64  `json:"size"`
+	Mode  int64  `json:"mode"`
+	Mtime string `json:"mtime"`
+	Atime string `json:"atime"`
+	Ctime string `json:"ctime"`
+}
+
+func (m *FindMatch) ToProto() *v1.LsEntry {
+	name := m.Path
+	if i := strings.LastIndex(strings.TrimSuffix(name, "/"), "/"); i != -1 {
+		name = name[i+1:]
+	}
+	return &v1.LsEntry{
+		Name:  name,
+		Type:  m.Type,
+		Path:  m.Path,
+		Uid:   m.Uid,
+		Gid:   m.Gid,
+		Size:  m.Size,
+		Mode:  m.Mode,
+		Mtime: m.Mtime,
+		Atime: m.Atime,
+		Ctime: m.Ctime,
+	}
+}
+
+// FindResult is the matches of restic find in one snapshot.
+type FindResult struct {
+	Snapshot string       `json:"snapshot"`
+	Hits     int          `json:"hits"`
+	Matches  []*FindMatch `json:"matches"`
+}
+
+// readFind parses the output of restic find --json, an array holding a FindResult per snapshot with matches. The array
+// is written as snapshots are searched so results are passed to callback as they are read. A callback error stops
+// reading and is returned.
+func readFind(output io.Reader, callback func(*FindResult) error) error {
+	dec := json.NewDecoder(output)
+	if t, err := dec.Token(); err != nil {
+		return fmt.Errorf("command output was not JSON: %w", err)
+	} else if t != json.Delim('[') {
+		return fmt.Errorf("unexpected find output %v, expected an array", t)
+	}
+	for dec.More() {
+		var result FindResult
+		if err := dec.Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		if err := callback(&result); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return nil
+}
+
+// readMigrations reads the migration names listed by restic migrate, one per line after a header e.g.
+// "  upgrade_repo_v2\tupgrade a repository to version 2".
+func readMigrations(output io.Reader) []string {
+	var migrations []string
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			migrations = append(migrations, fields[0])
+		}
+	}
+	return migrations
+}
+
+// RecoverResult is the outcome of restic recover.
+type RecoverResult struct {
+	Roots      int    // number of directories not referenced by any snapshot that were found.
+	SnapshotID string // short ID of the snapshot holding the directories, "" if none were found.
+}
+
+// readRecover reads the output of restic recover e.g. "found 1 unreferenced roots" followed by
+// "saved new snapshot 95deffdf".
+func readRecover(output io.Reader) *RecoverResult {
+	result := &RecoverResult{}
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if n, ok := strings.CutPrefix(line, "found "); ok && strings.HasSuffix(line, " unreferenced roots") {
+			result.Roots, _ = strconv.Atoi(strings.TrimSuffix(n, " unreferenced roots"))
+		} else if id, ok := strings.CutPrefix(line, "saved new snapshot "); ok {
+			result.SnapshotID = id
+		}
+	}
+	return result
+}
+
+type RewriteResult struct {
+	Rewritten int // number of snapshots that held excluded files and were, or in a dry run would be, saved under new IDs.
+}
+
+// readRewrite reads the output of restic rewrite, each changed snapshot is followed by "saved new snapshot 95deffdf" or
+// "would save new snapshot" in a dry run.
+func readRewrite(output io.Reader) *RewriteResult {
+	result := &RewriteResult{}
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "saved new snapshot ") || line == "would save new snapshot" {
+			result.Rewritten++
+		}
+	}
+	return result
+}
+
+// Key is a key of the repo as listed by restic key list --json, each key holds a password that opens the repo.
+type Key struct {
+	Current  bool   `json:"current"` // the key that opened the repo for the listing.
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	HostName string `json:"hostName"`
+	Created  string `json:"created"`
 }
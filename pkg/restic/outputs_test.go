@@ -2,6 +2,7 @@ package restic
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 )
 
@@ -26,6 +27,48 @@ func TestReadBackupProgressEntries(t *testing.T) {
 	}
 }
 
+func TestReadBackupProgressEntriesDryRun(t *testing.T) {
+	t.Parallel()
+	testInput := `{"message_type":"status","percent_done":0,"total_files":1,"total_bytes":15}
+{"message_type":"summary","dry_run":true,"files_new":1,"files_changed":0,"files_unmodified":0,"data_added":15,"total_files_processed":1,"total_bytes_processed":15,"total_duration":0.1}`
+
+	summary, err := readBackupProgressEntries(bytes.NewBufferString(testInput), nil)
+	if err != nil {
+		t.Fatalf("failed to read backup events: %v", err)
+	}
+	if !summary.DryRun || summary.FilesNew != 1 || summary.DataAdded != 15 {
+		t.Errorf("wanted the dry run summary, got: %+v", summary)
+	}
+}
+
+func TestReadBackupProgressEntriesErrorsAndWarnings(t *testing.T) {
+	t.Parallel()
+	testInput := `{"message_type":"status","percent_done":0,"total_files":1,"total_bytes":15}
+{"message_type":"error","error":{"message":"open /data/secret: permission denied"},"during":"archival","item":"/data/secret"}
+Warning: unable to open cache: permission denied
+
+{"message_type":"summary","total_files_processed":1,"snapshot_id":"d4558b360cc1b7966e416e010382ab8feb49d14da7832266832d69a43af10147"}`
+
+	var errs, warnings []*BackupProgressEntry
+	_, err := readBackupProgressEntries(bytes.NewBufferString(testInput), func(event *BackupProgressEntry) {
+		switch event.MessageType {
+		case "error":
+			errs = append(errs, event)
+		case "warning":
+			warnings = append(warnings, event)
+		}
+	})
+	if err != nil {
+		t.Fatalf("failed to read backup events: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Item != "/data/secret" || errs[0].ErrorMessage() != "open /data/secret: permission denied" {
+		t.Errorf("wanted the error reading /data/secret, got: %+v", errs)
+	}
+	if len(warnings) != 1 || warnings[0].Message != "Warning: unable to open cache: permission denied" {
+		t.Errorf("wanted the cache warning, got: %+v", warnings)
+	}
+}
+
 func TestReadLs(t *testing.T) {
 	testInput := `{"time":"2023-11-10T19:14:17.053824063-08:00","tree":"3e2918b261948e69602ee9504b8f475bcc7cdc4dcec0b3f34ecdb014287d07b2","paths":["/backrest"],"hostname":"pop-os","username":"dontpanic","uid":1000,"gid":1000,"id":"db155169d788e6e432e320aedbdff5a54cc439653093bb56944a67682528aa52","short_id":"db155169","struct_type":"snapshot"}
 	{"name":".git","type":"dir","path":"/.git","uid":1000,"gid":1000,"mode":2147484157,"mtime":"2023-11-10T18:32:38.156599473-08:00","atime":"2023-11-10T18:32:38.156599473-08:00","ctime":"2023-11-10T18:32:38.156599473-08:00","struct_type":"node"}
@@ -45,3 +88,135 @@ func TestReadLs(t *testing.T) {
 		t.Errorf("wanted 3 entries, got: %d", len(entries))
 	}
 }
+
+func TestReadDiff(t *testing.T) {
+	t.Parallel()
+	testInput := `{"message_type":"change","path":"/dd/a","modifier":"-"}
+{"message_type":"change","path":"/dd/b","modifier":"M"}
+{"message_type":"change","path":"/dd/sub/","modifier":"+"}
+{"message_type":"change","path":"/dd/sub/n","modifier":"+"}
+{"message_type":"statistics","source_snapshot":"c8e68ea7","target_snapshot":"fd2b26ea","changed_files":1,"added":{"files":1,"dirs":1,"others":0,"data_blobs":2,"tree_blobs":3,"bytes":1402},"removed":{"files":1,"dirs":0,"others":0,"data_blobs":2,"tree_blobs":2,"bytes":1046}}`
+
+	diff, err := readDiff(bytes.NewBufferString(testInput))
+	if err != nil {
+		t.Fatalf("failed to read diff output: %v", err)
+	}
+	if diff.SourceSnapshot != "c8e68ea7" || diff.TargetSnapshot != "fd2b26ea" || diff.ChangedFiles != 1 {
+		t.Errorf("unexpected diff statistics %+v", diff)
+	}
+	if diff.Added.Files != 1 || diff.Added.Dirs != 1 || diff.Added.Bytes != 1402 || diff.Removed.Files != 1 || diff.Removed.Bytes != 1046 {
+		t.Errorf("unexpected added or removed stats %+v %+v", diff.Added, diff.Removed)
+	}
+	want := []DiffChange{{"/dd/a", "-"}, {"/dd/b", "M"}, {"/dd/sub/", "+"}, {"/dd/sub/n", "+"}}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("wanted %d changes, got: %v", len(want), diff.Changes)
+	}
+	for i := range want {
+		if *diff.Changes[i] != want[i] {
+			t.Errorf("wanted change %v, got: %v", want[i], *diff.Changes[i])
+		}
+	}
+
+	if _, err := readDiff(bytes.NewBufferString(`{"message_type":"change","path":"/dd/a","modifier":"-"}`)); err == nil {
+		t.Errorf("wanted an error for output without statistics")
+	}
+}
+
+func TestReadFind(t *testing.T) {
+	t.Parallel()
+	testInput := `[{"matches":[{"path":"/dd/b","permissions":"-rw-r--r--","type":"file","mode":420,"mtime":"2026-10-14T17:08:13.987199508Z","atime":"2026-10-14T17:08:13.987199508Z","ctime":"2026-10-14T17:08:13.987199508Z","uid":0,"gid":0,"user":"root","group":"root","inode":15950353,"device_id":65024,"size":2,"links":1}],"hits":1,"snapshot":"c8e68ea756208e9685aefec0b5a23493bbc58fa7d49e7c678c3be7a8a092879c"},{"matches":[{"path":"/dd/b","permissions":"-rw-r--r--","type":"file","mode":420,"mtime":"2026-10-14T17:08:14.835199558Z","atime":"2026-10-14T17:08:14.835199558Z","ctime":"2026-10-14T17:08:14.835199558Z","uid":0,"gid":0,"user":"root","group":"root","inode":15950353,"device_id":65024,"size":3,"links":1}],"hits":1,"snapshot":"fd2b26ea762508f6b50b3ff0f065cff93faa860a5e39e60a946525b96aaa40f6"}]`
+
+	var results []*FindResult
+	if err := readFind(bytes.NewBufferString(testInput), func(r *FindResult) error {
+		results = append(results, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to read find output: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("wanted 2 results, got: %d", len(results))
+	}
+	if results[1].Snapshot != "fd2b26ea762508f6b50b3ff0f065cff93faa860a5e39e60a946525b96aaa40f6" || len(results[1].Matches) != 1 || results[1].Matches[0].Size != 3 {
+		t.Errorf("unexpected result %+v", results[1])
+	}
+	if entry := results[0].Matches[0].ToProto(); entry.Name != "b" || entry.Path != "/dd/b" {
+		t.Errorf("unexpected ls entry %v", entry)
+	}
+
+	stop := errors.New("stop")
+	var calls int
+	if err := readFind(bytes.NewBufferString(testInput), func(r *FindResult) error {
+		calls++
+		return stop
+	}); err != stop || calls != 1 {
+		t.Errorf("wanted the callback's error after 1 call, got %v after %d", err, calls)
+	}
+
+	if err := readFind(bytes.NewBufferString("[]"), func(r *FindResult) error { return nil }); err != nil {
+		t.Errorf("failed to read empty find output: %v", err)
+	}
+}
+
+func TestReadCopyOutput(t *testing.T) {
+	t.Parallel()
+	testInput := `
+snapshot cb3eb807 of [/tmp/data] at 2024-03-10 14:10:41.888789797 +0000 UTC)
+  copy started, this may take a while...
+[0:00] 100.00%  2 / 2 packs copied
+
+snapshot b6a6a053 saved
+
+snapshot fbebfb7b of [/tmp/data] at 2024-03-10 14:10:42.588850705 +0000 UTC)
+  copy started, this may take a while...
+[0:00]          0 packs copied
+
+snapshot 478f0e5f saved
+`
+
+	copied, err := readCopyOutput(bytes.NewBufferString(testInput))
+	if err != nil {
+		t.Fatalf("failed to read copy output: %v", err)
+	}
+	want := []CopiedSnapshot{
+		{SourceID: "cb3eb807", DestinationID: "b6a6a053"},
+		{SourceID: "fbebfb7b", DestinationID: "478f0e5f"},
+	}
+	if len(copied) != len(want) {
+		t.Fatalf("wanted %d copied snapshots, got: %v", len(want), copied)
+	}
+	for i := range want {
+		if copied[i] != want[i] {
+			t.Errorf("wanted copied snapshot %v, got: %v", want[i], copied[i])
+		}
+	}
+}
+
+func TestReadRewrite(t *testing.T) {
+	testInput := `
+snapshot 2e1d7ad2 of [/tmp/data] at 2024-03-10 14:10:41.888789797 +0000 UTC)
+excluding /tmp/data/secret
+saved new snapshot 95deffdf
+removed old snapshot 2e1d7ad2
+
+snapshot 6d4b1c8a of [/tmp/data] at 2024-03-10 14:10:42.588850705 +0000 UTC)
+
+snapshot 6d4b1c8a not modified
+
+modified 1 snapshots
+`
+	if result := readRewrite(bytes.NewBufferString(testInput)); result.Rewritten != 1 {
+		t.Errorf("wanted 1 rewritten snapshot, got: %+v", result)
+	}
+
+	dryRunInput := `
+snapshot 2e1d7ad2 of [/tmp/data] at 2024-03-10 14:10:41.888789797 +0000 UTC)
+excluding /tmp/data/secret
+would save new snapshot
+would remove old snapshot
+
+would have modified 1 snapshots
+`
+	if result := readRewrite(bytes.NewBufferString(dryRunInput)); result.Rewritten != 1 {
+		t.Errorf("wanted 1 snapshot rewritten in the dry run, got: %+v", result)
+	}
+}
@@ -9,9 +9,13 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
+	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/djherbis/buffer"
 	nio "github.com/djherbis/nio/v3"
@@ -21,6 +25,10 @@ import (
 var errAlreadyInitialized = errors.New("repo already initialized")
 var ErrPartialBackup = errors.New("incomplete backup")
 var ErrBackupFailed = errors.New("backup failed")
+var ErrMountUnsupported = errors.New("restic mount is not supported on this platform")
+
+// interruptTimeout is how long restic is given to exit after it's interrupted before it's killed.
+const interruptTimeout = 10 * time.Second
 
 type Repo struct {
 	cmd         string
@@ -56,8 +64,18 @@ func (r *Repo) commandWithContext(ctx context.Context, args []string, opts ...Ge
 	args = append(args, opt.extraArgs...)
 
 	cmd := exec.CommandContext(ctx, r.cmd, args...)
+	// interrupt rather than kill restic when the context is cancelled so that it removes its lock before exiting.
+	if runtime.GOOS != "windows" {
+		cmd.Cancel = func() error {
+			return cmd.Process.Signal(os.Interrupt)
+		}
+	}
+	cmd.WaitDelay = interruptTimeout
 	cmd.Env = append(cmd.Env, r.extraEnv...)
 	cmd.Env = append(cmd.Env, opt.extraEnv...)
+	if opt.stdin != nil {
+		cmd.Stdin = opt.stdin
+	}
 
 	addLoggingToCommand(ctx, cmd)
 
@@ -95,7 +113,7 @@ func (r *Repo) init(ctx context.Context, opts ...GenericOption) error {
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
 
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		if strings.Contains(output.String(), "config file already exists") || strings.Contains(output.String(), "already initialized") {
 			return errAlreadyInitialized
 		}
@@ -120,7 +138,7 @@ func (r *Repo) Backup(ctx context.Context, paths []string, progressCallback func
 		}
 	}
 
-	args := []string{"backup", "--json", "--exclude-caches"}
+	args := []string{"backup", "--json"}
 	args = append(args, paths...)
 
 	cmd := r.commandWithContext(ctx, args, opts...)
@@ -143,7 +161,7 @@ func (r *Repo) Backup(ctx context.Context, paths []string, progressCallback func
 		}
 	}()
 
-	cmdErr := cmd.Run()
+	cmdErr := runCommand(ctx, cmd)
 	writer.Close()
 	wg.Wait()
 
@@ -168,7 +186,7 @@ func (r *Repo) Snapshots(ctx context.Context, opts ...GenericOption) ([]*Snapsho
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
 
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		return nil, newCmdError(ctx, cmd, output.String(), err)
 	}
 
@@ -192,7 +210,7 @@ func (r *Repo) Forget(ctx context.Context, policy *RetentionPolicy, opts ...Gene
 	cmd := r.commandWithContext(ctx, args, opts...)
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		return nil, newCmdError(ctx, cmd, output.String(), err)
 	}
 
@@ -216,7 +234,7 @@ func (r *Repo) ForgetSnapshot(ctx context.Context, snapshotId string, opts ...Ge
 	cmd := r.commandWithContext(ctx, args, opts...)
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		return newCmdError(ctx, cmd, output.String(), err)
 	}
 
@@ -226,17 +244,226 @@ func (r *Repo) ForgetSnapshot(ctx context.Context, snapshotId string, opts ...Ge
 func (r *Repo) Prune(ctx context.Context, pruneOutput io.Writer, opts ...GenericOption) error {
 	args := []string{"prune"}
 	cmd := r.commandWithContext(ctx, args, opts...)
-	output := bytes.NewBuffer(nil)
+	output := ioutil.NewOutputCapturer(outputBufferLimit) // for error reporting, the full output may be megabytes.
 	r.pipeCmdOutputToWriter(cmd, output)
 	if pruneOutput != nil {
 		r.pipeCmdOutputToWriter(cmd, pruneOutput)
 	}
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
+		return newCmdError(ctx, cmd, string(output.Bytes()), err)
+	}
+	return nil
+}
+
+func (r *Repo) Check(ctx context.Context, checkOutput io.Writer, opts ...GenericOption) error {
+	args := []string{"check"}
+	cmd := r.commandWithContext(ctx, args, opts...)
+	output := ioutil.NewOutputCapturer(outputBufferLimit) // for error reporting, the full output may be megabytes.
+	r.pipeCmdOutputToWriter(cmd, output)
+	if checkOutput != nil {
+		r.pipeCmdOutputToWriter(cmd, checkOutput)
+	}
+	if err := runCommand(ctx, cmd); err != nil {
+		return newCmdError(ctx, cmd, string(output.Bytes()), err)
+	}
+	return nil
+}
+
+// Migrations returns the names of the migrations that can be applied to the repo e.g. "upgrade_repo_v2".
+func (r *Repo) Migrations(ctx context.Context, opts ...GenericOption) ([]string, error) {
+	cmd := r.commandWithContext(ctx, []string{"migrate"}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+	return readMigrations(output), nil
+}
+
+// Migrate applies the migration to the repo, writing restic's output to migrateOutput. It returns an error if the
+// migration doesn't apply to the repo, e.g. because the repo was already upgraded.
+func (r *Repo) Migrate(ctx context.Context, migration string, migrateOutput io.Writer, opts ...GenericOption) error {
+	// restic silently ignores unknown migrations, the name is checked against the applicable ones first.
+	migrations, err := r.Migrations(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("list migrations: %w", err)
+	}
+	if !slices.Contains(migrations, migration) {
+		return fmt.Errorf("migration %q can not be applied to the repo, applicable migrations: %v", migration, migrations)
+	}
+
+	cmd := r.commandWithContext(ctx, []string{"migrate", migration}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if migrateOutput != nil {
+		r.pipeCmdOutputToWriter(cmd, migrateOutput)
+	}
+	if err := runCommand(ctx, cmd); err != nil {
 		return newCmdError(ctx, cmd, output.String(), err)
 	}
+	if strings.Contains(output.String(), "cannot be applied") {
+		return newCmdError(ctx, cmd, output.String(), fmt.Errorf("migration %q was not applied", migration))
+	}
 	return nil
 }
 
+// Recover saves the directories in the repo that aren't referenced by any snapshot, e.g. the contents of forgotten
+// snapshots that were not pruned yet, into a new snapshot tagged "recovered". restic's output is also written to
+// recoverOutput if it is not nil.
+func (r *Repo) Recover(ctx context.Context, recoverOutput io.Writer, opts ...GenericOption) (*RecoverResult, error) {
+	cmd := r.commandWithContext(ctx, []string{"recover"}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if recoverOutput != nil {
+		r.pipeCmdOutputToWriter(cmd, recoverOutput)
+	}
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+	return readRecover(output), nil
+}
+
+// Rewrite removes the files matching the exclude patterns from the snapshots, restic saves each changed snapshot under
+// a new ID. Options such as --forget or --dry-run are passed as flags, restic's output is also written to rewriteOutput.
+func (r *Repo) Rewrite(ctx context.Context, snapshotIDs []string, excludes []string, rewriteOutput io.Writer, opts ...GenericOption) (*RewriteResult, error) {
+	if len(snapshotIDs) == 0 {
+		return nil, errors.New("no snapshots to rewrite, restic would rewrite all of the repo's snapshots")
+	}
+	if len(excludes) == 0 {
+		return nil, errors.New("no files to exclude")
+	}
+	args := []string{"rewrite"}
+	for _, exclude := range excludes {
+		args = append(args, "--exclude", exclude)
+	}
+	args = append(args, snapshotIDs...)
+
+	cmd := r.commandWithContext(ctx, args, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if rewriteOutput != nil {
+		r.pipeCmdOutputToWriter(cmd, rewriteOutput)
+	}
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+	return readRewrite(output), nil
+}
+
+// ListKeys lists the repo's keys.
+func (r *Repo) ListKeys(ctx context.Context, opts ...GenericOption) ([]*Key, error) {
+	cmd := r.commandWithContext(ctx, []string{"key", "list", "--json"}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+
+	var keys []*Key
+	if err := json.Unmarshal(output.Bytes(), &keys); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), fmt.Errorf("command output is not valid JSON: %w", err))
+	}
+	return keys, nil
+}
+
+// AddKey adds a key opening the repo with password and returns it. The user and host of the key default to the current
+// ones if empty.
+func (r *Repo) AddKey(ctx context.Context, password, user, host string, opts ...GenericOption) (*Key, error) {
+	if password == "" {
+		return nil, errors.New("password must not be empty")
+	}
+	// restic doesn't report the ID of the key it added, it is the one missing from the listing before.
+	before, err := r.ListKeys(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordFile, err := writePasswordFile(password)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(passwordFile)
+
+	args := []string{"key", "add", "--new-password-file", passwordFile}
+	if user != "" {
+		args = append(args, "--user", user)
+	}
+	if host != "" {
+		args = append(args, "--host", host)
+	}
+	cmd := r.commandWithContext(ctx, args, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+
+	after, err := r.ListKeys(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list keys after adding a key: %w", err)
+	}
+	for _, k := range after {
+		if !slices.ContainsFunc(before, func(b *Key) bool { return b.ID == k.ID }) {
+			return k, nil
+		}
+	}
+	return nil, errors.New("added key not found in the repo's keys")
+}
+
+// RemoveKey removes the key with the ID, restic refuses to remove the key that opens the repo.
+func (r *Repo) RemoveKey(ctx context.Context, id string, opts ...GenericOption) error {
+	if !keyIDRegex.MatchString(id) {
+		// IDs are positional arguments, one starting with "-" would be read as a flag.
+		return fmt.Errorf("invalid key ID %q", id)
+	}
+	cmd := r.commandWithContext(ctx, []string{"key", "remove", id}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if err := runCommand(ctx, cmd); err != nil {
+		return newCmdError(ctx, cmd, output.String(), err)
+	}
+	return nil
+}
+
+var keyIDRegex = regexp.MustCompile(`^[0-9a-f]{8,64}$`)
+
+// writePasswordFile writes password to a file only readable by the current user, restic reads new passwords from files.
+// The caller removes the file.
+func writePasswordFile(password string) (string, error) {
+	f, err := os.CreateTemp("", "backrest-key-*")
+	if err != nil {
+		return "", fmt.Errorf("create password file: %w", err)
+	}
+	if _, err := f.WriteString(password); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", fmt.Errorf("write password file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("write password file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// Copy copies snapshots into this repo from the repo set by RESTIC_FROM_REPOSITORY and RESTIC_FROM_PASSWORD, which
+// should be provided with WithEnv. Snapshots already present in this repo are skipped.
+func (r *Repo) Copy(ctx context.Context, opts ...GenericOption) ([]CopiedSnapshot, error) {
+	cmd := r.commandWithContext(ctx, []string{"copy"}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+
+	copied, err := readCopyOutput(bytes.NewReader(output.Bytes()))
+	if err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+	return copied, nil
+}
+
 func (r *Repo) Restore(ctx context.Context, snapshot string, callback func(*RestoreProgressEntry), opts ...GenericOption) (*RestoreProgressEntry, error) {
 	cmd := r.commandWithContext(ctx, []string{"restore", "--json", snapshot}, opts...)
 	capture := ioutil.NewOutputCapturer(outputBufferLimit) // for error reporting.
@@ -257,7 +484,7 @@ func (r *Repo) Restore(ctx context.Context, snapshot string, callback func(*Rest
 		}
 	}()
 
-	cmdErr := cmd.Run()
+	cmdErr := runCommand(ctx, cmd)
 	writer.Close()
 	wg.Wait()
 	if cmdErr != nil || readErr != nil {
@@ -283,11 +510,20 @@ func (r *Repo) ListDirectory(ctx context.Context, snapshot string, path string,
 		return nil, nil, errors.New("path must not be empty")
 	}
 
-	cmd := r.commandWithContext(ctx, []string{"ls", "--json", snapshot, path}, opts...)
+	return r.ListDirectories(ctx, snapshot, []string{path}, opts...)
+}
+
+// ListDirectories lists the entries of each of the directories in the snapshot, directories are not listed recursively.
+func (r *Repo) ListDirectories(ctx context.Context, snapshot string, paths []string, opts ...GenericOption) (*Snapshot, []*LsEntry, error) {
+	if len(paths) == 0 || slices.Contains(paths, "") {
+		return nil, nil, errors.New("paths must not be empty")
+	}
+
+	cmd := r.commandWithContext(ctx, append([]string{"ls", "--json", snapshot}, paths...), opts...)
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
 
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		return nil, nil, newCmdError(ctx, cmd, output.String(), err)
 	}
 
@@ -299,22 +535,263 @@ func (r *Repo) ListDirectory(ctx context.Context, snapshot string, path string,
 	return snapshots, entries, nil
 }
 
+// Dump writes the content of the file at path in the snapshot to w as restic reads it, directories are written as a
+// tar archive. The path must be absolute.
+func (r *Repo) Dump(ctx context.Context, snapshot string, path string, w io.Writer, opts ...GenericOption) error {
+	if !strings.HasPrefix(path, "/") {
+		// paths are positional arguments, a relative one could be read as a flag.
+		return fmt.Errorf("path %q must be absolute", path)
+	}
+
+	cmd := r.commandWithContext(ctx, []string{"dump", snapshot, path}, opts...)
+	// stdout is the file's content, only stderr is kept for errors and logged.
+	stderr := ioutil.NewOutputCapturer(outputBufferLimit)
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, stderr)
+	} else {
+		cmd.Stderr = stderr
+	}
+	cmd.Stdout = w
+
+	if err := runCommand(ctx, cmd); err != nil {
+		return newCmdError(ctx, cmd, string(stderr.Bytes()), err)
+	}
+	return nil
+}
+
+// Mount mounts the repo's snapshots as a read only filesystem at mountpoint, an existing empty directory. It blocks
+// until the repo is unmounted, restic unmounts it when ctx is cancelled. Mounting requires FUSE and isn't supported on
+// Windows.
+func (r *Repo) Mount(ctx context.Context, mountpoint string, opts ...GenericOption) error {
+	if runtime.GOOS == "windows" {
+		return ErrMountUnsupported
+	}
+	if !strings.HasPrefix(mountpoint, "/") {
+		// the mountpoint is a positional argument, a relative one could be read as a flag.
+		return fmt.Errorf("mountpoint %q must be absolute", mountpoint)
+	}
+
+	cmd := r.commandWithContext(ctx, []string{"mount", mountpoint}, opts...)
+	output := ioutil.NewOutputCapturer(outputBufferLimit)
+	r.pipeCmdOutputToWriter(cmd, output)
+
+	if err := runCommand(ctx, cmd); err != nil && ctx.Err() == nil {
+		return newCmdErrorPreformatted(ctx, cmd, string(output.Bytes()), err)
+	}
+	return nil
+}
+
+// Find searches the repo's snapshots, or the snapshots selected with options e.g. WithFlags("--snapshot", id), for
+// files whose name matches the glob pattern. Results are passed to callback a snapshot at a time as restic finds them,
+// an error returned by callback stops the search and is returned.
+func (r *Repo) Find(ctx context.Context, pattern string, callback func(*FindResult) error, opts ...GenericOption) error {
+	if pattern == "" || strings.HasPrefix(pattern, "-") {
+		// patterns are positional arguments, one starting with "-" would be read as a flag.
+		return fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cmd := r.commandWithContext(ctx, []string{"find", "--json", pattern}, opts...)
+	fullOutput := ioutil.NewOutputCapturer(outputBufferLimit)
+	buf := buffer.New(32 * 1024)
+	reader, writer := nio.Pipe(buf)
+	r.pipeCmdOutputToWriter(cmd, fullOutput)
+	// only stdout is parsed, restic reports errors reading snapshots on stderr.
+	cmd.Stdout = io.MultiWriter(cmd.Stdout, writer)
+
+	var callbackErr, readErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		readErr = readFind(reader, func(result *FindResult) error {
+			callbackErr = callback(result)
+			return callbackErr
+		})
+		if readErr != nil {
+			cancel() // stop the search now that its output isn't read.
+		}
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	cmdErr := runCommand(ctx, cmd)
+	writer.Close()
+	wg.Wait()
+
+	if callbackErr != nil {
+		return callbackErr
+	}
+	if cmdErr != nil || readErr != nil {
+		return newCmdErrorPreformatted(ctx, cmd, string(fullOutput.Bytes()), errors.Join(cmdErr, readErr))
+	}
+	return nil
+}
+
+// Diff lists the paths that changed from snapshot1 to snapshot2.
+func (r *Repo) Diff(ctx context.Context, snapshot1, snapshot2 string, opts ...GenericOption) (*DiffResult, error) {
+	cmd := r.commandWithContext(ctx, []string{"diff", "--json", snapshot1, snapshot2}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+
+	result, err := readDiff(output)
+	if err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+	return result, nil
+}
+
+// Reachable returns an error if the repo can't be opened e.g. because its backend is unreachable or the password is
+// wrong. It only reads the repo's config file, which is cheap for any backend, and takes no lock.
+func (r *Repo) Reachable(ctx context.Context, opts ...GenericOption) error {
+	cmd := r.commandWithContext(ctx, []string{"cat", "config", "--no-lock"}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if err := runCommand(ctx, cmd); err != nil {
+		return newCmdError(ctx, cmd, output.String(), err)
+	}
+	return nil
+}
+
+// ID returns the repo's ID from its config file, restic names the repo's cache directory after it. It takes no lock.
+func (r *Repo) ID(ctx context.Context, opts ...GenericOption) (string, error) {
+	config, err := r.config(ctx, opts...)
+	if err != nil {
+		return "", err
+	}
+	if config.ID == "" {
+		return "", errors.New("repo config has no id")
+	}
+	return config.ID, nil
+}
+
+// Version returns the repo's format version from its config file, repos of version 2 or later support compression. It
+// takes no lock.
+func (r *Repo) Version(ctx context.Context, opts ...GenericOption) (int, error) {
+	config, err := r.config(ctx, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if config.Version == 0 {
+		return 0, errors.New("repo config has no version")
+	}
+	return config.Version, nil
+}
+
+type repoConfig struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+}
+
+// config reads the repo's config file with restic cat config.
+func (r *Repo) config(ctx context.Context, opts ...GenericOption) (*repoConfig, error) {
+	cmd := r.commandWithContext(ctx, []string{"cat", "config", "--no-lock"}, opts...)
+	// stdout is the config's JSON, stderr is kept apart so that warnings don't break decoding it.
+	output := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	if cmd.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(cmd.Stdout, output)
+	} else {
+		cmd.Stdout = output
+	}
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, stderr)
+	} else {
+		cmd.Stderr = stderr
+	}
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, stderr.String(), err)
+	}
+
+	var config repoConfig
+	if err := json.Unmarshal(output.Bytes(), &config); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), fmt.Errorf("command output is not valid JSON: %w", err))
+	}
+	return &config, nil
+}
+
+// CacheCleanup runs restic cache --cleanup, which removes the caches of repos not used for 30 days from the cache
+// directory. It doesn't open the repo.
+func (r *Repo) CacheCleanup(ctx context.Context, cleanupOutput io.Writer, opts ...GenericOption) error {
+	cmd := r.commandWithContext(ctx, []string{"cache", "--cleanup"}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if cleanupOutput != nil {
+		r.pipeCmdOutputToWriter(cmd, cleanupOutput)
+	}
+	if err := runCommand(ctx, cmd); err != nil {
+		return newCmdError(ctx, cmd, output.String(), err)
+	}
+	return nil
+}
+
+// Locks lists the locks currently held on the repo. The repo is not locked to list them.
+func (r *Repo) Locks(ctx context.Context, opts ...GenericOption) ([]*Lock, error) {
+	cmd := r.commandWithContext(ctx, []string{"list", "locks", "--no-lock"}, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+
+	var locks []*Lock
+	for _, id := range strings.Fields(output.String()) {
+		if err := ValidateSnapshotId(id); err != nil {
+			return nil, fmt.Errorf("invalid lock ID in output: %w", err)
+		}
+		cmd := r.commandWithContext(ctx, []string{"cat", "lock", id, "--no-lock"}, opts...)
+		lockOutput := bytes.NewBuffer(nil)
+		r.pipeCmdOutputToWriter(cmd, lockOutput)
+		if err := runCommand(ctx, cmd); err != nil {
+			if ctx.Err() != nil {
+				return nil, newCmdError(ctx, cmd, lockOutput.String(), err)
+			}
+			continue // the lock was removed since it was listed.
+		}
+		lock := &Lock{ID: id}
+		if err := json.Unmarshal(lockOutput.Bytes(), lock); err != nil {
+			return nil, newCmdError(ctx, cmd, lockOutput.String(), fmt.Errorf("command output is not valid JSON: %w", err))
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
 func (r *Repo) Unlock(ctx context.Context, opts ...GenericOption) error {
 	cmd := r.commandWithContext(ctx, []string{"unlock"}, opts...)
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		return newCmdError(ctx, cmd, output.String(), err)
 	}
 	return nil
 }
 
+// Version returns the version of the restic binary e.g. "0.16.4".
+func Version(ctx context.Context, resticBin string) (string, error) {
+	output, err := exec.CommandContext(ctx, resticBin, "version").Output()
+	if err != nil {
+		return "", fmt.Errorf("restic version: %w", err)
+	}
+	// output takes the form "restic 0.16.4 compiled with go1.21.6 on linux/amd64".
+	fields := strings.Fields(string(output))
+	if len(fields) < 2 || fields[0] != "restic" {
+		return "", fmt.Errorf("restic version: unexpected output %q", string(output))
+	}
+	return fields[1], nil
+}
+
 func (r *Repo) Stats(ctx context.Context, opts ...GenericOption) (*RepoStats, error) {
 	cmd := r.commandWithContext(ctx, []string{"stats", "--json", "--mode=raw-data"}, opts...)
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
 
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		return nil, newCmdError(ctx, cmd, output.String(), err)
 	}
 
@@ -326,16 +803,70 @@ func (r *Repo) Stats(ctx context.Context, opts ...GenericOption) (*RepoStats, er
 	return &stats, nil
 }
 
+// SnapshotStats returns the stats of a single snapshot, it runs restic stats in both raw-data and restore-size modes.
+func (r *Repo) SnapshotStats(ctx context.Context, snapshotID string, opts ...GenericOption) (*SnapshotStats, error) {
+	rawData, err := r.statsInMode(ctx, "raw-data", snapshotID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	restoreSize, err := r.statsInMode(ctx, "restore-size", snapshotID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotStats{
+		FileCount:   restoreSize.TotalFileCount,
+		TotalSize:   rawData.TotalSize,
+		RestoreSize: restoreSize.TotalSize,
+	}, nil
+}
+
+// RestoreSizeStats returns the restore-size stats of the whole repo i.e. the size and number of files of restoring
+// every snapshot.
+func (r *Repo) RestoreSizeStats(ctx context.Context, opts ...GenericOption) (*RepoStats, error) {
+	return r.statsInMode(ctx, "restore-size", "", opts...)
+}
+
+// statsInMode runs restic stats in the given mode for a single snapshot, or for the whole repo if snapshotID is empty.
+func (r *Repo) statsInMode(ctx context.Context, mode string, snapshotID string, opts ...GenericOption) (*RepoStats, error) {
+	args := []string{"stats", "--json", "--mode=" + mode}
+	if snapshotID != "" {
+		args = append(args, snapshotID)
+	}
+	cmd := r.commandWithContext(ctx, args, opts...)
+	output := bytes.NewBuffer(nil)
+	r.pipeCmdOutputToWriter(cmd, output)
+
+	if err := runCommand(ctx, cmd); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), err)
+	}
+
+	var stats RepoStats
+	if err := json.Unmarshal(output.Bytes(), &stats); err != nil {
+		return nil, newCmdError(ctx, cmd, output.String(), fmt.Errorf("command output is not valid JSON: %w", err))
+	}
+	return &stats, nil
+}
+
 // AddTags adds tags to the specified snapshots.
 func (r *Repo) AddTags(ctx context.Context, snapshotIDs []string, tags []string, opts ...GenericOption) error {
+	return r.EditTags(ctx, snapshotIDs, tags, nil, opts...)
+}
+
+// EditTags adds and removes tags of the snapshots, restic saves each snapshot it changes under a new ID.
+func (r *Repo) EditTags(ctx context.Context, snapshotIDs []string, add []string, remove []string, opts ...GenericOption) error {
 	args := []string{"tag"}
-	args = append(args, "--add", strings.Join(tags, ","))
+	if len(add) > 0 {
+		args = append(args, "--add", strings.Join(add, ","))
+	}
+	if len(remove) > 0 {
+		args = append(args, "--remove", strings.Join(remove, ","))
+	}
 	args = append(args, snapshotIDs...)
 
 	cmd := r.commandWithContext(ctx, args, opts...)
 	output := bytes.NewBuffer(nil)
 	r.pipeCmdOutputToWriter(cmd, output)
-	if err := cmd.Run(); err != nil {
+	if err := runCommand(ctx, cmd); err != nil {
 		return newCmdError(ctx, cmd, output.String(), err)
 	}
 	return nil
@@ -380,6 +911,7 @@ func (r *RetentionPolicy) toForgetFlags() []string {
 type GenericOpts struct {
 	extraArgs []string
 	extraEnv  []string
+	stdin     io.Reader
 }
 
 func resolveOpts(opts []GenericOption) *GenericOpts {
@@ -406,6 +938,64 @@ func WithTags(tags ...string) GenericOption {
 	}
 }
 
+// BackupTunables are restic backup options tuning how files are read and stored, zero values use restic's defaults.
+type BackupTunables struct {
+	ReadConcurrency int    // number of files read concurrently.
+	PackSizeMiB     int    // target size of pack files.
+	Compression     string // "auto", "off", "fastest", "better" or "max".
+	ExcludeCaches   bool   // skip directories containing a CACHEDIR.TAG file.
+	OneFileSystem   bool   // don't cross filesystem boundaries.
+	IgnoreInode     bool   // ignore inode numbers when detecting changed files.
+}
+
+// WithBackupTunables sets the flags of the tunables on a backup command.
+func WithBackupTunables(t BackupTunables) GenericOption {
+	var flags []string
+	if t.ReadConcurrency > 0 {
+		flags = append(flags, "--read-concurrency", strconv.Itoa(t.ReadConcurrency))
+	}
+	if t.PackSizeMiB > 0 {
+		flags = append(flags, "--pack-size", strconv.Itoa(t.PackSizeMiB))
+	}
+	if t.Compression != "" {
+		flags = append(flags, "--compression", t.Compression)
+	}
+	if t.ExcludeCaches {
+		flags = append(flags, "--exclude-caches")
+	}
+	if t.OneFileSystem {
+		flags = append(flags, "--one-file-system")
+	}
+	if t.IgnoreInode {
+		flags = append(flags, "--ignore-inode")
+	}
+	return WithFlags(flags...)
+}
+
+// WithBandwidthLimits limits the upload and download rates of a command to the given KiB/s, 0 for unlimited.
+func WithBandwidthLimits(uploadKbps, downloadKbps int) GenericOption {
+	var flags []string
+	if uploadKbps > 0 {
+		flags = append(flags, "--limit-upload", strconv.Itoa(uploadKbps))
+	}
+	if downloadKbps > 0 {
+		flags = append(flags, "--limit-download", strconv.Itoa(downloadKbps))
+	}
+	return WithFlags(flags...)
+}
+
+// WithStdin backs up what's read from stdin as a single file named filename, restic's default name is used if it's
+// empty. A backup with stdin must not be given any paths.
+func WithStdin(stdin io.Reader, filename string) GenericOption {
+	return func(opts *GenericOpts) {
+		opts.stdin = stdin
+		opts.extraArgs = append(opts.extraArgs, "--stdin")
+		if filename != "" {
+			opts.extraArgs = append(opts.extraArgs, "--stdin-filename", filename)
+		}
+	}
+}
+
 func WithEnv(env ...string) GenericOption {
 	return func(opts *GenericOpts) {
 		opts.extraEnv = append(opts.extraEnv, env...)
@@ -5,7 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
 	"reflect"
 	"runtime"
@@ -28,6 +32,18 @@ func TestResticInit(t *testing.T) {
 	}
 }
 
+func TestResticVersion(t *testing.T) {
+	t.Parallel()
+
+	version, err := Version(context.Background(), helpers.ResticBinary(t))
+	if err != nil {
+		t.Fatalf("failed to get restic version: %v", err)
+	}
+	if !strings.HasPrefix(version, "0.") {
+		t.Errorf("unexpected restic version %q", version)
+	}
+}
+
 func TestResticBackup(t *testing.T) {
 	t.Parallel()
 	repo := t.TempDir()
@@ -74,6 +90,25 @@ func TestResticBackup(t *testing.T) {
 			opts:  []GenericOption{WithFlags("--iexclude=file*")},
 			files: 0,
 		},
+		{
+			name:  "with tunables",
+			paths: []string{testData},
+			opts: []GenericOption{WithBackupTunables(BackupTunables{
+				ReadConcurrency: 4,
+				PackSizeMiB:     8,
+				Compression:     "max",
+				ExcludeCaches:   true,
+				OneFileSystem:   true,
+				IgnoreInode:     true,
+			})},
+			files: 100,
+		},
+		{
+			name:  "with bandwidth limits",
+			paths: []string{testData},
+			opts:  []GenericOption{WithBandwidthLimits(10240, 10240)},
+			files: 100,
+		},
 		{
 			name:    "with nothing to backup",
 			paths:   []string{},
@@ -159,6 +194,41 @@ func TestResticPartialBackup(t *testing.T) {
 	}
 }
 
+func TestResticBackupExitCode3IsPartial(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script in place of restic")
+	}
+
+	// the script stands in for restic failing to read a file, a real unreadable file is readable when tests run as root.
+	dir := t.TempDir()
+	script := filepath.Join(dir, "restic")
+	if err := os.WriteFile(script, []byte(`#!/bin/sh
+echo '{"message_type":"error","error":{"message":"open /data/secret: permission denied"},"during":"archival","item":"/data/secret"}' >&2
+echo '{"message_type":"summary","total_files_processed":1,"snapshot_id":"d4558b360cc1b7966e416e010382ab8feb49d14da7832266832d69a43af10147"}'
+exit 3
+`), 0755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	r := NewRepo(script, t.TempDir())
+	var errs []*BackupProgressEntry
+	summary, err := r.Backup(context.Background(), []string{dir}, func(entry *BackupProgressEntry) {
+		if entry.MessageType == "error" {
+			errs = append(errs, entry)
+		}
+	})
+	if !errors.Is(err, ErrPartialBackup) {
+		t.Fatalf("wanted error to be partial backup, got: %v", err)
+	}
+	if summary == nil || summary.SnapshotId == "" {
+		t.Errorf("wanted the summary of the created snapshot, got: %+v", summary)
+	}
+	if len(errs) != 1 || errs[0].Item != "/data/secret" {
+		t.Errorf("wanted the error reading /data/secret, got: %+v", errs)
+	}
+}
+
 func TestResticBackupLots(t *testing.T) {
 	t.Parallel()
 	t.Skip("this test takes a long time to run")
@@ -268,6 +338,256 @@ func TestLs(t *testing.T) {
 	}
 }
 
+func TestResticDump(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	if err := os.WriteFile(filepath.Join(testData, "file10"), []byte("hello dump"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	summary, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup and create new snapshot: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Dump(context.Background(), summary.SnapshotId, filepath.ToSlash(filepath.Join(testData, "file10")), &buf); err != nil {
+		t.Fatalf("failed to dump file: %v", err)
+	}
+	if buf.String() != "hello dump" {
+		t.Errorf("wanted the file's content, got %q", buf.String())
+	}
+
+	if err := r.Dump(context.Background(), summary.SnapshotId, filepath.ToSlash(filepath.Join(testData, "missing")), io.Discard); err == nil {
+		t.Errorf("expected an error dumping a missing file")
+	}
+	if err := r.Dump(context.Background(), summary.SnapshotId, "--no-lock", io.Discard); err == nil {
+		t.Errorf("expected an error for a relative path")
+	}
+}
+
+func TestResticDiff(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	before, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup and create new snapshot: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(testData, "file10")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testData, "file11"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testData, "new"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to add file: %v", err)
+	}
+	after, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup and create new snapshot: %v", err)
+	}
+
+	diff, err := r.Diff(context.Background(), before.SnapshotId, after.SnapshotId)
+	if err != nil {
+		t.Fatalf("failed to diff snapshots: %v", err)
+	}
+	got := make(map[string]string)
+	for _, c := range diff.Changes {
+		got[path.Base(c.Path)] = c.Modifier
+	}
+	want := map[string]string{"file10": "-", "file11": "M", "new": "+"}
+	if !maps.Equal(got, want) {
+		t.Errorf("wanted changes %v, got: %v", want, got)
+	}
+	if diff.Added.Files != 1 || diff.Removed.Files != 1 || diff.ChangedFiles != 1 {
+		t.Errorf("unexpected diff stats %+v", diff)
+	}
+}
+
+func TestResticFind(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	first, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup and create new snapshot: %v", err)
+	}
+	if _, err := r.Backup(context.Background(), []string{testData}, nil); err != nil {
+		t.Fatalf("failed to backup and create new snapshot: %v", err)
+	}
+
+	var results []*FindResult
+	collect := func(r *FindResult) error {
+		results = append(results, r)
+		return nil
+	}
+	if err := r.Find(context.Background(), "file1*", collect); err != nil {
+		t.Fatalf("failed to find files: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("wanted matches in 2 snapshots, got: %d", len(results))
+	}
+	for _, res := range results {
+		// "file10" to "file19".
+		if len(res.Matches) != 10 {
+			t.Errorf("wanted 10 matches in snapshot %v, got: %d", res.Snapshot, len(res.Matches))
+		}
+	}
+
+	results = nil
+	if err := r.Find(context.Background(), "file 1", collect, WithFlags("--snapshot", first.SnapshotId)); err != nil {
+		t.Fatalf("failed to find files: %v", err)
+	}
+	if len(results) != 1 || results[0].Snapshot != first.SnapshotId || results[0].Matches[0].Size != int64(len("test data 1")) {
+		t.Errorf("wanted one match in snapshot %v, got: %+v", first.SnapshotId, results)
+	}
+
+	stop := errors.New("stop")
+	if err := r.Find(context.Background(), "file*", func(r *FindResult) error { return stop }); err != stop {
+		t.Errorf("wanted the callback's error, got: %v", err)
+	}
+	if err := r.Find(context.Background(), "--no-lock", collect); err == nil {
+		t.Errorf("wanted an error for a pattern that looks like a flag")
+	}
+}
+
+func TestResticCopy(t *testing.T) {
+	t.Parallel()
+
+	srcPath := t.TempDir()
+	src := NewRepo(helpers.ResticBinary(t), srcPath, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=src"))
+	if err := src.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init source repo: %v", err)
+	}
+	dst := NewRepo(helpers.ResticBinary(t), t.TempDir(), WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=dst"))
+	if err := dst.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init destination repo: %v", err)
+	}
+
+	output, err := src.Backup(context.Background(), []string{helpers.CreateTestData(t)}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	fromOpts := WithEnv("RESTIC_FROM_REPOSITORY="+srcPath, "RESTIC_FROM_PASSWORD=src")
+	copied, err := dst.Copy(context.Background(), fromOpts)
+	if err != nil {
+		t.Fatalf("failed to copy: %v", err)
+	}
+	if len(copied) != 1 || !strings.HasPrefix(output.SnapshotId, copied[0].SourceID) {
+		t.Fatalf("wanted snapshot %v to be copied, got: %v", output.SnapshotId, copied)
+	}
+
+	snapshots, err := dst.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list destination snapshots: %v", err)
+	}
+	if len(snapshots) != 1 || !strings.HasPrefix(snapshots[0].Id, copied[0].DestinationID) {
+		t.Errorf("wanted copy %v in destination, got: %v", copied[0].DestinationID, snapshots)
+	}
+
+	// snapshots that were already copied are skipped.
+	copied, err = dst.Copy(context.Background(), fromOpts)
+	if err != nil {
+		t.Fatalf("failed to copy again: %v", err)
+	}
+	if len(copied) != 0 {
+		t.Errorf("wanted no snapshots copied on the second run, got: %v", copied)
+	}
+}
+
+func TestResticLocks(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	locks, err := r.Locks(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list locks: %v", err)
+	}
+	if len(locks) != 0 {
+		t.Fatalf("wanted no locks, got: %v", locks)
+	}
+
+	// a backup from stdin holds its lock until stdin is closed.
+	cmd := exec.Command(helpers.ResticBinary(t), "backup", "--no-cache", "--stdin", "-r", repo)
+	cmd.Env = append(os.Environ(), "RESTIC_PASSWORD=test")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatalf("failed to get stdin pipe: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start backup: %v", err)
+	}
+	defer func() {
+		stdin.Close()
+		cmd.Wait()
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for len(locks) == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		locks, err = r.Locks(context.Background())
+		if err != nil {
+			t.Fatalf("failed to list locks: %v", err)
+		}
+	}
+
+	if len(locks) != 1 {
+		t.Fatalf("wanted 1 lock, got: %v", locks)
+	}
+	if locks[0].PID != cmd.Process.Pid || locks[0].Exclusive || time.Since(locks[0].Time) > time.Minute {
+		t.Errorf("unexpected lock: %+v", locks[0])
+	}
+}
+
+func TestResticReachable(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	if err := r.Reachable(context.Background()); err != nil {
+		t.Errorf("wanted repo to be reachable, got: %v", err)
+	}
+
+	wrongPassword := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=wrong"))
+	if err := wrongPassword.Reachable(context.Background()); err == nil {
+		t.Errorf("wanted an error opening the repo with the wrong password")
+	}
+
+	missing := NewRepo(helpers.ResticBinary(t), repo+"/missing", WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := missing.Reachable(context.Background()); err == nil {
+		t.Errorf("wanted an error opening a repo that does not exist")
+	}
+}
+
 func TestResticForget(t *testing.T) {
 	t.Parallel()
 
@@ -394,6 +714,222 @@ func TestResticPrune(t *testing.T) {
 	}
 }
 
+func TestResticCheck(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+	if _, err := r.Backup(context.Background(), []string{testData}, nil); err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+
+	output := bytes.NewBuffer(nil)
+	if err := r.Check(context.Background(), output); err != nil {
+		t.Fatalf("failed to check repo: %v", err)
+	}
+
+	wantStr := "no errors were found"
+	if !bytes.Contains(output.Bytes(), []byte(wantStr)) {
+		t.Errorf("wanted output to contain %q, got: %s", wantStr, output.String())
+	}
+}
+
+func TestResticMigrate(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background(), WithFlags("--repository-version", "1")); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	migrations, err := r.Migrations(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	if !slices.Contains(migrations, "upgrade_repo_v2") {
+		t.Fatalf("wanted upgrade_repo_v2 to be applicable to a v1 repo, got: %v", migrations)
+	}
+
+	if err := r.Migrate(context.Background(), "no_such_migration", nil); err == nil {
+		t.Errorf("wanted an error for an unknown migration")
+	}
+
+	output := bytes.NewBuffer(nil)
+	if err := r.Migrate(context.Background(), "upgrade_repo_v2", output); err != nil {
+		t.Fatalf("failed to migrate repo: %v", err)
+	}
+	if output.Len() == 0 {
+		t.Errorf("wanted migrate output")
+	}
+
+	if err := r.Migrate(context.Background(), "upgrade_repo_v2", nil); err == nil {
+		t.Errorf("wanted an error migrating an upgraded repo")
+	}
+}
+
+func TestResticRecover(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	result, err := r.Recover(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("failed to recover: %v", err)
+	}
+	if result.Roots != 0 || result.SnapshotID != "" {
+		t.Errorf("wanted nothing to recover from an empty repo, got: %+v", result)
+	}
+
+	testData := t.TempDir()
+	if err := os.WriteFile(filepath.Join(testData, "a"), []byte("a"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	forgotten, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testData, "b"), []byte("b"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if _, err := r.Backup(context.Background(), []string{testData}, nil); err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+	if err := r.ForgetSnapshot(context.Background(), forgotten.SnapshotId); err != nil {
+		t.Fatalf("failed to forget snapshot: %v", err)
+	}
+
+	output := bytes.NewBuffer(nil)
+	result, err = r.Recover(context.Background(), output)
+	if err != nil {
+		t.Fatalf("failed to recover: %v", err)
+	}
+	if result.Roots != 1 || result.SnapshotID == "" {
+		t.Errorf("wanted the forgotten snapshot's directory recovered into a snapshot, got: %+v", result)
+	}
+	if output.Len() == 0 {
+		t.Errorf("wanted recover output")
+	}
+
+	snapshots, err := r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if !slices.ContainsFunc(snapshots, func(s *Snapshot) bool {
+		return strings.HasPrefix(s.Id, result.SnapshotID) && slices.Contains(s.Tags, "recovered")
+	}) {
+		t.Errorf("wanted a snapshot tagged recovered with ID %v, got: %v", result.SnapshotID, snapshots)
+	}
+}
+
+func TestResticRewrite(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := t.TempDir()
+	for _, name := range []string{"secret", "kept"} {
+		if err := os.WriteFile(filepath.Join(testData, name), []byte(name), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+	summary, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup: %v", err)
+	}
+	excludes := []string{filepath.Join(testData, "secret")}
+
+	result, err := r.Rewrite(context.Background(), []string{summary.SnapshotId}, excludes, nil, WithFlags("--dry-run"))
+	if err != nil {
+		t.Fatalf("failed to dry run rewrite: %v", err)
+	}
+	if result.Rewritten != 1 {
+		t.Errorf("wanted the dry run to rewrite 1 snapshot, got: %+v", result)
+	}
+
+	output := bytes.NewBuffer(nil)
+	result, err = r.Rewrite(context.Background(), []string{summary.SnapshotId}, excludes, output, WithFlags("--forget"))
+	if err != nil {
+		t.Fatalf("failed to rewrite: %v", err)
+	}
+	if result.Rewritten != 1 || output.Len() == 0 {
+		t.Errorf("wanted 1 snapshot rewritten with output, got: %+v", result)
+	}
+
+	snapshots, err := r.Snapshots(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Id == summary.SnapshotId {
+		t.Fatalf("wanted the snapshot replaced by its rewritten copy, got: %v", snapshots)
+	}
+	_, entries, err := r.ListDirectory(context.Background(), snapshots[0].Id, testData)
+	if err != nil {
+		t.Fatalf("failed to list rewritten snapshot: %v", err)
+	}
+	if slices.ContainsFunc(entries, func(e *LsEntry) bool { return e.Name == "secret" }) {
+		t.Errorf("wanted the excluded file removed from the snapshot, got: %v", entries)
+	}
+
+	if _, err := r.Rewrite(context.Background(), nil, excludes, nil); err == nil {
+		t.Errorf("wanted an error rewriting without snapshot IDs")
+	}
+}
+
+func TestResticKeys(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	keys, err := r.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list keys: %v", err)
+	}
+	if len(keys) != 1 || !keys[0].Current {
+		t.Fatalf("wanted the repo's initial key, got: %v", keys)
+	}
+
+	added, err := r.AddKey(context.Background(), "other", "alice", "laptop")
+	if err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+	if added.ID == keys[0].ID || added.Current || added.UserName != "alice" || added.HostName != "laptop" {
+		t.Errorf("unexpected added key: %+v", added)
+	}
+
+	// the new key opens the repo.
+	other := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=other"))
+	if err := other.RemoveKey(context.Background(), added.ID); err == nil {
+		t.Errorf("wanted an error removing the key that opens the repo")
+	}
+	if err := other.RemoveKey(context.Background(), keys[0].ID); err != nil {
+		t.Fatalf("failed to remove key: %v", err)
+	}
+	if _, err := r.ListKeys(context.Background()); err == nil {
+		t.Errorf("wanted the removed key's password to no longer open the repo")
+	}
+	if err := other.RemoveKey(context.Background(), "--no-lock"); err == nil {
+		t.Errorf("wanted an error for an invalid key ID")
+	}
+}
+
 func TestResticRestore(t *testing.T) {
 	t.Parallel()
 
@@ -465,6 +1001,45 @@ func TestResticStats(t *testing.T) {
 	if stats.TotalBlobCount == 0 {
 		t.Errorf("wanted non-zero total blob count, got: %d", stats.TotalBlobCount)
 	}
+
+	restoreStats, err := r.RestoreSizeStats(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get restore size stats: %v", err)
+	}
+	if restoreStats.TotalSize == 0 || restoreStats.TotalFileCount == 0 {
+		t.Errorf("wanted non-zero restore size and file count, got: %+v", restoreStats)
+	}
+}
+
+func TestResticSnapshotStats(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	r := NewRepo(helpers.ResticBinary(t), repo, WithFlags("--no-cache"), WithEnv("RESTIC_PASSWORD=test"))
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	testData := helpers.CreateTestData(t)
+
+	summary, err := r.Backup(context.Background(), []string{testData}, nil)
+	if err != nil {
+		t.Fatalf("failed to backup and create new snapshot: %v", err)
+	}
+
+	stats, err := r.SnapshotStats(context.Background(), summary.SnapshotId)
+	if err != nil {
+		t.Fatalf("failed to get snapshot stats: %v", err)
+	}
+	if stats.FileCount == 0 {
+		t.Errorf("wanted non-zero file count, got: %d", stats.FileCount)
+	}
+	if stats.TotalSize == 0 {
+		t.Errorf("wanted non-zero total size, got: %d", stats.TotalSize)
+	}
+	if stats.RestoreSize == 0 {
+		t.Errorf("wanted non-zero restore size, got: %d", stats.RestoreSize)
+	}
 }
 
 func toRepoPath(path string) string {
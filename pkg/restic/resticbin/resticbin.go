@@ -0,0 +1,181 @@
+// Package resticbin downloads and verifies restic release binaries.
+package resticbin
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultReleaseURL is the URL restic releases are downloaded from, each release is in a subdirectory named "v<version>".
+const DefaultReleaseURL = "https://github.com/restic/restic/releases/download"
+
+// Manager downloads pinned restic releases for the current OS and architecture and stores them in a directory.
+// Downloads are verified against the SHA256 checksums published with the release.
+type Manager struct {
+	Dir        string       // directory the binaries are stored in.
+	ReleaseURL string       // optional, defaults to DefaultReleaseURL.
+	Client     *http.Client // optional, defaults to http.DefaultClient.
+
+	// VerifySums is optional, it is called with a release's SHA256SUMS file before the file is trusted e.g. to check the
+	// file's signature.
+	VerifySums func(ctx context.Context, version string, sums []byte) error
+}
+
+// BinaryName returns the file name of the binary of the given restic version.
+func BinaryName(version string) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("restic-%v.exe", version)
+	}
+	return fmt.Sprintf("restic-%v", version)
+}
+
+// ReleaseAssetName returns the name of the release asset holding the binary for the current OS and architecture.
+func ReleaseAssetName(version string) string {
+	if runtime.GOOS == "windows" {
+		// restic is only built for 386 and amd64 on Windows, default to amd64 for other platforms (e.g. arm64.)
+		arch := "amd64"
+		if runtime.GOARCH == "386" || runtime.GOARCH == "amd64" {
+			arch = runtime.GOARCH
+		}
+		return fmt.Sprintf("restic_%v_windows_%v.zip", version, arch)
+	}
+	return fmt.Sprintf("restic_%v_%v_%v.bz2", version, runtime.GOOS, runtime.GOARCH)
+}
+
+// Path returns the path the binary of the given version is stored at, it may not be installed yet.
+func (m *Manager) Path(version string) string {
+	return filepath.Join(m.Dir, BinaryName(version))
+}
+
+// Install downloads, verifies and stores the binary of the given version if it isn't already stored, returning its
+// path. It is not safe to install the same version from multiple processes at once.
+func (m *Manager) Install(ctx context.Context, version string) (string, error) {
+	binPath := m.Path(version)
+	if _, err := os.Stat(binPath); err == nil {
+		return binPath, nil
+	}
+
+	sums, err := m.get(ctx, m.releaseURL(version, "SHA256SUMS"))
+	if err != nil {
+		return "", fmt.Errorf("get checksums: %w", err)
+	}
+	if m.VerifySums != nil {
+		if err := m.VerifySums(ctx, version, sums); err != nil {
+			return "", fmt.Errorf("verify checksums: %w", err)
+		}
+	}
+	asset := ReleaseAssetName(version)
+	want, err := checksumForAsset(sums, asset)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := m.get(ctx, m.releaseURL(version, asset))
+	if err != nil {
+		return "", fmt.Errorf("download %v: %w", asset, err)
+	}
+	if sum := sha256.Sum256(body); hex.EncodeToString(sum[:]) != want {
+		return "", fmt.Errorf("checksum of %v is %x, the release's checksum is %v", asset, sum, want)
+	}
+	bin, err := extractBinary(asset, body)
+	if err != nil {
+		return "", fmt.Errorf("extract %v: %w", asset, err)
+	}
+
+	if err := os.MkdirAll(m.Dir, 0755); err != nil {
+		return "", fmt.Errorf("create directory %v: %w", m.Dir, err)
+	}
+	// the binary is written under a temporary name so that a partially written binary is never run.
+	tmpPath := binPath + ".tmp"
+	if err := os.WriteFile(tmpPath, bin, 0755); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("write %v: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, binPath); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("rename %v to %v: %w", tmpPath, binPath, err)
+	}
+	return binPath, nil
+}
+
+func (m *Manager) releaseURL(version, file string) string {
+	base := m.ReleaseURL
+	if base == "" {
+		base = DefaultReleaseURL
+	}
+	return fmt.Sprintf("%v/v%v/%v", strings.TrimSuffix(base, "/"), version, file)
+}
+
+func (m *Manager) get(ctx context.Context, url string) ([]byte, error) {
+	client := m.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http GET %v: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http GET %v: %v", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	return body, nil
+}
+
+// checksumForAsset returns the checksum of the asset in a SHA256SUMS file, lines take the form "<hex sha256>  <name>".
+func checksumForAsset(sums []byte, asset string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// sha256sum prefixes names with '*' in binary mode.
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum for %v in the release's checksums", asset)
+}
+
+// extractBinary returns the binary in a bz2 or zip compressed release asset.
+func extractBinary(asset string, body []byte) ([]byte, error) {
+	switch {
+	case strings.HasSuffix(asset, ".bz2"):
+		return io.ReadAll(bzip2.NewReader(bytes.NewReader(body)))
+	case strings.HasSuffix(asset, ".zip"):
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			return nil, fmt.Errorf("open zip archive: %w", err)
+		}
+		if len(archive.File) != 1 {
+			return nil, fmt.Errorf("expected zip archive to contain exactly one file, got %v", len(archive.File))
+		}
+		f, err := archive.File[0].Open()
+		if err != nil {
+			return nil, fmt.Errorf("open zip archive file %v: %w", archive.File[0].Name, err)
+		}
+		defer f.Close()
+		return io.ReadAll(f)
+	default:
+		return body, nil
+	}
+}
@@ -0,0 +1,136 @@
+package resticbin
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// testBinary is "#!/bin/sh\necho restic 0.16.4\n" compressed with bzip2.
+var testBinary = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xa0, 0x88, 0xb2, 0x15, 0x00, 0x00, 0x02, 0xd9, 0x80, 0x00,
+	0x10, 0x68, 0x01, 0xe5, 0x00, 0x1a, 0x61, 0x9c, 0x00, 0x20, 0x00, 0x31, 0x4c, 0x26, 0x9a, 0x03, 0x4c, 0x42, 0x80, 0x64,
+	0xf5, 0x32, 0x34, 0xc8, 0x6a, 0x8c, 0x58, 0x09, 0xa6, 0x1b, 0x92, 0xab, 0x30, 0xf3, 0xf6, 0x20, 0x9d, 0x00, 0x3f, 0x8b,
+	0xb9, 0x22, 0x9c, 0x28, 0x48, 0x50, 0x44, 0x59, 0x0a, 0x80,
+}
+
+func newReleaseServer(t *testing.T, sums string, requests *int) *httptest.Server {
+	asset := ReleaseAssetName("0.16.4")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0.16.4/SHA256SUMS", func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Write([]byte(sums))
+	})
+	mux.HandleFunc("/v0.16.4/"+asset, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Write(testBinary)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("release assets are zip archives on windows")
+	}
+	t.Parallel()
+
+	sum := sha256.Sum256(testBinary)
+	sums := fmt.Sprintf("%x  restic-0.16.4.tar.gz\n%x  %v\n", sha256.Sum256(nil), sum, ReleaseAssetName("0.16.4"))
+	var requests int
+	srv := newReleaseServer(t, sums, &requests)
+
+	var verified []byte
+	m := &Manager{
+		Dir:        t.TempDir(),
+		ReleaseURL: srv.URL,
+		VerifySums: func(ctx context.Context, version string, sums []byte) error {
+			verified = sums
+			return nil
+		},
+	}
+	path, err := m.Install(context.Background(), "0.16.4")
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if path != m.Path("0.16.4") {
+		t.Errorf("expected the binary to be installed at %v, got %v", m.Path("0.16.4"), path)
+	}
+	if string(verified) != sums {
+		t.Errorf("expected the checksums to be verified, got %q", verified)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading binary: %v", err)
+	}
+	if string(data) != "#!/bin/sh\necho restic 0.16.4\n" {
+		t.Errorf("unexpected binary contents %q", data)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode()&0100 == 0 {
+		t.Errorf("expected the binary to be executable, got %v %v", info, err)
+	}
+
+	// installed binaries are not downloaded again.
+	requests = 0
+	if _, err := m.Install(context.Background(), "0.16.4"); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests for an installed binary, got %d", requests)
+	}
+}
+
+func TestInstallRejectsUnverifiedBinaries(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("release assets are zip archives on windows")
+	}
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		sums       string
+		verifySums func(ctx context.Context, version string, sums []byte) error
+		wantErr    string
+	}{
+		{
+			name:    "checksum mismatch",
+			sums:    fmt.Sprintf("%x  %v\n", sha256.Sum256(nil), ReleaseAssetName("0.16.4")),
+			wantErr: "checksum of",
+		},
+		{
+			name:    "no checksum",
+			sums:    fmt.Sprintf("%x  restic-0.16.4.tar.gz\n", sha256.Sum256(testBinary)),
+			wantErr: "no checksum",
+		},
+		{
+			name: "checksums not verified",
+			sums: fmt.Sprintf("%x  %v\n", sha256.Sum256(testBinary), ReleaseAssetName("0.16.4")),
+			verifySums: func(ctx context.Context, version string, sums []byte) error {
+				return errors.New("bad signature")
+			},
+			wantErr: "bad signature",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int
+			srv := newReleaseServer(t, tc.sums, &requests)
+			m := &Manager{Dir: t.TempDir(), ReleaseURL: srv.URL, VerifySums: tc.verifySums}
+			if _, err := m.Install(context.Background(), "0.16.4"); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tc.wantErr, err)
+			}
+			if _, err := os.Stat(m.Path("0.16.4")); err == nil {
+				t.Errorf("expected no binary to be installed")
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package restic
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/garethgeorge/backrest/internal/sysinfo"
+)
+
+// usageSampleInterval is how often the usage of a running command is sampled.
+var usageSampleInterval = 1 * time.Second
+
+var usageKey = struct{ name string }{"usage"}
+
+// ContextWithUsage records the CPU and memory used by the commands run with the context in usage.
+func ContextWithUsage(ctx context.Context, usage *Usage) context.Context {
+	return context.WithValue(ctx, usageKey, usage)
+}
+
+func UsageFromContext(ctx context.Context) *Usage {
+	usage, _ := ctx.Value(usageKey).(*Usage)
+	return usage
+}
+
+// Usage accumulates the CPU and memory used by restic commands e.g. of an operation. Running commands are sampled
+// where the usage of a process can be read, otherwise only the totals reported when a command exits are recorded.
+type Usage struct {
+	mu           sync.Mutex
+	commands     int
+	runTime      time.Duration
+	cpuTime      time.Duration
+	peakCPUCores float64
+	peakRSSBytes uint64
+	rssSum       float64
+	rssSamples   int
+}
+
+// UsageSummary is the usage of the commands recorded by a Usage.
+type UsageSummary struct {
+	Commands     int
+	RunTime      time.Duration // total time the commands ran.
+	CPUTime      time.Duration // user and system CPU time used by the commands.
+	AvgCPUCores  float64       // CPUTime over RunTime, 1.0 is one core fully used.
+	PeakCPUCores float64       // highest CPU usage between two samples, 0 if no samples were taken.
+	AvgRSSBytes  uint64        // average resident memory of the samples, 0 if no samples were taken.
+	PeakRSSBytes uint64        // highest resident memory of any command.
+}
+
+func (u *Usage) Summary() UsageSummary {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	s := UsageSummary{
+		Commands:     u.commands,
+		RunTime:      u.runTime,
+		CPUTime:      u.cpuTime,
+		PeakCPUCores: u.peakCPUCores,
+		PeakRSSBytes: u.peakRSSBytes,
+	}
+	if u.runTime > 0 {
+		s.AvgCPUCores = u.cpuTime.Seconds() / u.runTime.Seconds()
+	}
+	if u.rssSamples > 0 {
+		s.AvgRSSBytes = uint64(u.rssSum / float64(u.rssSamples))
+	}
+	return s
+}
+
+// sample records the usage of the process every usageSampleInterval until the returned function is called.
+func (u *Usage) sample(pid int) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(usageSampleInterval)
+		defer ticker.Stop()
+		var last sysinfo.ProcessUsage
+		lastTime := time.Now()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			cur, err := sysinfo.Process(pid)
+			if err != nil {
+				return // the process exited or its usage can't be read on this platform.
+			}
+			now := time.Now()
+			u.mu.Lock()
+			u.peakCPUCores = max(u.peakCPUCores, (cur.CPUTime-last.CPUTime).Seconds()/now.Sub(lastTime).Seconds())
+			u.peakRSSBytes = max(u.peakRSSBytes, cur.RSSBytes)
+			u.rssSum += float64(cur.RSSBytes)
+			u.rssSamples++
+			u.mu.Unlock()
+			last, lastTime = cur, now
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+// record adds the totals of an exited command.
+func (u *Usage) record(cmd *exec.Cmd, runTime time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.commands++
+	u.runTime += runTime
+	if state := cmd.ProcessState; state != nil {
+		u.cpuTime += state.UserTime() + state.SystemTime()
+		u.peakRSSBytes = max(u.peakRSSBytes, sysinfo.PeakRSS(state))
+	}
+}
+
+// runCommand runs the command, recording its usage if the context carries a Usage.
+func runCommand(ctx context.Context, cmd *exec.Cmd) error {
+	usage := UsageFromContext(ctx)
+	if usage == nil {
+		return cmd.Run()
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	start := time.Now()
+	stop := usage.sample(cmd.Process.Pid)
+	err := cmd.Wait()
+	stop()
+	usage.record(cmd, time.Since(start))
+	return err
+}
@@ -0,0 +1,44 @@
+package restic
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRunCommandRecordsUsage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell loop")
+	}
+	usageSampleInterval = 10 * time.Millisecond
+	defer func() { usageSampleInterval = 1 * time.Second }()
+
+	usage := &Usage{}
+	ctx := ContextWithUsage(context.Background(), usage)
+	for i := 0; i < 2; i++ {
+		cmd := exec.Command("sh", "-c", "end=$(($(date +%s) + 1)); while [ $(date +%s) -lt $end ]; do :; done")
+		if err := runCommand(ctx, cmd); err != nil {
+			t.Fatalf("runCommand() error: %v", err)
+		}
+	}
+
+	summary := usage.Summary()
+	if summary.Commands != 2 {
+		t.Errorf("want 2 commands, got %d", summary.Commands)
+	}
+	if summary.RunTime <= 0 || summary.CPUTime <= 0 || summary.AvgCPUCores <= 0 {
+		t.Errorf("expected the commands' run time and CPU time to be recorded, got %+v", summary)
+	}
+	if runtime.GOOS == "linux" && (summary.PeakRSSBytes == 0 || summary.AvgRSSBytes == 0 || summary.PeakCPUCores == 0) {
+		t.Errorf("expected the commands to be sampled on linux, got %+v", summary)
+	}
+
+	if err := runCommand(context.Background(), exec.Command("true")); err != nil {
+		t.Fatalf("runCommand() error: %v", err)
+	}
+	if usage.Summary().Commands != 2 {
+		t.Errorf("expected commands run without a usage in the context not to be recorded")
+	}
+}
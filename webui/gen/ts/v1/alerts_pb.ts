@@ -0,0 +1,305 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file v1/alerts.proto (package v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+
+/**
+ * Alert is a problem found by one of backrest's health rules. An alert is listed until the problem is resolved,
+ * acknowledging or snoozing it only suppresses its repeat notifications.
+ *
+ * @generated from message v1.Alert
+ */
+export class Alert extends Message<Alert> {
+  /**
+   * identifies the alert e.g. "backup_failed/<plan>", stable for as long as the problem lasts.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: v1.Alert.Kind kind = 2;
+   */
+  kind = Alert_Kind.UNKNOWN;
+
+  /**
+   * optional, the plan the alert is about.
+   *
+   * @generated from field: string plan_id = 3;
+   */
+  planId = "";
+
+  /**
+   * optional, the repo the alert is about.
+   *
+   * @generated from field: string repo_id = 4;
+   */
+  repoId = "";
+
+  /**
+   * optional, the namespace the alert is about.
+   *
+   * @generated from field: string namespace = 5;
+   */
+  namespace = "";
+
+  /**
+   * human readable description of the problem.
+   *
+   * @generated from field: string message = 6;
+   */
+  message = "";
+
+  /**
+   * optional, unix time in milliseconds the problem started at.
+   *
+   * @generated from field: int64 since_ms = 7;
+   */
+  sinceMs = protoInt64.zero;
+
+  /**
+   * optional, the operation that most recently showed the problem.
+   *
+   * @generated from field: int64 operation_id = 8;
+   */
+  operationId = protoInt64.zero;
+
+  /**
+   * set if the alert was acknowledged or snoozed.
+   *
+   * @generated from field: v1.AlertState state = 9;
+   */
+  state?: AlertState;
+
+  constructor(data?: PartialMessage<Alert>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.Alert";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "kind", kind: "enum", T: proto3.getEnumType(Alert_Kind) },
+    { no: 3, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "namespace", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "since_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "operation_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "state", kind: "message", T: AlertState },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Alert {
+    return new Alert().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Alert {
+    return new Alert().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Alert {
+    return new Alert().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Alert | PlainMessage<Alert> | undefined, b: Alert | PlainMessage<Alert> | undefined): boolean {
+    return proto3.util.equals(Alert, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.Alert.Kind
+ */
+export enum Alert_Kind {
+  /**
+   * @generated from enum value: KIND_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * the plan's most recent backup failed.
+   *
+   * @generated from enum value: KIND_BACKUP_FAILED = 1;
+   */
+  BACKUP_FAILED = 1,
+
+  /**
+   * the plan has not run a backup since it was last expected to.
+   *
+   * @generated from enum value: KIND_MISSED_SCHEDULE = 2;
+   */
+  MISSED_SCHEDULE = 2,
+
+  /**
+   * the restores staged for the namespace are at or above its staging quota.
+   *
+   * @generated from enum value: KIND_STAGING_QUOTA = 3;
+   */
+  STAGING_QUOTA = 3,
+
+  /**
+   * the most recent oplog verification of the repo found inconsistencies.
+   *
+   * @generated from enum value: KIND_OPLOG_INCONSISTENT = 4;
+   */
+  OPLOG_INCONSISTENT = 4,
+}
+// Retrieve enum metadata with: proto3.getEnumType(Alert_Kind)
+proto3.util.setEnumType(Alert_Kind, "v1.Alert.Kind", [
+  { no: 0, name: "KIND_UNKNOWN" },
+  { no: 1, name: "KIND_BACKUP_FAILED" },
+  { no: 2, name: "KIND_MISSED_SCHEDULE" },
+  { no: 3, name: "KIND_STAGING_QUOTA" },
+  { no: 4, name: "KIND_OPLOG_INCONSISTENT" },
+]);
+
+/**
+ * @generated from message v1.AlertList
+ */
+export class AlertList extends Message<AlertList> {
+  /**
+   * @generated from field: repeated v1.Alert alerts = 1;
+   */
+  alerts: Alert[] = [];
+
+  constructor(data?: PartialMessage<AlertList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.AlertList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "alerts", kind: "message", T: Alert, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AlertList {
+    return new AlertList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AlertList {
+    return new AlertList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AlertList {
+    return new AlertList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AlertList | PlainMessage<AlertList> | undefined, b: AlertList | PlainMessage<AlertList> | undefined): boolean {
+    return proto3.util.equals(AlertList, a, b);
+  }
+}
+
+/**
+ * AlertState is the user's response to an alert, it's discarded once the alert is resolved.
+ *
+ * @generated from message v1.AlertState
+ */
+export class AlertState extends Message<AlertState> {
+  /**
+   * since_ms of the alert the state applies to, a recurrence of the problem is a new alert.
+   *
+   * @generated from field: int64 since_ms = 1;
+   */
+  sinceMs = protoInt64.zero;
+
+  /**
+   * unix time in milliseconds the alert was acknowledged, notifications are suppressed until it's resolved.
+   *
+   * @generated from field: int64 acknowledged_ms = 2;
+   */
+  acknowledgedMs = protoInt64.zero;
+
+  /**
+   * name of the user that acknowledged the alert, empty if authentication is disabled.
+   *
+   * @generated from field: string acknowledged_by = 3;
+   */
+  acknowledgedBy = "";
+
+  /**
+   * notifications are suppressed until this unix time in milliseconds.
+   *
+   * @generated from field: int64 snoozed_until_ms = 4;
+   */
+  snoozedUntilMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<AlertState>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.AlertState";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "since_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "acknowledged_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "acknowledged_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "snoozed_until_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AlertState {
+    return new AlertState().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AlertState {
+    return new AlertState().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AlertState {
+    return new AlertState().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AlertState | PlainMessage<AlertState> | undefined, b: AlertState | PlainMessage<AlertState> | undefined): boolean {
+    return proto3.util.equals(AlertState, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.SnoozeAlertRequest
+ */
+export class SnoozeAlertRequest extends Message<SnoozeAlertRequest> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * required, how long to suppress the alert's notifications for.
+   *
+   * @generated from field: int64 duration_minutes = 2;
+   */
+  durationMinutes = protoInt64.zero;
+
+  constructor(data?: PartialMessage<SnoozeAlertRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SnoozeAlertRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "duration_minutes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SnoozeAlertRequest {
+    return new SnoozeAlertRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SnoozeAlertRequest {
+    return new SnoozeAlertRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SnoozeAlertRequest {
+    return new SnoozeAlertRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SnoozeAlertRequest | PlainMessage<SnoozeAlertRequest> | undefined, b: SnoozeAlertRequest | PlainMessage<SnoozeAlertRequest> | undefined): boolean {
+    return proto3.util.equals(SnoozeAlertRequest, a, b);
+  }
+}
+
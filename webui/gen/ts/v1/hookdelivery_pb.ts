@@ -0,0 +1,284 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file v1/hookdelivery.proto (package v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+
+/**
+ * HookDelivery is a notification sent by a hook that couldn't be delivered e.g. because the receiver was down. Deliveries
+ * are persisted and retried with backoff until they succeed or run out of attempts, after which they are kept as dead letters.
+ *
+ * @generated from message v1.HookDelivery
+ */
+export class HookDelivery extends Message<HookDelivery> {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  /**
+   * the name of the hook that sent the notification e.g. "plan/<plan id>/hook/0".
+   *
+   * @generated from field: string hook = 2;
+   */
+  hook = "";
+
+  /**
+   * @generated from field: string repo_id = 3;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string plan_id = 4;
+   */
+  planId = "";
+
+  /**
+   * the hook's operation, updated when the delivery succeeds or is given up on.
+   *
+   * @generated from field: int64 operation_id = 5;
+   */
+  operationId = protoInt64.zero;
+
+  /**
+   * the scheme and host the notification is sent to, safe to display.
+   *
+   * @generated from field: string destination = 6;
+   */
+  destination = "";
+
+  /**
+   * @generated from field: int64 created_ms = 7;
+   */
+  createdMs = protoInt64.zero;
+
+  /**
+   * @generated from field: int32 attempts = 8;
+   */
+  attempts = 0;
+
+  /**
+   * unset for dead letters.
+   *
+   * @generated from field: int64 next_attempt_ms = 9;
+   */
+  nextAttemptMs = protoInt64.zero;
+
+  /**
+   * @generated from field: string last_error = 10;
+   */
+  lastError = "";
+
+  /**
+   * true once the delivery ran out of attempts.
+   *
+   * @generated from field: bool dead_letter = 11;
+   */
+  deadLetter = false;
+
+  /**
+   * the request to retry, omitted by the API as it may hold secrets e.g. tokens.
+   *
+   * @generated from oneof v1.HookDelivery.request
+   */
+  request: {
+    /**
+     * @generated from field: v1.HookDelivery.HttpRequest http = 100;
+     */
+    value: HookDelivery_HttpRequest;
+    case: "http";
+  } | {
+    /**
+     * @generated from field: v1.HookDelivery.ShoutrrrMessage shoutrrr = 101;
+     */
+    value: HookDelivery_ShoutrrrMessage;
+    case: "shoutrrr";
+  } | { case: undefined; value?: undefined } = { case: undefined };
+
+  constructor(data?: PartialMessage<HookDelivery>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.HookDelivery";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "hook", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "operation_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "destination", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "created_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "attempts", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 9, name: "next_attempt_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 10, name: "last_error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "dead_letter", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 100, name: "http", kind: "message", T: HookDelivery_HttpRequest, oneof: "request" },
+    { no: 101, name: "shoutrrr", kind: "message", T: HookDelivery_ShoutrrrMessage, oneof: "request" },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): HookDelivery {
+    return new HookDelivery().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): HookDelivery {
+    return new HookDelivery().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): HookDelivery {
+    return new HookDelivery().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: HookDelivery | PlainMessage<HookDelivery> | undefined, b: HookDelivery | PlainMessage<HookDelivery> | undefined): boolean {
+    return proto3.util.equals(HookDelivery, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.HookDelivery.HttpRequest
+ */
+export class HookDelivery_HttpRequest extends Message<HookDelivery_HttpRequest> {
+  /**
+   * @generated from field: string method = 1;
+   */
+  method = "";
+
+  /**
+   * @generated from field: string url = 2;
+   */
+  url = "";
+
+  /**
+   * @generated from field: string content_type = 3;
+   */
+  contentType = "";
+
+  /**
+   * @generated from field: bytes body = 4;
+   */
+  body = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<HookDelivery_HttpRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.HookDelivery.HttpRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "method", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "content_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "body", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): HookDelivery_HttpRequest {
+    return new HookDelivery_HttpRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): HookDelivery_HttpRequest {
+    return new HookDelivery_HttpRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): HookDelivery_HttpRequest {
+    return new HookDelivery_HttpRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: HookDelivery_HttpRequest | PlainMessage<HookDelivery_HttpRequest> | undefined, b: HookDelivery_HttpRequest | PlainMessage<HookDelivery_HttpRequest> | undefined): boolean {
+    return proto3.util.equals(HookDelivery_HttpRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.HookDelivery.ShoutrrrMessage
+ */
+export class HookDelivery_ShoutrrrMessage extends Message<HookDelivery_ShoutrrrMessage> {
+  /**
+   * @generated from field: string url = 1;
+   */
+  url = "";
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<HookDelivery_ShoutrrrMessage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.HookDelivery.ShoutrrrMessage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): HookDelivery_ShoutrrrMessage {
+    return new HookDelivery_ShoutrrrMessage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): HookDelivery_ShoutrrrMessage {
+    return new HookDelivery_ShoutrrrMessage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): HookDelivery_ShoutrrrMessage {
+    return new HookDelivery_ShoutrrrMessage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: HookDelivery_ShoutrrrMessage | PlainMessage<HookDelivery_ShoutrrrMessage> | undefined, b: HookDelivery_ShoutrrrMessage | PlainMessage<HookDelivery_ShoutrrrMessage> | undefined): boolean {
+    return proto3.util.equals(HookDelivery_ShoutrrrMessage, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.HookDeliveryList
+ */
+export class HookDeliveryList extends Message<HookDeliveryList> {
+  /**
+   * deliveries waiting for their next attempt.
+   *
+   * @generated from field: repeated v1.HookDelivery pending = 1;
+   */
+  pending: HookDelivery[] = [];
+
+  /**
+   * deliveries that ran out of attempts, most recent first.
+   *
+   * @generated from field: repeated v1.HookDelivery dead_letters = 2;
+   */
+  deadLetters: HookDelivery[] = [];
+
+  constructor(data?: PartialMessage<HookDeliveryList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.HookDeliveryList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "pending", kind: "message", T: HookDelivery, repeated: true },
+    { no: 2, name: "dead_letters", kind: "message", T: HookDelivery, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): HookDeliveryList {
+    return new HookDeliveryList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): HookDeliveryList {
+    return new HookDeliveryList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): HookDeliveryList {
+    return new HookDeliveryList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: HookDeliveryList | PlainMessage<HookDeliveryList> | undefined, b: HookDeliveryList | PlainMessage<HookDeliveryList> | undefined): boolean {
+    return proto3.util.equals(HookDeliveryList, a, b);
+  }
+}
+
@@ -101,6 +101,13 @@ export enum OperationStatus {
    * @generated from enum value: STATUS_USER_CANCELLED = 6;
    */
   STATUS_USER_CANCELLED = 6,
+
+  /**
+   * indicates the operation did not run because a run condition of its plan was not met.
+   *
+   * @generated from enum value: STATUS_SKIPPED = 8;
+   */
+  STATUS_SKIPPED = 8,
 }
 // Retrieve enum metadata with: proto3.getEnumType(OperationStatus)
 proto3.util.setEnumType(OperationStatus, "v1.OperationStatus", [
@@ -112,6 +119,7 @@ proto3.util.setEnumType(OperationStatus, "v1.OperationStatus", [
   { no: 4, name: "STATUS_ERROR" },
   { no: 5, name: "STATUS_SYSTEM_CANCELLED" },
   { no: 6, name: "STATUS_USER_CANCELLED" },
+  { no: 8, name: "STATUS_SKIPPED" },
 ]);
 
 /**
@@ -123,6 +131,13 @@ export class OperationList extends Message<OperationList> {
    */
   operations: Operation[] = [];
 
+  /**
+   * set if GetOperations has more operations, pass it as the page_token of the next request.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
   constructor(data?: PartialMessage<OperationList>) {
     super();
     proto3.util.initPartial(data, this);
@@ -132,6 +147,7 @@ export class OperationList extends Message<OperationList> {
   static readonly typeName = "v1.OperationList";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "operations", kind: "message", T: Operation, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationList {
@@ -226,6 +242,73 @@ export class Operation extends Message<Operation> {
    */
   logref = "";
 
+  /**
+   * optional, the attempt number if the operation is a retry of a failed operation. The first retry is attempt 2.
+   *
+   * @generated from field: int32 retry_attempt = 12;
+   */
+  retryAttempt = 0;
+
+  /**
+   * optional, the hostname of the machine and the backrest and restic versions that created the operation.
+   *
+   * @generated from field: string hostname = 13;
+   */
+  hostname = "";
+
+  /**
+   * @generated from field: string backrest_version = 14;
+   */
+  backrestVersion = "";
+
+  /**
+   * @generated from field: string restic_version = 15;
+   */
+  resticVersion = "";
+
+  /**
+   * optional, set on operations interrupted by a shutdown. The operation's task is queued again the next time backrest starts.
+   *
+   * @generated from field: bool resume_on_start = 16;
+   */
+  resumeOnStart = false;
+
+  /**
+   * optional, captured when a backup fails to help find the cause of intermittent failures.
+   *
+   * @generated from field: v1.FailureDiagnostics failure_diagnostics = 17;
+   */
+  failureDiagnostics?: FailureDiagnostics;
+
+  /**
+   * optional, the end of the log referenced by logref if it's a command log, the full log is fetched with GetLogs.
+   *
+   * @generated from field: string log_tail = 18;
+   */
+  logTail = "";
+
+  /**
+   * optional, a note left on the operation by a user e.g. explaining a failure, see AnnotateOperation.
+   *
+   * @generated from field: v1.OperationNote note = 19;
+   */
+  note?: OperationNote;
+
+  /**
+   * optional, CPU and memory used by the restic commands the operation ran.
+   *
+   * @generated from field: v1.ResourceUsage resource_usage = 20;
+   */
+  resourceUsage?: ResourceUsage;
+
+  /**
+   * optional, unix time in milliseconds the operation's snapshot was found to be no longer in the repo e.g. because it
+   * was forgotten. Orphaned operations are removed per OplogRetention.orphan_grace_days.
+   *
+   * @generated from field: int64 orphaned_ms = 21;
+   */
+  orphanedMs = protoInt64.zero;
+
   /**
    * @generated from oneof v1.Operation.op
    */
@@ -271,6 +354,54 @@ export class Operation extends Message<Operation> {
      */
     value: OperationRunHook;
     case: "operationRunHook";
+  } | {
+    /**
+     * @generated from field: v1.OperationCheck operation_check = 107;
+     */
+    value: OperationCheck;
+    case: "operationCheck";
+  } | {
+    /**
+     * @generated from field: v1.OperationCopy operation_copy = 108;
+     */
+    value: OperationCopy;
+    case: "operationCopy";
+  } | {
+    /**
+     * @generated from field: v1.OperationInit operation_init = 109;
+     */
+    value: OperationInit;
+    case: "operationInit";
+  } | {
+    /**
+     * @generated from field: v1.OperationMigrate operation_migrate = 110;
+     */
+    value: OperationMigrate;
+    case: "operationMigrate";
+  } | {
+    /**
+     * @generated from field: v1.OperationRecover operation_recover = 111;
+     */
+    value: OperationRecover;
+    case: "operationRecover";
+  } | {
+    /**
+     * @generated from field: v1.OperationTag operation_tag = 112;
+     */
+    value: OperationTag;
+    case: "operationTag";
+  } | {
+    /**
+     * @generated from field: v1.OperationVerifyOplog operation_verify_oplog = 113;
+     */
+    value: OperationVerifyOplog;
+    case: "operationVerifyOplog";
+  } | {
+    /**
+     * @generated from field: v1.OperationRewrite operation_rewrite = 114;
+     */
+    value: OperationRewrite;
+    case: "operationRewrite";
   } | { case: undefined; value?: undefined } = { case: undefined };
 
   constructor(data?: PartialMessage<Operation>) {
@@ -292,6 +423,16 @@ export class Operation extends Message<Operation> {
     { no: 6, name: "unix_time_end_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 7, name: "display_message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 9, name: "logref", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 12, name: "retry_attempt", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 13, name: "hostname", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 14, name: "backrest_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 15, name: "restic_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 16, name: "resume_on_start", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 17, name: "failure_diagnostics", kind: "message", T: FailureDiagnostics },
+    { no: 18, name: "log_tail", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 19, name: "note", kind: "message", T: OperationNote },
+    { no: 20, name: "resource_usage", kind: "message", T: ResourceUsage },
+    { no: 21, name: "orphaned_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 100, name: "operation_backup", kind: "message", T: OperationBackup, oneof: "op" },
     { no: 101, name: "operation_index_snapshot", kind: "message", T: OperationIndexSnapshot, oneof: "op" },
     { no: 102, name: "operation_forget", kind: "message", T: OperationForget, oneof: "op" },
@@ -299,6 +440,14 @@ export class Operation extends Message<Operation> {
     { no: 104, name: "operation_restore", kind: "message", T: OperationRestore, oneof: "op" },
     { no: 105, name: "operation_stats", kind: "message", T: OperationStats, oneof: "op" },
     { no: 106, name: "operation_run_hook", kind: "message", T: OperationRunHook, oneof: "op" },
+    { no: 107, name: "operation_check", kind: "message", T: OperationCheck, oneof: "op" },
+    { no: 108, name: "operation_copy", kind: "message", T: OperationCopy, oneof: "op" },
+    { no: 109, name: "operation_init", kind: "message", T: OperationInit, oneof: "op" },
+    { no: 110, name: "operation_migrate", kind: "message", T: OperationMigrate, oneof: "op" },
+    { no: 111, name: "operation_recover", kind: "message", T: OperationRecover, oneof: "op" },
+    { no: 112, name: "operation_tag", kind: "message", T: OperationTag, oneof: "op" },
+    { no: 113, name: "operation_verify_oplog", kind: "message", T: OperationVerifyOplog, oneof: "op" },
+    { no: 114, name: "operation_rewrite", kind: "message", T: OperationRewrite, oneof: "op" },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Operation {
@@ -377,6 +526,55 @@ export class OperationBackup extends Message<OperationBackup> {
    */
   errors: BackupProgressError[] = [];
 
+  /**
+   * no files changed since the plan's last snapshot and the plan skips unchanged backups, no snapshot was kept.
+   *
+   * @generated from field: bool unchanged = 5;
+   */
+  unchanged = false;
+
+  /**
+   * unix time in milliseconds the scheduled backup was due at including jitter_ms, set if the plan has jitter.
+   *
+   * @generated from field: int64 scheduled_time_ms = 6;
+   */
+  scheduledTimeMs = protoInt64.zero;
+
+  /**
+   * random delay added to the plan's schedule, see Plan.jitter_minutes.
+   *
+   * @generated from field: int64 jitter_ms = 7;
+   */
+  jitterMs = protoInt64.zero;
+
+  /**
+   * number of items restic failed to read, errors only holds the first of them.
+   *
+   * @generated from field: int64 error_count = 8;
+   */
+  errorCount = protoInt64.zero;
+
+  /**
+   * warnings restic printed outside of its JSON output e.g. about its cache, the first are kept.
+   *
+   * @generated from field: repeated string warnings = 9;
+   */
+  warnings: string[] = [];
+
+  /**
+   * number of warnings restic printed.
+   *
+   * @generated from field: int64 warning_count = 10;
+   */
+  warningCount = protoInt64.zero;
+
+  /**
+   * metadata attached to the backup's snapshot as "annotation:<key>=<value>" tags.
+   *
+   * @generated from field: repeated v1.SnapshotAnnotation annotations = 11;
+   */
+  annotations: SnapshotAnnotation[] = [];
+
   constructor(data?: PartialMessage<OperationBackup>) {
     super();
     proto3.util.initPartial(data, this);
@@ -387,6 +585,13 @@ export class OperationBackup extends Message<OperationBackup> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 3, name: "last_status", kind: "message", T: BackupProgressEntry },
     { no: 4, name: "errors", kind: "message", T: BackupProgressError, repeated: true },
+    { no: 5, name: "unchanged", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "scheduled_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "jitter_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "error_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "warnings", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 10, name: "warning_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 11, name: "annotations", kind: "message", T: SnapshotAnnotation, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationBackup {
@@ -406,6 +611,56 @@ export class OperationBackup extends Message<OperationBackup> {
   }
 }
 
+/**
+ * SnapshotAnnotation is a piece of metadata describing what a snapshot captured e.g. the version of the application
+ * whose data was backed up, it's given by the backup's trigger or written by the plan's pre backup command.
+ *
+ * @generated from message v1.SnapshotAnnotation
+ */
+export class SnapshotAnnotation extends Message<SnapshotAnnotation> {
+  /**
+   * letters, digits, '_', '.' and '-', at most 64 characters.
+   *
+   * @generated from field: string key = 1;
+   */
+  key = "";
+
+  /**
+   * at most 256 characters, no commas or control characters.
+   *
+   * @generated from field: string value = 2;
+   */
+  value = "";
+
+  constructor(data?: PartialMessage<SnapshotAnnotation>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SnapshotAnnotation";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SnapshotAnnotation {
+    return new SnapshotAnnotation().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SnapshotAnnotation {
+    return new SnapshotAnnotation().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SnapshotAnnotation {
+    return new SnapshotAnnotation().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SnapshotAnnotation | PlainMessage<SnapshotAnnotation> | undefined, b: SnapshotAnnotation | PlainMessage<SnapshotAnnotation> | undefined): boolean {
+    return proto3.util.equals(SnapshotAnnotation, a, b);
+  }
+}
+
 /**
  * OperationIndexSnapshot tracks that a snapshot was detected by backrest. 
  *
@@ -433,6 +688,13 @@ export class OperationIndexSnapshot extends Message<OperationIndexSnapshot> {
    */
   forgotByOp = protoInt64.zero;
 
+  /**
+   * unix time in milliseconds the plan's retention policy first selected the snapshot for removal, set while the repo's prune cool-down keeps it.
+   *
+   * @generated from field: int64 forget_due_ms = 5;
+   */
+  forgetDueMs = protoInt64.zero;
+
   constructor(data?: PartialMessage<OperationIndexSnapshot>) {
     super();
     proto3.util.initPartial(data, this);
@@ -444,6 +706,7 @@ export class OperationIndexSnapshot extends Message<OperationIndexSnapshot> {
     { no: 2, name: "snapshot", kind: "message", T: ResticSnapshot },
     { no: 3, name: "forgot", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 4, name: "forgot_by_op", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "forget_due_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationIndexSnapshot {
@@ -479,6 +742,13 @@ export class OperationForget extends Message<OperationForget> {
    */
   policy?: RetentionPolicy;
 
+  /**
+   * snapshots selected for removal that are kept until the repo's prune cool-down passed.
+   *
+   * @generated from field: repeated v1.ResticSnapshot retained = 3;
+   */
+  retained: ResticSnapshot[] = [];
+
   constructor(data?: PartialMessage<OperationForget>) {
     super();
     proto3.util.initPartial(data, this);
@@ -489,6 +759,7 @@ export class OperationForget extends Message<OperationForget> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "forget", kind: "message", T: ResticSnapshot, repeated: true },
     { no: 2, name: "policy", kind: "message", T: RetentionPolicy },
+    { no: 3, name: "retained", kind: "message", T: ResticSnapshot, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationForget {
@@ -550,29 +821,960 @@ export class OperationPrune extends Message<OperationPrune> {
 }
 
 /**
- * @generated from message v1.OperationRestore
+ * @generated from message v1.OperationCheck
  */
-export class OperationRestore extends Message<OperationRestore> {
+export class OperationCheck extends Message<OperationCheck> {
   /**
-   * path in the snapshot to restore.
+   * output of the check.
    *
-   * @generated from field: string path = 1;
+   * @generated from field: string output = 1;
    */
-  path = "";
+  output = "";
+
+  constructor(data?: PartialMessage<OperationCheck>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationCheck";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "output", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationCheck {
+    return new OperationCheck().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationCheck {
+    return new OperationCheck().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationCheck {
+    return new OperationCheck().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationCheck | PlainMessage<OperationCheck> | undefined, b: OperationCheck | PlainMessage<OperationCheck> | undefined): boolean {
+    return proto3.util.equals(OperationCheck, a, b);
+  }
+}
+
+/**
+ * OperationNote is a free text note attached to an operation by a user.
+ *
+ * @generated from message v1.OperationNote
+ */
+export class OperationNote extends Message<OperationNote> {
+  /**
+   * @generated from field: string text = 1;
+   */
+  text = "";
 
   /**
-   * location to restore it to.
+   * name of the user who wrote the note, empty if authentication is disabled.
    *
-   * @generated from field: string target = 2;
+   * @generated from field: string author = 2;
    */
-  target = "";
+  author = "";
 
   /**
-   * status of the restore.
+   * time the note was last edited.
    *
-   * @generated from field: v1.RestoreProgressEntry status = 3;
+   * @generated from field: int64 unix_time_ms = 3;
    */
-  status?: RestoreProgressEntry;
+  unixTimeMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<OperationNote>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationNote";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "text", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "author", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "unix_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationNote {
+    return new OperationNote().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationNote {
+    return new OperationNote().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationNote {
+    return new OperationNote().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationNote | PlainMessage<OperationNote> | undefined, b: OperationNote | PlainMessage<OperationNote> | undefined): boolean {
+    return proto3.util.equals(OperationNote, a, b);
+  }
+}
+
+/**
+ * FailureDiagnostics is a snapshot of the state of the system and the repo's backend taken when an operation failed.
+ *
+ * @generated from message v1.FailureDiagnostics
+ */
+export class FailureDiagnostics extends Message<FailureDiagnostics> {
+  /**
+   * time the diagnostics were captured.
+   *
+   * @generated from field: int64 unix_time_ms = 1;
+   */
+  unixTimeMs = protoInt64.zero;
+
+  /**
+   * tail of the failed restic command's output, progress messages are omitted.
+   *
+   * @generated from field: string restic_output = 2;
+   */
+  resticOutput = "";
+
+  /**
+   * the most recent lines of backrest's log.
+   *
+   * @generated from field: string daemon_log = 3;
+   */
+  daemonLog = "";
+
+  /**
+   * free space of the plan's paths, the repo if it's local and backrest's data dir.
+   *
+   * @generated from field: repeated v1.DiskUsage disks = 4;
+   */
+  disks: DiskUsage[] = [];
+
+  /**
+   * optional, total memory of the system. Only reported on Linux.
+   *
+   * @generated from field: int64 memory_total_bytes = 5;
+   */
+  memoryTotalBytes = protoInt64.zero;
+
+  /**
+   * optional, memory available to new processes. Only reported on Linux.
+   *
+   * @generated from field: int64 memory_available_bytes = 6;
+   */
+  memoryAvailableBytes = protoInt64.zero;
+
+  /**
+   * memory obtained from the OS by backrest.
+   *
+   * @generated from field: int64 daemon_memory_bytes = 7;
+   */
+  daemonMemoryBytes = protoInt64.zero;
+
+  /**
+   * time taken to read the repo's config from its backend.
+   *
+   * @generated from field: int64 backend_latency_ms = 8;
+   */
+  backendLatencyMs = protoInt64.zero;
+
+  /**
+   * set if the repo's config could not be read, backend_latency_ms is then the time until the failure.
+   *
+   * @generated from field: string backend_error = 9;
+   */
+  backendError = "";
+
+  constructor(data?: PartialMessage<FailureDiagnostics>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.FailureDiagnostics";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "unix_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "restic_output", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "daemon_log", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "disks", kind: "message", T: DiskUsage, repeated: true },
+    { no: 5, name: "memory_total_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "memory_available_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "daemon_memory_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "backend_latency_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "backend_error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FailureDiagnostics {
+    return new FailureDiagnostics().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): FailureDiagnostics {
+    return new FailureDiagnostics().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): FailureDiagnostics {
+    return new FailureDiagnostics().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: FailureDiagnostics | PlainMessage<FailureDiagnostics> | undefined, b: FailureDiagnostics | PlainMessage<FailureDiagnostics> | undefined): boolean {
+    return proto3.util.equals(FailureDiagnostics, a, b);
+  }
+}
+
+/**
+ * ResourceUsage is the CPU and memory used by the restic commands of an operation. Averages are over the time the
+ * commands ran, usage is sampled while they run on Linux and only the totals restic reports on exit are kept elsewhere.
+ *
+ * @generated from message v1.ResourceUsage
+ */
+export class ResourceUsage extends Message<ResourceUsage> {
+  /**
+   * number of restic commands run.
+   *
+   * @generated from field: int32 commands = 1;
+   */
+  commands = 0;
+
+  /**
+   * total time the commands ran.
+   *
+   * @generated from field: int64 run_time_ms = 2;
+   */
+  runTimeMs = protoInt64.zero;
+
+  /**
+   * user and system CPU time used by the commands.
+   *
+   * @generated from field: int64 cpu_time_ms = 3;
+   */
+  cpuTimeMs = protoInt64.zero;
+
+  /**
+   * cpu_time_ms over run_time_ms, 1.0 is one core fully used.
+   *
+   * @generated from field: double avg_cpu_cores = 4;
+   */
+  avgCpuCores = 0;
+
+  /**
+   * highest CPU usage between two samples.
+   *
+   * @generated from field: double peak_cpu_cores = 5;
+   */
+  peakCpuCores = 0;
+
+  /**
+   * average resident memory of the samples.
+   *
+   * @generated from field: int64 avg_rss_bytes = 6;
+   */
+  avgRssBytes = protoInt64.zero;
+
+  /**
+   * highest resident memory of any command.
+   *
+   * @generated from field: int64 peak_rss_bytes = 7;
+   */
+  peakRssBytes = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ResourceUsage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ResourceUsage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "commands", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "run_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "cpu_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "avg_cpu_cores", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+    { no: 5, name: "peak_cpu_cores", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+    { no: 6, name: "avg_rss_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "peak_rss_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResourceUsage {
+    return new ResourceUsage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResourceUsage {
+    return new ResourceUsage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResourceUsage {
+    return new ResourceUsage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ResourceUsage | PlainMessage<ResourceUsage> | undefined, b: ResourceUsage | PlainMessage<ResourceUsage> | undefined): boolean {
+    return proto3.util.equals(ResourceUsage, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.DiskUsage
+ */
+export class DiskUsage extends Message<DiskUsage> {
+  /**
+   * @generated from field: string path = 1;
+   */
+  path = "";
+
+  /**
+   * bytes available to unprivileged users.
+   *
+   * @generated from field: int64 free_bytes = 2;
+   */
+  freeBytes = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 total_bytes = 3;
+   */
+  totalBytes = protoInt64.zero;
+
+  /**
+   * set if the usage of the path's filesystem could not be read.
+   *
+   * @generated from field: string error = 4;
+   */
+  error = "";
+
+  constructor(data?: PartialMessage<DiskUsage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.DiskUsage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "free_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "total_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiskUsage {
+    return new DiskUsage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiskUsage {
+    return new DiskUsage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiskUsage {
+    return new DiskUsage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiskUsage | PlainMessage<DiskUsage> | undefined, b: DiskUsage | PlainMessage<DiskUsage> | undefined): boolean {
+    return proto3.util.equals(DiskUsage, a, b);
+  }
+}
+
+/**
+ * OperationInit tracks a restic init of the operation's repo.
+ *
+ * @generated from message v1.OperationInit
+ */
+export class OperationInit extends Message<OperationInit> {
+  /**
+   * output of the init.
+   *
+   * @generated from field: string output = 1;
+   */
+  output = "";
+
+  constructor(data?: PartialMessage<OperationInit>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationInit";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "output", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationInit {
+    return new OperationInit().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationInit {
+    return new OperationInit().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationInit {
+    return new OperationInit().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationInit | PlainMessage<OperationInit> | undefined, b: OperationInit | PlainMessage<OperationInit> | undefined): boolean {
+    return proto3.util.equals(OperationInit, a, b);
+  }
+}
+
+/**
+ * OperationMigrate tracks a restic migrate of the operation's repo.
+ *
+ * @generated from message v1.OperationMigrate
+ */
+export class OperationMigrate extends Message<OperationMigrate> {
+  /**
+   * name of the migration applied e.g. "upgrade_repo_v2", empty if the repo only needed recompressing.
+   *
+   * @generated from field: string migration = 1;
+   */
+  migration = "";
+
+  /**
+   * output of the migration and the recompression.
+   *
+   * @generated from field: string output = 2;
+   */
+  output = "";
+
+  /**
+   * the data stored uncompressed is repacked compressed after the migration.
+   *
+   * @generated from field: bool recompress = 3;
+   */
+  recompress = false;
+
+  /**
+   * the step of the upgrade the operation is at.
+   *
+   * @generated from field: v1.OperationMigrate.Phase phase = 4;
+   */
+  phase = OperationMigrate_Phase.UNKNOWN;
+
+  constructor(data?: PartialMessage<OperationMigrate>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationMigrate";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "migration", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "output", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "recompress", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "phase", kind: "enum", T: proto3.getEnumType(OperationMigrate_Phase) },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationMigrate {
+    return new OperationMigrate().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationMigrate {
+    return new OperationMigrate().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationMigrate {
+    return new OperationMigrate().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationMigrate | PlainMessage<OperationMigrate> | undefined, b: OperationMigrate | PlainMessage<OperationMigrate> | undefined): boolean {
+    return proto3.util.equals(OperationMigrate, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.OperationMigrate.Phase
+ */
+export enum OperationMigrate_Phase {
+  /**
+   * @generated from enum value: PHASE_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * the migration is being applied.
+   *
+   * @generated from enum value: PHASE_MIGRATE = 1;
+   */
+  MIGRATE = 1,
+
+  /**
+   * the data stored uncompressed is being repacked.
+   *
+   * @generated from enum value: PHASE_RECOMPRESS = 2;
+   */
+  RECOMPRESS = 2,
+
+  /**
+   * @generated from enum value: PHASE_DONE = 3;
+   */
+  DONE = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(OperationMigrate_Phase)
+proto3.util.setEnumType(OperationMigrate_Phase, "v1.OperationMigrate.Phase", [
+  { no: 0, name: "PHASE_UNKNOWN" },
+  { no: 1, name: "PHASE_MIGRATE" },
+  { no: 2, name: "PHASE_RECOMPRESS" },
+  { no: 3, name: "PHASE_DONE" },
+]);
+
+/**
+ * OperationRecover tracks a restic recover of the operation's repo.
+ *
+ * @generated from message v1.OperationRecover
+ */
+export class OperationRecover extends Message<OperationRecover> {
+  /**
+   * output of the recover.
+   *
+   * @generated from field: string output = 1;
+   */
+  output = "";
+
+  /**
+   * number of directories found that no snapshot referenced.
+   *
+   * @generated from field: int32 roots = 2;
+   */
+  roots = 0;
+
+  /**
+   * ID of the snapshot holding the recovered directories, empty if nothing was recovered.
+   *
+   * @generated from field: string snapshot_id = 3;
+   */
+  snapshotId = "";
+
+  constructor(data?: PartialMessage<OperationRecover>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationRecover";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "output", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "roots", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationRecover {
+    return new OperationRecover().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationRecover {
+    return new OperationRecover().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationRecover {
+    return new OperationRecover().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationRecover | PlainMessage<OperationRecover> | undefined, b: OperationRecover | PlainMessage<OperationRecover> | undefined): boolean {
+    return proto3.util.equals(OperationRecover, a, b);
+  }
+}
+
+/**
+ * OperationTag tracks a restic tag of snapshots in the operation's repo. restic saves each snapshot it changes under a
+ * new ID, the snapshots are indexed again once they are tagged.
+ *
+ * @generated from message v1.OperationTag
+ */
+export class OperationTag extends Message<OperationTag> {
+  /**
+   * IDs of the snapshots whose tags were changed.
+   *
+   * @generated from field: repeated string snapshot_ids = 1;
+   */
+  snapshotIds: string[] = [];
+
+  /**
+   * tags added to the snapshots.
+   *
+   * @generated from field: repeated string add = 2;
+   */
+  add: string[] = [];
+
+  /**
+   * tags removed from the snapshots.
+   *
+   * @generated from field: repeated string remove = 3;
+   */
+  remove: string[] = [];
+
+  constructor(data?: PartialMessage<OperationTag>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationTag";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "snapshot_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "add", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "remove", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationTag {
+    return new OperationTag().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationTag {
+    return new OperationTag().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationTag {
+    return new OperationTag().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationTag | PlainMessage<OperationTag> | undefined, b: OperationTag | PlainMessage<OperationTag> | undefined): boolean {
+    return proto3.util.equals(OperationTag, a, b);
+  }
+}
+
+/**
+ * OperationRewrite tracks a restic rewrite removing files from snapshots of the operation's repo.
+ *
+ * @generated from message v1.OperationRewrite
+ */
+export class OperationRewrite extends Message<OperationRewrite> {
+  /**
+   * IDs of the snapshots rewritten.
+   *
+   * @generated from field: repeated string snapshot_ids = 1;
+   */
+  snapshotIds: string[] = [];
+
+  /**
+   * patterns of the files removed from the snapshots.
+   *
+   * @generated from field: repeated string excludes = 2;
+   */
+  excludes: string[] = [];
+
+  /**
+   * whether the original snapshots are forgotten once rewritten.
+   *
+   * @generated from field: bool forget = 3;
+   */
+  forget = false;
+
+  /**
+   * number of snapshots that held excluded files and were saved under new IDs.
+   *
+   * @generated from field: int32 snapshots_rewritten = 4;
+   */
+  snapshotsRewritten = 0;
+
+  /**
+   * output of the rewrite.
+   *
+   * @generated from field: string output = 5;
+   */
+  output = "";
+
+  constructor(data?: PartialMessage<OperationRewrite>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationRewrite";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "snapshot_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "excludes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "forget", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "snapshots_rewritten", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "output", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationRewrite {
+    return new OperationRewrite().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationRewrite {
+    return new OperationRewrite().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationRewrite {
+    return new OperationRewrite().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationRewrite | PlainMessage<OperationRewrite> | undefined, b: OperationRewrite | PlainMessage<OperationRewrite> | undefined): boolean {
+    return proto3.util.equals(OperationRewrite, a, b);
+  }
+}
+
+/**
+ * OperationVerifyOplog tracks a check of the oplog's invariants for the operation's repo, the operation fails if any
+ * inconsistency is found.
+ *
+ * @generated from message v1.OperationVerifyOplog
+ */
+export class OperationVerifyOplog extends Message<OperationVerifyOplog> {
+  /**
+   * @generated from field: int32 operations_checked = 1;
+   */
+  operationsChecked = 0;
+
+  /**
+   * total number of inconsistencies found, only the first are listed.
+   *
+   * @generated from field: int32 inconsistency_count = 2;
+   */
+  inconsistencyCount = 0;
+
+  /**
+   * @generated from field: repeated v1.OplogInconsistency inconsistencies = 3;
+   */
+  inconsistencies: OplogInconsistency[] = [];
+
+  constructor(data?: PartialMessage<OperationVerifyOplog>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationVerifyOplog";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "operations_checked", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "inconsistency_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "inconsistencies", kind: "message", T: OplogInconsistency, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationVerifyOplog {
+    return new OperationVerifyOplog().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationVerifyOplog {
+    return new OperationVerifyOplog().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationVerifyOplog {
+    return new OperationVerifyOplog().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationVerifyOplog | PlainMessage<OperationVerifyOplog> | undefined, b: OperationVerifyOplog | PlainMessage<OperationVerifyOplog> | undefined): boolean {
+    return proto3.util.equals(OperationVerifyOplog, a, b);
+  }
+}
+
+/**
+ * OplogInconsistency is an operation that breaks one of the oplog's invariants.
+ *
+ * @generated from message v1.OplogInconsistency
+ */
+export class OplogInconsistency extends Message<OplogInconsistency> {
+  /**
+   * @generated from field: v1.OplogInconsistency.Kind kind = 1;
+   */
+  kind = OplogInconsistency_Kind.UNKNOWN;
+
+  /**
+   * @generated from field: int64 operation_id = 2;
+   */
+  operationId = protoInt64.zero;
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<OplogInconsistency>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OplogInconsistency";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "enum", T: proto3.getEnumType(OplogInconsistency_Kind) },
+    { no: 2, name: "operation_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OplogInconsistency {
+    return new OplogInconsistency().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OplogInconsistency {
+    return new OplogInconsistency().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OplogInconsistency {
+    return new OplogInconsistency().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OplogInconsistency | PlainMessage<OplogInconsistency> | undefined, b: OplogInconsistency | PlainMessage<OplogInconsistency> | undefined): boolean {
+    return proto3.util.equals(OplogInconsistency, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.OplogInconsistency.Kind
+ */
+export enum OplogInconsistency_Kind {
+  /**
+   * @generated from enum value: KIND_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * a successful backup that was not skipped as unchanged has no snapshot ID.
+   *
+   * @generated from enum value: KIND_BACKUP_WITHOUT_SNAPSHOT = 1;
+   */
+  BACKUP_WITHOUT_SNAPSHOT = 1,
+
+  /**
+   * an indexed snapshot not marked forgotten is missing from the repo.
+   *
+   * @generated from enum value: KIND_SNAPSHOT_NOT_IN_REPO = 2;
+   */
+  SNAPSHOT_NOT_IN_REPO = 2,
+
+  /**
+   * an operation is still pending long after it was scheduled to run.
+   *
+   * @generated from enum value: KIND_STUCK_PENDING = 3;
+   */
+  STUCK_PENDING = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(OplogInconsistency_Kind)
+proto3.util.setEnumType(OplogInconsistency_Kind, "v1.OplogInconsistency.Kind", [
+  { no: 0, name: "KIND_UNKNOWN" },
+  { no: 1, name: "KIND_BACKUP_WITHOUT_SNAPSHOT" },
+  { no: 2, name: "KIND_SNAPSHOT_NOT_IN_REPO" },
+  { no: 3, name: "KIND_STUCK_PENDING" },
+]);
+
+/**
+ * OperationCopy tracks a restic copy of a plan's snapshots into the operation's repo.
+ *
+ * @generated from message v1.OperationCopy
+ */
+export class OperationCopy extends Message<OperationCopy> {
+  /**
+   * ID of the repo the snapshots were copied from.
+   *
+   * @generated from field: string source_repo = 1;
+   */
+  sourceRepo = "";
+
+  /**
+   * snapshots newly copied, snapshots copied by earlier runs are not listed.
+   *
+   * @generated from field: repeated v1.CopiedSnapshot copied_snapshots = 2;
+   */
+  copiedSnapshots: CopiedSnapshot[] = [];
+
+  constructor(data?: PartialMessage<OperationCopy>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OperationCopy";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "source_repo", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "copied_snapshots", kind: "message", T: CopiedSnapshot, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationCopy {
+    return new OperationCopy().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OperationCopy {
+    return new OperationCopy().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OperationCopy {
+    return new OperationCopy().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OperationCopy | PlainMessage<OperationCopy> | undefined, b: OperationCopy | PlainMessage<OperationCopy> | undefined): boolean {
+    return proto3.util.equals(OperationCopy, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.CopiedSnapshot
+ */
+export class CopiedSnapshot extends Message<CopiedSnapshot> {
+  /**
+   * short ID of the snapshot in the source repo.
+   *
+   * @generated from field: string source_id = 1;
+   */
+  sourceId = "";
+
+  /**
+   * short ID of the copy in the destination repo.
+   *
+   * @generated from field: string destination_id = 2;
+   */
+  destinationId = "";
+
+  constructor(data?: PartialMessage<CopiedSnapshot>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.CopiedSnapshot";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "source_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "destination_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CopiedSnapshot {
+    return new CopiedSnapshot().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CopiedSnapshot {
+    return new CopiedSnapshot().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CopiedSnapshot {
+    return new CopiedSnapshot().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CopiedSnapshot | PlainMessage<CopiedSnapshot> | undefined, b: CopiedSnapshot | PlainMessage<CopiedSnapshot> | undefined): boolean {
+    return proto3.util.equals(CopiedSnapshot, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.OperationRestore
+ */
+export class OperationRestore extends Message<OperationRestore> {
+  /**
+   * path in the snapshot to restore.
+   *
+   * @generated from field: string path = 1;
+   */
+  path = "";
+
+  /**
+   * location to restore it to.
+   *
+   * @generated from field: string target = 2;
+   */
+  target = "";
+
+  /**
+   * status of the restore.
+   *
+   * @generated from field: v1.RestoreProgressEntry status = 3;
+   */
+  status?: RestoreProgressEntry;
+
+  /**
+   * files were restored to their original location, target is the filesystem root.
+   *
+   * @generated from field: bool in_place = 4;
+   */
+  inPlace = false;
+
+  /**
+   * the in place restore was staged next to path and swapped in once complete.
+   *
+   * @generated from field: bool swap = 5;
+   */
+  swap = false;
 
   constructor(data?: PartialMessage<OperationRestore>) {
     super();
@@ -585,6 +1787,8 @@ export class OperationRestore extends Message<OperationRestore> {
     { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "status", kind: "message", T: RestoreProgressEntry },
+    { no: 4, name: "in_place", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "swap", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OperationRestore {
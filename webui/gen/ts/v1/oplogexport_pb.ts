@@ -0,0 +1,83 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file v1/oplogexport.proto (package v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+import { Config } from "./config_pb.js";
+import { Operation } from "./operations_pb.js";
+
+/**
+ * OplogArchive is the content of an operation history export, it is written as gzip compressed JSON.
+ *
+ * @generated from message v1.OplogArchive
+ */
+export class OplogArchive extends Message<OplogArchive> {
+  /**
+   * version of the archive format, incremented on incompatible changes.
+   *
+   * @generated from field: int32 version = 1;
+   */
+  version = 0;
+
+  /**
+   * unix time in milliseconds the archive was exported at.
+   *
+   * @generated from field: int64 exported_at_ms = 2;
+   */
+  exportedAtMs = protoInt64.zero;
+
+  /**
+   * instance the operations were run by.
+   *
+   * @generated from field: string instance = 3;
+   */
+  instance = "";
+
+  /**
+   * the config at the time of the export, secrets are redacted.
+   *
+   * @generated from field: v1.Config config = 4;
+   */
+  config?: Config;
+
+  /**
+   * every operation in the log, in the order they were added.
+   *
+   * @generated from field: repeated v1.Operation operations = 5;
+   */
+  operations: Operation[] = [];
+
+  constructor(data?: PartialMessage<OplogArchive>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.OplogArchive";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "version", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "exported_at_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "instance", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "config", kind: "message", T: Config },
+    { no: 5, name: "operations", kind: "message", T: Operation, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OplogArchive {
+    return new OplogArchive().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OplogArchive {
+    return new OplogArchive().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OplogArchive {
+    return new OplogArchive().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OplogArchive | PlainMessage<OplogArchive> | undefined, b: OplogArchive | PlainMessage<OplogArchive> | undefined): boolean {
+    return proto3.util.equals(OplogArchive, a, b);
+  }
+}
+
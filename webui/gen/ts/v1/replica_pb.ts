@@ -0,0 +1,137 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file v1/replica.proto (package v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+import { Config } from "./config_pb.js";
+import { Operation } from "./operations_pb.js";
+
+/**
+ * ConfigReplica is what an instance knows about its repos and plans, pushed off-site so that a replacement instance can
+ * be bootstrapped from it.
+ *
+ * @generated from message v1.ConfigReplica
+ */
+export class ConfigReplica extends Message<ConfigReplica> {
+  /**
+   * @generated from field: v1.Config config = 1;
+   */
+  config?: Config;
+
+  /**
+   * the most recent operations of each plan, log refs are cleared as logs are not replicated.
+   *
+   * @generated from field: repeated v1.Operation operations = 2;
+   */
+  operations: Operation[] = [];
+
+  constructor(data?: PartialMessage<ConfigReplica>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ConfigReplica";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "config", kind: "message", T: Config },
+    { no: 2, name: "operations", kind: "message", T: Operation, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ConfigReplica {
+    return new ConfigReplica().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ConfigReplica {
+    return new ConfigReplica().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ConfigReplica {
+    return new ConfigReplica().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ConfigReplica | PlainMessage<ConfigReplica> | undefined, b: ConfigReplica | PlainMessage<ConfigReplica> | undefined): boolean {
+    return proto3.util.equals(ConfigReplica, a, b);
+  }
+}
+
+/**
+ * SealedReplica is a ConfigReplica encrypted with the passphrase from the config_replication settings of its instance.
+ *
+ * @generated from message v1.SealedReplica
+ */
+export class SealedReplica extends Message<SealedReplica> {
+  /**
+   * version of the encryption scheme.
+   *
+   * @generated from field: int32 version = 1;
+   */
+  version = 0;
+
+  /**
+   * ID of the instance the replica was created by.
+   *
+   * @generated from field: string instance = 2;
+   */
+  instance = "";
+
+  /**
+   * time the replica was created at.
+   *
+   * @generated from field: int64 unix_time_ms = 3;
+   */
+  unixTimeMs = protoInt64.zero;
+
+  /**
+   * salt of the key derived from the passphrase.
+   *
+   * @generated from field: bytes salt = 4;
+   */
+  salt = new Uint8Array(0);
+
+  /**
+   * @generated from field: bytes nonce = 5;
+   */
+  nonce = new Uint8Array(0);
+
+  /**
+   * AES-256-GCM encrypted ConfigReplica, the instance ID is authenticated as additional data.
+   *
+   * @generated from field: bytes ciphertext = 6;
+   */
+  ciphertext = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<SealedReplica>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SealedReplica";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "version", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "instance", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "unix_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "salt", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 5, name: "nonce", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 6, name: "ciphertext", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SealedReplica {
+    return new SealedReplica().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SealedReplica {
+    return new SealedReplica().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SealedReplica {
+    return new SealedReplica().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SealedReplica | PlainMessage<SealedReplica> | undefined, b: SealedReplica | PlainMessage<SealedReplica> | undefined): boolean {
+    return proto3.util.equals(SealedReplica, a, b);
+  }
+}
+
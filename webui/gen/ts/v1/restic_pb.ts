@@ -218,10 +218,41 @@ export class BackupProgressStatusEntry extends Message<BackupProgressStatusEntry
   bytesDone = protoInt64.zero;
 
   /**
+   * files currently being read by restic.
+   *
    * @generated from field: repeated string current_file = 6;
    */
   currentFile: string[] = [];
 
+  /**
+   * Remaining work is an estimate, the totals grow while restic is still scanning the filesystem.
+   *
+   * @generated from field: int64 files_remaining = 7;
+   */
+  filesRemaining = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 bytes_remaining = 8;
+   */
+  bytesRemaining = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 seconds_elapsed = 9;
+   */
+  secondsElapsed = protoInt64.zero;
+
+  /**
+   * 0 until restic has finished scanning and can estimate the time remaining.
+   *
+   * @generated from field: int64 seconds_remaining = 10;
+   */
+  secondsRemaining = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 error_count = 11;
+   */
+  errorCount = protoInt64.zero;
+
   constructor(data?: PartialMessage<BackupProgressStatusEntry>) {
     super();
     proto3.util.initPartial(data, this);
@@ -236,6 +267,11 @@ export class BackupProgressStatusEntry extends Message<BackupProgressStatusEntry
     { no: 4, name: "files_done", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 5, name: "bytes_done", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 6, name: "current_file", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "files_remaining", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "bytes_remaining", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "seconds_elapsed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 10, name: "seconds_remaining", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 11, name: "error_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BackupProgressStatusEntry {
@@ -527,6 +563,27 @@ export class RepoStats extends Message<RepoStats> {
    */
   snapshotCount = protoInt64.zero;
 
+  /**
+   * size of restoring every snapshot in the repo, from restic stats --mode restore-size.
+   *
+   * @generated from field: int64 restore_size = 7;
+   */
+  restoreSize = protoInt64.zero;
+
+  /**
+   * number of files in every snapshot in the repo, from restic stats --mode restore-size.
+   *
+   * @generated from field: int64 total_file_count = 8;
+   */
+  totalFileCount = protoInt64.zero;
+
+  /**
+   * percentage of the repo's data that is stored compressed.
+   *
+   * @generated from field: double compression_progress = 9;
+   */
+  compressionProgress = 0;
+
   constructor(data?: PartialMessage<RepoStats>) {
     super();
     proto3.util.initPartial(data, this);
@@ -540,6 +597,9 @@ export class RepoStats extends Message<RepoStats> {
     { no: 3, name: "compression_ratio", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
     { no: 5, name: "total_blob_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 6, name: "snapshot_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "restore_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "total_file_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "compression_progress", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoStats {
@@ -559,3 +619,368 @@ export class RepoStats extends Message<RepoStats> {
   }
 }
 
+/**
+ * RepoKey is a key of a repo, each key holds a password that opens the repo.
+ *
+ * @generated from message v1.RepoKey
+ */
+export class RepoKey extends Message<RepoKey> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * the key holding the password in backrest's config.
+   *
+   * @generated from field: bool current = 2;
+   */
+  current = false;
+
+  /**
+   * @generated from field: string username = 3;
+   */
+  username = "";
+
+  /**
+   * @generated from field: string hostname = 4;
+   */
+  hostname = "";
+
+  /**
+   * @generated from field: int64 created_unix_ms = 5;
+   */
+  createdUnixMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<RepoKey>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoKey";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "current", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "username", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "hostname", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "created_unix_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoKey {
+    return new RepoKey().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoKey {
+    return new RepoKey().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoKey {
+    return new RepoKey().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoKey | PlainMessage<RepoKey> | undefined, b: RepoKey | PlainMessage<RepoKey> | undefined): boolean {
+    return proto3.util.equals(RepoKey, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RepoKeyList
+ */
+export class RepoKeyList extends Message<RepoKeyList> {
+  /**
+   * @generated from field: repeated v1.RepoKey keys = 1;
+   */
+  keys: RepoKey[] = [];
+
+  constructor(data?: PartialMessage<RepoKeyList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoKeyList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "keys", kind: "message", T: RepoKey, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoKeyList {
+    return new RepoKeyList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoKeyList {
+    return new RepoKeyList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoKeyList {
+    return new RepoKeyList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoKeyList | PlainMessage<RepoKeyList> | undefined, b: RepoKeyList | PlainMessage<RepoKeyList> | undefined): boolean {
+    return proto3.util.equals(RepoKeyList, a, b);
+  }
+}
+
+/**
+ * SnapshotStats are statistics for a single snapshot. Snapshots are immutable so these are cached once computed.
+ *
+ * @generated from message v1.SnapshotStats
+ */
+export class SnapshotStats extends Message<SnapshotStats> {
+  /**
+   * number of files in the snapshot.
+   *
+   * @generated from field: int64 file_count = 1;
+   */
+  fileCount = protoInt64.zero;
+
+  /**
+   * size of the deduplicated data referenced by the snapshot as stored in the repo.
+   *
+   * @generated from field: int64 total_size = 2;
+   */
+  totalSize = protoInt64.zero;
+
+  /**
+   * size of the files in the snapshot if restored.
+   *
+   * @generated from field: int64 restore_size = 3;
+   */
+  restoreSize = protoInt64.zero;
+
+  constructor(data?: PartialMessage<SnapshotStats>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SnapshotStats";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "file_count", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "total_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "restore_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SnapshotStats {
+    return new SnapshotStats().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SnapshotStats {
+    return new SnapshotStats().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SnapshotStats {
+    return new SnapshotStats().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SnapshotStats | PlainMessage<SnapshotStats> | undefined, b: SnapshotStats | PlainMessage<SnapshotStats> | undefined): boolean {
+    return proto3.util.equals(SnapshotStats, a, b);
+  }
+}
+
+/**
+ * SnapshotDiff lists the paths that changed between two snapshots.
+ *
+ * @generated from message v1.SnapshotDiff
+ */
+export class SnapshotDiff extends Message<SnapshotDiff> {
+  /**
+   * the older snapshot of the diff.
+   *
+   * @generated from field: string base_snapshot_id = 1;
+   */
+  baseSnapshotId = "";
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId = "";
+
+  /**
+   * @generated from field: repeated v1.DiffEntry entries = 3;
+   */
+  entries: DiffEntry[] = [];
+
+  /**
+   * @generated from field: int64 files_added = 4;
+   */
+  filesAdded = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 files_removed = 5;
+   */
+  filesRemoved = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 files_changed = 6;
+   */
+  filesChanged = protoInt64.zero;
+
+  /**
+   * size of the data only referenced by snapshot_id.
+   *
+   * @generated from field: int64 bytes_added = 7;
+   */
+  bytesAdded = protoInt64.zero;
+
+  /**
+   * size of the data only referenced by base_snapshot_id.
+   *
+   * @generated from field: int64 bytes_removed = 8;
+   */
+  bytesRemoved = protoInt64.zero;
+
+  /**
+   * true if the diff changed too many directories for the sizes of its entries to be looked up.
+   *
+   * @generated from field: bool sizes_omitted = 9;
+   */
+  sizesOmitted = false;
+
+  constructor(data?: PartialMessage<SnapshotDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SnapshotDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "base_snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "entries", kind: "message", T: DiffEntry, repeated: true },
+    { no: 4, name: "files_added", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "files_removed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "files_changed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "bytes_added", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "bytes_removed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "sizes_omitted", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SnapshotDiff {
+    return new SnapshotDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SnapshotDiff {
+    return new SnapshotDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SnapshotDiff {
+    return new SnapshotDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SnapshotDiff | PlainMessage<SnapshotDiff> | undefined, b: SnapshotDiff | PlainMessage<SnapshotDiff> | undefined): boolean {
+    return proto3.util.equals(SnapshotDiff, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.DiffEntry
+ */
+export class DiffEntry extends Message<DiffEntry> {
+  /**
+   * path in the snapshot, directories end in "/".
+   *
+   * @generated from field: string path = 1;
+   */
+  path = "";
+
+  /**
+   * @generated from field: v1.DiffEntry.Change change = 2;
+   */
+  change = DiffEntry_Change.UNKNOWN;
+
+  /**
+   * size of the file in snapshot_id, 0 if removed.
+   *
+   * @generated from field: int64 size = 3;
+   */
+  size = protoInt64.zero;
+
+  /**
+   * size of the file in base_snapshot_id, 0 if added.
+   *
+   * @generated from field: int64 previous_size = 4;
+   */
+  previousSize = protoInt64.zero;
+
+  constructor(data?: PartialMessage<DiffEntry>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.DiffEntry";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "change", kind: "enum", T: proto3.getEnumType(DiffEntry_Change) },
+    { no: 3, name: "size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "previous_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffEntry {
+    return new DiffEntry().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffEntry {
+    return new DiffEntry().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffEntry {
+    return new DiffEntry().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffEntry | PlainMessage<DiffEntry> | undefined, b: DiffEntry | PlainMessage<DiffEntry> | undefined): boolean {
+    return proto3.util.equals(DiffEntry, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.DiffEntry.Change
+ */
+export enum DiffEntry_Change {
+  /**
+   * @generated from enum value: CHANGE_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * @generated from enum value: CHANGE_ADDED = 1;
+   */
+  ADDED = 1,
+
+  /**
+   * @generated from enum value: CHANGE_REMOVED = 2;
+   */
+  REMOVED = 2,
+
+  /**
+   * the file's contents changed.
+   *
+   * @generated from enum value: CHANGE_MODIFIED = 3;
+   */
+  MODIFIED = 3,
+
+  /**
+   * e.g. a file was replaced by a directory.
+   *
+   * @generated from enum value: CHANGE_TYPE_CHANGED = 4;
+   */
+  TYPE_CHANGED = 4,
+
+  /**
+   * only the file's metadata e.g. its mode or mtime changed.
+   *
+   * @generated from enum value: CHANGE_METADATA_CHANGED = 5;
+   */
+  METADATA_CHANGED = 5,
+}
+// Retrieve enum metadata with: proto3.getEnumType(DiffEntry_Change)
+proto3.util.setEnumType(DiffEntry_Change, "v1.DiffEntry.Change", [
+  { no: 0, name: "CHANGE_UNKNOWN" },
+  { no: 1, name: "CHANGE_ADDED" },
+  { no: 2, name: "CHANGE_REMOVED" },
+  { no: 3, name: "CHANGE_MODIFIED" },
+  { no: 4, name: "CHANGE_TYPE_CHANGED" },
+  { no: 5, name: "CHANGE_METADATA_CHANGED" },
+]);
+
@@ -0,0 +1,267 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file v1/scheduler.proto (package v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+
+/**
+ * SchedulerDecision records why the scheduler did or didn't run a task at a given time.
+ *
+ * @generated from message v1.SchedulerDecision
+ */
+export class SchedulerDecision extends Message<SchedulerDecision> {
+  /**
+   * unix time in milliseconds the decision was made at.
+   *
+   * @generated from field: int64 unix_time_ms = 1;
+   */
+  unixTimeMs = protoInt64.zero;
+
+  /**
+   * @generated from field: v1.SchedulerDecision.Kind kind = 2;
+   */
+  kind = SchedulerDecision_Kind.UNKNOWN;
+
+  /**
+   * optional, the name of the task the decision is about.
+   *
+   * @generated from field: string task = 3;
+   */
+  task = "";
+
+  /**
+   * optional, the plan the decision is about.
+   *
+   * @generated from field: string plan_id = 4;
+   */
+  planId = "";
+
+  /**
+   * optional, the repo the decision is about.
+   *
+   * @generated from field: string repo_id = 5;
+   */
+  repoId = "";
+
+  /**
+   * unix time in milliseconds the task is queued to run at, set for scheduled and deferred tasks.
+   *
+   * @generated from field: int64 run_at_ms = 6;
+   */
+  runAtMs = protoInt64.zero;
+
+  /**
+   * human readable explanation of the decision e.g. "jitter of 4m12s applied".
+   *
+   * @generated from field: string reason = 7;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<SchedulerDecision>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SchedulerDecision";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "unix_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "kind", kind: "enum", T: proto3.getEnumType(SchedulerDecision_Kind) },
+    { no: 3, name: "task", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "run_at_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SchedulerDecision {
+    return new SchedulerDecision().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SchedulerDecision {
+    return new SchedulerDecision().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SchedulerDecision {
+    return new SchedulerDecision().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SchedulerDecision | PlainMessage<SchedulerDecision> | undefined, b: SchedulerDecision | PlainMessage<SchedulerDecision> | undefined): boolean {
+    return proto3.util.equals(SchedulerDecision, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.SchedulerDecision.Kind
+ */
+export enum SchedulerDecision_Kind {
+  /**
+   * @generated from enum value: KIND_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * the task was queued to run at run_at_ms.
+   *
+   * @generated from enum value: KIND_SCHEDULED = 1;
+   */
+  SCHEDULED = 1,
+
+  /**
+   * the task started running, the reason explains a late start e.g. while the repo was busy.
+   *
+   * @generated from enum value: KIND_STARTED = 2;
+   */
+  STARTED = 2,
+
+  /**
+   * the task was due outside of its plan's backup window, it was queued again at run_at_ms.
+   *
+   * @generated from enum value: KIND_DEFERRED = 3;
+   */
+  DEFERRED = 3,
+
+  /**
+   * the task was due but skipped e.g. because its plan's run conditions were not met.
+   *
+   * @generated from enum value: KIND_SKIPPED = 4;
+   */
+  SKIPPED = 4,
+
+  /**
+   * the plan or repo is disabled or paused, its tasks are not queued.
+   *
+   * @generated from enum value: KIND_NOT_SCHEDULED = 5;
+   */
+  NOT_SCHEDULED = 5,
+
+  /**
+   * the queued task was cancelled by a config change, the tasks of the new config are queued instead.
+   *
+   * @generated from enum value: KIND_CANCELLED = 6;
+   */
+  CANCELLED = 6,
+
+  /**
+   * the running task was stopped because its plan's backup window closed.
+   *
+   * @generated from enum value: KIND_STOPPED = 7;
+   */
+  STOPPED = 7,
+}
+// Retrieve enum metadata with: proto3.getEnumType(SchedulerDecision_Kind)
+proto3.util.setEnumType(SchedulerDecision_Kind, "v1.SchedulerDecision.Kind", [
+  { no: 0, name: "KIND_UNKNOWN" },
+  { no: 1, name: "KIND_SCHEDULED" },
+  { no: 2, name: "KIND_STARTED" },
+  { no: 3, name: "KIND_DEFERRED" },
+  { no: 4, name: "KIND_SKIPPED" },
+  { no: 5, name: "KIND_NOT_SCHEDULED" },
+  { no: 6, name: "KIND_CANCELLED" },
+  { no: 7, name: "KIND_STOPPED" },
+]);
+
+/**
+ * @generated from message v1.SchedulerDecisionsRequest
+ */
+export class SchedulerDecisionsRequest extends Message<SchedulerDecisionsRequest> {
+  /**
+   * optional, only decisions about the plan are returned.
+   *
+   * @generated from field: string plan_id = 1;
+   */
+  planId = "";
+
+  /**
+   * optional, only decisions about the repo are returned.
+   *
+   * @generated from field: string repo_id = 2;
+   */
+  repoId = "";
+
+  /**
+   * optional, only decisions made at or after this unix time in milliseconds are returned.
+   *
+   * @generated from field: int64 since_ms = 3;
+   */
+  sinceMs = protoInt64.zero;
+
+  /**
+   * optional, the maximum number of decisions to return.
+   *
+   * @generated from field: int32 limit = 4;
+   */
+  limit = 0;
+
+  constructor(data?: PartialMessage<SchedulerDecisionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SchedulerDecisionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "since_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "limit", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SchedulerDecisionsRequest {
+    return new SchedulerDecisionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SchedulerDecisionsRequest {
+    return new SchedulerDecisionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SchedulerDecisionsRequest {
+    return new SchedulerDecisionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SchedulerDecisionsRequest | PlainMessage<SchedulerDecisionsRequest> | undefined, b: SchedulerDecisionsRequest | PlainMessage<SchedulerDecisionsRequest> | undefined): boolean {
+    return proto3.util.equals(SchedulerDecisionsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.SchedulerDecisionList
+ */
+export class SchedulerDecisionList extends Message<SchedulerDecisionList> {
+  /**
+   * newest first.
+   *
+   * @generated from field: repeated v1.SchedulerDecision decisions = 1;
+   */
+  decisions: SchedulerDecision[] = [];
+
+  constructor(data?: PartialMessage<SchedulerDecisionList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SchedulerDecisionList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "decisions", kind: "message", T: SchedulerDecision, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SchedulerDecisionList {
+    return new SchedulerDecisionList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SchedulerDecisionList {
+    return new SchedulerDecisionList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SchedulerDecisionList {
+    return new SchedulerDecisionList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SchedulerDecisionList | PlainMessage<SchedulerDecisionList> | undefined, b: SchedulerDecisionList | PlainMessage<SchedulerDecisionList> | undefined): boolean {
+    return proto3.util.equals(SchedulerDecisionList, a, b);
+  }
+}
+
@@ -5,10 +5,15 @@
 
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 import { Config, Repo } from "./config_pb.js";
-import { OperationEvent, OperationList } from "./operations_pb.js";
-import { ClearHistoryRequest, ForgetRequest, GetOperationsRequest, ListSnapshotFilesRequest, ListSnapshotFilesResponse, ListSnapshotsRequest, LogDataRequest, RestoreSnapshotRequest } from "./service_pb.js";
-import { ResticSnapshotList } from "./restic_pb.js";
+import { Operation, OperationEvent, OperationList } from "./operations_pb.js";
+import { AddRepoKeyRequest, AnnotateOperationRequest, BackupPreview, BackupRequest, ChangeRepoPasswordRequest, ClearHistoryRequest, DiffSnapshotsRequest, ExportOperationsRequest, FileVersionList, ForgetRequest, GetOperationsRequest, GetSnapshotStatsRequest, GetStatsRequest, ImportOperationsResponse, ListFileVersionsRequest, ListRestorePointsRequest, ListSnapshotFilesRequest, ListSnapshotFilesResponse, ListSnapshotsRequest, LogDataRequest, MigrateRepoRequest, MountRepoRequest, PlanStatsList, RemoveRepoKeyRequest, RepoCacheUsage, RepoMount, RepoMountList, RepoStatsHistory, RepoStatsHistoryRequest, RepoUpgradeAssessment, ResticInfo, RestoreConflictReport, RestoreDownloadURLRequest, RestorePointList, RestorePreview, RestoreSnapshotRequest, RewritePreview, RewriteSnapshotsRequest, SearchRequest, SearchResponse, SearchSnapshotsRequest, SetBandwidthLimitRequest, SetPausedRequest, SnapshotFileMatch, SubscribeOperationsRequest, TagSnapshotsRequest, UpgradeRepoRequest, ValidateCronRequest, ValidateCronResponse } from "./service_pb.js";
+import { RepoKey, RepoKeyList, ResticSnapshotList, SnapshotDiff, SnapshotStats } from "./restic_pb.js";
 import { BytesValue, Int64Value, StringList, StringValue } from "../types/value_pb.js";
+import { CreateShareLinkRequest, CreateShareLinkResponse, ShareLink, ShareLinkList } from "./sharelink_pb.js";
+import { SealedReplica } from "./replica_pb.js";
+import { Alert, AlertList, SnoozeAlertRequest } from "./alerts_pb.js";
+import { HookDeliveryList } from "./hookdelivery_pb.js";
+import { SchedulerDecisionList, SchedulerDecisionsRequest } from "./scheduler_pb.js";
 
 /**
  * @generated from service v1.Backrest
@@ -44,6 +49,8 @@ export const Backrest = {
       kind: MethodKind.Unary,
     },
     /**
+     * GetOperationEvents streams every operation event the caller may see, see SubscribeOperations.
+     *
      * @generated from rpc v1.Backrest.GetOperationEvents
      */
     getOperationEvents: {
@@ -52,6 +59,19 @@ export const Backrest = {
       O: OperationEvent,
       kind: MethodKind.ServerStreaming,
     },
+    /**
+     * SubscribeOperations streams the events of operations as they are created, updated and deleted, optionally only those
+     * of a plan or repo. The stream is closed if the client falls behind, clients reconnect and fetch the operations they
+     * may have missed with GetOperations.
+     *
+     * @generated from rpc v1.Backrest.SubscribeOperations
+     */
+    subscribeOperations: {
+      name: "SubscribeOperations",
+      I: SubscribeOperationsRequest,
+      O: OperationEvent,
+      kind: MethodKind.ServerStreaming,
+    },
     /**
      * @generated from rpc v1.Backrest.GetOperations
      */
@@ -79,6 +99,101 @@ export const Backrest = {
       O: ListSnapshotFilesResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * ListRestorePoints collapses a repo's snapshots into one restore point per plan and calendar day or week, newest first.
+     *
+     * @generated from rpc v1.Backrest.ListRestorePoints
+     */
+    listRestorePoints: {
+      name: "ListRestorePoints",
+      I: ListRestorePointsRequest,
+      O: RestorePointList,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DiffSnapshots lists the files added, removed and modified between two snapshots, by default between a snapshot and
+     * its parent i.e. what changed in the backup that created it.
+     *
+     * @generated from rpc v1.Backrest.DiffSnapshots
+     */
+    diffSnapshots: {
+      name: "DiffSnapshots",
+      I: DiffSnapshotsRequest,
+      O: SnapshotDiff,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * SearchSnapshots finds files whose name matches a glob pattern in a repo's snapshots, matches are streamed a
+     * snapshot at a time as they are found.
+     *
+     * @generated from rpc v1.Backrest.SearchSnapshots
+     */
+    searchSnapshots: {
+      name: "SearchSnapshots",
+      I: SearchSnapshotsRequest,
+      O: SnapshotFileMatch,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * ListFileVersions lists the versions of a file in a plan's snapshots newest first, so that the version to restore can
+     * be picked from the file's history.
+     *
+     * @generated from rpc v1.Backrest.ListFileVersions
+     */
+    listFileVersions: {
+      name: "ListFileVersions",
+      I: ListFileVersionsRequest,
+      O: FileVersionList,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * TagSnapshots schedules a restic tag adding and removing tags of snapshots, it returns the ID of the scheduled operation.
+     * restic saves each changed snapshot under a new ID. The plan and instance tags backrest selects snapshots by can't be
+     * changed.
+     *
+     * @generated from rpc v1.Backrest.TagSnapshots
+     */
+    tagSnapshots: {
+      name: "TagSnapshots",
+      I: TagSnapshotsRequest,
+      O: Int64Value,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * PreviewRewriteSnapshots reports the snapshots a rewrite would change by running restic rewrite --dry-run, the preview's
+     * digest must be passed back to RewriteSnapshots to confirm the rewrite.
+     *
+     * @generated from rpc v1.Backrest.PreviewRewriteSnapshots
+     */
+    previewRewriteSnapshots: {
+      name: "PreviewRewriteSnapshots",
+      I: RewriteSnapshotsRequest,
+      O: RewritePreview,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RewriteSnapshots schedules a restic rewrite removing the excluded files from snapshots, it returns the ID of the
+     * scheduled operation. restic saves each changed snapshot under a new ID, the originals are kept unless forget is set.
+     *
+     * @generated from rpc v1.Backrest.RewriteSnapshots
+     */
+    rewriteSnapshots: {
+      name: "RewriteSnapshots",
+      I: RewriteSnapshotsRequest,
+      O: Int64Value,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetSnapshotStats returns statistics for a snapshot, computed on the first request and cached afterwards.
+     *
+     * @generated from rpc v1.Backrest.GetSnapshotStats
+     */
+    getSnapshotStats: {
+      name: "GetSnapshotStats",
+      I: GetSnapshotStatsRequest,
+      O: SnapshotStats,
+      kind: MethodKind.Unary,
+    },
     /**
      * IndexSnapshots triggers indexin. It accepts a repo id and returns empty if the task is enqueued.
      *
@@ -102,25 +217,120 @@ export const Backrest = {
       kind: MethodKind.Unary,
     },
     /**
-     * Prune schedules a prune operation. It accepts a plan id and returns empty if the task is enqueued.
+     * BackupWithAnnotations runs a plan's backup like Backup and attaches the annotations to its snapshot as tags, e.g. the
+     * version of the application being released, so that the snapshot can be found by them later.
+     *
+     * @generated from rpc v1.Backrest.BackupWithAnnotations
+     */
+    backupWithAnnotations: {
+      name: "BackupWithAnnotations",
+      I: BackupRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * PreviewBackup runs a plan's backup with restic backup --dry-run and reports what it would add to the repo, nothing
+     * is written to the repo. It accepts a plan id.
+     *
+     * @generated from rpc v1.Backrest.PreviewBackup
+     */
+    previewBackup: {
+      name: "PreviewBackup",
+      I: StringValue,
+      O: BackupPreview,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Prune schedules a prune operation. It accepts a plan id and returns the ID of the scheduled operation.
      *
      * @generated from rpc v1.Backrest.Prune
      */
     prune: {
       name: "Prune",
       I: StringValue,
-      O: Empty,
+      O: Int64Value,
       kind: MethodKind.Unary,
     },
     /**
-     * Forget schedules a forget operation. It accepts a plan id and returns empty if the task is enqueued.
+     * Forget schedules a forget operation. It accepts a plan id and returns the ID of the scheduled operation.
      *
      * @generated from rpc v1.Backrest.Forget
      */
     forget: {
       name: "Forget",
       I: ForgetRequest,
-      O: Empty,
+      O: Int64Value,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Check schedules a check operation. It accepts a repo id and returns the ID of the scheduled operation.
+     *
+     * @generated from rpc v1.Backrest.Check
+     */
+    check: {
+      name: "Check",
+      I: StringValue,
+      O: Int64Value,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * InitRepo schedules a restic init of a repo already in the config, e.g. one whose storage was recreated. It accepts a
+     * repo id and returns the ID of the scheduled operation. Repos added with AddRepo are initialized when they are added.
+     *
+     * @generated from rpc v1.Backrest.InitRepo
+     */
+    initRepo: {
+      name: "InitRepo",
+      I: StringValue,
+      O: Int64Value,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * MigrateRepo schedules a restic migrate of the repo, by default upgrading it to the v2 repository format. It returns
+     * the ID of the scheduled operation.
+     *
+     * @generated from rpc v1.Backrest.MigrateRepo
+     */
+    migrateRepo: {
+      name: "MigrateRepo",
+      I: MigrateRepoRequest,
+      O: Int64Value,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetRepoUpgrade checks whether the repo uses an old format version or holds data stored uncompressed and describes
+     * the benefits and risks of upgrading it.
+     *
+     * @generated from rpc v1.Backrest.GetRepoUpgrade
+     */
+    getRepoUpgrade: {
+      name: "GetRepoUpgrade",
+      I: StringValue,
+      O: RepoUpgradeAssessment,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * UpgradeRepo schedules an upgrade of the repo to the v2 format, optionally followed by repacking the data stored
+     * uncompressed. It returns the ID of the scheduled operation, an interrupted upgrade is resumed when backrest next starts.
+     *
+     * @generated from rpc v1.Backrest.UpgradeRepo
+     */
+    upgradeRepo: {
+      name: "UpgradeRepo",
+      I: UpgradeRepoRequest,
+      O: Int64Value,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RecoverRepo schedules a restic recover of the repo, which saves the data of forgotten snapshots that was not pruned
+     * yet into a new snapshot. It returns the ID of the recover operation.
+     *
+     * @generated from rpc v1.Backrest.RecoverRepo
+     */
+    recoverRepo: {
+      name: "RecoverRepo",
+      I: StringValue,
+      O: Int64Value,
       kind: MethodKind.Unary,
     },
     /**
@@ -134,6 +344,30 @@ export const Backrest = {
       O: Empty,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetRestoreConflicts reports the local files an in place restore would overwrite, the report's digest must be passed
+     * back to Restore to confirm the in place restore.
+     *
+     * @generated from rpc v1.Backrest.GetRestoreConflicts
+     */
+    getRestoreConflicts: {
+      name: "GetRestoreConflicts",
+      I: RestoreSnapshotRequest,
+      O: RestoreConflictReport,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * PreviewRestore lists the files a restore would write and checks that the target can be written before the restore
+     * is scheduled, nothing is restored.
+     *
+     * @generated from rpc v1.Backrest.PreviewRestore
+     */
+    previewRestore: {
+      name: "PreviewRestore",
+      I: RestoreSnapshotRequest,
+      O: RestorePreview,
+      kind: MethodKind.Unary,
+    },
     /**
      * Unlock synchronously attempts to unlock the repo. Will block if other operations are in progress.
      *
@@ -145,6 +379,97 @@ export const Backrest = {
       O: Empty,
       kind: MethodKind.Unary,
     },
+    /**
+     * MountRepo mounts the repo's snapshots as a read only filesystem with restic mount, under backrest's data directory.
+     * The repo is unmounted once it wasn't requested for its idle timeout, mounting a mounted repo restarts the timeout.
+     *
+     * @generated from rpc v1.Backrest.MountRepo
+     */
+    mountRepo: {
+      name: "MountRepo",
+      I: MountRepoRequest,
+      O: RepoMount,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * UnmountRepo unmounts a repo mounted with MountRepo, it accepts a repo id.
+     *
+     * @generated from rpc v1.Backrest.UnmountRepo
+     */
+    unmountRepo: {
+      name: "UnmountRepo",
+      I: StringValue,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetMounts returns the mounted repos the caller may access.
+     *
+     * @generated from rpc v1.Backrest.GetMounts
+     */
+    getMounts: {
+      name: "GetMounts",
+      I: Empty,
+      O: RepoMountList,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ListRepoKeys lists the keys of a repo, it accepts a repo id.
+     *
+     * @generated from rpc v1.Backrest.ListRepoKeys
+     */
+    listRepoKeys: {
+      name: "ListRepoKeys",
+      I: StringValue,
+      O: RepoKeyList,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * AddRepoKey adds a key with another password to a repo e.g. for another machine, the repo's password in the config
+     * is unchanged.
+     *
+     * @generated from rpc v1.Backrest.AddRepoKey
+     */
+    addRepoKey: {
+      name: "AddRepoKey",
+      I: AddRepoKeyRequest,
+      O: RepoKey,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RemoveRepoKey removes a key from a repo, the key holding the repo's password in the config can't be removed.
+     *
+     * @generated from rpc v1.Backrest.RemoveRepoKey
+     */
+    removeRepoKey: {
+      name: "RemoveRepoKey",
+      I: RemoveRepoKeyRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ChangeRepoPassword replaces the key holding the repo's password in the config with a key holding the new password
+     * and stores the new password in the config. The old key is only removed once the config is updated.
+     *
+     * @generated from rpc v1.Backrest.ChangeRepoPassword
+     */
+    changeRepoPassword: {
+      name: "ChangeRepoPassword",
+      I: ChangeRepoPasswordRequest,
+      O: Config,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetRepoCacheUsage returns the disk usage of the repo's local restic cache, it accepts a repo id.
+     *
+     * @generated from rpc v1.Backrest.GetRepoCacheUsage
+     */
+    getRepoCacheUsage: {
+      name: "GetRepoCacheUsage",
+      I: StringValue,
+      O: RepoCacheUsage,
+      kind: MethodKind.Unary,
+    },
     /**
      * Stats runs 'restic stats` on the repository and appends the results to the operations log.
      *
@@ -156,6 +481,28 @@ export const Backrest = {
       O: Empty,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetRepoStatsHistory returns the stats collected for a repo over time, oldest first, e.g. to graph the repo's growth.
+     *
+     * @generated from rpc v1.Backrest.GetRepoStatsHistory
+     */
+    getRepoStatsHistory: {
+      name: "GetRepoStatsHistory",
+      I: RepoStatsHistoryRequest,
+      O: RepoStatsHistory,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetStats returns statistics of each plan's backups aggregated from the operation log, e.g. success rates and durations.
+     *
+     * @generated from rpc v1.Backrest.GetStats
+     */
+    getStats: {
+      name: "GetStats",
+      I: GetStatsRequest,
+      O: PlanStatsList,
+      kind: MethodKind.Unary,
+    },
     /**
      * Cancel attempts to cancel a task with the given operation ID. Not guaranteed to succeed.
      *
@@ -179,7 +526,18 @@ export const Backrest = {
       kind: MethodKind.Unary,
     },
     /**
-     * GetDownloadURL returns a signed download URL given a forget operation ID.
+     * GetResticInfo returns the restic binary backrest uses by default and the binary used by each repo the caller may access.
+     *
+     * @generated from rpc v1.Backrest.GetResticInfo
+     */
+    getResticInfo: {
+      name: "GetResticInfo",
+      I: Empty,
+      O: ResticInfo,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetDownloadURL returns a signed URL that downloads the files of a restore operation given its ID.
      *
      * @generated from rpc v1.Backrest.GetDownloadURL
      */
@@ -189,6 +547,111 @@ export const Backrest = {
       O: StringValue,
       kind: MethodKind.Unary,
     },
+    /**
+     * GetRestoreDownloadURL returns a signed URL that downloads a file or directory of a restore operation's target. The
+     * path is part of the signature, the URL can't be changed to download other files of the restore.
+     *
+     * @generated from rpc v1.Backrest.GetRestoreDownloadURL
+     */
+    getRestoreDownloadURL: {
+      name: "GetRestoreDownloadURL",
+      I: RestoreDownloadURLRequest,
+      O: StringValue,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * CreateShareLink creates a passphrase protected link to download a directory or file of a restore without access to
+     * the UI, the link's URL is only returned here.
+     *
+     * @generated from rpc v1.Backrest.CreateShareLink
+     */
+    createShareLink: {
+      name: "CreateShareLink",
+      I: CreateShareLinkRequest,
+      O: CreateShareLinkResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetShareLinks lists the share links of the repos the caller may access.
+     *
+     * @generated from rpc v1.Backrest.GetShareLinks
+     */
+    getShareLinks: {
+      name: "GetShareLinks",
+      I: Empty,
+      O: ShareLinkList,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RevokeShareLink revokes the share link with the given ID, downloads in progress are not interrupted.
+     *
+     * @generated from rpc v1.Backrest.RevokeShareLink
+     */
+    revokeShareLink: {
+      name: "RevokeShareLink",
+      I: Int64Value,
+      O: ShareLink,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetSnapshotFileDownloadURL returns a signed URL that streams a single file from a snapshot without restoring it first.
+     *
+     * @generated from rpc v1.Backrest.GetSnapshotFileDownloadURL
+     */
+    getSnapshotFileDownloadURL: {
+      name: "GetSnapshotFileDownloadURL",
+      I: ListSnapshotFilesRequest,
+      O: StringValue,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetSnapshotArchiveURL returns a signed URL that streams a directory of a snapshot, the whole snapshot if no path is
+     * given, as an uncompressed tar archive. Appending "?offset=<bytes>" to the URL resumes an interrupted download.
+     *
+     * @generated from rpc v1.Backrest.GetSnapshotArchiveURL
+     */
+    getSnapshotArchiveURL: {
+      name: "GetSnapshotArchiveURL",
+      I: ListSnapshotFilesRequest,
+      O: StringValue,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ExportOperations streams the operations of the plans and repos the caller may access as newline delimited protojson,
+     * messages are chunks of the output and lines may span messages.
+     *
+     * @generated from rpc v1.Backrest.ExportOperations
+     */
+    exportOperations: {
+      name: "ExportOperations",
+      I: ExportOperationsRequest,
+      O: BytesValue,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * ImportOperations adds the operations in a stream of newline delimited protojson, as written by ExportOperations, to
+     * the oplog. Operations are given new IDs, those already in the oplog or unfinished when they were exported are skipped.
+     *
+     * @generated from rpc v1.Backrest.ImportOperations
+     */
+    importOperations: {
+      name: "ImportOperations",
+      I: BytesValue,
+      O: ImportOperationsResponse,
+      kind: MethodKind.ClientStreaming,
+    },
+    /**
+     * AnnotateOperation attaches a note to a finished operation replacing any it has, an empty note removes it. Returns the
+     * updated operation.
+     *
+     * @generated from rpc v1.Backrest.AnnotateOperation
+     */
+    annotateOperation: {
+      name: "AnnotateOperation",
+      I: AnnotateOperationRequest,
+      O: Operation,
+      kind: MethodKind.Unary,
+    },
     /**
      * Clears the history of operations
      *
@@ -211,6 +674,140 @@ export const Backrest = {
       O: StringList,
       kind: MethodKind.Unary,
     },
+    /**
+     * DescribeCron returns a human readable description of a cron expression. Returns an error if the expression is invalid.
+     *
+     * @generated from rpc v1.Backrest.DescribeCron
+     */
+    describeCron: {
+      name: "DescribeCron",
+      I: StringValue,
+      O: StringValue,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * ValidateCron returns a description of a cron expression and the next times it fires. Returns an error if the expression is invalid.
+     *
+     * @generated from rpc v1.Backrest.ValidateCron
+     */
+    validateCron: {
+      name: "ValidateCron",
+      I: ValidateCronRequest,
+      O: ValidateCronResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * SetPaused pauses or resumes scheduled tasks for a plan or a repo, returns the updated config.
+     *
+     * @generated from rpc v1.Backrest.SetPaused
+     */
+    setPaused: {
+      name: "SetPaused",
+      I: SetPausedRequest,
+      O: Config,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * SetBandwidthLimit changes a repo's bandwidth limits, returns the updated config. Backups in progress for the repo are
+     * restarted with the new limits.
+     *
+     * @generated from rpc v1.Backrest.SetBandwidthLimit
+     */
+    setBandwidthLimit: {
+      name: "SetBandwidthLimit",
+      I: SetBandwidthLimitRequest,
+      O: Config,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * PutReplica stores a config replica pushed by another instance, replicas are kept per instance and replace older ones.
+     *
+     * @generated from rpc v1.Backrest.PutReplica
+     */
+    putReplica: {
+      name: "PutReplica",
+      I: SealedReplica,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Search finds operations and snapshots matching a query across every plan and repo the caller may access, newest first.
+     *
+     * @generated from rpc v1.Backrest.Search
+     */
+    search: {
+      name: "Search",
+      I: SearchRequest,
+      O: SearchResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetAlerts evaluates the health rules and returns the current alerts of the plans and repos the caller may access.
+     *
+     * @generated from rpc v1.Backrest.GetAlerts
+     */
+    getAlerts: {
+      name: "GetAlerts",
+      I: Empty,
+      O: AlertList,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * AcknowledgeAlert suppresses notifications for the alert with the given ID until it's resolved, returns the updated alert.
+     *
+     * @generated from rpc v1.Backrest.AcknowledgeAlert
+     */
+    acknowledgeAlert: {
+      name: "AcknowledgeAlert",
+      I: StringValue,
+      O: Alert,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * SnoozeAlert suppresses notifications for an alert for a duration, returns the updated alert.
+     *
+     * @generated from rpc v1.Backrest.SnoozeAlert
+     */
+    snoozeAlert: {
+      name: "SnoozeAlert",
+      I: SnoozeAlertRequest,
+      O: Alert,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetHookDeliveries lists the hook notifications waiting to be retried and those that were given up on.
+     *
+     * @generated from rpc v1.Backrest.GetHookDeliveries
+     */
+    getHookDeliveries: {
+      name: "GetHookDeliveries",
+      I: Empty,
+      O: HookDeliveryList,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * RetryHookDelivery moves the dead letter with the given ID back to the retry queue, it is attempted immediately.
+     *
+     * @generated from rpc v1.Backrest.RetryHookDelivery
+     */
+    retryHookDelivery: {
+      name: "RetryHookDelivery",
+      I: Int64Value,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * GetSchedulerDecisions lists the scheduler's recent decisions about the tasks of the plans and repos the caller may
+     * access, newest first, e.g. to find out why a backup didn't run. Decisions are kept in memory and lost on restart.
+     *
+     * @generated from rpc v1.Backrest.GetSchedulerDecisions
+     */
+    getSchedulerDecisions: {
+      name: "GetSchedulerDecisions",
+      I: SchedulerDecisionsRequest,
+      O: SchedulerDecisionList,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
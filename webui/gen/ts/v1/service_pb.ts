@@ -5,6 +5,51 @@
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
 import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+import { Operation, OperationStatus, SnapshotAnnotation } from "./operations_pb.js";
+import { RepoStats, ResticSnapshot } from "./restic_pb.js";
+
+/**
+ * @generated from message v1.AnnotateOperationRequest
+ */
+export class AnnotateOperationRequest extends Message<AnnotateOperationRequest> {
+  /**
+   * @generated from field: int64 operation_id = 1;
+   */
+  operationId = protoInt64.zero;
+
+  /**
+   * @generated from field: string note = 2;
+   */
+  note = "";
+
+  constructor(data?: PartialMessage<AnnotateOperationRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.AnnotateOperationRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "operation_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "note", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AnnotateOperationRequest {
+    return new AnnotateOperationRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AnnotateOperationRequest {
+    return new AnnotateOperationRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AnnotateOperationRequest {
+    return new AnnotateOperationRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AnnotateOperationRequest | PlainMessage<AnnotateOperationRequest> | undefined, b: AnnotateOperationRequest | PlainMessage<AnnotateOperationRequest> | undefined): boolean {
+    return proto3.util.equals(AnnotateOperationRequest, a, b);
+  }
+}
 
 /**
  * @generated from message v1.ClearHistoryRequest
@@ -62,273 +107,3406 @@ export class ClearHistoryRequest extends Message<ClearHistoryRequest> {
 }
 
 /**
- * @generated from message v1.ForgetRequest
+ * @generated from message v1.SubscribeOperationsRequest
  */
-export class ForgetRequest extends Message<ForgetRequest> {
+export class SubscribeOperationsRequest extends Message<SubscribeOperationsRequest> {
   /**
+   * optional, only the repo's operations.
+   *
    * @generated from field: string repo_id = 1;
    */
   repoId = "";
 
   /**
+   * optional, only the plan's operations.
+   *
    * @generated from field: string plan_id = 2;
    */
   planId = "";
 
+  constructor(data?: PartialMessage<SubscribeOperationsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SubscribeOperationsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SubscribeOperationsRequest {
+    return new SubscribeOperationsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SubscribeOperationsRequest {
+    return new SubscribeOperationsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SubscribeOperationsRequest {
+    return new SubscribeOperationsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SubscribeOperationsRequest | PlainMessage<SubscribeOperationsRequest> | undefined, b: SubscribeOperationsRequest | PlainMessage<SubscribeOperationsRequest> | undefined): boolean {
+    return proto3.util.equals(SubscribeOperationsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.BackupRequest
+ */
+export class BackupRequest extends Message<BackupRequest> {
+  /**
+   * @generated from field: string plan_id = 1;
+   */
+  planId = "";
+
   /**
-   * @generated from field: string snapshot_id = 3;
+   * @generated from field: repeated v1.SnapshotAnnotation annotations = 2;
    */
-  snapshotId = "";
+  annotations: SnapshotAnnotation[] = [];
 
-  constructor(data?: PartialMessage<ForgetRequest>) {
+  constructor(data?: PartialMessage<BackupRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "v1.ForgetRequest";
+  static readonly typeName = "v1.BackupRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "annotations", kind: "message", T: SnapshotAnnotation, repeated: true },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ForgetRequest {
-    return new ForgetRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BackupRequest {
+    return new BackupRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ForgetRequest {
-    return new ForgetRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BackupRequest {
+    return new BackupRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ForgetRequest {
-    return new ForgetRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BackupRequest {
+    return new BackupRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: ForgetRequest | PlainMessage<ForgetRequest> | undefined, b: ForgetRequest | PlainMessage<ForgetRequest> | undefined): boolean {
-    return proto3.util.equals(ForgetRequest, a, b);
+  static equals(a: BackupRequest | PlainMessage<BackupRequest> | undefined, b: BackupRequest | PlainMessage<BackupRequest> | undefined): boolean {
+    return proto3.util.equals(BackupRequest, a, b);
   }
 }
 
 /**
- * @generated from message v1.ListSnapshotsRequest
+ * @generated from message v1.ExportOperationsRequest
  */
-export class ListSnapshotsRequest extends Message<ListSnapshotsRequest> {
+export class ExportOperationsRequest extends Message<ExportOperationsRequest> {
   /**
+   * optional, only export the repo's operations.
+   *
    * @generated from field: string repo_id = 1;
    */
   repoId = "";
 
   /**
+   * optional, only export the plan's operations.
+   *
    * @generated from field: string plan_id = 2;
    */
   planId = "";
 
-  constructor(data?: PartialMessage<ListSnapshotsRequest>) {
+  /**
+   * redact the config's secrets and drop the output of commands e.g. to share the log for debugging.
+   *
+   * @generated from field: bool sanitize = 3;
+   */
+  sanitize = false;
+
+  constructor(data?: PartialMessage<ExportOperationsRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "v1.ListSnapshotsRequest";
+  static readonly typeName = "v1.ExportOperationsRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "sanitize", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSnapshotsRequest {
-    return new ListSnapshotsRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportOperationsRequest {
+    return new ExportOperationsRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSnapshotsRequest {
-    return new ListSnapshotsRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportOperationsRequest {
+    return new ExportOperationsRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSnapshotsRequest {
-    return new ListSnapshotsRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportOperationsRequest {
+    return new ExportOperationsRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: ListSnapshotsRequest | PlainMessage<ListSnapshotsRequest> | undefined, b: ListSnapshotsRequest | PlainMessage<ListSnapshotsRequest> | undefined): boolean {
-    return proto3.util.equals(ListSnapshotsRequest, a, b);
+  static equals(a: ExportOperationsRequest | PlainMessage<ExportOperationsRequest> | undefined, b: ExportOperationsRequest | PlainMessage<ExportOperationsRequest> | undefined): boolean {
+    return proto3.util.equals(ExportOperationsRequest, a, b);
   }
 }
 
 /**
- * @generated from message v1.GetOperationsRequest
+ * @generated from message v1.ImportOperationsResponse
  */
-export class GetOperationsRequest extends Message<GetOperationsRequest> {
+export class ImportOperationsResponse extends Message<ImportOperationsResponse> {
   /**
-   * @generated from field: string repo_id = 1;
+   * @generated from field: int64 imported = 1;
    */
-  repoId = "";
+  imported = protoInt64.zero;
 
   /**
-   * @generated from field: string plan_id = 2;
+   * operations skipped because they are already in the oplog.
+   *
+   * @generated from field: int64 duplicates = 2;
    */
-  planId = "";
+  duplicates = protoInt64.zero;
 
   /**
-   * @generated from field: string snapshot_id = 4;
+   * operations skipped because they were pending or in progress when they were exported.
+   *
+   * @generated from field: int64 unfinished = 3;
    */
-  snapshotId = "";
+  unfinished = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ImportOperationsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ImportOperationsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "imported", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "duplicates", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "unfinished", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImportOperationsResponse {
+    return new ImportOperationsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImportOperationsResponse {
+    return new ImportOperationsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImportOperationsResponse {
+    return new ImportOperationsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImportOperationsResponse | PlainMessage<ImportOperationsResponse> | undefined, b: ImportOperationsResponse | PlainMessage<ImportOperationsResponse> | undefined): boolean {
+    return proto3.util.equals(ImportOperationsResponse, a, b);
+  }
+}
 
+/**
+ * @generated from message v1.ValidateCronRequest
+ */
+export class ValidateCronRequest extends Message<ValidateCronRequest> {
   /**
-   * @generated from field: int64 flow_id = 6;
+   * 5 or 6 field (with leading seconds) cron expression, or a shorthand e.g. @daily.
+   *
+   * @generated from field: string expr = 1;
    */
-  flowId = protoInt64.zero;
+  expr = "";
 
   /**
-   * @generated from field: repeated int64 ids = 5;
+   * number of upcoming fire times to return, defaults to 5.
+   *
+   * @generated from field: int32 count = 2;
    */
-  ids: bigint[] = [];
+  count = 0;
+
+  constructor(data?: PartialMessage<ValidateCronRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
 
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ValidateCronRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "expr", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ValidateCronRequest {
+    return new ValidateCronRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ValidateCronRequest {
+    return new ValidateCronRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ValidateCronRequest {
+    return new ValidateCronRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ValidateCronRequest | PlainMessage<ValidateCronRequest> | undefined, b: ValidateCronRequest | PlainMessage<ValidateCronRequest> | undefined): boolean {
+    return proto3.util.equals(ValidateCronRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ValidateCronResponse
+ */
+export class ValidateCronResponse extends Message<ValidateCronResponse> {
   /**
-   * limit to the last n operations
-   *
-   * @generated from field: int64 last_n = 3;
+   * @generated from field: string description = 1;
    */
-  lastN = protoInt64.zero;
+  description = "";
 
-  constructor(data?: PartialMessage<GetOperationsRequest>) {
+  /**
+   * @generated from field: repeated int64 next_unix_time_ms = 2;
+   */
+  nextUnixTimeMs: bigint[] = [];
+
+  constructor(data?: PartialMessage<ValidateCronResponse>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "v1.GetOperationsRequest";
+  static readonly typeName = "v1.ValidateCronResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 4, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 6, name: "flow_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
-    { no: 5, name: "ids", kind: "scalar", T: 3 /* ScalarType.INT64 */, repeated: true },
-    { no: 3, name: "last_n", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 1, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "next_unix_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */, repeated: true },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetOperationsRequest {
-    return new GetOperationsRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ValidateCronResponse {
+    return new ValidateCronResponse().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetOperationsRequest {
-    return new GetOperationsRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ValidateCronResponse {
+    return new ValidateCronResponse().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetOperationsRequest {
-    return new GetOperationsRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ValidateCronResponse {
+    return new ValidateCronResponse().fromJsonString(jsonString, options);
   }
 
-  static equals(a: GetOperationsRequest | PlainMessage<GetOperationsRequest> | undefined, b: GetOperationsRequest | PlainMessage<GetOperationsRequest> | undefined): boolean {
-    return proto3.util.equals(GetOperationsRequest, a, b);
+  static equals(a: ValidateCronResponse | PlainMessage<ValidateCronResponse> | undefined, b: ValidateCronResponse | PlainMessage<ValidateCronResponse> | undefined): boolean {
+    return proto3.util.equals(ValidateCronResponse, a, b);
   }
 }
 
 /**
- * @generated from message v1.RestoreSnapshotRequest
+ * @generated from message v1.SetPausedRequest
  */
-export class RestoreSnapshotRequest extends Message<RestoreSnapshotRequest> {
+export class SetPausedRequest extends Message<SetPausedRequest> {
   /**
+   * exactly one of plan_id and repo_id must be set.
+   *
    * @generated from field: string plan_id = 1;
    */
   planId = "";
 
   /**
-   * @generated from field: string repo_id = 5;
+   * @generated from field: string repo_id = 2;
    */
   repoId = "";
 
   /**
-   * @generated from field: string snapshot_id = 2;
+   * @generated from field: bool paused = 3;
    */
-  snapshotId = "";
+  paused = false;
 
   /**
-   * @generated from field: string path = 3;
+   * @generated from field: string reason = 4;
    */
-  path = "";
+  reason = "";
+
+  constructor(data?: PartialMessage<SetPausedRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SetPausedRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "paused", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SetPausedRequest {
+    return new SetPausedRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SetPausedRequest {
+    return new SetPausedRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SetPausedRequest {
+    return new SetPausedRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SetPausedRequest | PlainMessage<SetPausedRequest> | undefined, b: SetPausedRequest | PlainMessage<SetPausedRequest> | undefined): boolean {
+    return proto3.util.equals(SetPausedRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.SetBandwidthLimitRequest
+ */
+export class SetBandwidthLimitRequest extends Message<SetBandwidthLimitRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
 
   /**
-   * @generated from field: string target = 4;
+   * KiB/s, 0 for unlimited.
+   *
+   * @generated from field: int32 limit_upload_kbps = 2;
    */
-  target = "";
+  limitUploadKbps = 0;
 
-  constructor(data?: PartialMessage<RestoreSnapshotRequest>) {
+  /**
+   * KiB/s, 0 for unlimited.
+   *
+   * @generated from field: int32 limit_download_kbps = 3;
+   */
+  limitDownloadKbps = 0;
+
+  constructor(data?: PartialMessage<SetBandwidthLimitRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "v1.RestoreSnapshotRequest";
+  static readonly typeName = "v1.SetBandwidthLimitRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 5, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 4, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "limit_upload_kbps", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "limit_download_kbps", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreSnapshotRequest {
-    return new RestoreSnapshotRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SetBandwidthLimitRequest {
+    return new SetBandwidthLimitRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreSnapshotRequest {
-    return new RestoreSnapshotRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SetBandwidthLimitRequest {
+    return new SetBandwidthLimitRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreSnapshotRequest {
-    return new RestoreSnapshotRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SetBandwidthLimitRequest {
+    return new SetBandwidthLimitRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: RestoreSnapshotRequest | PlainMessage<RestoreSnapshotRequest> | undefined, b: RestoreSnapshotRequest | PlainMessage<RestoreSnapshotRequest> | undefined): boolean {
-    return proto3.util.equals(RestoreSnapshotRequest, a, b);
+  static equals(a: SetBandwidthLimitRequest | PlainMessage<SetBandwidthLimitRequest> | undefined, b: SetBandwidthLimitRequest | PlainMessage<SetBandwidthLimitRequest> | undefined): boolean {
+    return proto3.util.equals(SetBandwidthLimitRequest, a, b);
   }
 }
 
 /**
- * @generated from message v1.ListSnapshotFilesRequest
+ * @generated from message v1.AddRepoKeyRequest
  */
-export class ListSnapshotFilesRequest extends Message<ListSnapshotFilesRequest> {
+export class AddRepoKeyRequest extends Message<AddRepoKeyRequest> {
   /**
    * @generated from field: string repo_id = 1;
    */
   repoId = "";
 
   /**
-   * @generated from field: string snapshot_id = 2;
+   * @generated from field: string password = 2;
    */
-  snapshotId = "";
+  password = "";
 
   /**
-   * @generated from field: string path = 3;
+   * defaults to the user backrest runs as.
+   *
+   * @generated from field: string username = 3;
    */
-  path = "";
+  username = "";
 
-  constructor(data?: PartialMessage<ListSnapshotFilesRequest>) {
+  /**
+   * defaults to the host backrest runs on.
+   *
+   * @generated from field: string hostname = 4;
+   */
+  hostname = "";
+
+  constructor(data?: PartialMessage<AddRepoKeyRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "v1.ListSnapshotFilesRequest";
+  static readonly typeName = "v1.AddRepoKeyRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "password", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "username", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "hostname", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSnapshotFilesRequest {
-    return new ListSnapshotFilesRequest().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AddRepoKeyRequest {
+    return new AddRepoKeyRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSnapshotFilesRequest {
-    return new ListSnapshotFilesRequest().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AddRepoKeyRequest {
+    return new AddRepoKeyRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSnapshotFilesRequest {
-    return new ListSnapshotFilesRequest().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AddRepoKeyRequest {
+    return new AddRepoKeyRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: ListSnapshotFilesRequest | PlainMessage<ListSnapshotFilesRequest> | undefined, b: ListSnapshotFilesRequest | PlainMessage<ListSnapshotFilesRequest> | undefined): boolean {
-    return proto3.util.equals(ListSnapshotFilesRequest, a, b);
+  static equals(a: AddRepoKeyRequest | PlainMessage<AddRepoKeyRequest> | undefined, b: AddRepoKeyRequest | PlainMessage<AddRepoKeyRequest> | undefined): boolean {
+    return proto3.util.equals(AddRepoKeyRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RemoveRepoKeyRequest
+ */
+export class RemoveRepoKeyRequest extends Message<RemoveRepoKeyRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string key_id = 2;
+   */
+  keyId = "";
+
+  constructor(data?: PartialMessage<RemoveRepoKeyRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RemoveRepoKeyRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "key_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RemoveRepoKeyRequest {
+    return new RemoveRepoKeyRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RemoveRepoKeyRequest {
+    return new RemoveRepoKeyRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RemoveRepoKeyRequest {
+    return new RemoveRepoKeyRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RemoveRepoKeyRequest | PlainMessage<RemoveRepoKeyRequest> | undefined, b: RemoveRepoKeyRequest | PlainMessage<RemoveRepoKeyRequest> | undefined): boolean {
+    return proto3.util.equals(RemoveRepoKeyRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ChangeRepoPasswordRequest
+ */
+export class ChangeRepoPasswordRequest extends Message<ChangeRepoPasswordRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string new_password = 2;
+   */
+  newPassword = "";
+
+  constructor(data?: PartialMessage<ChangeRepoPasswordRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ChangeRepoPasswordRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "new_password", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChangeRepoPasswordRequest {
+    return new ChangeRepoPasswordRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChangeRepoPasswordRequest {
+    return new ChangeRepoPasswordRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChangeRepoPasswordRequest {
+    return new ChangeRepoPasswordRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChangeRepoPasswordRequest | PlainMessage<ChangeRepoPasswordRequest> | undefined, b: ChangeRepoPasswordRequest | PlainMessage<ChangeRepoPasswordRequest> | undefined): boolean {
+    return proto3.util.equals(ChangeRepoPasswordRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.MountRepoRequest
+ */
+export class MountRepoRequest extends Message<MountRepoRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * defaults to 30.
+   *
+   * @generated from field: int32 idle_timeout_minutes = 2;
+   */
+  idleTimeoutMinutes = 0;
+
+  constructor(data?: PartialMessage<MountRepoRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.MountRepoRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "idle_timeout_minutes", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MountRepoRequest {
+    return new MountRepoRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MountRepoRequest {
+    return new MountRepoRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MountRepoRequest {
+    return new MountRepoRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MountRepoRequest | PlainMessage<MountRepoRequest> | undefined, b: MountRepoRequest | PlainMessage<MountRepoRequest> | undefined): boolean {
+    return proto3.util.equals(MountRepoRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RepoMount
+ */
+export class RepoMount extends Message<RepoMount> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string mountpoint = 2;
+   */
+  mountpoint = "";
+
+  /**
+   * @generated from field: v1.RepoMount.State state = 3;
+   */
+  state = RepoMount_State.MOUNTING;
+
+  /**
+   * @generated from field: int64 mounted_at_ms = 4;
+   */
+  mountedAtMs = protoInt64.zero;
+
+  /**
+   * when the repo is unmounted unless it's requested again.
+   *
+   * @generated from field: int64 unmount_at_ms = 5;
+   */
+  unmountAtMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<RepoMount>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoMount";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "mountpoint", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "state", kind: "enum", T: proto3.getEnumType(RepoMount_State) },
+    { no: 4, name: "mounted_at_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "unmount_at_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoMount {
+    return new RepoMount().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoMount {
+    return new RepoMount().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoMount {
+    return new RepoMount().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoMount | PlainMessage<RepoMount> | undefined, b: RepoMount | PlainMessage<RepoMount> | undefined): boolean {
+    return proto3.util.equals(RepoMount, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.RepoMount.State
+ */
+export enum RepoMount_State {
+  /**
+   * restic is starting, the mountpoint is empty until it's mounted.
+   *
+   * @generated from enum value: STATE_MOUNTING = 0;
+   */
+  MOUNTING = 0,
+
+  /**
+   * @generated from enum value: STATE_MOUNTED = 1;
+   */
+  MOUNTED = 1,
+}
+// Retrieve enum metadata with: proto3.getEnumType(RepoMount_State)
+proto3.util.setEnumType(RepoMount_State, "v1.RepoMount.State", [
+  { no: 0, name: "STATE_MOUNTING" },
+  { no: 1, name: "STATE_MOUNTED" },
+]);
+
+/**
+ * @generated from message v1.RepoMountList
+ */
+export class RepoMountList extends Message<RepoMountList> {
+  /**
+   * @generated from field: repeated v1.RepoMount mounts = 1;
+   */
+  mounts: RepoMount[] = [];
+
+  constructor(data?: PartialMessage<RepoMountList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoMountList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "mounts", kind: "message", T: RepoMount, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoMountList {
+    return new RepoMountList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoMountList {
+    return new RepoMountList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoMountList {
+    return new RepoMountList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoMountList | PlainMessage<RepoMountList> | undefined, b: RepoMountList | PlainMessage<RepoMountList> | undefined): boolean {
+    return proto3.util.equals(RepoMountList, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RepoCacheUsage
+ */
+export class RepoCacheUsage extends Message<RepoCacheUsage> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * the directory restic caches the repo's metadata in, shared with other repos unless the repo sets its own.
+   *
+   * @generated from field: string cache_dir = 2;
+   */
+  cacheDir = "";
+
+  /**
+   * the size of the repo's cache within cache_dir, 0 if it has no cache yet.
+   *
+   * @generated from field: int64 size_bytes = 3;
+   */
+  sizeBytes = protoInt64.zero;
+
+  /**
+   * the size the cache is cleared at by the cache cleanup, 0 for no limit.
+   *
+   * @generated from field: int64 max_size_bytes = 4;
+   */
+  maxSizeBytes = protoInt64.zero;
+
+  constructor(data?: PartialMessage<RepoCacheUsage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoCacheUsage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "cache_dir", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "size_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "max_size_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoCacheUsage {
+    return new RepoCacheUsage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoCacheUsage {
+    return new RepoCacheUsage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoCacheUsage {
+    return new RepoCacheUsage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoCacheUsage | PlainMessage<RepoCacheUsage> | undefined, b: RepoCacheUsage | PlainMessage<RepoCacheUsage> | undefined): boolean {
+    return proto3.util.equals(RepoCacheUsage, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ResticInfo
+ */
+export class ResticInfo extends Message<ResticInfo> {
+  /**
+   * the restic version backrest is pinned to, it is downloaded and verified if no binary is provided.
+   *
+   * @generated from field: string required_version = 1;
+   */
+  requiredVersion = "";
+
+  /**
+   * @generated from field: v1.ResticInfo.Binary default_binary = 2;
+   */
+  defaultBinary?: ResticInfo_Binary;
+
+  /**
+   * @generated from field: repeated v1.ResticInfo.RepoBinary repos = 3;
+   */
+  repos: ResticInfo_RepoBinary[] = [];
+
+  constructor(data?: PartialMessage<ResticInfo>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ResticInfo";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "required_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "default_binary", kind: "message", T: ResticInfo_Binary },
+    { no: 3, name: "repos", kind: "message", T: ResticInfo_RepoBinary, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResticInfo {
+    return new ResticInfo().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResticInfo {
+    return new ResticInfo().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResticInfo {
+    return new ResticInfo().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ResticInfo | PlainMessage<ResticInfo> | undefined, b: ResticInfo | PlainMessage<ResticInfo> | undefined): boolean {
+    return proto3.util.equals(ResticInfo, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ResticInfo.Binary
+ */
+export class ResticInfo_Binary extends Message<ResticInfo_Binary> {
+  /**
+   * @generated from field: string path = 1;
+   */
+  path = "";
+
+  /**
+   * e.g. "0.16.4", empty if error is set.
+   *
+   * @generated from field: string version = 2;
+   */
+  version = "";
+
+  /**
+   * set if the binary's version could not be determined e.g. because it's missing.
+   *
+   * @generated from field: string error = 3;
+   */
+  error = "";
+
+  constructor(data?: PartialMessage<ResticInfo_Binary>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ResticInfo.Binary";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResticInfo_Binary {
+    return new ResticInfo_Binary().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResticInfo_Binary {
+    return new ResticInfo_Binary().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResticInfo_Binary {
+    return new ResticInfo_Binary().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ResticInfo_Binary | PlainMessage<ResticInfo_Binary> | undefined, b: ResticInfo_Binary | PlainMessage<ResticInfo_Binary> | undefined): boolean {
+    return proto3.util.equals(ResticInfo_Binary, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ResticInfo.RepoBinary
+ */
+export class ResticInfo_RepoBinary extends Message<ResticInfo_RepoBinary> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: v1.ResticInfo.Binary binary = 2;
+   */
+  binary?: ResticInfo_Binary;
+
+  constructor(data?: PartialMessage<ResticInfo_RepoBinary>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ResticInfo.RepoBinary";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "binary", kind: "message", T: ResticInfo_Binary },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResticInfo_RepoBinary {
+    return new ResticInfo_RepoBinary().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResticInfo_RepoBinary {
+    return new ResticInfo_RepoBinary().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResticInfo_RepoBinary {
+    return new ResticInfo_RepoBinary().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ResticInfo_RepoBinary | PlainMessage<ResticInfo_RepoBinary> | undefined, b: ResticInfo_RepoBinary | PlainMessage<ResticInfo_RepoBinary> | undefined): boolean {
+    return proto3.util.equals(ResticInfo_RepoBinary, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ForgetRequest
+ */
+export class ForgetRequest extends Message<ForgetRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string plan_id = 2;
+   */
+  planId = "";
+
+  /**
+   * @generated from field: string snapshot_id = 3;
+   */
+  snapshotId = "";
+
+  constructor(data?: PartialMessage<ForgetRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ForgetRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ForgetRequest {
+    return new ForgetRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ForgetRequest {
+    return new ForgetRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ForgetRequest {
+    return new ForgetRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ForgetRequest | PlainMessage<ForgetRequest> | undefined, b: ForgetRequest | PlainMessage<ForgetRequest> | undefined): boolean {
+    return proto3.util.equals(ForgetRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.MigrateRepoRequest
+ */
+export class MigrateRepoRequest extends Message<MigrateRepoRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * restic migration to apply, defaults to "upgrade_repo_v2".
+   *
+   * @generated from field: string migration = 2;
+   */
+  migration = "";
+
+  constructor(data?: PartialMessage<MigrateRepoRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.MigrateRepoRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "migration", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MigrateRepoRequest {
+    return new MigrateRepoRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MigrateRepoRequest {
+    return new MigrateRepoRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MigrateRepoRequest {
+    return new MigrateRepoRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MigrateRepoRequest | PlainMessage<MigrateRepoRequest> | undefined, b: MigrateRepoRequest | PlainMessage<MigrateRepoRequest> | undefined): boolean {
+    return proto3.util.equals(MigrateRepoRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RepoUpgradeAssessment
+ */
+export class RepoUpgradeAssessment extends Message<RepoUpgradeAssessment> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * the repo's format version.
+   *
+   * @generated from field: int32 version = 2;
+   */
+  version = 0;
+
+  /**
+   * the restic migrations that can be applied to the repo.
+   *
+   * @generated from field: repeated string migrations = 3;
+   */
+  migrations: string[] = [];
+
+  /**
+   * the repo can be upgraded to the v2 format, which supports compression.
+   *
+   * @generated from field: bool upgrade_available = 4;
+   */
+  upgradeAvailable = false;
+
+  /**
+   * optional, the repo's last collected stats.
+   *
+   * @generated from field: v1.RepoStats stats = 5;
+   */
+  stats?: RepoStats;
+
+  /**
+   * the stats show data stored uncompressed.
+   *
+   * @generated from field: bool recompress_recommended = 6;
+   */
+  recompressRecommended = false;
+
+  /**
+   * @generated from field: repeated string benefits = 7;
+   */
+  benefits: string[] = [];
+
+  /**
+   * @generated from field: repeated string risks = 8;
+   */
+  risks: string[] = [];
+
+  constructor(data?: PartialMessage<RepoUpgradeAssessment>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoUpgradeAssessment";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "version", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "migrations", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "upgrade_available", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "stats", kind: "message", T: RepoStats },
+    { no: 6, name: "recompress_recommended", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "benefits", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "risks", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoUpgradeAssessment {
+    return new RepoUpgradeAssessment().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoUpgradeAssessment {
+    return new RepoUpgradeAssessment().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoUpgradeAssessment {
+    return new RepoUpgradeAssessment().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoUpgradeAssessment | PlainMessage<RepoUpgradeAssessment> | undefined, b: RepoUpgradeAssessment | PlainMessage<RepoUpgradeAssessment> | undefined): boolean {
+    return proto3.util.equals(RepoUpgradeAssessment, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.UpgradeRepoRequest
+ */
+export class UpgradeRepoRequest extends Message<UpgradeRepoRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * repack the data stored uncompressed after upgrading, this reads and rewrites the data.
+   *
+   * @generated from field: bool recompress = 2;
+   */
+  recompress = false;
+
+  constructor(data?: PartialMessage<UpgradeRepoRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.UpgradeRepoRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "recompress", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpgradeRepoRequest {
+    return new UpgradeRepoRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpgradeRepoRequest {
+    return new UpgradeRepoRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpgradeRepoRequest {
+    return new UpgradeRepoRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UpgradeRepoRequest | PlainMessage<UpgradeRepoRequest> | undefined, b: UpgradeRepoRequest | PlainMessage<UpgradeRepoRequest> | undefined): boolean {
+    return proto3.util.equals(UpgradeRepoRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ListSnapshotsRequest
+ */
+export class ListSnapshotsRequest extends Message<ListSnapshotsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string plan_id = 2;
+   */
+  planId = "";
+
+  /**
+   * optional, only snapshots holding all of these tags are listed.
+   *
+   * @generated from field: repeated string tags = 3;
+   */
+  tags: string[] = [];
+
+  constructor(data?: PartialMessage<ListSnapshotsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ListSnapshotsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "tags", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSnapshotsRequest {
+    return new ListSnapshotsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSnapshotsRequest {
+    return new ListSnapshotsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSnapshotsRequest {
+    return new ListSnapshotsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSnapshotsRequest | PlainMessage<ListSnapshotsRequest> | undefined, b: ListSnapshotsRequest | PlainMessage<ListSnapshotsRequest> | undefined): boolean {
+    return proto3.util.equals(ListSnapshotsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ListRestorePointsRequest
+ */
+export class ListRestorePointsRequest extends Message<ListRestorePointsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * optional, restore points of all of the repo's plans if unset.
+   *
+   * @generated from field: string plan_id = 2;
+   */
+  planId = "";
+
+  /**
+   * @generated from field: v1.ListRestorePointsRequest.Granularity granularity = 3;
+   */
+  granularity = ListRestorePointsRequest_Granularity.DAY;
+
+  /**
+   * optional IANA time zone calendar days are computed in e.g. "Europe/Berlin", defaults to the server's time zone.
+   *
+   * @generated from field: string timezone = 4;
+   */
+  timezone = "";
+
+  constructor(data?: PartialMessage<ListRestorePointsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ListRestorePointsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "granularity", kind: "enum", T: proto3.getEnumType(ListRestorePointsRequest_Granularity) },
+    { no: 4, name: "timezone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListRestorePointsRequest {
+    return new ListRestorePointsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListRestorePointsRequest {
+    return new ListRestorePointsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListRestorePointsRequest {
+    return new ListRestorePointsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListRestorePointsRequest | PlainMessage<ListRestorePointsRequest> | undefined, b: ListRestorePointsRequest | PlainMessage<ListRestorePointsRequest> | undefined): boolean {
+    return proto3.util.equals(ListRestorePointsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.ListRestorePointsRequest.Granularity
+ */
+export enum ListRestorePointsRequest_Granularity {
+  /**
+   * @generated from enum value: GRANULARITY_DAY = 0;
+   */
+  DAY = 0,
+
+  /**
+   * weeks start on Monday.
+   *
+   * @generated from enum value: GRANULARITY_WEEK = 1;
+   */
+  WEEK = 1,
+}
+// Retrieve enum metadata with: proto3.getEnumType(ListRestorePointsRequest_Granularity)
+proto3.util.setEnumType(ListRestorePointsRequest_Granularity, "v1.ListRestorePointsRequest.Granularity", [
+  { no: 0, name: "GRANULARITY_DAY" },
+  { no: 1, name: "GRANULARITY_WEEK" },
+]);
+
+/**
+ * @generated from message v1.RestorePoint
+ */
+export class RestorePoint extends Message<RestorePoint> {
+  /**
+   * empty for snapshots not created by a plan.
+   *
+   * @generated from field: string plan_id = 1;
+   */
+  planId = "";
+
+  /**
+   * date of the day or of the first day of the week e.g. "2024-05-07".
+   *
+   * @generated from field: string label = 2;
+   */
+  label = "";
+
+  /**
+   * start of the day or week in the request's time zone.
+   *
+   * @generated from field: int64 period_start_ms = 3;
+   */
+  periodStartMs = protoInt64.zero;
+
+  /**
+   * the newest snapshot of the period created by a backup that succeeded.
+   *
+   * @generated from field: v1.ResticSnapshot snapshot = 4;
+   */
+  snapshot?: ResticSnapshot;
+
+  /**
+   * number of snapshots of the plan taken during the period.
+   *
+   * @generated from field: int32 snapshot_count = 5;
+   */
+  snapshotCount = 0;
+
+  /**
+   * set if every snapshot of the period was created by a backup that failed, the newest one is chosen.
+   *
+   * @generated from field: bool partial = 6;
+   */
+  partial = false;
+
+  constructor(data?: PartialMessage<RestorePoint>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestorePoint";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "label", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "period_start_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "snapshot", kind: "message", T: ResticSnapshot },
+    { no: 5, name: "snapshot_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "partial", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestorePoint {
+    return new RestorePoint().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestorePoint {
+    return new RestorePoint().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestorePoint {
+    return new RestorePoint().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestorePoint | PlainMessage<RestorePoint> | undefined, b: RestorePoint | PlainMessage<RestorePoint> | undefined): boolean {
+    return proto3.util.equals(RestorePoint, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RestorePointList
+ */
+export class RestorePointList extends Message<RestorePointList> {
+  /**
+   * @generated from field: repeated v1.RestorePoint points = 1;
+   */
+  points: RestorePoint[] = [];
+
+  constructor(data?: PartialMessage<RestorePointList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestorePointList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "points", kind: "message", T: RestorePoint, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestorePointList {
+    return new RestorePointList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestorePointList {
+    return new RestorePointList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestorePointList {
+    return new RestorePointList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestorePointList | PlainMessage<RestorePointList> | undefined, b: RestorePointList | PlainMessage<RestorePointList> | undefined): boolean {
+    return proto3.util.equals(RestorePointList, a, b);
+  }
+}
+
+/**
+ * GetOperationsRequest selects operations by at most one of repo, plan, snapshot, flow, repo group or IDs, all operations
+ * if none are set. The other filters narrow the selection down.
+ *
+ * @generated from message v1.GetOperationsRequest
+ */
+export class GetOperationsRequest extends Message<GetOperationsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string plan_id = 2;
+   */
+  planId = "";
+
+  /**
+   * @generated from field: string snapshot_id = 4;
+   */
+  snapshotId = "";
+
+  /**
+   * @generated from field: int64 flow_id = 6;
+   */
+  flowId = protoInt64.zero;
+
+  /**
+   * @generated from field: repeated int64 ids = 5;
+   */
+  ids: bigint[] = [];
+
+  /**
+   * limit to the last n operations, applied before the filters below.
+   *
+   * @generated from field: int64 last_n = 3;
+   */
+  lastN = protoInt64.zero;
+
+  /**
+   * operations for any repo in the group.
+   *
+   * @generated from field: string repo_group_id = 7;
+   */
+  repoGroupId = "";
+
+  /**
+   * only operations with one of the statuses.
+   *
+   * @generated from field: repeated v1.OperationStatus statuses = 8;
+   */
+  statuses: OperationStatus[] = [];
+
+  /**
+   * only operations of the types, named by the operation's field without its prefix e.g. "backup" or "index_snapshot".
+   *
+   * @generated from field: repeated string types = 9;
+   */
+  types: string[] = [];
+
+  /**
+   * only operations started at or after this unix time in milliseconds.
+   *
+   * @generated from field: int64 start_after_ms = 10;
+   */
+  startAfterMs = protoInt64.zero;
+
+  /**
+   * only operations started before this unix time in milliseconds.
+   *
+   * @generated from field: int64 start_before_ms = 11;
+   */
+  startBeforeMs = protoInt64.zero;
+
+  /**
+   * order operations newest first by ID, operations are ordered by ID oldest first if descending or page_size is set
+   * and are otherwise returned in the order they were selected in.
+   *
+   * @generated from field: bool descending = 12;
+   */
+  descending = false;
+
+  /**
+   * maximum number of operations returned, the response's next_page_token fetches the next page. 0 returns every
+   * matching operation.
+   *
+   * @generated from field: int32 page_size = 13;
+   */
+  pageSize = 0;
+
+  /**
+   * next_page_token of the previous page, the other fields must match the previous request.
+   *
+   * @generated from field: string page_token = 14;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<GetOperationsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.GetOperationsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "flow_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "ids", kind: "scalar", T: 3 /* ScalarType.INT64 */, repeated: true },
+    { no: 3, name: "last_n", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "repo_group_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "statuses", kind: "enum", T: proto3.getEnumType(OperationStatus), repeated: true },
+    { no: 9, name: "types", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 10, name: "start_after_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 11, name: "start_before_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 12, name: "descending", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 13, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 14, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetOperationsRequest {
+    return new GetOperationsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetOperationsRequest {
+    return new GetOperationsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetOperationsRequest {
+    return new GetOperationsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetOperationsRequest | PlainMessage<GetOperationsRequest> | undefined, b: GetOperationsRequest | PlainMessage<GetOperationsRequest> | undefined): boolean {
+    return proto3.util.equals(GetOperationsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RestoreSnapshotRequest
+ */
+export class RestoreSnapshotRequest extends Message<RestoreSnapshotRequest> {
+  /**
+   * @generated from field: string plan_id = 1;
+   */
+  planId = "";
+
+  /**
+   * @generated from field: string repo_id = 5;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId = "";
+
+  /**
+   * @generated from field: string path = 3;
+   */
+  path = "";
+
+  /**
+   * @generated from field: string target = 4;
+   */
+  target = "";
+
+  /**
+   * restore the files to their original location rather than to a new directory under target.
+   *
+   * @generated from field: bool in_place = 6;
+   */
+  inPlace = false;
+
+  /**
+   * the digest of the conflict report the user confirmed, an in place restore is rejected if its conflicts changed since.
+   *
+   * @generated from field: string confirm_conflicts_digest = 7;
+   */
+  confirmConflictsDigest = "";
+
+  /**
+   * in place restores only, restore to a temporary directory next to path and swap it in once the restore succeeded.
+   * path is left untouched if the restore fails, local files under path that aren't in the snapshot are removed.
+   *
+   * @generated from field: bool swap = 8;
+   */
+  swap = false;
+
+  constructor(data?: PartialMessage<RestoreSnapshotRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestoreSnapshotRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "in_place", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "confirm_conflicts_digest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "swap", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreSnapshotRequest {
+    return new RestoreSnapshotRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreSnapshotRequest {
+    return new RestoreSnapshotRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreSnapshotRequest {
+    return new RestoreSnapshotRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestoreSnapshotRequest | PlainMessage<RestoreSnapshotRequest> | undefined, b: RestoreSnapshotRequest | PlainMessage<RestoreSnapshotRequest> | undefined): boolean {
+    return proto3.util.equals(RestoreSnapshotRequest, a, b);
+  }
+}
+
+/**
+ * RestoreConflict is a local file that a restore would overwrite.
+ *
+ * @generated from message v1.RestoreConflict
+ */
+export class RestoreConflict extends Message<RestoreConflict> {
+  /**
+   * @generated from field: string path = 1;
+   */
+  path = "";
+
+  /**
+   * @generated from field: int64 local_size = 2;
+   */
+  localSize = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 snapshot_size = 3;
+   */
+  snapshotSize = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 local_mtime_ms = 4;
+   */
+  localMtimeMs = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 snapshot_mtime_ms = 5;
+   */
+  snapshotMtimeMs = protoInt64.zero;
+
+  /**
+   * the local file was modified after the copy in the snapshot.
+   *
+   * @generated from field: bool local_newer = 6;
+   */
+  localNewer = false;
+
+  /**
+   * the local file isn't in the snapshot, swapping in the restore removes it.
+   *
+   * @generated from field: bool removed = 7;
+   */
+  removed = false;
+
+  constructor(data?: PartialMessage<RestoreConflict>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestoreConflict";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "local_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "snapshot_size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "local_mtime_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "snapshot_mtime_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "local_newer", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "removed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreConflict {
+    return new RestoreConflict().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreConflict {
+    return new RestoreConflict().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreConflict {
+    return new RestoreConflict().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestoreConflict | PlainMessage<RestoreConflict> | undefined, b: RestoreConflict | PlainMessage<RestoreConflict> | undefined): boolean {
+    return proto3.util.equals(RestoreConflict, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RestoreConflictReport
+ */
+export class RestoreConflictReport extends Message<RestoreConflictReport> {
+  /**
+   * @generated from field: int64 files_checked = 1;
+   */
+  filesChecked = protoInt64.zero;
+
+  /**
+   * files that exist locally and would be overwritten.
+   *
+   * @generated from field: int64 overwritten_files = 2;
+   */
+  overwrittenFiles = protoInt64.zero;
+
+  /**
+   * overwritten files that are newer locally than in the snapshot.
+   *
+   * @generated from field: int64 newer_local_files = 3;
+   */
+  newerLocalFiles = protoInt64.zero;
+
+  /**
+   * the conflicts, newer local files first, truncated if there are many.
+   *
+   * @generated from field: repeated v1.RestoreConflict conflicts = 4;
+   */
+  conflicts: RestoreConflict[] = [];
+
+  /**
+   * @generated from field: bool truncated = 5;
+   */
+  truncated = false;
+
+  /**
+   * identifies the full set of conflicts.
+   *
+   * @generated from field: string digest = 6;
+   */
+  digest = "";
+
+  /**
+   * local files that aren't in the snapshot, only checked for swap restores which remove them.
+   *
+   * @generated from field: int64 removed_files = 7;
+   */
+  removedFiles = protoInt64.zero;
+
+  constructor(data?: PartialMessage<RestoreConflictReport>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestoreConflictReport";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "files_checked", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "overwritten_files", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "newer_local_files", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "conflicts", kind: "message", T: RestoreConflict, repeated: true },
+    { no: 5, name: "truncated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "digest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "removed_files", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreConflictReport {
+    return new RestoreConflictReport().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreConflictReport {
+    return new RestoreConflictReport().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreConflictReport {
+    return new RestoreConflictReport().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestoreConflictReport | PlainMessage<RestoreConflictReport> | undefined, b: RestoreConflictReport | PlainMessage<RestoreConflictReport> | undefined): boolean {
+    return proto3.util.equals(RestoreConflictReport, a, b);
+  }
+}
+
+/**
+ * RestorePreview describes what a restore would write and the problems it would run into.
+ *
+ * @generated from message v1.RestorePreview
+ */
+export class RestorePreview extends Message<RestorePreview> {
+  /**
+   * the directory the snapshot's paths would be restored under, "/" for in place restores.
+   *
+   * @generated from field: string target = 1;
+   */
+  target = "";
+
+  /**
+   * @generated from field: int64 total_files = 2;
+   */
+  totalFiles = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 total_dirs = 3;
+   */
+  totalDirs = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 total_bytes = 4;
+   */
+  totalBytes = protoInt64.zero;
+
+  /**
+   * the files that would be written sorted by path, truncated if there are many.
+   *
+   * @generated from field: repeated v1.RestorePreviewFile files = 5;
+   */
+  files: RestorePreviewFile[] = [];
+
+  /**
+   * @generated from field: bool truncated = 6;
+   */
+  truncated = false;
+
+  /**
+   * @generated from field: repeated v1.RestoreProblem problems = 7;
+   */
+  problems: RestoreProblem[] = [];
+
+  /**
+   * estimated from the throughput of the repo's previous restores, 0 if there were none.
+   *
+   * @generated from field: int64 estimated_duration_ms = 8;
+   */
+  estimatedDurationMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<RestorePreview>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestorePreview";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "target", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "total_files", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "total_dirs", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "total_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "files", kind: "message", T: RestorePreviewFile, repeated: true },
+    { no: 6, name: "truncated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "problems", kind: "message", T: RestoreProblem, repeated: true },
+    { no: 8, name: "estimated_duration_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestorePreview {
+    return new RestorePreview().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestorePreview {
+    return new RestorePreview().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestorePreview {
+    return new RestorePreview().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestorePreview | PlainMessage<RestorePreview> | undefined, b: RestorePreview | PlainMessage<RestorePreview> | undefined): boolean {
+    return proto3.util.equals(RestorePreview, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RestorePreviewFile
+ */
+export class RestorePreviewFile extends Message<RestorePreviewFile> {
+  /**
+   * path the file would be written to.
+   *
+   * @generated from field: string path = 1;
+   */
+  path = "";
+
+  /**
+   * @generated from field: int64 size = 2;
+   */
+  size = protoInt64.zero;
+
+  /**
+   * a local file would be overwritten.
+   *
+   * @generated from field: bool overwrites = 3;
+   */
+  overwrites = false;
+
+  constructor(data?: PartialMessage<RestorePreviewFile>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestorePreviewFile";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "overwrites", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestorePreviewFile {
+    return new RestorePreviewFile().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestorePreviewFile {
+    return new RestorePreviewFile().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestorePreviewFile {
+    return new RestorePreviewFile().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestorePreviewFile | PlainMessage<RestorePreviewFile> | undefined, b: RestorePreviewFile | PlainMessage<RestorePreviewFile> | undefined): boolean {
+    return proto3.util.equals(RestorePreviewFile, a, b);
+  }
+}
+
+/**
+ * RestoreProblem is a problem a restore would run into, problems of the same kind in the same directory are reported once.
+ *
+ * @generated from message v1.RestoreProblem
+ */
+export class RestoreProblem extends Message<RestoreProblem> {
+  /**
+   * @generated from field: v1.RestoreProblem.Kind kind = 1;
+   */
+  kind = RestoreProblem_Kind.UNKNOWN;
+
+  /**
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message = "";
+
+  /**
+   * number of files affected.
+   *
+   * @generated from field: int64 files = 4;
+   */
+  files = protoInt64.zero;
+
+  constructor(data?: PartialMessage<RestoreProblem>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestoreProblem";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "enum", T: proto3.getEnumType(RestoreProblem_Kind) },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "files", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreProblem {
+    return new RestoreProblem().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreProblem {
+    return new RestoreProblem().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreProblem {
+    return new RestoreProblem().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestoreProblem | PlainMessage<RestoreProblem> | undefined, b: RestoreProblem | PlainMessage<RestoreProblem> | undefined): boolean {
+    return proto3.util.equals(RestoreProblem, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.RestoreProblem.Kind
+ */
+export enum RestoreProblem_Kind {
+  /**
+   * @generated from enum value: KIND_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * the directory or file can't be written by backrest.
+   *
+   * @generated from enum value: KIND_NOT_WRITABLE = 1;
+   */
+  NOT_WRITABLE = 1,
+
+  /**
+   * the directory or file is on a filesystem mounted read-only.
+   *
+   * @generated from enum value: KIND_READ_ONLY_FILESYSTEM = 2;
+   */
+  READ_ONLY_FILESYSTEM = 2,
+
+  /**
+   * a file exists where the restore needs a directory.
+   *
+   * @generated from enum value: KIND_NOT_DIRECTORY = 3;
+   */
+  NOT_DIRECTORY = 3,
+
+  /**
+   * the directory doesn't exist, it would be created. Informational.
+   *
+   * @generated from enum value: KIND_MISSING_PARENT = 4;
+   */
+  MISSING_PARENT = 4,
+
+  /**
+   * backrest doesn't run as root, ownership of the files would not be restored.
+   *
+   * @generated from enum value: KIND_OWNERSHIP = 5;
+   */
+  OWNERSHIP = 5,
+
+  /**
+   * the filesystem has less free space than the restore needs.
+   *
+   * @generated from enum value: KIND_INSUFFICIENT_SPACE = 6;
+   */
+  INSUFFICIENT_SPACE = 6,
+}
+// Retrieve enum metadata with: proto3.getEnumType(RestoreProblem_Kind)
+proto3.util.setEnumType(RestoreProblem_Kind, "v1.RestoreProblem.Kind", [
+  { no: 0, name: "KIND_UNKNOWN" },
+  { no: 1, name: "KIND_NOT_WRITABLE" },
+  { no: 2, name: "KIND_READ_ONLY_FILESYSTEM" },
+  { no: 3, name: "KIND_NOT_DIRECTORY" },
+  { no: 4, name: "KIND_MISSING_PARENT" },
+  { no: 5, name: "KIND_OWNERSHIP" },
+  { no: 6, name: "KIND_INSUFFICIENT_SPACE" },
+]);
+
+/**
+ * @generated from message v1.SearchRequest
+ */
+export class SearchRequest extends Message<SearchRequest> {
+  /**
+   * required, case insensitive text matched against snapshot IDs, paths, error messages and snapshot tags.
+   *
+   * @generated from field: string query = 1;
+   */
+  query = "";
+
+  /**
+   * optional, only match operations started at or after this unix time in milliseconds.
+   *
+   * @generated from field: int64 after_ms = 2;
+   */
+  afterMs = protoInt64.zero;
+
+  /**
+   * optional, only match operations started before this unix time in milliseconds.
+   *
+   * @generated from field: int64 before_ms = 3;
+   */
+  beforeMs = protoInt64.zero;
+
+  /**
+   * optional, the maximum number of results, defaults to 100.
+   *
+   * @generated from field: int32 limit = 4;
+   */
+  limit = 0;
+
+  constructor(data?: PartialMessage<SearchRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SearchRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "query", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "after_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "before_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "limit", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SearchRequest {
+    return new SearchRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SearchRequest {
+    return new SearchRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SearchRequest {
+    return new SearchRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SearchRequest | PlainMessage<SearchRequest> | undefined, b: SearchRequest | PlainMessage<SearchRequest> | undefined): boolean {
+    return proto3.util.equals(SearchRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.SearchResult
+ */
+export class SearchResult extends Message<SearchResult> {
+  /**
+   * @generated from field: v1.SearchResult.Kind kind = 1;
+   */
+  kind = SearchResult_Kind.UNKNOWN;
+
+  /**
+   * @generated from field: v1.Operation operation = 2;
+   */
+  operation?: Operation;
+
+  /**
+   * the field that matched e.g. "snapshot_id", "path", "error", "tag", "annotation" or "note".
+   *
+   * @generated from field: string field = 3;
+   */
+  field = "";
+
+  /**
+   * the matching text with some of its surrounding context.
+   *
+   * @generated from field: string snippet = 4;
+   */
+  snippet = "";
+
+  /**
+   * link to the result in the web UI, relative to the UI's root.
+   *
+   * @generated from field: string link = 5;
+   */
+  link = "";
+
+  constructor(data?: PartialMessage<SearchResult>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SearchResult";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "enum", T: proto3.getEnumType(SearchResult_Kind) },
+    { no: 2, name: "operation", kind: "message", T: Operation },
+    { no: 3, name: "field", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "snippet", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "link", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SearchResult {
+    return new SearchResult().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SearchResult {
+    return new SearchResult().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SearchResult {
+    return new SearchResult().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SearchResult | PlainMessage<SearchResult> | undefined, b: SearchResult | PlainMessage<SearchResult> | undefined): boolean {
+    return proto3.util.equals(SearchResult, a, b);
+  }
+}
+
+/**
+ * @generated from enum v1.SearchResult.Kind
+ */
+export enum SearchResult_Kind {
+  /**
+   * @generated from enum value: KIND_UNKNOWN = 0;
+   */
+  UNKNOWN = 0,
+
+  /**
+   * @generated from enum value: KIND_OPERATION = 1;
+   */
+  OPERATION = 1,
+
+  /**
+   * a snapshot indexed by backrest, operation is the operation that indexed it.
+   *
+   * @generated from enum value: KIND_SNAPSHOT = 2;
+   */
+  SNAPSHOT = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(SearchResult_Kind)
+proto3.util.setEnumType(SearchResult_Kind, "v1.SearchResult.Kind", [
+  { no: 0, name: "KIND_UNKNOWN" },
+  { no: 1, name: "KIND_OPERATION" },
+  { no: 2, name: "KIND_SNAPSHOT" },
+]);
+
+/**
+ * @generated from message v1.SearchResponse
+ */
+export class SearchResponse extends Message<SearchResponse> {
+  /**
+   * @generated from field: repeated v1.SearchResult results = 1;
+   */
+  results: SearchResult[] = [];
+
+  /**
+   * more results matched than were returned.
+   *
+   * @generated from field: bool truncated = 2;
+   */
+  truncated = false;
+
+  constructor(data?: PartialMessage<SearchResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SearchResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "results", kind: "message", T: SearchResult, repeated: true },
+    { no: 2, name: "truncated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SearchResponse {
+    return new SearchResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SearchResponse {
+    return new SearchResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SearchResponse {
+    return new SearchResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SearchResponse | PlainMessage<SearchResponse> | undefined, b: SearchResponse | PlainMessage<SearchResponse> | undefined): boolean {
+    return proto3.util.equals(SearchResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RepoStatsHistoryRequest
+ */
+export class RepoStatsHistoryRequest extends Message<RepoStatsHistoryRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * optional, only return stats collected at or after this unix time in milliseconds.
+   *
+   * @generated from field: int64 after_ms = 2;
+   */
+  afterMs = protoInt64.zero;
+
+  /**
+   * optional, the maximum number of entries, the newest are returned. Defaults to 365.
+   *
+   * @generated from field: int32 limit = 3;
+   */
+  limit = 0;
+
+  constructor(data?: PartialMessage<RepoStatsHistoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoStatsHistoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "after_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "limit", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoStatsHistoryRequest {
+    return new RepoStatsHistoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoStatsHistoryRequest {
+    return new RepoStatsHistoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoStatsHistoryRequest {
+    return new RepoStatsHistoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoStatsHistoryRequest | PlainMessage<RepoStatsHistoryRequest> | undefined, b: RepoStatsHistoryRequest | PlainMessage<RepoStatsHistoryRequest> | undefined): boolean {
+    return proto3.util.equals(RepoStatsHistoryRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RepoStatsHistory
+ */
+export class RepoStatsHistory extends Message<RepoStatsHistory> {
+  /**
+   * oldest first.
+   *
+   * @generated from field: repeated v1.RepoStatsHistory.Entry entries = 1;
+   */
+  entries: RepoStatsHistory_Entry[] = [];
+
+  constructor(data?: PartialMessage<RepoStatsHistory>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoStatsHistory";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "entries", kind: "message", T: RepoStatsHistory_Entry, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoStatsHistory {
+    return new RepoStatsHistory().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoStatsHistory {
+    return new RepoStatsHistory().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoStatsHistory {
+    return new RepoStatsHistory().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoStatsHistory | PlainMessage<RepoStatsHistory> | undefined, b: RepoStatsHistory | PlainMessage<RepoStatsHistory> | undefined): boolean {
+    return proto3.util.equals(RepoStatsHistory, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RepoStatsHistory.Entry
+ */
+export class RepoStatsHistory_Entry extends Message<RepoStatsHistory_Entry> {
+  /**
+   * when the stats were collected.
+   *
+   * @generated from field: int64 unix_time_ms = 1;
+   */
+  unixTimeMs = protoInt64.zero;
+
+  /**
+   * the stats operation that collected them.
+   *
+   * @generated from field: int64 operation_id = 2;
+   */
+  operationId = protoInt64.zero;
+
+  /**
+   * @generated from field: v1.RepoStats stats = 3;
+   */
+  stats?: RepoStats;
+
+  constructor(data?: PartialMessage<RepoStatsHistory_Entry>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RepoStatsHistory.Entry";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "unix_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "operation_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "stats", kind: "message", T: RepoStats },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RepoStatsHistory_Entry {
+    return new RepoStatsHistory_Entry().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RepoStatsHistory_Entry {
+    return new RepoStatsHistory_Entry().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RepoStatsHistory_Entry {
+    return new RepoStatsHistory_Entry().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RepoStatsHistory_Entry | PlainMessage<RepoStatsHistory_Entry> | undefined, b: RepoStatsHistory_Entry | PlainMessage<RepoStatsHistory_Entry> | undefined): boolean {
+    return proto3.util.equals(RepoStatsHistory_Entry, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RestoreDownloadURLRequest
+ */
+export class RestoreDownloadURLRequest extends Message<RestoreDownloadURLRequest> {
+  /**
+   * @generated from field: int64 restore_op_id = 1;
+   */
+  restoreOpId = protoInt64.zero;
+
+  /**
+   * optional, relative to the restore's target. Defaults to the whole target.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * optional, the URL is rejected after its first download. Downloads of it can't be resumed.
+   *
+   * @generated from field: bool single_use = 3;
+   */
+  singleUse = false;
+
+  constructor(data?: PartialMessage<RestoreDownloadURLRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RestoreDownloadURLRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "restore_op_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "single_use", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RestoreDownloadURLRequest {
+    return new RestoreDownloadURLRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RestoreDownloadURLRequest {
+    return new RestoreDownloadURLRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RestoreDownloadURLRequest {
+    return new RestoreDownloadURLRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RestoreDownloadURLRequest | PlainMessage<RestoreDownloadURLRequest> | undefined, b: RestoreDownloadURLRequest | PlainMessage<RestoreDownloadURLRequest> | undefined): boolean {
+    return proto3.util.equals(RestoreDownloadURLRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.GetStatsRequest
+ */
+export class GetStatsRequest extends Message<GetStatsRequest> {
+  /**
+   * optional, only return the stats of this plan. Defaults to every plan.
+   *
+   * @generated from field: string plan_id = 1;
+   */
+  planId = "";
+
+  /**
+   * optional, only aggregate backups started at or after this unix time in milliseconds. Defaults to 30 days ago.
+   *
+   * @generated from field: int64 after_ms = 2;
+   */
+  afterMs = protoInt64.zero;
+
+  /**
+   * optional, the IANA timezone days are bucketed in. Defaults to the server's local time.
+   *
+   * @generated from field: string timezone = 3;
+   */
+  timezone = "";
+
+  constructor(data?: PartialMessage<GetStatsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.GetStatsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "after_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "timezone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetStatsRequest {
+    return new GetStatsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetStatsRequest {
+    return new GetStatsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetStatsRequest {
+    return new GetStatsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetStatsRequest | PlainMessage<GetStatsRequest> | undefined, b: GetStatsRequest | PlainMessage<GetStatsRequest> | undefined): boolean {
+    return proto3.util.equals(GetStatsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.PlanStats
+ */
+export class PlanStats extends Message<PlanStats> {
+  /**
+   * @generated from field: string plan_id = 1;
+   */
+  planId = "";
+
+  /**
+   * @generated from field: string repo_id = 2;
+   */
+  repoId = "";
+
+  /**
+   * backups that succeeded, including those that completed with warnings.
+   *
+   * @generated from field: int32 success_count = 3;
+   */
+  successCount = 0;
+
+  /**
+   * backups that completed with warnings.
+   *
+   * @generated from field: int32 warning_count = 4;
+   */
+  warningCount = 0;
+
+  /**
+   * @generated from field: int32 failure_count = 5;
+   */
+  failureCount = 0;
+
+  /**
+   * @generated from field: int32 cancelled_count = 6;
+   */
+  cancelledCount = 0;
+
+  /**
+   * of the successful backups.
+   *
+   * @generated from field: int64 avg_duration_ms = 7;
+   */
+  avgDurationMs = protoInt64.zero;
+
+  /**
+   * of the successful backups.
+   *
+   * @generated from field: int64 p95_duration_ms = 8;
+   */
+  p95DurationMs = protoInt64.zero;
+
+  /**
+   * by the successful backups.
+   *
+   * @generated from field: int64 bytes_added = 9;
+   */
+  bytesAdded = protoInt64.zero;
+
+  /**
+   * the days backups started on, oldest first.
+   *
+   * @generated from field: repeated v1.PlanStats.Day days = 10;
+   */
+  days: PlanStats_Day[] = [];
+
+  /**
+   * when the last successful backup finished, including backups before after_ms. 0 if there is none.
+   *
+   * @generated from field: int64 last_success_ms = 11;
+   */
+  lastSuccessMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<PlanStats>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.PlanStats";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "success_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "warning_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "failure_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "cancelled_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 7, name: "avg_duration_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "p95_duration_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "bytes_added", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 10, name: "days", kind: "message", T: PlanStats_Day, repeated: true },
+    { no: 11, name: "last_success_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PlanStats {
+    return new PlanStats().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PlanStats {
+    return new PlanStats().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PlanStats {
+    return new PlanStats().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PlanStats | PlainMessage<PlanStats> | undefined, b: PlanStats | PlainMessage<PlanStats> | undefined): boolean {
+    return proto3.util.equals(PlanStats, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.PlanStats.Day
+ */
+export class PlanStats_Day extends Message<PlanStats_Day> {
+  /**
+   * the start of the day.
+   *
+   * @generated from field: int64 unix_time_ms = 1;
+   */
+  unixTimeMs = protoInt64.zero;
+
+  /**
+   * @generated from field: int32 backups = 2;
+   */
+  backups = 0;
+
+  /**
+   * @generated from field: int64 bytes_added = 3;
+   */
+  bytesAdded = protoInt64.zero;
+
+  constructor(data?: PartialMessage<PlanStats_Day>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.PlanStats.Day";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "unix_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "backups", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "bytes_added", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PlanStats_Day {
+    return new PlanStats_Day().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PlanStats_Day {
+    return new PlanStats_Day().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PlanStats_Day {
+    return new PlanStats_Day().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PlanStats_Day | PlainMessage<PlanStats_Day> | undefined, b: PlanStats_Day | PlainMessage<PlanStats_Day> | undefined): boolean {
+    return proto3.util.equals(PlanStats_Day, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.PlanStatsList
+ */
+export class PlanStatsList extends Message<PlanStatsList> {
+  /**
+   * @generated from field: repeated v1.PlanStats plans = 1;
+   */
+  plans: PlanStats[] = [];
+
+  constructor(data?: PartialMessage<PlanStatsList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.PlanStatsList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plans", kind: "message", T: PlanStats, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PlanStatsList {
+    return new PlanStatsList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PlanStatsList {
+    return new PlanStatsList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PlanStatsList {
+    return new PlanStatsList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PlanStatsList | PlainMessage<PlanStatsList> | undefined, b: PlanStatsList | PlainMessage<PlanStatsList> | undefined): boolean {
+    return proto3.util.equals(PlanStatsList, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ListSnapshotFilesRequest
+ */
+export class ListSnapshotFilesRequest extends Message<ListSnapshotFilesRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId = "";
+
+  /**
+   * @generated from field: string path = 3;
+   */
+  path = "";
+
+  constructor(data?: PartialMessage<ListSnapshotFilesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ListSnapshotFilesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListSnapshotFilesRequest {
+    return new ListSnapshotFilesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListSnapshotFilesRequest {
+    return new ListSnapshotFilesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListSnapshotFilesRequest {
+    return new ListSnapshotFilesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListSnapshotFilesRequest | PlainMessage<ListSnapshotFilesRequest> | undefined, b: ListSnapshotFilesRequest | PlainMessage<ListSnapshotFilesRequest> | undefined): boolean {
+    return proto3.util.equals(ListSnapshotFilesRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.DiffSnapshotsRequest
+ */
+export class DiffSnapshotsRequest extends Message<DiffSnapshotsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId = "";
+
+  /**
+   * defaults to the snapshot's parent.
+   *
+   * @generated from field: string base_snapshot_id = 3;
+   */
+  baseSnapshotId = "";
+
+  constructor(data?: PartialMessage<DiffSnapshotsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.DiffSnapshotsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "base_snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffSnapshotsRequest {
+    return new DiffSnapshotsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffSnapshotsRequest {
+    return new DiffSnapshotsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffSnapshotsRequest {
+    return new DiffSnapshotsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffSnapshotsRequest | PlainMessage<DiffSnapshotsRequest> | undefined, b: DiffSnapshotsRequest | PlainMessage<DiffSnapshotsRequest> | undefined): boolean {
+    return proto3.util.equals(DiffSnapshotsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.SearchSnapshotsRequest
+ */
+export class SearchSnapshotsRequest extends Message<SearchSnapshotsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * required, glob pattern matched against file names e.g. "*.jpg" or "config.json".
+   *
+   * @generated from field: string pattern = 2;
+   */
+  pattern = "";
+
+  /**
+   * optional, only search these snapshots.
+   *
+   * @generated from field: repeated string snapshot_ids = 3;
+   */
+  snapshotIds: string[] = [];
+
+  /**
+   * optional, only search the plan's snapshots.
+   *
+   * @generated from field: string plan_id = 4;
+   */
+  planId = "";
+
+  /**
+   * @generated from field: bool ignore_case = 5;
+   */
+  ignoreCase = false;
+
+  /**
+   * optional, the maximum number of matches, defaults to 1000.
+   *
+   * @generated from field: int32 limit = 6;
+   */
+  limit = 0;
+
+  constructor(data?: PartialMessage<SearchSnapshotsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SearchSnapshotsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "pattern", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "snapshot_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "ignore_case", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "limit", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SearchSnapshotsRequest {
+    return new SearchSnapshotsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SearchSnapshotsRequest {
+    return new SearchSnapshotsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SearchSnapshotsRequest {
+    return new SearchSnapshotsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SearchSnapshotsRequest | PlainMessage<SearchSnapshotsRequest> | undefined, b: SearchSnapshotsRequest | PlainMessage<SearchSnapshotsRequest> | undefined): boolean {
+    return proto3.util.equals(SearchSnapshotsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.SnapshotFileMatch
+ */
+export class SnapshotFileMatch extends Message<SnapshotFileMatch> {
+  /**
+   * @generated from field: string snapshot_id = 1;
+   */
+  snapshotId = "";
+
+  /**
+   * @generated from field: v1.LsEntry entry = 2;
+   */
+  entry?: LsEntry;
+
+  constructor(data?: PartialMessage<SnapshotFileMatch>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.SnapshotFileMatch";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "entry", kind: "message", T: LsEntry },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SnapshotFileMatch {
+    return new SnapshotFileMatch().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SnapshotFileMatch {
+    return new SnapshotFileMatch().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SnapshotFileMatch {
+    return new SnapshotFileMatch().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SnapshotFileMatch | PlainMessage<SnapshotFileMatch> | undefined, b: SnapshotFileMatch | PlainMessage<SnapshotFileMatch> | undefined): boolean {
+    return proto3.util.equals(SnapshotFileMatch, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ListFileVersionsRequest
+ */
+export class ListFileVersionsRequest extends Message<ListFileVersionsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * required, the plan whose snapshots are searched.
+   *
+   * @generated from field: string plan_id = 2;
+   */
+  planId = "";
+
+  /**
+   * required, the absolute path of the file in the snapshots.
+   *
+   * @generated from field: string path = 3;
+   */
+  path = "";
+
+  constructor(data?: PartialMessage<ListFileVersionsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ListFileVersionsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListFileVersionsRequest {
+    return new ListFileVersionsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListFileVersionsRequest {
+    return new ListFileVersionsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListFileVersionsRequest {
+    return new ListFileVersionsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListFileVersionsRequest | PlainMessage<ListFileVersionsRequest> | undefined, b: ListFileVersionsRequest | PlainMessage<ListFileVersionsRequest> | undefined): boolean {
+    return proto3.util.equals(ListFileVersionsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.FileVersion
+ */
+export class FileVersion extends Message<FileVersion> {
+  /**
+   * @generated from field: string snapshot_id = 1;
+   */
+  snapshotId = "";
+
+  /**
+   * @generated from field: int64 snapshot_time_ms = 2;
+   */
+  snapshotTimeMs = protoInt64.zero;
+
+  /**
+   * the file as it was in the snapshot, holding its size and mtime.
+   *
+   * @generated from field: v1.LsEntry entry = 3;
+   */
+  entry?: LsEntry;
+
+  /**
+   * the file's size or mtime differs from the next older snapshot holding it, always set for the oldest version.
+   *
+   * @generated from field: bool changed = 4;
+   */
+  changed = false;
+
+  constructor(data?: PartialMessage<FileVersion>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.FileVersion";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot_time_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "entry", kind: "message", T: LsEntry },
+    { no: 4, name: "changed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FileVersion {
+    return new FileVersion().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): FileVersion {
+    return new FileVersion().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): FileVersion {
+    return new FileVersion().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: FileVersion | PlainMessage<FileVersion> | undefined, b: FileVersion | PlainMessage<FileVersion> | undefined): boolean {
+    return proto3.util.equals(FileVersion, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.FileVersionList
+ */
+export class FileVersionList extends Message<FileVersionList> {
+  /**
+   * newest snapshot first, snapshots without the file are omitted.
+   *
+   * @generated from field: repeated v1.FileVersion versions = 1;
+   */
+  versions: FileVersion[] = [];
+
+  constructor(data?: PartialMessage<FileVersionList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.FileVersionList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "versions", kind: "message", T: FileVersion, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FileVersionList {
+    return new FileVersionList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): FileVersionList {
+    return new FileVersionList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): FileVersionList {
+    return new FileVersionList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: FileVersionList | PlainMessage<FileVersionList> | undefined, b: FileVersionList | PlainMessage<FileVersionList> | undefined): boolean {
+    return proto3.util.equals(FileVersionList, a, b);
+  }
+}
+
+/**
+ * BackupPreview is the summary of a plan's backup run with restic backup --dry-run.
+ *
+ * @generated from message v1.BackupPreview
+ */
+export class BackupPreview extends Message<BackupPreview> {
+  /**
+   * @generated from field: string plan_id = 1;
+   */
+  planId = "";
+
+  /**
+   * @generated from field: string repo_id = 2;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: int64 files_new = 3;
+   */
+  filesNew = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 files_changed = 4;
+   */
+  filesChanged = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 files_unmodified = 5;
+   */
+  filesUnmodified = protoInt64.zero;
+
+  /**
+   * data the backup would add to the repo, after deduplication and before compression.
+   *
+   * @generated from field: int64 bytes_added = 6;
+   */
+  bytesAdded = protoInt64.zero;
+
+  /**
+   * files the backup would read, excludes are already applied.
+   *
+   * @generated from field: int64 total_files = 7;
+   */
+  totalFiles = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 total_bytes = 8;
+   */
+  totalBytes = protoInt64.zero;
+
+  /**
+   * duration of the dry run, a backup takes at least as long to scan the plan's paths.
+   *
+   * @generated from field: int64 duration_ms = 9;
+   */
+  durationMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<BackupPreview>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.BackupPreview";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "files_new", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "files_changed", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "files_unmodified", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "bytes_added", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "total_files", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "total_bytes", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "duration_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BackupPreview {
+    return new BackupPreview().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BackupPreview {
+    return new BackupPreview().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BackupPreview {
+    return new BackupPreview().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BackupPreview | PlainMessage<BackupPreview> | undefined, b: BackupPreview | PlainMessage<BackupPreview> | undefined): boolean {
+    return proto3.util.equals(BackupPreview, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.TagSnapshotsRequest
+ */
+export class TagSnapshotsRequest extends Message<TagSnapshotsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * optional, the plan the tag operation is shown under.
+   *
+   * @generated from field: string plan_id = 2;
+   */
+  planId = "";
+
+  /**
+   * required, the snapshots to tag.
+   *
+   * @generated from field: repeated string snapshot_ids = 3;
+   */
+  snapshotIds: string[] = [];
+
+  /**
+   * tags to add to the snapshots.
+   *
+   * @generated from field: repeated string add = 4;
+   */
+  add: string[] = [];
+
+  /**
+   * tags to remove from the snapshots.
+   *
+   * @generated from field: repeated string remove = 5;
+   */
+  remove: string[] = [];
+
+  constructor(data?: PartialMessage<TagSnapshotsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.TagSnapshotsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "snapshot_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "add", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "remove", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TagSnapshotsRequest {
+    return new TagSnapshotsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TagSnapshotsRequest {
+    return new TagSnapshotsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TagSnapshotsRequest {
+    return new TagSnapshotsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: TagSnapshotsRequest | PlainMessage<TagSnapshotsRequest> | undefined, b: TagSnapshotsRequest | PlainMessage<TagSnapshotsRequest> | undefined): boolean {
+    return proto3.util.equals(TagSnapshotsRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.RewriteSnapshotsRequest
+ */
+export class RewriteSnapshotsRequest extends Message<RewriteSnapshotsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * optional, the plan the rewrite operation is shown under.
+   *
+   * @generated from field: string plan_id = 2;
+   */
+  planId = "";
+
+  /**
+   * required, the snapshots to rewrite.
+   *
+   * @generated from field: repeated string snapshot_ids = 3;
+   */
+  snapshotIds: string[] = [];
+
+  /**
+   * required, patterns of the files to remove in restic's --exclude format.
+   *
+   * @generated from field: repeated string excludes = 4;
+   */
+  excludes: string[] = [];
+
+  /**
+   * forget the original snapshots once rewritten, their data is removed by the next prune.
+   *
+   * @generated from field: bool forget = 5;
+   */
+  forget = false;
+
+  /**
+   * the digest of the preview the user confirmed, a rewrite is rejected if what it would change differs from the preview.
+   *
+   * @generated from field: string confirm_digest = 6;
+   */
+  confirmDigest = "";
+
+  constructor(data?: PartialMessage<RewriteSnapshotsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RewriteSnapshotsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "snapshot_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "excludes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "forget", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "confirm_digest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RewriteSnapshotsRequest {
+    return new RewriteSnapshotsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RewriteSnapshotsRequest {
+    return new RewriteSnapshotsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RewriteSnapshotsRequest {
+    return new RewriteSnapshotsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RewriteSnapshotsRequest | PlainMessage<RewriteSnapshotsRequest> | undefined, b: RewriteSnapshotsRequest | PlainMessage<RewriteSnapshotsRequest> | undefined): boolean {
+    return proto3.util.equals(RewriteSnapshotsRequest, a, b);
+  }
+}
+
+/**
+ * RewritePreview is the result of a dry run of a rewrite.
+ *
+ * @generated from message v1.RewritePreview
+ */
+export class RewritePreview extends Message<RewritePreview> {
+  /**
+   * number of snapshots that hold excluded files and would be saved under new IDs.
+   *
+   * @generated from field: int32 snapshots_rewritten = 1;
+   */
+  snapshotsRewritten = 0;
+
+  /**
+   * output of the dry run, listing the files that would be removed.
+   *
+   * @generated from field: string output = 2;
+   */
+  output = "";
+
+  /**
+   * identifies the preview, passed to RewriteSnapshots to confirm it.
+   *
+   * @generated from field: string digest = 3;
+   */
+  digest = "";
+
+  constructor(data?: PartialMessage<RewritePreview>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.RewritePreview";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "snapshots_rewritten", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "output", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "digest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RewritePreview {
+    return new RewritePreview().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RewritePreview {
+    return new RewritePreview().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RewritePreview {
+    return new RewritePreview().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RewritePreview | PlainMessage<RewritePreview> | undefined, b: RewritePreview | PlainMessage<RewritePreview> | undefined): boolean {
+    return proto3.util.equals(RewritePreview, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.GetSnapshotStatsRequest
+ */
+export class GetSnapshotStatsRequest extends Message<GetSnapshotStatsRequest> {
+  /**
+   * @generated from field: string repo_id = 1;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string snapshot_id = 2;
+   */
+  snapshotId = "";
+
+  constructor(data?: PartialMessage<GetSnapshotStatsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.GetSnapshotStatsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "snapshot_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetSnapshotStatsRequest {
+    return new GetSnapshotStatsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetSnapshotStatsRequest {
+    return new GetSnapshotStatsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetSnapshotStatsRequest {
+    return new GetSnapshotStatsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetSnapshotStatsRequest | PlainMessage<GetSnapshotStatsRequest> | undefined, b: GetSnapshotStatsRequest | PlainMessage<GetSnapshotStatsRequest> | undefined): boolean {
+    return proto3.util.equals(GetSnapshotStatsRequest, a, b);
   }
 }
 
@@ -0,0 +1,301 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file v1/sharelink.proto (package v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+
+/**
+ * ShareLink lets anyone holding its URL and passphrase download a directory or file of a restore, without access to
+ * the UI. Links expire, may be limited to a number of downloads and can be revoked.
+ *
+ * @generated from message v1.ShareLink
+ */
+export class ShareLink extends Message<ShareLink> {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  /**
+   * the restore operation whose target holds the shared directory.
+   *
+   * @generated from field: int64 restore_op_id = 2;
+   */
+  restoreOpId = protoInt64.zero;
+
+  /**
+   * @generated from field: string repo_id = 3;
+   */
+  repoId = "";
+
+  /**
+   * @generated from field: string plan_id = 4;
+   */
+  planId = "";
+
+  /**
+   * the shared directory or file relative to the restore's target, "" for the whole restore.
+   *
+   * @generated from field: string path = 5;
+   */
+  path = "";
+
+  /**
+   * the user that created the link.
+   *
+   * @generated from field: string created_by = 6;
+   */
+  createdBy = "";
+
+  /**
+   * @generated from field: int64 created_ms = 7;
+   */
+  createdMs = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 expires_ms = 8;
+   */
+  expiresMs = protoInt64.zero;
+
+  /**
+   * 0 for no limit.
+   *
+   * @generated from field: int32 max_downloads = 9;
+   */
+  maxDownloads = 0;
+
+  /**
+   * @generated from field: int32 downloads = 10;
+   */
+  downloads = 0;
+
+  /**
+   * @generated from field: int64 last_download_ms = 11;
+   */
+  lastDownloadMs = protoInt64.zero;
+
+  /**
+   * wrong passphrases entered, the link is revoked once too many are.
+   *
+   * @generated from field: int32 failed_attempts = 12;
+   */
+  failedAttempts = 0;
+
+  /**
+   * set once the link is revoked.
+   *
+   * @generated from field: int64 revoked_ms = 13;
+   */
+  revokedMs = protoInt64.zero;
+
+  /**
+   * SHA-256 of the token in the link's URL, omitted by the API.
+   *
+   * @generated from field: bytes token_hash = 14;
+   */
+  tokenHash = new Uint8Array(0);
+
+  /**
+   * base64 encoded bcrypt hash of the passphrase, omitted by the API.
+   *
+   * @generated from field: string passphrase_bcrypt = 15;
+   */
+  passphraseBcrypt = "";
+
+  constructor(data?: PartialMessage<ShareLink>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ShareLink";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "restore_op_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "repo_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "plan_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "created_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "created_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "expires_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "max_downloads", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 10, name: "downloads", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 11, name: "last_download_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 12, name: "failed_attempts", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 13, name: "revoked_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 14, name: "token_hash", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 15, name: "passphrase_bcrypt", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ShareLink {
+    return new ShareLink().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ShareLink {
+    return new ShareLink().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ShareLink {
+    return new ShareLink().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ShareLink | PlainMessage<ShareLink> | undefined, b: ShareLink | PlainMessage<ShareLink> | undefined): boolean {
+    return proto3.util.equals(ShareLink, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.ShareLinkList
+ */
+export class ShareLinkList extends Message<ShareLinkList> {
+  /**
+   * newest first.
+   *
+   * @generated from field: repeated v1.ShareLink links = 1;
+   */
+  links: ShareLink[] = [];
+
+  constructor(data?: PartialMessage<ShareLinkList>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.ShareLinkList";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "links", kind: "message", T: ShareLink, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ShareLinkList {
+    return new ShareLinkList().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ShareLinkList {
+    return new ShareLinkList().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ShareLinkList {
+    return new ShareLinkList().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ShareLinkList | PlainMessage<ShareLinkList> | undefined, b: ShareLinkList | PlainMessage<ShareLinkList> | undefined): boolean {
+    return proto3.util.equals(ShareLinkList, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.CreateShareLinkRequest
+ */
+export class CreateShareLinkRequest extends Message<CreateShareLinkRequest> {
+  /**
+   * @generated from field: int64 restore_op_id = 1;
+   */
+  restoreOpId = protoInt64.zero;
+
+  /**
+   * directory or file relative to the restore's target, "" shares the whole restore.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * at least 8 characters, guests must enter it to download.
+   *
+   * @generated from field: string passphrase = 3;
+   */
+  passphrase = "";
+
+  /**
+   * how long the link is valid for, defaults to 7 days and is at most 30 days.
+   *
+   * @generated from field: int64 ttl_seconds = 4;
+   */
+  ttlSeconds = protoInt64.zero;
+
+  /**
+   * 0 for no limit.
+   *
+   * @generated from field: int32 max_downloads = 5;
+   */
+  maxDownloads = 0;
+
+  constructor(data?: PartialMessage<CreateShareLinkRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.CreateShareLinkRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "restore_op_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "passphrase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "ttl_seconds", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "max_downloads", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateShareLinkRequest {
+    return new CreateShareLinkRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateShareLinkRequest {
+    return new CreateShareLinkRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateShareLinkRequest {
+    return new CreateShareLinkRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateShareLinkRequest | PlainMessage<CreateShareLinkRequest> | undefined, b: CreateShareLinkRequest | PlainMessage<CreateShareLinkRequest> | undefined): boolean {
+    return proto3.util.equals(CreateShareLinkRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message v1.CreateShareLinkResponse
+ */
+export class CreateShareLinkResponse extends Message<CreateShareLinkResponse> {
+  /**
+   * @generated from field: v1.ShareLink link = 1;
+   */
+  link?: ShareLink;
+
+  /**
+   * relative to the UI's URL, only returned when the link is created.
+   *
+   * @generated from field: string url = 2;
+   */
+  url = "";
+
+  constructor(data?: PartialMessage<CreateShareLinkResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "v1.CreateShareLinkResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "link", kind: "message", T: ShareLink },
+    { no: 2, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateShareLinkResponse {
+    return new CreateShareLinkResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateShareLinkResponse {
+    return new CreateShareLinkResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateShareLinkResponse {
+    return new CreateShareLinkResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateShareLinkResponse | PlainMessage<CreateShareLinkResponse> | undefined, b: CreateShareLinkResponse | PlainMessage<CreateShareLinkResponse> | undefined): boolean {
+    return proto3.util.equals(CreateShareLinkResponse, a, b);
+  }
+}
+
@@ -1,6 +1,6 @@
 import { useMemo } from "react";
 import { createConnectTransport } from "@connectrpc/connect-web";
-import { createPromiseClient } from "@connectrpc/connect";
+import { Code, ConnectError, Interceptor, createPromiseClient } from "@connectrpc/connect";
 import { Backrest } from "../gen/ts/v1/service_connect";
 import { Authentication } from "../gen/ts/v1/authentication_connect";
 
@@ -23,10 +23,33 @@ const fetch = (
   return window.fetch(input, init);
 };
 
+const maxBackendRetries = 3;
+
+// retryExhausted retries unary requests the server rejected because too many requests were reading from a repo's
+// backend, waiting for the server's Retry-After hint between attempts.
+const retryExhausted: Interceptor = (next) => async (req) => {
+  if (req.stream) {
+    return next(req);
+  }
+  for (let attempt = 0; ; attempt++) {
+    try {
+      return await next(req);
+    } catch (e: any) {
+      const err = ConnectError.from(e);
+      if (err.code !== Code.ResourceExhausted || attempt >= maxBackendRetries || req.signal.aborted) {
+        throw err;
+      }
+      const seconds = parseInt(err.metadata.get("Retry-After") || "", 10) || 5;
+      await new Promise((resolve) => setTimeout(resolve, seconds * 1000 * (attempt + 1)));
+    }
+  }
+};
+
 const transport = createConnectTransport({
   baseUrl: "./",
   useBinaryFormat: true,
   fetch: fetch as typeof globalThis.fetch,
+  interceptors: [retryExhausted],
 });
 
 export const authenticationService = createPromiseClient(
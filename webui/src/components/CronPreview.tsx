@@ -0,0 +1,62 @@
+import React, { useEffect, useState } from "react";
+import { Input, Typography } from "antd";
+import { ValidateCronRequest } from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { formatTime } from "../lib/formatting";
+
+const PREVIEW_COUNT = 5;
+
+// CronPreview edits a cron expression as text, which allows @ shorthands and a leading seconds field, and previews the
+// next times it fires.
+export const CronPreview = ({
+  expr,
+  onChange,
+}: {
+  expr: string;
+  onChange: (expr: string) => void;
+}) => {
+  const [description, setDescription] = useState<string | null>(null);
+  const [nextTimes, setNextTimes] = useState<number[]>([]);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!expr) {
+      setDescription(null);
+      setNextTimes([]);
+      setError(null);
+      return;
+    }
+    const timeout = setTimeout(() => {
+      backrestService
+        .validateCron(new ValidateCronRequest({ expr, count: PREVIEW_COUNT }))
+        .then((res) => {
+          setDescription(res.description);
+          setNextTimes(res.nextUnixTimeMs.map((t) => Number(t)));
+          setError(null);
+        })
+        .catch((e: any) => {
+          setDescription(null);
+          setNextTimes([]);
+          setError(e.message);
+        });
+    }, 300);
+    return () => clearTimeout(timeout);
+  }, [expr]);
+
+  return (
+    <>
+      <Input
+        value={expr}
+        placeholder="e.g. 0 2 * * *, @daily or */30 * * * * * with seconds"
+        onChange={(e) => onChange(e.target.value)}
+        status={error ? "error" : undefined}
+      />
+      {error ? <Typography.Text type="danger">{error}</Typography.Text> : null}
+      {description ? (
+        <Typography.Text type="secondary">
+          Runs {description}, next at {nextTimes.map((t) => formatTime(t)).join(", ")}
+        </Typography.Text>
+      ) : null}
+    </>
+  );
+};
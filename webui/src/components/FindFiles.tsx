@@ -0,0 +1,96 @@
+import React, { useEffect, useRef, useState } from "react";
+import { Button, Checkbox, Empty, Flex, Input, Table, Typography } from "antd";
+import { SearchSnapshotsRequest, SnapshotFileMatch } from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { formatBytes, formatTime, normalizeSnapshotId } from "../lib/formatting";
+
+// FindFiles searches every snapshot of a repo for files whose name matches a glob pattern, results are shown as the
+// server finds them.
+export const FindFiles = ({ repoId, planId }: { repoId: string; planId?: string }) => {
+  const alertApi = useAlertApi();
+  const [pattern, setPattern] = useState("");
+  const [ignoreCase, setIgnoreCase] = useState(true);
+  const [matches, setMatches] = useState<SnapshotFileMatch[] | null>(null);
+  const [searching, setSearching] = useState(false);
+  const abort = useRef<AbortController | null>(null);
+
+  useEffect(() => () => abort.current?.abort(), []);
+
+  const search = async () => {
+    abort.current?.abort();
+    const controller = new AbortController();
+    abort.current = controller;
+    setSearching(true);
+    setMatches([]);
+    try {
+      let found: SnapshotFileMatch[] = [];
+      for await (const match of backrestService.searchSnapshots(
+        new SearchSnapshotsRequest({ repoId, planId, pattern, ignoreCase }),
+        { signal: controller.signal }
+      )) {
+        found = [...found, match];
+        setMatches(found);
+      }
+    } catch (e: any) {
+      if (!controller.signal.aborted) {
+        alertApi?.error("Search failed: " + e.message);
+      }
+    } finally {
+      if (abort.current === controller) {
+        setSearching(false);
+      }
+    }
+  };
+
+  return (
+    <>
+      <Flex gap="small" align="center" style={{ marginBottom: "1em" }}>
+        <Input
+          placeholder="File name or glob pattern e.g. *.jpg"
+          value={pattern}
+          onChange={(e) => setPattern(e.target.value)}
+          onPressEnter={search}
+        />
+        <Checkbox checked={ignoreCase} onChange={(e) => setIgnoreCase(e.target.checked)}>
+          Ignore Case
+        </Checkbox>
+        <Button type="primary" onClick={search} loading={searching} disabled={!pattern.trim()}>
+          Find
+        </Button>
+      </Flex>
+      {matches === null ? (
+        <Typography.Text type="secondary">
+          Find a file in every backup of the repo, e.g. to pick the version to restore.
+        </Typography.Text>
+      ) : matches.length === 0 && !searching ? (
+        <Empty description="No files found" />
+      ) : (
+        <Table
+          size="small"
+          rowKey={(m) => m.snapshotId + m.entry!.path}
+          dataSource={matches}
+          pagination={{ pageSize: 20, size: "small" }}
+          columns={[
+            {
+              title: "Snapshot",
+              width: 120,
+              render: (_, m) => normalizeSnapshotId(m.snapshotId),
+            },
+            { title: "Path", render: (_, m) => m.entry!.path },
+            {
+              title: "Size",
+              width: 120,
+              render: (_, m) => (m.entry!.type === "file" ? formatBytes(Number(m.entry!.size)) : null),
+            },
+            {
+              title: "Modified",
+              width: 200,
+              render: (_, m) => formatTime(new Date(m.entry!.mtime)),
+            },
+          ]}
+        />
+      )}
+    </>
+  );
+};
@@ -1,6 +1,6 @@
 import React, { useState } from 'react';
-import { Hook, Hook_Command, Hook_Condition, Hook_Discord, Hook_Gotify, Hook_OnError, Hook_Webhook } from '../../gen/ts/v1/config_pb';
-import { Button, Card, Collapse, CollapseProps, Form, FormListFieldData, Input, Popover, Radio, Row, Select, Tooltip } from 'antd';
+import { Hook, Hook_Command, Hook_Condition, Hook_Discord, Hook_Gotify, Hook_OnError, Hook_Webhook, Hook_Webhook_Method } from '../../gen/ts/v1/config_pb';
+import { Button, Card, Collapse, CollapseProps, Form, FormListFieldData, Input, InputNumber, Popover, Radio, Row, Select, Tooltip } from 'antd';
 import { MinusCircleOutlined, PlusOutlined } from '@ant-design/icons';
 import { Rule } from 'antd/es/form';
 import { proto3 } from '@bufbuild/protobuf';
@@ -19,6 +19,7 @@ export interface HookFields {
   actionWebhook?: any;
   actionSlack?: any;
   actionShoutrrr?: any;
+  encryptionKey?: string;
 }
 
 export const hooksListTooltipText = <>
@@ -87,6 +88,21 @@ export const HooksFormList = () => {
             }}>
               <HookBuilder field={field} />
             </Form.Item>
+            <Tooltip title="The hook is abandoned and marked as failed if it runs longer than this. Defaults to 300 seconds if unset.">
+              <Form.Item name={[field.name, "timeoutSeconds"]}>
+                <InputNumber addonBefore={<div style={{ width: "5em" }}>Timeout</div>} addonAfter={<div style={{ width: "5em" }}>seconds</div>} type="number" min={0} placeholder="300" />
+              </Form.Item>
+            </Tooltip>
+            {hookData && !("actionCommand" in hookData) ? (
+              <>
+                <Tooltip title="Optional, an ASCII armored OpenPGP public key e.g. from `gpg --armor --export`. The notification's content is encrypted to the key so that the notification service can't read it.">
+                  Encryption Key:
+                </Tooltip>
+                <Form.Item name={[field.name, "encryptionKey"]}>
+                  <Input.TextArea style={{ width: "100%", fontFamily: "monospace" }} placeholder="-----BEGIN PGP PUBLIC KEY BLOCK-----" autoSize={{ minRows: 1, maxRows: 6 }} />
+                </Form.Item>
+              </>
+            ) : null}
           </Card>
         })}
         <Form.Item>
@@ -236,6 +252,33 @@ const hookTypes: {
           </Form.Item >
         </>
       }
+    },
+    {
+      name: "Webhook", template: {
+        actionWebhook: {
+          webhookUrl: "",
+          method: "POST",
+          template: "{{ .Summary }}",
+        },
+        conditions: [],
+      },
+      oneofKey: "actionWebhook",
+      component: ({ field }: { field: FormListFieldData }) => {
+        return <>
+          <Form.Item name={[field.name, "actionWebhook", "webhookUrl"]} rules={[requiredField("webhook URL is required"), { type: "url" }]} >
+            <Input addonBefore={<div style={{ width: "8em" }}>Webhook URL</div>} />
+          </Form.Item >
+          <Form.Item name={[field.name, "actionWebhook", "method"]} >
+            <Select
+              options={proto3.getEnumType(Hook_Webhook_Method).values.filter(v => v.no !== 0).map(v => ({ label: v.name, value: v.name }))}
+            />
+          </Form.Item >
+          Body Template (POST only, sent as JSON if it renders valid JSON):
+          <Form.Item name={[field.name, "actionWebhook", "template"]} >
+            <Input.TextArea style={{ width: "100%", fontFamily: "monospace" }} />
+          </Form.Item >
+        </>
+      }
     }
   ];
 
@@ -273,7 +316,7 @@ const ItemOnErrorSelector = ({ field }: { field: FormListFieldData }) => {
     <Tooltip title={<>
       What happens when the hook fails (currently only has effect on backup start hooks)
       <ul>
-        <li>IGNORE - the failure is ignored, subsequent hooks and the backup operation will run as normal.</li>
+        <li>IGNORE - the failure is ignored, subsequent hooks and the backup operation will run as normal. The hook runs in the background and does not delay the operation. Notifications that fail to send are retried for a few hours.</li>
         <li>FATAL - stops the backup with an error status (triggers an error notification). Skips running all subsequent hooks.</li>
         <li>CANCEL - marks the backup as cancelled but does not trigger any error notification. Skips running all subsequent hooks.</li>
       </ul>
@@ -0,0 +1,53 @@
+import React, { useEffect, useState } from "react";
+import { Tooltip, Typography } from "antd";
+import { StringValue } from "@bufbuild/protobuf";
+import { MountRepoRequest, RepoMount } from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { SpinButton } from "./SpinButton";
+import { formatTime } from "../lib/formatting";
+
+// MountButton mounts the repo's snapshots with restic mount, or unmounts them if the repo is mounted.
+export const MountButton = ({ repoId }: { repoId: string }) => {
+  const alertsApi = useAlertApi()!;
+  const [mount, setMount] = useState<RepoMount | null>(null);
+
+  useEffect(() => {
+    backrestService.getMounts({}).then((res) => {
+      setMount(res.mounts.find((m) => m.repoId === repoId) || null);
+    }).catch((e) => {
+      console.error("failed to list mounts: ", e);
+    });
+  }, [repoId]);
+
+  const handleClick = async () => {
+    try {
+      if (mount) {
+        await backrestService.unmountRepo(new StringValue({ value: repoId }));
+        setMount(null);
+        alertsApi.success("Unmounted repo.");
+      } else {
+        const res = await backrestService.mountRepo(new MountRepoRequest({ repoId }));
+        setMount(res);
+        alertsApi.success("Mounted repo at " + res.mountpoint);
+      }
+    } catch (e: any) {
+      alertsApi.error("Failed to " + (mount ? "unmount" : "mount") + " repo: " + e.message);
+    }
+  };
+
+  return (
+    <>
+      <Tooltip title="Runs restic mount to browse the repository's snapshots as a filesystem on the Backrest host, the repo is unmounted after 30 minutes. Requires FUSE, not supported on Windows.">
+        <SpinButton type="default" onClickAsync={handleClick}>
+          {mount ? "Unmount" : "Mount"}
+        </SpinButton>
+      </Tooltip>
+      {mount ? (
+        <Typography.Text type="secondary">
+          Mounted at <Typography.Text code copyable>{mount.mountpoint}</Typography.Text> until {formatTime(Number(mount.unmountAtMs))}
+        </Typography.Text>
+      ) : null}
+    </>
+  );
+};
@@ -5,13 +5,14 @@ import {
   OperationEventType,
 } from "../../gen/ts/v1/operations_pb";
 import {
+  Button,
   Empty,
   List,
 } from "antd";
 import {
   BackupInfo,
   BackupInfoCollector,
-  getOperations,
+  getOperationsPage,
   subscribeToOperations,
   unsubscribeFromOperations,
 } from "../state/oplog";
@@ -19,8 +20,10 @@ import _ from "lodash";
 import { GetOperationsRequest } from "../../gen/ts/v1/service_pb";
 import { useAlertApi } from "./Alerts";
 import { OperationRow } from "./OperationRow";
+import { OPERATION_LIST_PAGE_SIZE } from "../constants";
 
 // OperationList displays a list of operations that are either fetched based on 'req' or passed in via 'useBackups'.
+// Operations fetched based on 'req' are loaded a page at a time newest first, req.lastN is ignored.
 // If showPlan is provided the planId will be displayed next to each operation in the operation list.
 export const OperationList = ({
   req,
@@ -36,9 +39,36 @@ export const OperationList = ({
   const alertApi = useAlertApi();
 
   let backups: BackupInfo[] = [];
+  let loadMore: (() => void) | null = null;
+  let loadingMore = false;
   if (req) {
     const [backupState, setBackups] = useState<BackupInfo[]>(useBackups || []);
+    const [collector, setCollector] = useState<BackupInfoCollector | null>(null);
+    const [nextPageToken, setNextPageToken] = useState("");
+    const [loading, setLoading] = useState(false);
     backups = backupState;
+    loadingMore = loading;
+
+    const fetchPage = (backupCollector: BackupInfoCollector, pageToken: string) => {
+      const pageReq = req.clone();
+      pageReq.lastN = BigInt(0);
+      pageReq.descending = true;
+      pageReq.pageSize = OPERATION_LIST_PAGE_SIZE;
+      pageReq.pageToken = pageToken;
+      setLoading(true);
+      getOperationsPage(pageReq)
+        .then((page) => {
+          backupCollector.bulkAddOperations(page.operations);
+          setNextPageToken(page.nextPageToken);
+        })
+        .catch((e) => {
+          alertApi!.error("Failed to fetch operations: " + e.message);
+        })
+        .finally(() => setLoading(false));
+    };
+    if (collector && nextPageToken) {
+      loadMore = () => fetchPage(collector, nextPageToken);
+    }
 
     // track backups for this operation tree view.
     useEffect(() => {
@@ -70,13 +100,9 @@ export const OperationList = ({
         setBackups(backups);
       }, 50));
 
-      getOperations(req)
-        .then((ops) => {
-          backupCollector.bulkAddOperations(ops);
-        })
-        .catch((e) => {
-          alertApi!.error("Failed to fetch operations: " + e.message);
-        });
+      setCollector(backupCollector);
+      setNextPageToken("");
+      fetchPage(backupCollector, "");
       return () => {
         unsubscribeFromOperations(lis);
       };
@@ -99,19 +125,26 @@ export const OperationList = ({
     return Number(b.unixTimeStartMs - a.unixTimeStartMs)
   });
   return (
-    <List
-      itemLayout="horizontal"
-      size="small"
-      dataSource={operations}
-      renderItem={(op) => {
-        return <OperationRow alertApi={alertApi!} key={op.id} operation={op} showPlan={showPlan || false} />
-      }}
-      pagination={
-        operations.length > 25
-          ? { position: "both", align: "center", defaultPageSize: 25 }
-          : undefined
-      }
-    />
+    <>
+      <List
+        itemLayout="horizontal"
+        size="small"
+        dataSource={operations}
+        renderItem={(op) => {
+          return <OperationRow alertApi={alertApi!} key={op.id} operation={op} showPlan={showPlan || false} />
+        }}
+        pagination={
+          operations.length > 25
+            ? { position: "both", align: "center", defaultPageSize: 25 }
+            : undefined
+        }
+      />
+      {loadMore ? (
+        <div style={{ textAlign: "center", marginTop: "8px" }}>
+          <Button onClick={loadMore} loading={loadingMore}>Load older operations</Button>
+        </div>
+      ) : null}
+    </>
   );
 };
 
@@ -1,5 +1,6 @@
 import React, { useEffect, useState } from "react";
 import {
+  FailureDiagnostics,
   Operation,
   OperationEvent,
   OperationEventType,
@@ -11,11 +12,15 @@ import {
   Button,
   Col,
   Collapse,
+  Dropdown,
   Empty,
+  Input,
   List,
   Modal,
   Progress,
   Row,
+  Table,
+  Tag,
   Typography,
 } from "antd";
 import {
@@ -25,8 +30,10 @@ import {
   DownloadOutlined,
   RobotOutlined,
   InfoCircleOutlined,
+  CopyOutlined,
+  CommentOutlined,
 } from "@ant-design/icons";
-import { BackupProgressEntry, ResticSnapshot } from "../../gen/ts/v1/restic_pb";
+import { BackupProgressEntry, DiffEntry_Change, ResticSnapshot, SnapshotDiff, SnapshotStats } from "../../gen/ts/v1/restic_pb";
 import {
   DisplayType,
   detailsForOperation,
@@ -36,14 +43,16 @@ import {
 import { SnapshotBrowser } from "./SnapshotBrowser";
 import {
   formatBytes,
+  formatDuration,
   formatTime,
   normalizeSnapshotId,
 } from "../lib/formatting";
 import _ from "lodash";
-import { LogDataRequest } from "../../gen/ts/v1/service_pb";
+import { AnnotateOperationRequest, DiffSnapshotsRequest, GetSnapshotStatsRequest, LogDataRequest } from "../../gen/ts/v1/service_pb";
 import { MessageInstance } from "antd/es/message/interface";
 import { backrestService } from "../api";
 import { useShowModal } from "./ModalManager";
+import { ShareLinks } from "./ShareLinks";
 import { proto3 } from "@bufbuild/protobuf";
 import { Hook_Condition } from "../../gen/ts/v1/config_pb";
 
@@ -54,6 +63,8 @@ export const OperationRow = ({
   showPlan,
 }: React.PropsWithoutRef<{ operation: Operation, alertApi?: MessageInstance, showPlan: boolean }>) => {
   const showModal = useShowModal();
+  // glob patterns selecting the restored files to download, "!" marks an exclude pattern.
+  const [downloadFilter, setDownloadFilter] = useState("");
   const details = detailsForOperation(operation);
   const displayType = getTypeForDisplay(operation);
   let avatar: React.ReactNode;
@@ -86,7 +97,17 @@ export const OperationRow = ({
     case DisplayType.RUNHOOK:
       avatar = <RobotOutlined style={{ color: details.color }} />;
       break;
+    case DisplayType.COPY:
+      avatar = <CopyOutlined style={{ color: details.color }} />;
+      break;
     case DisplayType.STATS:
+    case DisplayType.CHECK:
+    case DisplayType.INIT:
+    case DisplayType.MIGRATE:
+    case DisplayType.RECOVER:
+    case DisplayType.TAG:
+    case DisplayType.VERIFY:
+    case DisplayType.REWRITE:
       avatar = <InfoCircleOutlined style={{ color: details.color }} />;
       break;
   }
@@ -121,13 +142,24 @@ export const OperationRow = ({
             width="50%"
             title={"Logs for operation " + opName + " at " + formatTime(Number(operation.unixTimeStartMs))}
             visible={true} footer={null} onCancel={() => { showModal(null); }}>
-            <BigOperationDataVerbatim logref={operation.logref!} />
+            <BigOperationDataVerbatim logref={operation.logref!} tail={operation.logTail} />
           </Modal>);
         }}>[View Logs]</Button>
       </small >
     </>
   }
 
+  if (operation.status !== OperationStatus.STATUS_PENDING && operation.status !== OperationStatus.STATUS_INPROGRESS) {
+    title = <>
+      {title}
+      <small>
+        <Button type="link" size="middle" className="backrest operation-details" onClick={() => {
+          showModal(<OperationNoteModal operation={operation} alertApi={alertApi} />);
+        }}>{operation.note ? "[Edit Note]" : "[Add Note]"}</Button>
+      </small>
+    </>
+  }
+
   let body: React.ReactNode | undefined;
   let displayMessage = operation.displayMessage;
 
@@ -142,10 +174,52 @@ export const OperationRow = ({
     ];
 
     if (backupOp.errors.length > 0) {
+      const more = Number(backupOp.errorCount) - backupOp.errors.length;
       items.splice(0, 0, {
         key: 2,
-        label: "Item Errors",
-        children: <pre>{backupOp.errors.map(e => "Error on item: " + e.item).join("\n")}</pre>,
+        label: "Item Errors (" + Math.max(Number(backupOp.errorCount), backupOp.errors.length) + ")",
+        children: <pre>
+          {backupOp.errors.map(e => "Error on item: " + e.item + (e.message ? " (" + e.message + ")" : "")).join("\n")}
+          {more > 0 ? "\n... and " + more + " more" : null}
+        </pre>,
+      });
+    }
+
+    if (backupOp.warnings.length > 0) {
+      const more = Number(backupOp.warningCount) - backupOp.warnings.length;
+      items.splice(0, 0, {
+        key: 5,
+        label: "Restic Warnings (" + Math.max(Number(backupOp.warningCount), backupOp.warnings.length) + ")",
+        children: <pre>
+          {backupOp.warnings.join("\n")}
+          {more > 0 ? "\n... and " + more + " more" : null}
+        </pre>,
+      });
+    }
+
+    if (backupOp.annotations.length > 0) {
+      items.push({
+        key: 6,
+        label: "Annotations",
+        children: <>
+          {backupOp.annotations.map(a => <Tag key={a.key}>{a.key}={a.value}</Tag>)}
+        </>,
+      });
+    }
+
+    if (backupOp.jitterMs) {
+      items.push({
+        key: 3,
+        label: "Schedule",
+        children: <p>Scheduled for {formatTime(Number(backupOp.scheduledTimeMs))}, delayed by {formatDuration(Number(backupOp.jitterMs))} of jitter.</p>,
+      });
+    }
+
+    if (operation.failureDiagnostics) {
+      items.push({
+        key: 4,
+        label: "Failure Diagnostics",
+        children: <FailureDiagnosticsView diagnostics={operation.failureDiagnostics} />,
       });
     }
 
@@ -186,23 +260,205 @@ export const OperationRow = ({
         ]}
       />
     );
+  } else if (operation.op.case === "operationCheck") {
+    const check = operation.op.value;
+    body = (
+      <Collapse
+        size="small"
+        destroyInactivePanel
+        items={[
+          {
+            key: 1,
+            label: "Check Output",
+            children: <pre>{check.output}</pre>,
+          },
+        ]}
+      />
+    );
+  } else if (operation.op.case === "operationInit") {
+    const init = operation.op.value;
+    body = (
+      <Collapse
+        size="small"
+        destroyInactivePanel
+        items={[
+          {
+            key: 1,
+            label: "Init Output",
+            children: <pre>{init.output}</pre>,
+          },
+        ]}
+      />
+    );
+  } else if (operation.op.case === "operationMigrate") {
+    const migrate = operation.op.value;
+    body = (
+      <Collapse
+        size="small"
+        destroyInactivePanel
+        items={[
+          {
+            key: 1,
+            label: migrate.migration
+              ? "Migrate Output (" + migrate.migration + (migrate.recompress ? ", recompress" : "") + ")"
+              : "Upgrade Output" + (migrate.recompress ? " (recompress)" : ""),
+            children: <pre>{migrate.output}</pre>,
+          },
+        ]}
+      />
+    );
+  } else if (operation.op.case === "operationTag") {
+    const tag = operation.op.value;
+    body = (
+      <>
+        Snapshots: {tag.snapshotIds.map(normalizeSnapshotId).join(", ")}
+        <br />
+        {tag.add.length > 0 ? <>Added tags: {tag.add.join(", ")}<br /></> : null}
+        {tag.remove.length > 0 ? <>Removed tags: {tag.remove.join(", ")}<br /></> : null}
+      </>
+    );
+  } else if (operation.op.case === "operationVerifyOplog") {
+    const verify = operation.op.value;
+    body = (
+      <>
+        Checked {verify.operationsChecked} operations, found {verify.inconsistencyCount} inconsistencies
+        {verify.inconsistencies.length > 0 ? (
+          <ul>
+            {verify.inconsistencies.map((i) => (
+              <li key={i.operationId.toString()}>{i.message}</li>
+            ))}
+          </ul>
+        ) : null}
+      </>
+    );
+  } else if (operation.op.case === "operationRewrite") {
+    const rewrite = operation.op.value;
+    body = (
+      <>
+        Snapshots: {rewrite.snapshotIds.map(normalizeSnapshotId).join(", ")}
+        <br />
+        Excluded: {rewrite.excludes.join(", ")}
+        <br />
+        Rewrote {rewrite.snapshotsRewritten} snapshots{rewrite.forget ? ", the originals are forgotten" : ""}
+        <br />
+        <Collapse
+          size="small"
+          destroyInactivePanel
+          items={[
+            {
+              key: 1,
+              label: "Rewrite Output",
+              children: <pre>{rewrite.output}</pre>,
+            },
+          ]}
+        />
+      </>
+    );
+  } else if (operation.op.case === "operationRecover") {
+    const recover = operation.op.value;
+    body = (
+      <>
+        {recover.snapshotId ? (
+          <>
+            Recovered {recover.roots} directories into snapshot {normalizeSnapshotId(recover.snapshotId)}
+            <br />
+          </>
+        ) : null}
+        <Collapse
+          size="small"
+          destroyInactivePanel
+          items={[
+            {
+              key: 1,
+              label: "Recover Output",
+              children: <pre>{recover.output}</pre>,
+            },
+          ]}
+        />
+      </>
+    );
+  } else if (operation.op.case === "operationCopy") {
+    const copy = operation.op.value;
+    body = (
+      <>
+        Copied {copy.copiedSnapshots.length} snapshot(s) from repo {copy.sourceRepo}
+        {copy.copiedSnapshots.length > 0 ? (
+          <Collapse
+            size="small"
+            destroyInactivePanel
+            items={[
+              {
+                key: 1,
+                label: "Copied Snapshots",
+                children: (
+                  <pre>
+                    {copy.copiedSnapshots.map((s) => s.sourceId + " -> " + s.destinationId).join("\n")}
+                  </pre>
+                ),
+              },
+            ]}
+          />
+        ) : null}
+      </>
+    );
   } else if (operation.op.case === "operationRestore") {
     const restore = operation.op.value;
+    const downloadRestore = (format: string) => {
+      const params = new URLSearchParams(format === "zip" ? { format: "zip" } : { compression: format });
+      for (const pattern of downloadFilter.split(/\s+/).filter((p) => p !== "")) {
+        if (pattern.startsWith("!")) {
+          params.append("exclude", pattern.substring(1));
+        } else {
+          params.append("include", pattern);
+        }
+      }
+      const query = "?" + params.toString();
+      backrestService.getDownloadURL({ value: operation.id }).then((resp) => {
+        window.open(resp.value + query, "_blank");
+      }).catch((e) => {
+        alertApi?.error("Failed to fetch download URL: " + e.message);
+      });
+    };
     body = (
       <>
-        Restore {restore.path} to {restore.target}
+        Restore {restore.path} to {restore.inPlace ? "its original location" : restore.target}
+        {restore.swap ? ", swapped in when complete" : ""}
         {details.percentage !== undefined ? (
           <Progress percent={details.percentage || 0} status="active" />
         ) : null}
-        {operation.status == OperationStatus.STATUS_SUCCESS ? (<>
+        {operation.status == OperationStatus.STATUS_SUCCESS && !restore.inPlace ? (<>
           <br />
+          <Dropdown.Button
+            type="link"
+            onClick={() => downloadRestore("zstd")}
+            menu={{
+              items: [
+                { key: "zstd", label: "Download as .tar.zst (fast)" },
+                { key: "gzip", label: "Download as .tar.gz (compatible)" },
+                { key: "none", label: "Download as .tar (uncompressed)" },
+                { key: "zip", label: "Download as .zip (Windows)" },
+              ],
+              onClick: ({ key }) => downloadRestore(key),
+            }}
+          >
+            Download File(s)
+          </Dropdown.Button>
+          <Input
+            size="small"
+            style={{ width: "200px" }}
+            placeholder="e.g. *.docx !drafts"
+            title="Only download the files matching these patterns, ! excludes files"
+            value={downloadFilter}
+            onChange={(e) => setDownloadFilter(e.target.value)}
+          />
           <Button type="link" onClick={() => {
-            backrestService.getDownloadURL({ value: operation.id }).then((resp) => {
-              window.open(resp.value, "_blank");
-            }).catch((e) => {
-              alertApi?.error("Failed to fetch download URL: " + e.message);
-            });
-          }}>Download File(s)</Button>
+            showModal(<Modal
+              width="60%"
+              title={"Share files restored from snapshot " + normalizeSnapshotId(operation.snapshotId)}
+              open={true} footer={null} onCancel={() => { showModal(null); }}>
+              <ShareLinks restoreOpId={operation.id} />
+            </Modal>);
+          }}>Share</Button>
         </>) : null}
       </>
     );
@@ -224,6 +480,42 @@ export const OperationRow = ({
 
   children.push(body);
 
+  if (operation.note) {
+    children.push(<Typography.Paragraph style={{ whiteSpace: "pre-wrap", margin: "4px 0" }}>
+      <CommentOutlined />{" "}{operation.note.text}{" "}
+      <small style={{ color: "gray" }}>
+        - {operation.note.author ? operation.note.author + ", " : null}{formatTime(Number(operation.note.unixTimeMs))}
+      </small>
+    </Typography.Paragraph>);
+  }
+
+  if (operation.orphanedMs) {
+    children.push(<small style={{ color: "gray" }}>
+      snapshot no longer in the repo since {formatTime(Number(operation.orphanedMs))}
+      <br />
+    </small>);
+  }
+
+  if (operation.resourceUsage) {
+    const usage = operation.resourceUsage;
+    children.push(<small style={{ color: "gray" }}>
+      restic used {formatDuration(Number(usage.cpuTimeMs))} CPU ({usage.avgCpuCores.toFixed(2)} cores avg
+      {usage.peakCpuCores ? ", " + usage.peakCpuCores.toFixed(2) + " peak" : null})
+      {usage.peakRssBytes ? ", " + formatBytes(Number(usage.peakRssBytes)) + " memory peak" : null}
+      {usage.avgRssBytes ? " (" + formatBytes(Number(usage.avgRssBytes)) + " avg)" : null}
+      {usage.commands > 1 ? " over " + usage.commands + " commands" : null}
+      <br />
+    </small>);
+  }
+
+  if (operation.hostname || operation.backrestVersion) {
+    children.push(<small style={{ color: "gray" }}>
+      {operation.hostname ? "host " + operation.hostname + " " : null}
+      {operation.backrestVersion ? "backrest " + operation.backrestVersion + " " : null}
+      {operation.resticVersion ? "restic " + operation.resticVersion : null}
+    </small>);
+  }
+
   return (
     <List.Item>
       <List.Item.Meta title={title} avatar={avatar} description={children} />
@@ -231,6 +523,45 @@ export const OperationRow = ({
   );
 };
 
+// OperationNoteModal edits the note attached to a finished operation, saving an empty note removes it.
+const OperationNoteModal = ({ operation, alertApi }: { operation: Operation, alertApi?: MessageInstance }) => {
+  const showModal = useShowModal();
+  const [note, setNote] = useState(operation.note?.text || "");
+  const [saving, setSaving] = useState(false);
+
+  const handleOk = async () => {
+    setSaving(true);
+    try {
+      await backrestService.annotateOperation(new AnnotateOperationRequest({ operationId: operation.id, note }));
+      showModal(null);
+    } catch (e: any) {
+      alertApi?.error("Failed to save note: " + e.message);
+    } finally {
+      setSaving(false);
+    }
+  };
+
+  return (
+    <Modal
+      open={true}
+      title={"Note on " + displayTypeToString(getTypeForDisplay(operation)) + " at " + formatTime(Number(operation.unixTimeStartMs))}
+      okText="Save"
+      confirmLoading={saving}
+      onOk={handleOk}
+      onCancel={() => showModal(null)}
+    >
+      <Input.TextArea
+        autoFocus
+        rows={4}
+        maxLength={4096}
+        value={note}
+        placeholder="e.g. this failure was due to a NAS reboot"
+        onChange={(e) => setNote(e.target.value)}
+      />
+    </Modal>
+  );
+};
+
 const SnapshotInfo = ({
   snapshot,
   repoId,
@@ -274,6 +605,16 @@ const SnapshotInfo = ({
             </>
           ),
         },
+        {
+          key: 3,
+          label: "Snapshot Statistics",
+          children: <SnapshotStatsView snapshotId={snapshot.id!} repoId={repoId} />,
+        },
+        ...(snapshot.parent ? [{
+          key: 4,
+          label: "Changes Since Previous Snapshot",
+          children: <SnapshotDiffView snapshotId={snapshot.id!} repoId={repoId} />,
+        }] : []),
         {
           key: 2,
           label: "Browse and Restore Files in Backup",
@@ -290,6 +631,202 @@ const SnapshotInfo = ({
   );
 };
 
+const SnapshotStatsView = ({
+  snapshotId,
+  repoId,
+}: {
+  snapshotId: string;
+  repoId: string;
+}) => {
+  const [stats, setStats] = useState<SnapshotStats | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    backrestService
+      .getSnapshotStats(new GetSnapshotStatsRequest({ repoId, snapshotId }))
+      .then(setStats)
+      .catch((e) => setError("Failed to load snapshot stats: " + e.message));
+  }, [repoId, snapshotId]);
+
+  if (error) {
+    return <Typography.Text type="danger">{error}</Typography.Text>;
+  } else if (!stats) {
+    return <>Loading...</>;
+  }
+
+  return (
+    <Row gutter={16}>
+      <Col span={8}>
+        <Typography.Text strong>Files</Typography.Text>
+        <br />
+        {Number(stats.fileCount)}
+      </Col>
+      <Col span={8}>
+        <Typography.Text strong>Stored Size</Typography.Text>
+        <br />
+        {formatBytes(Number(stats.totalSize))}
+      </Col>
+      <Col span={8}>
+        <Typography.Text strong>Restore Size</Typography.Text>
+        <br />
+        {formatBytes(Number(stats.restoreSize))}
+      </Col>
+    </Row>
+  );
+};
+
+const FailureDiagnosticsView = ({ diagnostics }: { diagnostics: FailureDiagnostics }) => {
+  return (
+    <>
+      <Typography.Text type="secondary">Captured at {formatTime(Number(diagnostics.unixTimeMs))}</Typography.Text>
+      <Row gutter={16}>
+        <Col span={8}>
+          <Typography.Text strong>Backend</Typography.Text>
+          <br />
+          {diagnostics.backendError
+            ? "unreachable after " + formatDuration(Number(diagnostics.backendLatencyMs)) + ": " + diagnostics.backendError
+            : "reachable in " + formatDuration(Number(diagnostics.backendLatencyMs))}
+        </Col>
+        <Col span={8}>
+          <Typography.Text strong>System Memory</Typography.Text>
+          <br />
+          {diagnostics.memoryTotalBytes
+            ? formatBytes(Number(diagnostics.memoryAvailableBytes)) + " available of " + formatBytes(Number(diagnostics.memoryTotalBytes))
+            : "unknown"}
+        </Col>
+        <Col span={8}>
+          <Typography.Text strong>Backrest Memory</Typography.Text>
+          <br />
+          {formatBytes(Number(diagnostics.daemonMemoryBytes))}
+        </Col>
+      </Row>
+      <Table
+        size="small"
+        pagination={false}
+        rowKey={(d) => d.path}
+        dataSource={diagnostics.disks}
+        columns={[
+          { title: "Path", render: (_, d) => d.path },
+          {
+            title: "Free Space",
+            render: (_, d) => (d.error ? d.error : formatBytes(Number(d.freeBytes)) + " of " + formatBytes(Number(d.totalBytes))),
+          },
+        ]}
+      />
+      {diagnostics.resticOutput ? (
+        <>
+          <Typography.Text strong>Restic Output</Typography.Text>
+          <pre>{diagnostics.resticOutput}</pre>
+        </>
+      ) : null}
+      {diagnostics.daemonLog ? (
+        <>
+          <Typography.Text strong>Backrest Log</Typography.Text>
+          <pre>{diagnostics.daemonLog}</pre>
+        </>
+      ) : null}
+    </>
+  );
+};
+
+const changeTags: { [change: number]: [string, string] } = {
+  [DiffEntry_Change.ADDED]: ["added", "success"],
+  [DiffEntry_Change.REMOVED]: ["removed", "error"],
+  [DiffEntry_Change.MODIFIED]: ["modified", "processing"],
+  [DiffEntry_Change.TYPE_CHANGED]: ["type changed", "warning"],
+  [DiffEntry_Change.METADATA_CHANGED]: ["metadata", "default"],
+};
+
+// SnapshotDiffView lists the files that changed between a snapshot and its parent i.e. what changed in its backup.
+const SnapshotDiffView = ({
+  snapshotId,
+  repoId,
+}: {
+  snapshotId: string;
+  repoId: string;
+}) => {
+  const [diff, setDiff] = useState<SnapshotDiff | null>(null);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    backrestService
+      .diffSnapshots(new DiffSnapshotsRequest({ repoId, snapshotId }))
+      .then(setDiff)
+      .catch((e) => setError("Failed to load changes: " + e.message));
+  }, [repoId, snapshotId]);
+
+  if (error) {
+    return <Typography.Text type="danger">{error}</Typography.Text>;
+  } else if (!diff) {
+    return <>Loading...</>;
+  }
+
+  return (
+    <>
+      <Row gutter={16}>
+        <Col span={6}>
+          <Typography.Text strong>Files Added</Typography.Text>
+          <br />
+          {Number(diff.filesAdded)}
+        </Col>
+        <Col span={6}>
+          <Typography.Text strong>Files Removed</Typography.Text>
+          <br />
+          {Number(diff.filesRemoved)}
+        </Col>
+        <Col span={6}>
+          <Typography.Text strong>Files Changed</Typography.Text>
+          <br />
+          {Number(diff.filesChanged)}
+        </Col>
+        <Col span={6}>
+          <Typography.Text strong>Data Added / Removed</Typography.Text>
+          <br />
+          {formatBytes(Number(diff.bytesAdded))} / {formatBytes(Number(diff.bytesRemoved))}
+        </Col>
+      </Row>
+      <Table
+        size="small"
+        style={{ marginTop: "1em" }}
+        rowKey="path"
+        dataSource={diff.entries}
+        pagination={{ pageSize: 20, size: "small" }}
+        columns={[
+          {
+            title: "Change",
+            dataIndex: "change",
+            width: 120,
+            render: (change: DiffEntry_Change) => {
+              const [label, color] = changeTags[change] || ["unknown", "default"];
+              return <Tag color={color}>{label}</Tag>;
+            },
+          },
+          { title: "Path", dataIndex: "path" },
+          {
+            title: "Size",
+            width: 200,
+            render: (_, entry) => {
+              if (diff.sizesOmitted || entry.path.endsWith("/")) {
+                return null;
+              }
+              const size = formatBytes(Number(entry.size));
+              const previousSize = formatBytes(Number(entry.previousSize));
+              switch (entry.change) {
+                case DiffEntry_Change.ADDED:
+                  return size;
+                case DiffEntry_Change.REMOVED:
+                  return previousSize;
+                default:
+                  return `${previousSize} → ${size}`;
+              }
+            },
+          },
+        ]}
+      />
+    </>
+  );
+};
+
 const BackupOperationStatus = ({
   status,
 }: {
@@ -321,8 +858,27 @@ const BackupOperationStatus = ({
             {Number(st.filesDone)}/{Number(st.totalFiles)}
           </Col>
         </Row>
+        <Row gutter={16}>
+          <Col span={12}>
+            <Typography.Text strong>Files Remaining</Typography.Text>
+            <br />
+            {Number(st.filesRemaining)} ({formatBytes(Number(st.bytesRemaining))})
+          </Col>
+          <Col span={12}>
+            <Typography.Text strong>Time Elapsed/Remaining</Typography.Text>
+            <br />
+            {formatDuration(Number(st.secondsElapsed) * 1000)}/
+            {st.secondsRemaining > 0 ? formatDuration(Number(st.secondsRemaining) * 1000) : "estimating"}
+          </Col>
+        </Row>
+        {st.errorCount > 0 ? (
+          <Typography.Text type="warning">{Number(st.errorCount)} item error(s) so far</Typography.Text>
+        ) : null}
         {st.currentFile && st.currentFile.length > 0 ? (
-          <pre>Current file: {st.currentFile.join("\n")}</pre>
+          <pre>
+            Currently uploading {st.currentFile.join(", ")}, {Number(st.filesDone).toLocaleString()} of{" "}
+            {Number(st.totalFiles).toLocaleString()} files
+          </pre>
         ) : null}
       </>
     );
@@ -405,7 +961,7 @@ const ForgetOperationDetails = ({ forgetOp }: { forgetOp: OperationForget }) =>
       items={[
         {
           key: 1,
-          label: "Removed " + forgetOp.forget?.length + " Snapshots",
+          label: "Removed " + forgetOp.forget?.length + " Snapshots" + (forgetOp.retained.length > 0 ? ", Kept " + forgetOp.retained.length + " for the Cooldown" : ""),
           children: <>
             Removed snapshots:
             <pre>{forgetOp.forget?.map((f) => (
@@ -413,6 +969,14 @@ const ForgetOperationDetails = ({ forgetOp }: { forgetOp: OperationForget }) =>
                 {"removed snapshot " + normalizeSnapshotId(f.id!) + " taken at " + formatTime(Number(f.unixTimeMs))} <br />
               </div>
             ))}</pre>
+            {forgetOp.retained.length > 0 ? (<>
+              Kept until the repo's prune cooldown passed:
+              <pre>{forgetOp.retained.map((f) => (
+                <div key={f.id}>
+                  {"kept snapshot " + normalizeSnapshotId(f.id!) + " taken at " + formatTime(Number(f.unixTimeMs))} <br />
+                </div>
+              ))}</pre>
+            </>) : null}
             {/* Policy:
             <ul>
               {policyDesc.map((desc, idx) => (
@@ -427,11 +991,13 @@ const ForgetOperationDetails = ({ forgetOp }: { forgetOp: OperationForget }) =>
 }
 
 // TODO: refactor this to use the provider pattern
-const BigOperationDataVerbatim = ({ logref }: { logref: string }) => {
+// BigOperationDataVerbatim shows the log of an operation, if only the end of the log is given the full log is fetched on request.
+const BigOperationDataVerbatim = ({ logref, tail }: { logref: string, tail?: string }) => {
   const [output, setOutput] = useState<string | undefined>(undefined);
+  const [loadFull, setLoadFull] = useState(!tail);
 
   useEffect(() => {
-    if (!logref) {
+    if (!logref || !loadFull) {
       return;
     }
     backrestService.getLogs(new LogDataRequest({
@@ -441,7 +1007,13 @@ const BigOperationDataVerbatim = ({ logref }: { logref: string }) => {
     }).catch((e) => {
       console.error("Failed to fetch hook output: ", e);
     });
-  }, [logref]);
+  }, [logref, loadFull]);
 
+  if (!loadFull) {
+    return <>
+      <Button type="link" onClick={() => setLoadFull(true)}>[Load Full Log]</Button>
+      <pre>...{tail}</pre>
+    </>;
+  }
   return <pre>{output}</pre>;
 }
@@ -29,11 +29,11 @@ import {
 import { OperationEvent, OperationEventType, OperationStatus } from "../../gen/ts/v1/operations_pb";
 import { useAlertApi } from "./Alerts";
 import { OperationList } from "./OperationList";
-import { ClearHistoryRequest, ForgetRequest, GetOperationsRequest } from "../../gen/ts/v1/service_pb";
+import { ClearHistoryRequest, ForgetRequest, GetOperationsRequest, RewriteSnapshotsRequest, TagSnapshotsRequest } from "../../gen/ts/v1/service_pb";
 import { isMobile } from "../lib/browserutil";
 import { useShowModal } from "./ModalManager";
 import { backrestService } from "../api";
-import { ConfirmButton } from "./SpinButton";
+import { ConfirmButton, SpinButton } from "./SpinButton";
 
 type OpTreeNode = DataNode & {
   backup?: BackupInfo;
@@ -144,6 +144,8 @@ export const OperationTree = ({
           details.push("system cancel");
         } else if (b.status === OperationStatus.STATUS_USER_CANCELLED) {
           details.push("cancelled");
+        } else if (b.status === OperationStatus.STATUS_SKIPPED) {
+          details.push("skipped");
         }
 
         if (b.backupLastStatus) {
@@ -283,12 +285,73 @@ const BackupView = ({ backup }: { backup?: BackupInfo }) => {
           repoId: backup.repoId!,
           snapshotId: backup.snapshotId!
         }));
-        alertApi!.success("Snapshot forgotten.");
+        alertApi!.success("Forget scheduled.");
       } catch (e) {
         alertApi!.error("Failed to forget snapshot: " + e);
       }
     }
 
+    const doEditTags = async () => {
+      const splitTags = (s: string | null) => (s || "").split(",").map((t) => t.trim()).filter((t) => t !== "");
+      const add = splitTags(window.prompt("Tags to add, comma separated (optional)"));
+      const remove = splitTags(window.prompt("Tags to remove, comma separated (optional)"));
+      if (add.length === 0 && remove.length === 0) {
+        return;
+      }
+      try {
+        await backrestService.tagSnapshots(new TagSnapshotsRequest({
+          planId: backup.planId!,
+          repoId: backup.repoId!,
+          snapshotIds: [backup.snapshotId!],
+          add,
+          remove,
+        }));
+        alertApi!.success("Tag scheduled.");
+      } catch (e) {
+        alertApi!.error("Failed to tag snapshot: " + e);
+      }
+    }
+
+    const doRewrite = async () => {
+      const excludes = (window.prompt("Files to remove from the snapshot, comma separated exclude patterns e.g. /home/user/.ssh or *.iso") || "")
+        .split(",").map((e) => e.trim()).filter((e) => e !== "");
+      if (excludes.length === 0) {
+        return;
+      }
+      const req = new RewriteSnapshotsRequest({
+        planId: backup.planId!,
+        repoId: backup.repoId!,
+        snapshotIds: [backup.snapshotId!],
+        excludes,
+        forget: window.confirm("Forget the original snapshot once rewritten? Its data, including the excluded files, is removed by the next prune. Cancel to keep it."),
+      });
+      try {
+        const preview = await backrestService.previewRewriteSnapshots(req);
+        if (preview.snapshotsRewritten === 0) {
+          alertApi!.info("The snapshot holds no files matching the excludes, nothing to rewrite.");
+          return;
+        }
+        if (!window.confirm("The rewrite would change " + preview.snapshotsRewritten + " snapshots:\n\n" + preview.output + "\nRewrite?")) {
+          return;
+        }
+        req.confirmDigest = preview.digest;
+        await backrestService.rewriteSnapshots(req);
+        alertApi!.success("Rewrite scheduled.");
+      } catch (e) {
+        alertApi!.error("Failed to rewrite snapshot: " + e);
+      }
+    }
+
+    const rewriteButton = backup.snapshotId ?
+      <Tooltip title="Removes files from the snapshot with restic rewrite, e.g. secrets backed up by accident. restic saves the snapshot under a new ID.">
+        <SpinButton type="text" onClickAsync={doRewrite}>Remove Files</SpinButton>
+      </Tooltip> : null;
+
+    const tagButton = backup.snapshotId ?
+      <Tooltip title="Adds or removes tags of the snapshot with restic tag, restic saves the snapshot under a new ID.">
+        <SpinButton type="text" onClickAsync={doEditTags}>Edit Tags</SpinButton>
+      </Tooltip> : null;
+
     const deleteButton = backup.snapshotId ?
       <Tooltip title="This will remove the snapshot from the repository. This is irreversible.">
         <ConfirmButton
@@ -319,7 +382,7 @@ const BackupView = ({ backup }: { backup?: BackupInfo }) => {
       }}>
         <h3>Backup on {formatTime(backup.displayTime)}</h3>
         <div style={{ position: "absolute", right: "20px" }}>
-          {backup.status !== OperationStatus.STATUS_PENDING && backup.status != OperationStatus.STATUS_INPROGRESS ? deleteButton : null}
+          {backup.status !== OperationStatus.STATUS_PENDING && backup.status != OperationStatus.STATUS_INPROGRESS ? <>{tagButton}{rewriteButton}{deleteButton}</> : null}
         </div>
       </div>
       <OperationList key={backup.id} useBackups={[backup]} />
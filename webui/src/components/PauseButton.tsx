@@ -0,0 +1,69 @@
+import React from "react";
+import { Tag, Tooltip } from "antd";
+import { PauseState } from "../../gen/ts/v1/config_pb";
+import { SetPausedRequest } from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { useConfig } from "./ConfigProvider";
+import { useAlertApi } from "./Alerts";
+import { SpinButton } from "./SpinButton";
+import { formatTime } from "../lib/formatting";
+
+// PauseButton pauses or resumes the scheduled tasks of a plan or repo, exactly one of planId and repoId should be set.
+export const PauseButton = ({
+  planId,
+  repoId,
+  paused,
+}: {
+  planId?: string;
+  repoId?: string;
+  paused?: PauseState;
+}) => {
+  const [, setConfig] = useConfig();
+  const alertsApi = useAlertApi()!;
+
+  const handleClick = async () => {
+    let reason = "";
+    if (!paused) {
+      reason = window.prompt("Reason for pausing (optional)") ?? "";
+    }
+    try {
+      const newConfig = await backrestService.setPaused(new SetPausedRequest({
+        planId,
+        repoId,
+        paused: !paused,
+        reason,
+      }));
+      setConfig(newConfig);
+      alertsApi.success(paused ? "Resumed scheduled tasks." : "Paused scheduled tasks.");
+    } catch (e: any) {
+      alertsApi.error("Failed to update pause state: " + e.message);
+    }
+  };
+
+  return (
+    <Tooltip title="Paused plans and repos are not backed up or maintained on schedule, operations started manually still run.">
+      <SpinButton type="default" onClickAsync={handleClick}>
+        {paused ? "Resume" : "Pause"}
+      </SpinButton>
+    </Tooltip>
+  );
+};
+
+export const PausedTag = ({ paused }: { paused?: PauseState }) => {
+  if (!paused) {
+    return null;
+  }
+  let details = "Paused";
+  if (paused.pausedBy) {
+    details += " by " + paused.pausedBy;
+  }
+  details += " at " + formatTime(Number(paused.unixTimeMs));
+  if (paused.reason) {
+    details += ": " + paused.reason;
+  }
+  return (
+    <Tooltip title={details}>
+      <Tag color="orange">Paused</Tag>
+    </Tooltip>
+  );
+};
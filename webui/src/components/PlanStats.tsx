@@ -0,0 +1,80 @@
+import React, { useEffect, useState } from "react";
+import { Col, Empty, Row, Spin, Statistic, Table } from "antd";
+import { GetStatsRequest, PlanStats as PlanStatsProto } from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { formatBytes, formatDate, formatDuration, formatTime } from "../lib/formatting";
+
+// PlanStats shows the success rate, durations and bytes added of a plan's backups over the last 30 days, aggregated by
+// the server from the operation log.
+export const PlanStats = ({ planId }: { planId: string }) => {
+  const alertApi = useAlertApi();
+  const [stats, setStats] = useState<PlanStatsProto | null>(null);
+  const [loading, setLoading] = useState(true);
+
+  useEffect(() => {
+    setLoading(true);
+    backrestService
+      .getStats(
+        new GetStatsRequest({
+          planId,
+          timezone: Intl.DateTimeFormat().resolvedOptions().timeZone,
+        })
+      )
+      .then((res) => setStats(res.plans[0] || null))
+      .catch((e) => alertApi?.error("Failed to get plan stats: " + e.message))
+      .finally(() => setLoading(false));
+  }, [planId]);
+
+  if (loading) {
+    return <Spin />;
+  } else if (!stats) {
+    return <Empty description="No stats available" />;
+  }
+
+  const finished = stats.successCount + stats.failureCount;
+  return (
+    <>
+      <Row gutter={16}>
+        <Col span={4}>
+          <Statistic
+            title="Success Rate (30 days)"
+            value={finished > 0 ? (100 * stats.successCount) / finished : "-"}
+            precision={1}
+            suffix={finished > 0 ? "%" : undefined}
+          />
+        </Col>
+        <Col span={4}>
+          <Statistic title="Succeeded / Failed" value={`${stats.successCount} / ${stats.failureCount}`} />
+        </Col>
+        <Col span={4}>
+          <Statistic title="Average Duration" value={formatDuration(Number(stats.avgDurationMs))} />
+        </Col>
+        <Col span={4}>
+          <Statistic title="95th Percentile Duration" value={formatDuration(Number(stats.p95DurationMs))} />
+        </Col>
+        <Col span={4}>
+          <Statistic title="Bytes Added" value={formatBytes(Number(stats.bytesAdded))} />
+        </Col>
+        <Col span={4}>
+          <Statistic
+            title="Last Success"
+            value={stats.lastSuccessMs ? formatTime(Number(stats.lastSuccessMs)) : "Never"}
+          />
+        </Col>
+      </Row>
+      <Table
+        size="small"
+        style={{ marginTop: "16px" }}
+        rowKey={(day) => day.unixTimeMs.toString()}
+        dataSource={[...stats.days].reverse()}
+        pagination={{ pageSize: 10, size: "small" }}
+        columns={[
+          { title: "Day", render: (_, day) => formatDate(Number(day.unixTimeMs)) },
+          { title: "Backups", dataIndex: "backups" },
+          { title: "Bytes Added", render: (_, day) => formatBytes(Number(day.bytesAdded)) },
+        ]}
+      />
+    </>
+  );
+};
@@ -0,0 +1,122 @@
+import React, { useEffect, useState } from "react";
+import { Button, Flex, Input, Popconfirm, Table, Tag, Typography } from "antd";
+import { StringValue } from "@bufbuild/protobuf";
+import { RepoKey } from "../../gen/ts/v1/restic_pb";
+import {
+  AddRepoKeyRequest,
+  ChangeRepoPasswordRequest,
+  RemoveRepoKeyRequest,
+} from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { useConfig } from "./ConfigProvider";
+import { formatTime } from "../lib/formatting";
+
+// RepoKeys lists the keys of a repo and adds, removes and rotates them. Each key holds a password that opens the repo.
+export const RepoKeys = ({ repoId }: { repoId: string }) => {
+  const alertApi = useAlertApi();
+  const [, setConfig] = useConfig();
+  const [keys, setKeys] = useState<RepoKey[] | null>(null);
+  const [password, setPassword] = useState("");
+  const [newPassword, setNewPassword] = useState("");
+  const [busy, setBusy] = useState(false);
+
+  const refresh = async () => {
+    try {
+      const res = await backrestService.listRepoKeys(new StringValue({ value: repoId }));
+      setKeys(res.keys);
+    } catch (e: any) {
+      alertApi?.error("Failed to list keys: " + e.message);
+    }
+  };
+
+  useEffect(() => {
+    refresh();
+  }, [repoId]);
+
+  const run = async (action: () => Promise<void>, success: string) => {
+    setBusy(true);
+    try {
+      await action();
+      alertApi?.success(success);
+    } catch (e: any) {
+      alertApi?.error(e.message);
+    } finally {
+      setBusy(false);
+      refresh();
+    }
+  };
+
+  const addKey = () =>
+    run(async () => {
+      await backrestService.addRepoKey(new AddRepoKeyRequest({ repoId, password }));
+      setPassword("");
+    }, "Added key");
+
+  const removeKey = (keyId: string) =>
+    run(async () => {
+      await backrestService.removeRepoKey(new RemoveRepoKeyRequest({ repoId, keyId }));
+    }, "Removed key");
+
+  const changePassword = () =>
+    run(async () => {
+      setConfig(await backrestService.changeRepoPassword(new ChangeRepoPasswordRequest({ repoId, newPassword })));
+      setNewPassword("");
+    }, "Changed the repo's password");
+
+  return (
+    <>
+      <Table
+        size="small"
+        rowKey={(k) => k.id}
+        loading={keys === null}
+        dataSource={keys || []}
+        pagination={false}
+        columns={[
+          {
+            title: "ID",
+            render: (_, k) => (
+              <>
+                {k.id} {k.current ? <Tag color="blue">backrest</Tag> : null}
+              </>
+            ),
+          },
+          { title: "User", render: (_, k) => k.username },
+          { title: "Host", render: (_, k) => k.hostname },
+          { title: "Created", render: (_, k) => formatTime(Number(k.createdUnixMs)) },
+          {
+            title: "",
+            width: 100,
+            render: (_, k) =>
+              k.current ? null : (
+                <Popconfirm title="Remove this key? Its password will no longer open the repo." onConfirm={() => removeKey(k.id)}>
+                  <Button size="small" danger disabled={busy}>
+                    Remove
+                  </Button>
+                </Popconfirm>
+              ),
+          },
+        ]}
+      />
+      <Typography.Title level={5}>Add Key</Typography.Title>
+      <Flex gap="small" align="center">
+        <Input.Password placeholder="Password of the new key" value={password} onChange={(e) => setPassword(e.target.value)} />
+        <Button onClick={addKey} loading={busy} disabled={!password}>
+          Add Key
+        </Button>
+      </Flex>
+      <Typography.Title level={5}>Change Password</Typography.Title>
+      <Typography.Text type="secondary">
+        Replaces the key holding the password in backrest's config, other machines using the old password lose access.
+      </Typography.Text>
+      <Flex gap="small" align="center" style={{ marginTop: "0.5em" }}>
+        <Input.Password placeholder="New password" value={newPassword} onChange={(e) => setNewPassword(e.target.value)} />
+        <Popconfirm title="Change the repo's password?" onConfirm={changePassword}>
+          <Button danger loading={busy} disabled={!newPassword}>
+            Change Password
+          </Button>
+        </Popconfirm>
+      </Flex>
+    </>
+  );
+};
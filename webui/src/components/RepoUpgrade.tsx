@@ -0,0 +1,93 @@
+import React, { useEffect, useState } from "react";
+import { Alert, Checkbox, List, Modal, Spin, Typography } from "antd";
+import { StringValue } from "@bufbuild/protobuf";
+import { RepoUpgradeAssessment, UpgradeRepoRequest } from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { useShowModal } from "./ModalManager";
+
+// RepoUpgradeModal checks whether the repo can be upgraded to the v2 format or recompressed, explains the benefits and
+// risks of doing so and schedules the upgrade once confirmed.
+export const RepoUpgradeModal = ({ repoId }: { repoId: string }) => {
+  const showModal = useShowModal();
+  const alertApi = useAlertApi();
+  const [assessment, setAssessment] = useState<RepoUpgradeAssessment | null>(null);
+  const [recompress, setRecompress] = useState(false);
+
+  useEffect(() => {
+    backrestService
+      .getRepoUpgrade(new StringValue({ value: repoId }))
+      .then((res) => {
+        setAssessment(res);
+        setRecompress(res.recompressRecommended);
+      })
+      .catch((e) => {
+        alertApi?.error("Failed to check the repo for upgrades: " + e.message);
+        showModal(null);
+      });
+  }, [repoId]);
+
+  const canUpgrade = !!assessment && (assessment.upgradeAvailable || assessment.recompressRecommended);
+
+  const handleOk = async () => {
+    try {
+      await backrestService.upgradeRepo(new UpgradeRepoRequest({ repoId, recompress }));
+      alertApi?.success("Upgrade scheduled, its progress is shown in the operation list.");
+    } catch (e: any) {
+      alertApi?.error("Failed to schedule upgrade: " + e.message);
+    }
+    showModal(null);
+  };
+
+  return (
+    <Modal
+      open={true}
+      title={"Upgrade repo " + repoId}
+      width="40vw"
+      okText="Upgrade"
+      okButtonProps={{ disabled: !canUpgrade || (!assessment?.upgradeAvailable && !recompress) }}
+      onOk={handleOk}
+      onCancel={() => showModal(null)}
+    >
+      {assessment === null ? (
+        <Spin />
+      ) : (
+        <>
+          <Typography.Paragraph>
+            The repo uses format version {assessment.version}.{" "}
+            {assessment.upgradeAvailable
+              ? "It can be upgraded to version 2, which supports compression."
+              : assessment.recompressRecommended
+                ? "Some of its data is stored uncompressed."
+                : "It is up to date, there is nothing to upgrade."}
+          </Typography.Paragraph>
+          {canUpgrade ? (
+            <>
+              <List
+                size="small"
+                header="Benefits"
+                dataSource={assessment.benefits}
+                renderItem={(b) => <List.Item>{b}</List.Item>}
+              />
+              <Alert
+                type="warning"
+                style={{ margin: "8px 0" }}
+                message="Risks"
+                description={
+                  <ul style={{ margin: 0, paddingLeft: "16px" }}>
+                    {assessment.risks.map((r, i) => (
+                      <li key={i}>{r}</li>
+                    ))}
+                  </ul>
+                }
+              />
+              <Checkbox checked={recompress} onChange={(e) => setRecompress(e.target.checked)}>
+                Recompress the data stored uncompressed after upgrading
+              </Checkbox>
+            </>
+          ) : null}
+        </>
+      )}
+    </Modal>
+  );
+};
@@ -0,0 +1,106 @@
+import React, { useEffect, useState } from "react";
+import { Col, Empty, List, Radio, Row, Spin, Tag, Typography } from "antd";
+import {
+  ListRestorePointsRequest,
+  ListRestorePointsRequest_Granularity,
+  RestorePoint,
+} from "../../gen/ts/v1/service_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { formatTime, normalizeSnapshotId } from "../lib/formatting";
+import { SnapshotBrowser } from "./SnapshotBrowser";
+
+// RestorePoints lists a plan's snapshots collapsed into one restore point per day or week, selecting a restore point
+// browses its snapshot.
+export const RestorePoints = ({ repoId, planId }: { repoId: string; planId: string }) => {
+  const alertApi = useAlertApi();
+  const [granularity, setGranularity] = useState(ListRestorePointsRequest_Granularity.DAY);
+  const [points, setPoints] = useState<RestorePoint[] | null>(null);
+  const [selected, setSelected] = useState<RestorePoint | null>(null);
+
+  useEffect(() => {
+    setPoints(null);
+    setSelected(null);
+    backrestService
+      .listRestorePoints(new ListRestorePointsRequest({
+        repoId,
+        planId,
+        granularity,
+        timezone: Intl.DateTimeFormat().resolvedOptions().timeZone,
+      }))
+      .then((res) => setPoints(res.points))
+      .catch((e) => {
+        alertApi?.error("Failed to list restore points: " + e.message);
+        setPoints([]);
+      });
+  }, [repoId, planId, granularity]);
+
+  return (
+    <>
+      <Radio.Group
+        value={granularity}
+        onChange={(e) => setGranularity(e.target.value)}
+        style={{ marginBottom: "1em" }}
+      >
+        <Radio.Button value={ListRestorePointsRequest_Granularity.DAY}>By Day</Radio.Button>
+        <Radio.Button value={ListRestorePointsRequest_Granularity.WEEK}>By Week</Radio.Button>
+      </Radio.Group>
+      {points === null ? (
+        <Spin />
+      ) : points.length === 0 ? (
+        <Empty description="No restore points" />
+      ) : (
+        <Row gutter={16}>
+          <Col span={8}>
+            <List
+              size="small"
+              dataSource={points}
+              pagination={{ pageSize: 14, size: "small" }}
+              renderItem={(point) => (
+                <List.Item
+                  onClick={() => setSelected(point)}
+                  style={{
+                    cursor: "pointer",
+                    background: selected === point ? "rgba(0, 0, 0, 0.06)" : undefined,
+                  }}
+                >
+                  <List.Item.Meta
+                    title={
+                      <>
+                        {granularity === ListRestorePointsRequest_Granularity.WEEK ? "Week of " : ""}
+                        {point.label}{" "}
+                        {point.partial ? (
+                          <Tag color="warning">only failed backups</Tag>
+                        ) : null}
+                      </>
+                    }
+                    description={`as of ${formatTime(Number(point.snapshot!.unixTimeMs))}, ${point.snapshotCount} snapshot${point.snapshotCount === 1 ? "" : "s"}`}
+                  />
+                </List.Item>
+              )}
+            />
+          </Col>
+          <Col span={16}>
+            {selected ? (
+              <>
+                <Typography.Text type="secondary">
+                  Snapshot {normalizeSnapshotId(selected.snapshot!.id)}
+                </Typography.Text>
+                <SnapshotBrowser
+                  key={selected.snapshot!.id}
+                  repoId={repoId}
+                  planId={planId}
+                  snapshotId={selected.snapshot!.id}
+                />
+              </>
+            ) : (
+              <Typography.Text type="secondary">
+                Select a restore point to browse and restore its files.
+              </Typography.Text>
+            )}
+          </Col>
+        </Row>
+      )}
+    </>
+  );
+};
@@ -0,0 +1,63 @@
+import React, { useEffect, useState } from "react";
+import { Empty, List, Spin, Tag, Typography } from "antd";
+import {
+  SchedulerDecision,
+  SchedulerDecision_Kind,
+  SchedulerDecisionsRequest,
+} from "../../gen/ts/v1/scheduler_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { formatTime } from "../lib/formatting";
+
+const kindLabels: { [kind: number]: [string, string] } = {
+  [SchedulerDecision_Kind.SCHEDULED]: ["Scheduled", "blue"],
+  [SchedulerDecision_Kind.STARTED]: ["Started", "green"],
+  [SchedulerDecision_Kind.DEFERRED]: ["Deferred", "orange"],
+  [SchedulerDecision_Kind.SKIPPED]: ["Skipped", "orange"],
+  [SchedulerDecision_Kind.NOT_SCHEDULED]: ["Not Scheduled", "red"],
+  [SchedulerDecision_Kind.CANCELLED]: ["Cancelled", "default"],
+  [SchedulerDecision_Kind.STOPPED]: ["Stopped", "red"],
+};
+
+// SchedulerDecisions lists the scheduler's recent decisions about a plan's tasks, newest first, to explain why a task
+// did or didn't run.
+export const SchedulerDecisions = ({ planId }: { planId: string }) => {
+  const alertApi = useAlertApi();
+  const [decisions, setDecisions] = useState<SchedulerDecision[] | null>(null);
+
+  useEffect(() => {
+    setDecisions(null);
+    backrestService
+      .getSchedulerDecisions(new SchedulerDecisionsRequest({ planId, limit: 200 }))
+      .then((res) => setDecisions(res.decisions))
+      .catch((e) => {
+        alertApi?.error("Failed to get scheduler decisions: " + e.message);
+        setDecisions([]);
+      });
+  }, [planId]);
+
+  if (decisions === null) {
+    return <Spin />;
+  } else if (decisions.length === 0) {
+    return <Empty description="No scheduler decisions since Backrest started" />;
+  }
+  return (
+    <List
+      size="small"
+      dataSource={decisions}
+      pagination={{ pageSize: 25, size: "small" }}
+      renderItem={(d) => {
+        const [label, color] = kindLabels[d.kind] || ["Unknown", "default"];
+        return (
+          <List.Item>
+            <Typography.Text type="secondary">{formatTime(Number(d.unixTimeMs))}</Typography.Text>{" "}
+            <Tag color={color}>{label}</Tag>
+            {d.task}
+            {d.runAtMs ? <> to run at {formatTime(Number(d.runAtMs))}</> : null}
+            {d.reason ? <Typography.Text type="secondary">: {d.reason}</Typography.Text> : null}
+          </List.Item>
+        );
+      }}
+    />
+  );
+};
@@ -0,0 +1,145 @@
+import React, { useEffect, useState } from "react";
+import { Button, Flex, Input, InputNumber, Popconfirm, Table, Tag, Typography } from "antd";
+import { Empty, Int64Value } from "@bufbuild/protobuf";
+import { CreateShareLinkRequest, ShareLink } from "../../gen/ts/v1/sharelink_pb";
+import { backrestService } from "../api";
+import { useAlertApi } from "./Alerts";
+import { formatTime } from "../lib/formatting";
+
+const linkStatus = (link: ShareLink) => {
+  if (link.revokedMs) {
+    return <Tag color="red">revoked</Tag>;
+  } else if (Number(link.expiresMs) <= Date.now()) {
+    return <Tag>expired</Tag>;
+  } else if (link.maxDownloads > 0 && link.downloads >= link.maxDownloads) {
+    return <Tag>used up</Tag>;
+  }
+  return <Tag color="green">active</Tag>;
+};
+
+// ShareLinks creates and revokes passphrase protected links to download a directory or file of a restore, they let
+// guests without access to the UI download the files.
+export const ShareLinks = ({ restoreOpId }: { restoreOpId: bigint }) => {
+  const alertApi = useAlertApi();
+  const [links, setLinks] = useState<ShareLink[] | null>(null);
+  const [path, setPath] = useState("");
+  const [passphrase, setPassphrase] = useState("");
+  const [days, setDays] = useState<number | null>(7);
+  const [maxDownloads, setMaxDownloads] = useState<number | null>(null);
+  const [url, setUrl] = useState("");
+  const [busy, setBusy] = useState(false);
+
+  const refresh = async () => {
+    try {
+      const res = await backrestService.getShareLinks(new Empty());
+      setLinks(res.links.filter((l) => l.restoreOpId === restoreOpId));
+    } catch (e: any) {
+      alertApi?.error("Failed to list share links: " + e.message);
+    }
+  };
+
+  useEffect(() => {
+    refresh();
+  }, [restoreOpId]);
+
+  const createLink = async () => {
+    setBusy(true);
+    try {
+      const res = await backrestService.createShareLink(
+        new CreateShareLinkRequest({
+          restoreOpId,
+          path,
+          passphrase,
+          ttlSeconds: BigInt(Math.round((days || 7) * 24 * 60 * 60)),
+          maxDownloads: maxDownloads || 0,
+        })
+      );
+      setUrl(new URL(res.url, window.location.href).toString());
+      setPassphrase("");
+      alertApi?.success("Created share link, send the passphrase separately from the link");
+    } catch (e: any) {
+      alertApi?.error("Failed to create share link: " + e.message);
+    } finally {
+      setBusy(false);
+      refresh();
+    }
+  };
+
+  const revokeLink = async (id: bigint) => {
+    try {
+      await backrestService.revokeShareLink(new Int64Value({ value: id }));
+      alertApi?.success("Revoked share link");
+    } catch (e: any) {
+      alertApi?.error("Failed to revoke share link: " + e.message);
+    } finally {
+      refresh();
+    }
+  };
+
+  return (
+    <>
+      <Typography.Text type="secondary">
+        Anyone with a share link and its passphrase can download the shared directory as a .tar.gz archive, or the shared
+        file as is, until the link expires or is revoked, without access to backrest.
+      </Typography.Text>
+      <Flex vertical gap="small" style={{ marginTop: "0.5em" }}>
+        <Input
+          addonBefore="Path"
+          placeholder="relative to the restore target, empty for all restored files"
+          value={path}
+          onChange={(e) => setPath(e.target.value)}
+        />
+        <Input.Password
+          addonBefore="Passphrase"
+          placeholder="at least 8 characters"
+          value={passphrase}
+          onChange={(e) => setPassphrase(e.target.value)}
+        />
+        <Flex gap="small">
+          <InputNumber addonBefore="Expires after" addonAfter="days" min={1} max={30} value={days} onChange={setDays} />
+          <InputNumber addonBefore="Max downloads" placeholder="unlimited" min={1} value={maxDownloads} onChange={setMaxDownloads} />
+          <Button type="primary" onClick={createLink} loading={busy} disabled={passphrase.length < 8}>
+            Create Link
+          </Button>
+        </Flex>
+        {url ? (
+          <Typography.Paragraph copyable={{ text: url }}>
+            <Typography.Text code>{url}</Typography.Text>
+            <br />
+            <Typography.Text type="secondary">The link is only shown once.</Typography.Text>
+          </Typography.Paragraph>
+        ) : null}
+      </Flex>
+      <Table
+        size="small"
+        style={{ marginTop: "1em" }}
+        rowKey={(l) => l.id.toString()}
+        loading={links === null}
+        dataSource={links || []}
+        pagination={false}
+        columns={[
+          { title: "Path", render: (_, l) => l.path || "/" },
+          { title: "Status", render: (_, l) => linkStatus(l) },
+          {
+            title: "Downloads",
+            render: (_, l) => (l.maxDownloads > 0 ? `${l.downloads} / ${l.maxDownloads}` : l.downloads),
+          },
+          { title: "Expires", render: (_, l) => formatTime(Number(l.expiresMs)) },
+          { title: "Created By", render: (_, l) => l.createdBy },
+          {
+            title: "",
+            width: 100,
+            render: (_, l) =>
+              l.revokedMs ? null : (
+                <Popconfirm title="Revoke this link? Guests can no longer download the files." onConfirm={() => revokeLink(l.id)}>
+                  <Button size="small" danger>
+                    Revoke
+                  </Button>
+                </Popconfirm>
+              ),
+          },
+        ]}
+      />
+    </>
+  );
+};
@@ -1,9 +1,13 @@
 import React, { useEffect, useMemo, useState } from "react";
-import { Button, Dropdown, Form, Input, Modal, Space, Spin, Tree } from "antd";
+import { Alert, Button, Checkbox, Dropdown, Form, Input, List, Modal, Space, Spin, Tree, Typography } from "antd";
 import type { DataNode, EventDataNode } from "antd/es/tree";
 import {
+  FileVersion,
   ListSnapshotFilesResponse,
   LsEntry,
+  RestoreConflictReport,
+  RestorePreview,
+  RestoreProblem_Kind,
   RestoreSnapshotRequest,
 } from "../../gen/ts/v1/service_pb";
 import { useAlertApi } from "./Alerts";
@@ -13,11 +17,11 @@ import {
   FolderOutlined,
 } from "@ant-design/icons";
 import { useShowModal } from "./ModalManager";
-import { formatBytes, normalizeSnapshotId } from "../lib/formatting";
+import { formatBytes, formatDuration, formatTime, normalizeSnapshotId } from "../lib/formatting";
 import { URIAutocomplete } from "./URIAutocomplete";
 import { validateForm } from "../lib/formutil";
 import { backrestService } from "../api";
-import { ConfirmButton } from "./SpinButton";
+import { ConfirmButton, SpinButton } from "./SpinButton";
 
 const SnapshotBrowserContext = React.createContext<{
   snapshotId: string;
@@ -166,10 +170,29 @@ const respToNodes = (resp: ListSnapshotFilesResponse): DataNode[] => {
 
 const FileNode = ({ entry }: { entry: LsEntry }) => {
   const [dropdown, setDropdown] = useState<React.ReactNode>(null);
+  const alertApi = useAlertApi();
   const { snapshotId, repoId, planId, showModal } = React.useContext(
     SnapshotBrowserContext
   )!;
 
+  const download = async () => {
+    try {
+      // the file, or a directory as a tar archive, is streamed from the snapshot, nothing is restored to disk first.
+      const req = {
+        repoId,
+        snapshotId,
+        path: entry.path!,
+      };
+      const resp =
+        entry.type === "dir"
+          ? await backrestService.getSnapshotArchiveURL(req)
+          : await backrestService.getSnapshotFileDownloadURL(req);
+      window.open(resp.value, "_blank");
+    } catch (e: any) {
+      alertApi?.error("Failed to fetch download URL: " + e.message);
+    }
+  };
+
   const showDropdown = () => {
     setDropdown(
       <Dropdown
@@ -192,6 +215,40 @@ const FileNode = ({ entry }: { entry: LsEntry }) => {
                 );
               },
             },
+            ...(entry.type === "file"
+              ? [
+                  {
+                    key: "download",
+                    label: "Download",
+                    onClick: download,
+                  },
+                  ...(planId
+                    ? [
+                        {
+                          key: "versions",
+                          label: "Version history",
+                          onClick: () => {
+                            showModal(
+                              <FileVersionsModal
+                                path={entry.path!}
+                                repoId={repoId}
+                                planId={planId}
+                              />
+                            );
+                          },
+                        },
+                      ]
+                    : []),
+                ]
+              : entry.type === "dir"
+              ? [
+                  {
+                    key: "download",
+                    label: "Download as tar",
+                    onClick: download,
+                  },
+                ]
+              : []),
             {
               key: "restore",
               label: "Restore to path",
@@ -227,6 +284,80 @@ const FileNode = ({ entry }: { entry: LsEntry }) => {
   );
 };
 
+// FileVersionsModal lists the versions of a file in the plan's snapshots, any of them can be restored.
+const FileVersionsModal = ({
+  repoId,
+  planId,
+  path,
+}: {
+  repoId: string;
+  planId: string;
+  path: string;
+}) => {
+  const showModal = useShowModal();
+  const alertApi = useAlertApi();
+  const [versions, setVersions] = useState<FileVersion[] | null>(null);
+
+  useEffect(() => {
+    backrestService
+      .listFileVersions({ repoId, planId, path })
+      .then((resp) => setVersions(resp.versions))
+      .catch((e) => {
+        alertApi?.error("Failed to list file versions: " + e.message);
+        setVersions([]);
+      });
+  }, [repoId, planId, path]);
+
+  return (
+    <Modal
+      open={true}
+      title={"Versions of " + path}
+      width="40vw"
+      cancelButtonProps={{ style: { display: "none" } }}
+      onCancel={() => showModal(null)}
+      onOk={() => showModal(null)}
+    >
+      {versions === null ? (
+        <Spin />
+      ) : (
+        <List
+          size="small"
+          style={{ maxHeight: "50vh", overflowY: "auto" }}
+          dataSource={versions}
+          renderItem={(v) => (
+            <List.Item
+              actions={[
+                <Button
+                  key="restore"
+                  size="small"
+                  onClick={() =>
+                    showModal(
+                      <RestoreModal
+                        path={path}
+                        repoId={repoId}
+                        planId={planId}
+                        snapshotId={v.snapshotId}
+                      />
+                    )
+                  }
+                >
+                  Restore
+                </Button>,
+              ]}
+            >
+              <Typography.Text type={v.changed ? undefined : "secondary"}>
+                {formatTime(Number(v.snapshotTimeMs))} ({normalizeSnapshotId(v.snapshotId)}):{" "}
+                {formatBytes(Number(v.entry?.size))}, modified {formatTime(v.entry?.mtime || 0)}
+                {v.changed ? "" : ", unchanged"}
+              </Typography.Text>
+            </List.Item>
+          )}
+        />
+      )}
+    </Modal>
+  );
+};
+
 const RestoreModal = ({
   repoId,
   planId,
@@ -240,6 +371,11 @@ const RestoreModal = ({
 }) => {
   const [form] = Form.useForm<RestoreSnapshotRequest>();
   const showModal = useShowModal();
+  const inPlace = Form.useWatch("inPlace", form);
+  // the files an in place restore would overwrite, shown for confirmation before the restore is scheduled.
+  const [conflicts, setConflicts] = useState<RestoreConflictReport | null>(null);
+  // a dry run of the restore, listing the files it would write and the problems writing them.
+  const [preview, setPreview] = useState<RestorePreview | null>(null);
 
   const handleCancel = () => {
     showModal(null);
@@ -248,17 +384,50 @@ const RestoreModal = ({
   const handleOk = async () => {
     try {
       const values = await validateForm(form);
+      if (values.inPlace && !conflicts) {
+        setConflicts(
+          await backrestService.getRestoreConflicts({
+            repoId,
+            planId,
+            snapshotId,
+            path,
+            inPlace: true,
+            swap: values.swap,
+          })
+        );
+        return; // the user confirms the conflicts before restoring.
+      }
       await backrestService.restore({
         repoId,
         planId,
         snapshotId,
         path,
-        target: values.target,
+        target: values.inPlace ? "" : values.target,
+        inPlace: values.inPlace,
+        swap: values.inPlace && values.swap,
+        confirmConflictsDigest: conflicts?.digest,
       });
     } catch (e: any) {
       alert("Failed to restore snapshot: " + e.message);
-    } finally {
-      showModal(null); // close.
+    }
+    showModal(null); // close.
+  };
+
+  const handlePreview = async () => {
+    try {
+      const values = await validateForm(form);
+      setPreview(
+        await backrestService.previewRestore({
+          repoId,
+          planId,
+          snapshotId,
+          path,
+          target: values.inPlace ? "" : values.target,
+          inPlace: values.inPlace,
+        })
+      );
+    } catch (e: any) {
+      alert("Failed to preview restore: " + e.message);
     }
   };
 
@@ -274,13 +443,21 @@ const RestoreModal = ({
         <Button key="back" onClick={handleCancel}>
           Cancel
         </Button>,
+        <SpinButton key="preview" onClickAsync={handlePreview}>
+          Preview
+        </SpinButton>,
         <ConfirmButton
           key="submit"
           type="primary"
           confirmTitle="Confirm Restore?"
           onClickAsync={handleOk}
         >
-          Restore
+          {conflicts
+            ? `Restore, overwriting ${conflicts.overwrittenFiles} files` +
+              (conflicts.removedFiles > 0 ? ` and removing ${conflicts.removedFiles}` : "")
+            : inPlace
+            ? "Check for conflicts"
+            : "Restore"}
         </ConfirmButton>,
       ]}
     >
@@ -291,14 +468,111 @@ const RestoreModal = ({
         wrapperCol={{ span: 16 }}
       >
         <Form.Item
-          label="Restore to path"
-          name="target"
-          required={true}
-          rules={[{ required: true, message: "Please enter a restore path" }]}
+          label="Original location"
+          name="inPlace"
+          valuePropName="checked"
+          tooltip="Restore the files where they were backed up from, overwriting the local copies. The files that would be overwritten are listed for confirmation first."
         >
-          <URIAutocomplete onBlur={() => form.validateFields()} />
+          <Checkbox
+            onChange={() => {
+              setConflicts(null);
+              setPreview(null);
+            }}
+          />
         </Form.Item>
+        {inPlace ? (
+          <Form.Item
+            label="Swap in when complete"
+            name="swap"
+            valuePropName="checked"
+            tooltip="Restore to a directory next to the path first and swap it in once the restore succeeded, the local files are left untouched if it fails. Needs free space for a full copy, local files that aren't in the snapshot are removed."
+          >
+            <Checkbox onChange={() => setConflicts(null)} />
+          </Form.Item>
+        ) : (
+          <Form.Item
+            label="Restore to path"
+            name="target"
+            required={true}
+            rules={[{ required: true, message: "Please enter a restore path" }]}
+          >
+            <URIAutocomplete
+              onBlur={() => {
+                setPreview(null);
+                form.validateFields();
+              }}
+            />
+          </Form.Item>
+        )}
       </Form>
+      {preview ? (
+        <>
+          <Typography.Paragraph>
+            {preview.totalFiles.toString()} files ({formatBytes(Number(preview.totalBytes))}) and{" "}
+            {preview.totalDirs.toString()} directories would be restored to {preview.target}
+            {preview.estimatedDurationMs > 0
+              ? `, taking about ${formatDuration(Number(preview.estimatedDurationMs))} based on past restores`
+              : ""}
+            .
+          </Typography.Paragraph>
+          {preview.problems.map((p, i) => (
+            <Alert
+              key={i}
+              type={p.kind === RestoreProblem_Kind.MISSING_PARENT ? "info" : "warning"}
+              style={{ marginBottom: "8px" }}
+              message={`${p.path}: ${p.message}`}
+              description={p.files > 0 ? `Affects ${p.files.toString()} files.` : undefined}
+            />
+          ))}
+          <List
+            size="small"
+            style={{ maxHeight: "30vh", overflowY: "auto" }}
+            dataSource={preview.files}
+            renderItem={(f) => (
+              <List.Item>
+                <Typography.Text type={f.overwrites ? "warning" : undefined}>
+                  {f.path} ({formatBytes(Number(f.size))}
+                  {f.overwrites ? ", overwrites a local file" : ""})
+                </Typography.Text>
+              </List.Item>
+            )}
+            footer={preview.truncated ? "Only the first files are listed." : undefined}
+          />
+        </>
+      ) : null}
+      {conflicts ? (
+        <>
+          <Typography.Paragraph>
+            {conflicts.overwrittenFiles.toString()} of {conflicts.filesChecked.toString()} files exist locally and will be overwritten,{" "}
+            {conflicts.newerLocalFiles.toString()} of them were modified after the snapshot was taken.
+            {conflicts.removedFiles > 0
+              ? ` ${conflicts.removedFiles.toString()} local files aren't in the snapshot and will be removed.`
+              : ""}
+          </Typography.Paragraph>
+          <List
+            size="small"
+            style={{ maxHeight: "30vh", overflowY: "auto" }}
+            dataSource={conflicts.conflicts}
+            renderItem={(c) => (
+              <List.Item>
+                <Typography.Text type={c.localNewer || c.removed ? "danger" : undefined}>
+                  {c.removed ? (
+                    <>
+                      {c.path} ({formatBytes(Number(c.localSize))} locally, not in snapshot and will be removed)
+                    </>
+                  ) : (
+                    <>
+                      {c.path} ({formatBytes(Number(c.snapshotSize))} in snapshot, {formatBytes(Number(c.localSize))} locally
+                      {c.localNewer ? ", newer locally" : ""})
+                    </>
+                  )}
+                </Typography.Text>
+              </List.Item>
+            )}
+            footer={conflicts.truncated ? "Only the first conflicts are listed." : undefined}
+          />
+        </>
+      ) : null}
     </Modal>
   );
 };
@@ -1,3 +1,4 @@
 export const MAX_OPERATION_HISTORY = 10000;
 export const STATUS_OPERATION_HISTORY = 20; // number of operations to load when determining plan / repo status.
 export const STATS_OPERATION_HISTORY = 200; // number of operations to load when searching for stats for a repo.
+export const OPERATION_LIST_PAGE_SIZE = 500; // number of operations the operation list loads at a time, newest first.
@@ -2,6 +2,7 @@ import {
   Operation,
   OperationEvent,
   OperationEventType,
+  OperationList,
   OperationStatus,
 } from "../../gen/ts/v1/operations_pb";
 import { GetOperationsRequest } from "../../gen/ts/v1/service_pb";
@@ -18,7 +19,7 @@ const subscribers: ((event: OperationEvent) => void)[] = [];
   while (true) {
     let nextConnWaitUntil = new Date().getTime() + 5000;
     try {
-      for await (const event of backrestService.getOperationEvents({})) {
+      for await (const event of backrestService.subscribeOperations({})) {
         console.log("operation event", event);
         subscribers.forEach((subscriber) => subscriber(event));
       }
@@ -38,6 +39,13 @@ export const getOperations = async (
   return opList.operations || [];
 };
 
+// getOperationsPage fetches a page of the operations, the returned list's nextPageToken fetches the next page.
+export const getOperationsPage = async (
+  req: GetOperationsRequest
+): Promise<OperationList> => {
+  return await backrestService.getOperations(req);
+};
+
 export const subscribeToOperations = (
   callback: (event: OperationEvent) => void
 ) => {
@@ -90,7 +98,8 @@ const getStatus = async (req: GetOperationsRequest) => {
     if (
       op.status !== OperationStatus.STATUS_SUCCESS &&
       op.status !== OperationStatus.STATUS_USER_CANCELLED &&
-      op.status !== OperationStatus.STATUS_SYSTEM_CANCELLED
+      op.status !== OperationStatus.STATUS_SYSTEM_CANCELLED &&
+      op.status !== OperationStatus.STATUS_SKIPPED
     ) {
       return op.status;
     }
@@ -107,6 +116,14 @@ export enum DisplayType {
   RESTORE,
   STATS,
   RUNHOOK,
+  CHECK,
+  COPY,
+  INIT,
+  MIGRATE,
+  RECOVER,
+  TAG,
+  VERIFY,
+  REWRITE,
 }
 
 export interface BackupInfo {
@@ -339,6 +356,22 @@ export const getTypeForDisplay = (op: Operation) => {
       return DisplayType.STATS;
     case "operationRunHook":
       return DisplayType.RUNHOOK;
+    case "operationCheck":
+      return DisplayType.CHECK;
+    case "operationCopy":
+      return DisplayType.COPY;
+    case "operationInit":
+      return DisplayType.INIT;
+    case "operationMigrate":
+      return DisplayType.MIGRATE;
+    case "operationRecover":
+      return DisplayType.RECOVER;
+    case "operationTag":
+      return DisplayType.TAG;
+    case "operationVerifyOplog":
+      return DisplayType.VERIFY;
+    case "operationRewrite":
+      return DisplayType.REWRITE;
     default:
       return DisplayType.UNKNOWN;
   }
@@ -360,6 +393,22 @@ export const displayTypeToString = (type: DisplayType) => {
       return "Stats";
     case DisplayType.RUNHOOK:
       return "Run Hook";
+    case DisplayType.CHECK:
+      return "Check";
+    case DisplayType.COPY:
+      return "Copy";
+    case DisplayType.INIT:
+      return "Init";
+    case DisplayType.MIGRATE:
+      return "Migrate";
+    case DisplayType.RECOVER:
+      return "Recover";
+    case DisplayType.TAG:
+      return "Tag";
+    case DisplayType.VERIFY:
+      return "Verify Oplog";
+    case DisplayType.REWRITE:
+      return "Rewrite";
     default:
       return "Unknown";
   }
@@ -424,6 +473,10 @@ export const detailsForOperation = (
       state = "cancelled";
       color = "orange";
       break;
+    case OperationStatus.STATUS_SKIPPED:
+      state = "skipped";
+      color = "grey";
+      break;
     default:
       state = "";
       color = "grey";
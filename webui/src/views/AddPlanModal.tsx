@@ -22,6 +22,7 @@ import { MinusCircleOutlined, PlusOutlined } from "@ant-design/icons";
 import { URIAutocomplete } from "../components/URIAutocomplete";
 import { useAlertApi } from "../components/Alerts";
 import { Cron } from "react-js-cron";
+import { CronPreview } from "../components/CronPreview";
 import { namePattern, validateForm } from "../lib/formutil";
 import { HooksFormList, hooksListTooltipText } from "../components/HooksFormList";
 import { ConfirmButton, SpinButton } from "../components/SpinButton";
@@ -34,6 +35,10 @@ export const AddPlanModal = ({
   template: Plan | null;
 }) => {
   const [confirmLoading, setConfirmLoading] = useState(false);
+  // chained plans may run only after their upstream plans rather than on a schedule of their own.
+  const [onlyAfter, setOnlyAfter] = useState(
+    !!template && template.after.length > 0 && !template.cron && !template.schedule?.interval
+  );
   const showModal = useShowModal();
   const alertsApi = useAlertApi()!;
   const [config, setConfig] = useConfig();
@@ -88,6 +93,24 @@ export const AddPlanModal = ({
         delete plan.retention;
       }
 
+      if (plan.filesFrom && plan.filesFrom.paths.length === 0 && !plan.filesFrom.command) {
+        delete plan.filesFrom;
+      }
+
+      if (plan.commandSource && !plan.commandSource.command) {
+        delete plan.commandSource;
+      }
+
+      // an interval schedule replaces the cron schedule, plans that only run after other plans have neither.
+      if (onlyAfter && plan.after.length > 0) {
+        plan.cron = "";
+        delete plan.schedule;
+      } else if (plan.schedule?.interval) {
+        plan.cron = "";
+      } else {
+        delete plan.schedule;
+      }
+
       // Merge the new plan (or update) into the config
       if (template) {
         const idx = config.plans.findIndex((r) => r.id === template.id);
@@ -210,7 +233,7 @@ export const AddPlanModal = ({
           </Form.Item>
 
           {/* Plan.paths */}
-          <Form.Item label="Paths" required={true}>
+          <Form.Item label="Paths" required={false}>
             <Form.List
               name="paths"
               rules={[]}
@@ -261,6 +284,37 @@ export const AddPlanModal = ({
             </Form.List>
           </Form.Item>
 
+          {/* Plan.files_from */}
+          <Form.Item label={<Tooltip title="Paths to back up in addition to the paths above, passed to restic with --files-from-verbatim. Paths are used verbatim, glob patterns are not expanded.">Files From</Tooltip>}>
+            <Form.Item name={["filesFrom", "paths"]} noStyle>
+              <Select mode="tags" placeholder="static paths" style={{ width: "90%" }} open={false} />
+            </Form.Item>
+            <Form.Item name={["filesFrom", "command"]} noStyle>
+              <Input.TextArea
+                placeholder="command run before each backup that prints one path per line e.g. find /data -newer /var/run/marker"
+                autoSize={{ minRows: 1 }}
+                style={{ width: "90%", marginTop: "5px" }}
+              />
+            </Form.Item>
+          </Form.Item>
+
+          {/* Plan.command_source */}
+          <Form.Item label={<Tooltip title="Backs up what a command prints to stdout instead of paths e.g. a database dump, it's piped into restic backup --stdin. The backup fails without a snapshot if the command exits with a non-zero code. Leave the paths and files from empty.">Command Source</Tooltip>}>
+            <Form.Item name={["commandSource", "command"]} noStyle>
+              <Input.TextArea
+                placeholder="command whose output is backed up e.g. pg_dump mydb"
+                autoSize={{ minRows: 1 }}
+                style={{ width: "90%" }}
+              />
+            </Form.Item>
+            <Form.Item name={["commandSource", "filename"]} noStyle>
+              <Input
+                placeholder="filename in the snapshot e.g. mydb.sql, defaults to stdin"
+                style={{ width: "90%", marginTop: "5px" }}
+              />
+            </Form.Item>
+          </Form.Item>
+
           {/* Plan.excludes */}
           <Form.Item label="Excludes" required={false}>
             <Form.List
@@ -369,6 +423,64 @@ export const AddPlanModal = ({
             </Form.List>
           </Form.Item>
 
+          {/* Plan.excludeFiles */}
+          <Form.Item label={<Tooltip title="Files on this host listing patterns to exclude, one per line, passed to restic as --exclude-file.">Exclude Files</Tooltip>} required={false}>
+            <Form.List name="excludeFiles" initialValue={template ? template.excludeFiles : []}>
+              {(fields, { add, remove }, { errors }) => (
+                <>
+                  {fields.map((field) => (
+                    <Form.Item required={false} key={field.key}>
+                      <Form.Item {...field} validateTrigger={["onChange", "onBlur"]} initialValue={""} rules={[{ required: true }]} noStyle>
+                        <URIAutocomplete style={{ width: "90%" }} onBlur={() => form.validateFields()} />
+                      </Form.Item>
+                      <MinusCircleOutlined className="dynamic-delete-button" onClick={() => remove(field.name)} style={{ paddingLeft: "5px" }} />
+                    </Form.Item>
+                  ))}
+                  <Form.Item>
+                    <Button type="dashed" onClick={() => add()} style={{ width: "90%" }} icon={<PlusOutlined />}>
+                      Add Exclude File
+                    </Button>
+                    <Form.ErrorList errors={errors} />
+                  </Form.Item>
+                </>
+              )}
+            </Form.List>
+          </Form.Item>
+
+          {/* Plan.excludeIfPresent */}
+          <Form.Item label={<Tooltip title="Directories containing a file with this name are excluded, e.g. .nobackup. Takes the format filename[:header] to also require the file to start with header.">Exclude If Present</Tooltip>} required={false}>
+            <Form.List name="excludeIfPresent" initialValue={template ? template.excludeIfPresent : []}>
+              {(fields, { add, remove }, { errors }) => (
+                <>
+                  {fields.map((field) => (
+                    <Form.Item required={false} key={field.key}>
+                      <Form.Item {...field} validateTrigger={["onChange", "onBlur"]} initialValue={""} rules={[{ required: true }]} noStyle>
+                        <Input style={{ width: "90%" }} placeholder=".nobackup" />
+                      </Form.Item>
+                      <MinusCircleOutlined className="dynamic-delete-button" onClick={() => remove(field.name)} style={{ paddingLeft: "5px" }} />
+                    </Form.Item>
+                  ))}
+                  <Form.Item>
+                    <Button type="dashed" onClick={() => add()} style={{ width: "90%" }} icon={<PlusOutlined />}>
+                      Add Marker File Name
+                    </Button>
+                    <Form.ErrorList errors={errors} />
+                  </Form.Item>
+                </>
+              )}
+            </Form.List>
+          </Form.Item>
+
+          {/* Plan.exclude_larger_than */}
+          <Form.Item<Plan>
+            name="excludeLargerThan"
+            label={<Tooltip title="Files larger than this size are excluded, a number optionally followed by K, M, G or T e.g. 500M.">Exclude Larger Than</Tooltip>}
+            initialValue={template ? template.excludeLargerThan : ""}
+            rules={[{ pattern: /^[0-9]+[bBkKmMgGtT]?$/, message: "Must be a number optionally followed by K, M, G or T" }]}
+          >
+            <Input style={{ width: "12em" }} placeholder="500M" />
+          </Form.Item>
+
           {/* Plan.cron */}
           <Tooltip title="Cron expression to schedule the plan in 24 hour time">
             <Form.Item<Plan>
@@ -392,6 +504,140 @@ export const AddPlanModal = ({
               />
             </Form.Item>
           </Tooltip>
+          <Form.Item
+            label={<Tooltip title="The schedule as a cron expression, supports @hourly, @daily, @weekly, @monthly and @yearly shorthands and a leading seconds field.">Expression</Tooltip>}
+            shouldUpdate={(prev, cur) => prev.cron !== cur.cron}
+          >
+            {() => (
+              <CronPreview
+                expr={form.getFieldValue("cron") || ""}
+                onChange={(val) => form.setFieldValue("cron", val)}
+              />
+            )}
+          </Form.Item>
+
+          {/* Plan.schedule.interval */}
+          <Form.Item<Plan>
+            name={["schedule", "interval"]}
+            label={<Tooltip title="Run a backup this long after the last successful backup instead of on the cron schedule e.g. 4h or 30m. Missed backups are always caught up and a failed backup is retried after the interval or an hour, whichever is shorter.">Interval</Tooltip>}
+            initialValue={template?.schedule?.interval || ""}
+            rules={[
+              {
+                pattern: /^(\d+(\.\d+)?(h|m|s))*$/,
+                message: "Interval must be a duration e.g. 4h or 1h30m",
+              },
+            ]}
+          >
+            <Input placeholder="leave empty to use the cron schedule" />
+          </Form.Item>
+
+          {/* Plan.after */}
+          <Form.Item label={<Tooltip title="Run a backup of this plan after each successful backup of any of these plans, e.g. to back up to a cloud repo once the local backup finished.">Run After</Tooltip>}>
+            <Form.Item<Plan> name="after" initialValue={template?.after || []} noStyle>
+              <Select
+                mode="multiple"
+                allowClear
+                placeholder="Plans this plan runs after"
+                options={config.plans.filter((p) => p.id !== template?.id).map((p) => ({
+                  value: p.id,
+                }))}
+              />
+            </Form.Item>
+            <Checkbox
+              style={{ marginTop: "5px" }}
+              checked={onlyAfter}
+              onChange={(e) => setOnlyAfter(e.target.checked)}
+            >
+              Only run after these plans, ignore the schedule
+            </Checkbox>
+          </Form.Item>
+
+          {/* Plan.catchup_policy */}
+          <Form.Item<Plan>
+            name="catchupPolicy"
+            label={<Tooltip title="What to do when a scheduled backup is missed because backrest was not running (e.g. the machine was asleep).">Missed Backups</Tooltip>}
+            initialValue={"CATCHUP_POLICY_SKIP"}
+          >
+            <Select
+              options={[
+                { value: "CATCHUP_POLICY_SKIP", label: "Skip, wait for the next scheduled time" },
+                { value: "CATCHUP_POLICY_RUN_IMMEDIATELY", label: "Run immediately" },
+                { value: "CATCHUP_POLICY_RUN_ONCE_WITHIN_WINDOW", label: "Run immediately if missed within window" },
+              ]}
+            />
+          </Form.Item>
+
+          {/* Plan.catchup_window_minutes */}
+          <Form.Item shouldUpdate noStyle>
+            {() =>
+              form.getFieldValue("catchupPolicy") === "CATCHUP_POLICY_RUN_ONCE_WITHIN_WINDOW" ? (
+                <Form.Item<Plan>
+                  name="catchupWindowMinutes"
+                  label={<Tooltip title="A missed backup is only run if backrest starts within this many minutes of the missed time.">Missed Backup Window</Tooltip>}
+                  rules={[{ required: true, message: "Please input a window" }]}
+                >
+                  <InputNumber addonAfter={<div style={{ width: "5em" }}>minutes</div>} type="number" min={1} />
+                </Form.Item>
+              ) : null
+            }
+          </Form.Item>
+
+          {/* Plan.jitter_minutes */}
+          <Form.Item<Plan>
+            name="jitterMinutes"
+            label={<Tooltip title="Delay each scheduled backup by a random duration of up to this many minutes, spreads out plans that share a schedule.">Jitter</Tooltip>}
+          >
+            <InputNumber addonAfter={<div style={{ width: "5em" }}>minutes</div>} type="number" min={0} />
+          </Form.Item>
+
+          {/* Plan.min_interval */}
+          <Form.Item<Plan>
+            name="minInterval"
+            label={<Tooltip title="Schedules firing more often than this are refused, and a scheduled backup due within this long of the last backup is deferred. Guards against a mistyped cron e.g. '* 2 * * *' running every minute. Defaults to 1m, set a lower value to allow a more frequent schedule.">Minimum Interval</Tooltip>}
+            rules={[{ pattern: /^(\d+(\.\d+)?(h|m|s))*$/, message: "Minimum interval must be a duration e.g. 1h or 30m" }]}
+          >
+            <Input placeholder="1m" />
+          </Form.Item>
+
+          {/* Plan.backup_tunables */}
+          <Form.Item label={<Tooltip title="restic backup options tuning how files are read and stored. Unset options use restic's defaults, backup flags may override them.">Backup Tunables</Tooltip>}>
+            <Row gutter={16}>
+              <Col span={8}>
+                <Form.Item<Plan> name={["backupTunables", "readConcurrency"]}>
+                  <InputNumber addonBefore="Read Concurrency" min={0} placeholder="2" />
+                </Form.Item>
+              </Col>
+              <Col span={8}>
+                <Form.Item<Plan> name={["backupTunables", "packSizeMb"]}>
+                  <InputNumber addonBefore="Pack Size" addonAfter="MiB" min={0} max={128} placeholder="16" />
+                </Form.Item>
+              </Col>
+              <Col span={8}>
+                <Form.Item<Plan> name={["backupTunables", "compression"]}>
+                  <Select
+                    placeholder="Compression: auto"
+                    allowClear
+                    options={[
+                      { value: "COMPRESSION_AUTO", label: "Compression: auto" },
+                      { value: "COMPRESSION_OFF", label: "Compression: off" },
+                      { value: "COMPRESSION_FASTEST", label: "Compression: fastest" },
+                      { value: "COMPRESSION_BETTER", label: "Compression: better" },
+                      { value: "COMPRESSION_MAX", label: "Compression: max" },
+                    ]}
+                  />
+                </Form.Item>
+              </Col>
+            </Row>
+            <Form.Item<Plan> name={["backupTunables", "includeCaches"]} valuePropName="checked" noStyle>
+              <Checkbox>Include cache directories (CACHEDIR.TAG)</Checkbox>
+            </Form.Item>
+            <Form.Item<Plan> name={["backupTunables", "oneFileSystem"]} valuePropName="checked" noStyle>
+              <Checkbox>Stay on one filesystem</Checkbox>
+            </Form.Item>
+            <Form.Item<Plan> name={["backupTunables", "ignoreInode"]} valuePropName="checked" noStyle>
+              <Checkbox>Ignore inode numbers</Checkbox>
+            </Form.Item>
+          </Form.Item>
 
           {/* Plan.backup_flags */}
           <Form.Item label={<Tooltip title="Extra flags to add to the 'restic backup' command">Backup Flags</Tooltip>}>
@@ -441,6 +687,66 @@ export const AddPlanModal = ({
             </Form.List>
           </Form.Item>
 
+          {/* Plan.env */}
+          <Form.Item label={<Tooltip title={"Environment variables set for the restic commands run for this plan and for its hooks, pre and post backup and file list commands e.g. HTTPS_PROXY or GODEBUG. "
+            + "They take precedence over the repo's env vars, references to parent-process env variables are supported as ${MY_FOO_VAR}. "
+            + "Variables backrest sets itself e.g. RESTIC_PASSWORD or BACKREST_PLAN_ID can't be overridden. Values marked secret are redacted from logs."}>
+            Environment Variables
+          </Tooltip>}>
+            <Form.List name="env">
+              {(fields, { add, remove }) => (
+                <>
+                  {fields.map((field, index) => (
+                    <Row key={field.key} gutter={8} style={{ marginBottom: "8px" }}>
+                      <Col span={9}>
+                        <Form.Item
+                          name={[field.name, "name"]}
+                          rules={[
+                            { required: true, pattern: /^[A-Za-z_][A-Za-z0-9_]*$/, message: "Invalid environment variable name" },
+                            { pattern: /^(?!BACKREST_)/, message: "Variables prefixed BACKREST_ are set by backrest" },
+                          ]}
+                          noStyle
+                        >
+                          <Input placeholder="HTTPS_PROXY" />
+                        </Form.Item>
+                      </Col>
+                      <Col span={9}>
+                        <Form.Item noStyle shouldUpdate>
+                          {({ getFieldValue }) => (
+                            <Form.Item name={[field.name, "value"]} noStyle>
+                              {getFieldValue(["env", field.name, "secret"]) ? <Input.Password placeholder="value" /> : <Input placeholder="value" />}
+                            </Form.Item>
+                          )}
+                        </Form.Item>
+                      </Col>
+                      <Col span={4}>
+                        <Form.Item name={[field.name, "secret"]} valuePropName="checked" noStyle>
+                          <Checkbox>Secret</Checkbox>
+                        </Form.Item>
+                      </Col>
+                      <Col span={1}>
+                        <MinusCircleOutlined
+                          className="dynamic-delete-button"
+                          onClick={() => remove(index)}
+                        />
+                      </Col>
+                    </Row>
+                  ))}
+                  <Form.Item>
+                    <Button
+                      type="dashed"
+                      onClick={() => add({ name: "", value: "", secret: false })}
+                      style={{ width: "90%" }}
+                      icon={<PlusOutlined />}
+                    >
+                      Set Env Var
+                    </Button>
+                  </Form.Item>
+                </>
+              )}
+            </Form.List>
+          </Form.Item>
+
           {/* Plan.retention */}
           <RetentionPolicyView />
 
@@ -451,6 +757,97 @@ export const AddPlanModal = ({
             <HooksFormList />
           </Form.Item>
 
+          {/* Plan.pre_backup */}
+          <BackupCommandFormItem
+            name="preBackup"
+            label="Pre Backup Command"
+            tooltip="Shell command run before each backup e.g. to dump a database. BACKREST_PLAN_ID and BACKREST_REPO_ID are set in its environment."
+          />
+
+          {/* Plan.post_backup */}
+          <BackupCommandFormItem
+            name="postBackup"
+            label="Post Backup Command"
+            tooltip="Shell command run after each backup, even if it failed. BACKREST_SNAPSHOT_ID and BACKREST_BACKUP_STATUS are additionally set in its environment."
+          />
+
+
+          {/* Plan.copy_to */}
+          <Form.Item label={<Tooltip title="Copies the plan's snapshots to a second repo with restic copy e.g. to keep an offsite copy. The second repo's retention is not managed by this plan.">Copy To</Tooltip>}>
+            <Form.Item name={["copyTo", "repo"]} noStyle>
+              <Select
+                allowClear
+                placeholder="Repo to copy snapshots to"
+                options={repos.filter((repo) => repo.id !== form.getFieldValue("repo")).map((repo) => ({
+                  value: repo.id,
+                }))}
+              />
+            </Form.Item>
+            <Form.Item name={["copyTo", "cron"]} noStyle>
+              <Input
+                placeholder="Cron schedule, copies after each backup if unset"
+                style={{ marginTop: "5px" }}
+              />
+            </Form.Item>
+          </Form.Item>
+
+          {/* Plan.tags */}
+          <Form.Item<Plan>
+            name="tags"
+            label={<Tooltip title="Tags added to the plan's snapshots, e.g. to filter them in restic snapshots --tag. Tags starting with plan: or created-by: are reserved.">Snapshot Tags</Tooltip>}
+            initialValue={template ? template.tags : []}
+          >
+            <Select mode="tags" placeholder="Tags" tokenSeparators={[","]} />
+          </Form.Item>
+
+          {/* Plan.history */}
+          <Form.Item label={<Tooltip title="Keeps the plan's history together after moving it to another machine. The hostname is recorded in new snapshots, e.g. the old machine's hostname. Snapshots the plan created under the former instance IDs count towards its snapshots and retention.">Migration History</Tooltip>}>
+            <Form.Item name={["history", "hostname"]} noStyle>
+              <Input placeholder="Hostname, defaults to this machine's hostname" />
+            </Form.Item>
+            <Form.Item name={["history", "formerInstances"]} noStyle>
+              <Select
+                mode="tags"
+                placeholder="Former instance IDs"
+                style={{ marginTop: "5px" }}
+                tokenSeparators={[",", " "]}
+              />
+            </Form.Item>
+          </Form.Item>
+
+          {/* Plan.run_conditions */}
+          <Form.Item label={<Tooltip title="Scheduled backups are skipped when a condition is not met. Backups started manually always run.">Run Conditions</Tooltip>}>
+            <Form.Item name={["runConditions", "skipOnBattery"]} valuePropName="checked" noStyle>
+              <Checkbox>Skip on battery</Checkbox>
+            </Form.Item>
+            <Form.Item name={["runConditions", "requireAcPower"]} valuePropName="checked" noStyle>
+              <Checkbox>Require AC power</Checkbox>
+            </Form.Item>
+            <Form.Item name={["runConditions", "skipOnMeteredNetwork"]} valuePropName="checked" noStyle>
+              <Checkbox>Skip on metered network</Checkbox>
+            </Form.Item>
+          </Form.Item>
+
+          {/* Plan.timeout */}
+          <Form.Item<Plan>
+            name="timeout"
+            label={<Tooltip title="Maximum duration of the pre backup command and backup e.g. 6h. Restic is stopped and the backup fails once it passes. Leave empty for no limit.">Timeout</Tooltip>}
+            rules={[
+              {
+                pattern: /^(\d+(\.\d+)?(h|m|s))*$/,
+                message: "Timeout must be a duration e.g. 6h or 1h30m",
+              },
+            ]}
+          >
+            <Input placeholder="no limit" />
+          </Form.Item>
+
+          {/* Plan.skip_if_unchanged */}
+          <Form.Item label={<Tooltip title="Don't keep a snapshot when no files changed since the plan's last snapshot. The backup is still recorded, as having no changes.">
+            Skip Unchanged
+          </Tooltip>} name="skipIfUnchanged" valuePropName="checked">
+            <Checkbox />
+          </Form.Item>
 
           {/* Disabled? toggles whether the plan will be scheduled. */}
           <Form.Item label={<Tooltip title={"Toggles whether the plan's scheduling is enabled. If disabled no scheduled operations will be run."}>
@@ -641,3 +1038,30 @@ const RetentionPolicyView = () => {
     </>
   );
 };
+
+const BackupCommandFormItem = ({ name, label, tooltip }: { name: string; label: string; tooltip: string }) => {
+  return (
+    <Form.Item label={<Tooltip title={tooltip}>{label}</Tooltip>}>
+      <Form.Item name={[name, "command"]}>
+        <Input.TextArea placeholder="e.g. pg_dump mydb > /backups/mydb.sql" autoSize={{ minRows: 1 }} />
+      </Form.Item>
+      <Row gutter={16}>
+        <Col span={12}>
+          <Form.Item name={[name, "timeoutSeconds"]} label="Timeout" initialValue={0}>
+            <InputNumber addonAfter={<div style={{ width: "5em" }}>seconds</div>} type="number" min={0} />
+          </Form.Item>
+        </Col>
+        <Col span={12}>
+          <Form.Item name={[name, "onFailure"]} label="On Failure" initialValue={"FAILURE_POLICY_ABORT"}>
+            <Select
+              options={[
+                { value: "FAILURE_POLICY_ABORT", label: "Fail the backup" },
+                { value: "FAILURE_POLICY_WARN", label: "Warn and continue" },
+              ]}
+            />
+          </Form.Item>
+        </Col>
+      </Row>
+    </Form.Item>
+  );
+};
@@ -13,6 +13,7 @@ import {
   FormInstance,
   Collapse,
   Checkbox,
+  Select,
 } from "antd";
 import React, { useEffect, useState } from "react";
 import { useShowModal } from "../components/ModalManager";
@@ -105,6 +106,14 @@ export const AddRepoModal = ({
         }
         config.repos![idx] = repo;
         setConfig(await backrestService.setConfig(config));
+        if (repo.limitUploadKbps !== template.limitUploadKbps || repo.limitDownloadKbps !== template.limitDownloadKbps) {
+          // restarts the repo's backups in progress so that they pick up the new limits.
+          setConfig(await backrestService.setBandwidthLimit({
+            repoId: repo.id,
+            limitUploadKbps: repo.limitUploadKbps,
+            limitDownloadKbps: repo.limitDownloadKbps,
+          }));
+        }
         showModal(null);
         alertsApi.success("Updated repo " + repo.uri);
 
@@ -390,6 +399,28 @@ export const AddRepoModal = ({
             </Form.List>
           </Form.Item>
 
+          {/* Repo.backendOptions */}
+          <Form.Item label={<Tooltip title={"Options for the repo's backend passed to every restic command for the repo, checked when the repo is saved. "
+            + "Backend options are passed as -o NAME=VALUE and must be prefixed by the repo's backend e.g. sftp.command, rclone.program or rest.connections. "
+            + "Backend env vars e.g. RCLONE_CONFIG take precedence over the repo's env vars, references to parent-process env variables are supported as ${MY_FOO_VAR}."}>
+            Backend Options
+          </Tooltip>}>
+            <NameValueFormList
+              name={["backendOptions", "options"]}
+              namePlaceholder="sftp.command"
+              namePattern={/^[a-z0-9]+\.[a-z0-9-]+$/}
+              nameMessage="Option must take format BACKEND.OPTION e.g. sftp.command"
+              addLabel="Set Backend Option"
+            />
+            <NameValueFormList
+              name={["backendOptions", "env"]}
+              namePlaceholder="RCLONE_CONFIG"
+              namePattern={/^[A-Za-z_][A-Za-z0-9_]*$/}
+              nameMessage="Invalid environment variable name"
+              addLabel="Set Backend Env Var"
+            />
+          </Form.Item>
+
           {/* Repo.prunePolicy */}
           <Form.Item
             required={false}
@@ -434,6 +465,46 @@ export const AddRepoModal = ({
                 }
               />
             </Form.Item>
+            <Form.Item
+              name={["prunePolicy", "cooldownDays"]}
+              initialValue={0}
+              required={false}
+            >
+              <InputNumber
+                min={0}
+                addonBefore={
+                  <Tooltip title="Snapshots selected for removal by a plan's retention policy are kept for this many days before they're forgotten and pruned, a mistaken retention policy can be fixed in the meantime. 0 removes them right away.">
+                    <div style={{ width: "12em" }}>Cooldown Days</div>
+                  </Tooltip>
+                }
+              />
+            </Form.Item>
+          </Form.Item>
+
+          {/* Repo.group */}
+          <Form.Item<Repo>
+            name="group"
+            label={<Tooltip title="Optional group to inherit shared settings (env vars, flags, prune policy, hooks, and bandwidth limits) from. Settings on the repo take precedence.">Group</Tooltip>}
+          >
+            <Select
+              allowClear
+              options={(config?.repoGroups || []).map((group) => ({
+                value: group.id,
+              }))}
+            />
+          </Form.Item>
+
+          {/* Repo.namespace */}
+          <Form.Item<Repo>
+            name="namespace"
+            label={<Tooltip title="Optional namespace the repo and its plans belong to, only users in the namespace and users without a namespace can see them.">Namespace</Tooltip>}
+          >
+            <Select
+              allowClear
+              options={(config?.namespaces || []).map((ns) => ({
+                value: ns.id,
+              }))}
+            />
           </Form.Item>
 
           <Form.Item label={<Tooltip title={"Auto-unlock will remove lockfiles at the start of forget and prune operations. "
@@ -443,6 +514,66 @@ export const AddRepoModal = ({
             <Checkbox />
           </Form.Item>
 
+          <Form.Item label={<Tooltip title={"Absolute path of the restic binary used for this repo e.g. to use a newer restic version than the one backrest installs. Defaults to backrest's restic binary."}>
+            Restic Binary Path
+          </Tooltip>} name="resticBinaryPath">
+            <Input placeholder="/usr/local/bin/restic" />
+          </Form.Item>
+
+          <Form.Item label={<Tooltip title={"Locks that have not been refreshed for this many minutes are removed before backups and maintenance operations, "
+            + "e.g. locks left behind by a crashed restic process. restic refreshes the locks of running commands every 5 minutes. 0 disables stale lock removal."}>
+            Stale Lock Age (Minutes)
+          </Tooltip>} name="staleLockMinutes">
+            <InputNumber min={0} />
+          </Form.Item>
+
+          <Form.Item label={<Tooltip title={"Upload and download bandwidth limits in KiB/s passed to restic as --limit-upload and --limit-download. 0 for unlimited or to use the repo group's limits. Changing the limits restarts backups in progress."}>
+            Bandwidth Limits
+          </Tooltip>}>
+            <Row gutter={16}>
+              <Col span={12}>
+                <Form.Item name="limitUploadKbps" noStyle>
+                  <InputNumber min={0} addonBefore="Upload KiB/s" />
+                </Form.Item>
+              </Col>
+              <Col span={12}>
+                <Form.Item name="limitDownloadKbps" noStyle>
+                  <InputNumber min={0} addonBefore="Download KiB/s" />
+                </Form.Item>
+              </Col>
+            </Row>
+          </Form.Item>
+
+          <Form.Item label={<Tooltip title={"Cron expression for when the repo's size stats are collected for its growth graph e.g. \"0 3 * * *\". Defaults to daily at midnight."}>
+            Stats Schedule
+          </Tooltip>} name="statsCron">
+            <Input placeholder="@daily" />
+          </Form.Item>
+
+          <Form.Item label={<Tooltip title={"Cron expression for when the repo's snapshots are indexed e.g. \"*/15 * * * *\". Snapshots created outside of backrest are found and run the repo's CONDITION_SNAPSHOT_EXTERNAL hooks. Snapshots are otherwise only indexed after backrest's own operations."}>
+            Index Schedule
+          </Tooltip>} name="indexCron">
+            <Input placeholder="disabled" />
+          </Form.Item>
+
+          <Form.Item label={<Tooltip title={"Absolute path of the directory restic caches the repo's metadata in, passed as --cache-dir. Defaults to restic's cache directory e.g. ~/.cache/restic."}>
+            Cache Directory
+          </Tooltip>} name="cacheDir">
+            <Input placeholder="restic default" />
+          </Form.Item>
+
+          <Form.Item label={<Tooltip title={"The repo's cache is cleared by the cache cleanup once it's larger than this many MiB, restic rebuilds it as needed. 0 for no limit."}>
+            Max Cache Size (MiB)
+          </Tooltip>} name="maxCacheSizeMb">
+            <InputNumber min={0} />
+          </Form.Item>
+
+          <Form.Item label={<Tooltip title={"Cron expression for when restic cache --cleanup removes the caches of repos unused for 30 days and the max cache size is enforced. Defaults to daily if a max cache size is set, disabled otherwise."}>
+            Cache Cleanup Schedule
+          </Tooltip>} name="cacheCleanupCron">
+            <Input placeholder="@daily if a max cache size is set" />
+          </Form.Item>
+
           <Form.Item
             label={<Tooltip title={hooksListTooltipText}>Hooks</Tooltip>}
           >
@@ -473,6 +604,62 @@ export const AddRepoModal = ({
   );
 };
 
+// NameValueFormList edits a list of {name, value} pairs e.g. backend options.
+const NameValueFormList = ({
+  name,
+  namePlaceholder,
+  namePattern,
+  nameMessage,
+  addLabel,
+}: {
+  name: string[];
+  namePlaceholder: string;
+  namePattern: RegExp;
+  nameMessage: string;
+  addLabel: string;
+}) => (
+  <Form.List name={name}>
+    {(fields, { add, remove }) => (
+      <>
+        {fields.map((field, index) => (
+          <Row key={field.key} gutter={8} style={{ marginBottom: "8px" }}>
+            <Col span={10}>
+              <Form.Item
+                name={[field.name, "name"]}
+                rules={[{ required: true, pattern: namePattern, message: nameMessage }]}
+                noStyle
+              >
+                <Input placeholder={namePlaceholder} />
+              </Form.Item>
+            </Col>
+            <Col span={11}>
+              <Form.Item name={[field.name, "value"]} noStyle>
+                <Input placeholder="value" />
+              </Form.Item>
+            </Col>
+            <Col span={1}>
+              <MinusCircleOutlined
+                className="dynamic-delete-button"
+                onClick={() => remove(index)}
+              />
+            </Col>
+          </Row>
+        ))}
+        <Form.Item>
+          <Button
+            type="dashed"
+            onClick={() => add({ name: "", value: "" })}
+            style={{ width: "90%" }}
+            icon={<PlusOutlined />}
+          >
+            {addLabel}
+          </Button>
+        </Form.Item>
+      </>
+    )}
+  </Form.List>
+);
+
 const expectedEnvVars: { [scheme: string]: string[][] } = {
   s3: [["AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"]],
   b2: [["B2_ACCOUNT_ID", "B2_ACCOUNT_KEY"]],
@@ -500,6 +687,8 @@ const envVarSetValidator = (form: FormInstance<FormData>, envVars: string[]) =>
     }
     return e.substring(0, idx);
   });
+  const backendEnv: { name?: string }[] = form.getFieldValue(["backendOptions", "env"]) || [];
+  envVarNames.push(...backendEnv.map((e) => e?.name || ""));
 
   // check that password is provided in some form
   const password = form.getFieldValue("password");
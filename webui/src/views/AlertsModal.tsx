@@ -0,0 +1,185 @@
+import React, { useEffect, useState } from "react";
+import { Button, Dropdown, Empty, List, Modal, Tag, Typography } from "antd";
+import { useShowModal } from "../components/ModalManager";
+import { useAlertApi } from "../components/Alerts";
+import { backrestService } from "../api";
+import { Alert, Alert_Kind, SnoozeAlertRequest } from "../../gen/ts/v1/alerts_pb";
+import { HookDeliveryList } from "../../gen/ts/v1/hookdelivery_pb";
+import { formatTime } from "../lib/formatting";
+
+const snoozeOptions = [
+  { key: "60", label: "1 hour" },
+  { key: "1440", label: "1 day" },
+  { key: "10080", label: "1 week" },
+];
+
+const kindLabel = (kind: Alert_Kind) => {
+  switch (kind) {
+    case Alert_Kind.KIND_BACKUP_FAILED:
+      return "Backup failed";
+    case Alert_Kind.KIND_MISSED_SCHEDULE:
+      return "Missed schedule";
+    case Alert_Kind.KIND_STAGING_QUOTA:
+      return "Staging quota";
+    case Alert_Kind.OPLOG_INCONSISTENT:
+      return "Oplog inconsistent";
+    default:
+      return "Alert";
+  }
+};
+
+// AlertsModal lists the current alerts, alerts can be acknowledged or snoozed to suppress their repeat notifications.
+// Hook notifications that couldn't be delivered are listed below the alerts.
+export const AlertsModal = () => {
+  const showModal = useShowModal();
+  const alertApi = useAlertApi()!;
+  const [alerts, setAlerts] = useState<Alert[] | null>(null);
+  const [deliveries, setDeliveries] = useState<HookDeliveryList | null>(null);
+
+  const load = async () => {
+    try {
+      setAlerts((await backrestService.getAlerts({})).alerts);
+      setDeliveries(await backrestService.getHookDeliveries({}));
+    } catch (e: any) {
+      alertApi.error("Failed to load alerts: " + e.message);
+    }
+  };
+
+  const retryDelivery = async (id: bigint) => {
+    try {
+      await backrestService.retryHookDelivery({ value: id });
+      await load();
+    } catch (e: any) {
+      alertApi.error("Failed to retry notification: " + e.message);
+    }
+  };
+
+  useEffect(() => {
+    load();
+  }, []);
+
+  const update = async (fn: () => Promise<Alert>) => {
+    try {
+      await fn();
+      await load();
+    } catch (e: any) {
+      alertApi.error("Failed to update alert: " + e.message);
+    }
+  };
+
+  const stateDescription = (alert: Alert) => {
+    const state = alert.state;
+    if (!state) return null;
+    if (state.acknowledgedMs) {
+      return `Acknowledged${state.acknowledgedBy ? " by " + state.acknowledgedBy : ""} at ${formatTime(Number(state.acknowledgedMs))}`;
+    }
+    if (Number(state.snoozedUntilMs) > Date.now()) {
+      return `Snoozed until ${formatTime(Number(state.snoozedUntilMs))}`;
+    }
+    return null;
+  };
+
+  return (
+    <Modal
+      open={true}
+      title="Alerts"
+      width="60vw"
+      footer={null}
+      onCancel={() => showModal(null)}
+    >
+      {alerts && alerts.length === 0 ? (
+        <Empty description="No alerts, everything is healthy." />
+      ) : (
+        <List
+          loading={alerts === null}
+          dataSource={alerts || []}
+          renderItem={(alert) => (
+            <List.Item
+              actions={[
+                <Button
+                  key="acknowledge"
+                  size="small"
+                  disabled={!!alert.state?.acknowledgedMs}
+                  onClick={() => update(() => backrestService.acknowledgeAlert({ value: alert.id }))}
+                >
+                  Acknowledge
+                </Button>,
+                <Dropdown
+                  key="snooze"
+                  menu={{
+                    items: snoozeOptions,
+                    onClick: ({ key }) =>
+                      update(() =>
+                        backrestService.snoozeAlert(
+                          new SnoozeAlertRequest({ id: alert.id, durationMinutes: BigInt(key) })
+                        )
+                      ),
+                  }}
+                >
+                  <Button size="small">Snooze</Button>
+                </Dropdown>,
+              ]}
+            >
+              <List.Item.Meta
+                title={
+                  <>
+                    <Tag color="red">{kindLabel(alert.kind)}</Tag>
+                    {alert.planId || alert.repoId || alert.namespace}
+                  </>
+                }
+                description={
+                  <>
+                    {alert.message}
+                    {alert.sinceMs ? <div>Since {formatTime(Number(alert.sinceMs))}</div> : null}
+                    {stateDescription(alert) ? (
+                      <Typography.Text type="secondary">{stateDescription(alert)}</Typography.Text>
+                    ) : null}
+                  </>
+                }
+              />
+            </List.Item>
+          )}
+        />
+      )}
+      {deliveries && (deliveries.pending.length > 0 || deliveries.deadLetters.length > 0) ? (
+        <>
+          <Typography.Title level={5}>Undelivered Notifications</Typography.Title>
+          {deliveries.pending.length > 0 ? (
+            <Typography.Text type="secondary">
+              {deliveries.pending.length} notification(s) waiting to be retried.
+            </Typography.Text>
+          ) : null}
+          <List
+            dataSource={deliveries.deadLetters}
+            renderItem={(d) => (
+              <List.Item
+                actions={[
+                  <Button key="retry" size="small" onClick={() => retryDelivery(d.id)}>
+                    Retry
+                  </Button>,
+                ]}
+              >
+                <List.Item.Meta
+                  title={
+                    <>
+                      <Tag color="orange">Gave up</Tag>
+                      {d.hook} to {d.destination}
+                    </>
+                  }
+                  description={
+                    <>
+                      {d.lastError}
+                      <div>
+                        Sent {formatTime(Number(d.createdMs))}, {d.attempts} attempts
+                      </div>
+                    </>
+                  }
+                />
+              </List.Item>
+            )}
+          />
+        </>
+      ) : null}
+    </Modal>
+  );
+};
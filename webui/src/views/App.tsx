@@ -7,6 +7,8 @@ import {
   ExclamationOutlined,
   SettingOutlined,
   LoadingOutlined,
+  SearchOutlined,
+  BellOutlined,
 } from "@ant-design/icons";
 import type { MenuProps } from "antd";
 import { Button, Layout, Menu, Spin, theme } from "antd";
@@ -89,6 +91,36 @@ export const App: React.FC = () => {
     }
   }, [config === null]);
 
+  // links to plans and repos, e.g. from search results, have the form #/plan/<id> or #/repo/<id>.
+  useEffect(() => {
+    if (!config) return;
+    const navigate = async () => {
+      const match = window.location.hash.match(/^#\/(plan|repo)\/([^?]+)/);
+      if (!match) return;
+      const id = decodeURIComponent(match[2]);
+      if (match[1] === "plan") {
+        const plan = config.plans.find((p) => p.id === id);
+        if (!plan) return;
+        const { PlanView } = await import("./PlanView");
+        setContent(<PlanView key={plan.id} plan={plan} />, [
+          { title: "Plans" },
+          { title: plan.id || "" },
+        ]);
+      } else {
+        const repo = config.repos.find((r) => r.id === id);
+        if (!repo) return;
+        const { RepoView } = await import("./RepoView");
+        setContent(<RepoView key={repo.id} repo={repo} />, [
+          { title: "Repos" },
+          { title: repo.id || "" },
+        ]);
+      }
+    };
+    navigate();
+    window.addEventListener("hashchange", navigate);
+    return () => window.removeEventListener("hashchange", navigate);
+  }, [config]);
+
 
   const items = getSidenavItems(config);
 
@@ -123,6 +155,28 @@ export const App: React.FC = () => {
           <small style={{ color: "rgba(255,255,255,0.3)", fontSize: "0.6em" }}>
             {config && config.instance ? config.instance : undefined}
           </small>
+          <Button
+            type="text"
+            style={{ marginLeft: "10px", color: "white", visibility: config ? "visible" : "hidden" }}
+            icon={<BellOutlined />}
+            onClick={async () => {
+              const { AlertsModal } = await import("./AlertsModal");
+              showModal(<AlertsModal />);
+            }}
+          >
+            Alerts
+          </Button>
+          <Button
+            type="text"
+            style={{ marginLeft: "10px", color: "white", visibility: config ? "visible" : "hidden" }}
+            icon={<SearchOutlined />}
+            onClick={async () => {
+              const { SearchModal } = await import("./SearchModal");
+              showModal(<SearchModal />);
+            }}
+          >
+            Search
+          </Button>
           <Button
             type="text"
             style={{ marginLeft: "10px", color: "white", visibility: config?.auth?.disabled ? "hidden" : "visible" }}
@@ -9,9 +9,34 @@ import { backrestService } from "../api";
 import { GetOperationsRequest } from "../../gen/ts/v1/service_pb";
 import { SpinButton } from "../components/SpinButton";
 import { shouldHideStatus } from "../state/oplog";
+import { PauseButton, PausedTag } from "../components/PauseButton";
+import { useConfig } from "../components/ConfigProvider";
+import { RestorePoints } from "../components/RestorePoints";
+import { SchedulerDecisions } from "../components/SchedulerDecisions";
+import { PlanStats } from "../components/PlanStats";
+import { formatBytes, formatDuration } from "../lib/formatting";
 
 export const PlanView = ({ plan }: React.PropsWithChildren<{ plan: Plan }>) => {
   const alertsApi = useAlertApi()!;
+  const [config] = useConfig();
+  const [schedule, setSchedule] = useState<string | null>(null);
+
+  // read the plan from the config so that changes e.g. pausing the plan are reflected.
+  plan = config?.plans?.find((p) => p.id === plan.id) || plan;
+
+  useEffect(() => {
+    if (plan.schedule?.interval) {
+      setSchedule(`every ${plan.schedule.interval} after the last successful backup`);
+      return;
+    }
+    if (!plan.cron && plan.after.length > 0) {
+      setSchedule(`after successful backups of ${plan.after.join(", ")}`);
+      return;
+    }
+    backrestService.describeCron({ value: plan.cron })
+      .then((res) => setSchedule(res.value))
+      .catch(() => setSchedule(null));
+  }, [plan.cron, plan.schedule?.interval, plan.after.join(",")]);
 
   const handleBackupNow = async () => {
     try {
@@ -22,6 +47,19 @@ export const PlanView = ({ plan }: React.PropsWithChildren<{ plan: Plan }>) => {
     }
   };
 
+  const handlePreviewBackup = async () => {
+    try {
+      const preview = await backrestService.previewBackup({ value: plan.id });
+      alertsApi.info(
+        `Backup would add ${formatBytes(Number(preview.bytesAdded))} for ${preview.filesNew} new and ${preview.filesChanged} changed files, ` +
+          `${preview.totalFiles} files (${formatBytes(Number(preview.totalBytes))}) in total. Scanned in ${formatDuration(Number(preview.durationMs))}.`,
+        15
+      );
+    } catch (e: any) {
+      alertsApi.error("Failed to preview backup: " + e.message);
+    }
+  };
+
   const handlePruneNow = async () => {
     try {
       await backrestService.prune({ value: plan.id });
@@ -57,11 +95,18 @@ export const PlanView = ({ plan }: React.PropsWithChildren<{ plan: Plan }>) => {
         <Typography.Title>
           {plan.id}
         </Typography.Title>
+        {schedule ? <Typography.Text type="secondary">Runs {schedule}</Typography.Text> : null}
+        <PausedTag paused={plan.paused} />
       </Flex>
       <Flex gap="small" align="center" wrap="wrap">
         <SpinButton type="primary" onClickAsync={handleBackupNow}>
           Backup Now
         </SpinButton>
+        <Tooltip title="Runs restic backup --dry-run to show what a backup would add to the repository, e.g. to check the plan's excludes. The pre backup command is not run">
+          <SpinButton type="default" onClickAsync={handlePreviewBackup}>
+            Preview Backup
+          </SpinButton>
+        </Tooltip>
         <Tooltip title="Runs a prune operation on the repository that will remove old snapshots and free up space">
           <SpinButton type="default" onClickAsync={handlePruneNow}>
             Prune Now
@@ -72,6 +117,7 @@ export const PlanView = ({ plan }: React.PropsWithChildren<{ plan: Plan }>) => {
             Unlock Repo
           </SpinButton>
         </Tooltip>
+        <PauseButton planId={plan.id} paused={plan.paused} />
         <Tooltip title="Removes failed operations from the list">
           <SpinButton type="default" onClickAsync={handleClearErrorHistory}>
             Clear Error History
@@ -107,6 +153,24 @@ export const PlanView = ({ plan }: React.PropsWithChildren<{ plan: Plan }>) => {
             ),
             destroyInactiveTabPane: true,
           },
+          {
+            key: "3",
+            label: "Restore Points",
+            children: <RestorePoints repoId={plan.repo!} planId={plan.id!} />,
+            destroyInactiveTabPane: true,
+          },
+          {
+            key: "4",
+            label: "Scheduler",
+            children: <SchedulerDecisions planId={plan.id!} />,
+            destroyInactiveTabPane: true,
+          },
+          {
+            key: "5",
+            label: "Statistics",
+            children: <PlanStats planId={plan.id!} />,
+            destroyInactiveTabPane: true,
+          },
         ]}
       />
     </>
@@ -4,20 +4,26 @@ import { Col, Empty, Flex, Row, Spin, TabsProps, Tabs, Tooltip, Typography } fro
 import { OperationList } from "../components/OperationList";
 import { OperationTree } from "../components/OperationTree";
 import { MAX_OPERATION_HISTORY, STATS_OPERATION_HISTORY } from "../constants";
-import { GetOperationsRequest } from "../../gen/ts/v1/service_pb";
-import { BackupInfo, BackupInfoCollector, getOperations, shouldHideStatus } from "../state/oplog";
+import { GetOperationsRequest, RepoStatsHistoryRequest, RepoStatsHistory_Entry } from "../../gen/ts/v1/service_pb";
+import { BackupInfo, shouldHideStatus } from "../state/oplog";
 import { formatBytes, formatDate, formatTime } from "../lib/formatting";
-import { Operation, OperationStats, OperationStatus } from "../../gen/ts/v1/operations_pb";
 import { backrestService } from "../api";
 import { StringValue } from "@bufbuild/protobuf";
 import { SpinButton } from "../components/SpinButton";
 import { useConfig } from "../components/ConfigProvider";
 import { useAlertApi } from "../components/Alerts";
 import { LineChart } from "@mui/x-charts";
+import { PauseButton, PausedTag } from "../components/PauseButton";
+import { FindFiles } from "../components/FindFiles";
+import { RepoKeys } from "../components/RepoKeys";
+import { MountButton } from "../components/MountButton";
+import { RepoUpgradeModal } from "../components/RepoUpgrade";
+import { useShowModal } from "../components/ModalManager";
 
 
 export const RepoView = ({ repo }: React.PropsWithChildren<{ repo: Repo }>) => {
   const [config, setConfig] = useConfig();
+  const showModal = useShowModal();
 
   // Task handlers
   const handleIndexNow = async () => {
@@ -28,6 +34,18 @@ export const RepoView = ({ repo }: React.PropsWithChildren<{ repo: Repo }>) => {
     await backrestService.stats(new StringValue({ value: repo.id! }));
   }
 
+  const handleCheckNow = async () => {
+    await backrestService.check(new StringValue({ value: repo.id! }));
+  }
+
+  const handleUpgrade = async () => {
+    showModal(<RepoUpgradeModal repoId={repo.id!} />);
+  }
+
+  const handleRecoverNow = async () => {
+    await backrestService.recoverRepo(new StringValue({ value: repo.id! }));
+  }
+
   // Gracefully handle deletions by checking if the plan is still in the config.
   let repoInConfig = config?.repos?.find((r) => r.id === repo.id);
   if (!repoInConfig) {
@@ -79,6 +97,27 @@ export const RepoView = ({ repo }: React.PropsWithChildren<{ repo: Repo }>) => {
       ),
       destroyInactiveTabPane: true,
     },
+    {
+      key: "4",
+      label: "Find Files",
+      children: (
+        <>
+          <h3>Find Files in Backups</h3>
+          <FindFiles repoId={repo.id!} />
+        </>
+      ),
+    },
+    {
+      key: "5",
+      label: "Keys",
+      children: (
+        <>
+          <h3>Repository Keys</h3>
+          <RepoKeys repoId={repo.id!} />
+        </>
+      ),
+      destroyInactiveTabPane: true,
+    },
   ]
   return (
     <>
@@ -86,6 +125,7 @@ export const RepoView = ({ repo }: React.PropsWithChildren<{ repo: Repo }>) => {
         <Typography.Title>
           {repo.id}
         </Typography.Title>
+        <PausedTag paused={repo.paused} />
       </Flex>
       <Flex gap="small" align="center" wrap="wrap">
         <Tooltip title="Indexes the snapshots in the repository. Snapshots are also indexed automatically after each backup.">
@@ -99,6 +139,28 @@ export const RepoView = ({ repo }: React.PropsWithChildren<{ repo: Repo }>) => {
             Compute Stats
           </SpinButton>
         </Tooltip>
+
+        <Tooltip title="Runs restic check on the repository to verify its integrity, this may be a slow operation">
+          <SpinButton type="default" onClickAsync={handleCheckNow}>
+            Check Now
+          </SpinButton>
+        </Tooltip>
+
+        <Tooltip title="Checks whether the repository can be upgraded to the v2 format, which supports compression, or has data stored uncompressed, and upgrades it once confirmed">
+          <SpinButton type="default" onClickAsync={handleUpgrade}>
+            Upgrade Repo
+          </SpinButton>
+        </Tooltip>
+
+        <Tooltip title="Runs restic recover to save the data of forgotten snapshots into a new snapshot tagged 'recovered'. Only data that wasn't pruned yet can be recovered, the original snapshot times, hosts and tags are lost">
+          <SpinButton type="default" onClickAsync={handleRecoverNow}>
+            Recover Forgotten Snapshots
+          </SpinButton>
+        </Tooltip>
+
+        <MountButton repoId={repo.id!} />
+
+        <PauseButton repoId={repo.id} paused={repo.paused} />
       </Flex>
       <Tabs
         defaultActiveKey={items[0].key}
@@ -109,7 +171,7 @@ export const RepoView = ({ repo }: React.PropsWithChildren<{ repo: Repo }>) => {
 };
 
 const StatsPanel = ({ repoId }: { repoId: string }) => {
-  const [operations, setOperations] = useState<Operation[]>([]);
+  const [entries, setEntries] = useState<RepoStatsHistory_Entry[]>([]);
   const alertApi = useAlertApi();
 
   useEffect(() => {
@@ -117,40 +179,33 @@ const StatsPanel = ({ repoId }: { repoId: string }) => {
       return;
     }
 
-    const backupCollector = new BackupInfoCollector((op) => {
-      return op.status === OperationStatus.STATUS_SUCCESS && op.op.case === "operationStats" && !!op.op.value.stats
-    });
-
-    getOperations(new GetOperationsRequest({ repoId: repoId, lastN: BigInt(MAX_OPERATION_HISTORY) }))
-      .then((ops) => {
-        backupCollector.bulkAddOperations(ops);
-
-        const operations = backupCollector.getAll().flatMap((b) => b.operations);
-        operations.sort((a, b) => {
-          return Number(b.unixTimeEndMs - a.unixTimeEndMs);
-        });
-        setOperations(operations);
+    backrestService
+      .getRepoStatsHistory(new RepoStatsHistoryRequest({ repoId: repoId }))
+      .then((history) => {
+        setEntries(history.entries);
       })
       .catch((e) => {
-        alertApi!.error("Failed to fetch operations: " + e.message);
+        alertApi!.error("Failed to fetch stats history: " + e.message);
       });
   }, [repoId]);
 
-  if (operations.length === 0) {
-    return <Empty description="No stats available. Have you run a prune operation yet?" />
+  if (entries.length === 0) {
+    return <Empty description="No stats available yet. Stats are collected daily, or use Compute Stats to collect them now." />
   }
 
   const dataset: {
     time: number,
     totalSizeMb: number,
+    restoreSizeMb: number,
     compressionRatio: number,
     snapshotCount: number,
     totalBlobCount: number,
-  }[] = operations.map((op) => {
-    const stats = (op.op.value! as OperationStats).stats!;
+  }[] = entries.map((entry) => {
+    const stats = entry.stats!;
     return {
-      time: Number(op.unixTimeEndMs!),
+      time: Number(entry.unixTimeMs),
       totalSizeMb: Number(stats.totalSize) / 1000000,
+      restoreSizeMb: Number(stats.restoreSize) / 1000000,
       compressionRatio: Number(stats.compressionRatio),
       snapshotCount: Number(stats.snapshotCount),
       totalBlobCount: Number(stats.totalBlobCount),
@@ -176,6 +231,11 @@ const StatsPanel = ({ repoId }: { repoId: string }) => {
               label: "Total Size",
               valueFormatter: (v: any) => formatBytes(v * 1000000 as number),
             },
+            {
+              dataKey: "restoreSizeMb",
+              label: "Restore Size",
+              valueFormatter: (v: any) => formatBytes(v * 1000000 as number),
+            },
           ]}
           height={300}
           dataset={dataset}
@@ -0,0 +1,117 @@
+import React, { useState } from "react";
+import { DatePicker, Input, List, Modal, Tag, Typography } from "antd";
+import { useShowModal } from "../components/ModalManager";
+import { useAlertApi } from "../components/Alerts";
+import { backrestService } from "../api";
+import {
+  SearchRequest,
+  SearchResponse,
+  SearchResult_Kind,
+} from "../../gen/ts/v1/service_pb";
+import {
+  colorForStatus,
+  displayTypeToString,
+  getTypeForDisplay,
+} from "../state/oplog";
+import { formatTime } from "../lib/formatting";
+
+// SearchModal searches the operations and snapshots of every plan and repo, selecting a result navigates to it.
+export const SearchModal = () => {
+  const showModal = useShowModal();
+  const alertApi = useAlertApi()!;
+  const [range, setRange] = useState<any>(null); // [start, end] days, either may be unset.
+  const [loading, setLoading] = useState(false);
+  const [response, setResponse] = useState<SearchResponse | null>(null);
+
+  const handleSearch = async (query: string) => {
+    if (!query.trim()) {
+      setResponse(null);
+      return;
+    }
+    setLoading(true);
+    try {
+      setResponse(
+        await backrestService.search(
+          new SearchRequest({
+            query: query,
+            afterMs: BigInt(range?.[0]?.startOf("day").valueOf() || 0),
+            beforeMs: BigInt(range?.[1]?.endOf("day").valueOf() || 0),
+          })
+        )
+      );
+    } catch (e: any) {
+      alertApi.error("Search failed: " + (e.message ? e.message : "" + e));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <Modal
+      open={true}
+      title="Search"
+      width="60vw"
+      footer={null}
+      onCancel={() => showModal(null)}
+    >
+      <Input.Search
+        autoFocus
+        placeholder="Snapshot ID, path, error message or tag"
+        loading={loading}
+        onSearch={handleSearch}
+        enterButton
+      />
+      <DatePicker.RangePicker
+        allowEmpty={[true, true]}
+        style={{ marginTop: "8px" }}
+        value={range}
+        onChange={setRange}
+      />
+      {response ? (
+        <List
+          size="small"
+          dataSource={response.results}
+          footer={
+            response.truncated ? (
+              <Typography.Text type="secondary">
+                Only the newest {response.results.length} results are shown, narrow the search to find older results.
+              </Typography.Text>
+            ) : undefined
+          }
+          renderItem={(result) => {
+            const op = result.operation!;
+            return (
+              <List.Item
+                style={{ cursor: "pointer" }}
+                onClick={() => {
+                  showModal(null);
+                  window.location.hash = result.link;
+                }}
+              >
+                <List.Item.Meta
+                  title={
+                    <>
+                      <Tag color={colorForStatus(op.status)}>
+                        {result.kind === SearchResult_Kind.KIND_SNAPSHOT
+                          ? "Snapshot"
+                          : displayTypeToString(getTypeForDisplay(op))}
+                      </Tag>
+                      {formatTime(Number(op.unixTimeStartMs))} in plan{" "}
+                      {op.planId} on repo {op.repoId}
+                    </>
+                  }
+                  description={
+                    <>
+                      <Typography.Text type="secondary">{result.field}: </Typography.Text>
+                      <Typography.Text code>{result.snippet}</Typography.Text>
+                    </>
+                  }
+                />
+              </List.Item>
+            );
+          }}
+        />
+      ) : null}
+    </Modal>
+  );
+};
@@ -0,0 +1,13 @@
+//go:build headless
+// +build headless
+
+package webui
+
+import "embed"
+
+// headless builds embed no assets so that they can be built without building the web UI.
+var content embed.FS
+var contentPrefix = "dist"
+
+// Embedded is true if the web UI's assets are built into the binary.
+const Embedded = false
@@ -1,5 +1,6 @@
-//go:build linux || darwin || freebsd
+//go:build (linux || darwin || freebsd) && !headless
 // +build linux darwin freebsd
+// +build !headless
 
 //go:generate npm install
 //go:generate npm run clean
@@ -15,3 +16,6 @@ import (
 //go:embed dist
 var content embed.FS
 var contentPrefix = "dist"
+
+// Embedded is true if the web UI's assets are built into the binary.
+const Embedded = true
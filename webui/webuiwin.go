@@ -1,5 +1,5 @@
-//go:build windows
-// +build windows
+//go:build windows && !headless
+// +build windows,!headless
 
 //go:generate npm install
 //go:generate npm run clean-windows
@@ -12,3 +12,6 @@ import "embed"
 //go:embed dist-windows/*
 var content embed.FS
 var contentPrefix = "dist-windows"
+
+// Embedded is true if the web UI's assets are built into the binary.
+const Embedded = true